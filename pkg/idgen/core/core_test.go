@@ -36,12 +36,24 @@ func TestGeneratorType(t *testing.T) {
 			wantValid: true,
 			wantStr:   "uuid",
 		},
+		{
+			name:      "Segment类型_有效",
+			genType:   core.GeneratorTypeSegment,
+			wantValid: true,
+			wantStr:   "segment",
+		},
 		{
 			name:      "Custom类型_有效",
 			genType:   core.GeneratorTypeCustom,
 			wantValid: true,
 			wantStr:   "custom",
 		},
+		{
+			name:      "Mock类型_有效",
+			genType:   core.GeneratorTypeMock,
+			wantValid: true,
+			wantStr:   "mock",
+		},
 		{
 			name:      "无效类型",
 			genType:   core.GeneratorType("invalid"),
@@ -15,9 +15,24 @@ var (
 	// ErrInvalidSnowflakeID 无效的Snowflake ID
 	ErrInvalidSnowflakeID = errors.New("invalid snowflake id: id must be positive")
 
+	// ErrInvalidSegmentID 无效的号段ID
+	ErrInvalidSegmentID = errors.New("invalid segment id: id must be positive")
+
+	// ErrInvalidMockID 无效的确定性生成器ID
+	ErrInvalidMockID = errors.New("invalid mock id: id must be positive")
+
+	// ErrMockSequenceExhausted 确定性生成器的预设序列已用尽
+	ErrMockSequenceExhausted = errors.New("mock sequence exhausted: no more preset ids")
+
+	// ErrSegmentLoadFailed 号段加载失败
+	ErrSegmentLoadFailed = errors.New("segment load failed: unable to allocate number range from store")
+
 	// ErrInvalidBatchSize 批量生成数量无效
 	ErrInvalidBatchSize = errors.New("invalid batch size: must be positive and within limits")
 
+	// ErrGeneratorClosed 生成器已关闭
+	ErrGeneratorClosed = errors.New("generator closed: cannot generate id after close")
+
 	// ErrNilConfig 配置对象为nil
 	ErrNilConfig = errors.New("config cannot be nil")
 
@@ -45,6 +60,33 @@ var (
 	// ErrValidatorNotFound 验证器未找到
 	ErrValidatorNotFound = errors.New("validator not found: no validator registered for the specified type")
 
+	// ErrEncoderNotFound 编码器未找到
+	ErrEncoderNotFound = errors.New("encoder not found: no encoder registered with the specified name")
+
+	// ErrInvalidEncodedID 无效的编码ID
+	ErrInvalidEncodedID = errors.New("invalid encoded id: malformed or out of range")
+
+	// ErrInvalidTimeRange 无效的时间范围
+	ErrInvalidTimeRange = errors.New("invalid time range: start must not be after end and both must be within the id timestamp range")
+
+	// ErrInvalidLayout 无效的ID位布局
+	ErrInvalidLayout = errors.New("invalid id layout: bits must total 63 and epoch must not be in the future")
+
+	// ErrTimestampOverflow 时间戳超出布局可表示的范围
+	ErrTimestampOverflow = errors.New("timestamp overflow: current time is outside the id timestamp range")
+
+	// ErrDuplicateID 检测到重复ID
+	ErrDuplicateID = errors.New("duplicate id: the same id was generated more than once")
+
 	// ErrInvalidKeyFormat 无效的键格式
 	ErrInvalidKeyFormat = errors.New("invalid key format: only alphanumeric, underscore, hyphen, and dot allowed")
+
+	// ErrUnauthenticated 发号服务鉴权失败
+	ErrUnauthenticated = errors.New("unauthenticated: missing or invalid credentials")
+
+	// ErrRateLimited 发号服务请求超出速率限制
+	ErrRateLimited = errors.New("rate limited: too many id requests")
+
+	// ErrKeyNotExposed 发号服务未开放该生成器
+	ErrKeyNotExposed = errors.New("key not exposed: generator is not served by this endpoint")
 )
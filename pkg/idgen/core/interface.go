@@ -35,6 +35,15 @@ type IMonitorableGenerator interface {
 
 	// GetIDCount 获取已生成的ID总数
 	GetIDCount() uint64
+
+	// Stats 获取运行统计（发号数量、序列号翻转、时钟等待、最近发号时间戳）
+	Stats() GeneratorStats
+}
+
+// IHealthChecker 可健康检查的生成器接口
+type IHealthChecker interface {
+	// HealthCheck 检查生成器是否卡死或时钟漂移
+	HealthCheck() HealthStatus
 }
 
 // IValidaParseableGenerator 可验证+解析的生成器接口
@@ -46,6 +55,23 @@ type IValidaParseableGenerator interface {
 	ValidateID(id int64) error
 }
 
+// ICloseableGenerator 可关闭的生成器接口
+type ICloseableGenerator interface {
+	// Close 关闭生成器，释放后台资源并持久化最终状态
+	// 说明：关闭后再次调用NextID将返回ErrGeneratorClosed，重复关闭是安全的
+	Close() error
+}
+
+// IStateStore 生成器状态存储接口
+// 用途：持久化最近发号的时间戳，防止进程崩溃后快速重启导致ID重复
+type IStateStore interface {
+	// Load 读取已持久化的时间戳（Unix毫秒），不存在时返回0
+	Load(key string) (int64, error)
+
+	// Save 持久化时间戳（Unix毫秒）
+	Save(key string, timestamp int64) error
+}
+
 // IGenerator 完整功能的生成器接口
 type IGenerator interface {
 	IIDGenerator
@@ -53,6 +79,7 @@ type IGenerator interface {
 	IConfigurableGenerator
 	IMonitorableGenerator
 	IValidaParseableGenerator
+	ICloseableGenerator
 }
 
 // IGeneratorFactory 生成器工厂接口
@@ -96,3 +123,23 @@ type IIDValidator interface {
 	// ValidateBatch 批量验证ID
 	ValidateBatch(ids []int64) error
 }
+
+// IIDEncoder ID编码器接口
+// 用途：将内部int64 ID转换为对外公开的短字符串，避免暴露序列信息
+type IIDEncoder interface {
+	// Encode 将ID编码为URL安全的短字符串
+	Encode(id int64) (string, error)
+
+	// Decode 将短字符串解码还原为ID
+	Decode(code string) (int64, error)
+}
+
+// IIDObfuscator ID混淆接口
+// 用途：对生成器输出的ID做可逆变换，对外ID不再暴露发号速率和时间顺序，服务端可还原内部ID
+type IIDObfuscator interface {
+	// Obfuscate 将内部ID变换为对外ID（正整数之间的一一映射）
+	Obfuscate(id int64) int64
+
+	// Deobfuscate 将对外ID还原为内部ID
+	Deobfuscate(id int64) int64
+}
@@ -19,9 +19,23 @@ const (
 	//   - 适用于对ID长度不敏感的场景
 	GeneratorTypeUUID GeneratorType = "uuid"
 
+	// GeneratorTypeSegment 号段生成器（Leaf-segment模式）
+	// 特点：
+	//   - 由数据库号段表统一发号，ID整体趋势递增
+	//   - 双缓冲预加载号段，数据库短暂不可用时仍可继续发号
+	//   - 适用于必须由数据库签发的业务ID
+	GeneratorTypeSegment GeneratorType = "segment"
+
 	// GeneratorTypeCustom 自定义生成器（预留，便于扩展）
 	// 用途：支持业务自定义的ID生成算法
 	GeneratorTypeCustom GeneratorType = "custom"
+
+	// GeneratorTypeMock 确定性生成器（测试专用）
+	// 特点：
+	//   - 按配置的起始值和步长，或预设的ID序列发号
+	//   - 相同配置每次运行得到相同的ID，便于断言
+	//   - 不能用于生产环境
+	GeneratorTypeMock GeneratorType = "mock"
 )
 
 // String 实现Stringer接口，便于日志打印和调试
@@ -32,7 +46,7 @@ func (t GeneratorType) String() string {
 // IsValid 验证生成器类型是否有效
 func (t GeneratorType) IsValid() bool {
 	switch t {
-	case GeneratorTypeSnowflake, GeneratorTypeUUID, GeneratorTypeCustom:
+	case GeneratorTypeSnowflake, GeneratorTypeUUID, GeneratorTypeSegment, GeneratorTypeCustom, GeneratorTypeMock:
 		return true
 	default:
 		return false
@@ -97,3 +111,39 @@ func (s ClockBackwardStrategy) String() string {
 func (s ClockBackwardStrategy) IsValid() bool {
 	return s >= StrategyError && s <= StrategyUseLastTimestamp
 }
+
+// GeneratorStats 生成器运行统计
+// 说明：计数类字段仅在生成器启用监控（EnableMetrics）时累计，未启用时为0
+type GeneratorStats struct {
+	IDsIssued         uint64 // 已发放的ID总数
+	SequenceRollovers uint64 // 序列号耗尽（翻转到下一毫秒）次数
+	ClockBackwards    uint64 // 检测到时钟回拨的次数
+	ClockWaitTimeNs   uint64 // 累计等待时钟的时间（纳秒）
+	LastTimestamp     int64  // 最近一次发号使用的时间戳（Unix毫秒），0表示尚未发号或不适用
+}
+
+// HealthState 生成器健康状态
+type HealthState string
+
+const (
+	// HealthStateHealthy 健康：可正常发号
+	HealthStateHealthy HealthState = "healthy"
+
+	// HealthStateDegraded 降级：仍可发号，但存在需要关注的异常（如：发生过时钟回拨）
+	HealthStateDegraded HealthState = "degraded"
+
+	// HealthStateUnhealthy 不健康：发号会失败或阻塞（如：时钟落后于已发号时间戳）
+	HealthStateUnhealthy HealthState = "unhealthy"
+)
+
+// HealthStatus 生成器健康检查结果
+type HealthStatus struct {
+	State   HealthState    // 健康状态
+	Message string         // 状态说明（健康时为空）
+	Stats   GeneratorStats // 检查时刻的运行统计
+}
+
+// IsHealthy 是否处于健康状态
+func (s HealthStatus) IsHealthy() bool {
+	return s.State == HealthStateHealthy
+}
@@ -0,0 +1,191 @@
+package encoder
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+const (
+	// base62Alphabet 默认Base62字母表（URL安全）
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// idMask 63位掩码
+	// 说明：混淆运算在[0, 2^63)内进行，保证结果仍为非负int64
+	idMask = uint64(1)<<63 - 1
+
+	// maxMinLength 最小编码长度的上限
+	maxMinLength = 32
+
+	// maxCodeLength 编码字符串的最大长度
+	// 说明：Base62表示2^63-1最多需要11个字符，加上填充上限
+	maxCodeLength = maxMinLength + 11
+)
+
+// Config 编码器配置
+type Config struct {
+	// Secret 混淆密钥
+	// 说明：
+	//   - 为空时仅做Base62编码，编码结果保留ID的大小顺序
+	//   - 非空时使用Knuth乘法哈希+异或混淆ID，并打乱字母表，相邻ID的编码结果无规律
+	//
+	// 注意：密钥变更后旧编码无法解码，上线后不可随意修改
+	Secret string
+
+	// MinLength 编码结果的最小长度
+	// 说明：不足时左侧填充字母表首字符，解码时自动忽略
+	// 范围：0-32
+	// 默认值：0（不填充）
+	MinLength int
+}
+
+// Validate 验证配置的有效性
+func (c *Config) Validate() error {
+	if c.MinLength < 0 || c.MinLength > maxMinLength {
+		return fmt.Errorf("min length must be between 0 and %d, got %d", maxMinLength, c.MinLength)
+	}
+	return nil
+}
+
+// Encoder 短码编码器
+// 说明：将int64 ID与URL安全的短字符串互相转换，实例创建后只读，可并发使用
+type Encoder struct {
+	alphabet  []byte    // 字母表（可能已被密钥打乱）
+	index     [256]int8 // 字符 -> 字母表下标（-1表示非法字符）
+	obfuscate bool      // 是否启用混淆
+	prime     uint64    // 乘法因子（奇数，模2^63可逆）
+	inverse   uint64    // 乘法因子的模逆元
+	xorKey    uint64    // 异或密钥
+	minLength int       // 最小编码长度
+}
+
+// NewBase62 创建不带混淆的Base62编码器
+func NewBase62() *Encoder {
+	enc, _ := New(&Config{})
+	return enc
+}
+
+// New 使用配置创建编码器
+func New(config *Config) (*Encoder, error) {
+	if config == nil {
+		return nil, core.ErrNilConfig
+	}
+
+	// 步骤1：验证配置
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	enc := &Encoder{
+		alphabet:  []byte(base62Alphabet),
+		minLength: config.MinLength,
+	}
+
+	// 步骤2：由密钥派生混淆参数
+	if config.Secret != "" {
+		sum := sha256.Sum256([]byte(config.Secret))
+		enc.obfuscate = true
+		enc.prime = binary.BigEndian.Uint64(sum[0:8]) | 1 // 奇数保证可逆
+		enc.inverse = modInverse(enc.prime)
+		enc.xorKey = binary.BigEndian.Uint64(sum[8:16]) & idMask
+		shuffle(enc.alphabet, int64(binary.BigEndian.Uint64(sum[16:24])))
+	}
+
+	// 步骤3：构建反查表
+	for i := range enc.index {
+		enc.index[i] = -1
+	}
+	for i, c := range enc.alphabet {
+		enc.index[c] = int8(i)
+	}
+
+	return enc, nil
+}
+
+// Encode 将ID编码为短字符串
+// 实现core.IIDEncoder接口
+func (e *Encoder) Encode(id int64) (string, error) {
+	if id < 0 {
+		return "", fmt.Errorf("%w: id must be non-negative, got %d", core.ErrInvalidEncodedID, id)
+	}
+
+	// 步骤1：混淆（可选）
+	value := uint64(id)
+	if e.obfuscate {
+		value = ((value * e.prime) & idMask) ^ e.xorKey
+	}
+
+	// 步骤2：Base62编码（从低位到高位写入缓冲区尾部）
+	var buf [maxCodeLength]byte
+	pos := len(buf)
+	base := uint64(len(e.alphabet))
+	for {
+		pos--
+		buf[pos] = e.alphabet[value%base]
+		value /= base
+		if value == 0 {
+			break
+		}
+	}
+
+	// 步骤3：左侧填充到最小长度
+	for len(buf)-pos < e.minLength {
+		pos--
+		buf[pos] = e.alphabet[0]
+	}
+
+	return string(buf[pos:]), nil
+}
+
+// Decode 将短字符串解码为ID
+// 实现core.IIDEncoder接口
+func (e *Encoder) Decode(code string) (int64, error) {
+	if len(code) == 0 || len(code) > maxCodeLength {
+		return 0, fmt.Errorf("%w: invalid length %d", core.ErrInvalidEncodedID, len(code))
+	}
+
+	// 步骤1：Base62解码（带溢出检查）
+	var value uint64
+	base := uint64(len(e.alphabet))
+	for i := 0; i < len(code); i++ {
+		digit := e.index[code[i]]
+		if digit < 0 {
+			return 0, fmt.Errorf("%w: invalid character %q at position %d",
+				core.ErrInvalidEncodedID, code[i], i)
+		}
+		if value > (idMask-uint64(digit))/base {
+			return 0, fmt.Errorf("%w: value overflows int64", core.ErrInvalidEncodedID)
+		}
+		value = value*base + uint64(digit)
+	}
+
+	// 步骤2：还原混淆（可选）
+	if e.obfuscate {
+		value = ((value ^ e.xorKey) * e.inverse) & idMask
+	}
+
+	return int64(value), nil
+}
+
+// modInverse 计算奇数a在模2^64下的乘法逆元（牛顿迭代）
+// 说明：模2^64的逆元同时也是模2^63的逆元
+func modInverse(a uint64) uint64 {
+	inv := a // a*a ≡ 1 (mod 8)，初始即有3位正确
+	for i := 0; i < 5; i++ {
+		inv *= 2 - a*inv // 每次迭代正确位数翻倍
+	}
+	return inv
+}
+
+// shuffle 使用确定性随机源打乱字母表（Fisher-Yates）
+// 说明：相同的种子总是得到相同的排列
+func shuffle(alphabet []byte, seed int64) {
+	r := rand.New(rand.NewSource(seed))
+	for i := len(alphabet) - 1; i > 0; i-- {
+		j := r.Int63n(int64(i + 1))
+		alphabet[i], alphabet[j] = alphabet[j], alphabet[i]
+	}
+}
@@ -0,0 +1,149 @@
+package encoder_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"katydid-common-account/pkg/idgen/core"
+	"katydid-common-account/pkg/idgen/encoder"
+	"katydid-common-account/pkg/idgen/snowflake"
+)
+
+// ============================================================================
+// 1. 编码器基础功能测试
+// ============================================================================
+
+// TestBase62 测试不带混淆的Base62编码
+func TestBase62(t *testing.T) {
+	enc := encoder.NewBase62()
+
+	tests := []struct {
+		id   int64
+		want string
+	}{
+		{0, "0"},
+		{9, "9"},
+		{10, "A"},
+		{61, "z"},
+		{62, "10"},
+		{math.MaxInt64, "AzL8n0Y58m7"},
+	}
+
+	for _, tt := range tests {
+		got, err := enc.Encode(tt.id)
+		if err != nil {
+			t.Fatalf("Encode(%d) error = %v", tt.id, err)
+		}
+		if got != tt.want {
+			t.Errorf("Encode(%d) = %q, want %q", tt.id, got, tt.want)
+		}
+		back, err := enc.Decode(got)
+		if err != nil || back != tt.id {
+			t.Errorf("Decode(%q) = %d, %v; want %d", got, back, err, tt.id)
+		}
+	}
+}
+
+// TestObfuscatedRoundTrip 测试混淆编码往返
+func TestObfuscatedRoundTrip(t *testing.T) {
+	enc, err := encoder.New(&encoder.Config{Secret: "s3cret", MinLength: 10})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	gen, _ := snowflake.New(1, 1)
+	ids, _ := gen.NextIDBatch(1000)
+	ids = append(ids, 0, 1, 2, math.MaxInt64)
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		code, err := enc.Encode(id)
+		if err != nil {
+			t.Fatalf("Encode(%d) error = %v", id, err)
+		}
+		if len(code) < 10 {
+			t.Errorf("Encode(%d) = %q shorter than MinLength", id, code)
+		}
+		if seen[code] {
+			t.Fatalf("Duplicate code %q", code)
+		}
+		seen[code] = true
+
+		back, err := enc.Decode(code)
+		if err != nil || back != id {
+			t.Fatalf("Decode(%q) = %d, %v; want %d", code, back, err, id)
+		}
+	}
+}
+
+// TestObfuscationHidesSequence 测试混淆后相邻ID的编码无明显规律
+func TestObfuscationHidesSequence(t *testing.T) {
+	plain := encoder.NewBase62()
+	secret, _ := encoder.New(&encoder.Config{Secret: "s3cret"})
+	other, _ := encoder.New(&encoder.Config{Secret: "another"})
+
+	a, _ := secret.Encode(1000)
+	b, _ := secret.Encode(1001)
+	p, _ := plain.Encode(1000)
+	o, _ := other.Encode(1000)
+
+	if a == p {
+		t.Error("Obfuscated code equals plain base62 code")
+	}
+	if a[:len(a)-1] == b[:len(b)-1] {
+		t.Errorf("Adjacent IDs share prefix: %q, %q", a, b)
+	}
+	if a == o {
+		t.Error("Different secrets produced identical code")
+	}
+}
+
+// TestInvalidInput 测试非法输入
+func TestInvalidInput(t *testing.T) {
+	enc := encoder.NewBase62()
+
+	if _, err := enc.Encode(-1); !errors.Is(err, core.ErrInvalidEncodedID) {
+		t.Errorf("Encode(-1) expected ErrInvalidEncodedID, got %v", err)
+	}
+
+	for _, code := range []string{"", "abc-def", "zzzzzzzzzzzz", "你好"} {
+		if _, err := enc.Decode(code); !errors.Is(err, core.ErrInvalidEncodedID) {
+			t.Errorf("Decode(%q) expected ErrInvalidEncodedID, got %v", code, err)
+		}
+	}
+
+	if _, err := encoder.New(&encoder.Config{MinLength: 100}); err == nil {
+		t.Error("New() with oversized MinLength should return error")
+	}
+	if _, err := encoder.New(nil); !errors.Is(err, core.ErrNilConfig) {
+		t.Errorf("New(nil) expected ErrNilConfig, got %v", err)
+	}
+}
+
+// ============================================================================
+// 2. 性能基准测试
+// ============================================================================
+
+// BenchmarkEncode 基准测试混淆编码
+func BenchmarkEncode(b *testing.B) {
+	enc, _ := encoder.New(&encoder.Config{Secret: "s3cret"})
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = enc.Encode(int64(i))
+	}
+}
+
+// BenchmarkDecode 基准测试混淆解码
+func BenchmarkDecode(b *testing.B) {
+	enc, _ := encoder.New(&encoder.Config{Secret: "s3cret"})
+	code, _ := enc.Encode(1234567890123456789)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = enc.Decode(code)
+	}
+}
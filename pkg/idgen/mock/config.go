@@ -0,0 +1,83 @@
+package mock
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ============================================================================
+// Mock 配置定义
+// ============================================================================
+
+const (
+	// defaultStart 默认起始ID
+	defaultStart = 1
+
+	// defaultStep 默认步长
+	defaultStep = 1
+
+	// maxBatchSize 批量生成ID的最大数量，与Snowflake生成器保持一致
+	maxBatchSize = 100_000
+)
+
+// Config 确定性生成器配置
+type Config struct {
+	// Start 起始ID（第一个发放的ID）
+	// 默认值：1
+	Start int64
+
+	// Step 步长（相邻ID的差值）
+	// 默认值：1
+	Step int64
+
+	// Values 预设的ID序列
+	// 说明：非空时按顺序发放，忽略Start和Step；用尽后的行为由Cycle决定
+	Values []int64
+
+	// Cycle 预设序列用尽后是否从头开始
+	// 说明：false时用尽后返回 core.ErrMockSequenceExhausted
+	Cycle bool
+
+	// Timestamp ParseID返回的固定时间戳（Unix毫秒）
+	// 说明：便于断言依赖ID时间的逻辑；0表示不含时间戳
+	Timestamp int64
+
+	// DatacenterID ParseID和GetDatacenterID返回的数据中心ID
+	DatacenterID int64
+
+	// WorkerID ParseID和GetWorkerID返回的工作机器ID
+	WorkerID int64
+}
+
+// Validate 验证配置的有效性
+func (c *Config) Validate() error {
+	if c.Start < 0 {
+		return fmt.Errorf("start must not be negative, got %d", c.Start)
+	}
+	if c.Step < 0 {
+		return fmt.Errorf("step must not be negative, got %d", c.Step)
+	}
+	for i, v := range c.Values {
+		if v <= 0 {
+			return fmt.Errorf("preset value at index %d must be positive, got %d", i, v)
+		}
+	}
+	return nil
+}
+
+// SetDefaults 设置配置的默认值
+func (c *Config) SetDefaults() {
+	if c.Start == 0 {
+		c.Start = defaultStart
+	}
+	if c.Step == 0 {
+		c.Step = defaultStep
+	}
+}
+
+// Clone 克隆配置对象
+func (c *Config) Clone() *Config {
+	clone := *c
+	clone.Values = slices.Clone(c.Values)
+	return &clone
+}
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"fmt"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// Factory 确定性生成器工厂
+type Factory struct{}
+
+// NewFactory 创建确定性生成器工厂实例
+func NewFactory() *Factory {
+	return &Factory{}
+}
+
+// Create 创建确定性生成器实例
+// 实现core.GeneratorFactory接口
+func (f *Factory) Create(config any) (core.IGenerator, error) {
+	mockConfig, ok := config.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("invalid config type: expected *mock.Config, got %T", config)
+	}
+
+	return NewWithConfig(mockConfig)
+}
@@ -0,0 +1,221 @@
+// Package mock 确定性ID生成器（测试专用）
+//
+// 测试需要可重复的ID：相同配置每次运行都按相同顺序发放相同的ID。
+// 被测代码通过注册表获取生成器时，测试用 registry.WithGenerator 临时替换，
+// 无需在业务代码中按环境分支。
+package mock
+
+import (
+	"fmt"
+	"sync"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// Generator 确定性ID生成器
+// 说明：
+//   - 默认按 Start、Start+Step、Start+2*Step... 发号；配置 Values 时按预设序列发号
+//   - 线程安全；并发调用时每个ID仍只发放一次，但各协程拿到的顺序不确定
+type Generator struct {
+	config *Config
+	next   int64  // 下一个ID（按步长发号时）
+	index  int    // 下一个预设值的下标（预设序列发号时）
+	issued uint64 // 已发放的ID数量
+	closed bool   // 是否已关闭
+
+	validator core.IIDValidator
+	mu        sync.Mutex
+}
+
+// New 创建按起始值和步长发号的确定性生成器
+func New(start, step int64) (*Generator, error) {
+	return NewWithConfig(&Config{Start: start, Step: step})
+}
+
+// NewSequence 创建按预设序列发号的确定性生成器（用尽后返回错误）
+func NewSequence(values ...int64) (*Generator, error) {
+	return NewWithConfig(&Config{Values: values})
+}
+
+// NewWithConfig 使用配置创建确定性生成器
+// 说明：配置被复制，创建后修改原配置不影响生成器
+func NewWithConfig(config *Config) (*Generator, error) {
+	if config == nil {
+		return nil, core.ErrNilConfig
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	cfg := config.Clone()
+	cfg.SetDefaults()
+
+	return &Generator{
+		config:    cfg,
+		next:      cfg.Start,
+		validator: NewValidator(),
+	}, nil
+}
+
+// NextID 生成下一个ID（线程安全）
+// 实现core.IDGenerator接口
+func (g *Generator) NextID() (int64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.closed {
+		return 0, core.ErrGeneratorClosed
+	}
+	return g.nextIDUnsafe()
+}
+
+// NextIDBatch 批量生成ID（线程安全）
+// 实现core.BatchGenerator接口
+// 说明：预设序列剩余不足时返回错误，且不消耗序列
+func (g *Generator) NextIDBatch(n int) ([]int64, error) {
+	if n <= 0 || n > maxBatchSize {
+		return nil, fmt.Errorf("%w: must be between 1 and %d, got %d",
+			core.ErrInvalidBatchSize, maxBatchSize, n)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.closed {
+		return nil, core.ErrGeneratorClosed
+	}
+	if values := g.config.Values; len(values) > 0 && !g.config.Cycle && g.index+n > len(values) {
+		return nil, fmt.Errorf("%w: requested %d, remaining %d",
+			core.ErrMockSequenceExhausted, n, len(values)-g.index)
+	}
+
+	ids := make([]int64, n)
+	for i := range ids {
+		id, err := g.nextIDUnsafe()
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// NextIDs 批量生成ID（线程安全）
+// 说明：NextIDBatch的别名
+func (g *Generator) NextIDs(n int) ([]int64, error) {
+	return g.NextIDBatch(n)
+}
+
+// Reset 重置到初始状态，重新从第一个ID开始发号（并重新打开已关闭的生成器）
+func (g *Generator) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.next = g.config.Start
+	g.index = 0
+	g.issued = 0
+	g.closed = false
+}
+
+// Config 返回生成器配置的副本
+func (g *Generator) Config() *Config {
+	return g.config.Clone()
+}
+
+// GetWorkerID 获取工作机器ID
+// 实现core.ConfigurableGenerator接口
+func (g *Generator) GetWorkerID() int64 {
+	return g.config.WorkerID
+}
+
+// GetDatacenterID 获取数据中心ID
+// 实现core.ConfigurableGenerator接口
+func (g *Generator) GetDatacenterID() int64 {
+	return g.config.DatacenterID
+}
+
+// GetMetrics 获取性能监控指标
+// 实现core.MonitorableGenerator接口
+func (g *Generator) GetMetrics() map[string]uint64 {
+	return map[string]uint64{"id_count": g.GetIDCount()}
+}
+
+// ResetMetrics 重置性能监控指标（不影响发号位置）
+// 实现core.MonitorableGenerator接口
+func (g *Generator) ResetMetrics() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.issued = 0
+}
+
+// GetIDCount 获取已生成的ID总数
+// 实现core.MonitorableGenerator接口
+func (g *Generator) GetIDCount() uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.issued
+}
+
+// Stats 获取运行统计
+// 实现core.MonitorableGenerator接口
+func (g *Generator) Stats() core.GeneratorStats {
+	return core.GeneratorStats{
+		IDsIssued:     g.GetIDCount(),
+		LastTimestamp: g.config.Timestamp,
+	}
+}
+
+// ParseID 解析ID
+// 实现core.ParseableGenerator接口
+// 说明：ID本身不携带元信息，时间戳和机器ID取自配置，序列号为-1
+func (g *Generator) ParseID(id int64) (*core.IDInfo, error) {
+	if err := g.validator.Validate(id); err != nil {
+		return nil, err
+	}
+	return &core.IDInfo{
+		ID:           id,
+		Timestamp:    g.config.Timestamp,
+		DatacenterID: g.config.DatacenterID,
+		WorkerID:     g.config.WorkerID,
+		Sequence:     -1,
+	}, nil
+}
+
+// ValidateID 验证ID
+// 实现core.ParseableGenerator接口
+func (g *Generator) ValidateID(id int64) error {
+	return g.validator.Validate(id)
+}
+
+// Close 关闭生成器
+// 实现core.CloseableGenerator接口
+func (g *Generator) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.closed = true
+	return nil
+}
+
+// nextIDUnsafe 发放下一个ID
+// 说明：调用者必须已持有锁
+func (g *Generator) nextIDUnsafe() (int64, error) {
+	var id int64
+	if values := g.config.Values; len(values) > 0 {
+		if g.index >= len(values) {
+			if !g.config.Cycle {
+				return 0, core.ErrMockSequenceExhausted
+			}
+			g.index = 0
+		}
+		id = values[g.index]
+		g.index++
+	} else {
+		id = g.next
+		g.next += g.config.Step
+	}
+	g.issued++
+	return id, nil
+}
@@ -0,0 +1,202 @@
+package mock_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"katydid-common-account/pkg/idgen/core"
+	"katydid-common-account/pkg/idgen/mock"
+	"katydid-common-account/pkg/idgen/registry"
+	"katydid-common-account/pkg/idgen/snowflake"
+)
+
+// nextN 连续生成n个ID
+func nextN(t *testing.T, gen core.IGenerator, n int) []int64 {
+	t.Helper()
+	ids := make([]int64, n)
+	for i := range ids {
+		id, err := gen.NextID()
+		if err != nil {
+			t.Fatalf("NextID() error = %v", err)
+		}
+		ids[i] = id
+	}
+	return ids
+}
+
+// ============================================================================
+// 1. 确定性生成器基础功能测试
+// ============================================================================
+
+// TestNewWithConfig 测试创建生成器
+func TestNewWithConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *mock.Config
+		wantErr bool
+	}{
+		{"默认配置", &mock.Config{}, false},
+		{"预设序列", &mock.Config{Values: []int64{3, 1, 2}}, false},
+		{"空配置", nil, true},
+		{"负起始值", &mock.Config{Start: -1}, true},
+		{"负步长", &mock.Config{Step: -1}, true},
+		{"预设值非正", &mock.Config{Values: []int64{1, 0}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mock.NewWithConfig(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewWithConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestGenerator_Step 测试按起始值和步长发号
+func TestGenerator_Step(t *testing.T) {
+	gen, err := mock.New(100, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ids := nextN(t, gen, 3); !slices.Equal(ids, []int64{100, 110, 120}) {
+		t.Errorf("NextID() = %v", ids)
+	}
+	if ids, err := gen.NextIDBatch(2); err != nil || !slices.Equal(ids, []int64{130, 140}) {
+		t.Errorf("NextIDBatch() = %v, %v", ids, err)
+	}
+	if gen.GetIDCount() != 5 {
+		t.Errorf("GetIDCount() = %d, want 5", gen.GetIDCount())
+	}
+
+	gen.Reset()
+	if ids := nextN(t, gen, 2); !slices.Equal(ids, []int64{100, 110}) {
+		t.Errorf("Reset() 后 NextID() = %v", ids)
+	}
+
+	defaults, _ := mock.New(0, 0)
+	if ids := nextN(t, defaults, 3); !slices.Equal(ids, []int64{1, 2, 3}) {
+		t.Errorf("默认配置 NextID() = %v", ids)
+	}
+}
+
+// TestGenerator_Sequence 测试按预设序列发号
+func TestGenerator_Sequence(t *testing.T) {
+	gen, err := mock.NewSequence(7, 3, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := gen.NextIDBatch(4); !errors.Is(err, core.ErrMockSequenceExhausted) {
+		t.Errorf("剩余不足时 NextIDBatch() error = %v", err)
+	}
+	if ids := nextN(t, gen, 3); !slices.Equal(ids, []int64{7, 3, 9}) {
+		t.Errorf("NextID() = %v", ids)
+	}
+	if _, err := gen.NextID(); !errors.Is(err, core.ErrMockSequenceExhausted) {
+		t.Errorf("用尽后 NextID() error = %v", err)
+	}
+
+	cycle, _ := mock.NewWithConfig(&mock.Config{Values: []int64{1, 2}, Cycle: true})
+	if ids, err := cycle.NextIDBatch(5); err != nil || !slices.Equal(ids, []int64{1, 2, 1, 2, 1}) {
+		t.Errorf("循环序列 NextIDBatch() = %v, %v", ids, err)
+	}
+}
+
+// TestGenerator_ParseID 测试解析和关闭
+func TestGenerator_ParseID(t *testing.T) {
+	gen, _ := mock.NewWithConfig(&mock.Config{Timestamp: 1700000000000, DatacenterID: 2, WorkerID: 5})
+
+	info, err := gen.ParseID(42)
+	if err != nil {
+		t.Fatalf("ParseID() error = %v", err)
+	}
+	want := core.IDInfo{ID: 42, Timestamp: 1700000000000, DatacenterID: 2, WorkerID: 5, Sequence: -1}
+	if *info != want {
+		t.Errorf("ParseID() = %+v, want %+v", *info, want)
+	}
+	if _, err := gen.ParseID(0); !errors.Is(err, core.ErrInvalidMockID) {
+		t.Errorf("ParseID(0) error = %v", err)
+	}
+
+	_ = gen.Close()
+	if _, err := gen.NextID(); !errors.Is(err, core.ErrGeneratorClosed) {
+		t.Errorf("关闭后 NextID() error = %v", err)
+	}
+}
+
+// ============================================================================
+// 2. 注册表集成测试
+// ============================================================================
+
+// TestRegistry_Create 测试通过注册表创建确定性生成器
+func TestRegistry_Create(t *testing.T) {
+	r := registry.GetRegistry()
+	r.Clear()
+	defer r.Clear()
+
+	gen, err := r.Create("mock", core.GeneratorTypeMock, &mock.Config{Start: 10, Step: 5})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if ids := nextN(t, gen, 2); !slices.Equal(ids, []int64{10, 15}) {
+		t.Errorf("NextID() = %v", ids)
+	}
+
+	info, err := registry.Parse(core.GeneratorTypeMock, 10)
+	if err != nil || info.ID != 10 {
+		t.Errorf("Parse() = %+v, %v", info, err)
+	}
+}
+
+// TestWithGenerator 测试临时替换注册表中的生成器
+func TestWithGenerator(t *testing.T) {
+	r := registry.GetRegistry()
+	r.Clear()
+	defer r.Clear()
+
+	original, err := r.Create("order", core.GeneratorTypeSnowflake, &snowflake.Config{DatacenterID: 1, WorkerID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gen, _ := mock.New(1000, 1)
+	restore := registry.WithGenerator("order", gen)
+
+	// 被测代码按原方式获取生成器，拿到的是确定性生成器
+	got, err := r.GetOrCreate("order", core.GeneratorTypeSnowflake, &snowflake.Config{DatacenterID: 1, WorkerID: 1})
+	if err != nil || got != gen {
+		t.Fatalf("GetOrCreate() = %v, %v, want mock generator", got, err)
+	}
+	if id, _ := got.NextID(); id != 1000 {
+		t.Errorf("NextID() = %d, want 1000", id)
+	}
+
+	restore()
+	restore() // 重复调用无副作用
+	if got, _ := r.Get("order"); got != original {
+		t.Error("restore() 后应恢复原生成器")
+	}
+	if _, err := original.NextID(); err != nil {
+		t.Errorf("原生成器不应被关闭: %v", err)
+	}
+
+	// 键不存在时直接注册，恢复后移除；命名空间键同样适用
+	restore = registry.WithGenerator("tenant/invoice", gen)
+	if got, err := r.Namespace("tenant").Get("invoice"); err != nil || got != gen {
+		t.Errorf("Namespace().Get() = %v, %v", got, err)
+	}
+	restore()
+	if r.Namespace("tenant").Has("invoice") {
+		t.Error("restore() 后应移除新注册的键")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("非法键应panic")
+		}
+	}()
+	registry.WithGenerator("bad key!", gen)
+}
@@ -0,0 +1,57 @@
+package mock
+
+import (
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// Parser 确定性生成器ID解析器
+// 说明：ID不携带元信息，解析结果只包含ID本身，其余字段按"不可提取"约定返回；
+// 需要配置中的时间戳和机器ID时使用 Generator.ParseID
+type Parser struct {
+	validator core.IIDValidator // 验证器，用于解析前验证ID有效性
+}
+
+// NewParser 创建新的解析器实例
+func NewParser() core.IIDParser {
+	return &Parser{
+		validator: NewValidator(),
+	}
+}
+
+// Parse 解析ID
+// 实现core.IDParser接口
+func (p *Parser) Parse(id int64) (*core.IDInfo, error) {
+	if err := p.validator.Validate(id); err != nil {
+		return nil, err
+	}
+	return &core.IDInfo{
+		ID:           id,
+		DatacenterID: -1,
+		WorkerID:     -1,
+		Sequence:     -1,
+	}, nil
+}
+
+// ExtractTimestamp ID不含时间戳，固定返回0
+// 实现core.IDParser接口
+func (p *Parser) ExtractTimestamp(int64) int64 {
+	return 0
+}
+
+// ExtractDatacenterID ID不含数据中心ID，固定返回-1
+// 实现core.IDParser接口
+func (p *Parser) ExtractDatacenterID(int64) int64 {
+	return -1
+}
+
+// ExtractWorkerID ID不含工作机器ID，固定返回-1
+// 实现core.IDParser接口
+func (p *Parser) ExtractWorkerID(int64) int64 {
+	return -1
+}
+
+// ExtractSequence ID不含序列号，固定返回-1
+// 实现core.IDParser接口
+func (p *Parser) ExtractSequence(int64) int64 {
+	return -1
+}
@@ -0,0 +1,87 @@
+package registry
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// DefaultEncoderName 默认编码器名称（不带混淆的Base62）
+const DefaultEncoderName = "base62"
+
+// EncoderRegistry 编码器注册表
+// 说明：按名称管理编码器，不同业务可使用不同密钥的编码器
+type EncoderRegistry struct {
+	encoders map[string]core.IIDEncoder // 编码器映射表
+	mu       sync.RWMutex               // 读写锁，保护并发访问
+}
+
+var (
+	// globalEncoderRegistry 全局编码器注册表实例（单例）
+	globalEncoderRegistry *EncoderRegistry
+
+	// encoderRegistryOnce 确保编码器注册表只初始化一次
+	encoderRegistryOnce sync.Once
+)
+
+// GetEncoderRegistry 获取全局编码器注册表
+func GetEncoderRegistry() *EncoderRegistry {
+	encoderRegistryOnce.Do(func() {
+		globalEncoderRegistry = &EncoderRegistry{
+			encoders: make(map[string]core.IIDEncoder),
+		}
+	})
+	return globalEncoderRegistry
+}
+
+// GetEncoder 从全局编码器注册表获取编码器
+func GetEncoder(name string) (core.IIDEncoder, error) {
+	return GetEncoderRegistry().Get(name)
+}
+
+// Register 注册编码器
+func (r *EncoderRegistry) Register(name string, encoder core.IIDEncoder) error {
+	// 验证名称
+	if err := validateKey(name); err != nil {
+		return err
+	}
+
+	// 验证编码器不为nil
+	if encoder == nil {
+		return fmt.Errorf("encoder cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 注册编码器（允许覆盖已有编码器）
+	r.encoders[name] = encoder
+
+	log.Println("编码器已注册", "name", name)
+
+	return nil
+}
+
+// Get 获取编码器
+func (r *EncoderRegistry) Get(name string) (core.IIDEncoder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	encoder, exists := r.encoders[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", core.ErrEncoderNotFound, name)
+	}
+
+	return encoder, nil
+}
+
+// Has 检查编码器是否存在
+func (r *EncoderRegistry) Has(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.encoders[name]
+	return exists
+}
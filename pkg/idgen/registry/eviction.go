@@ -0,0 +1,300 @@
+package registry
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// EvictionPolicy 生成器淘汰策略
+type EvictionPolicy int
+
+const (
+	// EvictionNone 不淘汰（默认）
+	// 说明：达到数量上限时拒绝创建新的生成器
+	EvictionNone EvictionPolicy = iota
+
+	// EvictionLRU 淘汰最久未访问的生成器
+	// 说明：达到数量上限（全局上限或命名空间配额）时，
+	// 淘汰同一范围内最久未访问的生成器并调用其Close
+	EvictionLRU
+)
+
+// String 实现Stringer接口，便于日志打印和调试
+func (p EvictionPolicy) String() string {
+	switch p {
+	case EvictionNone:
+		return "None"
+	case EvictionLRU:
+		return "LRU"
+	default:
+		return "Unknown"
+	}
+}
+
+// IsValid 验证淘汰策略是否有效
+func (p EvictionPolicy) IsValid() bool {
+	return p >= EvictionNone && p <= EvictionLRU
+}
+
+const (
+	// minIdleCheckInterval 空闲检查的最小间隔
+	// 目的：防止过小的TTL导致后台goroutine频繁加锁扫描
+	minIdleCheckInterval = time.Second
+)
+
+// SetEvictionPolicy 设置淘汰策略
+func (r *Registry) SetEvictionPolicy(policy EvictionPolicy) error {
+	if !policy.IsValid() {
+		return fmt.Errorf("invalid eviction policy: %d", policy)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.evictionPolicy = policy
+
+	log.Println("淘汰策略已调整", "policy", policy)
+
+	return nil
+}
+
+// GetEvictionPolicy 获取淘汰策略
+func (r *Registry) GetEvictionPolicy() EvictionPolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.evictionPolicy
+}
+
+// SetIdleTTL 设置空闲生成器的存活时间
+// 说明：
+//   - ttl>0时启动后台检查，定期淘汰超过ttl未被访问的生成器并调用其Close
+//   - ttl=0时停止后台检查
+//   - 后台检查间隔为ttl的一半，且不小于1秒
+func (r *Registry) SetIdleTTL(ttl time.Duration) error {
+	if ttl < 0 {
+		return fmt.Errorf("idle ttl must be non-negative, got %v", ttl)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 停止旧的后台检查
+	if r.idleStopCh != nil {
+		close(r.idleStopCh)
+		r.idleStopCh = nil
+	}
+
+	r.idleTTL = ttl
+
+	// 启动新的后台检查
+	if ttl > 0 {
+		interval := ttl / 2
+		if interval < minIdleCheckInterval {
+			interval = minIdleCheckInterval
+		}
+		r.idleStopCh = make(chan struct{})
+		go r.idleLoop(interval, r.idleStopCh)
+	}
+
+	log.Println("空闲存活时间已调整", "idle_ttl", ttl)
+
+	return nil
+}
+
+// EvictIdle 立即淘汰超过空闲存活时间未被访问的生成器
+// 返回值：被淘汰的生成器键列表（未设置空闲存活时间时为空）
+func (r *Registry) EvictIdle() []string {
+	// 步骤1：持锁摘除空闲生成器
+	r.mu.Lock()
+	if r.idleTTL <= 0 {
+		r.mu.Unlock()
+		return []string{}
+	}
+
+	deadline := time.Now().Add(-r.idleTTL).UnixNano()
+	evicted := make(map[string]core.IGenerator)
+	for key, lastAccess := range r.lastAccess {
+		if lastAccess.Load() < deadline {
+			evicted[key] = r.generators[key]
+			r.deleteUnsafe(key, RemoveReasonEvictIdle)
+		}
+	}
+	r.unlockAndNotify()
+
+	// 步骤2：释放锁后关闭生成器，避免阻塞其他访问
+	keys := make([]string, 0, len(evicted))
+	for key, generator := range evicted {
+		closeEvicted(key, generator)
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// idleLoop 后台空闲检查
+func (r *Registry) idleLoop(interval time.Duration, stopCh chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.EvictIdle()
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// touch 记录生成器的访问时间
+// 说明：调用者必须已持有读锁或写锁（访问时间本身通过原子操作更新）
+func (r *Registry) touch(key string) {
+	if lastAccess, exists := r.lastAccess[key]; exists {
+		lastAccess.Store(time.Now().UnixNano())
+	}
+}
+
+// addUnsafe 注册生成器并记录访问时间
+// 说明：调用者必须已持有写锁
+func (r *Registry) addUnsafe(key string, generator core.IGenerator, entry generatorEntry) {
+	lastAccess := &atomic.Int64{}
+	lastAccess.Store(time.Now().UnixNano())
+
+	r.generators[key] = generator
+	r.entries[key] = entry
+	r.lastAccess[key] = lastAccess
+	r.recordUnsafe(key, entry, true, "")
+}
+
+// deleteUnsafe 移除生成器及其访问时间
+// 说明：调用者必须已持有写锁
+func (r *Registry) deleteUnsafe(key string, reason RemoveReason) {
+	r.recordUnsafe(key, r.entries[key], false, reason)
+	delete(r.generators, key)
+	delete(r.entries, key)
+	delete(r.lastAccess, key)
+}
+
+// resetUnsafe 移除所有生成器（不关闭）
+// 说明：调用者必须已持有写锁
+func (r *Registry) resetUnsafe(reason RemoveReason) {
+	for key := range r.generators {
+		r.recordUnsafe(key, r.entries[key], false, reason)
+	}
+	r.generators = make(map[string]core.IGenerator)
+	r.entries = make(map[string]generatorEntry)
+	r.lastAccess = make(map[string]*atomic.Int64)
+}
+
+// evictionVictimUnsafe 创建key前检查数量限制，返回需要淘汰的生成器（不需要时为空）
+// 说明：调用者必须已持有写锁；命名空间配额已满时在命名空间内淘汰，同时释放全局名额，
+// 因此一次创建至多淘汰一个生成器
+func (r *Registry) evictionVictimUnsafe(key string) (string, error) {
+	globalFull := len(r.generators) >= r.maxGenerators
+
+	namespace, _, namespaced := splitNamespacedKey(key)
+	namespaceFull, count, quota := false, 0, 0
+	if namespaced {
+		if q, exists := r.namespaceQuotas[namespace]; exists {
+			count, quota = r.countNamespaceUnsafe(namespace), q
+			namespaceFull = count >= quota
+		}
+	}
+
+	globalErr := func() error {
+		return fmt.Errorf("%w: current %d, max %d",
+			core.ErrMaxGeneratorsReached, len(r.generators), r.maxGenerators)
+	}
+	namespaceErr := func() error {
+		return fmt.Errorf("%w: namespace '%s' current %d, max %d",
+			core.ErrMaxGeneratorsReached, namespace, count, quota)
+	}
+
+	if r.evictionPolicy != EvictionLRU {
+		switch {
+		case globalFull:
+			return "", globalErr()
+		case namespaceFull:
+			return "", namespaceErr()
+		}
+		return "", nil
+	}
+
+	switch {
+	case namespaceFull:
+		victim, ok := r.lruCandidateUnsafe(namespace + namespaceSeparator)
+		if !ok {
+			return "", namespaceErr()
+		}
+		return victim, nil
+	case globalFull:
+		victim, ok := r.lruCandidateUnsafe("")
+		if !ok {
+			return "", globalErr()
+		}
+		return victim, nil
+	}
+	return "", nil
+}
+
+// lruCandidateUnsafe 查找指定范围内最久未访问的生成器
+// 说明：调用者必须已持有读锁或写锁；prefix为空表示在全部生成器中查找
+func (r *Registry) lruCandidateUnsafe(prefix string) (string, bool) {
+	var (
+		oldestKey  string
+		oldestTime int64
+		found      bool
+	)
+	for key, lastAccess := range r.lastAccess {
+		if prefix != "" && !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if t := lastAccess.Load(); !found || t < oldestTime {
+			oldestKey, oldestTime, found = key, t, true
+		}
+	}
+	return oldestKey, found
+}
+
+// evictUnsafe 淘汰生成器
+// 说明：调用者必须已持有写锁，并通过unlockAndNotify释放锁（被淘汰的生成器在释放锁后关闭）
+func (r *Registry) evictUnsafe(key string, reason RemoveReason) {
+	// 关闭可能同步写入状态存储，推迟到释放锁之后（由unlockAndNotify关闭）
+	r.closeLaterUnsafe(key, r.generators[key])
+	r.deleteUnsafe(key, reason)
+	log.Println("生成器已淘汰", "key", key, "reason", reason)
+}
+
+// pendingClose 持锁期间移除、释放锁后关闭的生成器
+type pendingClose struct {
+	key       string
+	generator core.IGenerator
+}
+
+// closeLaterUnsafe 登记释放锁后需要关闭的生成器（由unlockAndNotify关闭）
+// 说明：调用者必须已持有写锁；关闭可能同步写入状态存储，不能在持锁期间进行
+func (r *Registry) closeLaterUnsafe(key string, generator core.IGenerator) {
+	r.closing = append(r.closing, pendingClose{key: key, generator: generator})
+}
+
+// closeRemoved 关闭被移除的生成器
+func closeRemoved(key string, generator core.IGenerator) {
+	if err := generator.Close(); err != nil {
+		log.Println("关闭被移除的生成器失败", "key", key, "error", err)
+	}
+}
+
+// closeEvicted 关闭被淘汰的生成器
+func closeEvicted(key string, generator core.IGenerator) {
+	if err := generator.Close(); err != nil {
+		log.Println("关闭被淘汰的生成器失败", "key", key, "error", err)
+		return
+	}
+	log.Println("生成器已淘汰", "key", key)
+}
@@ -0,0 +1,96 @@
+package registry
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// HealthCheck 对所有已注册的生成器进行健康检查
+// 说明：
+//   - 实现了core.IHealthChecker的生成器使用其自身的检查逻辑
+//   - 其余生成器仅采集运行统计，视为健康
+func (r *Registry) HealthCheck() map[string]core.HealthStatus {
+	// 先复制生成器列表，避免在持锁期间执行检查
+	r.mu.RLock()
+	generators := make(map[string]core.IGenerator, len(r.generators))
+	for key, generator := range r.generators {
+		generators[key] = generator
+	}
+	r.mu.RUnlock()
+
+	result := make(map[string]core.HealthStatus, len(generators))
+	for key, generator := range generators {
+		if checker, ok := generator.(core.IHealthChecker); ok {
+			result[key] = checker.HealthCheck()
+			continue
+		}
+		result[key] = core.HealthStatus{
+			State: core.HealthStateHealthy,
+			Stats: generator.Stats(),
+		}
+	}
+
+	return result
+}
+
+// IsHealthy 检查所有已注册的生成器是否均健康
+func (r *Registry) IsHealthy() bool {
+	for _, status := range r.HealthCheck() {
+		if !status.IsHealthy() {
+			return false
+		}
+	}
+	return true
+}
+
+// WritePrometheus 以Prometheus文本格式输出所有生成器的统计与健康状态
+// 说明：可直接挂载到/metrics端点，无需依赖Prometheus客户端库
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	statuses := r.HealthCheck()
+
+	// 按key排序，保证输出稳定
+	keys := make([]string, 0, len(statuses))
+	for key := range statuses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	metrics := []struct {
+		name  string
+		help  string
+		kind  string
+		value func(core.HealthStatus) string
+	}{
+		{"idgen_ids_issued_total", "Total number of IDs issued.", "counter",
+			func(s core.HealthStatus) string { return fmt.Sprint(s.Stats.IDsIssued) }},
+		{"idgen_sequence_rollovers_total", "Total number of sequence rollovers.", "counter",
+			func(s core.HealthStatus) string { return fmt.Sprint(s.Stats.SequenceRollovers) }},
+		{"idgen_clock_backwards_total", "Total number of detected clock backward moves.", "counter",
+			func(s core.HealthStatus) string { return fmt.Sprint(s.Stats.ClockBackwards) }},
+		{"idgen_clock_wait_seconds_total", "Total time spent waiting for the clock.", "counter",
+			func(s core.HealthStatus) string { return fmt.Sprint(float64(s.Stats.ClockWaitTimeNs) / 1e9) }},
+		{"idgen_last_timestamp_milliseconds", "Timestamp of the last issued ID in Unix milliseconds.", "gauge",
+			func(s core.HealthStatus) string { return fmt.Sprint(s.Stats.LastTimestamp) }},
+		{"idgen_healthy", "Whether the generator is healthy (1) or not (0).", "gauge",
+			func(s core.HealthStatus) string {
+				if s.IsHealthy() {
+					return "1"
+				}
+				return "0"
+			}},
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, m := range metrics {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind)
+		for _, key := range keys {
+			fmt.Fprintf(bw, "%s{key=%q} %s\n", m.name, key, m.value(statuses[key]))
+		}
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,168 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// RemoveReason 生成器移除原因
+type RemoveReason string
+
+const (
+	// RemoveReasonManual 调用Remove移除
+	RemoveReasonManual RemoveReason = "remove"
+
+	// RemoveReasonEvictLRU 达到数量上限时被LRU淘汰
+	RemoveReasonEvictLRU RemoveReason = "evict_lru"
+
+	// RemoveReasonEvictIdle 空闲超时被淘汰
+	RemoveReasonEvictIdle RemoveReason = "evict_idle"
+
+	// RemoveReasonClear 调用Clear清空
+	RemoveReasonClear RemoveReason = "clear"
+
+	// RemoveReasonClose 调用Close关闭
+	RemoveReasonClose RemoveReason = "close"
+
+	// RemoveReasonOverride 被WithGenerator临时替换，或替换结束后恢复
+	RemoveReasonOverride RemoveReason = "override"
+)
+
+// RegistryEvent 注册表事件
+type RegistryEvent struct {
+	Key        string             // 生成器键（命名空间内为"命名空间/键"）
+	Type       core.GeneratorType // 生成器类型
+	ConfigHash string             // 配置哈希（相同配置得到相同哈希，便于发现配置漂移）
+	Reason     RemoveReason       // 移除原因（仅OnRemove）
+	Time       time.Time          // 事件发生时间
+}
+
+// RegistryListener 注册表监听器接口
+// 职责：监听生成器的创建和移除（观察者模式），用于日志和指标导出
+// 说明：
+//   - 回调在释放注册表锁之后同步调用，可以在回调中访问注册表
+//   - 多个协程同时修改注册表时回调可能被并发调用，实现需要自行保证线程安全
+//   - 回调中的panic会被恢复并记录日志，不影响注册表
+type RegistryListener interface {
+	// OnCreate 生成器创建并注册成功
+	OnCreate(event RegistryEvent)
+
+	// OnRemove 生成器被移除（包括Remove、淘汰、Clear和Close）
+	OnRemove(event RegistryEvent)
+}
+
+// generatorEntry 生成器的注册信息
+type generatorEntry struct {
+	generatorType core.GeneratorType // 生成器类型
+	config        any                // 脱敏后的配置副本（用于快照导出）
+	configHash    string             // 脱敏后配置的哈希
+}
+
+// pendingEvent 持锁期间记录、释放锁后分发的事件
+type pendingEvent struct {
+	event  RegistryEvent
+	create bool // true为创建事件，false为移除事件
+}
+
+// AddListener 添加注册表监听器
+// 说明：只接收添加之后发生的事件
+func (r *Registry) AddListener(listener RegistryListener) {
+	if listener == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 写时复制：分发事件时使用的是快照，不受并发添加影响
+	listeners := make([]RegistryListener, 0, len(r.listeners)+1)
+	listeners = append(listeners, r.listeners...)
+	r.listeners = append(listeners, listener)
+}
+
+// RemoveListener 移除注册表监听器
+// 返回值：是否找到并移除
+func (r *Registry) RemoveListener(listener RegistryListener) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, l := range r.listeners {
+		if l == listener {
+			listeners := make([]RegistryListener, 0, len(r.listeners)-1)
+			listeners = append(listeners, r.listeners[:i]...)
+			r.listeners = append(listeners, r.listeners[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// recordUnsafe 记录待分发的事件
+// 说明：调用者必须已持有写锁；没有监听器时不记录
+func (r *Registry) recordUnsafe(key string, entry generatorEntry, create bool, reason RemoveReason) {
+	if len(r.listeners) == 0 {
+		return
+	}
+	r.pending = append(r.pending, pendingEvent{
+		event: RegistryEvent{
+			Key:        key,
+			Type:       entry.generatorType,
+			ConfigHash: entry.configHash,
+			Reason:     reason,
+			Time:       time.Now(),
+		},
+		create: create,
+	})
+}
+
+// unlockAndNotify 释放写锁后关闭被移除的生成器，并分发持锁期间记录的事件
+// 说明：替代 r.mu.Unlock()，保证监听器和生成器的Close在锁外调用
+func (r *Registry) unlockAndNotify() {
+	events, listeners, closing := r.pending, r.listeners, r.closing
+	r.pending, r.closing = nil, nil
+	r.mu.Unlock()
+
+	// 先关闭被移除的生成器，监听器收到移除事件时最终状态已持久化
+	for _, c := range closing {
+		closeRemoved(c.key, c.generator)
+	}
+
+	for _, pending := range events {
+		for _, listener := range listeners {
+			notifyListener(listener, pending)
+		}
+	}
+}
+
+// notifyListener 调用单个监听器，恢复监听器中的panic
+func notifyListener(listener RegistryListener, pending pendingEvent) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			log.Println("注册表监听器panic", "key", pending.event.Key, "panic", rec)
+		}
+	}()
+
+	if pending.create {
+		listener.OnCreate(pending.event)
+	} else {
+		listener.OnRemove(pending.event)
+	}
+}
+
+// configHash 计算配置哈希（SHA-256前8字节的十六进制）
+// 说明：传入脱敏后的配置（见sanitizeConfig），按JSON编码后计算，运行时依赖不影响哈希；
+// 无法JSON编码时退化为类型和fmt格式化结果
+func configHash(config any) string {
+	data, err := json.Marshal(config)
+	if err != nil {
+		data = []byte(fmt.Sprintf("%T:%+v", config, config))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,259 @@
+package registry
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// namespaceSeparator 命名空间与键之间的分隔符
+// 说明：普通键不允许包含该字符，因此命名空间内的键不会与平铺键冲突
+const namespaceSeparator = "/"
+
+// Namespace 命名空间视图
+// 说明：
+//   - 将生成器按租户等维度隔离，同名键在不同命名空间中互不影响
+//   - 命名空间只是注册表上的视图，本身不持有生成器，可随用随取
+//   - 命名空间内的生成器同样受注册表全局数量上限约束
+type Namespace struct {
+	registry *Registry // 所属注册表
+	name     string    // 命名空间名称
+	err      error     // 名称验证错误（非nil时所有操作直接返回该错误）
+}
+
+// Namespace 获取指定名称的命名空间视图
+// 说明：名称规则与键相同；名称非法时，返回视图上的所有操作都会返回错误
+func (r *Registry) Namespace(name string) *Namespace {
+	return &Namespace{
+		registry: r,
+		name:     name,
+		err:      validateKey(name),
+	}
+}
+
+// ListByNamespace 列出命名空间内所有生成器的键（不含命名空间前缀）
+func (r *Registry) ListByNamespace(namespace string) []string {
+	prefix := namespace + namespaceSeparator
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]string, 0)
+	for key := range r.generators {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key[len(prefix):])
+		}
+	}
+	return keys
+}
+
+// Name 获取命名空间名称
+func (n *Namespace) Name() string {
+	return n.name
+}
+
+// Create 在命名空间内创建并注册一个新的生成器
+func (n *Namespace) Create(key string, generatorType core.GeneratorType, config any) (core.IGenerator, error) {
+	// 步骤1：验证参数
+	fullKey, err := n.fullKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !generatorType.IsValid() {
+		return nil, fmt.Errorf("%w: %s", core.ErrInvalidGeneratorType, generatorType)
+	}
+
+	// 步骤2：加写锁，保护注册表（释放锁后分发事件）
+	n.registry.mu.Lock()
+	defer n.registry.unlockAndNotify()
+
+	// 步骤3：检查key是否已存在
+	if _, exists := n.registry.generators[fullKey]; exists {
+		return nil, fmt.Errorf("%w: key '%s' in namespace '%s'",
+			core.ErrGeneratorAlreadyExists, key, n.name)
+	}
+
+	// 步骤4：创建并注册生成器（含全局上限与命名空间配额检查）
+	return n.registry.createUnsafe(fullKey, generatorType, config)
+}
+
+// GetOrCreate 获取命名空间内的生成器，如果不存在则创建
+func (n *Namespace) GetOrCreate(key string, generatorType core.GeneratorType, config any) (core.IGenerator, error) {
+	// 步骤1：验证参数
+	fullKey, err := n.fullKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !generatorType.IsValid() {
+		return nil, fmt.Errorf("%w: %s", core.ErrInvalidGeneratorType, generatorType)
+	}
+
+	// 步骤2：加写锁，保护注册表（释放锁后分发事件）
+	n.registry.mu.Lock()
+	defer n.registry.unlockAndNotify()
+
+	// 步骤3：检查key是否已存在
+	if generator, exists := n.registry.generators[fullKey]; exists {
+		n.registry.touch(fullKey)
+		return generator, nil
+	}
+
+	// 步骤4：创建并注册生成器（含全局上限与命名空间配额检查）
+	return n.registry.createUnsafe(fullKey, generatorType, config)
+}
+
+// Get 获取命名空间内已注册的生成器
+func (n *Namespace) Get(key string) (core.IGenerator, error) {
+	fullKey, err := n.fullKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	n.registry.mu.RLock()
+	defer n.registry.mu.RUnlock()
+
+	generator, exists := n.registry.generators[fullKey]
+	if !exists {
+		return nil, fmt.Errorf("%w: key '%s' in namespace '%s'",
+			core.ErrGeneratorNotFound, key, n.name)
+	}
+	n.registry.touch(fullKey)
+
+	return generator, nil
+}
+
+// Has 检查命名空间内的生成器是否存在
+func (n *Namespace) Has(key string) bool {
+	fullKey, err := n.fullKey(key)
+	if err != nil {
+		return false
+	}
+
+	n.registry.mu.RLock()
+	defer n.registry.mu.RUnlock()
+
+	_, exists := n.registry.generators[fullKey]
+	return exists
+}
+
+// Remove 移除并关闭命名空间内的生成器
+func (n *Namespace) Remove(key string) error {
+	fullKey, err := n.fullKey(key)
+	if err != nil {
+		return err
+	}
+
+	n.registry.mu.Lock()
+	defer n.registry.unlockAndNotify()
+
+	generator, exists := n.registry.generators[fullKey]
+	if !exists {
+		return fmt.Errorf("%w: key '%s' in namespace '%s'",
+			core.ErrGeneratorNotFound, key, n.name)
+	}
+
+	n.registry.deleteUnsafe(fullKey, RemoveReasonManual)
+	n.registry.closeLaterUnsafe(fullKey, generator)
+
+	log.Println("生成器已移除", "namespace", n.name, "key", key)
+
+	return nil
+}
+
+// ListKeys 列出命名空间内所有生成器的键
+func (n *Namespace) ListKeys() []string {
+	if n.err != nil {
+		return []string{}
+	}
+	return n.registry.ListByNamespace(n.name)
+}
+
+// Count 获取命名空间内的生成器数量
+func (n *Namespace) Count() int {
+	if n.err != nil {
+		return 0
+	}
+
+	n.registry.mu.RLock()
+	defer n.registry.mu.RUnlock()
+
+	return n.registry.countNamespaceUnsafe(n.name)
+}
+
+// SetMaxGenerators 设置命名空间的最大生成器数量（配额）
+// 说明：未设置配额的命名空间仅受注册表全局上限约束
+func (n *Namespace) SetMaxGenerators(max int) error {
+	if n.err != nil {
+		return n.err
+	}
+
+	if max <= 0 {
+		return fmt.Errorf("max generators must be positive, got %d", max)
+	}
+
+	n.registry.mu.Lock()
+	defer n.registry.mu.Unlock()
+
+	if max > n.registry.maxGenerators {
+		return fmt.Errorf("namespace quota cannot exceed registry max %d, got %d",
+			n.registry.maxGenerators, max)
+	}
+
+	// 检查当前数量是否已超过新的配额
+	if count := n.registry.countNamespaceUnsafe(n.name); count > max {
+		return fmt.Errorf("namespace '%s' generator count %d exceeds new max %d",
+			n.name, count, max)
+	}
+
+	n.registry.namespaceQuotas[n.name] = max
+
+	log.Println("命名空间配额已调整", "namespace", n.name, "new_max", max)
+
+	return nil
+}
+
+// GetMaxGenerators 获取命名空间的最大生成器数量
+// 说明：未设置配额时返回注册表全局上限
+func (n *Namespace) GetMaxGenerators() int {
+	n.registry.mu.RLock()
+	defer n.registry.mu.RUnlock()
+
+	if quota, exists := n.registry.namespaceQuotas[n.name]; exists {
+		return quota
+	}
+	return n.registry.maxGenerators
+}
+
+// fullKey 验证键并拼接命名空间前缀
+func (n *Namespace) fullKey(key string) (string, error) {
+	if n.err != nil {
+		return "", fmt.Errorf("invalid namespace: %w", n.err)
+	}
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return n.name + namespaceSeparator + key, nil
+}
+
+// countNamespaceUnsafe 统计命名空间内的生成器数量
+// 说明：调用者必须已持有锁
+func (r *Registry) countNamespaceUnsafe(namespace string) int {
+	prefix := namespace + namespaceSeparator
+	count := 0
+	for key := range r.generators {
+		if strings.HasPrefix(key, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
+// splitNamespacedKey 拆分命名空间内的完整键
+// 返回值：不含分隔符的平铺键ok为false
+func splitNamespacedKey(fullKey string) (namespace, key string, ok bool) {
+	return strings.Cut(fullKey, namespaceSeparator)
+}
@@ -0,0 +1,81 @@
+package registry
+
+import (
+	"fmt"
+	"sync"
+
+	"katydid-common-account/pkg/idgen/core"
+	"katydid-common-account/pkg/idgen/mock"
+)
+
+// ============================================================================
+// 测试替换
+// ============================================================================
+
+// WithGenerator 在全局注册表中临时用指定生成器替换键（测试专用）
+// 说明：详见 Registry.WithGenerator
+func WithGenerator(key string, generator core.IGenerator) (restore func()) {
+	return GetRegistry().WithGenerator(key, generator)
+}
+
+// WithGenerator 临时用指定生成器替换键（测试专用），返回恢复函数
+// 说明：
+//   - 键不存在时直接注册，存在时替换原生成器；命名空间内的键使用"命名空间/键"的形式
+//   - 被替换的生成器不会关闭，调用恢复函数后重新注册；恢复函数可重复调用
+//   - 恢复时键已被其他生成器占用则保留占用者，不覆盖
+//   - 不受数量上限和淘汰策略约束；替换和恢复触发 RemoveReasonOverride 的移除事件及创建事件
+//   - 键非法或生成器为nil时panic
+//
+// 用法：
+//
+//	gen, _ := mock.New(1000, 1)
+//	defer registry.WithGenerator("order", gen)()
+func (r *Registry) WithGenerator(key string, generator core.IGenerator) (restore func()) {
+	if err := validateSnapshotKey(key); err != nil {
+		panic(fmt.Sprintf("registry: WithGenerator: %v", err))
+	}
+	if generator == nil {
+		panic("registry: WithGenerator: generator cannot be nil")
+	}
+
+	r.mu.Lock()
+	original, existed := r.generators[key]
+	originalEntry := r.entries[key]
+	if existed {
+		r.deleteUnsafe(key, RemoveReasonOverride)
+	}
+	r.addUnsafe(key, generator, overrideEntry(generator))
+	r.unlockAndNotify()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.unlockAndNotify()
+
+			if current, exists := r.generators[key]; exists {
+				if current != generator {
+					return
+				}
+				r.deleteUnsafe(key, RemoveReasonOverride)
+			}
+			if existed {
+				r.addUnsafe(key, original, originalEntry)
+			}
+		})
+	}
+}
+
+// overrideEntry 构造替换生成器的注册信息
+// 说明：确定性生成器记录其配置，便于快照导出；其他生成器按自定义类型记录，不含配置
+func overrideEntry(generator core.IGenerator) generatorEntry {
+	if g, ok := generator.(*mock.Generator); ok {
+		sanitized := sanitizeConfig(g.Config())
+		return generatorEntry{
+			generatorType: core.GeneratorTypeMock,
+			config:        sanitized,
+			configHash:    configHash(sanitized),
+		}
+	}
+	return generatorEntry{generatorType: core.GeneratorTypeCustom}
+}
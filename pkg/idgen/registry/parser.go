@@ -1,13 +1,19 @@
 package registry
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"katydid-common-account/pkg/idgen/core"
 )
 
+// maxParseBatchSize 批量解析的最大数量
+// 目的：防止一次性解析过多ID导致内存耗尽
+const maxParseBatchSize = 1_000_000
+
 // ParserRegistry 解析器注册表
 type ParserRegistry struct {
 	parsers map[core.GeneratorType]core.IIDParser // 解析器映射表
@@ -76,3 +82,61 @@ func (r *ParserRegistry) Has(generatorType core.GeneratorType) bool {
 	_, exists := r.parsers[generatorType]
 	return exists
 }
+
+// Parse 使用全局解析器注册表解析ID
+// 说明：根据生成器类型自动选择已注册的解析器
+func Parse(generatorType core.GeneratorType, id int64) (*core.IDInfo, error) {
+	parser, err := GetParserRegistry().Get(generatorType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, generatorType)
+	}
+	return parser.Parse(id)
+}
+
+// ParseBatch 批量解析ID
+// 说明：
+//   - 返回结果与输入一一对应，解析失败的位置为nil
+//   - 单个ID解析失败不会中断整批解析，所有失败原因合并后通过error返回
+func ParseBatch(generatorType core.GeneratorType, ids []int64) ([]*core.IDInfo, error) {
+	if ids == nil {
+		return nil, fmt.Errorf("ids slice cannot be nil")
+	}
+	if len(ids) > maxParseBatchSize {
+		return nil, fmt.Errorf("%w: batch size too large (max %d), got %d",
+			core.ErrInvalidBatchSize, maxParseBatchSize, len(ids))
+	}
+
+	parser, err := GetParserRegistry().Get(generatorType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, generatorType)
+	}
+
+	infos := make([]*core.IDInfo, len(ids))
+	var errs []error
+	for i, id := range ids {
+		info, err := parser.Parse(id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid ID at index %d: %w", i, err))
+			continue
+		}
+		infos[i] = info
+	}
+
+	return infos, errors.Join(errs...)
+}
+
+// FormatInfo 将ID元信息格式化为便于调试的单行文本
+// 示例：id=1234567890 time=2024-01-01T08:00:00.123Z datacenter=1 worker=1 sequence=0
+func FormatInfo(info *core.IDInfo) string {
+	if info == nil {
+		return "<nil>"
+	}
+
+	ts := "-"
+	if info.Timestamp > 0 {
+		ts = time.UnixMilli(info.Timestamp).UTC().Format("2006-01-02T15:04:05.000Z07:00")
+	}
+
+	return fmt.Sprintf("id=%d time=%s datacenter=%d worker=%d sequence=%d",
+		info.ID, ts, info.DatacenterID, info.WorkerID, info.Sequence)
+}
@@ -1,11 +1,17 @@
 package registry
 
 import (
+	"errors"
 	"fmt"
+	"katydid-common-account/pkg/idgen/encoder"
+	"katydid-common-account/pkg/idgen/mock"
+	"katydid-common-account/pkg/idgen/segment"
 	"katydid-common-account/pkg/idgen/snowflake"
 	"log"
 	"regexp"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"katydid-common-account/pkg/idgen/core"
 )
@@ -20,9 +26,26 @@ func init() {
 	// 注册Snowflake验证器
 	_ = GetValidatorRegistry().Register(core.GeneratorTypeSnowflake, snowflake.NewValidator())
 
+	// 注册Segment工厂
+	_ = GetFactoryRegistry().Register(core.GeneratorTypeSegment, segment.NewFactory())
+	// 注册Segment解析器
+	_ = GetParserRegistry().Register(core.GeneratorTypeSegment, segment.NewParser())
+	// 注册Segment验证器
+	_ = GetValidatorRegistry().Register(core.GeneratorTypeSegment, segment.NewValidator())
+
+	// 注册Mock工厂（测试专用）
+	_ = GetFactoryRegistry().Register(core.GeneratorTypeMock, mock.NewFactory())
+	// 注册Mock解析器
+	_ = GetParserRegistry().Register(core.GeneratorTypeMock, mock.NewParser())
+	// 注册Mock验证器
+	_ = GetValidatorRegistry().Register(core.GeneratorTypeMock, mock.NewValidator())
+
+	// 注册默认Base62编码器
+	_ = GetEncoderRegistry().Register(DefaultEncoderName, encoder.NewBase62())
+
 	// ...注册其他的
 
-	log.Println("ID生成器工厂初始化完成", "registered_types", []string{"snowflake"})
+	log.Println("ID生成器工厂初始化完成", "registered_types", []string{"snowflake", "segment", "mock"})
 }
 
 const (
@@ -47,9 +70,18 @@ var keyFormatRegex = regexp.MustCompile(`^[a-zA-Z0-9_\-.]+$`)
 
 // Registry 生成器注册表
 type Registry struct {
-	generators    map[string]core.IGenerator // 生成器映射表
-	maxGenerators int                        // 最大生成器数量限制
-	mu            sync.RWMutex               // 读写锁，保护并发访问
+	generators      map[string]core.IGenerator // 生成器映射表
+	entries         map[string]generatorEntry  // 生成器注册信息（类型、配置哈希）
+	lastAccess      map[string]*atomic.Int64   // 生成器最近访问时间（Unix纳秒）
+	maxGenerators   int                        // 最大生成器数量限制
+	namespaceQuotas map[string]int             // 命名空间 -> 最大生成器数量
+	evictionPolicy  EvictionPolicy             // 达到上限时的淘汰策略
+	idleTTL         time.Duration              // 空闲存活时间（0表示不淘汰空闲生成器）
+	idleStopCh      chan struct{}              // 通知空闲检查goroutine退出
+	listeners       []RegistryListener         // 监听器（写时复制）
+	pending         []pendingEvent             // 持锁期间记录、释放锁后分发的事件
+	closing         []pendingClose             // 持锁期间移除、释放锁后关闭的生成器
+	mu              sync.RWMutex               // 读写锁，保护并发访问
 }
 
 var (
@@ -64,8 +96,11 @@ var (
 func GetRegistry() *Registry {
 	registryOnce.Do(func() {
 		globalRegistry = &Registry{
-			generators:    make(map[string]core.IGenerator),
-			maxGenerators: defaultMaxGenerators,
+			generators:      make(map[string]core.IGenerator),
+			entries:         make(map[string]generatorEntry),
+			lastAccess:      make(map[string]*atomic.Int64),
+			maxGenerators:   defaultMaxGenerators,
+			namespaceQuotas: make(map[string]int),
 		}
 	})
 	return globalRegistry
@@ -82,35 +117,53 @@ func (r *Registry) Create(key string, generatorType core.GeneratorType, config a
 		return nil, fmt.Errorf("%w: %s", core.ErrInvalidGeneratorType, generatorType)
 	}
 
-	// 步骤2：加写锁，保护注册表
+	// 步骤2：加写锁，保护注册表（释放锁后分发事件）
 	r.mu.Lock()
-	defer r.mu.Unlock()
+	defer r.unlockAndNotify()
 
 	// 步骤3：检查key是否已存在
 	if _, exists := r.generators[key]; exists {
 		return nil, fmt.Errorf("%w: key '%s'", core.ErrGeneratorAlreadyExists, key)
 	}
 
-	// 步骤4：检查数量限制
-	if len(r.generators) >= r.maxGenerators {
-		return nil, fmt.Errorf("%w: current %d, max %d",
-			core.ErrMaxGeneratorsReached, len(r.generators), r.maxGenerators)
+	// 步骤4：创建并注册生成器
+	return r.createUnsafe(key, generatorType, config)
+}
+
+// createUnsafe 检查数量限制后创建并注册生成器
+// 说明：调用者必须已持有写锁，且已确认key不存在；
+// 生成器创建成功后才淘汰（至多一个），配置错误或类型未注册时不会影响已有的生成器
+func (r *Registry) createUnsafe(key string, generatorType core.GeneratorType, config any) (core.IGenerator, error) {
+	// 步骤1：检查数量限制，确定需要淘汰的生成器（此时不淘汰）
+	victim, err := r.evictionVictimUnsafe(key)
+	if err != nil {
+		return nil, err
 	}
 
-	// 步骤5：从工厂注册表获取工厂
+	// 步骤2：从工厂注册表获取工厂
 	factory, err := GetFactoryRegistry().Get(generatorType)
 	if err != nil {
 		return nil, err
 	}
 
-	// 步骤6：使用工厂创建生成器
+	// 步骤3：使用工厂创建生成器
 	generator, err := factory.Create(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create generator: %w", err)
 	}
 
-	// 步骤7：注册生成器
-	r.generators[key] = generator
+	// 步骤4：淘汰为新生成器腾出位置
+	if victim != "" {
+		r.evictUnsafe(victim, RemoveReasonEvictLRU)
+	}
+
+	// 步骤5：注册生成器（记录脱敏后的配置，用于事件和快照导出）
+	sanitized := sanitizeConfig(config)
+	r.addUnsafe(key, generator, generatorEntry{
+		generatorType: generatorType,
+		config:        sanitized,
+		configHash:    configHash(sanitized),
+	})
 
 	log.Println("生成器创建成功", "key", key, "type", generatorType)
 
@@ -132,6 +185,7 @@ func (r *Registry) Get(key string) (core.IGenerator, error) {
 	if !exists {
 		return nil, fmt.Errorf("%w: key '%s'", core.ErrGeneratorNotFound, key)
 	}
+	r.touch(key)
 
 	return generator, nil
 }
@@ -147,39 +201,18 @@ func (r *Registry) GetOrCreate(key string, generatorType core.GeneratorType, con
 		return nil, fmt.Errorf("%w: %s", core.ErrInvalidGeneratorType, generatorType)
 	}
 
-	// 步骤2：加写锁，保护注册表
+	// 步骤2：加写锁，保护注册表（释放锁后分发事件）
 	r.mu.Lock()
-	defer r.mu.Unlock()
+	defer r.unlockAndNotify()
 
 	// 步骤3：检查key是否已存在
 	if generator, exists := r.generators[key]; exists {
+		r.touch(key)
 		return generator, nil
 	}
 
-	// 步骤4：检查数量限制
-	if len(r.generators) >= r.maxGenerators {
-		return nil, fmt.Errorf("%w: current %d, max %d",
-			core.ErrMaxGeneratorsReached, len(r.generators), r.maxGenerators)
-	}
-
-	// 步骤5：从工厂注册表获取工厂
-	factory, err := GetFactoryRegistry().Get(generatorType)
-	if err != nil {
-		return nil, err
-	}
-
-	// 步骤6：使用工厂创建生成器
-	generator, err := factory.Create(config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create generator: %w", err)
-	}
-
-	// 步骤7：注册生成器
-	r.generators[key] = generator
-
-	log.Println("生成器创建成功", "key", key, "type", generatorType)
-
-	return generator, nil
+	// 步骤4：创建并注册生成器
+	return r.createUnsafe(key, generatorType, config)
 }
 
 // Has 检查生成器是否存在
@@ -196,7 +229,8 @@ func (r *Registry) Has(key string) bool {
 	return exists
 }
 
-// Remove 移除生成器
+// Remove 移除并关闭生成器
+// 说明：生成器在释放锁之后关闭，关闭时的状态持久化不会阻塞其他访问
 func (r *Registry) Remove(key string) error {
 	// 验证key
 	if err := validateKey(key); err != nil {
@@ -204,33 +238,59 @@ func (r *Registry) Remove(key string) error {
 	}
 
 	r.mu.Lock()
-	defer r.mu.Unlock()
+	defer r.unlockAndNotify()
 
 	// 检查是否存在
-	if _, exists := r.generators[key]; !exists {
+	generator, exists := r.generators[key]
+	if !exists {
 		return fmt.Errorf("%w: key '%s'", core.ErrGeneratorNotFound, key)
 	}
 
-	// 删除生成器
-	delete(r.generators, key)
+	// 删除生成器（释放锁后关闭）
+	r.deleteUnsafe(key, RemoveReasonManual)
+	r.closeLaterUnsafe(key, generator)
 
 	log.Println("生成器已移除", "key", key)
 
 	return nil
 }
 
-// Clear 清空所有生成器
+// Clear 清空并关闭所有生成器
+// 说明：生成器在释放锁之后关闭；需要收集关闭错误时使用 Close
 func (r *Registry) Clear() {
 	r.mu.Lock()
-	defer r.mu.Unlock()
+	defer r.unlockAndNotify()
 
-	// 创建新的map，让GC回收旧的map
-	r.generators = make(map[string]core.IGenerator)
+	// 释放锁后关闭，创建新的map，让GC回收旧的map
+	for key, generator := range r.generators {
+		r.closeLaterUnsafe(key, generator)
+	}
+	r.resetUnsafe(RemoveReasonClear)
 
 	// 日志建议：此处可添加日志记录
 	log.Println("注册表已清空", "操作", "Clear")
 }
 
+// Close 关闭并清空所有生成器
+// 说明：用于服务优雅退出，确保各生成器持久化最终状态
+func (r *Registry) Close() error {
+	r.mu.Lock()
+	generators := r.generators
+	r.resetUnsafe(RemoveReasonClose)
+	r.unlockAndNotify()
+
+	var errs []error
+	for key, generator := range generators {
+		if err := generator.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close generator '%s': %w", key, err))
+		}
+	}
+
+	log.Println("注册表已关闭", "closed_count", len(generators))
+
+	return errors.Join(errs...)
+}
+
 // Count 获取生成器数量
 func (r *Registry) Count() int {
 	r.mu.RLock()
@@ -240,6 +300,7 @@ func (r *Registry) Count() int {
 }
 
 // ListKeys 列出所有生成器的键
+// 说明：命名空间内的生成器以"命名空间/键"的形式列出
 func (r *Registry) ListKeys() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -1,15 +1,20 @@
 package registry_test
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"katydid-common-account/pkg/idgen/core"
+	"katydid-common-account/pkg/idgen/encoder"
 	"katydid-common-account/pkg/idgen/registry"
+	"katydid-common-account/pkg/idgen/segment"
 	"katydid-common-account/pkg/idgen/snowflake"
 )
 
@@ -57,6 +62,27 @@ func TestRegistry_Create(t *testing.T) {
 			t.Error("Create() with empty key should return error")
 		}
 	})
+
+	t.Run("Segment类型", func(t *testing.T) {
+		segConfig := &segment.Config{
+			BizTag: "order",
+			Store:  segment.NewMemoryStore(),
+		}
+		gen, err := r.Create("segment1", core.GeneratorTypeSegment, segConfig)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if id, err := gen.NextID(); err != nil || id != 1 {
+			t.Errorf("NextID() = %d, %v; want 1", id, err)
+		}
+	})
+
+	t.Run("Segment类型_配置类型错误", func(t *testing.T) {
+		_, err := r.Create("segment2", core.GeneratorTypeSegment, config)
+		if err == nil {
+			t.Error("Create() with snowflake config should return error")
+		}
+	})
 }
 
 // TestRegistry_Get 测试获取生成器
@@ -172,7 +198,8 @@ func TestRegistry_Remove(t *testing.T) {
 		WorkerID:     1,
 	}
 
-	_, _ = r.Create("test1", core.GeneratorTypeSnowflake, config)
+	gen, _ := r.Create("test1", core.GeneratorTypeSnowflake, config)
+	nsGen, _ := r.Namespace("tenant").Create("test1", core.GeneratorTypeSnowflake, config)
 
 	t.Run("删除存在的生成器", func(t *testing.T) {
 		err := r.Remove("test1")
@@ -182,6 +209,18 @@ func TestRegistry_Remove(t *testing.T) {
 		if r.Has("test1") {
 			t.Error("IGenerator still exists after Remove()")
 		}
+		if _, err := gen.NextID(); !errors.Is(err, core.ErrGeneratorClosed) {
+			t.Errorf("NextID after Remove expected ErrGeneratorClosed, got %v", err)
+		}
+	})
+
+	t.Run("删除命名空间内的生成器", func(t *testing.T) {
+		if err := r.Namespace("tenant").Remove("test1"); err != nil {
+			t.Errorf("Namespace.Remove() error = %v", err)
+		}
+		if _, err := nsGen.NextID(); !errors.Is(err, core.ErrGeneratorClosed) {
+			t.Errorf("NextID after Namespace.Remove expected ErrGeneratorClosed, got %v", err)
+		}
 	})
 
 	t.Run("删除不存在的生成器", func(t *testing.T) {
@@ -203,8 +242,10 @@ func TestRegistry_Clear(t *testing.T) {
 	}
 
 	// 创建多个生成器
+	generators := make([]core.IGenerator, 0, 5)
 	for i := 0; i < 5; i++ {
-		_, _ = r.Create(fmt.Sprintf("test%d", i), core.GeneratorTypeSnowflake, config)
+		gen, _ := r.Create(fmt.Sprintf("test%d", i), core.GeneratorTypeSnowflake, config)
+		generators = append(generators, gen)
 	}
 
 	r.Clear()
@@ -212,6 +253,431 @@ func TestRegistry_Clear(t *testing.T) {
 	if r.Count() != 0 {
 		t.Errorf("Count() = %d after Clear(), want 0", r.Count())
 	}
+	for i, gen := range generators {
+		if _, err := gen.NextID(); !errors.Is(err, core.ErrGeneratorClosed) {
+			t.Errorf("generator %d: NextID after Clear expected ErrGeneratorClosed, got %v", i, err)
+		}
+	}
+}
+
+// TestRegistry_Close 测试关闭注册表
+func TestRegistry_Close(t *testing.T) {
+	r := registry.GetRegistry()
+	defer r.Clear()
+
+	gen, err := r.Create("close1", core.GeneratorTypeSnowflake, &snowflake.Config{DatacenterID: 1, WorkerID: 1})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if r.Count() != 0 {
+		t.Errorf("Count() after Close = %d, want 0", r.Count())
+	}
+	if _, err := gen.NextID(); !errors.Is(err, core.ErrGeneratorClosed) {
+		t.Errorf("NextID after Close expected ErrGeneratorClosed, got %v", err)
+	}
+}
+
+// TestRegistry_Namespace 测试命名空间隔离与配额
+func TestRegistry_Namespace(t *testing.T) {
+	r := registry.GetRegistry()
+	defer r.Clear()
+
+	config := &snowflake.Config{DatacenterID: 1, WorkerID: 1}
+	tenantA := r.Namespace("tenant-a")
+	tenantB := r.Namespace("tenant-b")
+
+	t.Run("同名键互相隔离", func(t *testing.T) {
+		genA, err := tenantA.GetOrCreate("order", core.GeneratorTypeSnowflake, config)
+		if err != nil {
+			t.Fatalf("GetOrCreate() error = %v", err)
+		}
+		genB, err := tenantB.GetOrCreate("order", core.GeneratorTypeSnowflake, config)
+		if err != nil {
+			t.Fatalf("GetOrCreate() error = %v", err)
+		}
+		if genA == genB {
+			t.Error("Namespaces share the same generator")
+		}
+		if r.Has("order") {
+			t.Error("Namespaced key leaked into flat keys")
+		}
+		if got, _ := tenantA.Get("order"); got != genA {
+			t.Error("Get() returned a different generator")
+		}
+	})
+
+	t.Run("按命名空间列出", func(t *testing.T) {
+		_, _ = tenantA.Create("user", core.GeneratorTypeSnowflake, config)
+		keys := r.ListByNamespace("tenant-a")
+		if len(keys) != 2 || tenantA.Count() != 2 {
+			t.Errorf("ListByNamespace() = %v, want 2 keys", keys)
+		}
+	})
+
+	t.Run("命名空间配额", func(t *testing.T) {
+		tenantC := r.Namespace("tenant-c")
+		if err := tenantC.SetMaxGenerators(1); err != nil {
+			t.Fatalf("SetMaxGenerators() error = %v", err)
+		}
+		if _, err := tenantC.Create("k1", core.GeneratorTypeSnowflake, config); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		_, err := tenantC.Create("k2", core.GeneratorTypeSnowflake, config)
+		if !errors.Is(err, core.ErrMaxGeneratorsReached) {
+			t.Errorf("expected ErrMaxGeneratorsReached, got %v", err)
+		}
+		if err := tenantC.Remove("k1"); err != nil {
+			t.Fatalf("Remove() error = %v", err)
+		}
+		if _, err := tenantC.Create("k2", core.GeneratorTypeSnowflake, config); err != nil {
+			t.Errorf("Create() after Remove error = %v", err)
+		}
+	})
+
+	t.Run("非法命名空间", func(t *testing.T) {
+		_, err := r.Namespace("bad/name").GetOrCreate("k", core.GeneratorTypeSnowflake, config)
+		if !errors.Is(err, core.ErrInvalidKeyFormat) {
+			t.Errorf("expected ErrInvalidKeyFormat, got %v", err)
+		}
+	})
+}
+
+// registryProbeStore 保存时检测注册表是否被锁住的状态存储（测试用）
+type registryProbeStore struct {
+	r      *registry.Registry
+	locked *atomic.Bool // 保存时注册表处于写锁中
+}
+
+func (s registryProbeStore) Load(string) (int64, error) { return 0, nil }
+
+func (s registryProbeStore) Save(string, int64) error {
+	done := make(chan struct{})
+	go func() {
+		_ = s.r.Count()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.locked.Store(true)
+	}
+	return nil
+}
+
+// TestRegistry_Eviction 测试LRU淘汰与空闲淘汰
+func TestRegistry_Eviction(t *testing.T) {
+	r := registry.GetRegistry()
+	defer r.Clear()
+	defer r.SetMaxGenerators(r.GetMaxGenerators())
+	defer r.SetEvictionPolicy(registry.EvictionNone)
+
+	config := &snowflake.Config{DatacenterID: 1, WorkerID: 1}
+
+	t.Run("LRU淘汰最久未访问", func(t *testing.T) {
+		r.Clear()
+		_ = r.SetMaxGenerators(2)
+		_ = r.SetEvictionPolicy(registry.EvictionLRU)
+
+		old, _ := r.Create("lru1", core.GeneratorTypeSnowflake, config)
+		// lru2关闭时持久化状态，检测此时注册表是否仍被锁住
+		locked := &atomic.Bool{}
+		evictee, _ := r.Create("lru2", core.GeneratorTypeSnowflake, &snowflake.Config{
+			DatacenterID: 1, WorkerID: 2, StateStore: registryProbeStore{r: r, locked: locked},
+		})
+		_, _ = evictee.NextID()
+		time.Sleep(time.Millisecond)
+		_, _ = r.Get("lru1") // Touch：lru2成为最久未访问
+
+		if _, err := r.Create("lru3", core.GeneratorTypeSnowflake, config); err != nil {
+			t.Fatalf("Create() with LRU error = %v", err)
+		}
+		if locked.Load() {
+			t.Error("evicted generator was closed while holding the registry lock")
+		}
+		if r.Has("lru2") || !r.Has("lru1") || !r.Has("lru3") {
+			t.Errorf("unexpected keys after eviction: %v", r.ListKeys())
+		}
+		if _, err := old.NextID(); err != nil {
+			t.Errorf("touched generator should stay open, got %v", err)
+		}
+		if _, err := evictee.NextID(); !errors.Is(err, core.ErrGeneratorClosed) {
+			t.Errorf("evicted generator expected ErrGeneratorClosed, got %v", err)
+		}
+	})
+
+	t.Run("创建失败时不淘汰", func(t *testing.T) {
+		before := r.ListKeys()
+		bad := &snowflake.Config{DatacenterID: 1 << 20, WorkerID: 1}
+		if _, err := r.Create("lru5", core.GeneratorTypeSnowflake, bad); err == nil {
+			t.Fatal("Create() with invalid config should return error")
+		}
+		if _, err := r.GetOrCreate("lru5", core.GeneratorTypeSnowflake, "not a config"); err == nil {
+			t.Fatal("GetOrCreate() with invalid config should return error")
+		}
+		if got := r.ListKeys(); len(got) != len(before) || !r.Has("lru1") || !r.Has("lru3") {
+			t.Errorf("keys after failed create = %v, want %v", got, before)
+		}
+	})
+
+	t.Run("命名空间配额与全局上限同时满时只淘汰一个", func(t *testing.T) {
+		r.Clear()
+		_ = r.SetMaxGenerators(2)
+		_ = r.SetEvictionPolicy(registry.EvictionLRU)
+		ns := r.Namespace("evictns")
+		if err := ns.SetMaxGenerators(1); err != nil {
+			t.Fatalf("SetMaxGenerators() error = %v", err)
+		}
+
+		_, _ = r.Create("global1", core.GeneratorTypeSnowflake, config)
+		time.Sleep(time.Millisecond)
+		_, _ = ns.Create("a", core.GeneratorTypeSnowflake, config)
+
+		// global1 最久未访问，但淘汰命名空间内的 a 已同时释放全局名额
+
+		if _, err := ns.Create("b", core.GeneratorTypeSnowflake, config); err != nil {
+			t.Fatalf("Namespace.Create() with LRU error = %v", err)
+		}
+		if !r.Has("global1") || ns.Has("a") || !ns.Has("b") || r.Count() != 2 {
+			t.Errorf("unexpected keys after eviction: %v", r.ListKeys())
+		}
+	})
+
+	t.Run("无淘汰策略时拒绝创建", func(t *testing.T) {
+		_ = r.SetEvictionPolicy(registry.EvictionNone)
+		_, err := r.Create("lru4", core.GeneratorTypeSnowflake, config)
+		if !errors.Is(err, core.ErrMaxGeneratorsReached) {
+			t.Errorf("expected ErrMaxGeneratorsReached, got %v", err)
+		}
+	})
+
+	t.Run("空闲淘汰并关闭", func(t *testing.T) {
+		r.Clear()
+		_ = r.SetMaxGenerators(100)
+		idle, _ := r.Create("idle1", core.GeneratorTypeSnowflake, config)
+		if err := r.SetIdleTTL(time.Hour); err != nil {
+			t.Fatalf("SetIdleTTL() error = %v", err)
+		}
+		if evicted := r.EvictIdle(); len(evicted) != 0 {
+			t.Errorf("EvictIdle() = %v, want none", evicted)
+		}
+
+		_ = r.SetIdleTTL(time.Nanosecond)
+		defer r.SetIdleTTL(0)
+		time.Sleep(time.Millisecond)
+		evicted := r.EvictIdle()
+		if len(evicted) != 1 || evicted[0] != "idle1" {
+			t.Errorf("EvictIdle() = %v, want [idle1]", evicted)
+		}
+		if _, err := idle.NextID(); !errors.Is(err, core.ErrGeneratorClosed) {
+			t.Errorf("evicted generator expected ErrGeneratorClosed, got %v", err)
+		}
+	})
+
+	t.Run("无效策略", func(t *testing.T) {
+		if err := r.SetEvictionPolicy(registry.EvictionPolicy(99)); err == nil {
+			t.Error("SetEvictionPolicy(99) should return error")
+		}
+	})
+}
+
+// recordingListener 记录事件的监听器（测试用）
+type recordingListener struct {
+	mu      sync.Mutex
+	created []registry.RegistryEvent
+	removed []registry.RegistryEvent
+	onEvent func()
+}
+
+func (l *recordingListener) OnCreate(event registry.RegistryEvent) {
+	l.mu.Lock()
+	l.created = append(l.created, event)
+	l.mu.Unlock()
+	if l.onEvent != nil {
+		l.onEvent()
+	}
+}
+
+func (l *recordingListener) OnRemove(event registry.RegistryEvent) {
+	l.mu.Lock()
+	l.removed = append(l.removed, event)
+	l.mu.Unlock()
+	if l.onEvent != nil {
+		l.onEvent()
+	}
+}
+
+// TestRegistry_Listener 测试注册表监听器
+func TestRegistry_Listener(t *testing.T) {
+	r := registry.GetRegistry()
+	r.Clear()
+	defer r.Clear()
+	defer r.SetMaxGenerators(r.GetMaxGenerators())
+	defer r.SetEvictionPolicy(registry.EvictionNone)
+
+	// 在回调中访问注册表，验证回调在锁外调用
+	listener := &recordingListener{onEvent: func() { _ = r.Count() }}
+	r.AddListener(listener)
+	defer r.RemoveListener(listener)
+
+	config := &snowflake.Config{DatacenterID: 1, WorkerID: 1}
+	if _, err := r.Create("l1", core.GeneratorTypeSnowflake, config); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	_, _ = r.GetOrCreate("l1", core.GeneratorTypeSnowflake, config) // 已存在，不触发事件
+	_, _ = r.Create("l2", core.GeneratorTypeSnowflake, &snowflake.Config{DatacenterID: 1, WorkerID: 2})
+	_, _ = r.Namespace("tenant").Create("l3", core.GeneratorTypeSnowflake, config)
+	_, _ = r.Create("l1", core.GeneratorTypeSnowflake, config) // 重复创建失败，不触发事件
+
+	if len(listener.created) != 3 {
+		t.Fatalf("created events = %d, want 3", len(listener.created))
+	}
+	first := listener.created[0]
+	if first.Key != "l1" || first.Type != core.GeneratorTypeSnowflake || first.ConfigHash == "" || first.Time.IsZero() {
+		t.Errorf("OnCreate event = %+v", first)
+	}
+	if listener.created[1].ConfigHash == first.ConfigHash {
+		t.Error("different configs should have different hashes")
+	}
+	if listener.created[2].Key != "tenant/l3" || listener.created[2].ConfigHash != first.ConfigHash {
+		t.Errorf("namespace OnCreate event = %+v", listener.created[2])
+	}
+
+	// 移除、LRU淘汰、清空
+	_ = r.Remove("l1")
+	_ = r.SetMaxGenerators(2)
+	_ = r.SetEvictionPolicy(registry.EvictionLRU)
+	_, _ = r.Create("l4", core.GeneratorTypeSnowflake, config)
+	r.Clear()
+
+	wantReasons := []registry.RemoveReason{
+		registry.RemoveReasonManual,
+		registry.RemoveReasonEvictLRU,
+		registry.RemoveReasonClear,
+		registry.RemoveReasonClear,
+	}
+	if len(listener.removed) != len(wantReasons) {
+		t.Fatalf("removed events = %+v", listener.removed)
+	}
+	for i, want := range wantReasons {
+		if listener.removed[i].Reason != want {
+			t.Errorf("removed[%d].Reason = %s, want %s", i, listener.removed[i].Reason, want)
+		}
+	}
+	if listener.removed[0].Key != "l1" || listener.removed[0].ConfigHash != first.ConfigHash {
+		t.Errorf("OnRemove event = %+v", listener.removed[0])
+	}
+
+	// 移除监听器后不再接收事件
+	if !r.RemoveListener(listener) || r.RemoveListener(listener) {
+		t.Error("RemoveListener() should succeed exactly once")
+	}
+	_, _ = r.Create("l5", core.GeneratorTypeSnowflake, config)
+	if len(listener.created) != 4 {
+		t.Errorf("created events after removal = %d, want 4", len(listener.created))
+	}
+}
+
+// TestRegistry_Snapshot 测试配置快照导出和导入
+func TestRegistry_Snapshot(t *testing.T) {
+	r := registry.GetRegistry()
+	r.Clear()
+	defer r.Clear()
+
+	obfuscator, _ := snowflake.NewFeistelObfuscator("secret")
+	store := segment.NewMemoryStore()
+	_, _ = r.Create("orders", core.GeneratorTypeSnowflake, &snowflake.Config{DatacenterID: 2, WorkerID: 3, Obfuscator: obfuscator})
+	_, _ = r.Namespace("tenant").Create("invoices", core.GeneratorTypeSegment, &segment.Config{BizTag: "invoice", Store: store, Step: 50})
+
+	snapshot, err := r.Export()
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(snapshot.Entries) != 2 || snapshot.Entries[0].Key != "orders" || snapshot.Entries[1].Key != "tenant/invoices" {
+		t.Fatalf("Export() entries = %+v", snapshot.Entries)
+	}
+	if strings.Contains(string(snapshot.Entries[0].Config), "secret") || !strings.Contains(string(snapshot.Entries[0].Config), `"Obfuscator":null`) {
+		t.Errorf("config should be sanitized: %s", snapshot.Entries[0].Config)
+	}
+
+	// 经过JSON往返后导入
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var restored registry.Snapshot
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	listener := &recordingListener{}
+	r.AddListener(listener)
+	defer r.RemoveListener(listener)
+
+	r.Clear()
+	resolver := func(key string, generatorType core.GeneratorType, config any) error {
+		switch c := config.(type) {
+		case *segment.Config:
+			c.Store = store
+		case *snowflake.Config:
+			c.Obfuscator = obfuscator
+		}
+		return nil
+	}
+	if err := r.Import(&restored, resolver); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	gen, err := r.Get("orders")
+	if err != nil || gen.GetDatacenterID() != 2 || gen.GetWorkerID() != 3 {
+		t.Fatalf("restored generator = %v, %v", gen, err)
+	}
+	id, _ := gen.NextID()
+	if gen.(*snowflake.Generator).InternalID(id) == id {
+		t.Error("resolver should re-inject obfuscator")
+	}
+	if _, err := r.Namespace("tenant").Get("invoices"); err != nil {
+		t.Errorf("namespace generator not restored: %v", err)
+	}
+
+	// 配置哈希与原始创建时一致（脱敏后计算，不受运行时依赖影响）
+	again, _ := r.Export()
+	for i := range again.Entries {
+		if string(again.Entries[i].Config) != string(snapshot.Entries[i].Config) {
+			t.Errorf("re-export config mismatch: %s vs %s", again.Entries[i].Config, snapshot.Entries[i].Config)
+		}
+	}
+
+	// 重复导入：配置相同时跳过
+	created := len(listener.created)
+	if err := r.Import(&restored, resolver); err != nil {
+		t.Errorf("Import() same snapshot error = %v", err)
+	}
+	if len(listener.created) != created {
+		t.Error("re-import should not create generators")
+	}
+
+	// 键冲突、缺少依赖和无效条目
+	conflict := &registry.Snapshot{Entries: []registry.SnapshotEntry{
+		{Key: "orders", Type: core.GeneratorTypeSnowflake, Config: json.RawMessage(`{"DatacenterID":9}`)},
+		{Key: "no-store", Type: core.GeneratorTypeSegment, Config: json.RawMessage(`{"BizTag":"x"}`)},
+		{Key: "bad type", Type: core.GeneratorTypeSnowflake},
+		{Key: "uuid", Type: core.GeneratorTypeUUID},
+		{Key: "fresh", Type: core.GeneratorTypeSnowflake, Config: json.RawMessage(`{"WorkerID":5}`)},
+	}}
+	err = r.Import(conflict, nil)
+	if !errors.Is(err, core.ErrGeneratorAlreadyExists) || !errors.Is(err, core.ErrInvalidKeyFormat) || !errors.Is(err, core.ErrInvalidGeneratorType) {
+		t.Errorf("Import() error = %v", err)
+	}
+	if r.Has("no-store") || !r.Has("fresh") {
+		t.Errorf("unexpected keys after partial import: %v", r.ListKeys())
+	}
+	if err := r.Import(nil, nil); err == nil {
+		t.Error("Import(nil) should return error")
+	}
 }
 
 // TestRegistry_Count 测试计数
@@ -300,6 +766,137 @@ func TestRegistry_MaxGenerators(t *testing.T) {
 	})
 }
 
+// TestRegistry_GetEncoder 测试编码器注册与获取
+func TestRegistry_GetEncoder(t *testing.T) {
+	t.Run("默认编码器", func(t *testing.T) {
+		enc, err := registry.GetEncoder(registry.DefaultEncoderName)
+		if err != nil {
+			t.Fatalf("GetEncoder() error = %v", err)
+		}
+		code, _ := enc.Encode(61)
+		if code != "z" {
+			t.Errorf("Encode(61) = %q, want \"z\"", code)
+		}
+	})
+
+	t.Run("注册自定义编码器", func(t *testing.T) {
+		enc, _ := encoder.New(&encoder.Config{Secret: "public-order", MinLength: 8})
+		if err := registry.GetEncoderRegistry().Register("order", enc); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+		got, err := registry.GetEncoder("order")
+		if err != nil || got != enc {
+			t.Errorf("GetEncoder(order) = %v, %v", got, err)
+		}
+	})
+
+	t.Run("不存在的编码器", func(t *testing.T) {
+		if _, err := registry.GetEncoder("missing"); !errors.Is(err, core.ErrEncoderNotFound) {
+			t.Errorf("expected ErrEncoderNotFound, got %v", err)
+		}
+	})
+}
+
+// TestParseBatch 测试按生成器类型解析与批量解析
+func TestParseBatch(t *testing.T) {
+	gen, _ := snowflake.New(3, 7)
+	ids, _ := gen.NextIDBatch(100)
+
+	t.Run("单个解析", func(t *testing.T) {
+		info, err := registry.Parse(core.GeneratorTypeSnowflake, ids[0])
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		if info.DatacenterID != 3 || info.WorkerID != 7 {
+			t.Errorf("Parse() = %+v, want datacenter 3 worker 7", info)
+		}
+	})
+
+	t.Run("批量解析_部分失败", func(t *testing.T) {
+		input := append([]int64{-1}, ids...)
+		infos, err := registry.ParseBatch(core.GeneratorTypeSnowflake, input)
+		if err == nil {
+			t.Error("ParseBatch() with invalid ID should return error")
+		}
+		if len(infos) != len(input) || infos[0] != nil {
+			t.Fatalf("ParseBatch() result misaligned with input")
+		}
+		for i, info := range infos[1:] {
+			if info == nil || info.ID != ids[i] {
+				t.Fatalf("infos[%d] = %+v, want ID %d", i+1, info, ids[i])
+			}
+		}
+	})
+
+	t.Run("Segment类型", func(t *testing.T) {
+		infos, err := registry.ParseBatch(core.GeneratorTypeSegment, []int64{1, 2})
+		if err != nil || infos[1].ID != 2 {
+			t.Errorf("ParseBatch(segment) = %v, %v", infos, err)
+		}
+	})
+
+	t.Run("未注册解析器", func(t *testing.T) {
+		if _, err := registry.Parse(core.GeneratorTypeUUID, 1); !errors.Is(err, core.ErrParserNotFound) {
+			t.Errorf("expected ErrParserNotFound, got %v", err)
+		}
+	})
+
+	t.Run("格式化", func(t *testing.T) {
+		info := &core.IDInfo{ID: 42, Timestamp: 1704067200123, DatacenterID: 1, WorkerID: 2, Sequence: 3}
+		want := "id=42 time=2024-01-01T00:00:00.123Z datacenter=1 worker=2 sequence=3"
+		if got := registry.FormatInfo(info); got != want {
+			t.Errorf("FormatInfo() = %q, want %q", got, want)
+		}
+		if got := registry.FormatInfo(nil); got != "<nil>" {
+			t.Errorf("FormatInfo(nil) = %q", got)
+		}
+	})
+}
+
+// TestRegistry_HealthCheck 测试健康检查与Prometheus导出
+func TestRegistry_HealthCheck(t *testing.T) {
+	r := registry.GetRegistry()
+	defer r.Clear()
+
+	config := &snowflake.Config{DatacenterID: 1, WorkerID: 1, EnableMetrics: true}
+	gen, err := r.Create("health1", core.GeneratorTypeSnowflake, config)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	_, _ = gen.NextIDBatch(10)
+
+	t.Run("聚合状态", func(t *testing.T) {
+		statuses := r.HealthCheck()
+		status, ok := statuses["health1"]
+		if !ok {
+			t.Fatal("HealthCheck() missing key health1")
+		}
+		if !status.IsHealthy() || status.Stats.IDsIssued != 10 {
+			t.Errorf("HealthCheck()[health1] = %+v", status)
+		}
+		if !r.IsHealthy() {
+			t.Error("IsHealthy() = false, want true")
+		}
+	})
+
+	t.Run("Prometheus导出", func(t *testing.T) {
+		var buf strings.Builder
+		if err := r.WritePrometheus(&buf); err != nil {
+			t.Fatalf("WritePrometheus() error = %v", err)
+		}
+		out := buf.String()
+		for _, want := range []string{
+			"# TYPE idgen_ids_issued_total counter",
+			`idgen_ids_issued_total{key="health1"} 10`,
+			`idgen_healthy{key="health1"} 1`,
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("WritePrometheus() output missing %q", want)
+			}
+		}
+	})
+}
+
 // ============================================================================
 // 2. 并发测试
 // ============================================================================
@@ -0,0 +1,205 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"katydid-common-account/pkg/idgen/core"
+	"katydid-common-account/pkg/idgen/mock"
+	"katydid-common-account/pkg/idgen/segment"
+	"katydid-common-account/pkg/idgen/snowflake"
+)
+
+// ============================================================================
+// 配置快照（导出/导入）
+// ============================================================================
+
+// Snapshot 注册表配置快照
+// 用途：持久化服务的生成器拓扑，重启后按快照确定性地重建
+// 说明：可直接JSON序列化保存到文件或配置中心
+type Snapshot struct {
+	CreatedAt time.Time       `json:"created_at"` // 导出时间
+	Entries   []SnapshotEntry `json:"entries"`    // 生成器列表（按键排序）
+}
+
+// SnapshotEntry 单个生成器的快照
+type SnapshotEntry struct {
+	Key    string             `json:"key"`    // 生成器键（命名空间内为"命名空间/键"）
+	Type   core.GeneratorType `json:"type"`   // 生成器类型
+	Config json.RawMessage    `json:"config"` // 脱敏后的配置（JSON）
+}
+
+// ConfigResolver 导入时补全配置的回调
+// 用途：快照不包含存储、混淆器等运行时依赖，导入时通过回调重新注入
+// 参数：config 为按类型解码后的配置指针（如 *snowflake.Config、*segment.Config），可直接修改
+type ConfigResolver func(key string, generatorType core.GeneratorType, config any) error
+
+// Export 导出当前所有生成器的配置快照
+// 说明：
+//   - 配置经过脱敏，不包含存储（StateStore、Store）和混淆器（Obfuscator）等运行时依赖
+//   - 条目按键排序，相同拓扑导出的条目顺序一致
+func (r *Registry) Export() (*Snapshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := &Snapshot{
+		CreatedAt: time.Now(),
+		Entries:   make([]SnapshotEntry, 0, len(r.entries)),
+	}
+	for key, entry := range r.entries {
+		data, err := json.Marshal(entry.config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export generator '%s': %w", key, err)
+		}
+		snapshot.Entries = append(snapshot.Entries, SnapshotEntry{
+			Key:    key,
+			Type:   entry.generatorType,
+			Config: data,
+		})
+	}
+	sort.Slice(snapshot.Entries, func(i, j int) bool {
+		return snapshot.Entries[i].Key < snapshot.Entries[j].Key
+	})
+	return snapshot, nil
+}
+
+// Import 按快照重建生成器
+// 说明：
+//   - 按快照中的顺序逐个创建，单个条目失败不影响其他条目，所有错误合并返回
+//   - 键已存在且脱敏后的配置相同时跳过；配置不同时返回 core.ErrGeneratorAlreadyExists
+//   - resolver 可为nil；号段生成器等需要运行时依赖的类型必须通过 resolver 注入
+//
+// 示例：
+//
+//	err := reg.Import(snapshot, func(key string, t core.GeneratorType, config any) error {
+//		if c, ok := config.(*segment.Config); ok {
+//			c.Store = sqlStore
+//		}
+//		return nil
+//	})
+func (r *Registry) Import(snapshot *Snapshot, resolver ConfigResolver) error {
+	if snapshot == nil {
+		return fmt.Errorf("snapshot cannot be nil")
+	}
+
+	var errs []error
+	imported := 0
+	for _, entry := range snapshot.Entries {
+		created, err := r.importEntry(entry, resolver)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to import generator '%s': %w", entry.Key, err))
+			continue
+		}
+		if created {
+			imported++
+		}
+	}
+
+	log.Println("注册表快照导入完成", "total", len(snapshot.Entries), "imported", imported, "failed", len(errs))
+
+	return errors.Join(errs...)
+}
+
+// importEntry 按单个快照条目创建生成器
+// 返回值：是否新建了生成器（已存在且配置相同时为false）
+func (r *Registry) importEntry(entry SnapshotEntry, resolver ConfigResolver) (bool, error) {
+	if err := validateSnapshotKey(entry.Key); err != nil {
+		return false, err
+	}
+	if !entry.Type.IsValid() {
+		return false, fmt.Errorf("%w: %s", core.ErrInvalidGeneratorType, entry.Type)
+	}
+
+	// 步骤1：按类型解码配置
+	config, err := newConfig(entry.Type)
+	if err != nil {
+		return false, err
+	}
+	if len(entry.Config) > 0 {
+		if err := json.Unmarshal(entry.Config, config); err != nil {
+			return false, fmt.Errorf("invalid config: %w", err)
+		}
+	}
+
+	// 步骤2：补全运行时依赖
+	if resolver != nil {
+		if err := resolver(entry.Key, entry.Type, config); err != nil {
+			return false, err
+		}
+	}
+
+	// 步骤3：加写锁创建（释放锁后分发事件）
+	r.mu.Lock()
+	defer r.unlockAndNotify()
+
+	if existing, exists := r.entries[entry.Key]; exists {
+		if existing.generatorType == entry.Type && existing.configHash == configHash(sanitizeConfig(config)) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%w: key '%s' with different config", core.ErrGeneratorAlreadyExists, entry.Key)
+	}
+
+	if _, err := r.createUnsafe(entry.Key, entry.Type, config); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// validateSnapshotKey 验证快照中的键（允许"命名空间/键"形式）
+func validateSnapshotKey(key string) error {
+	if namespace, name, ok := splitNamespacedKey(key); ok {
+		if err := validateKey(namespace); err != nil {
+			return err
+		}
+		return validateKey(name)
+	}
+	return validateKey(key)
+}
+
+// newConfig 创建指定类型的空配置（用于解码快照）
+func newConfig(generatorType core.GeneratorType) (any, error) {
+	switch generatorType {
+	case core.GeneratorTypeSnowflake:
+		return &snowflake.Config{}, nil
+	case core.GeneratorTypeSegment:
+		return &segment.Config{}, nil
+	case core.GeneratorTypeMock:
+		return &mock.Config{}, nil
+	default:
+		return nil, fmt.Errorf("%w: snapshot import not supported for %s", core.ErrInvalidGeneratorType, generatorType)
+	}
+}
+
+// sanitizeConfig 返回去除运行时依赖后的配置副本
+// 说明：存储、混淆器、时钟源等接口字段无法序列化且可能包含连接信息或密钥，不进入快照和配置哈希
+func sanitizeConfig(config any) any {
+	switch c := config.(type) {
+	case *snowflake.Config:
+		if c == nil {
+			return c
+		}
+		clone := c.Clone()
+		clone.StateStore = nil
+		clone.Obfuscator = nil
+		clone.Clock = nil
+		return clone
+	case *segment.Config:
+		if c == nil {
+			return c
+		}
+		clone := c.Clone()
+		clone.Store = nil
+		return clone
+	case *mock.Config:
+		if c == nil {
+			return c
+		}
+		return c.Clone()
+	default:
+		return config
+	}
+}
@@ -0,0 +1,84 @@
+package segment
+
+import "fmt"
+
+// ============================================================================
+// Segment 配置定义
+// ============================================================================
+
+// Config 号段生成器配置
+type Config struct {
+	// BizTag 业务标签
+	// 说明：对应号段表中的biz_tag列，不同业务使用独立的号段
+	BizTag string
+
+	// Store 号段存储
+	// 说明：通常为SQLStore，测试时可使用MemoryStore
+	Store IRangeStore
+
+	// Step 号段步长
+	// 说明：每次从存储申请的ID数量，步长越大访问存储越少，但重启时浪费的ID越多
+	// 范围：1-1,000,000
+	// 默认值：1000
+	Step int64
+
+	// PreloadThreshold 预加载阈值
+	// 说明：当前号段剩余比例低于该值时，异步加载下一个号段（双缓冲）
+	// 范围：(0, 1)
+	// 默认值：0.9（即已消耗10%时开始预加载）
+	PreloadThreshold float64
+
+	// EnableMetrics 是否启用性能监控
+	// 默认值：false
+	EnableMetrics bool
+}
+
+// Validate 验证配置的有效性
+func (c *Config) Validate() error {
+	// 验证业务标签
+	if c.BizTag == "" {
+		return fmt.Errorf("biz tag cannot be empty")
+	}
+	if len(c.BizTag) > maxBizTagLength {
+		return fmt.Errorf("biz tag too long (max %d), got %d", maxBizTagLength, len(c.BizTag))
+	}
+
+	// 验证存储
+	if c.Store == nil {
+		return fmt.Errorf("store cannot be nil")
+	}
+
+	// 验证步长（0表示使用默认值）
+	if c.Step < 0 || c.Step > maxStep {
+		return fmt.Errorf("step must be between 1 and %d, got %d", maxStep, c.Step)
+	}
+
+	// 验证预加载阈值（0表示使用默认值）
+	if c.PreloadThreshold < 0 || c.PreloadThreshold >= 1 {
+		return fmt.Errorf("preload threshold must be in (0, 1), got %v", c.PreloadThreshold)
+	}
+
+	return nil
+}
+
+// SetDefaults 设置配置的默认值
+func (c *Config) SetDefaults() {
+	if c.Step == 0 {
+		c.Step = defaultStep
+	}
+	if c.PreloadThreshold == 0 {
+		c.PreloadThreshold = defaultPreloadThreshold
+	}
+}
+
+// Clone 克隆配置对象
+// 注意：Store为共享引用，不会被深拷贝
+func (c *Config) Clone() *Config {
+	return &Config{
+		BizTag:           c.BizTag,
+		Store:            c.Store,
+		Step:             c.Step,
+		PreloadThreshold: c.PreloadThreshold,
+		EnableMetrics:    c.EnableMetrics,
+	}
+}
@@ -0,0 +1,25 @@
+package segment
+
+const (
+	// defaultStep 默认号段步长
+	// 值：1000
+	defaultStep = 1000
+
+	// maxStep 号段步长上限
+	// 值：1,000,000（100万）
+	// 目的：防止单次申请过大号段，进程重启时浪费过多ID
+	maxStep = 1_000_000
+
+	// defaultPreloadThreshold 默认预加载阈值
+	// 说明：当前号段剩余比例低于90%时异步加载下一号段
+	defaultPreloadThreshold = 0.9
+
+	// maxBizTagLength 业务标签最大长度
+	maxBizTagLength = 128
+)
+
+const (
+	// maxBatchSize 批量生成ID的最大数量
+	// 值：100,000（10万），与Snowflake生成器保持一致
+	maxBatchSize = 100_000
+)
@@ -0,0 +1,26 @@
+package segment
+
+import (
+	"fmt"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// Factory 号段生成器工厂
+type Factory struct{}
+
+// NewFactory 创建号段工厂实例
+func NewFactory() *Factory {
+	return &Factory{}
+}
+
+// Create 创建号段生成器实例
+// 实现core.GeneratorFactory接口
+func (f *Factory) Create(config any) (core.IGenerator, error) {
+	segConfig, ok := config.(*Config)
+	if !ok {
+		return nil, fmt.Errorf("invalid config type: expected *segment.Config, got %T", config)
+	}
+
+	return NewWithConfig(segConfig)
+}
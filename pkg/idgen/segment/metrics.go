@@ -0,0 +1,46 @@
+package segment
+
+import "sync/atomic"
+
+// Metrics 性能监控指标
+type Metrics struct {
+	// IDCount 已生成的ID总数
+	IDCount atomic.Uint64
+
+	// SegmentLoads 号段加载次数
+	// 说明：每次成功从存储申请号段时递增
+	SegmentLoads atomic.Uint64
+
+	// LoadFailures 号段加载失败次数
+	// 用途：监控存储可用性，失败过多需要检查数据库
+	LoadFailures atomic.Uint64
+
+	// WaitCount 等待号段加载的次数
+	// 说明：当前号段耗尽而下一号段尚未就绪时递增
+	// 用途：频繁等待说明步长过小或预加载阈值过低
+	WaitCount atomic.Uint64
+}
+
+// NewMetrics 创建新的监控指标实例
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// Reset 重置所有监控指标
+func (m *Metrics) Reset() {
+	m.IDCount.Store(0)
+	m.SegmentLoads.Store(0)
+	m.LoadFailures.Store(0)
+	m.WaitCount.Store(0)
+}
+
+// ToMap 转换为map格式
+func (m *Metrics) ToMap() map[string]uint64 {
+	return map[string]uint64{
+		"metrics_enabled": 1,                     // 监控已启用
+		"id_count":        m.IDCount.Load(),      // ID生成总数
+		"segment_loads":   m.SegmentLoads.Load(), // 号段加载次数
+		"load_failures":   m.LoadFailures.Load(), // 号段加载失败次数
+		"wait_count":      m.WaitCount.Load(),    // 等待次数
+	}
+}
@@ -0,0 +1,399 @@
+package segment
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// segmentRange 号段
+// 说明：可用ID范围为 [cursor, max]
+type segmentRange struct {
+	cursor int64 // 下一个可用ID
+	max    int64 // 号段最大ID（包含）
+}
+
+// remaining 号段剩余ID数量（尚未加载时为0）
+func (s *segmentRange) remaining() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.max - s.cursor + 1
+}
+
+// Generator 号段模式（Leaf-segment）的ID生成器实现
+// 说明：
+//   - 从数据库号段表批量申请ID区间，在内存中逐个发放
+//   - 双缓冲：当前号段消耗到阈值时异步加载下一号段，切换时无需等待数据库
+//   - 生成的ID在单实例内严格递增，多实例间整体趋势递增
+type Generator struct {
+	// ========== 核心状态 ==========
+	current *segmentRange // 当前使用的号段（nil表示首个号段尚未加载）
+	next    *segmentRange // 预加载的下一号段（nil表示尚未就绪）
+	loading bool          // 是否正在异步加载下一号段
+	failed  bool          // 本号段内加载是否已失败（异步失败后不再重复预加载）
+	closed  bool          // 是否已关闭
+
+	// ========== 配置 ==========
+	config           *Config     // 生成器配置
+	preloadRemaining int64       // 当前号段剩余数量低于该值时触发预加载
+	store            IRangeStore // 号段存储
+
+	// ========== 监控和工具 ==========
+	metrics   *Metrics          // 性能监控指标（可选，nil时不收集）
+	validator core.IIDValidator // ID验证器
+	parser    core.IIDParser    // ID解析器
+
+	// ========== 并发控制 ==========
+	mu     sync.Mutex // 互斥锁，保护生成器状态
+	loaded *sync.Cond // 异步加载完成通知（基于mu）
+}
+
+// New 创建一个新的号段ID生成器
+// 说明：使用默认步长和预加载阈值，默认关闭监控
+func New(bizTag string, store IRangeStore) (core.IGenerator, error) {
+	return NewWithConfig(&Config{
+		BizTag: bizTag,
+		Store:  store,
+	})
+}
+
+// NewWithConfig 使用配置创建号段ID生成器
+// 说明：创建时不访问存储，第一个号段在首次发号时同步加载，避免阻塞创建方（如持有锁的注册表）；
+// 存储不可用时由首次发号返回错误
+func NewWithConfig(config *Config) (core.IGenerator, error) {
+	if config == nil {
+		return nil, core.ErrNilConfig
+	}
+
+	// 步骤1：验证配置
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 步骤2：设置默认值
+	config.SetDefaults()
+
+	// 步骤3：初始化监控（如果启用）
+	var metrics *Metrics
+	if config.EnableMetrics {
+		metrics = NewMetrics()
+	}
+
+	// 步骤4：创建生成器实例
+	generator := &Generator{
+		config:           config.Clone(),
+		preloadRemaining: int64(float64(config.Step) * config.PreloadThreshold),
+		store:            config.Store,
+		metrics:          metrics,
+		validator:        NewValidator(),
+		parser:           NewParser(),
+	}
+	generator.loaded = sync.NewCond(&generator.mu)
+
+	log.Println("Segment生成器创建成功",
+		"biz_tag", config.BizTag,
+		"step", config.Step,
+		"metrics_enabled", config.EnableMetrics)
+
+	return generator, nil
+}
+
+// NextID 生成下一个唯一ID（线程安全）
+// 实现core.IDGenerator接口
+func (g *Generator) NextID() (int64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.closed {
+		return 0, core.ErrGeneratorClosed
+	}
+
+	return g.nextIDUnsafe()
+}
+
+// NextIDBatch 批量生成ID（线程安全）
+// 实现core.BatchGenerator接口
+func (g *Generator) NextIDBatch(n int) ([]int64, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: batch size must be positive, got %d",
+			core.ErrInvalidBatchSize, n)
+	}
+	if n > maxBatchSize {
+		return nil, fmt.Errorf("%w: batch size too large (max %d), got %d",
+			core.ErrInvalidBatchSize, maxBatchSize, n)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.closed {
+		return nil, core.ErrGeneratorClosed
+	}
+
+	return g.nextIDBatchUnsafe(n)
+}
+
+// NextIDs 批量生成ID（线程安全）
+// 说明：NextIDBatch的别名
+func (g *Generator) NextIDs(n int) ([]int64, error) {
+	return g.NextIDBatch(n)
+}
+
+// GetWorkerID 获取工作机器ID
+// 实现core.ConfigurableGenerator接口
+// 说明：号段模式由数据库统一发号，不区分机器，固定返回0
+func (g *Generator) GetWorkerID() int64 {
+	return 0
+}
+
+// GetDatacenterID 获取数据中心ID
+// 实现core.ConfigurableGenerator接口
+// 说明：号段模式由数据库统一发号，不区分数据中心，固定返回0
+func (g *Generator) GetDatacenterID() int64 {
+	return 0
+}
+
+// GetBizTag 获取业务标签
+func (g *Generator) GetBizTag() string {
+	return g.config.BizTag
+}
+
+// GetMetrics 获取性能监控指标
+// 实现core.MonitorableGenerator接口
+func (g *Generator) GetMetrics() map[string]uint64 {
+	if g.metrics == nil {
+		return map[string]uint64{"metrics_enabled": 0}
+	}
+	return g.metrics.ToMap()
+}
+
+// ResetMetrics 重置性能监控指标
+// 实现core.MonitorableGenerator接口
+func (g *Generator) ResetMetrics() {
+	if g.metrics != nil {
+		g.metrics.Reset()
+	}
+}
+
+// GetIDCount 获取已生成的ID总数
+// 实现core.MonitorableGenerator接口
+func (g *Generator) GetIDCount() uint64 {
+	if g.metrics == nil {
+		return 0
+	}
+	return g.metrics.IDCount.Load()
+}
+
+// Stats 获取运行统计
+// 实现core.MonitorableGenerator接口
+// 说明：号段ID不含时间戳，LastTimestamp与时钟相关字段固定为0
+func (g *Generator) Stats() core.GeneratorStats {
+	stats := core.GeneratorStats{}
+	if g.metrics != nil {
+		stats.IDsIssued = g.metrics.IDCount.Load()
+	}
+	return stats
+}
+
+// HealthCheck 健康检查
+// 实现core.HealthChecker接口
+// 说明：
+//   - 首个号段尚未加载：健康（首次发号时加载）；加载失败后不健康
+//   - 当前号段已耗尽且下一号段未就绪：不健康（下次发号需同步访问存储）
+//   - 下一号段预加载失败：降级（当前号段用尽后可能无法发号）
+func (g *Generator) HealthCheck() core.HealthStatus {
+	g.mu.Lock()
+	pending := g.current == nil
+	exhausted := g.current.remaining() <= 0 && g.next == nil
+	failed := g.failed
+	g.mu.Unlock()
+
+	status := core.HealthStatus{State: core.HealthStateHealthy, Stats: g.Stats()}
+	switch {
+	case pending && failed:
+		status.State = core.HealthStateUnhealthy
+		status.Message = "first segment load failed"
+	case pending:
+	case exhausted:
+		status.State = core.HealthStateUnhealthy
+		status.Message = "current segment exhausted and next segment not ready"
+	case failed:
+		status.State = core.HealthStateDegraded
+		status.Message = "next segment preload failed"
+	}
+
+	return status
+}
+
+// ParseID 解析ID
+// 实现core.ParseableGenerator接口
+// 说明：号段ID不携带时间戳、机器等元信息，仅返回ID本身
+func (g *Generator) ParseID(id int64) (*core.IDInfo, error) {
+	return g.parser.Parse(id)
+}
+
+// ValidateID 验证ID
+// 实现core.ParseableGenerator接口
+func (g *Generator) ValidateID(id int64) error {
+	return g.validator.Validate(id)
+}
+
+// Close 关闭生成器
+// 实现core.CloseableGenerator接口
+// 说明：号段状态由存储统一维护，关闭时无需持久化，未使用完的号段会被丢弃
+func (g *Generator) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.closed = true
+	return nil
+}
+
+// nextIDUnsafe 内部使用的不加锁版本的ID生成方法
+// 说明：调用者必须已持有锁
+func (g *Generator) nextIDUnsafe() (int64, error) {
+	// 步骤1：当前号段耗尽时切换到下一号段
+	if g.current.remaining() <= 0 {
+		if err := g.switchSegmentUnsafe(); err != nil {
+			return 0, err
+		}
+	}
+
+	// 步骤2：从当前号段取出ID
+	id := g.current.cursor
+	g.current.cursor++
+
+	// 步骤3：检查是否需要预加载下一号段
+	g.preloadIfNeededUnsafe()
+
+	// 步骤4：更新监控指标
+	if g.metrics != nil {
+		g.metrics.IDCount.Add(1)
+	}
+
+	return id, nil
+}
+
+// nextIDBatchUnsafe 内部使用的不加锁版本的批量生成方法
+// 说明：调用者必须已持有锁，号段不足时自动跨号段生成
+func (g *Generator) nextIDBatchUnsafe(n int) ([]int64, error) {
+	ids := make([]int64, 0, n)
+
+	for len(ids) < n {
+		if g.current.remaining() <= 0 {
+			if err := g.switchSegmentUnsafe(); err != nil {
+				// 返回已生成的ID和错误，指标按实际返回的数量记录
+				g.recordBatchUnsafe(len(ids))
+				return ids, fmt.Errorf("%w (generated %d/%d IDs)", err, len(ids), n)
+			}
+		}
+
+		// 从当前号段取出尽可能多的ID
+		take := int64(n - len(ids))
+		if remaining := g.current.remaining(); take > remaining {
+			take = remaining
+		}
+		for i := int64(0); i < take; i++ {
+			ids = append(ids, g.current.cursor)
+			g.current.cursor++
+		}
+
+		g.preloadIfNeededUnsafe()
+	}
+
+	g.recordBatchUnsafe(n)
+
+	return ids, nil
+}
+
+// recordBatchUnsafe 记录批量生成的ID数量
+func (g *Generator) recordBatchUnsafe(n int) {
+	if g.metrics != nil && n > 0 {
+		g.metrics.IDCount.Add(uint64(n))
+	}
+}
+
+// switchSegmentUnsafe 切换到下一号段
+// 说明：调用者必须已持有锁
+func (g *Generator) switchSegmentUnsafe() error {
+	// 步骤1：下一号段正在加载时等待其完成
+	if g.next == nil && g.loading {
+		if g.metrics != nil {
+			g.metrics.WaitCount.Add(1)
+		}
+		for g.loading {
+			g.loaded.Wait()
+		}
+	}
+
+	// 步骤2：下一号段已就绪，直接切换
+	if g.next != nil {
+		g.current, g.next = g.next, nil
+		return nil
+	}
+
+	// 步骤3：首个号段、预加载失败或未触发，同步加载
+	seg, err := g.loadSegment()
+	if err != nil {
+		g.failed = true
+		return err
+	}
+	g.current = seg
+	g.failed = false
+	return nil
+}
+
+// preloadIfNeededUnsafe 当前号段消耗到阈值时异步加载下一号段
+// 说明：调用者必须已持有锁
+func (g *Generator) preloadIfNeededUnsafe() {
+	if g.next != nil || g.loading || g.failed || g.current.remaining() >= g.preloadRemaining {
+		return
+	}
+
+	g.loading = true
+	go func() {
+		seg, err := g.loadSegment()
+
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		g.loading = false
+		if err == nil {
+			g.next = seg
+		} else {
+			g.failed = true
+		}
+		g.loaded.Broadcast()
+	}()
+}
+
+// loadSegment 从存储申请一个新号段
+// 说明：不访问生成器状态，可在不持有锁的情况下调用
+func (g *Generator) loadSegment() (*segmentRange, error) {
+	maxID, err := g.store.NextRange(g.config.BizTag, g.config.Step)
+	if err != nil {
+		if g.metrics != nil {
+			g.metrics.LoadFailures.Add(1)
+		}
+		log.Println("号段加载失败", "biz_tag", g.config.BizTag, "error", err)
+		return nil, fmt.Errorf("%w: biz tag '%s': %w", core.ErrSegmentLoadFailed, g.config.BizTag, err)
+	}
+
+	// 号段上界必须为正，否则会发放非正数ID
+	if maxID < g.config.Step {
+		return nil, fmt.Errorf("%w: biz tag '%s': max id %d is smaller than step %d",
+			core.ErrSegmentLoadFailed, g.config.BizTag, maxID, g.config.Step)
+	}
+
+	if g.metrics != nil {
+		g.metrics.SegmentLoads.Add(1)
+	}
+
+	return &segmentRange{
+		cursor: maxID - g.config.Step + 1,
+		max:    maxID,
+	}, nil
+}
@@ -0,0 +1,274 @@
+package segment_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"katydid-common-account/pkg/idgen/core"
+	"katydid-common-account/pkg/idgen/segment"
+)
+
+// failingStore 总是失败的号段存储（测试用）
+type failingStore struct{}
+
+func (failingStore) NextRange(string, int64) (int64, error) {
+	return 0, errors.New("db unavailable")
+}
+
+// flakyStore 首次成功、之后失败的号段存储（测试用）
+type flakyStore struct {
+	calls atomic.Int64
+	inner *segment.MemoryStore
+}
+
+func (s *flakyStore) NextRange(bizTag string, step int64) (int64, error) {
+	if s.calls.Add(1) > 1 {
+		return 0, errors.New("db unavailable")
+	}
+	return s.inner.NextRange(bizTag, step)
+}
+
+// ============================================================================
+// 1. Segment生成器基础功能测试
+// ============================================================================
+
+// TestNewWithConfig 测试创建生成器
+func TestNewWithConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *segment.Config
+		wantErr bool
+	}{
+		{"正常配置", &segment.Config{BizTag: "order", Store: segment.NewMemoryStore()}, false},
+		{"自定义步长", &segment.Config{BizTag: "order", Store: segment.NewMemoryStore(), Step: 10}, false},
+		{"空配置", nil, true},
+		{"空业务标签", &segment.Config{Store: segment.NewMemoryStore()}, true},
+		{"空存储", &segment.Config{BizTag: "order"}, true},
+		{"负步长", &segment.Config{BizTag: "order", Store: segment.NewMemoryStore(), Step: -1}, true},
+		{"阈值越界", &segment.Config{BizTag: "order", Store: segment.NewMemoryStore(), PreloadThreshold: 1}, true},
+		{"创建时不访问存储", &segment.Config{BizTag: "order", Store: failingStore{}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := segment.NewWithConfig(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewWithConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("存储错误可识别", func(t *testing.T) {
+		gen, err := segment.New("order", failingStore{})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if _, err := gen.NextID(); !errors.Is(err, core.ErrSegmentLoadFailed) {
+			t.Errorf("expected ErrSegmentLoadFailed, got %v", err)
+		}
+		if status := gen.(core.IHealthChecker).HealthCheck(); status.State != core.HealthStateUnhealthy {
+			t.Errorf("HealthCheck() = %v, want unhealthy", status.State)
+		}
+	})
+
+	t.Run("首次发号时加载", func(t *testing.T) {
+		store := &flakyStore{inner: segment.NewMemoryStore()}
+		gen, err := segment.New("order", store)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if calls := store.calls.Load(); calls != 0 {
+			t.Errorf("创建时访问存储 %d 次, want 0", calls)
+		}
+		if status := gen.(core.IHealthChecker).HealthCheck(); status.State != core.HealthStateHealthy {
+			t.Errorf("加载前 HealthCheck() = %v, want healthy", status.State)
+		}
+		if id, err := gen.NextID(); err != nil || id != 1 {
+			t.Errorf("NextID() = %d, %v, want 1", id, err)
+		}
+	})
+}
+
+// TestNextID 测试ID跨号段连续递增
+func TestNextID(t *testing.T) {
+	gen, err := segment.NewWithConfig(&segment.Config{
+		BizTag:        "order",
+		Store:         segment.NewMemoryStore(),
+		Step:          10,
+		EnableMetrics: true,
+	})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	for want := int64(1); want <= 35; want++ {
+		id, err := gen.NextID()
+		if err != nil {
+			t.Fatalf("NextID failed: %v", err)
+		}
+		if id != want {
+			t.Fatalf("NextID() = %d, want %d", id, want)
+		}
+	}
+
+	if gen.GetIDCount() != 35 {
+		t.Errorf("GetIDCount() = %d, want 35", gen.GetIDCount())
+	}
+}
+
+// TestNextIDBatch 测试批量生成
+func TestNextIDBatch(t *testing.T) {
+	gen, err := segment.NewWithConfig(&segment.Config{
+		BizTag: "order",
+		Store:  segment.NewMemoryStore(),
+		Step:   100,
+	})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	ids, err := gen.(*segment.Generator).NextIDs(250)
+	if err != nil {
+		t.Fatalf("NextIDs failed: %v", err)
+	}
+	for i, id := range ids {
+		if id != int64(i+1) {
+			t.Fatalf("ids[%d] = %d, want %d", i, id, i+1)
+		}
+	}
+
+	if _, err := gen.NextIDBatch(0); !errors.Is(err, core.ErrInvalidBatchSize) {
+		t.Errorf("expected ErrInvalidBatchSize, got %v", err)
+	}
+}
+
+// TestStoreFailure 测试存储故障时已加载号段仍可使用
+func TestStoreFailure(t *testing.T) {
+	store := &flakyStore{inner: segment.NewMemoryStore()}
+	gen, err := segment.NewWithConfig(&segment.Config{
+		BizTag: "order",
+		Store:  store,
+		Step:   5,
+	})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := gen.NextID(); err != nil {
+			t.Fatalf("NextID within loaded segment failed: %v", err)
+		}
+	}
+
+	if _, err := gen.NextID(); !errors.Is(err, core.ErrSegmentLoadFailed) {
+		t.Errorf("expected ErrSegmentLoadFailed, got %v", err)
+	}
+}
+
+// TestStoreFailure_Batch 测试批量生成中途存储故障时返回已生成的ID，指标按实际数量记录
+func TestStoreFailure_Batch(t *testing.T) {
+	store := &flakyStore{inner: segment.NewMemoryStore()}
+	gen, err := segment.NewWithConfig(&segment.Config{
+		BizTag:        "order",
+		Store:         store,
+		Step:          5,
+		EnableMetrics: true,
+	})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	ids, err := gen.NextIDBatch(8)
+	if !errors.Is(err, core.ErrSegmentLoadFailed) {
+		t.Errorf("expected ErrSegmentLoadFailed, got %v", err)
+	}
+	if len(ids) != 5 {
+		t.Fatalf("len(ids) = %d, want 5", len(ids))
+	}
+	if gen.GetIDCount() != 5 {
+		t.Errorf("GetIDCount() = %d, want 5", gen.GetIDCount())
+	}
+}
+
+// TestValidateID 测试ID验证与解析
+func TestValidateID(t *testing.T) {
+	gen, _ := segment.New("order", segment.NewMemoryStore())
+
+	if err := gen.ValidateID(1); err != nil {
+		t.Errorf("ValidateID(1) error = %v", err)
+	}
+	if err := gen.ValidateID(0); !errors.Is(err, core.ErrInvalidSegmentID) {
+		t.Errorf("expected ErrInvalidSegmentID, got %v", err)
+	}
+
+	info, err := gen.ParseID(42)
+	if err != nil || info.ID != 42 {
+		t.Errorf("ParseID(42) = %+v, %v", info, err)
+	}
+}
+
+// ============================================================================
+// 2. 并发测试
+// ============================================================================
+
+// TestConcurrentGeneration 测试多实例共享存储时的并发唯一性
+func TestConcurrentGeneration(t *testing.T) {
+	store := segment.NewMemoryStore()
+	const instances, goroutines, perGoroutine = 3, 20, 500
+
+	gens := make([]core.IGenerator, instances)
+	for i := range gens {
+		gen, err := segment.NewWithConfig(&segment.Config{
+			BizTag: "order",
+			Store:  store,
+			Step:   64,
+		})
+		if err != nil {
+			t.Fatalf("Setup failed: %v", err)
+		}
+		gens[i] = gen
+	}
+
+	var idMap sync.Map
+	var duplicates atomic.Int64
+	var wg sync.WaitGroup
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(gen core.IGenerator) {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				id, err := gen.NextID()
+				if err != nil {
+					t.Errorf("NextID failed: %v", err)
+					return
+				}
+				if _, loaded := idMap.LoadOrStore(id, true); loaded {
+					duplicates.Add(1)
+				}
+			}
+		}(gens[i%instances])
+	}
+	wg.Wait()
+
+	if duplicates.Load() > 0 {
+		t.Errorf("Found %d duplicate IDs", duplicates.Load())
+	}
+}
+
+// ============================================================================
+// 3. 性能基准测试
+// ============================================================================
+
+// BenchmarkNextID 基准测试单个ID生成
+func BenchmarkNextID(b *testing.B) {
+	gen, _ := segment.New("order", segment.NewMemoryStore())
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = gen.NextID()
+	}
+}
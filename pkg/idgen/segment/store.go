@@ -0,0 +1,118 @@
+package segment
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// IRangeStore 号段存储接口
+// 说明：负责原子地推进业务标签的最大ID，并返回新号段的上界
+type IRangeStore interface {
+	// NextRange 申请下一个号段
+	// 返回值：新号段的最大ID（包含），号段范围为 (maxID-step, maxID]
+	NextRange(bizTag string, step int64) (maxID int64, err error)
+}
+
+// tableNameRegex 表名的合法字符正则表达式
+// 目的：表名会拼接进SQL语句，必须防止注入
+var tableNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// defaultTableName 默认号段表名
+const defaultTableName = "number_range"
+
+// SQLStore 基于数据库号段表的存储实现
+// 表结构（MySQL示例）：
+//
+//	CREATE TABLE number_range (
+//	    biz_tag     VARCHAR(128) NOT NULL PRIMARY KEY,
+//	    max_id      BIGINT       NOT NULL DEFAULT 0,
+//	    step        INT          NOT NULL DEFAULT 1000,
+//	    update_time TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+//	);
+//
+// 注意：使用"?"占位符，适用于MySQL、SQLite等驱动
+type SQLStore struct {
+	db         *sql.DB // 数据库连接
+	updateStmt string  // 推进max_id的SQL
+	selectStmt string  // 查询max_id的SQL
+}
+
+// NewSQLStore 创建数据库号段存储
+// 说明：tableName为空时使用默认表名number_range
+func NewSQLStore(db *sql.DB, tableName string) (*SQLStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("db cannot be nil")
+	}
+
+	if tableName == "" {
+		tableName = defaultTableName
+	}
+	if !tableNameRegex.MatchString(tableName) {
+		return nil, fmt.Errorf("invalid table name '%s'", tableName)
+	}
+
+	return &SQLStore{
+		db:         db,
+		updateStmt: "UPDATE " + tableName + " SET max_id = max_id + ? WHERE biz_tag = ?",
+		selectStmt: "SELECT max_id FROM " + tableName + " WHERE biz_tag = ?",
+	}, nil
+}
+
+// NextRange 在事务中推进max_id并读取新值
+// 实现IRangeStore接口
+func (s *SQLStore) NextRange(bizTag string, step int64) (int64, error) {
+	// 步骤1：开启事务，保证更新与读取的原子性
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// 步骤2：推进max_id（行锁保证多实例间号段不重叠）
+	result, err := tx.Exec(s.updateStmt, step, bizTag)
+	if err != nil {
+		return 0, err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return 0, fmt.Errorf("biz tag '%s' not found in number range table", bizTag)
+	}
+
+	// 步骤3：读取推进后的max_id
+	var maxID int64
+	if err := tx.QueryRow(s.selectStmt, bizTag).Scan(&maxID); err != nil {
+		return 0, err
+	}
+
+	// 步骤4：提交事务
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return maxID, nil
+}
+
+// MemoryStore 基于内存的号段存储实现
+// 说明：仅适用于单进程测试和本地开发，进程重启后号段会重新开始
+type MemoryStore struct {
+	maxIDs map[string]int64 // 业务标签 -> 当前最大ID
+	mu     sync.Mutex       // 互斥锁，保护maxIDs
+}
+
+// NewMemoryStore 创建内存号段存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		maxIDs: make(map[string]int64),
+	}
+}
+
+// NextRange 推进内存中的max_id
+// 实现IRangeStore接口
+func (s *MemoryStore) NextRange(bizTag string, step int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maxIDs[bizTag] += step
+	return s.maxIDs[bizTag], nil
+}
@@ -0,0 +1,41 @@
+package segment
+
+import (
+	"fmt"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// Validator 号段ID验证器
+// 说明：号段ID不携带元信息，只能校验取值范围
+type Validator struct{}
+
+// NewValidator 创建新的验证器实例
+func NewValidator() core.IIDValidator {
+	return &Validator{}
+}
+
+// Validate 验证号段ID的有效性
+// 实现core.IDValidator接口
+func (v *Validator) Validate(id int64) error {
+	if id <= 0 {
+		return fmt.Errorf("%w: got %d", core.ErrInvalidSegmentID, id)
+	}
+	return nil
+}
+
+// ValidateBatch 批量验证ID
+// 实现core.IDValidator接口
+func (v *Validator) ValidateBatch(ids []int64) error {
+	if ids == nil {
+		return fmt.Errorf("ids slice cannot be nil")
+	}
+
+	for i, id := range ids {
+		if err := v.Validate(id); err != nil {
+			return fmt.Errorf("invalid ID at index %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
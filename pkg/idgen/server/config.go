@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"katydid-common-account/pkg/idgen/core"
+	"katydid-common-account/pkg/idgen/registry"
+)
+
+// ============================================================================
+// 发号服务配置
+// ============================================================================
+
+// defaultMaxBatch 默认单次批量发号的最大数量
+const defaultMaxBatch = 1000
+
+// Operation 发号服务操作
+type Operation string
+
+const (
+	// OperationNextID 生成单个ID
+	OperationNextID Operation = "next_id"
+
+	// OperationNextIDs 批量生成ID
+	OperationNextIDs Operation = "next_ids"
+
+	// OperationParse 解析ID
+	OperationParse Operation = "parse"
+)
+
+// Request 发号请求（鉴权和限流钩子的输入）
+type Request struct {
+	Operation   Operation // 请求的操作
+	Key         string    // 生成器键（已解析默认键）
+	Count       int       // 请求的ID数量（解析时为0）
+	Credentials string    // 调用方凭证（HTTP的Authorization头，gRPC的authorization元数据）
+}
+
+// Authenticator 鉴权钩子
+// 说明：返回错误时拒绝请求；返回的错误应包装 core.ErrUnauthenticated，否则按内部错误处理
+type Authenticator func(ctx context.Context, req *Request) error
+
+// RateLimiter 限流钩子
+type RateLimiter interface {
+	// Allow 是否允许请求（批量请求按ID数量计）
+	Allow(ctx context.Context, req *Request) bool
+}
+
+// Config 发号服务配置
+type Config struct {
+	// Registry 生成器注册表
+	// 默认值：registry.GetRegistry()
+	Registry *registry.Registry
+
+	// Keys 对外开放的生成器键（必填）
+	// 说明：命名空间内的生成器使用"命名空间/键"的形式；只开放列出的键，其他键返回 core.ErrKeyNotExposed
+	Keys []string
+
+	// DefaultKey 请求未指定键时使用的键
+	// 默认值：只开放一个键时为该键，否则请求必须指定键
+	DefaultKey string
+
+	// MaxBatch 单次批量发号的最大数量
+	// 默认值：1000
+	MaxBatch int
+
+	// Authenticator 鉴权钩子（可选，nil表示不鉴权）
+	Authenticator Authenticator
+
+	// RateLimiter 限流钩子（可选，nil表示不限流）
+	RateLimiter RateLimiter
+}
+
+// validate 验证配置并设置默认值
+func (c *Config) validate() error {
+	if len(c.Keys) == 0 {
+		return fmt.Errorf("%w: at least one key must be exposed", core.ErrNilConfig)
+	}
+	if c.Registry == nil {
+		c.Registry = registry.GetRegistry()
+	}
+	if c.MaxBatch <= 0 {
+		c.MaxBatch = defaultMaxBatch
+	}
+	if c.DefaultKey == "" && len(c.Keys) == 1 {
+		c.DefaultKey = c.Keys[0]
+	}
+	return nil
+}
+
+// ============================================================================
+// 令牌桶限流
+// ============================================================================
+
+// TokenBucket 令牌桶限流器（所有请求共享一个桶）
+// 说明：批量请求按ID数量消耗令牌；需要按调用方限流时可实现 RateLimiter，以 Request.Credentials 分桶
+type TokenBucket struct {
+	rate   float64    // 每秒补充的令牌数
+	burst  float64    // 桶容量
+	tokens float64    // 当前令牌数
+	last   time.Time  // 上次补充令牌的时间
+	mu     sync.Mutex // 保护令牌数
+}
+
+// NewTokenBucket 创建令牌桶限流器
+// 参数：rate 每秒发放的ID数量，burst 允许的突发数量（同时是单次请求的上限）
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Allow 实现 RateLimiter 接口
+func (b *TokenBucket) Allow(_ context.Context, req *Request) bool {
+	cost := float64(max(req.Count, 1))
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+	}
+	b.last = now
+
+	if b.tokens < cost {
+		return false
+	}
+	b.tokens -= cost
+	return true
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// ============================================================================
+// HTTP适配
+// ============================================================================
+
+// NewHTTPHandler 将发号服务暴露为HTTP接口
+//
+// 路由（挂载到子路径时配合 http.StripPrefix 使用）：
+//   - POST /next?key=order           -> {"id":"1234567890"}
+//   - POST /batch?key=order&count=10 -> {"ids":["...","..."]}
+//   - GET  /parse?key=order&id=123   -> {"id":"123","timestamp":...,"datacenter_id":...,"worker_id":...,"sequence":...}
+//
+// 说明：
+//   - ID以字符串输出，避免JavaScript等语言的精度丢失；key省略时使用 Config.DefaultKey
+//   - Authorization 请求头作为 Request.Credentials 传给鉴权钩子
+//   - 错误响应为 {"error":"...","code":"..."}，状态码见 HTTPStatus；5xx错误只返回状态文本
+func NewHTTPHandler(service *Service) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/next", func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r, http.MethodPost) {
+			return
+		}
+		id, err := service.NextID(r.Context(), r.FormValue("key"), r.Header.Get("Authorization"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"id": strconv.FormatInt(id, 10)})
+	})
+	mux.HandleFunc("/batch", func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r, http.MethodPost) {
+			return
+		}
+		count, err := strconv.Atoi(r.FormValue("count"))
+		if err != nil {
+			writeError(w, core.ErrInvalidBatchSize)
+			return
+		}
+		ids, err := service.NextIDs(r.Context(), r.FormValue("key"), count, r.Header.Get("Authorization"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		encoded := make([]string, len(ids))
+		for i, id := range ids {
+			encoded[i] = strconv.FormatInt(id, 10)
+		}
+		writeJSON(w, http.StatusOK, map[string][]string{"ids": encoded})
+	})
+	mux.HandleFunc("/parse", func(w http.ResponseWriter, r *http.Request) {
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+		id, err := strconv.ParseInt(r.FormValue("id"), 10, 64)
+		if err != nil {
+			writeError(w, core.ErrInvalidSnowflakeID)
+			return
+		}
+		info, err := service.Parse(r.Context(), r.FormValue("key"), id, r.Header.Get("Authorization"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, idInfoJSON{
+			ID:           strconv.FormatInt(info.ID, 10),
+			Timestamp:    info.Timestamp,
+			DatacenterID: info.DatacenterID,
+			WorkerID:     info.WorkerID,
+			Sequence:     info.Sequence,
+		})
+	})
+	return mux
+}
+
+// idInfoJSON 解析结果的JSON结构
+type idInfoJSON struct {
+	ID           string `json:"id"`
+	Timestamp    int64  `json:"timestamp"`
+	DatacenterID int64  `json:"datacenter_id"`
+	WorkerID     int64  `json:"worker_id"`
+	Sequence     int64  `json:"sequence"`
+}
+
+// HTTPStatus 发号服务错误对应的HTTP状态码和错误码
+// 说明：gRPC适配可按同样的分类映射为 Unauthenticated、ResourceExhausted、NotFound、InvalidArgument、Unavailable
+func HTTPStatus(err error) (status int, code string) {
+	switch {
+	case errors.Is(err, core.ErrUnauthenticated):
+		return http.StatusUnauthorized, "unauthenticated"
+	case errors.Is(err, core.ErrRateLimited):
+		return http.StatusTooManyRequests, "rate_limited"
+	case errors.Is(err, core.ErrKeyNotExposed), errors.Is(err, core.ErrGeneratorNotFound):
+		return http.StatusNotFound, "not_found"
+	case errors.Is(err, core.ErrInvalidBatchSize), errors.Is(err, core.ErrInvalidSnowflakeID),
+		errors.Is(err, core.ErrInvalidSegmentID), errors.Is(err, core.ErrInvalidMockID),
+		errors.Is(err, core.ErrInvalidKey), errors.Is(err, core.ErrInvalidKeyFormat):
+		return http.StatusBadRequest, "invalid_argument"
+	case errors.Is(err, core.ErrGeneratorClosed), errors.Is(err, core.ErrClockMovedBackwards),
+		errors.Is(err, core.ErrSegmentLoadFailed), errors.Is(err, core.ErrMockSequenceExhausted):
+		return http.StatusServiceUnavailable, "unavailable"
+	default:
+		return http.StatusInternalServerError, "internal"
+	}
+}
+
+// allowMethod 检查请求方法，不允许时写入405响应
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed", "code": "method_not_allowed"})
+	return false
+}
+
+// writeError 写入错误响应
+// 说明：服务端错误（5xx）只返回状态文本和错误码，原始错误只写日志，避免泄露内部信息
+func writeError(w http.ResponseWriter, err error) {
+	status, code := HTTPStatus(err)
+	message := err.Error()
+	if status >= http.StatusInternalServerError {
+		log.Println("发号请求失败", "code", code, "error", err)
+		message = http.StatusText(status)
+	}
+	writeJSON(w, status, map[string]string{"error": message, "code": code})
+}
+
+// writeJSON 写入JSON响应
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
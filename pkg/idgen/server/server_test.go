@@ -0,0 +1,218 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"katydid-common-account/pkg/idgen/core"
+	"katydid-common-account/pkg/idgen/registry"
+	"katydid-common-account/pkg/idgen/server"
+	"katydid-common-account/pkg/idgen/snowflake"
+)
+
+// newTestService 创建开放 "order" 和 "tenant/invoice" 的发号服务
+func newTestService(t *testing.T, config server.Config) *server.Service {
+	t.Helper()
+	r := registry.GetRegistry()
+	t.Cleanup(r.Clear)
+
+	if _, err := r.Create("order", core.GeneratorTypeSnowflake, &snowflake.Config{DatacenterID: 1, WorkerID: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Namespace("tenant").Create("invoice", core.GeneratorTypeSnowflake, &snowflake.Config{DatacenterID: 3, WorkerID: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Create("internal", core.GeneratorTypeSnowflake, &snowflake.Config{DatacenterID: 5, WorkerID: 6}); err != nil {
+		t.Fatal(err)
+	}
+
+	config.Registry = r
+	if config.Keys == nil {
+		config.Keys = []string{"order", "tenant/invoice"}
+	}
+	service, err := server.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+// TestService_Issue 测试发号、解析和键的开放范围
+func TestService_Issue(t *testing.T) {
+	service := newTestService(t, server.Config{DefaultKey: "order", MaxBatch: 10})
+	ctx := context.Background()
+
+	id, err := service.NextID(ctx, "", "")
+	if err != nil {
+		t.Fatalf("NextID() error = %v", err)
+	}
+	info, err := service.Parse(ctx, "order", id, "")
+	if err != nil || info.DatacenterID != 1 || info.WorkerID != 2 {
+		t.Errorf("Parse() = %+v, %v", info, err)
+	}
+
+	ids, err := service.NextIDs(ctx, "tenant/invoice", 5, "")
+	if err != nil || len(ids) != 5 {
+		t.Fatalf("NextIDs() = %v, %v", ids, err)
+	}
+	if info, _ := service.Parse(ctx, "tenant/invoice", ids[0], ""); info.WorkerID != 4 {
+		t.Errorf("命名空间生成器解析 = %+v", info)
+	}
+
+	if _, err := service.NextIDs(ctx, "order", 11, ""); !errors.Is(err, core.ErrInvalidBatchSize) {
+		t.Errorf("超过 MaxBatch error = %v", err)
+	}
+	if _, err := service.NextID(ctx, "internal", ""); !errors.Is(err, core.ErrKeyNotExposed) {
+		t.Errorf("未开放的键 error = %v", err)
+	}
+
+	if _, err := server.New(server.Config{}); err == nil {
+		t.Error("未开放任何键时应返回错误")
+	}
+	if _, err := server.New(server.Config{Keys: []string{"order"}, DefaultKey: "internal"}); !errors.Is(err, core.ErrKeyNotExposed) {
+		t.Errorf("默认键未开放 error = %v", err)
+	}
+}
+
+// TestService_Hooks 测试鉴权和限流钩子
+func TestService_Hooks(t *testing.T) {
+	var seen []server.Request
+	service := newTestService(t, server.Config{
+		Keys: []string{"order"},
+		Authenticator: func(ctx context.Context, req *server.Request) error {
+			seen = append(seen, *req)
+			if req.Credentials != "Bearer secret" {
+				return fmt.Errorf("%w: bad token", core.ErrUnauthenticated)
+			}
+			return nil
+		},
+		RateLimiter: server.NewTokenBucket(0, 3),
+	})
+	ctx := context.Background()
+
+	if _, err := service.NextID(ctx, "", "Bearer wrong"); !errors.Is(err, core.ErrUnauthenticated) {
+		t.Errorf("错误凭证 error = %v", err)
+	}
+	if _, err := service.NextIDs(ctx, "", 2, "Bearer secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.NextIDs(ctx, "", 2, "Bearer secret"); !errors.Is(err, core.ErrRateLimited) {
+		t.Errorf("超出令牌 error = %v", err)
+	}
+	if _, err := service.NextID(ctx, "", "Bearer secret"); err != nil {
+		t.Errorf("剩余令牌 error = %v", err)
+	}
+
+	want := server.Request{Operation: server.OperationNextIDs, Key: "order", Count: 2, Credentials: "Bearer secret"}
+	if len(seen) != 4 || seen[1] != want {
+		t.Errorf("鉴权钩子收到 %+v", seen)
+	}
+
+	// 先鉴权：未通过鉴权时不暴露键是否开放、批量上限等信息
+	if _, err := service.NextID(ctx, "internal", "Bearer wrong"); !errors.Is(err, core.ErrUnauthenticated) {
+		t.Errorf("未鉴权访问未开放的键 error = %v", err)
+	}
+	if _, err := service.NextIDs(ctx, "", 1<<20, "Bearer wrong"); !errors.Is(err, core.ErrUnauthenticated) {
+		t.Errorf("未鉴权的超大批量 error = %v", err)
+	}
+}
+
+// TestHTTPHandler 测试HTTP接口
+func TestHTTPHandler(t *testing.T) {
+	service := newTestService(t, server.Config{
+		Authenticator: func(ctx context.Context, req *server.Request) error {
+			switch req.Credentials {
+			case "":
+				return core.ErrUnauthenticated
+			case "Bearer broken":
+				return errors.New("auth backend: dial tcp 10.0.0.7:5432: connection refused")
+			}
+			return nil
+		},
+	})
+	ts := httptest.NewServer(http.StripPrefix("/ids", server.NewHTTPHandler(service)))
+	defer ts.Close()
+
+	doAs := func(method, path, credentials string, out any) int {
+		t.Helper()
+		req, _ := http.NewRequest(method, ts.URL+path, nil)
+		if credentials != "" {
+			req.Header.Set("Authorization", credentials)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return resp.StatusCode
+	}
+	do := func(method, path string, auth bool, out any) int {
+		t.Helper()
+		credentials := ""
+		if auth {
+			credentials = "Bearer token"
+		}
+		return doAs(method, path, credentials, out)
+	}
+
+	var next struct{ ID string }
+	if status := do(http.MethodPost, "/ids/next?key=order", true, &next); status != http.StatusOK || next.ID == "" {
+		t.Fatalf("next = %d %+v", status, next)
+	}
+
+	var batch struct{ IDs []string }
+	if status := do(http.MethodPost, "/ids/batch?key=tenant/invoice&count=3", true, &batch); status != http.StatusOK || len(batch.IDs) != 3 {
+		t.Fatalf("batch = %d %+v", status, batch)
+	}
+
+	var parsed struct {
+		ID       string `json:"id"`
+		WorkerID int64  `json:"worker_id"`
+	}
+	if status := do(http.MethodGet, "/ids/parse?key=order&id="+next.ID, true, &parsed); status != http.StatusOK ||
+		parsed.ID != next.ID || parsed.WorkerID != 2 {
+		t.Errorf("parse = %d %+v", status, parsed)
+	}
+
+	var failure struct{ Error, Code string }
+	tests := []struct {
+		method, path string
+		auth         bool
+		status       int
+		code         string
+	}{
+		{http.MethodPost, "/ids/next?key=order", false, http.StatusUnauthorized, "unauthenticated"},
+		{http.MethodPost, "/ids/next?key=internal", false, http.StatusUnauthorized, "unauthenticated"}, // 未鉴权不暴露键是否开放
+		{http.MethodPost, "/ids/next", true, http.StatusNotFound, "not_found"},                         // 开放多个键时必须指定键
+		{http.MethodPost, "/ids/batch?key=order&count=x", true, http.StatusBadRequest, "invalid_argument"},
+		{http.MethodGet, "/ids/next?key=order", true, http.StatusMethodNotAllowed, "method_not_allowed"},
+		{http.MethodGet, "/ids/parse?key=order&id=" + strconv.Itoa(-1), true, http.StatusBadRequest, "invalid_argument"},
+	}
+	for _, tt := range tests {
+		if status := do(tt.method, tt.path, tt.auth, &failure); status != tt.status || failure.Code != tt.code {
+			t.Errorf("%s %s = %d %+v, want %d %s", tt.method, tt.path, status, failure, tt.status, tt.code)
+		}
+	}
+	if !strings.Contains(failure.Error, "invalid") {
+		t.Errorf("error message = %q", failure.Error)
+	}
+
+	// 内部错误只返回状态文本，不泄露原始错误
+	var internal struct{ Error, Code string }
+	if status := doAs(http.MethodPost, "/ids/next?key=order", "Bearer broken", &internal); status != http.StatusInternalServerError ||
+		internal.Code != "internal" || internal.Error != http.StatusText(http.StatusInternalServerError) {
+		t.Errorf("internal error = %d %+v", status, internal)
+	}
+}
@@ -0,0 +1,99 @@
+// Package server 发号服务适配器
+//
+// 无法嵌入Go生成器的服务（其他语言实现）通过网络调用发号：
+//   - Service 与传输协议无关，负责键的开放范围、鉴权和限流
+//   - NewHTTPHandler 将 Service 暴露为HTTP接口
+//   - proto/idgen.proto 定义gRPC接口，生成的服务端桩代码直接委托给 Service
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// Service 发号服务
+// 说明：线程安全；生成器在每次请求时从注册表获取，注册表中替换生成器后立即生效
+type Service struct {
+	config  Config
+	exposed map[string]bool
+}
+
+// New 创建发号服务
+func New(config Config) (*Service, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	exposed := make(map[string]bool, len(config.Keys))
+	for _, key := range config.Keys {
+		exposed[key] = true
+	}
+	if config.DefaultKey != "" && !exposed[config.DefaultKey] {
+		return nil, fmt.Errorf("%w: default key '%s'", core.ErrKeyNotExposed, config.DefaultKey)
+	}
+
+	return &Service{config: config, exposed: exposed}, nil
+}
+
+// NextID 生成单个ID
+func (s *Service) NextID(ctx context.Context, key, credentials string) (int64, error) {
+	req := &Request{Operation: OperationNextID, Key: key, Count: 1, Credentials: credentials}
+	generator, err := s.prepare(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	return generator.NextID()
+}
+
+// NextIDs 批量生成ID
+func (s *Service) NextIDs(ctx context.Context, key string, count int, credentials string) ([]int64, error) {
+	req := &Request{Operation: OperationNextIDs, Key: key, Count: count, Credentials: credentials}
+	generator, err := s.prepare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return generator.NextIDBatch(count)
+}
+
+// Parse 解析ID
+func (s *Service) Parse(ctx context.Context, key string, id int64, credentials string) (*core.IDInfo, error) {
+	req := &Request{Operation: OperationParse, Key: key, Credentials: credentials}
+	generator, err := s.prepare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return generator.ParseID(id)
+}
+
+// prepare 鉴权，检查键和批量大小，限流，返回生成器
+// 说明：先鉴权再检查键，未通过鉴权的调用方无法据此探测开放了哪些键
+func (s *Service) prepare(ctx context.Context, req *Request) (core.IGenerator, error) {
+	if req.Key == "" {
+		req.Key = s.config.DefaultKey
+	}
+
+	if s.config.Authenticator != nil {
+		if err := s.config.Authenticator(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if !s.exposed[req.Key] {
+		return nil, fmt.Errorf("%w: key '%s'", core.ErrKeyNotExposed, req.Key)
+	}
+	if req.Operation == OperationNextIDs && (req.Count <= 0 || req.Count > s.config.MaxBatch) {
+		return nil, fmt.Errorf("%w: count must be between 1 and %d, got %d",
+			core.ErrInvalidBatchSize, s.config.MaxBatch, req.Count)
+	}
+	if s.config.RateLimiter != nil && !s.config.RateLimiter.Allow(ctx, req) {
+		return nil, fmt.Errorf("%w: key '%s'", core.ErrRateLimited, req.Key)
+	}
+
+	if namespace, key, ok := strings.Cut(req.Key, "/"); ok {
+		return s.config.Registry.Namespace(namespace).Get(key)
+	}
+	return s.config.Registry.Get(req.Key)
+}
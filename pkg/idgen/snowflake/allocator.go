@@ -0,0 +1,193 @@
+package snowflake
+
+import (
+	"fmt"
+	"sync"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+const (
+	// defaultBlockSize 默认的预分配块大小
+	// 说明：每次向生成器申请的ID数量
+	// 值：1024（约为单毫秒序列号容量的1/4）
+	defaultBlockSize = 1024
+)
+
+// BlockAllocator 预分配ID分配器（环形缓冲模式）
+// 说明：
+//   - 通过生成器的NextIDBatch一次性预取一整块ID放入环形缓冲区
+//   - 缓冲区耗尽时才会访问生成器，锁获取次数降低约blockSize倍
+//   - Block方法可将一段连续ID交给单个goroutine独占使用，取ID时完全无锁
+//
+// 注意：预分配的ID时间戳为预取时刻，与实际使用时刻存在偏差，
+// 对ID时间精度敏感的场景应直接使用生成器
+type BlockAllocator struct {
+	generator core.IBatchGenerator // 底层生成器
+	blockSize int                  // 每次预取的ID数量
+
+	ring  []int64 // 环形缓冲区
+	head  int     // 下一个可用ID的位置
+	count int     // 缓冲区中剩余的ID数量
+
+	mu sync.Mutex // 互斥锁，保护缓冲区
+}
+
+// NewBlockAllocator 创建预分配ID分配器
+// 说明：blockSize<=0时使用默认值1024，最大不超过批量生成上限
+func NewBlockAllocator(generator core.IBatchGenerator, blockSize int) (*BlockAllocator, error) {
+	if generator == nil {
+		return nil, fmt.Errorf("generator cannot be nil")
+	}
+
+	if blockSize <= 0 {
+		blockSize = defaultBlockSize
+	}
+	if blockSize > maxBatchSize {
+		return nil, fmt.Errorf("%w: block size too large (max %d), got %d",
+			core.ErrInvalidBatchSize, maxBatchSize, blockSize)
+	}
+
+	return &BlockAllocator{
+		generator: generator,
+		blockSize: blockSize,
+		ring:      make([]int64, blockSize),
+	}, nil
+}
+
+// NextID 从缓冲区获取下一个ID（线程安全）
+// 实现core.IIDGenerator接口
+func (a *BlockAllocator) NextID() (int64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.count == 0 {
+		if err := a.refillUnsafe(); err != nil {
+			return 0, err
+		}
+	}
+
+	return a.takeUnsafe(), nil
+}
+
+// NextIDs 从缓冲区批量获取ID（线程安全）
+// 说明：
+//   - 缓冲区不足时，不足部分直接向生成器申请
+//   - 向生成器申请失败时返回nil和错误，已从缓冲区取出的ID放回缓冲区，
+//     生成器已生成的部分ID在容量允许时一并放入缓冲区，不会因失败丢失
+func (a *BlockAllocator) NextIDs(n int) ([]int64, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: batch size must be positive, got %d",
+			core.ErrInvalidBatchSize, n)
+	}
+	if n > maxBatchSize {
+		return nil, fmt.Errorf("%w: batch size too large (max %d), got %d",
+			core.ErrInvalidBatchSize, maxBatchSize, n)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ids := make([]int64, 0, n)
+	for len(ids) < n && a.count > 0 {
+		ids = append(ids, a.takeUnsafe())
+	}
+
+	// 缓冲区已耗尽，剩余部分直接从生成器获取
+	if remaining := n - len(ids); remaining > 0 {
+		more, err := a.generator.NextIDBatch(remaining)
+		if err != nil {
+			a.restoreUnsafe(ids, more)
+			return nil, err
+		}
+		ids = append(ids, more...)
+	}
+
+	return ids, nil
+}
+
+// Block 获取一段供单个goroutine独占使用的ID块
+// 说明：返回的IDBlock不是线程安全的，应由调用方goroutine独占使用，
+// 用尽后再次调用Block获取新的块
+func (a *BlockAllocator) Block() (*IDBlock, error) {
+	ids, err := a.NextIDs(a.blockSize)
+	if err != nil {
+		return nil, err
+	}
+	return &IDBlock{ids: ids}, nil
+}
+
+// BlockSize 获取预分配块大小
+func (a *BlockAllocator) BlockSize() int {
+	return a.blockSize
+}
+
+// Buffered 获取缓冲区中剩余的ID数量
+func (a *BlockAllocator) Buffered() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.count
+}
+
+// refillUnsafe 从生成器预取一整块ID填满缓冲区
+// 说明：调用者必须已持有锁，且缓冲区为空
+func (a *BlockAllocator) refillUnsafe() error {
+	ids, err := a.generator.NextIDBatch(a.blockSize)
+	if err != nil {
+		return fmt.Errorf("failed to refill id buffer: %w", err)
+	}
+
+	copy(a.ring, ids)
+	a.head = 0
+	a.count = len(ids)
+	return nil
+}
+
+// takeUnsafe 从缓冲区取出一个ID
+// 说明：调用者必须已持有锁，且缓冲区非空
+func (a *BlockAllocator) takeUnsafe() int64 {
+	id := a.ring[a.head]
+	a.head = (a.head + 1) % len(a.ring)
+	a.count--
+	return id
+}
+
+// restoreUnsafe 将批量获取失败时已取出的ID放回缓冲区
+// 说明：调用者必须已持有锁；taken是刚从缓冲区头部取出的ID（此时缓冲区已空），
+// 放回后保持原顺序，more追加在其后，超出容量的部分丢弃（跳号不影响唯一性）
+func (a *BlockAllocator) restoreUnsafe(taken, more []int64) {
+	a.head = (a.head - len(taken) + len(a.ring)) % len(a.ring)
+	a.count = len(taken)
+
+	for _, id := range more {
+		if a.count == len(a.ring) {
+			return
+		}
+		a.ring[(a.head+a.count)%len(a.ring)] = id
+		a.count++
+	}
+}
+
+// IDBlock 连续ID块
+// 说明：由BlockAllocator.Block创建，非线程安全，供单个goroutine独占使用
+type IDBlock struct {
+	ids []int64 // 块内的ID
+	pos int     // 下一个可用ID的位置
+}
+
+// Next 获取块内的下一个ID
+// 返回值：块已用尽时ok为false
+func (b *IDBlock) Next() (id int64, ok bool) {
+	if b.pos >= len(b.ids) {
+		return 0, false
+	}
+	id = b.ids[b.pos]
+	b.pos++
+	return id, true
+}
+
+// Remaining 获取块内剩余的ID数量
+func (b *IDBlock) Remaining() int {
+	return len(b.ids) - b.pos
+}
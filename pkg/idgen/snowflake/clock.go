@@ -0,0 +1,228 @@
+package snowflake
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// 时钟源
+// ============================================================================
+
+// Clock 生成器使用的时钟源
+// 说明：
+//   - Now 返回Unix毫秒时间戳（UTC），与时区和夏令时无关
+//   - 实现必须可并发调用
+//   - 生产环境可接入PTP等高精度时钟；测试可用 ManualClock / DriftClock 模拟回拨
+type Clock interface {
+	// Now 当前时间（Unix毫秒）
+	Now() int64
+}
+
+// ClockFunc 函数形式的时钟源
+// 示例：snowflake.ClockFunc(ptpClient.NowMillis)
+type ClockFunc func() int64
+
+// Now 实现 Clock 接口
+func (f ClockFunc) Now() int64 {
+	return f()
+}
+
+// WallClock 直接读取系统墙上时间的时钟源
+// 说明：NTP步进校时、闰秒处理、手动改时间都会直接反映为时间跳变，通常只用于对比审计
+var WallClock Clock = ClockFunc(func() int64 {
+	return time.Now().UnixMilli()
+})
+
+// systemClock 进程内共享的默认时钟源
+var systemClock = NewMonotonicClock()
+
+// SystemClock 返回默认时钟源（进程内共享的单调时钟）
+// 说明：同一进程内的生成器共享同一个基准，彼此的时间戳一致
+func SystemClock() Clock {
+	return systemClock
+}
+
+// MonotonicClock 基于单调时钟推算的时钟源（默认）
+// 说明：
+//   - 创建时记录一次墙上时间作为基准，之后按单调时钟的流逝推算当前时间
+//   - 不受NTP步进、闰秒重复的一秒和手动改时间影响，运行期间不会回退
+//   - 代价：基准之后的墙上时间校正不会被跟随；进程启动时系统时间若明显错误，应先完成校时再启动
+type MonotonicClock struct {
+	base      time.Time // 基准时间（含单调时钟读数）
+	baseNanos int64     // 基准的Unix纳秒
+}
+
+// NewMonotonicClock 以当前系统时间为基准创建单调时钟
+func NewMonotonicClock() *MonotonicClock {
+	now := time.Now()
+	return &MonotonicClock{base: now, baseNanos: now.UnixNano()}
+}
+
+// Now 实现 Clock 接口
+func (c *MonotonicClock) Now() int64 {
+	return (c.baseNanos + time.Since(c.base).Nanoseconds()) / int64(time.Millisecond)
+}
+
+// ============================================================================
+// 时钟漂移模拟（测试用）
+// ============================================================================
+
+// ManualClock 手动控制的时钟（测试用）
+// 注意：时间不会自动前进；同一毫秒内的序列号用尽后生成器会一直等待下一毫秒
+type ManualClock struct {
+	now int64
+	mu  sync.Mutex
+}
+
+// NewManualClock 创建停在指定时间（Unix毫秒）的时钟
+func NewManualClock(millis int64) *ManualClock {
+	return &ManualClock{now: millis}
+}
+
+// Now 实现 Clock 接口
+func (c *ManualClock) Now() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Set 设置当前时间（Unix毫秒），可以早于当前值以模拟回拨
+func (c *ManualClock) Set(millis int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = millis
+}
+
+// Advance 前进指定时长（负数表示回拨）
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now += d.Milliseconds()
+}
+
+// DriftClock 在基础时钟上叠加偏移的时钟（测试用）
+// 说明：基础时钟照常前进，Jump 模拟NTP步进、闰秒（回拨1秒）等跳变
+type DriftClock struct {
+	base   Clock
+	offset int64 // 累计偏移（毫秒）
+	mu     sync.Mutex
+}
+
+// NewDriftClock 创建叠加偏移的时钟（base为nil时使用 SystemClock）
+func NewDriftClock(base Clock) *DriftClock {
+	if base == nil {
+		base = SystemClock()
+	}
+	return &DriftClock{base: base}
+}
+
+// Now 实现 Clock 接口
+func (c *DriftClock) Now() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.base.Now() + c.offset
+}
+
+// Jump 使时间跳变指定时长（负数表示回拨），偏移累计生效
+// 示例：Jump(-time.Second) 模拟闰秒时系统时间重复一秒
+func (c *DriftClock) Jump(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.offset += d.Milliseconds()
+}
+
+// Offset 当前累计偏移
+func (c *DriftClock) Offset() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return time.Duration(c.offset) * time.Millisecond
+}
+
+// NewNaiveLocalClock 模拟把本地时间当作UTC返回的错误时钟源（测试用）
+// 说明：读数为基础时钟在loc时区的墙上时间；夏令时切换时表现为±1小时的跳变（秋季回拨）
+func NewNaiveLocalClock(base Clock, loc *time.Location) Clock {
+	if base == nil {
+		base = SystemClock()
+	}
+	return ClockFunc(func() int64 {
+		now := base.Now()
+		_, offset := time.UnixMilli(now).In(loc).Zone()
+		return now + int64(offset)*1000
+	})
+}
+
+// ============================================================================
+// 时钟审计
+// ============================================================================
+
+const (
+	// timezoneOffsetUnit 时区偏移的最小单位（部分时区为整刻钟）
+	timezoneOffsetUnit = 15 * time.Minute
+
+	// timezoneOffsetSlack 判断时区偏移时允许的误差
+	timezoneOffsetSlack = time.Second
+
+	// leapSecondSlack 判断闰秒回拨时允许的误差
+	leapSecondSlack = 100 * time.Millisecond
+)
+
+// ClockAudit 时钟源审计结果
+type ClockAudit struct {
+	Offset            time.Duration // 时钟源与系统UTC时间的差值（正数表示超前）
+	TimezoneSuspect   bool          // 差值接近整刻钟的时区偏移，时钟源可能返回了本地时间
+	Backwards         int           // 采样期间的回退次数
+	MaxBackward       time.Duration // 采样期间的最大回退幅度
+	LeapSecondSuspect bool          // 出现约1秒的回退，可能是闰秒以步进方式处理（建议启用闰秒平滑）
+}
+
+// OK 时钟源是否未发现问题
+func (a ClockAudit) OK() bool {
+	return !a.TimezoneSuspect && a.Backwards == 0
+}
+
+// String 实现Stringer接口，便于日志打印
+func (a ClockAudit) String() string {
+	return fmt.Sprintf("offset=%v timezone_suspect=%t backwards=%d max_backward=%v leap_second_suspect=%t",
+		a.Offset, a.TimezoneSuspect, a.Backwards, a.MaxBackward, a.LeapSecondSuspect)
+}
+
+// AuditClock 审计时钟源的时区安全性和单调性
+// 说明：
+//   - 按interval间隔连续读取samples次，统计回退；samples小于2时只检查时区偏移
+//   - 时区检查与系统墙上时间对比，系统时间本身不准时结果也不准
+//   - 用于上线前检查自定义时钟源，或在闰秒、夏令时切换窗口内巡检
+func AuditClock(clock Clock, samples int, interval time.Duration) ClockAudit {
+	audit := ClockAudit{
+		Offset: time.Duration(clock.Now()-time.Now().UnixMilli()) * time.Millisecond,
+	}
+	if abs := audit.Offset.Abs(); abs >= timezoneOffsetUnit {
+		remainder := abs % timezoneOffsetUnit
+		audit.TimezoneSuspect = remainder <= timezoneOffsetSlack || timezoneOffsetUnit-remainder <= timezoneOffsetSlack
+	}
+
+	last := clock.Now()
+	for i := 1; i < samples; i++ {
+		if interval > 0 {
+			time.Sleep(interval)
+		}
+		now := clock.Now()
+		if now < last {
+			backward := time.Duration(last-now) * time.Millisecond
+			audit.Backwards++
+			audit.MaxBackward = max(audit.MaxBackward, backward)
+			if (backward - time.Second).Abs() <= leapSecondSlack {
+				audit.LeapSecondSuspect = true
+			}
+		}
+		last = now
+	}
+	return audit
+}
@@ -2,6 +2,7 @@ package snowflake
 
 import (
 	"fmt"
+	"time"
 
 	"katydid-common-account/pkg/idgen/core"
 )
@@ -13,12 +14,12 @@ import (
 // Config Snowflake生成器配置
 type Config struct {
 	// DatacenterID 数据中心ID
-	// 范围：0-31（5位二进制）
+	// 范围：0-31（默认布局5位二进制，自定义布局时为 0 到 2^DatacenterBits-1）
 	// 用途：标识不同的数据中心，避免跨数据中心ID冲突
 	DatacenterID int64
 
 	// WorkerID 工作机器ID
-	// 范围：0-31（5位二进制）
+	// 范围：0-31（默认布局5位二进制，自定义布局时为 0 到 2^WorkerBits-1）
 	// 用途：标识同一数据中心内的不同机器，避免同数据中心内ID冲突
 	WorkerID int64
 
@@ -49,20 +50,105 @@ type Config struct {
 	// 默认值：false
 	// 建议：生产环境根据需要开启，测试环境可关闭以提升性能
 	EnableMetrics bool
+
+	// StateStore 状态存储（可选）
+	// 说明：
+	//   - 设置后生成器会定期及在Close时持久化最近发号的时间戳
+	//   - 创建时读取已持久化的时间戳，首次发号前等待时钟越过该时间戳（加一个持久化间隔），创建本身不阻塞
+	//   - 用于防止进程崩溃后在同一毫秒内快速重启导致ID重复
+	//
+	// 默认值：nil（不持久化）
+	StateStore core.IStateStore
+
+	// StateKey 状态存储的键
+	// 默认值：snowflake-{DatacenterID}-{WorkerID}
+	StateKey string
+
+	// PersistInterval 状态持久化间隔
+	// 说明：间隔越短，启动时需要等待的时间越短，但存储写入越频繁
+	// 范围：10ms-1min
+	// 默认值：1s
+	PersistInterval time.Duration
+
+	// EpochMillis 起始时间戳（Unix毫秒）
+	// 说明：不能晚于当前时间；修改后新旧ID不兼容
+	// 默认值：0（使用 Epoch，即 2023-01-01 00:00:00 UTC）
+	EpochMillis int64
+
+	// TimestampBits / DatacenterBits / WorkerBits / SequenceBits 自定义位布局
+	// 说明：
+	//   - 全部为0时使用默认布局（41 | 5 | 5 | 12）
+	//   - 设置任意一项时四项之和必须为63，数据中心ID和工作机器ID位数可以为0
+	//   - DatacenterID / WorkerID 的有效范围随位数变化
+	//
+	// 示例：42位时间戳 + 12位工作机器ID + 9位序列号
+	//   TimestampBits: 42, WorkerBits: 12, SequenceBits: 9
+	TimestampBits  int
+	DatacenterBits int
+	WorkerBits     int
+	SequenceBits   int
+
+	// Obfuscator ID混淆器（可选）
+	// 说明：
+	//   - 设置后 NextID / NextIDBatch 返回混淆后的对外ID，相邻ID无规律，不暴露发号速率
+	//   - ParseID / ValidateID 先还原内部ID再处理；内部ID可通过 Generator.InternalID 获取
+	//   - 对外ID不再按时间递增，需要排序或时间范围查询时使用内部ID
+	//   - 上线后不可更换混淆器或密钥，否则已发出的ID无法还原
+	//
+	// 默认值：nil（不混淆）
+	// 示例：obfuscator, _ := snowflake.NewFeistelObfuscator(secret)
+	Obfuscator core.IIDObfuscator
+
+	// Clock 时钟源（可选）
+	// 说明：
+	//   - 返回Unix毫秒时间戳，发号、时钟回拨检测、启动等待和健康检查均使用该时钟
+	//   - 生产环境可接入PTP等高精度时钟（snowflake.ClockFunc）
+	//   - 测试可用 ManualClock / DriftClock 模拟时钟回拨、闰秒和夏令时问题
+	//
+	// 默认值：SystemClock()（基于单调时钟推算，不受系统时间跳变影响）
+	Clock Clock
+}
+
+// Layout 返回配置对应的ID位布局
+func (c *Config) Layout() Layout {
+	layout := DefaultLayout()
+	if c.TimestampBits != 0 || c.DatacenterBits != 0 || c.WorkerBits != 0 || c.SequenceBits != 0 {
+		layout.TimestampBits = c.TimestampBits
+		layout.DatacenterBits = c.DatacenterBits
+		layout.WorkerBits = c.WorkerBits
+		layout.SequenceBits = c.SequenceBits
+	}
+	if c.EpochMillis != 0 {
+		layout.EpochMillis = c.EpochMillis
+	}
+	return layout
 }
 
 // Validate 验证配置的有效性
 func (c *Config) Validate() error {
+	// 验证位布局
+	layout := c.Layout()
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+
 	// 验证数据中心ID
-	if c.DatacenterID < 0 || c.DatacenterID > MaxDatacenterID {
+	if c.DatacenterID < 0 || c.DatacenterID > layout.MaxDatacenterID() {
 		return fmt.Errorf("%w: got %d, valid range [0, %d]",
-			core.ErrInvalidDatacenterID, c.DatacenterID, MaxDatacenterID)
+			core.ErrInvalidDatacenterID, c.DatacenterID, layout.MaxDatacenterID())
 	}
 
 	// 验证工作机器ID
-	if c.WorkerID < 0 || c.WorkerID > MaxWorkerID {
+	if c.WorkerID < 0 || c.WorkerID > layout.MaxWorkerID() {
 		return fmt.Errorf("%w: got %d, valid range [0, %d]",
-			core.ErrInvalidWorkerID, c.WorkerID, MaxWorkerID)
+			core.ErrInvalidWorkerID, c.WorkerID, layout.MaxWorkerID())
+	}
+
+	// 验证状态持久化间隔（0表示使用默认值）
+	if c.PersistInterval != 0 &&
+		(c.PersistInterval < minPersistInterval || c.PersistInterval > maxPersistInterval) {
+		return fmt.Errorf("persist interval must be between %v and %v, got %v",
+			minPersistInterval, maxPersistInterval, c.PersistInterval)
 	}
 
 	// 验证时钟回拨容忍时间（不能为负数）
@@ -88,6 +174,21 @@ func (c *Config) SetDefaults() {
 		c.ClockBackwardTolerance = maxClockBackwardTolerance
 	}
 
+	// 设置状态持久化的默认值（仅在启用状态存储时有意义）
+	if c.StateStore != nil {
+		if c.StateKey == "" {
+			c.StateKey = fmt.Sprintf("snowflake-%d-%d", c.DatacenterID, c.WorkerID)
+		}
+		if c.PersistInterval == 0 {
+			c.PersistInterval = defaultPersistInterval
+		}
+	}
+
+	// 设置时钟源的默认值
+	if c.Clock == nil {
+		c.Clock = SystemClock()
+	}
+
 	// 注意：ClockBackwardStrategy的零值是StrategyError，这是合理的默认值
 	// 因此无需显式设置
 }
@@ -101,5 +202,15 @@ func (c *Config) Clone() *Config {
 		ClockBackwardStrategy:  c.ClockBackwardStrategy,
 		ClockBackwardTolerance: c.ClockBackwardTolerance,
 		EnableMetrics:          c.EnableMetrics,
+		StateStore:             c.StateStore,
+		StateKey:               c.StateKey,
+		PersistInterval:        c.PersistInterval,
+		EpochMillis:            c.EpochMillis,
+		TimestampBits:          c.TimestampBits,
+		DatacenterBits:         c.DatacenterBits,
+		WorkerBits:             c.WorkerBits,
+		SequenceBits:           c.SequenceBits,
+		Obfuscator:             c.Obfuscator,
+		Clock:                  c.Clock,
 	}
 }
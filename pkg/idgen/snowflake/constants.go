@@ -114,3 +114,20 @@ const (
 	//   - 容忍服务器之间的时钟偏差
 	maxFutureTimeTolerance = 60 * 1000
 )
+
+const (
+	// defaultPersistInterval 默认状态持久化间隔
+	defaultPersistInterval = time.Second
+
+	// minPersistInterval 状态持久化间隔下限
+	// 目的：防止过于频繁地写入存储
+	minPersistInterval = 10 * time.Millisecond
+
+	// maxPersistInterval 状态持久化间隔上限
+	// 目的：启动时最多需要等待一个间隔，间隔过大会拖慢启动
+	maxPersistInterval = time.Minute
+
+	// maxStartupWait 启动时等待越过已持久化时间戳的最长时间
+	// 说明：超过该值说明时钟严重回拨或状态数据异常，直接拒绝启动
+	maxStartupWait = maxPersistInterval + 5*time.Second
+)
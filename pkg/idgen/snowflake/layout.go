@@ -0,0 +1,119 @@
+package snowflake
+
+import (
+	"fmt"
+	"time"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// ============================================================================
+// ID位布局
+// ============================================================================
+
+// idBits ID可用位数（最高位为符号位，不使用）
+const idBits = 63
+
+// Layout Snowflake ID位布局描述
+// 说明：
+//   - 从高位到低位依次为：时间戳 | 数据中心ID | 工作机器ID | 序列号
+//   - 四部分位数之和必须为63
+//   - 生成和解析必须使用相同的布局，否则解析结果错误
+//
+// 示例：42位时间戳 + 12位工作机器ID + 9位序列号（不区分数据中心）
+//
+//	Layout{EpochMillis: snowflake.Epoch, TimestampBits: 42, WorkerBits: 12, SequenceBits: 9}
+type Layout struct {
+	EpochMillis    int64 // 起始时间戳（Unix毫秒）
+	TimestampBits  int   // 时间戳位数
+	DatacenterBits int   // 数据中心ID位数（可以为0）
+	WorkerBits     int   // 工作机器ID位数（可以为0）
+	SequenceBits   int   // 序列号位数
+}
+
+// DefaultLayout 默认布局：41位时间戳 | 5位数据中心ID | 5位工作机器ID | 12位序列号
+func DefaultLayout() Layout {
+	return Layout{
+		EpochMillis:    Epoch,
+		TimestampBits:  idBits - TimestampShift,
+		DatacenterBits: DatacenterIDBits,
+		WorkerBits:     WorkerIDBits,
+		SequenceBits:   SequenceBits,
+	}
+}
+
+// Validate 验证布局的有效性
+func (l Layout) Validate() error {
+	if l.TimestampBits <= 0 || l.SequenceBits <= 0 || l.DatacenterBits < 0 || l.WorkerBits < 0 {
+		return fmt.Errorf("%w: timestamp and sequence bits must be positive, datacenter and worker bits must be non-negative, got %s",
+			core.ErrInvalidLayout, l)
+	}
+	if total := l.TimestampBits + l.DatacenterBits + l.WorkerBits + l.SequenceBits; total != idBits {
+		return fmt.Errorf("%w: bits must total %d, got %d (%s)",
+			core.ErrInvalidLayout, idBits, total, l)
+	}
+	if l.EpochMillis < 0 || l.EpochMillis > time.Now().UnixMilli() {
+		return fmt.Errorf("%w: epoch must be between 0 and now, got %d",
+			core.ErrInvalidLayout, l.EpochMillis)
+	}
+	return nil
+}
+
+// String 实现Stringer接口，便于日志打印和调试
+func (l Layout) String() string {
+	return fmt.Sprintf("epoch=%d timestamp=%d datacenter=%d worker=%d sequence=%d",
+		l.EpochMillis, l.TimestampBits, l.DatacenterBits, l.WorkerBits, l.SequenceBits)
+}
+
+// WorkerShift 工作机器ID的左移位数
+func (l Layout) WorkerShift() int {
+	return l.SequenceBits
+}
+
+// DatacenterShift 数据中心ID的左移位数
+func (l Layout) DatacenterShift() int {
+	return l.SequenceBits + l.WorkerBits
+}
+
+// TimestampShift 时间戳的左移位数
+func (l Layout) TimestampShift() int {
+	return l.SequenceBits + l.WorkerBits + l.DatacenterBits
+}
+
+// MaxDatacenterID 数据中心ID的最大值
+func (l Layout) MaxDatacenterID() int64 {
+	return -1 ^ (-1 << l.DatacenterBits)
+}
+
+// MaxWorkerID 工作机器ID的最大值
+func (l Layout) MaxWorkerID() int64 {
+	return -1 ^ (-1 << l.WorkerBits)
+}
+
+// MaxSequence 序列号的最大值
+func (l Layout) MaxSequence() int64 {
+	return -1 ^ (-1 << l.SequenceBits)
+}
+
+// MaxTimestampDiff 时间戳部分可表示的最大值（相对Epoch的毫秒数）
+func (l Layout) MaxTimestampDiff() int64 {
+	return -1 ^ (-1 << l.TimestampBits)
+}
+
+// Compose 按布局组装ID（timestamp为Unix毫秒）
+// 说明：不做范围检查，调用者需保证各部分在有效范围内
+func (l Layout) Compose(timestamp, datacenterID, workerID, sequence int64) int64 {
+	return (timestamp-l.EpochMillis)<<l.TimestampShift() |
+		datacenterID<<l.DatacenterShift() |
+		workerID<<l.WorkerShift() |
+		sequence
+}
+
+// Decompose 按布局拆分ID（timestamp为Unix毫秒）
+func (l Layout) Decompose(id int64) (timestamp, datacenterID, workerID, sequence int64) {
+	timestamp = (id >> l.TimestampShift()) + l.EpochMillis
+	datacenterID = (id >> l.DatacenterShift()) & l.MaxDatacenterID()
+	workerID = (id >> l.WorkerShift()) & l.MaxWorkerID()
+	sequence = id & l.MaxSequence()
+	return
+}
@@ -0,0 +1,121 @@
+package snowflake
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// ============================================================================
+// ID混淆（Feistel置换）
+// ============================================================================
+
+const (
+	// feistelRounds Feistel轮数（必须为偶数，保证左右两部分位宽还原）
+	feistelRounds = 6
+
+	// feistelLeftBits / feistelRightBits 63位ID拆分为31位和32位两部分
+	feistelLeftBits  = 31
+	feistelRightBits = idBits - feistelLeftBits
+)
+
+// FeistelObfuscator 基于密钥的63位Feistel置换
+// 说明：
+//   - 在正整数范围[1, 2^63)内一一映射，结果仍为合法的正int64
+//   - 相邻ID的变换结果无规律，对外不暴露发号速率；持有密钥的服务端可以还原内部ID
+//   - 实例创建后只读，可并发使用
+//
+// 注意：这是混淆而非加密，不能替代访问控制；密钥变更后旧ID无法还原，上线后不可随意修改
+type FeistelObfuscator struct {
+	keys [feistelRounds]uint64 // 轮密钥
+}
+
+// NewFeistelObfuscator 由密钥创建Feistel混淆器
+func NewFeistelObfuscator(secret string) (*FeistelObfuscator, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("obfuscator secret cannot be empty")
+	}
+
+	sum := sha256.Sum256([]byte(secret))
+	o := &FeistelObfuscator{}
+	for i := range o.keys {
+		// 32字节摘要循环派生轮密钥，轮序号参与混合避免轮密钥重复
+		offset := (i % 4) * 8
+		o.keys[i] = mix64(binary.BigEndian.Uint64(sum[offset:offset+8]) + uint64(i))
+	}
+	return o, nil
+}
+
+// Obfuscate 将内部ID变换为对外ID
+// 实现core.IIDObfuscator接口
+// 说明：非正数原样返回；置换结果为0时继续置换（循环行走），保证结果为正数
+func (o *FeistelObfuscator) Obfuscate(id int64) int64 {
+	if id <= 0 {
+		return id
+	}
+	value := uint64(id)
+	for {
+		value = o.encrypt(value)
+		if value != 0 {
+			return int64(value)
+		}
+	}
+}
+
+// Deobfuscate 将对外ID还原为内部ID
+// 实现core.IIDObfuscator接口
+func (o *FeistelObfuscator) Deobfuscate(id int64) int64 {
+	if id <= 0 {
+		return id
+	}
+	value := uint64(id)
+	for {
+		value = o.decrypt(value)
+		if value != 0 {
+			return int64(value)
+		}
+	}
+}
+
+// encrypt 非平衡Feistel正向置换
+// 说明：每轮 (L, R) -> (R, L ^ F(R))，左右位宽交替为31/32位，偶数轮后还原
+func (o *FeistelObfuscator) encrypt(value uint64) uint64 {
+	leftBits, rightBits := feistelLeftBits, feistelRightBits
+	left, right := value>>rightBits, value&mask(rightBits)
+	for i := 0; i < feistelRounds; i++ {
+		left, right = right, (left^o.round(i, right))&mask(leftBits)
+		leftBits, rightBits = rightBits, leftBits
+	}
+	return left<<rightBits | right
+}
+
+// decrypt 非平衡Feistel逆向置换
+func (o *FeistelObfuscator) decrypt(value uint64) uint64 {
+	leftBits, rightBits := feistelLeftBits, feistelRightBits
+	left, right := value>>rightBits, value&mask(rightBits)
+	for i := feistelRounds - 1; i >= 0; i-- {
+		leftBits, rightBits = rightBits, leftBits
+		left, right = (right^o.round(i, left))&mask(leftBits), left
+	}
+	return left<<rightBits | right
+}
+
+// round 轮函数
+func (o *FeistelObfuscator) round(i int, value uint64) uint64 {
+	return mix64(value ^ o.keys[i])
+}
+
+// mask 低n位掩码
+func mask(bits int) uint64 {
+	return 1<<bits - 1
+}
+
+// mix64 SplitMix64 终结函数，将输入充分扩散到64位
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -9,7 +9,9 @@ import (
 
 // Parser Snowflake ID解析器
 type Parser struct {
-	validator core.IIDValidator // 验证器，用于解析前验证ID有效性
+	validator  core.IIDValidator  // 验证器，用于解析前验证ID有效性
+	layout     Layout             // ID位布局
+	obfuscator core.IIDObfuscator // ID混淆器（可选，设置后先还原内部ID再解析）
 }
 
 // ParseID 全局解析函数
@@ -24,38 +26,75 @@ func ParseID(id int64) (timestamp int64, datacenterID int64, workerID int64, seq
 	return
 }
 
-// NewParser 创建新的解析器实例
+// NewParser 创建新的解析器实例（默认布局）
 func NewParser() core.IIDParser {
 	return &Parser{
 		validator: NewValidator(),
+		layout:    DefaultLayout(),
 	}
 }
 
+// NewParserWithLayout 创建使用自定义位布局的解析器
+// 说明：解析自定义布局生成的ID时必须使用相同的布局，否则各部分会错位
+func NewParserWithLayout(layout Layout) (*Parser, error) {
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+	return &Parser{
+		validator: &Validator{layout: layout},
+		layout:    layout,
+	}, nil
+}
+
+// NewParserWithConfig 按生成器配置创建解析器（使用配置的位布局和ID混淆器）
+// 用途：服务端解析对外混淆后的ID，与生成器使用同一份配置即可
+func NewParserWithConfig(config *Config) (*Parser, error) {
+	if config == nil {
+		return nil, core.ErrNilConfig
+	}
+	parser, err := NewParserWithLayout(config.Layout())
+	if err != nil {
+		return nil, err
+	}
+	parser.obfuscator = config.Obfuscator
+	return parser, nil
+}
+
+// Layout 返回解析器使用的位布局
+func (p *Parser) Layout() Layout {
+	return p.layout
+}
+
+// InternalID 返回混淆前的内部ID（未设置混淆器时原样返回）
+// 用途：内部ID按时间递增，可用于排序和时间范围查询
+func (p *Parser) InternalID(id int64) int64 {
+	if p.obfuscator == nil {
+		return id
+	}
+	return p.obfuscator.Deobfuscate(id)
+}
+
 // Parse 解析Snowflake ID，提取完整的元信息
 // 实现core.IDParser接口
+// 说明：设置了混淆器时先还原内部ID，返回的IDInfo.ID仍为传入的ID
 func (p *Parser) Parse(id int64) (*core.IDInfo, error) {
+	external := id
+	id = p.InternalID(id)
+
 	// 步骤1：先验证ID的有效性
 	// 说明：只解析有效的ID，避免返回错误的元信息
 	if err := p.validator.Validate(id); err != nil {
 		return nil, fmt.Errorf("invalid snowflake ID: %w", err)
 	}
 
-	// 步骤2：提取各部分信息（使用位运算）
-	// 时间戳：右移22位，加上Epoch得到Unix毫秒时间戳
-	timestamp := (id >> TimestampShift) + Epoch
-
-	// 数据中心ID：右移17位后与掩码31进行与运算，提取5位
-	datacenterID := (id >> DatacenterIDShift) & MaxDatacenterID
-
-	// 工作机器ID：右移12位后与掩码31进行与运算，提取5位
-	workerID := (id >> WorkerIDShift) & MaxWorkerID
-
-	// 序列号：与掩码4095进行与运算，提取低12位
-	sequence := id & MaxSequence
+	// 步骤2：按布局提取各部分信息（使用位运算）
+	// 默认布局：时间戳右移22位加上Epoch，数据中心ID右移17位取5位，
+	// 工作机器ID右移12位取5位，序列号取低12位
+	timestamp, datacenterID, workerID, sequence := p.layout.Decompose(id)
 
 	// 步骤3：返回完整信息
 	return &core.IDInfo{
-		ID:           id,
+		ID:           external,
 		Timestamp:    timestamp,
 		DatacenterID: datacenterID,
 		WorkerID:     workerID,
@@ -70,8 +109,9 @@ func (p *Parser) ExtractTimestamp(id int64) int64 {
 	if id <= 0 {
 		return 0
 	}
+	id = p.InternalID(id)
 	// 位运算提取时间戳部分并加上Epoch
-	return (id >> TimestampShift) + Epoch
+	return (id >> p.layout.TimestampShift()) + p.layout.EpochMillis
 }
 
 // ExtractTimestampAsTime 从Snowflake ID中提取时间戳并转换为time.Time
@@ -92,8 +132,9 @@ func (p *Parser) ExtractDatacenterID(id int64) int64 {
 	if id <= 0 {
 		return -1
 	}
-	// 位运算提取数据中心ID（右移17位，取低5位）
-	return (id >> DatacenterIDShift) & MaxDatacenterID
+	id = p.InternalID(id)
+	// 位运算提取数据中心ID（默认布局右移17位，取低5位）
+	return (id >> p.layout.DatacenterShift()) & p.layout.MaxDatacenterID()
 }
 
 // ExtractWorkerID 从Snowflake ID中提取工作机器ID
@@ -103,8 +144,9 @@ func (p *Parser) ExtractWorkerID(id int64) int64 {
 	if id <= 0 {
 		return -1
 	}
-	// 位运算提取工作机器ID（右移12位，取低5位）
-	return (id >> WorkerIDShift) & MaxWorkerID
+	id = p.InternalID(id)
+	// 位运算提取工作机器ID（默认布局右移12位，取低5位）
+	return (id >> p.layout.WorkerShift()) & p.layout.MaxWorkerID()
 }
 
 // ExtractSequence 从Snowflake ID中提取序列号
@@ -114,6 +156,7 @@ func (p *Parser) ExtractSequence(id int64) int64 {
 	if id <= 0 {
 		return -1
 	}
-	// 位运算提取序列号（取低12位）
-	return id & MaxSequence
+	id = p.InternalID(id)
+	// 位运算提取序列号（默认布局取低12位）
+	return id & p.layout.MaxSequence()
 }
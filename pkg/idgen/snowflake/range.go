@@ -0,0 +1,100 @@
+package snowflake
+
+import (
+	"fmt"
+	"time"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// ============================================================================
+// 时间范围查询与分片路由
+// ============================================================================
+
+// IDRangeForTime 计算时间窗口[start, end]内可能生成的最小和最大ID（闭区间，毫秒精度，默认布局）
+// 用途：ID按时间递增，仓储可以直接用 id BETWEEN minID AND maxID 做时间范围扫描，无需单独的时间戳索引
+// 说明：
+//   - minID 为 start 所在毫秒的最小ID（机器ID和序列号全为0）
+//   - maxID 为 end 所在毫秒的最大ID（机器ID和序列号全为1）
+//   - start 早于 Epoch 时按 Epoch 计算；end 早于 Epoch、start 晚于 end 或超出时间戳范围时返回错误
+//
+// 示例：
+//
+//	minID, maxID, err := snowflake.IDRangeForTime(dayStart, dayStart.Add(24*time.Hour-time.Millisecond))
+//	db.Where("id BETWEEN ? AND ?", minID, maxID).Find(&orders)
+func IDRangeForTime(start, end time.Time) (minID, maxID int64, err error) {
+	return DefaultLayout().IDRangeForTime(start, end)
+}
+
+// MinIDForTime 返回 t 所在毫秒的最小ID（适合 id >= ? 的查询，默认布局）
+func MinIDForTime(t time.Time) (int64, error) {
+	return DefaultLayout().MinIDForTime(t)
+}
+
+// MachineID 返回ID中的机器编号（数据中心ID和工作机器ID拼接，默认布局下范围0-1023）
+// 说明：无效ID返回-1
+func MachineID(id int64) int64 {
+	return DefaultLayout().MachineID(id)
+}
+
+// ShardFor 按ID中的数据中心ID和工作机器ID计算分片编号（范围[0, n)，默认布局）
+// 用途：同一台机器签发的ID落到同一分片，分片键可以直接从ID推导，无需额外字段
+// 说明：
+//   - 机器编号 = 数据中心ID<<工作机器ID位数 | 工作机器ID，分片编号 = 机器编号 % n
+//   - 机器数量少于分片数时部分分片不会被使用，n 建议不超过实际部署的机器数量
+//   - ID无效或 n <= 0 时返回-1
+func ShardFor(id int64, n int) int {
+	return DefaultLayout().ShardFor(id, n)
+}
+
+// IDRangeForTime 按布局计算时间窗口[start, end]内的最小和最大ID（语义同包级 IDRangeForTime）
+func (l Layout) IDRangeForTime(start, end time.Time) (minID, maxID int64, err error) {
+	startDiff := start.UnixMilli() - l.EpochMillis
+	endDiff := end.UnixMilli() - l.EpochMillis
+
+	if endDiff < 0 || startDiff > endDiff || endDiff > l.MaxTimestampDiff() {
+		return 0, 0, fmt.Errorf("%w: [%s, %s], epoch %d",
+			core.ErrInvalidTimeRange, start.Format(time.RFC3339Nano), end.Format(time.RFC3339Nano), l.EpochMillis)
+	}
+	if startDiff < 0 {
+		startDiff = 0
+	}
+
+	shift := l.TimestampShift()
+	minID = startDiff << shift
+	maxID = endDiff<<shift | (1<<shift - 1)
+	return minID, maxID, nil
+}
+
+// MinIDForTime 按布局返回 t 所在毫秒的最小ID
+// 说明：t 早于 Epoch 时返回0，超出时间戳范围时返回错误
+func (l Layout) MinIDForTime(t time.Time) (int64, error) {
+	diff := t.UnixMilli() - l.EpochMillis
+	if diff < 0 {
+		return 0, nil
+	}
+	if diff > l.MaxTimestampDiff() {
+		return 0, fmt.Errorf("%w: %s, epoch %d", core.ErrInvalidTimeRange, t.Format(time.RFC3339Nano), l.EpochMillis)
+	}
+	return diff << l.TimestampShift(), nil
+}
+
+// MachineID 按布局返回ID中的机器编号，无效ID返回-1
+func (l Layout) MachineID(id int64) int64 {
+	if id <= 0 {
+		return -1
+	}
+	return (id >> l.WorkerShift()) & (-1 ^ (-1 << (l.DatacenterBits + l.WorkerBits)))
+}
+
+// ShardFor 按布局计算分片编号（语义同包级 ShardFor）
+func (l Layout) ShardFor(id int64, n int) int {
+	if n <= 0 {
+		return -1
+	}
+	machineID := l.MachineID(id)
+	if machineID < 0 {
+		return -1
+	}
+	return int(machineID % int64(n))
+}
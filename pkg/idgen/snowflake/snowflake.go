@@ -21,13 +21,31 @@ type Generator struct {
 	config *Config // 生成器配置（依赖倒置：依赖配置抽象）
 
 	// ========== 性能优化 ==========
-	precomputedPart int64 // 预计算的ID部分（datacenterID和workerID），避免重复计算
+	precomputedPart int64  // 预计算的ID部分（datacenterID和workerID），避免重复计算
+	layout          Layout // ID位布局
+	timestampShift  int    // 预计算的时间戳左移位数
+	maxSequence     int64  // 预计算的序列号最大值
+	maxTimeDiff     int64  // 预计算的时间戳部分最大值
+
+	// ========== ID混淆 ==========
+	obfuscator core.IIDObfuscator // ID混淆器（可选，nil时不混淆）
+
+	// ========== 时钟源 ==========
+	clock Clock // 时钟源（默认 SystemClock）
 
 	// ========== 监控和工具 ==========
 	metrics   *Metrics          // 性能监控指标（可选，nil时不收集）
 	validator core.IIDValidator // ID验证器
 	parser    core.IIDParser    // ID解析器
 
+	// ========== 生命周期 ==========
+	closed             bool          // 是否已关闭
+	closeOnce          sync.Once     // 确保只关闭一次
+	stopCh             chan struct{} // 通知持久化goroutine退出
+	persistDone        chan struct{} // 持久化goroutine已退出
+	persistedTimestamp int64         // 最近一次持久化的时间戳
+	readyAt            int64         // 最早可发号的时间戳（重启后首次发号前等待，0表示无需等待）
+
 	// ========== 并发控制 ==========
 	mu sync.Mutex // 互斥锁，保护生成器状态
 }
@@ -57,17 +75,28 @@ func NewWithConfig(config *Config) (core.IGenerator, error) {
 	// 步骤2：设置默认值
 	config.SetDefaults()
 
-	// 步骤3：预先计算datacenterID和workerID部分（性能优化）
+	// 步骤3：启动检查，计算可以安全发号的时间戳（如果启用状态存储）
+	// 说明：只计算不等待，等待推迟到首次发号，避免阻塞创建方（如持有锁的注册表）
+	var readyAt int64
+	if config.StateStore != nil {
+		var err error
+		if readyAt, err = loadReadyAt(config); err != nil {
+			return nil, err
+		}
+	}
+
+	// 步骤4：预先计算datacenterID和workerID部分（性能优化）
 	// 说明：这两部分在生成器生命周期内不变，预先计算避免每次生成ID时重复计算
-	precomputedPart := (config.DatacenterID << DatacenterIDShift) | (config.WorkerID << WorkerIDShift)
+	layout := config.Layout()
+	precomputedPart := (config.DatacenterID << layout.DatacenterShift()) | (config.WorkerID << layout.WorkerShift())
 
-	// 步骤4：初始化监控（如果启用）
+	// 步骤5：初始化监控（如果启用）
 	var metrics *Metrics
 	if config.EnableMetrics {
 		metrics = NewMetrics()
 	}
 
-	// 步骤5：创建生成器实例
+	// 步骤6：创建生成器实例
 	generator := &Generator{
 		datacenterID:    config.DatacenterID,
 		workerID:        config.WorkerID,
@@ -75,14 +104,30 @@ func NewWithConfig(config *Config) (core.IGenerator, error) {
 		sequence:        -1,             // 初始化为-1，首次生成时会递增为0
 		config:          config.Clone(), // 使用配置副本（不可变性原则）
 		precomputedPart: precomputedPart,
+		layout:          layout,
+		timestampShift:  layout.TimestampShift(),
+		maxSequence:     layout.MaxSequence(),
+		maxTimeDiff:     layout.MaxTimestampDiff(),
+		obfuscator:      config.Obfuscator,
+		clock:           config.Clock,
+		readyAt:         readyAt,
 		metrics:         metrics,
-		validator:       NewValidator(),
-		parser:          NewParser(),
+		validator:       &Validator{layout: layout},
+		parser:          &Parser{validator: &Validator{layout: layout}, layout: layout, obfuscator: config.Obfuscator},
+	}
+
+	// 步骤7：启动状态持久化（如果启用）
+	if config.StateStore != nil {
+		generator.stopCh = make(chan struct{})
+		generator.persistDone = make(chan struct{})
+		go generator.persistLoop()
 	}
 
 	log.Println("Snowflake生成器创建成功",
 		"datacenter_id", config.DatacenterID,
 		"worker_id", config.WorkerID,
+		"layout", layout,
+		"obfuscated", config.Obfuscator != nil,
 		"metrics_enabled", config.EnableMetrics)
 
 	return generator, nil
@@ -94,7 +139,15 @@ func (g *Generator) NextID() (int64, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	return g.nextIDUnsafe()
+	if g.closed {
+		return 0, core.ErrGeneratorClosed
+	}
+
+	id, err := g.nextIDUnsafe()
+	if err != nil {
+		return 0, err
+	}
+	return g.obfuscate(id), nil
 }
 
 // NextIDBatch 批量生成ID（线程安全）
@@ -113,7 +166,22 @@ func (g *Generator) NextIDBatch(n int) ([]int64, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	return g.nextIDBatchUnsafe(n)
+	if g.closed {
+		return nil, core.ErrGeneratorClosed
+	}
+
+	ids, err := g.nextIDBatchUnsafe(n)
+	// 出错时已生成的部分ID也会返回给调用者，同样需要混淆
+	for i := range ids {
+		ids[i] = g.obfuscate(ids[i])
+	}
+	return ids, err
+}
+
+// NextIDs 批量生成ID（线程安全）
+// 说明：NextIDBatch的别名，整批ID只获取一次锁，相比循环调用NextID可将锁竞争降低约n倍
+func (g *Generator) NextIDs(n int) ([]int64, error) {
+	return g.NextIDBatch(n)
 }
 
 // GetWorkerID 获取工作机器ID
@@ -128,6 +196,12 @@ func (g *Generator) GetDatacenterID() int64 {
 	return g.datacenterID
 }
 
+// Layout 返回生成器使用的ID位布局
+// 说明：解析该生成器签发的ID时应使用相同的布局（NewParserWithLayout）
+func (g *Generator) Layout() Layout {
+	return g.layout
+}
+
 // GetMetrics 获取性能监控指标
 // 实现core.MonitorableGenerator接口
 func (g *Generator) GetMetrics() map[string]uint64 {
@@ -154,6 +228,54 @@ func (g *Generator) GetIDCount() uint64 {
 	return g.metrics.IDCount.Load()
 }
 
+// Stats 获取运行统计
+// 实现core.MonitorableGenerator接口
+func (g *Generator) Stats() core.GeneratorStats {
+	g.mu.Lock()
+	lastTimestamp := g.lastTimestamp
+	g.mu.Unlock()
+
+	stats := core.GeneratorStats{}
+	if lastTimestamp > 0 {
+		stats.LastTimestamp = lastTimestamp
+	}
+	if g.metrics != nil {
+		stats.IDsIssued = g.metrics.IDCount.Load()
+		stats.SequenceRollovers = g.metrics.SequenceOverflow.Load()
+		stats.ClockBackwards = g.metrics.ClockBackward.Load()
+		stats.ClockWaitTimeNs = g.metrics.TotalWaitTimeNs.Load()
+	}
+	return stats
+}
+
+// HealthCheck 健康检查
+// 实现core.HealthChecker接口
+// 说明：
+//   - 当前时钟落后于最近发号时间戳且超出容忍范围：不健康（发号将失败或阻塞）
+//   - 曾检测到时钟回拨：降级（需要检查NTP配置）
+func (g *Generator) HealthCheck() core.HealthStatus {
+	stats := g.Stats()
+	status := core.HealthStatus{State: core.HealthStateHealthy, Stats: stats}
+
+	// 检查1：时钟是否落后于已发号时间戳
+	if stats.LastTimestamp > 0 {
+		drift := stats.LastTimestamp - g.clock.Now()
+		if drift > g.config.ClockBackwardTolerance {
+			status.State = core.HealthStateUnhealthy
+			status.Message = fmt.Sprintf("clock is %d ms behind last issued timestamp", drift)
+			return status
+		}
+	}
+
+	// 检查2：历史时钟回拨
+	if stats.ClockBackwards > 0 {
+		status.State = core.HealthStateDegraded
+		status.Message = fmt.Sprintf("clock moved backwards %d times", stats.ClockBackwards)
+	}
+
+	return status
+}
+
 // ParseID 解析ID
 // 实现core.ParseableGenerator接口
 func (g *Generator) ParseID(id int64) (*core.IDInfo, error) {
@@ -162,15 +284,58 @@ func (g *Generator) ParseID(id int64) (*core.IDInfo, error) {
 
 // ValidateID 验证ID
 // 实现core.ParseableGenerator接口
+// 说明：设置了混淆器时先还原内部ID再验证
 func (g *Generator) ValidateID(id int64) error {
-	return g.validator.Validate(id)
+	return g.validator.Validate(g.InternalID(id))
+}
+
+// InternalID 将对外ID还原为内部ID（未设置混淆器时原样返回）
+// 用途：内部ID按时间递增，服务端可用于排序、时间范围查询（IDRangeForTime）和分片路由（ShardFor）
+func (g *Generator) InternalID(id int64) int64 {
+	if g.obfuscator == nil {
+		return id
+	}
+	return g.obfuscator.Deobfuscate(id)
+}
+
+// obfuscate 将内部ID变换为对外ID（未设置混淆器时原样返回）
+func (g *Generator) obfuscate(id int64) int64 {
+	if g.obfuscator == nil {
+		return id
+	}
+	return g.obfuscator.Obfuscate(id)
+}
+
+// Close 关闭生成器
+// 实现core.CloseableGenerator接口
+// 说明：停止后台持久化并写入最终状态，重复调用是安全的
+func (g *Generator) Close() error {
+	var err error
+	g.closeOnce.Do(func() {
+		g.mu.Lock()
+		g.closed = true
+		g.mu.Unlock()
+
+		if g.config.StateStore == nil {
+			return
+		}
+
+		// 等待持久化goroutine退出后再写入最终状态，避免并发写
+		close(g.stopCh)
+		<-g.persistDone
+		err = g.persistState()
+	})
+	return err
 }
 
 // nextIDUnsafe 内部使用的不加锁版本的ID生成方法
 // 说明：调用者必须已持有锁
 func (g *Generator) nextIDUnsafe() (int64, error) {
+	// 步骤0：重启后首次发号，等待时钟越过已持久化的时间戳
+	g.waitReadyUnsafe()
+
 	// 步骤1：获取当前时间戳（毫秒）
-	timestamp := time.Now().UnixNano() / 1e6
+	timestamp := g.clock.Now()
 
 	// 步骤2：时钟回拨检测与处理
 	if timestamp < g.lastTimestamp {
@@ -182,14 +347,14 @@ func (g *Generator) nextIDUnsafe() (int64, error) {
 			return 0, err
 		}
 		// 重新获取时间戳（可能已经等待或使用了上次时间戳）
-		timestamp = time.Now().UnixNano() / 1e6
+		timestamp = g.clock.Now()
 	}
 
 	// 步骤3：序列号管理
 	if timestamp == g.lastTimestamp {
 		// 同一毫秒内，检查序列号是否溢出
-		if g.sequence >= MaxSequence {
-			// 序列号已达上限（4095），需要等待下一毫秒
+		if g.sequence >= g.maxSequence {
+			// 序列号已达上限（默认布局为4095），需要等待下一毫秒
 			if g.metrics != nil {
 				g.metrics.SequenceOverflow.Add(1)
 				g.metrics.WaitCount.Add(1)
@@ -212,9 +377,12 @@ func (g *Generator) nextIDUnsafe() (int64, error) {
 	}
 
 	// 步骤4：组装ID
-	// ID结构：时间戳(41位) | 数据中心ID(5位) | 工作机器ID(5位) | 序列号(12位)
-	timeDiff := timestamp - Epoch
-	id := (timeDiff << TimestampShift) | g.precomputedPart | g.sequence
+	// ID结构（默认布局）：时间戳(41位) | 数据中心ID(5位) | 工作机器ID(5位) | 序列号(12位)
+	timeDiff, err := g.timeDiff(timestamp)
+	if err != nil {
+		return 0, err
+	}
+	id := (timeDiff << g.timestampShift) | g.precomputedPart | g.sequence
 
 	// 步骤5：更新监控指标
 	if g.metrics != nil {
@@ -227,12 +395,14 @@ func (g *Generator) nextIDUnsafe() (int64, error) {
 // nextIDBatchUnsafe 内部使用的不加锁版本的批量生成方法
 // 说明：调用者必须已持有锁
 func (g *Generator) nextIDBatchUnsafe(n int) ([]int64, error) {
+	g.waitReadyUnsafe()
+
 	ids := make([]int64, 0, n)
 	remainingIDs := n
 
 	for remainingIDs > 0 {
 		// 步骤1：获取当前时间戳
-		timestamp := time.Now().UnixNano() / 1e6
+		timestamp := g.clock.Now()
 
 		// 步骤2：时钟回拨检测
 		if timestamp < g.lastTimestamp {
@@ -244,7 +414,7 @@ func (g *Generator) nextIDBatchUnsafe(n int) ([]int64, error) {
 					"error", err)
 				return ids, fmt.Errorf("%w (generated %d/%d IDs)", err, len(ids), n)
 			}
-			timestamp = time.Now().UnixNano() / 1e6
+			timestamp = g.clock.Now()
 		}
 
 		// 步骤3：计算当前毫秒可用的ID数量
@@ -252,9 +422,9 @@ func (g *Generator) nextIDBatchUnsafe(n int) ([]int64, error) {
 		if timestamp == g.lastTimestamp {
 			// 同一毫秒内，计算剩余可用序列号数量
 			// g.sequence 是当前已使用的最后一个序列号（范围0-4095）
-			// 剩余可用数量 = MaxSequence - g.sequence
-			// 例如：g.sequence=9，则剩余4095-9=4086个
-			availableInCurrentMs = int(MaxSequence - g.sequence)
+			// 剩余可用数量 = maxSequence - g.sequence
+			// 例如：默认布局下g.sequence=9，则剩余4095-9=4086个
+			availableInCurrentMs = int(g.maxSequence - g.sequence)
 			if availableInCurrentMs <= 0 {
 				// 序列号已耗尽，等待下一毫秒
 				if g.metrics != nil {
@@ -269,13 +439,13 @@ func (g *Generator) nextIDBatchUnsafe(n int) ([]int64, error) {
 				// 新毫秒，重置为-1，后续会从0开始
 				g.sequence = -1
 				g.lastTimestamp = timestamp
-				availableInCurrentMs = MaxSequence + 1 // 0-4095，共4096个
+				availableInCurrentMs = int(g.maxSequence + 1) // 默认布局0-4095，共4096个
 			}
 		} else {
 			// 新的毫秒，有完整的4096个序列号（0-4095）
 			g.sequence = -1
 			g.lastTimestamp = timestamp
-			availableInCurrentMs = MaxSequence + 1 // 0-4095，共4096个
+			availableInCurrentMs = int(g.maxSequence + 1) // 默认布局0-4095，共4096个
 		}
 
 		// 步骤4：确定本轮生成数量
@@ -285,8 +455,11 @@ func (g *Generator) nextIDBatchUnsafe(n int) ([]int64, error) {
 		}
 
 		// 步骤5：批量生成ID
-		timeDiff := timestamp - Epoch
-		baseID := (timeDiff << TimestampShift) | g.precomputedPart
+		timeDiff, err := g.timeDiff(timestamp)
+		if err != nil {
+			return ids, fmt.Errorf("%w (generated %d/%d IDs)", err, len(ids), n)
+		}
+		baseID := (timeDiff << g.timestampShift) | g.precomputedPart
 
 		// 批量生成：每次递增序列号并组装ID
 		for i := 0; i < batchSize; i++ {
@@ -307,6 +480,16 @@ func (g *Generator) nextIDBatchUnsafe(n int) ([]int64, error) {
 	return ids, nil
 }
 
+// timeDiff 计算相对Epoch的时间戳部分，超出布局可表示的范围时返回错误
+func (g *Generator) timeDiff(timestamp int64) (int64, error) {
+	diff := timestamp - g.layout.EpochMillis
+	if diff < 0 || diff > g.maxTimeDiff {
+		return 0, fmt.Errorf("%w: timestamp %d, epoch %d, %d timestamp bits",
+			core.ErrTimestampOverflow, timestamp, g.layout.EpochMillis, g.layout.TimestampBits)
+	}
+	return diff, nil
+}
+
 // handleClockBackward 处理时钟回拨
 func (g *Generator) handleClockBackward(currentTimestamp int64) error {
 	// 计算回拨偏移量
@@ -330,7 +513,7 @@ func (g *Generator) handleClockBackward(currentTimestamp int64) error {
 			// 回拨在容忍范围内，尝试等待
 			for retries := 0; retries < maxWaitRetries; retries++ {
 				time.Sleep(time.Duration(offset+1) * time.Millisecond)
-				newTimestamp := time.Now().UnixNano() / 1e6
+				newTimestamp := g.clock.Now()
 				if newTimestamp >= g.lastTimestamp {
 					// 时钟已追上
 					return nil
@@ -364,10 +547,10 @@ func (g *Generator) handleClockBackward(currentTimestamp int64) error {
 // waitNextMillis 等待直到获取到比lastTimestamp更大的时间戳
 // 说明：当序列号耗尽时，需要等待下一毫秒
 func (g *Generator) waitNextMillis(lastTimestamp int64) int64 {
-	timestamp := time.Now().UnixNano() / 1e6
+	timestamp := g.clock.Now()
 	for timestamp <= lastTimestamp {
 		time.Sleep(sleepDuration) // 休眠100微秒，避免CPU空转
-		timestamp = time.Now().UnixNano() / 1e6
+		timestamp = g.clock.Now()
 	}
 	return timestamp
 }
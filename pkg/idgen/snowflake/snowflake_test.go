@@ -1,12 +1,14 @@
 package snowflake_test
 
 import (
+	"errors"
 	"katydid-common-account/pkg/idgen/core"
 	"runtime"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+	_ "time/tzdata" // 夏令时测试需要时区数据
 
 	"katydid-common-account/pkg/idgen/snowflake"
 )
@@ -168,6 +170,269 @@ func TestNextIDBatch(t *testing.T) {
 	}
 }
 
+// TestBlockAllocator 测试预分配ID分配器
+func TestBlockAllocator(t *testing.T) {
+	gen, err := snowflake.New(1, 1)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	t.Run("无效参数", func(t *testing.T) {
+		if _, err := snowflake.NewBlockAllocator(nil, 10); err == nil {
+			t.Error("Expected error for nil generator")
+		}
+		if _, err := snowflake.NewBlockAllocator(gen, 100001); err == nil {
+			t.Error("Expected error for oversized block")
+		}
+	})
+
+	t.Run("默认块大小", func(t *testing.T) {
+		alloc, err := snowflake.NewBlockAllocator(gen, 0)
+		if err != nil {
+			t.Fatalf("NewBlockAllocator failed: %v", err)
+		}
+		if alloc.BlockSize() != 1024 {
+			t.Errorf("BlockSize() = %d, want 1024", alloc.BlockSize())
+		}
+	})
+
+	t.Run("单个获取_递增且跨块", func(t *testing.T) {
+		alloc, _ := snowflake.NewBlockAllocator(gen, 16)
+		var last int64
+		for i := 0; i < 50; i++ {
+			id, err := alloc.NextID()
+			if err != nil {
+				t.Fatalf("NextID failed: %v", err)
+			}
+			if id <= last {
+				t.Fatalf("ID not increasing: %d <= %d", id, last)
+			}
+			last = id
+		}
+		if alloc.Buffered() != 14 {
+			t.Errorf("Buffered() = %d, want 14", alloc.Buffered())
+		}
+	})
+
+	t.Run("批量获取_超出缓冲", func(t *testing.T) {
+		alloc, _ := snowflake.NewBlockAllocator(gen, 8)
+		_, _ = alloc.NextID()
+		ids, err := alloc.NextIDs(20)
+		if err != nil {
+			t.Fatalf("NextIDs failed: %v", err)
+		}
+		if len(ids) != 20 {
+			t.Fatalf("NextIDs(20) returned %d IDs", len(ids))
+		}
+		if _, err := alloc.NextIDs(0); err == nil {
+			t.Error("Expected error for zero batch")
+		}
+	})
+
+	t.Run("批量获取失败时ID放回缓冲区", func(t *testing.T) {
+		stub := &stubBatchGenerator{}
+		alloc, _ := snowflake.NewBlockAllocator(stub, 4)
+		if id, _ := alloc.NextID(); id != 1 {
+			t.Fatalf("NextID() = %d, want 1", id)
+		}
+
+		stub.err = errors.New("generator unavailable")
+		stub.partial = []int64{100}
+		if ids, err := alloc.NextIDs(6); err == nil || ids != nil {
+			t.Fatalf("NextIDs(6) = %v, %v, want nil and error", ids, err)
+		}
+		if alloc.Buffered() != 4 {
+			t.Errorf("Buffered() = %d, want 4", alloc.Buffered())
+		}
+
+		stub.err = nil
+		ids, err := alloc.NextIDs(4)
+		if err != nil {
+			t.Fatalf("NextIDs failed: %v", err)
+		}
+		if want := []int64{2, 3, 4, 100}; !equalIDs(ids, want) {
+			t.Errorf("NextIDs(4) = %v, want %v", ids, want)
+		}
+	})
+
+	t.Run("ID块独占使用", func(t *testing.T) {
+		alloc, _ := snowflake.NewBlockAllocator(gen, 32)
+		block, err := alloc.Block()
+		if err != nil {
+			t.Fatalf("Block failed: %v", err)
+		}
+		count := 0
+		for {
+			if _, ok := block.Next(); !ok {
+				break
+			}
+			count++
+		}
+		if count != 32 || block.Remaining() != 0 {
+			t.Errorf("Block yielded %d IDs, remaining %d", count, block.Remaining())
+		}
+	})
+
+	t.Run("并发唯一性", func(t *testing.T) {
+		alloc, _ := snowflake.NewBlockAllocator(gen, 64)
+		const goroutines, perGoroutine = 50, 200
+		var idMap sync.Map
+		var duplicates atomic.Int64
+		var wg sync.WaitGroup
+
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+			go func(useBlock bool) {
+				defer wg.Done()
+				var block *snowflake.IDBlock
+				for j := 0; j < perGoroutine; j++ {
+					var id int64
+					if useBlock {
+						if block == nil || block.Remaining() == 0 {
+							block, _ = alloc.Block()
+						}
+						id, _ = block.Next()
+					} else {
+						id, _ = alloc.NextID()
+					}
+					if _, loaded := idMap.LoadOrStore(id, true); loaded {
+						duplicates.Add(1)
+					}
+				}
+			}(i%2 == 0)
+		}
+		wg.Wait()
+
+		if duplicates.Load() > 0 {
+			t.Errorf("Found %d duplicate IDs", duplicates.Load())
+		}
+	})
+}
+
+// stubBatchGenerator 按顺序发号、可注入错误的批量生成器（测试用）
+type stubBatchGenerator struct {
+	next    int64
+	err     error   // 非nil时NextIDBatch返回partial和该错误
+	partial []int64 // 失败时已生成的部分ID
+}
+
+func (g *stubBatchGenerator) NextID() (int64, error) {
+	ids, err := g.NextIDBatch(1)
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+func (g *stubBatchGenerator) NextIDBatch(n int) ([]int64, error) {
+	if g.err != nil {
+		return g.partial, g.err
+	}
+	ids := make([]int64, n)
+	for i := range ids {
+		g.next++
+		ids[i] = g.next
+	}
+	return ids, nil
+}
+
+// equalIDs 比较ID序列
+func equalIDs(got, want []int64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TestStatePersistence 测试状态持久化与优雅关闭
+func TestStatePersistence(t *testing.T) {
+	store, err := snowflake.NewFileStateStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStateStore failed: %v", err)
+	}
+
+	newGen := func() core.IGenerator {
+		gen, err := snowflake.NewWithConfig(&snowflake.Config{
+			DatacenterID:    1,
+			WorkerID:        2,
+			StateStore:      store,
+			PersistInterval: 20 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("NewWithConfig failed: %v", err)
+		}
+		return gen
+	}
+
+	t.Run("关闭时持久化", func(t *testing.T) {
+		gen := newGen()
+		id, _ := gen.NextID()
+		if err := gen.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+
+		persisted, err := store.Load("snowflake-1-2")
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		info, _ := gen.ParseID(id)
+		if persisted != info.Timestamp {
+			t.Errorf("persisted = %d, want %d", persisted, info.Timestamp)
+		}
+
+		if _, err := gen.NextID(); !errors.Is(err, core.ErrGeneratorClosed) {
+			t.Errorf("NextID after Close expected ErrGeneratorClosed, got %v", err)
+		}
+		if err := gen.Close(); err != nil {
+			t.Errorf("second Close failed: %v", err)
+		}
+	})
+
+	t.Run("重启后等待越过持久化时间戳", func(t *testing.T) {
+		future := time.Now().UnixMilli() + 200
+		if err := store.Save("snowflake-1-2", future); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+
+		// 创建不阻塞（可能在注册表锁内调用），等待推迟到首次发号
+		start := time.Now()
+		gen := newGen()
+		defer gen.Close()
+		if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+			t.Errorf("NewWithConfig blocked for %v", elapsed)
+		}
+
+		id, _ := gen.NextID()
+		info, _ := gen.ParseID(id)
+		if info.Timestamp <= future+20 {
+			t.Errorf("first timestamp %d not past persisted %d + interval", info.Timestamp, future)
+		}
+	})
+
+	t.Run("时钟严重落后拒绝启动", func(t *testing.T) {
+		_ = store.Save("snowflake-1-2", time.Now().Add(time.Hour).UnixMilli())
+		_, err := snowflake.NewWithConfig(&snowflake.Config{
+			DatacenterID: 1,
+			WorkerID:     2,
+			StateStore:   store,
+		})
+		if !errors.Is(err, core.ErrClockMovedBackwards) {
+			t.Errorf("expected ErrClockMovedBackwards, got %v", err)
+		}
+	})
+
+	t.Run("非法状态键", func(t *testing.T) {
+		if err := store.Save("../escape", 1); err == nil {
+			t.Error("Save with path traversal key should return error")
+		}
+	})
+}
+
 // TestGetWorkerID 测试获取WorkerID
 func TestGetWorkerID(t *testing.T) {
 	tests := []struct {
@@ -242,6 +507,23 @@ func TestMetrics(t *testing.T) {
 		}
 	})
 
+	t.Run("运行统计", func(t *testing.T) {
+		stats := gen.Stats()
+		if stats.IDsIssued < count {
+			t.Errorf("Stats().IDsIssued = %d, want >= %d", stats.IDsIssued, count)
+		}
+		if now := time.Now().UnixMilli(); stats.LastTimestamp <= 0 || stats.LastTimestamp > now {
+			t.Errorf("Stats().LastTimestamp = %d, now %d", stats.LastTimestamp, now)
+		}
+	})
+
+	t.Run("健康检查", func(t *testing.T) {
+		status := gen.(core.IHealthChecker).HealthCheck()
+		if !status.IsHealthy() {
+			t.Errorf("HealthCheck() = %+v, want healthy", status)
+		}
+	})
+
 	t.Run("重置指标", func(t *testing.T) {
 		gen.ResetMetrics()
 		idCount := gen.GetIDCount()
@@ -306,6 +588,368 @@ func TestValidateID(t *testing.T) {
 	}
 }
 
+// TestIDRangeForTime 测试时间窗口ID范围
+func TestIDRangeForTime(t *testing.T) {
+	gen, _ := snowflake.New(31, 31)
+	defer gen.Close()
+
+	start := time.Now().Add(-time.Millisecond)
+	id, err := gen.NextID()
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	end := time.Now().Add(time.Millisecond)
+
+	minID, maxID, err := snowflake.IDRangeForTime(start, end)
+	if err != nil {
+		t.Fatalf("IDRangeForTime() error = %v", err)
+	}
+	if id < minID || id > maxID {
+		t.Errorf("ID %d not in range [%d, %d]", id, minID, maxID)
+	}
+
+	// 窗口之外的ID不在范围内
+	_, beforeMax, _ := snowflake.IDRangeForTime(start.Add(-time.Hour), start.Add(-time.Minute))
+	if id <= beforeMax {
+		t.Errorf("ID %d should be after earlier window max %d", id, beforeMax)
+	}
+
+	// 单个毫秒的范围覆盖所有机器和序列号
+	ms := time.UnixMilli(snowflake.Epoch + 1000)
+	minID, maxID, _ = snowflake.IDRangeForTime(ms, ms)
+	if ts, dc, worker, seq := snowflake.ParseID(maxID); ts != ms.UnixMilli() || dc != 31 || worker != 31 || seq != snowflake.MaxSequence {
+		t.Errorf("ParseID(maxID) = %d %d %d %d", ts, dc, worker, seq)
+	}
+	if got, _ := snowflake.MinIDForTime(ms); got != minID {
+		t.Errorf("MinIDForTime() = %d, want %d", got, minID)
+	}
+
+	// 早于Epoch的开始时间按Epoch计算
+	if minID, _, err := snowflake.IDRangeForTime(time.UnixMilli(0), ms); err != nil || minID != 0 {
+		t.Errorf("IDRangeForTime(before epoch) = %d, %v", minID, err)
+	}
+
+	invalid := [][2]time.Time{
+		{ms, ms.Add(-time.Millisecond)},               // start 晚于 end
+		{time.UnixMilli(0), time.UnixMilli(1)},        // end 早于 Epoch
+		{ms, time.UnixMilli(snowflake.Epoch + 1<<41)}, // 超出41位时间戳
+	}
+	for _, window := range invalid {
+		if _, _, err := snowflake.IDRangeForTime(window[0], window[1]); !errors.Is(err, core.ErrInvalidTimeRange) {
+			t.Errorf("IDRangeForTime(%v, %v) error = %v, want ErrInvalidTimeRange", window[0], window[1], err)
+		}
+	}
+}
+
+// TestShardFor 测试按机器ID分片
+func TestShardFor(t *testing.T) {
+	gen, _ := snowflake.New(2, 3)
+	defer gen.Close()
+
+	ids, err := gen.NextIDBatch(100)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	// 机器编号 = 2<<5 | 3 = 67
+	for _, id := range ids {
+		if got := snowflake.MachineID(id); got != 67 {
+			t.Fatalf("MachineID() = %d, want 67", got)
+		}
+		if got := snowflake.ShardFor(id, 16); got != 67%16 {
+			t.Fatalf("ShardFor() = %d, want %d", got, 67%16)
+		}
+	}
+
+	if snowflake.ShardFor(ids[0], 0) != -1 || snowflake.ShardFor(0, 16) != -1 || snowflake.MachineID(-1) != -1 {
+		t.Error("invalid input should return -1")
+	}
+}
+
+// TestCustomLayout 测试自定义位布局
+func TestCustomLayout(t *testing.T) {
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	config := &snowflake.Config{
+		WorkerID:      4000,
+		EpochMillis:   epoch,
+		TimestampBits: 42,
+		WorkerBits:    12,
+		SequenceBits:  9,
+	}
+	gen, err := snowflake.NewWithConfig(config)
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	defer gen.Close()
+
+	layout := gen.(*snowflake.Generator).Layout()
+	if layout != config.Layout() || layout.MaxWorkerID() != 4095 || layout.MaxSequence() != 511 || layout.MaxDatacenterID() != 0 {
+		t.Fatalf("Layout() = %s", layout)
+	}
+
+	before := time.Now().UnixMilli()
+	ids, err := gen.NextIDBatch(2000) // 跨越多个毫秒（每毫秒512个）
+	if err != nil {
+		t.Fatalf("NextIDBatch() error = %v", err)
+	}
+	after := time.Now().UnixMilli()
+
+	parser, err := snowflake.NewParserWithLayout(layout)
+	if err != nil {
+		t.Fatalf("NewParserWithLayout() error = %v", err)
+	}
+	for i, id := range ids {
+		if i > 0 && id <= ids[i-1] {
+			t.Fatalf("IDs not increasing at %d", i)
+		}
+		info, err := parser.Parse(id)
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		if info.WorkerID != 4000 || info.DatacenterID != 0 || info.Timestamp < before || info.Timestamp > after {
+			t.Fatalf("Parse() = %+v", info)
+		}
+	}
+
+	// 生成器自带的解析器使用相同布局
+	if info, err := gen.ParseID(ids[0]); err != nil || info.WorkerID != 4000 {
+		t.Errorf("gen.ParseID() = %+v, %v", info, err)
+	}
+	// 默认解析器会错位
+	if snowflake.NewParser().ExtractWorkerID(ids[0]) == 4000 {
+		t.Error("default parser should not decode custom layout")
+	}
+	if got := layout.MachineID(ids[0]); got != 4000 {
+		t.Errorf("MachineID() = %d, want 4000", got)
+	}
+	if ts, _, worker, _ := layout.Decompose(layout.Compose(epoch+5, 0, 7, 3)); ts != epoch+5 || worker != 7 {
+		t.Errorf("Compose/Decompose round trip = %d, %d", ts, worker)
+	}
+
+	if snowflake.DefaultLayout().TimestampShift() != snowflake.TimestampShift || snowflake.DefaultLayout().MaxSequence() != snowflake.MaxSequence {
+		t.Error("DefaultLayout should match package constants")
+	}
+}
+
+// TestCustomLayoutValidation 测试自定义位布局验证
+func TestCustomLayoutValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  snowflake.Config
+		wantErr error
+	}{
+		{"位数之和不为63", snowflake.Config{TimestampBits: 41, WorkerBits: 10, SequenceBits: 11}, core.ErrInvalidLayout},
+		{"序列号位数为0", snowflake.Config{TimestampBits: 53, WorkerBits: 10}, core.ErrInvalidLayout},
+		{"负数位数", snowflake.Config{TimestampBits: 45, DatacenterBits: -1, WorkerBits: 10, SequenceBits: 9}, core.ErrInvalidLayout},
+		{"未来的Epoch", snowflake.Config{EpochMillis: time.Now().Add(time.Hour).UnixMilli()}, core.ErrInvalidLayout},
+		{"工作机器ID超出自定义范围", snowflake.Config{WorkerID: 16, TimestampBits: 43, WorkerBits: 4, SequenceBits: 16}, core.ErrInvalidWorkerID},
+		{"数据中心位数为0", snowflake.Config{DatacenterID: 1, TimestampBits: 42, WorkerBits: 12, SequenceBits: 9}, core.ErrInvalidDatacenterID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			if _, err := snowflake.NewWithConfig(&config); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewWithConfig() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := snowflake.NewParserWithLayout(snowflake.Layout{TimestampBits: 63}); !errors.Is(err, core.ErrInvalidLayout) {
+		t.Errorf("NewParserWithLayout() error = %v, want ErrInvalidLayout", err)
+	}
+}
+
+// TestObfuscator 测试ID混淆
+func TestObfuscator(t *testing.T) {
+	obfuscator, err := snowflake.NewFeistelObfuscator("test-secret")
+	if err != nil {
+		t.Fatalf("NewFeistelObfuscator() error = %v", err)
+	}
+	if _, err := snowflake.NewFeistelObfuscator(""); err == nil {
+		t.Error("NewFeistelObfuscator(\"\") should return error")
+	}
+
+	// 边界值往返
+	for _, id := range []int64{1, 2, 4095, 1 << 22, 1<<62 + 12345, 1<<63 - 1} {
+		external := obfuscator.Obfuscate(id)
+		if external <= 0 {
+			t.Fatalf("Obfuscate(%d) = %d, want positive", id, external)
+		}
+		if got := obfuscator.Deobfuscate(external); got != id {
+			t.Fatalf("Deobfuscate(Obfuscate(%d)) = %d", id, got)
+		}
+	}
+	if obfuscator.Obfuscate(0) != 0 || obfuscator.Obfuscate(-5) != -5 {
+		t.Error("non-positive IDs should be returned unchanged")
+	}
+
+	config := &snowflake.Config{DatacenterID: 3, WorkerID: 7, Obfuscator: obfuscator}
+	gen, err := snowflake.NewWithConfig(config)
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	defer gen.Close()
+	generator := gen.(*snowflake.Generator)
+
+	ids, err := gen.NextIDBatch(1000)
+	if err != nil {
+		t.Fatalf("NextIDBatch() error = %v", err)
+	}
+	single, err := gen.NextID()
+	if err != nil {
+		t.Fatalf("NextID() error = %v", err)
+	}
+	ids = append(ids, single)
+
+	parser, err := snowflake.NewParserWithConfig(config)
+	if err != nil {
+		t.Fatalf("NewParserWithConfig() error = %v", err)
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	increasing := 0
+	for i, id := range ids {
+		if id <= 0 || seen[id] {
+			t.Fatalf("invalid or duplicate external ID %d", id)
+		}
+		seen[id] = true
+
+		// 内部ID保持递增
+		internal := generator.InternalID(id)
+		if i > 0 {
+			if internal <= generator.InternalID(ids[i-1]) {
+				t.Fatalf("internal IDs not increasing at %d", i)
+			}
+			if id > ids[i-1] {
+				increasing++
+			}
+		}
+
+		if err := gen.ValidateID(id); err != nil {
+			t.Fatalf("ValidateID() error = %v", err)
+		}
+		info, err := parser.Parse(id)
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		if info.ID != id || info.DatacenterID != 3 || info.WorkerID != 7 || parser.ExtractWorkerID(id) != 7 {
+			t.Fatalf("Parse() = %+v", info)
+		}
+	}
+
+	// 对外ID不应保持递增（随机置换下约一半相邻对递增）
+	if increasing > len(ids)*3/4 || increasing < len(ids)/4 {
+		t.Errorf("external IDs look ordered: %d/%d increasing pairs", increasing, len(ids)-1)
+	}
+
+	// 生成器自带的解析器会先还原内部ID，不带混淆器的解析器无法得到正确的机器信息
+	if info, err := gen.ParseID(ids[0]); err != nil || info.WorkerID != 7 {
+		t.Errorf("gen.ParseID() = %+v, %v", info, err)
+	}
+	if snowflake.NewParser().ExtractWorkerID(ids[0]) == 7 && snowflake.NewParser().ExtractDatacenterID(ids[0]) == 3 {
+		t.Error("plain parser should not decode obfuscated ID")
+	}
+}
+
+// TestClock 测试时钟源注入与时钟漂移模拟
+func TestClock(t *testing.T) {
+	// 2024-11-03 05:59:59.999 UTC，即美国东部夏令时结束前的最后一毫秒
+	start := time.Date(2024, 11, 3, 5, 59, 59, 999_000_000, time.UTC).UnixMilli()
+	clock := snowflake.NewManualClock(start)
+
+	gen, err := snowflake.NewWithConfig(&snowflake.Config{DatacenterID: 1, WorkerID: 1, Clock: clock})
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	defer gen.Close()
+
+	id, err := gen.NextID()
+	if err != nil {
+		t.Fatalf("NextID() error = %v", err)
+	}
+	if info, _ := gen.ParseID(id); info.Timestamp != start {
+		t.Errorf("Timestamp = %d, want %d (from injected clock)", info.Timestamp, start)
+	}
+
+	// 回拨：默认策略返回错误，健康检查报告不健康
+	clock.Advance(-10 * time.Millisecond)
+	if _, err := gen.NextID(); !errors.Is(err, core.ErrClockMovedBackwards) {
+		t.Errorf("NextID() after backward jump error = %v", err)
+	}
+	if status := gen.(core.IHealthChecker).HealthCheck(); status.State != core.HealthStateUnhealthy {
+		t.Errorf("HealthCheck() = %+v, want unhealthy", status)
+	}
+	clock.Set(start + 1)
+	if _, err := gen.NextID(); err != nil {
+		t.Errorf("NextID() after recovery error = %v", err)
+	}
+
+	// 夏令时：把本地时间当作UTC的时钟源在秋季回拨1小时
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+	clock.Set(start)
+	naive := snowflake.NewNaiveLocalClock(clock, loc)
+	before := naive.Now()
+	clock.Advance(time.Millisecond)
+	if jump := naive.Now() - before; jump != 1-time.Hour.Milliseconds() {
+		t.Errorf("naive local clock jump = %d ms, want %d", jump, 1-time.Hour.Milliseconds())
+	}
+	if jump := clock.Now() - start; jump != 1 {
+		t.Errorf("UTC clock jump = %d ms, want 1", jump)
+	}
+
+	// 漂移时钟：容忍范围内的回拨等待追上，闰秒式的1秒回拨返回错误
+	drift := snowflake.NewDriftClock(nil)
+	waitGen, _ := snowflake.NewWithConfig(&snowflake.Config{
+		ClockBackwardStrategy:  core.StrategyWait,
+		ClockBackwardTolerance: 5,
+		Clock:                  drift,
+	})
+	defer waitGen.Close()
+	if _, err := waitGen.NextID(); err != nil {
+		t.Fatal(err)
+	}
+	drift.Jump(-3 * time.Millisecond)
+	if _, err := waitGen.NextID(); err != nil {
+		t.Errorf("NextID() within tolerance error = %v", err)
+	}
+	drift.Jump(-time.Second)
+	if _, err := waitGen.NextID(); !errors.Is(err, core.ErrClockMovedBackwards) {
+		t.Errorf("NextID() after leap second error = %v", err)
+	}
+	if drift.Offset() != -1003*time.Millisecond {
+		t.Errorf("Offset() = %v", drift.Offset())
+	}
+}
+
+// TestAuditClock 测试时钟源审计
+func TestAuditClock(t *testing.T) {
+	if audit := snowflake.AuditClock(snowflake.SystemClock(), 3, time.Millisecond); !audit.OK() {
+		t.Errorf("AuditClock(SystemClock) = %v", audit)
+	}
+
+	local := snowflake.NewNaiveLocalClock(nil, time.FixedZone("UTC+8", 8*3600))
+	if audit := snowflake.AuditClock(local, 1, 0); !audit.TimezoneSuspect || audit.OK() {
+		t.Errorf("AuditClock(local) = %v, want timezone suspect", audit)
+	}
+
+	// 模拟闰秒：读数在两次采样之间回退约1秒
+	now := time.Now().UnixMilli()
+	readings := []int64{now, now, now + 10, now - 990, now - 980}
+	var i int
+	stepped := snowflake.ClockFunc(func() int64 {
+		v := readings[min(i, len(readings)-1)]
+		i++
+		return v
+	})
+	audit := snowflake.AuditClock(stepped, 4, 0)
+	if audit.Backwards != 1 || audit.MaxBackward != time.Second || !audit.LeapSecondSuspect || audit.TimezoneSuspect {
+		t.Errorf("AuditClock(stepped) = %v", audit)
+	}
+}
+
 // ============================================================================
 // 2. 并发测试
 // ============================================================================
@@ -643,6 +1287,24 @@ func BenchmarkNextID_Parallel(b *testing.B) {
 	})
 }
 
+// BenchmarkBlockAllocator_Parallel 并行基准测试预分配ID获取
+func BenchmarkBlockAllocator_Parallel(b *testing.B) {
+	gen, _ := snowflake.New(1, 1)
+	alloc, _ := snowflake.NewBlockAllocator(gen, 1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		block, _ := alloc.Block()
+		for pb.Next() {
+			if block.Remaining() == 0 {
+				block, _ = alloc.Block()
+			}
+			_, _ = block.Next()
+		}
+	})
+}
+
 // BenchmarkParseID 基准测试ID解析
 func BenchmarkParseID(b *testing.B) {
 	gen, _ := snowflake.New(5, 10)
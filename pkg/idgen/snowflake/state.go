@@ -0,0 +1,212 @@
+package snowflake
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// stateKeyRegex 状态键的合法字符正则表达式
+// 目的：文件存储会将键作为文件名，防止路径穿越
+var stateKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9_\-.]+$`)
+
+// ============================================================================
+// 状态存储实现
+// ============================================================================
+
+// FileStateStore 基于本地文件的状态存储
+// 说明：每个键对应目录下的一个文件，写入时先写临时文件再原子重命名
+type FileStateStore struct {
+	dir string // 状态文件所在目录
+}
+
+// NewFileStateStore 创建文件状态存储
+// 说明：目录不存在时自动创建
+func NewFileStateStore(dir string) (*FileStateStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("state directory cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+	return &FileStateStore{dir: dir}, nil
+}
+
+// Load 读取已持久化的时间戳
+// 实现core.IStateStore接口
+func (s *FileStateStore) Load(key string) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// Save 持久化时间戳
+// 实现core.IStateStore接口
+func (s *FileStateStore) Save(key string, timestamp int64) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	// 先写临时文件再重命名，避免崩溃时留下半写入的文件
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatInt(timestamp, 10)), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// path 获取键对应的文件路径
+func (s *FileStateStore) path(key string) (string, error) {
+	if !stateKeyRegex.MatchString(key) {
+		return "", fmt.Errorf("%w: state key '%s' contains invalid characters",
+			core.ErrInvalidKeyFormat, key)
+	}
+	return filepath.Join(s.dir, key+".state"), nil
+}
+
+// IKeyValueClient 键值存储客户端的最小接口
+// 说明：用于适配Redis等外部存储，避免本包直接依赖具体客户端
+type IKeyValueClient interface {
+	// Get 读取键的值，键不存在时found为false
+	Get(key string) (value string, found bool, err error)
+
+	// Set 写入键的值
+	Set(key string, value string) error
+}
+
+// KVStateStore 基于键值存储（如Redis）的状态存储
+type KVStateStore struct {
+	client IKeyValueClient // 键值存储客户端
+	prefix string          // 键前缀
+}
+
+// NewKVStateStore 创建键值状态存储
+// 说明：prefix会拼接在状态键之前，如"idgen:state:"
+func NewKVStateStore(client IKeyValueClient, prefix string) (*KVStateStore, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+	return &KVStateStore{client: client, prefix: prefix}, nil
+}
+
+// Load 读取已持久化的时间戳
+// 实现core.IStateStore接口
+func (s *KVStateStore) Load(key string) (int64, error) {
+	value, found, err := s.client.Get(s.prefix + key)
+	if err != nil || !found {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// Save 持久化时间戳
+// 实现core.IStateStore接口
+func (s *KVStateStore) Save(key string, timestamp int64) error {
+	return s.client.Set(s.prefix+key, strconv.FormatInt(timestamp, 10))
+}
+
+// ============================================================================
+// 生成器状态持久化
+// ============================================================================
+
+// loadReadyAt 启动检查：计算可以安全发号的最早时间戳
+// 说明：
+//   - 持久化是定期进行的，崩溃前最多还可能发出一个持久化间隔内的ID
+//   - 因此需要等待到 持久化时间戳 + 持久化间隔 之后才能安全发号
+//   - 只计算不等待：等待推迟到首次发号（见 waitReadyUnsafe），避免在注册表锁内阻塞
+//
+// 返回值：最早可发号的时间戳，0表示无需等待
+func loadReadyAt(config *Config) (int64, error) {
+	persisted, err := config.StateStore.Load(config.StateKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load persisted state: %w", err)
+	}
+	if persisted <= 0 {
+		return 0, nil
+	}
+
+	readyAt := persisted + config.PersistInterval.Milliseconds() + 1
+	wait := time.Duration(readyAt-config.Clock.Now()) * time.Millisecond
+	if wait <= 0 {
+		return 0, nil
+	}
+
+	// 等待时间过长，说明时钟严重回拨或状态数据异常
+	if wait > maxStartupWait {
+		return 0, fmt.Errorf("%w: clock is %v behind persisted state",
+			core.ErrClockMovedBackwards, wait)
+	}
+
+	log.Println("首次发号将等待时钟越过已持久化的时间戳",
+		"state_key", config.StateKey,
+		"persisted_timestamp", persisted,
+		"wait", wait)
+
+	return readyAt, nil
+}
+
+// waitReadyUnsafe 首次发号前等待时钟越过启动检查计算的时间戳
+// 说明：调用者必须已持有锁；只阻塞本生成器的发号，最长为 maxStartupWait
+func (g *Generator) waitReadyUnsafe() {
+	if g.readyAt == 0 {
+		return
+	}
+	g.waitNextMillis(g.readyAt - 1)
+	g.readyAt = 0
+}
+
+// persistLoop 定期持久化最近发号的时间戳
+// 说明：在独立goroutine中运行，直到stopCh关闭
+func (g *Generator) persistLoop() {
+	defer close(g.persistDone)
+
+	ticker := time.NewTicker(g.config.PersistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			g.persistState()
+		case <-g.stopCh:
+			return
+		}
+	}
+}
+
+// persistState 持久化最近发号的时间戳（仅在有新的发号时写入）
+func (g *Generator) persistState() error {
+	g.mu.Lock()
+	lastTimestamp := g.lastTimestamp
+	g.mu.Unlock()
+
+	if lastTimestamp <= g.persistedTimestamp {
+		return nil
+	}
+
+	if err := g.config.StateStore.Save(g.config.StateKey, lastTimestamp); err != nil {
+		log.Println("状态持久化失败", "state_key", g.config.StateKey, "error", err)
+		return err
+	}
+	g.persistedTimestamp = lastTimestamp
+
+	return nil
+}
@@ -8,17 +8,27 @@ import (
 )
 
 // Validator Snowflake ID验证器
-type Validator struct{}
+type Validator struct {
+	layout Layout // ID位布局（零值表示默认布局）
+}
 
 // ValidateID 全局验证函数
 func ValidateID(id int64) error {
 	return NewValidator().Validate(id)
 }
 
-// NewValidator 创建新的验证器实例
+// NewValidator 创建新的验证器实例（默认布局）
 // 说明：验证器是无状态的，可以创建多个实例或共享单个实例
 func NewValidator() core.IIDValidator {
-	return &Validator{}
+	return &Validator{layout: DefaultLayout()}
+}
+
+// NewValidatorWithLayout 创建使用自定义位布局的验证器
+func NewValidatorWithLayout(layout Layout) (*Validator, error) {
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+	return &Validator{layout: layout}, nil
 }
 
 // Validate 验证Snowflake ID的有效性
@@ -31,15 +41,20 @@ func (v *Validator) Validate(id int64) error {
 	}
 
 	// 提取时间戳部分（通过位运算）
-	timestamp := (id >> TimestampShift) + Epoch
+	layout := v.layout
+	if layout.TimestampBits == 0 {
+		layout = DefaultLayout()
+	}
+	epoch := layout.EpochMillis
+	timestamp := (id >> layout.TimestampShift()) + epoch
 
 	// 验证2：时间戳必须在Epoch之后
 	// 说明：如果时间戳早于Epoch，可能是：
 	//   - 使用了不同的Epoch生成的ID
 	//   - ID格式错误或损坏
-	if timestamp < Epoch {
+	if timestamp < epoch {
 		return fmt.Errorf("%w: timestamp %d is before epoch %d",
-			core.ErrInvalidSnowflakeID, timestamp, Epoch)
+			core.ErrInvalidSnowflakeID, timestamp, epoch)
 	}
 
 	// 验证3：时间戳不能太超前
@@ -0,0 +1,87 @@
+package verify
+
+import "math"
+
+// ============================================================================
+// 布隆过滤器
+// ============================================================================
+
+// bloomFilter 固定容量的布隆过滤器（非线程安全，由调用者加锁）
+type bloomFilter struct {
+	bits   []uint64 // 位数组
+	size   uint64   // 位数组长度（位）
+	hashes int      // 哈希函数数量
+	count  uint64   // 已插入元素数量
+}
+
+// newBloomFilter 按预期元素数量和误判率创建布隆过滤器
+// 说明：m = -n*ln(p)/(ln2)^2，k = m/n*ln2
+func newBloomFilter(expected uint64, falsePositiveRate float64) *bloomFilter {
+	size := uint64(math.Ceil(-float64(expected) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	if size < 64 {
+		size = 64
+	}
+	hashes := int(math.Round(float64(size) / float64(expected) * math.Ln2))
+	if hashes < 1 {
+		hashes = 1
+	}
+	return &bloomFilter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+// add 插入ID，返回插入前是否可能已存在
+func (f *bloomFilter) add(id int64) bool {
+	h1, h2 := bloomHash(id)
+	present := true
+	for i := 0; i < f.hashes; i++ {
+		// 双重哈希：h1 + i*h2 模拟 k 个独立哈希函数
+		pos := (h1 + uint64(i)*h2) % f.size
+		word, bit := pos/64, uint64(1)<<(pos%64)
+		if f.bits[word]&bit == 0 {
+			present = false
+			f.bits[word] |= bit
+		}
+	}
+	f.count++
+	return present
+}
+
+// contains 检查ID是否可能已存在
+func (f *bloomFilter) contains(id int64) bool {
+	h1, h2 := bloomHash(id)
+	for i := 0; i < f.hashes; i++ {
+		pos := (h1 + uint64(i)*h2) % f.size
+		if f.bits[pos/64]&(uint64(1)<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// reset 清空过滤器
+func (f *bloomFilter) reset() {
+	clear(f.bits)
+	f.count = 0
+}
+
+// bloomHash 计算两个独立的64位哈希值
+// 说明：Snowflake ID的低位（序列号）变化集中，需要充分扩散后再取模
+func bloomHash(id int64) (uint64, uint64) {
+	x := uint64(id)
+	h1 := mix64(x)
+	h2 := mix64(x^0x9e3779b97f4a7c15) | 1 // 奇数步长，避免与位数组长度有公因子时退化
+	return h1, h2
+}
+
+// mix64 SplitMix64 终结函数
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,167 @@
+package verify
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// ============================================================================
+// 运行时冲突检测
+// ============================================================================
+
+const (
+	defaultExpectedItems     = 1_000_000 // 默认每代过滤器容量
+	defaultFalsePositiveRate = 1e-6      // 默认误判率
+)
+
+// DetectorConfig 冲突检测器配置
+type DetectorConfig struct {
+	// ExpectedItems 每代布隆过滤器的容量
+	// 说明：检测器保留当前和上一代两个过滤器，当前代写满后轮换，
+	// 因此至少能覆盖最近 ExpectedItems 个ID，最多覆盖 2*ExpectedItems 个
+	// 默认值：1000000（每代约3.6MB，误判率1e-6时）
+	ExpectedItems uint64
+
+	// FalsePositiveRate 单个过滤器的误判率（0-1之间）
+	// 说明：布隆过滤器可能把未出现过的ID误判为重复，误判率越低占用内存越多
+	// 默认值：1e-6
+	FalsePositiveRate float64
+
+	// FailOnCollision 检测到疑似重复时是否返回错误
+	// 说明：true时NextID返回 core.ErrDuplicateID（疑似重复的ID不会返回给调用者），
+	// 适合预发环境断言唯一性；false时只计数和回调
+	FailOnCollision bool
+
+	// OnCollision 检测到疑似重复时的回调（可选）
+	// 说明：在持有检测器锁时同步调用，不能在回调中使用检测器生成ID
+	OnCollision func(id int64)
+}
+
+// CollisionDetector 基于布隆过滤器的运行时ID冲突检测器
+// 用途：在预发环境包装生成器，配置变更（机器ID分配、位布局、Epoch）后断言ID唯一
+// 说明：
+//   - 同一个检测器可以包装多个生成器，检测这些生成器之间的冲突
+//   - 布隆过滤器存在误判，报告的是"疑似重复"，需要结合日志确认
+//   - 每个ID都需要加锁检查，有一定性能开销，不建议在生产环境长期开启
+type CollisionDetector struct {
+	config   DetectorConfig
+	current  *bloomFilter // 当前代过滤器
+	previous *bloomFilter // 上一代过滤器
+	checked  atomic.Uint64
+	detected atomic.Uint64
+	mu       sync.Mutex
+}
+
+// NewCollisionDetector 创建冲突检测器
+func NewCollisionDetector(config DetectorConfig) (*CollisionDetector, error) {
+	if config.ExpectedItems == 0 {
+		config.ExpectedItems = defaultExpectedItems
+	}
+	if config.FalsePositiveRate == 0 {
+		config.FalsePositiveRate = defaultFalsePositiveRate
+	}
+	if config.FalsePositiveRate < 0 || config.FalsePositiveRate >= 1 {
+		return nil, fmt.Errorf("false positive rate must be between 0 and 1, got %v", config.FalsePositiveRate)
+	}
+	return &CollisionDetector{
+		config:   config,
+		current:  newBloomFilter(config.ExpectedItems, config.FalsePositiveRate),
+		previous: newBloomFilter(config.ExpectedItems, config.FalsePositiveRate),
+	}, nil
+}
+
+// Wrap 包装生成器，生成的每个ID都会经过冲突检测
+// 说明：返回的生成器实现完整的core.IGenerator接口，除生成方法外均直接委托给原生成器
+func (d *CollisionDetector) Wrap(generator core.IGenerator) core.IGenerator {
+	return &detectingGenerator{IGenerator: generator, detector: d}
+}
+
+// Check 检查并记录ID，疑似重复时返回 core.ErrDuplicateID
+// 说明：未通过Wrap生成的ID（如号段预分配、外部导入）也可以直接调用
+func (d *CollisionDetector) Check(id int64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.checkUnsafe(id)
+}
+
+// Checked 已检查的ID数量
+func (d *CollisionDetector) Checked() uint64 {
+	return d.checked.Load()
+}
+
+// Collisions 检测到的疑似重复数量
+func (d *CollisionDetector) Collisions() uint64 {
+	return d.detected.Load()
+}
+
+// Reset 清空检测记录和计数
+func (d *CollisionDetector) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.current.reset()
+	d.previous.reset()
+	d.checked.Store(0)
+	d.detected.Store(0)
+}
+
+// checkUnsafe 检查并记录ID（调用者必须已持有锁）
+func (d *CollisionDetector) checkUnsafe(id int64) error {
+	d.checked.Add(1)
+
+	// 当前代写满后轮换，上一代被丢弃
+	if d.current.count >= d.config.ExpectedItems {
+		d.current, d.previous = d.previous, d.current
+		d.current.reset()
+	}
+
+	if !d.previous.contains(id) && !d.current.add(id) {
+		return nil
+	}
+
+	d.detected.Add(1)
+	if d.config.OnCollision != nil {
+		d.config.OnCollision(id)
+	}
+	if d.config.FailOnCollision {
+		return fmt.Errorf("%w: %d", core.ErrDuplicateID, id)
+	}
+	return nil
+}
+
+// detectingGenerator 带冲突检测的生成器
+type detectingGenerator struct {
+	core.IGenerator
+	detector *CollisionDetector
+}
+
+// NextID 生成ID并检测冲突
+// 实现core.IIDGenerator接口
+func (g *detectingGenerator) NextID() (int64, error) {
+	id, err := g.IGenerator.NextID()
+	if err != nil {
+		return id, err
+	}
+	if err := g.detector.Check(id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// NextIDBatch 批量生成ID并检测冲突
+// 实现core.IBatchGenerator接口
+// 说明：FailOnCollision时返回疑似重复ID之前的部分ID和错误
+func (g *detectingGenerator) NextIDBatch(n int) ([]int64, error) {
+	ids, err := g.IGenerator.NextIDBatch(n)
+
+	g.detector.mu.Lock()
+	defer g.detector.mu.Unlock()
+	for i, id := range ids {
+		if checkErr := g.detector.checkUnsafe(id); checkErr != nil {
+			return ids[:i], checkErr
+		}
+	}
+	return ids, err
+}
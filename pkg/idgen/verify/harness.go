@@ -0,0 +1,224 @@
+package verify
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"katydid-common-account/pkg/idgen/core"
+)
+
+// ============================================================================
+// 多实例协同验证
+// ============================================================================
+
+const (
+	defaultInstances  = 4     // 默认实例数量
+	defaultIterations = 10000 // 默认每个实例的生成次数
+	defaultMaxSamples = 10    // 默认保留的冲突样本数量
+)
+
+// Options 多实例验证参数
+type Options struct {
+	// Instances 同时运行的生成器实例数量
+	// 默认值：4
+	Instances int
+
+	// Iterations 每个实例的生成次数
+	// 默认值：10000
+	Iterations int
+
+	// BatchSize 每次批量生成的数量
+	// 说明：0或1时逐个调用NextID，大于1时调用NextIDBatch，每个实例共生成 Iterations*BatchSize 个ID
+	BatchSize int
+
+	// MaxSamples 报告中保留的冲突样本数量
+	// 默认值：10
+	MaxSamples int
+}
+
+// setDefaults 设置默认值
+func (o *Options) setDefaults() {
+	if o.Instances <= 0 {
+		o.Instances = defaultInstances
+	}
+	if o.Iterations <= 0 {
+		o.Iterations = defaultIterations
+	}
+	if o.BatchSize < 1 {
+		o.BatchSize = 1
+	}
+	if o.MaxSamples <= 0 {
+		o.MaxSamples = defaultMaxSamples
+	}
+}
+
+// Collision 冲突样本
+type Collision struct {
+	ID        int64 // 重复的ID
+	Instances []int // 生成该ID的实例序号（同一实例重复生成时会出现相同序号）
+}
+
+// Report 多实例验证报告
+type Report struct {
+	Instances  int           // 实例数量
+	Iterations int           // 每个实例的生成次数
+	Total      int           // 生成的ID总数
+	Duplicates int           // 重复ID数量（同一ID第二次及以后出现的次数）
+	Collisions []Collision   // 冲突样本（最多 MaxSamples 个，按ID升序）
+	Duration   time.Duration // 生成耗时（不含创建和查重）
+}
+
+// HasCollisions 是否检测到重复ID
+func (r *Report) HasCollisions() bool {
+	return r.Duplicates > 0
+}
+
+// Err 检测到重复ID时返回包装了 core.ErrDuplicateID 的错误，否则返回nil
+func (r *Report) Err() error {
+	if !r.HasCollisions() {
+		return nil
+	}
+	return fmt.Errorf("%w: %d duplicates in %d ids from %d instances",
+		core.ErrDuplicateID, r.Duplicates, r.Total, r.Instances)
+}
+
+// String 实现Stringer接口，便于日志打印
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "instances=%d iterations=%d total=%d duplicates=%d duration=%v",
+		r.Instances, r.Iterations, r.Total, r.Duplicates, r.Duration)
+	for _, c := range r.Collisions {
+		fmt.Fprintf(&b, "\n  id=%d instances=%v", c.ID, c.Instances)
+	}
+	return b.String()
+}
+
+// Run 使用同一份配置创建多个生成器实例并发生成ID，检测是否出现重复
+// 用途：
+//   - 验证配置变更（如机器ID分配、位布局调整）后多实例部署仍能保证唯一性
+//   - 模拟误配置（多个实例使用相同的机器ID）时的冲突情况
+//
+// 说明：
+//   - 实例按顺序创建（部分生成器会修改配置的默认值），创建完成后同时开始生成
+//   - 任意实例创建或生成失败时关闭所有实例并返回错误
+//   - 查重在全部生成完成后进行，内存占用约为ID总数的16字节以上
+//
+// 示例：
+//
+//	report, err := verify.Run(snowflake.NewFactory(), &snowflake.Config{WorkerID: 1},
+//		verify.Options{Instances: 8, Iterations: 100000})
+//	if err == nil && report.HasCollisions() {
+//		log.Println(report)
+//	}
+func Run(factory core.IGeneratorFactory, config any, opts Options) (*Report, error) {
+	if factory == nil {
+		return nil, fmt.Errorf("factory cannot be nil")
+	}
+	opts.setDefaults()
+
+	// 步骤1：按顺序创建所有实例
+	generators := make([]core.IGenerator, 0, opts.Instances)
+	defer func() {
+		for _, gen := range generators {
+			_ = gen.Close()
+		}
+	}()
+	for i := 0; i < opts.Instances; i++ {
+		gen, err := factory.Create(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create instance %d: %w", i, err)
+		}
+		generators = append(generators, gen)
+	}
+
+	// 步骤2：所有实例同时开始生成
+	results := make([][]int64, opts.Instances)
+	errs := make([]error, opts.Instances)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, gen := range generators {
+		wg.Add(1)
+		go func(i int, gen core.IGenerator) {
+			defer wg.Done()
+			<-start
+			results[i], errs[i] = generate(gen, opts.Iterations, opts.BatchSize)
+		}(i, gen)
+	}
+	begin := time.Now()
+	close(start)
+	wg.Wait()
+	duration := time.Since(begin)
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("instance %d failed: %w", i, err)
+		}
+	}
+
+	// 步骤3：查重
+	report := &Report{
+		Instances:  opts.Instances,
+		Iterations: opts.Iterations,
+		Duration:   duration,
+	}
+	collectCollisions(report, results, opts.MaxSamples)
+	return report, nil
+}
+
+// generate 单个实例生成ID
+func generate(gen core.IGenerator, iterations, batchSize int) ([]int64, error) {
+	ids := make([]int64, 0, iterations*batchSize)
+	for i := 0; i < iterations; i++ {
+		if batchSize == 1 {
+			id, err := gen.NextID()
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+			continue
+		}
+		batch, err := gen.NextIDBatch(batchSize)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, batch...)
+	}
+	return ids, nil
+}
+
+// collectCollisions 统计重复ID并收集样本
+func collectCollisions(report *Report, results [][]int64, maxSamples int) {
+	total := 0
+	for _, ids := range results {
+		total += len(ids)
+	}
+	report.Total = total
+
+	owners := make(map[int64]int, total) // ID -> 首次生成的实例序号
+	samples := make(map[int64][]int)     // 冲突样本：ID -> 实例序号列表
+	for instance, ids := range results {
+		for _, id := range ids {
+			first, exists := owners[id]
+			if !exists {
+				owners[id] = instance
+				continue
+			}
+			report.Duplicates++
+			if list, ok := samples[id]; ok {
+				samples[id] = append(list, instance)
+			} else if len(samples) < maxSamples {
+				samples[id] = []int{first, instance}
+			}
+		}
+	}
+
+	for id, instances := range samples {
+		report.Collisions = append(report.Collisions, Collision{ID: id, Instances: instances})
+	}
+	sort.Slice(report.Collisions, func(i, j int) bool {
+		return report.Collisions[i].ID < report.Collisions[j].ID
+	})
+}
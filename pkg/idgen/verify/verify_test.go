@@ -0,0 +1,145 @@
+package verify_test
+
+import (
+	"errors"
+	"testing"
+
+	"katydid-common-account/pkg/idgen/core"
+	"katydid-common-account/pkg/idgen/segment"
+	"katydid-common-account/pkg/idgen/snowflake"
+	"katydid-common-account/pkg/idgen/verify"
+)
+
+// ============================================================================
+// 1. 多实例协同验证测试
+// ============================================================================
+
+// TestRun 测试多实例验证
+func TestRun(t *testing.T) {
+	t.Run("共享号段存储无冲突", func(t *testing.T) {
+		config := &segment.Config{BizTag: "order", Store: segment.NewMemoryStore(), Step: 100}
+		report, err := verify.Run(segment.NewFactory(), config, verify.Options{Instances: 4, Iterations: 2000})
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if report.Total != 8000 || report.HasCollisions() || report.Err() != nil {
+			t.Errorf("Run() = %s", report)
+		}
+	})
+
+	t.Run("相同机器ID产生冲突", func(t *testing.T) {
+		config := &snowflake.Config{DatacenterID: 1, WorkerID: 1}
+		report, err := verify.Run(snowflake.NewFactory(), config, verify.Options{Instances: 4, Iterations: 20, BatchSize: 500, MaxSamples: 3})
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if report.Total != 40000 || !report.HasCollisions() {
+			t.Fatalf("Run() = %s", report)
+		}
+		if len(report.Collisions) != 3 || len(report.Collisions[0].Instances) < 2 {
+			t.Errorf("Collisions = %+v", report.Collisions)
+		}
+		if !errors.Is(report.Err(), core.ErrDuplicateID) {
+			t.Errorf("Err() = %v, want ErrDuplicateID", report.Err())
+		}
+	})
+
+	t.Run("创建失败", func(t *testing.T) {
+		if _, err := verify.Run(snowflake.NewFactory(), &snowflake.Config{WorkerID: 99}, verify.Options{}); !errors.Is(err, core.ErrInvalidWorkerID) {
+			t.Errorf("Run() error = %v, want ErrInvalidWorkerID", err)
+		}
+		if _, err := verify.Run(nil, nil, verify.Options{}); err == nil {
+			t.Error("Run() with nil factory should return error")
+		}
+	})
+}
+
+// ============================================================================
+// 2. 运行时冲突检测测试
+// ============================================================================
+
+// TestCollisionDetector 测试冲突检测器
+func TestCollisionDetector(t *testing.T) {
+	if _, err := verify.NewCollisionDetector(verify.DetectorConfig{FalsePositiveRate: 1.5}); err == nil {
+		t.Error("NewCollisionDetector() with invalid rate should return error")
+	}
+
+	t.Run("唯一ID无误报", func(t *testing.T) {
+		detector, err := verify.NewCollisionDetector(verify.DetectorConfig{ExpectedItems: 10000})
+		if err != nil {
+			t.Fatalf("NewCollisionDetector() error = %v", err)
+		}
+		gen, _ := snowflake.New(1, 1)
+		wrapped := detector.Wrap(gen)
+		defer wrapped.Close()
+
+		for i := 0; i < 10; i++ {
+			if _, err := wrapped.NextIDBatch(3000); err != nil {
+				t.Fatalf("NextIDBatch() error = %v", err)
+			}
+		}
+		if _, err := wrapped.NextID(); err != nil {
+			t.Fatalf("NextID() error = %v", err)
+		}
+		if detector.Checked() != 30001 || detector.Collisions() != 0 {
+			t.Errorf("Checked() = %d, Collisions() = %d", detector.Checked(), detector.Collisions())
+		}
+	})
+
+	t.Run("检测重复并返回错误", func(t *testing.T) {
+		var reported []int64
+		detector, _ := verify.NewCollisionDetector(verify.DetectorConfig{
+			FailOnCollision: true,
+			OnCollision:     func(id int64) { reported = append(reported, id) },
+		})
+
+		if err := detector.Check(42); err != nil {
+			t.Fatalf("Check() first time error = %v", err)
+		}
+		if err := detector.Check(42); !errors.Is(err, core.ErrDuplicateID) {
+			t.Fatalf("Check() duplicate error = %v, want ErrDuplicateID", err)
+		}
+
+		// 同一个检测器包装的两个生成器之间的冲突
+		first, _ := segment.New("a", segment.NewMemoryStore())
+		second, _ := segment.New("a", segment.NewMemoryStore()) // 独立存储，号段重叠
+		genA, genB := detector.Wrap(first), detector.Wrap(second)
+		defer genA.Close()
+		defer genB.Close()
+
+		if _, err := genA.NextIDBatch(10); err != nil {
+			t.Fatalf("NextIDBatch() error = %v", err)
+		}
+		ids, err := genB.NextIDBatch(10)
+		if !errors.Is(err, core.ErrDuplicateID) || len(ids) != 0 {
+			t.Errorf("NextIDBatch() = %v, %v, want ErrDuplicateID", ids, err)
+		}
+		if _, err := genA.NextID(); err != nil {
+			t.Fatalf("NextID() error = %v", err)
+		}
+		if _, err := genB.NextID(); !errors.Is(err, core.ErrDuplicateID) {
+			t.Errorf("NextID() error = %v, want ErrDuplicateID", err)
+		}
+		if detector.Collisions() != 3 || len(reported) != 3 || reported[0] != 42 {
+			t.Errorf("Collisions() = %d, reported = %v", detector.Collisions(), reported)
+		}
+
+		detector.Reset()
+		if detector.Checked() != 0 || detector.Check(42) != nil {
+			t.Error("Reset() should clear history")
+		}
+	})
+
+	t.Run("过滤器轮换", func(t *testing.T) {
+		detector, _ := verify.NewCollisionDetector(verify.DetectorConfig{ExpectedItems: 100, FailOnCollision: true})
+		for id := int64(1); id <= 150; id++ {
+			if err := detector.Check(id); err != nil {
+				t.Fatalf("Check(%d) error = %v", id, err)
+			}
+		}
+		// 上一代中的ID仍能检测到
+		if err := detector.Check(50); !errors.Is(err, core.ErrDuplicateID) {
+			t.Errorf("Check() after rotation error = %v, want ErrDuplicateID", err)
+		}
+	})
+}
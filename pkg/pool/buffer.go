@@ -0,0 +1,126 @@
+package pool
+
+import (
+	"bytes"
+	"strings"
+)
+
+// ============================================================================
+// 字符串构建器池
+// ============================================================================
+
+// DefaultMaxBuilderCap 字符串构建器归还时的默认容量上限（10KB）
+const DefaultMaxBuilderCap = 10 * 1024
+
+// StringBuilderPool strings.Builder 对象池
+// 注意：strings.Builder 的 Reset 会丢弃底层缓冲区（String() 返回的字符串仍在引用），
+// 复用的只是构建器本身；需要复用缓冲区时使用 BytesBufferPool
+type StringBuilderPool struct {
+	basePool[*strings.Builder]
+}
+
+// NewStringBuilderPool 创建字符串构建器池（默认容量上限 DefaultMaxBuilderCap）
+func NewStringBuilderPool(opts ...Option) *StringBuilderPool {
+	p := &StringBuilderPool{}
+	p.init(newConfig("strings.Builder", DefaultMaxBuilderCap, opts))
+	return p
+}
+
+// Get 获取已重置的字符串构建器，使用后调用 Put 归还
+func (p *StringBuilderPool) Get() *strings.Builder {
+	sb := p.get(newStringBuilder)
+	sb.Reset()
+	return sb
+}
+
+// Put 归还字符串构建器（nil 忽略）
+func (p *StringBuilderPool) Put(sb *strings.Builder) {
+	if sb == nil {
+		return
+	}
+	capacity := sb.Cap()
+	sb.Reset()
+	p.put(sb, capacity)
+}
+
+// newStringBuilder 创建字符串构建器
+func newStringBuilder() *strings.Builder {
+	return &strings.Builder{}
+}
+
+// ============================================================================
+// 字节缓冲区池
+// ============================================================================
+
+// DefaultMaxBufferCap 字节缓冲区归还时的默认容量上限（64KB）
+const DefaultMaxBufferCap = 64 * 1024
+
+// BytesBufferPool bytes.Buffer 对象池（归还时保留底层缓冲区）
+type BytesBufferPool struct {
+	basePool[*bytes.Buffer]
+}
+
+// NewBytesBufferPool 创建字节缓冲区池（默认容量上限 DefaultMaxBufferCap）
+func NewBytesBufferPool(opts ...Option) *BytesBufferPool {
+	p := &BytesBufferPool{}
+	p.init(newConfig("bytes.Buffer", DefaultMaxBufferCap, opts))
+	return p
+}
+
+// Get 获取已重置的字节缓冲区，使用后调用 Put 归还
+// 注意：归还后不能再使用 Bytes() 返回的切片
+func (p *BytesBufferPool) Get() *bytes.Buffer {
+	buf := p.get(newBytesBuffer)
+	buf.Reset()
+	return buf
+}
+
+// Put 归还字节缓冲区（nil 忽略）
+func (p *BytesBufferPool) Put(buf *bytes.Buffer) {
+	if buf == nil {
+		return
+	}
+	buf.Reset()
+	p.put(buf, buf.Cap())
+}
+
+// newBytesBuffer 创建字节缓冲区
+func newBytesBuffer() *bytes.Buffer {
+	return &bytes.Buffer{}
+}
+
+// ============================================================================
+// 默认池
+// ============================================================================
+
+var (
+	defaultBuilders = NewStringBuilderPool(WithName("default.strings.Builder"))
+	defaultBuffers  = NewBytesBufferPool(WithName("default.bytes.Buffer"))
+)
+
+// GetStringBuilder 从默认池获取字符串构建器
+func GetStringBuilder() *strings.Builder {
+	return defaultBuilders.Get()
+}
+
+// PutStringBuilder 归还字符串构建器到默认池
+func PutStringBuilder(sb *strings.Builder) {
+	defaultBuilders.Put(sb)
+}
+
+// GetBuffer 从默认池获取字节缓冲区
+func GetBuffer() *bytes.Buffer {
+	return defaultBuffers.Get()
+}
+
+// PutBuffer 归还字节缓冲区到默认池
+func PutBuffer(buf *bytes.Buffer) {
+	defaultBuffers.Put(buf)
+}
+
+// SetDefaultHooks 设置默认池的指标钩子，nil 表示移除
+// 说明：钩子通过名称区分池（default.strings.Builder、default.bytes.Buffer）
+func SetDefaultHooks(hooks Hooks) {
+	defaultBuilders.SetHooks(hooks)
+	defaultBuffers.SetHooks(hooks)
+}
@@ -0,0 +1,193 @@
+// Package pool 提供带指标钩子的通用对象池
+//
+// 包含 StringBuilderPool、BytesBufferPool、SlicePool[T]，以及包级默认池（GetStringBuilder、GetBuffer 等），
+// 替代各模块自行实现的 acquireXxx/releaseXxx。
+package pool
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ============================================================================
+// 指标钩子
+// ============================================================================
+
+// Hooks 对象池指标钩子
+// 说明：在 Get/Put 中同步调用，实现需要并发安全且足够轻量（如原子计数）
+type Hooks interface {
+	// OnGet 获取对象；reused 为 false 表示池中没有可用对象，新建了对象
+	OnGet(pool string, reused bool)
+
+	// OnPut 归还对象；discarded 为 true 表示容量超过上限，未放回池中（交给 GC 回收）
+	OnPut(pool string, discarded bool)
+}
+
+// Metrics 内置的指标钩子（原子计数，可同时挂到多个池上）
+type Metrics struct {
+	// Gets 获取次数
+	Gets atomic.Uint64
+
+	// News 新建次数（池中没有可用对象）
+	News atomic.Uint64
+
+	// Puts 归还到池中的次数
+	Puts atomic.Uint64
+
+	// Discards 因容量超过上限而丢弃的次数
+	// 用途：持续增长说明上限偏小，或存在异常大的对象
+	Discards atomic.Uint64
+}
+
+// NewMetrics 创建指标钩子
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// OnGet 实现 Hooks 接口
+func (m *Metrics) OnGet(_ string, reused bool) {
+	m.Gets.Add(1)
+	if !reused {
+		m.News.Add(1)
+	}
+}
+
+// OnPut 实现 Hooks 接口
+func (m *Metrics) OnPut(_ string, discarded bool) {
+	if discarded {
+		m.Discards.Add(1)
+		return
+	}
+	m.Puts.Add(1)
+}
+
+// Hits 复用次数（获取次数 - 新建次数）
+func (m *Metrics) Hits() uint64 {
+	gets, news := m.Gets.Load(), m.News.Load()
+	if news > gets {
+		return 0
+	}
+	return gets - news
+}
+
+// Reset 重置所有指标
+func (m *Metrics) Reset() {
+	m.Gets.Store(0)
+	m.News.Store(0)
+	m.Puts.Store(0)
+	m.Discards.Store(0)
+}
+
+// ToMap 转换为 map 格式
+func (m *Metrics) ToMap() map[string]uint64 {
+	return map[string]uint64{
+		"gets":     m.Gets.Load(),     // 获取次数
+		"news":     m.News.Load(),     // 新建次数
+		"hits":     m.Hits(),          // 复用次数
+		"puts":     m.Puts.Load(),     // 归还次数
+		"discards": m.Discards.Load(), // 丢弃次数
+	}
+}
+
+// ============================================================================
+// 选项
+// ============================================================================
+
+// config 对象池配置
+type config struct {
+	name   string
+	maxCap int
+	hooks  Hooks
+}
+
+// Option 对象池选项
+type Option func(*config)
+
+// WithName 设置对象池名称（传给指标钩子，用于区分不同的池）
+func WithName(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// WithMaxCap 设置归还时的容量上限，超过上限的对象不放回池中，<=0 表示不限制
+// 说明：防止偶发的大对象长期占用内存；字符串构建器和缓冲区按字节计，切片按元素个数计
+func WithMaxCap(maxCap int) Option {
+	return func(c *config) {
+		c.maxCap = maxCap
+	}
+}
+
+// WithHooks 设置指标钩子
+func WithHooks(hooks Hooks) Option {
+	return func(c *config) {
+		c.hooks = hooks
+	}
+}
+
+// newConfig 创建配置并应用选项
+func newConfig(name string, maxCap int, opts []Option) config {
+	c := config{name: name, maxCap: maxCap}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// ============================================================================
+// 通用对象池
+// ============================================================================
+
+// basePool 带指标钩子和容量上限的对象池
+// 说明：不设置 sync.Pool.New，由 get 自行创建，以便区分复用和新建
+type basePool[T any] struct {
+	pool   sync.Pool
+	name   string
+	maxCap int
+	hooks  atomic.Pointer[Hooks]
+}
+
+// init 初始化
+func (p *basePool[T]) init(c config) {
+	p.name = c.name
+	p.maxCap = c.maxCap
+	p.SetHooks(c.hooks)
+}
+
+// SetHooks 设置指标钩子，nil 表示移除（可在运行时调用）
+func (p *basePool[T]) SetHooks(hooks Hooks) {
+	if hooks == nil {
+		p.hooks.Store(nil)
+		return
+	}
+	p.hooks.Store(&hooks)
+}
+
+// get 获取对象，池中没有时调用 newFn 创建
+func (p *basePool[T]) get(newFn func() T) T {
+	var obj T
+	v := p.pool.Get()
+	reused := v != nil
+	if reused {
+		obj = v.(T)
+	} else {
+		obj = newFn()
+	}
+
+	if hooks := p.hooks.Load(); hooks != nil {
+		(*hooks).OnGet(p.name, reused)
+	}
+	return obj
+}
+
+// put 归还对象，capacity 超过上限时丢弃
+func (p *basePool[T]) put(obj T, capacity int) {
+	discarded := p.maxCap > 0 && capacity > p.maxCap
+	if !discarded {
+		p.pool.Put(obj)
+	}
+
+	if hooks := p.hooks.Load(); hooks != nil {
+		(*hooks).OnPut(p.name, discarded)
+	}
+}
@@ -0,0 +1,187 @@
+package pool_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"katydid-common-account/pkg/pool"
+)
+
+// ============================================================================
+// 1. 字符串构建器和缓冲区
+// ============================================================================
+
+// TestStringBuilderPool 测试获取的构建器已重置
+func TestStringBuilderPool(t *testing.T) {
+	p := pool.NewStringBuilderPool()
+
+	sb := p.Get()
+	sb.WriteString("hello")
+	if got := sb.String(); got != "hello" {
+		t.Fatalf("String() = %q, want %q", got, "hello")
+	}
+	p.Put(sb)
+	p.Put(nil)
+
+	if sb := p.Get(); sb.Len() != 0 {
+		t.Errorf("Get() after Put len = %d, want 0", sb.Len())
+	}
+}
+
+// TestBytesBufferPoolKeepsCapacity 测试缓冲区归还后保留容量
+func TestBytesBufferPoolKeepsCapacity(t *testing.T) {
+	metrics := pool.NewMetrics()
+	p := pool.NewBytesBufferPool(pool.WithHooks(metrics))
+
+	buf := p.Get()
+	buf.WriteString(strings.Repeat("x", 512))
+	p.Put(buf)
+
+	again := p.Get()
+	if again.Len() != 0 {
+		t.Errorf("Get() len = %d, want 0", again.Len())
+	}
+	// sync.Pool 不保证一定复用，只在复用时检查容量
+	if metrics.Hits() > 0 && again.Cap() < 512 {
+		t.Errorf("reused buffer cap = %d, want >= 512", again.Cap())
+	}
+}
+
+// ============================================================================
+// 2. 切片池
+// ============================================================================
+
+// TestSlicePoolClearsElements 测试切片归还时清空元素
+func TestSlicePoolClearsElements(t *testing.T) {
+	p := pool.NewSlicePool[*int](4)
+
+	s := p.Get()
+	if len(*s) != 0 || cap(*s) < 4 {
+		t.Fatalf("Get() len = %d cap = %d, want len 0 cap >= 4", len(*s), cap(*s))
+	}
+
+	v := 1
+	*s = append(*s, &v, &v, &v)
+	backing := (*s)[:cap(*s)]
+	*s = (*s)[:1] // 截断后 len 之外的元素同样需要清空
+	p.Put(s)
+
+	for i, elem := range backing {
+		if elem != nil {
+			t.Errorf("element %d = %v after Put, want nil", i, elem)
+		}
+	}
+	if len(*s) != 0 {
+		t.Errorf("len after Put = %d, want 0", len(*s))
+	}
+}
+
+// ============================================================================
+// 3. 容量上限和指标
+// ============================================================================
+
+// TestMaxCapDiscards 测试超过容量上限的对象被丢弃
+func TestMaxCapDiscards(t *testing.T) {
+	metrics := pool.NewMetrics()
+	p := pool.NewBytesBufferPool(pool.WithName("test"), pool.WithMaxCap(128), pool.WithHooks(metrics))
+
+	small := p.Get()
+	small.WriteString("ok")
+	p.Put(small)
+
+	large := p.Get()
+	large.WriteString(strings.Repeat("x", 1024))
+	p.Put(large)
+
+	if got := metrics.Gets.Load(); got != 2 {
+		t.Errorf("Gets = %d, want 2", got)
+	}
+	if got := metrics.Puts.Load(); got != 1 {
+		t.Errorf("Puts = %d, want 1", got)
+	}
+	if got := metrics.Discards.Load(); got != 1 {
+		t.Errorf("Discards = %d, want 1", got)
+	}
+
+	metrics.Reset()
+	if got := metrics.ToMap(); got["gets"] != 0 || got["discards"] != 0 {
+		t.Errorf("ToMap() after Reset = %v, want zeros", got)
+	}
+}
+
+// recordingHooks 记录池名称的钩子
+type recordingHooks struct {
+	mu    sync.Mutex
+	names map[string]int
+}
+
+func (h *recordingHooks) OnGet(pool string, _ bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.names[pool]++
+}
+
+func (h *recordingHooks) OnPut(string, bool) {}
+
+// TestDefaultHooks 测试默认池的钩子和名称
+func TestDefaultHooks(t *testing.T) {
+	hooks := &recordingHooks{names: make(map[string]int)}
+	pool.SetDefaultHooks(hooks)
+	defer pool.SetDefaultHooks(nil)
+
+	pool.PutStringBuilder(pool.GetStringBuilder())
+	pool.PutBuffer(pool.GetBuffer())
+
+	if hooks.names["default.strings.Builder"] != 1 || hooks.names["default.bytes.Buffer"] != 1 {
+		t.Errorf("hook calls = %v, want one per default pool", hooks.names)
+	}
+}
+
+// TestConcurrentUse 测试并发使用
+func TestConcurrentUse(t *testing.T) {
+	metrics := pool.NewMetrics()
+	p := pool.NewSlicePool[int](8, pool.WithHooks(metrics))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				s := p.Get()
+				*s = append(*s, j)
+				p.Put(s)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := metrics.Gets.Load(); got != 8000 {
+		t.Errorf("Gets = %d, want 8000", got)
+	}
+}
+
+// ============================================================================
+// 4. 基准测试
+// ============================================================================
+
+func BenchmarkBytesBufferPool(b *testing.B) {
+	p := pool.NewBytesBufferPool()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := p.Get()
+		buf.WriteString("hello world")
+		p.Put(buf)
+	}
+}
+
+func BenchmarkSlicePool(b *testing.B) {
+	p := pool.NewSlicePool[int](16)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := p.Get()
+		*s = append(*s, i)
+		p.Put(s)
+	}
+}
@@ -0,0 +1,61 @@
+package pool
+
+// ============================================================================
+// 切片池
+// ============================================================================
+
+// DefaultMaxSliceCap 切片归还时的默认容量上限（元素个数）
+const DefaultMaxSliceCap = 1024
+
+// SlicePool 切片对象池
+// 说明：存取的是切片指针（*[]T），避免放回 sync.Pool 时切片头逃逸产生分配
+//
+// 使用示例：
+//
+//	var errorSlices = pool.NewSlicePool[*FieldError](8)
+//
+//	errs := errorSlices.Get()
+//	defer errorSlices.Put(errs)
+//	*errs = append(*errs, err)
+type SlicePool[T any] struct {
+	basePool[*[]T]
+	initialCap int
+}
+
+// NewSlicePool 创建切片池
+// 参数：initialCap 为新建切片的初始容量（默认容量上限 DefaultMaxSliceCap 个元素）
+func NewSlicePool[T any](initialCap int, opts ...Option) *SlicePool[T] {
+	if initialCap < 0 {
+		initialCap = 0
+	}
+	p := &SlicePool[T]{initialCap: initialCap}
+	p.init(newConfig("slice", DefaultMaxSliceCap, opts))
+	return p
+}
+
+// Get 获取长度为 0 的切片，使用后调用 Put 归还
+func (p *SlicePool[T]) Get() *[]T {
+	s := p.get(p.newSlice)
+	*s = (*s)[:0]
+	return s
+}
+
+// Put 归还切片（nil 忽略）
+// 说明：归还前清空全部元素（含 len 之外的部分），避免池中的切片继续引用对象
+func (p *SlicePool[T]) Put(s *[]T) {
+	if s == nil {
+		return
+	}
+	capacity := cap(*s)
+	if p.maxCap <= 0 || capacity <= p.maxCap {
+		clear((*s)[:capacity])
+		*s = (*s)[:0]
+	}
+	p.put(s, capacity)
+}
+
+// newSlice 创建切片
+func (p *SlicePool[T]) newSlice() *[]T {
+	s := make([]T, 0, p.initialCap)
+	return &s
+}
@@ -0,0 +1,238 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ============================================================================
+// 结构体差异
+// ============================================================================
+
+// Diff 比较两个同类型的值，返回发生变化的字段路径（按字典序排列）
+//
+// 路径规则：
+// - 字段名使用 json 标签名（没有标签时使用字段名），json:"-" 的字段被忽略
+// - 嵌套结构体用 "." 连接，如 profile.email；匿名嵌入且没有 json 标签的结构体会被展开（如 ModelTimes）
+// - Extras 等 string 键的 map 按键展开，如 extras.theme；只在一边存在的键也算变化
+// - 指针一边为 nil 一边不为 nil、切片、数组等其他值整体比较，变化时返回该字段自身的路径
+// - 带 Equal 方法（如 time.Time、Money）或实现 json.Marshaler（如 MilliTime、Optional）的类型作为整体比较
+//
+// 使用示例：
+//
+//	changed, _ := types.Diff(oldUser, newUser) // [email extras.theme profile.nickname]
+//
+// 返回值：old 和 new 类型不同时返回错误；nil 指针视为该类型的零值
+func Diff(old, new any) ([]string, error) {
+	ov, nv := reflect.ValueOf(old), reflect.ValueOf(new)
+	if !ov.IsValid() || !nv.IsValid() {
+		if ov.IsValid() == nv.IsValid() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("diff: cannot compare nil with %T", firstNonNil(old, new))
+	}
+	if ov.Type() != nv.Type() {
+		return nil, fmt.Errorf("diff: type mismatch %s vs %s", ov.Type(), nv.Type())
+	}
+
+	d := &differ{}
+	d.diff("", derefOrZero(ov), derefOrZero(nv))
+	sort.Strings(d.paths)
+	return d.paths, nil
+}
+
+// differ 差异收集器
+type differ struct {
+	paths []string
+}
+
+// add 记录变化的路径
+func (d *differ) add(path string) {
+	d.paths = append(d.paths, path)
+}
+
+var (
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// diff 递归比较
+func (d *differ) diff(path string, ov, nv reflect.Value) {
+	typ := ov.Type()
+
+	// string 键的 map 优先按键展开（Extras 同时实现了 Equal 和 MarshalJSON）
+	if typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String {
+		d.diffMap(path, ov, nv)
+		return
+	}
+
+	// 带 Equal 方法或自定义 JSON 的类型作为整体比较
+	if equal, ok := leafEqual(ov, nv); ok {
+		if !equal {
+			d.add(path)
+		}
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		if ov.IsNil() || nv.IsNil() {
+			if ov.IsNil() != nv.IsNil() {
+				d.add(path)
+			}
+			return
+		}
+		d.diff(path, ov.Elem(), nv.Elem())
+
+	case reflect.Interface:
+		if ov.IsNil() || nv.IsNil() || ov.Elem().Type() != nv.Elem().Type() {
+			if !(ov.IsNil() && nv.IsNil()) {
+				d.add(path)
+			}
+			return
+		}
+		d.diff(path, ov.Elem(), nv.Elem())
+
+	case reflect.Struct:
+		d.diffStruct(path, ov, nv)
+
+	default:
+		if !reflect.DeepEqual(ov.Interface(), nv.Interface()) {
+			d.add(path)
+		}
+	}
+}
+
+// diffStruct 比较结构体的导出字段
+func (d *differ) diffStruct(path string, ov, nv reflect.Value) {
+	typ := ov.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		// 匿名嵌入且没有 json 标签的结构体：展开到当前层级
+		if field.Anonymous && name == field.Name && indirectKind(field.Type) == reflect.Struct {
+			d.diff(path, derefOrZero(ov.Field(i)), derefOrZero(nv.Field(i)))
+			continue
+		}
+
+		d.diff(joinPath(path, name), ov.Field(i), nv.Field(i))
+	}
+}
+
+// diffMap 按键比较 string 键的 map
+func (d *differ) diffMap(path string, ov, nv reflect.Value) {
+	keys := make(map[string]bool, ov.Len()+nv.Len())
+	for _, k := range ov.MapKeys() {
+		keys[k.String()] = true
+	}
+	for _, k := range nv.MapKeys() {
+		keys[k.String()] = true
+	}
+
+	keyType := ov.Type().Key()
+	for key := range keys {
+		k := reflect.ValueOf(key).Convert(keyType)
+		oe, ne := ov.MapIndex(k), nv.MapIndex(k)
+		sub := joinPath(path, key)
+		if !oe.IsValid() || !ne.IsValid() {
+			d.add(sub)
+			continue
+		}
+		d.diff(sub, oe, ne)
+	}
+}
+
+// leafEqual 整体比较的类型
+// 返回值：ok 为 false 表示不是整体比较的类型
+func leafEqual(ov, nv reflect.Value) (equal, ok bool) {
+	typ := ov.Type()
+	if typ.Kind() == reflect.Interface {
+		return false, false
+	}
+
+	// Equal(T) bool，如 time.Time、Money
+	if method, found := typ.MethodByName("Equal"); found &&
+		method.Type.NumIn() == 2 && method.Type.In(1) == typ &&
+		method.Type.NumOut() == 1 && method.Type.Out(0).Kind() == reflect.Bool {
+		if typ.Kind() == reflect.Ptr && (ov.IsNil() || nv.IsNil()) {
+			return ov.IsNil() == nv.IsNil(), true
+		}
+		return method.Func.Call([]reflect.Value{ov, nv})[0].Bool(), true
+	}
+
+	// 自定义 JSON 序列化，如 MilliTime、Optional
+	if typ.Kind() != reflect.Ptr && typ.Implements(marshalerType) {
+		ob, oerr := json.Marshal(ov.Interface())
+		nb, nerr := json.Marshal(nv.Interface())
+		if oerr != nil || nerr != nil {
+			return reflect.DeepEqual(ov.Interface(), nv.Interface()), true
+		}
+		return bytes.Equal(ob, nb), true
+	}
+
+	return false, false
+}
+
+// jsonFieldName 获取字段的 json 名称
+func jsonFieldName(field reflect.StructField) (name string, skip bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return field.Name, false
+	}
+	name, _, _ = strings.Cut(tag, ",")
+	if name == "-" {
+		return "", true
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, false
+}
+
+// derefOrZero 解引用指针，nil 指针返回零值
+func derefOrZero(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Zero(v.Type().Elem())
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// indirectKind 解引用后的类型种类
+func indirectKind(typ reflect.Type) reflect.Kind {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Kind()
+}
+
+// joinPath 拼接路径
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// firstNonNil 返回第一个非 nil 的值
+func firstNonNil(values ...any) any {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
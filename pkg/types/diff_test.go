@@ -0,0 +1,101 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// Diff 测试
+// ============================================================================
+
+type diffProfile struct {
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar,omitempty"`
+}
+
+type diffUser struct {
+	ID       int64        `json:"id"`
+	Email    string       `json:"email"`
+	Password string       `json:"-"`
+	Profile  diffProfile  `json:"profile"`
+	Backup   *diffProfile `json:"backup"`
+	Tags     []string     `json:"tags"`
+	Balance  Money        `json:"balance"`
+	Birthday time.Time    `json:"birthday"`
+	Extras   Extras       `json:"extras"`
+	ModelTimes
+}
+
+func TestDiff(t *testing.T) {
+	base := func() diffUser {
+		return diffUser{
+			ID:       1,
+			Email:    "a@example.com",
+			Profile:  diffProfile{Nickname: "tom"},
+			Tags:     []string{"a"},
+			Balance:  MustParseMoney("10.00", "CNY"),
+			Birthday: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			Extras:   Extras{"theme": "dark", "prefs": map[string]any{"lang": "zh"}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(u *diffUser)
+		want   []string
+	}{
+		{"no change", func(u *diffUser) {}, nil},
+		{"json name", func(u *diffUser) { u.Email = "b@example.com" }, []string{"email"}},
+		{"ignored field", func(u *diffUser) { u.Password = "secret" }, nil},
+		{"nested struct", func(u *diffUser) { u.Profile.Nickname = "jerry" }, []string{"profile.nickname"}},
+		{"nil pointer", func(u *diffUser) { u.Backup = &diffProfile{} }, []string{"backup"}},
+		{"slice", func(u *diffUser) { u.Tags = append(u.Tags, "b") }, []string{"tags"}},
+		{"equal method", func(u *diffUser) { u.Balance = MustParseMoney("10", "CNY") }, nil},
+		{"same instant", func(u *diffUser) { u.Birthday = u.Birthday.In(time.FixedZone("CST", 8*3600)) }, nil},
+		{"extras key", func(u *diffUser) { u.Extras["theme"] = "light" }, []string{"extras.theme"}},
+		{"extras nested", func(u *diffUser) { u.Extras["prefs"] = map[string]any{"lang": "en"} }, []string{"extras.prefs.lang"}},
+		{"extras added and removed", func(u *diffUser) {
+			delete(u.Extras, "theme")
+			u.Extras["vip"] = true
+		}, []string{"extras.theme", "extras.vip"}},
+		{"embedded struct", func(u *diffUser) {
+			u.UpdatedAt = NewMilliTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		}, []string{"updated_at"}},
+		{"sorted", func(u *diffUser) {
+			u.ID = 2
+			u.Email = "b@example.com"
+		}, []string{"email", "id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old, new := base(), base()
+			tt.modify(&new)
+
+			got, err := Diff(&old, &new)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff_Errors(t *testing.T) {
+	if _, err := Diff(&diffUser{}, &diffProfile{}); err == nil {
+		t.Error("Diff() with different types should fail")
+	}
+	if _, err := Diff(nil, &diffUser{}); err == nil {
+		t.Error("Diff() with nil should fail")
+	}
+
+	// nil 指针视为零值
+	got, err := Diff((*diffUser)(nil), &diffUser{Email: "a@example.com"})
+	if err != nil || !reflect.DeepEqual(got, []string{"email"}) {
+		t.Errorf("Diff(nil ptr) = %v, %v", got, err)
+	}
+}
@@ -0,0 +1,307 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ============================================================================
+// 枚举
+// ============================================================================
+
+// ErrInvalidEnumValue 值不在枚举中
+var ErrInvalidEnumValue = errors.New("enum: invalid value")
+
+// EnumValue 枚举的底层类型（字符串或整数）
+type EnumValue interface {
+	~string | ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32
+}
+
+// EnumOption 枚举选项（值和显示名称，用于下拉框等）
+type EnumOption[T EnumValue] struct {
+	Value T      `json:"value"`
+	Label string `json:"label"`
+}
+
+// Enum 枚举定义：允许的值及其显示名称，按名称注册到全局表
+//
+// 设计说明：
+//   - 取值范围只在 Go 常量旁声明一次，验证规则 enum=名称、JSON、数据库读写都从这里检查，
+//     替代各处手写的 oneof 列表（常量增减时 oneof 容易漏改）
+//   - Go 不能为类型参数添加方法，枚举类型的 JSON/数据库接口通过一行委托实现
+//
+// 使用示例：
+//
+//	type UserRole string
+//
+//	const (
+//	    RoleAdmin  UserRole = "admin"
+//	    RoleMember UserRole = "member"
+//	)
+//
+//	var UserRoles = types.NewEnum[UserRole]("UserRole").
+//	    Add(RoleAdmin, "管理员").
+//	    Add(RoleMember, "成员")
+//
+//	func (r UserRole) Valid() bool                    { return UserRoles.Valid(r) }
+//	func (r UserRole) MarshalJSON() ([]byte, error)   { return UserRoles.EncodeJSON(r) }
+//	func (r *UserRole) UnmarshalJSON(b []byte) error  { return UserRoles.DecodeJSON(b, r) }
+//	func (r UserRole) Value() (driver.Value, error)   { return UserRoles.Value(r) }
+//	func (r *UserRole) Scan(src any) error            { return UserRoles.Scan(src, r) }
+//
+// 注意事项：
+// - 在包初始化时（变量声明中）完成注册，注册后只读，可并发使用
+// - 名称全局唯一，重复注册 panic；同一枚举中的值重复 panic
+type Enum[T EnumValue] struct {
+	name    string
+	options []EnumOption[T]
+	index   map[string]int // 值的文本形式 -> options 下标
+}
+
+// enumChecker 按名称检查值（验证规则 enum=名称 使用，不依赖类型参数）
+type enumChecker interface {
+	isString() bool
+	hasText(text string) bool
+}
+
+var (
+	enumMu       sync.RWMutex
+	enumRegistry = make(map[string]enumChecker)
+)
+
+// NewEnum 创建并注册枚举，name 为验证规则中使用的名称（如 enum=UserRole）
+func NewEnum[T EnumValue](name string) *Enum[T] {
+	if name == "" {
+		panic("enum: name cannot be empty")
+	}
+	e := &Enum[T]{name: name, index: make(map[string]int)}
+
+	enumMu.Lock()
+	defer enumMu.Unlock()
+	if _, dup := enumRegistry[name]; dup {
+		panic(fmt.Sprintf("enum: %s already registered", name))
+	}
+	enumRegistry[name] = e
+	return e
+}
+
+// Add 添加枚举值及其显示名称（按添加顺序排列）
+func (e *Enum[T]) Add(value T, label string) *Enum[T] {
+	text := enumText(value)
+	if _, dup := e.index[text]; dup {
+		panic(fmt.Sprintf("enum %s: duplicate value %s", e.name, text))
+	}
+	e.index[text] = len(e.options)
+	e.options = append(e.options, EnumOption[T]{Value: value, Label: label})
+	return e
+}
+
+// Name 枚举名称
+func (e *Enum[T]) Name() string {
+	return e.name
+}
+
+// Values 所有枚举值（按添加顺序）
+func (e *Enum[T]) Values() []T {
+	values := make([]T, len(e.options))
+	for i, opt := range e.options {
+		values[i] = opt.Value
+	}
+	return values
+}
+
+// Options 所有选项（按添加顺序，返回副本）
+func (e *Enum[T]) Options() []EnumOption[T] {
+	return append([]EnumOption[T](nil), e.options...)
+}
+
+// Valid 值是否在枚举中
+func (e *Enum[T]) Valid(v T) bool {
+	_, ok := e.index[enumText(v)]
+	return ok
+}
+
+// Label 显示名称，值不在枚举中时返回值的文本形式
+func (e *Enum[T]) Label(v T) string {
+	text := enumText(v)
+	if i, ok := e.index[text]; ok {
+		return e.options[i].Label
+	}
+	return text
+}
+
+// Parse 解析文本形式的值（如查询参数），整数枚举接受十进制数字
+func (e *Enum[T]) Parse(s string) (T, error) {
+	i, ok := e.index[strings.TrimSpace(s)]
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%w %q for %s", ErrInvalidEnumValue, s, e.name)
+	}
+	return e.options[i].Value, nil
+}
+
+// MustParse 解析文本形式的值，失败时 panic
+func (e *Enum[T]) MustParse(s string) T {
+	v, err := e.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String 枚举的文本形式（如 admin|member，用于错误消息和文档）
+func (e *Enum[T]) String() string {
+	texts := make([]string, len(e.options))
+	for i, opt := range e.options {
+		texts[i] = enumText(opt.Value)
+	}
+	return strings.Join(texts, "|")
+}
+
+// isString 实现 enumChecker
+func (e *Enum[T]) isString() bool {
+	var zero T
+	return reflect.ValueOf(zero).Kind() == reflect.String
+}
+
+// hasText 实现 enumChecker
+func (e *Enum[T]) hasText(text string) bool {
+	_, ok := e.index[text]
+	return ok
+}
+
+// ============================================================================
+// 序列化（供枚举类型的方法委托）
+// ============================================================================
+
+// EncodeJSON 序列化为底层值（字符串或数字），值不在枚举中时返回错误
+func (e *Enum[T]) EncodeJSON(v T) ([]byte, error) {
+	if !e.Valid(v) {
+		return nil, fmt.Errorf("%w %s for %s", ErrInvalidEnumValue, enumText(v), e.name)
+	}
+	if e.isString() {
+		return json.Marshal(enumText(v))
+	}
+	return []byte(enumText(v)), nil
+}
+
+// DecodeJSON 反序列化并检查取值范围（整数枚举同时接受数字字符串），null 保持原值
+func (e *Enum[T]) DecodeJSON(data []byte, dst *T) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	text := string(data)
+	if e.isString() || (len(data) > 0 && data[0] == '"') {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return fmt.Errorf("enum %s: %w", e.name, err)
+		}
+	}
+	v, err := e.Parse(text)
+	if err != nil {
+		return err
+	}
+	*dst = v
+	return nil
+}
+
+// Value 写入数据库（字符串枚举为 string，整数枚举为 int64），值不在枚举中时返回错误
+func (e *Enum[T]) Value(v T) (driver.Value, error) {
+	if !e.Valid(v) {
+		return nil, fmt.Errorf("%w %s for %s", ErrInvalidEnumValue, enumText(v), e.name)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return int64(rv.Uint()), nil
+	default:
+		return rv.Int(), nil
+	}
+}
+
+// Scan 从数据库读取并检查取值范围，NULL 时为零值
+func (e *Enum[T]) Scan(src any, dst *T) error {
+	var text string
+	switch v := src.(type) {
+	case nil:
+		var zero T
+		*dst = zero
+		return nil
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	case int64:
+		text = strconv.FormatInt(v, 10)
+	default:
+		return fmt.Errorf("enum %s: cannot scan type %T", e.name, src)
+	}
+
+	v, err := e.Parse(text)
+	if err != nil {
+		return err
+	}
+	*dst = v
+	return nil
+}
+
+// enumText 值的文本形式（字符串为原值，整数为十进制）
+func enumText[T EnumValue](v T) string {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return strconv.FormatUint(rv.Uint(), 10)
+	default:
+		return strconv.FormatInt(rv.Int(), 10)
+	}
+}
+
+// ============================================================================
+// 按名称查询
+// ============================================================================
+
+// IsEnumValue 值是否属于已注册的枚举（验证规则 enum=名称 使用）
+// 说明：value 可以是字符串、整数或以它们为底层类型的枚举类型；枚举未注册或类型不匹配（字符串与整数）时返回 false
+func IsEnumValue(name string, value any) bool {
+	enumMu.RLock()
+	checker, ok := enumRegistry[name]
+	enumMu.RUnlock()
+	if !ok {
+		return false
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		return checker.isString() && checker.hasText(rv.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return !checker.isString() && checker.hasText(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return !checker.isString() && checker.hasText(strconv.FormatUint(rv.Uint(), 10))
+	default:
+		return false
+	}
+}
+
+// EnumNames 已注册的枚举名称（排序后）
+func EnumNames() []string {
+	enumMu.RLock()
+	defer enumMu.RUnlock()
+	names := make([]string, 0, len(enumRegistry))
+	for name := range enumRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
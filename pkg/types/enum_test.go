@@ -0,0 +1,171 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// ============================================================================
+// 枚举测试
+// ============================================================================
+
+type testRole string
+
+const (
+	testRoleAdmin  testRole = "admin"
+	testRoleMember testRole = "member"
+)
+
+var testRoles = NewEnum[testRole]("testRole").
+	Add(testRoleAdmin, "管理员").
+	Add(testRoleMember, "成员")
+
+func (r testRole) MarshalJSON() ([]byte, error)  { return testRoles.EncodeJSON(r) }
+func (r *testRole) UnmarshalJSON(b []byte) error { return testRoles.DecodeJSON(b, r) }
+
+type testLevel int8
+
+var testLevels = NewEnum[testLevel]("testLevel").
+	Add(1, "低").
+	Add(2, "中").
+	Add(3, "高")
+
+// TestEnumBasics 测试取值范围和显示名称
+func TestEnumBasics(t *testing.T) {
+	if !testRoles.Valid(testRoleAdmin) || testRoles.Valid("guest") {
+		t.Error("Valid 结果不正确")
+	}
+	if got := testRoles.Values(); !reflect.DeepEqual(got, []testRole{testRoleAdmin, testRoleMember}) {
+		t.Errorf("Values() = %v", got)
+	}
+	if got := testRoles.Label(testRoleMember); got != "成员" {
+		t.Errorf("Label() = %q", got)
+	}
+	if got := testRoles.Label("guest"); got != "guest" {
+		t.Errorf("未知值的 Label() = %q", got)
+	}
+	if got := testLevels.String(); got != "1|2|3" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := testRoles.Options(); len(got) != 2 || got[0].Label != "管理员" {
+		t.Errorf("Options() = %v", got)
+	}
+}
+
+// TestEnumParse 测试文本解析
+func TestEnumParse(t *testing.T) {
+	if v, err := testRoles.Parse("member"); err != nil || v != testRoleMember {
+		t.Errorf("Parse(member) = %v, %v", v, err)
+	}
+	if _, err := testRoles.Parse("guest"); !errors.Is(err, ErrInvalidEnumValue) {
+		t.Errorf("Parse(guest) error = %v", err)
+	}
+	if v, err := testLevels.Parse(" 2 "); err != nil || v != 2 {
+		t.Errorf("Parse(2) = %v, %v", v, err)
+	}
+	if _, err := testLevels.Parse("9"); err == nil {
+		t.Error("Parse(9) 应该失败")
+	}
+}
+
+// TestEnumJSON 测试 JSON 序列化
+func TestEnumJSON(t *testing.T) {
+	var payload struct {
+		Role testRole `json:"role"`
+	}
+	if err := json.Unmarshal([]byte(`{"role":"admin"}`), &payload); err != nil || payload.Role != testRoleAdmin {
+		t.Fatalf("Unmarshal = %v, %v", payload.Role, err)
+	}
+	if err := json.Unmarshal([]byte(`{"role":"guest"}`), &payload); !errors.Is(err, ErrInvalidEnumValue) {
+		t.Errorf("未知值 Unmarshal error = %v", err)
+	}
+	if _, err := json.Marshal(testRole("guest")); err == nil {
+		t.Error("未知值 Marshal 应该失败")
+	}
+	data, _ := json.Marshal(payload)
+	if string(data) != `{"role":"admin"}` {
+		t.Errorf("Marshal = %s", data)
+	}
+
+	var level testLevel
+	for _, input := range []string{`3`, `"3"`} {
+		if err := testLevels.DecodeJSON([]byte(input), &level); err != nil || level != 3 {
+			t.Errorf("DecodeJSON(%s) = %v, %v", input, level, err)
+		}
+	}
+	if data, err := testLevels.EncodeJSON(2); err != nil || string(data) != "2" {
+		t.Errorf("EncodeJSON(2) = %s, %v", data, err)
+	}
+}
+
+// TestEnumDatabase 测试数据库读写
+func TestEnumDatabase(t *testing.T) {
+	if v, err := testRoles.Value(testRoleAdmin); err != nil || v != "admin" {
+		t.Errorf("Value() = %v, %v", v, err)
+	}
+	if v, err := testLevels.Value(3); err != nil || v != int64(3) {
+		t.Errorf("Value() = %v, %v", v, err)
+	}
+	if _, err := testLevels.Value(7); err == nil {
+		t.Error("未知值 Value 应该失败")
+	}
+
+	var role testRole
+	if err := testRoles.Scan([]byte("member"), &role); err != nil || role != testRoleMember {
+		t.Errorf("Scan([]byte) = %v, %v", role, err)
+	}
+	if err := testRoles.Scan(nil, &role); err != nil || role != "" {
+		t.Errorf("Scan(nil) = %v, %v", role, err)
+	}
+	var level testLevel
+	if err := testLevels.Scan(int64(2), &level); err != nil || level != 2 {
+		t.Errorf("Scan(int64) = %v, %v", level, err)
+	}
+	if err := testLevels.Scan(int64(8), &level); err == nil {
+		t.Error("Scan 未知值应该失败")
+	}
+	if err := testLevels.Scan(2.5, &level); err == nil {
+		t.Error("Scan float64 应该失败")
+	}
+}
+
+// TestIsEnumValue 测试按名称检查
+func TestIsEnumValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"testRole", "admin", true},
+		{"testRole", testRoleMember, true},
+		{"testRole", "guest", false},
+		{"testLevel", 2, true},
+		{"testLevel", testLevel(3), true},
+		{"testLevel", uint(1), true},
+		{"testLevel", 4, false},
+		{"testLevel", "2", false},
+		{"testRole", 1, false},
+		{"unknown", "admin", false},
+	}
+	for _, tt := range tests {
+		if got := IsEnumValue(tt.name, tt.value); got != tt.want {
+			t.Errorf("IsEnumValue(%s, %v) = %v, 期望 %v", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestNewEnumDuplicate 测试重复注册
+func TestNewEnumDuplicate(t *testing.T) {
+	assertPanics := func(name string, fn func()) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s 应该 panic", name)
+			}
+		}()
+		fn()
+	}
+	assertPanics("重复名称", func() { NewEnum[testRole]("testRole") })
+	assertPanics("重复值", func() { NewEnum[testLevel]("testLevelDup").Add(1, "a").Add(1, "b") })
+}
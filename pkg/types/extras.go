@@ -1,12 +1,14 @@
 package types
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"maps"
 	"math"
 	"reflect"
+	"slices"
 	"strings"
 	"unsafe"
 )
@@ -1514,6 +1516,14 @@ func (e Extras) Keys() []string {
 	return keys
 }
 
+// SortedKeys 返回按键名升序排序的所有键
+// 说明：与 MarshalJSON 的键顺序一致，可用于稳定的遍历和签名计算
+func (e Extras) SortedKeys() []string {
+	keys := e.Keys()
+	slices.Sort(keys)
+	return keys
+}
+
 // KeysBuffer 将键写入提供的缓冲区（零分配版本，适合高频调用场景）
 func (e Extras) KeysBuffer(buf []string) []string {
 	// 重用切片内存
@@ -1960,6 +1970,16 @@ func (e Extras) Range(fn func(key string, value any) bool) {
 	}
 }
 
+// RangeSorted 按键名升序遍历所有键值对（顺序稳定，需要分配键切片）
+// 说明：需要保留插入顺序时请使用 OrderedExtras
+func (e Extras) RangeSorted(fn func(key string, value any) bool) {
+	for _, k := range e.SortedKeys() {
+		if !fn(k, e[k]) {
+			return
+		}
+	}
+}
+
 // RangeKeys 仅遍历键（零分配+线程不安全)
 func (e Extras) RangeKeys(fn func(key string) bool) {
 	for k := range e {
@@ -2081,6 +2101,7 @@ func (e Extras) PrettyJSON() ([]byte, error) {
 // ============================================================================
 
 // MarshalJSON 实现 json.Marshaler 接口
+// 说明：encoding/json 按键名排序输出 map（包括嵌套 map），相同内容的序列化结果总是一致
 //
 //go:inline
 func (e Extras) MarshalJSON() ([]byte, error) {
@@ -2124,6 +2145,31 @@ func (e *Extras) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalText 实现 encoding.TextMarshaler 接口，输出紧凑 JSON（与 CompactJSON 一致）
+func (e Extras) MarshalText() ([]byte, error) {
+	return e.CompactJSON()
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler 接口，解析 JSON 对象（空文本视为空 Extras）
+// 说明：用于环境变量、命令行参数等文本配置
+func (e *Extras) UnmarshalText(text []byte) error {
+	if len(bytes.TrimSpace(text)) == 0 {
+		*e = make(Extras)
+		return nil
+	}
+	return e.FromJSON(text)
+}
+
+// String 实现 fmt.Stringer 接口，输出紧凑 JSON（与 MarshalText 一致）
+// 说明：包含无法序列化的值时回退为 map 的默认格式
+func (e Extras) String() string {
+	data, err := e.CompactJSON()
+	if err != nil {
+		return fmt.Sprint(map[string]any(e))
+	}
+	return string(data)
+}
+
 // Value 实现 driver.Valuer 接口，用于数据库存储
 func (e Extras) Value() (driver.Value, error) {
 	data, err := json.Marshal(e)
@@ -0,0 +1,170 @@
+package types
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// 数值聚合
+// ============================================================================
+
+// SumInt 对路径匹配的所有值求整数和
+// 路径语法：
+// - 点号分隔，与 GetPath 相同，如 "stats.views"
+// - "*" 匹配对象的所有值或切片的所有元素，如 "items.*.price"、"scores.*"
+// - 数字段匹配切片的指定下标，如 "items.0.price"
+// - 路径终点为切片时，对切片的所有元素聚合，如 "ids"
+// 返回值：
+// - 路径不存在（不含通配符的部分找不到）时返回 false
+// - 任意一个匹配值无法通过 convertToInt64 转换（字符串、nil、非整数浮点数等）或求和溢出时返回 false
+// - 通配符没有匹配到值时返回 0, true；通配符下缺少后续路径的元素被跳过
+func (e Extras) SumInt(path string) (int64, bool) {
+	values, ok := e.aggregateValues(path)
+	if !ok {
+		return 0, false
+	}
+
+	var sum int64
+	for _, value := range values {
+		n, ok := convertToInt64(value)
+		if !ok {
+			return 0, false
+		}
+		if (n > 0 && sum > math.MaxInt64-n) || (n < 0 && sum < math.MinInt64-n) {
+			return 0, false
+		}
+		sum += n
+	}
+	return sum, true
+}
+
+// SumFloat 对路径匹配的所有值求浮点数和（路径语法和返回值同 SumInt）
+func (e Extras) SumFloat(path string) (float64, bool) {
+	values, ok := e.aggregateValues(path)
+	if !ok {
+		return 0, false
+	}
+
+	var sum float64
+	for _, value := range values {
+		f, ok := convertToFloat64(value)
+		if !ok {
+			return 0, false
+		}
+		sum += f
+	}
+	return sum, true
+}
+
+// MinMax 返回路径匹配的所有值的最小值和最大值（路径语法同 SumInt）
+// 说明：没有匹配到值或任意一个值不是数值时返回 false
+func (e Extras) MinMax(path string) (min, max float64, ok bool) {
+	values, ok := e.aggregateValues(path)
+	if !ok || len(values) == 0 {
+		return 0, 0, false
+	}
+
+	for i, value := range values {
+		f, ok := convertToFloat64(value)
+		if !ok {
+			return 0, 0, false
+		}
+		if i == 0 || f < min {
+			min = f
+		}
+		if i == 0 || f > max {
+			max = f
+		}
+	}
+	return min, max, true
+}
+
+// aggregateValues 收集路径匹配的所有值
+func (e Extras) aggregateValues(path string) ([]any, bool) {
+	if len(path) == 0 {
+		return nil, false
+	}
+	segments := strings.Split(path, ".")
+	for _, segment := range segments {
+		if len(segment) == 0 {
+			return nil, false
+		}
+	}
+
+	var values []any
+	if !collectPathValues(map[string]any(e), segments, &values) {
+		return nil, false
+	}
+	return values, true
+}
+
+// collectPathValues 按路径段递归收集值，返回路径是否存在
+// 说明：通配符下的子路径不存在时跳过该元素，不影响整体结果
+func collectPathValues(current any, segments []string, out *[]any) bool {
+	if len(segments) == 0 {
+		// 终点为切片时展开一层
+		if rv, ok := aggregateSlice(current); ok {
+			for i := 0; i < rv.Len(); i++ {
+				*out = append(*out, rv.Index(i).Interface())
+			}
+			return true
+		}
+		*out = append(*out, current)
+		return true
+	}
+
+	segment, rest := segments[0], segments[1:]
+
+	var m map[string]any
+	switch v := current.(type) {
+	case Extras:
+		m = v
+	case map[string]any:
+		m = v
+	}
+	if m != nil {
+		if segment == "*" {
+			for _, value := range m {
+				collectPathValues(value, rest, out)
+			}
+			return true
+		}
+		value, exists := m[segment]
+		if !exists {
+			return false
+		}
+		return collectPathValues(value, rest, out)
+	}
+
+	rv, ok := aggregateSlice(current)
+	if !ok {
+		return false
+	}
+	if segment == "*" {
+		for i := 0; i < rv.Len(); i++ {
+			collectPathValues(rv.Index(i).Interface(), rest, out)
+		}
+		return true
+	}
+	index, err := strconv.Atoi(segment)
+	if err != nil || index < 0 || index >= rv.Len() {
+		return false
+	}
+	return collectPathValues(rv.Index(index).Interface(), rest, out)
+}
+
+// aggregateSlice 检查值是否为可聚合的切片或数组（不含 []byte）
+func aggregateSlice(value any) (reflect.Value, bool) {
+	switch value.(type) {
+	case nil, []byte:
+		return reflect.Value{}, false
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return reflect.Value{}, false
+	}
+	return rv, true
+}
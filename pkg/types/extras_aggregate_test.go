@@ -0,0 +1,86 @@
+package types
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+// ============================================================================
+// 数值聚合测试
+// ============================================================================
+
+// TestExtrasAggregate 测试数值聚合
+func TestExtrasAggregate(t *testing.T) {
+	var e Extras
+	data := `{
+		"items": [
+			{"price": 10, "qty": 2, "tags": [1, 2]},
+			{"price": 2.5, "qty": 1, "tags": [3]},
+			{"qty": 3}
+		],
+		"scores": {"math": 90, "art": 75},
+		"names": ["a", "b"],
+		"mixed": [1, "2"],
+		"empty": []
+	}`
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		t.Fatal(err)
+	}
+	e.Set("ids", []int{1, 2, 3})
+
+	tests := []struct {
+		name   string
+		got    aggregateResult
+		want   float64
+		wantOK bool
+	}{
+		{"SumInt 通配符", intResult(e.SumInt("items.*.qty")), 6, true},
+		{"SumInt 非整数", intResult(e.SumInt("items.*.price")), 0, false},
+		{"SumFloat 通配符跳过缺少字段的元素", floatResult(e.SumFloat("items.*.price")), 12.5, true},
+		{"SumInt 对象的所有值", intResult(e.SumInt("scores.*")), 165, true},
+		{"SumInt 终点为切片", intResult(e.SumInt("ids")), 6, true},
+		{"SumInt 嵌套切片展开", intResult(e.SumInt("items.*.tags")), 6, true},
+		{"SumInt 下标", intResult(e.SumInt("items.1.qty")), 1, true},
+		{"SumInt 下标越界", intResult(e.SumInt("items.5.qty")), 0, false},
+		{"SumInt 非数值", intResult(e.SumInt("names")), 0, false},
+		{"SumFloat 混合类型", floatResult(e.SumFloat("mixed")), 0, false},
+		{"SumInt 空切片", intResult(e.SumInt("empty")), 0, true},
+		{"SumInt 路径不存在", intResult(e.SumInt("missing.*")), 0, false},
+		{"SumInt 空路径段", intResult(e.SumInt("items..qty")), 0, false},
+	}
+	for _, tt := range tests {
+		if tt.got.value != tt.want || tt.got.ok != tt.wantOK {
+			t.Errorf("%s = %v, %v, 期望 %v, %v", tt.name, tt.got.value, tt.got.ok, tt.want, tt.wantOK)
+		}
+	}
+
+	if min, max, ok := e.MinMax("items.*.price"); !ok || min != 2.5 || max != 10 {
+		t.Errorf("MinMax(items.*.price) = %v, %v, %v", min, max, ok)
+	}
+	if _, _, ok := e.MinMax("empty"); ok {
+		t.Error("MinMax 没有匹配值时应返回 false")
+	}
+	if _, _, ok := e.MinMax("mixed"); ok {
+		t.Error("MinMax 包含非数值时应返回 false")
+	}
+
+	overflow := Extras{"n": []int64{math.MaxInt64, 1}}
+	if _, ok := overflow.SumInt("n"); ok {
+		t.Error("SumInt 溢出时应返回 false")
+	}
+}
+
+// aggregateResult 聚合结果（统一为 float64 便于表驱动比较）
+type aggregateResult struct {
+	value float64
+	ok    bool
+}
+
+func intResult(v int64, ok bool) aggregateResult {
+	return aggregateResult{float64(v), ok}
+}
+
+func floatResult(v float64, ok bool) aggregateResult {
+	return aggregateResult{v, ok}
+}
@@ -0,0 +1,316 @@
+package types
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"katydid-common-account/pkg/validator/errs"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// 绑定到结构体
+// ============================================================================
+
+// 绑定错误的标签
+const (
+	BindTagType    = "type"    // 值无法转换为字段类型
+	BindTagDefault = "default" // default 标签的值无法转换为字段类型
+	BindTagUnknown = "unknown" // 结构体没有声明该键（ErrorOnUnknown）
+)
+
+// BindOptions 绑定选项
+type BindOptions struct {
+	// Defaults 键不存在或为 null 时使用字段的 default 标签填充（如 `default:"30s"`）
+	Defaults bool
+	// ErrorOnUnknown 结构体没有声明的键报告 unknown 错误（默认忽略）
+	ErrorOnUnknown bool
+}
+
+// BindExtras 将 Extras 绑定到结构体（按 json 标签匹配键，没有标签时使用 Go 字段名）
+// 说明：
+// - target 必须是结构体指针；未出现在 Extras 中的字段保持原值（开启 Defaults 时使用 default 标签）
+// - 嵌套结构体的值为 map 时递归绑定，内部字段的默认值和未知键同样生效；匿名嵌入的结构体按 encoding/json 的规则展开
+// - 值与字段类型不同时按 JSON 规则转换（float64 -> int 要求是整数），time.Duration 额外支持 "30s" 形式的字符串
+// - 所有字段绑定完成后统一返回错误：*errs.ValidationError，每个字段一条 FieldError（命名空间如 Config.retry.max）
+//
+// 示例：
+//
+//	type PaymentConfig struct {
+//	    Timeout time.Duration `json:"timeout" default:"30s"`
+//	    Retries int           `json:"retries" default:"3"`
+//	}
+//
+//	var cfg PaymentConfig
+//	err := types.BindExtras(merchant.Extras, &cfg, types.BindOptions{Defaults: true})
+func BindExtras(e Extras, target any, opts BindOptions) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind extras: target must be a non-nil pointer to struct, got %T", target)
+	}
+
+	b := &extrasBinder{opts: opts}
+	b.bindStruct(v.Elem(), e, v.Elem().Type().Name())
+	if len(b.errors) == 0 {
+		return nil
+	}
+	return &errs.ValidationError{Message: "bind extras failed", Errors: b.errors}
+}
+
+// extrasBinder 绑定过程的状态
+type extrasBinder struct {
+	opts   BindOptions
+	errors []*errs.FieldError
+}
+
+// bindStruct 绑定一层结构体，namespace 为当前结构体的命名空间
+func (b *extrasBinder) bindStruct(v reflect.Value, kvs map[string]any, namespace string) {
+	known := make(map[string]struct{}, v.NumField())
+	b.bindFields(v, kvs, namespace, known)
+
+	if !b.opts.ErrorOnUnknown {
+		return
+	}
+	unknown := make([]string, 0)
+	for key := range kvs {
+		if _, ok := known[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		b.addError(namespace+"."+key, BindTagUnknown, "", kvs[key],
+			fmt.Sprintf("unknown key %q", key))
+	}
+}
+
+// bindFields 绑定结构体字段，记录已声明的键（匿名嵌入结构体的字段提升到当前层）
+func (b *extrasBinder) bindFields(v reflect.Value, kvs map[string]any, namespace string, known map[string]struct{}) {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		fieldValue := v.Field(i)
+		if field.Anonymous && name == "" && indirectStructType(field.Type) != nil {
+			if embedded := settableStruct(fieldValue); embedded.IsValid() {
+				b.bindFields(embedded, kvs, namespace, known)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[name] = struct{}{}
+
+		fieldNamespace := namespace + "." + name
+		raw, ok := kvs[name]
+		switch {
+		case ok && raw != nil:
+			b.bindValue(fieldValue, raw, fieldNamespace)
+		case b.opts.Defaults:
+			b.applyDefault(fieldValue, field, fieldNamespace)
+		}
+	}
+}
+
+// bindValue 绑定单个值（嵌套结构体的值为 map 时递归绑定）
+func (b *extrasBinder) bindValue(field reflect.Value, raw any, namespace string) {
+	if nested, ok := asStringMap(raw); ok && indirectStructType(field.Type()) != nil && !isBindLeaf(field.Type()) {
+		b.bindStruct(settableStruct(field), nested, namespace)
+		return
+	}
+	if err := assignValue(field, raw); err != nil {
+		b.addError(namespace, BindTagType, field.Type().String(), raw,
+			fmt.Sprintf("cannot bind %T to %s: %v", raw, field.Type(), err))
+	}
+}
+
+// applyDefault 使用 default 标签填充字段；没有标签的嵌套结构体递归填充内部字段的默认值
+func (b *extrasBinder) applyDefault(field reflect.Value, sf reflect.StructField, namespace string) {
+	def, ok := sf.Tag.Lookup("default")
+	if !ok {
+		if field.Kind() == reflect.Struct && !isBindLeaf(field.Type()) {
+			b.bindStruct(field, nil, namespace)
+		}
+		return
+	}
+	if err := assignString(field, def); err != nil {
+		b.addError(namespace, BindTagDefault, def, def,
+			fmt.Sprintf("invalid default %q for %s: %v", def, field.Type(), err))
+	}
+}
+
+// addError 记录字段错误
+func (b *extrasBinder) addError(namespace, tag, param string, value any, message string) {
+	b.errors = append(b.errors, &errs.FieldError{
+		Namespace: namespace,
+		Field:     errs.FieldName(namespace),
+		Tag:       tag,
+		Param:     param,
+		Value:     value,
+		Message:   message,
+	})
+}
+
+// durationType time.Duration 的反射类型
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// textUnmarshalerType encoding.TextUnmarshaler 的反射类型
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// jsonUnmarshalerType json.Unmarshaler 的反射类型
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// assignValue 将 Extras 中的值赋给字段（类型可直接赋值时直接赋值，否则按 JSON 规则转换）
+func assignValue(field reflect.Value, raw any) error {
+	rv := reflect.ValueOf(raw)
+	if rv.Type().AssignableTo(field.Type()) {
+		field.Set(rv)
+		return nil
+	}
+	if field.Type() == durationType {
+		if s, ok := raw.(string); ok {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return err
+			}
+			field.SetInt(int64(d))
+			return nil
+		}
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	ptr := reflect.New(field.Type())
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return err
+	}
+	field.Set(ptr.Elem())
+	return nil
+}
+
+// assignString 将 default 标签的字符串赋给字段
+// 说明：字符串类型直接赋值；数值、布尔、time.Duration 按字面量解析；
+// 实现 TextUnmarshaler 的类型按文本解析；其他类型（切片、map、结构体）按 JSON 解析，如 `default:"[\"a\",\"b\"]"`
+func assignString(field reflect.Value, s string) error {
+	typ := field.Type()
+	if typ.Kind() == reflect.Ptr {
+		elem := reflect.New(typ.Elem())
+		if err := assignString(elem.Elem(), s); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+
+	switch {
+	case typ == durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	case reflect.PointerTo(typ).Implements(textUnmarshalerType):
+		ptr := reflect.New(typ)
+		if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return err
+		}
+		field.Set(ptr.Elem())
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, typ.Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, typ.Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, typ.Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		ptr := reflect.New(typ)
+		if err := json.Unmarshal([]byte(s), ptr.Interface()); err != nil {
+			return err
+		}
+		field.Set(ptr.Elem())
+	}
+	return nil
+}
+
+// asStringMap 值是否为 JSON 对象（map[string]any 或 Extras）
+func asStringMap(raw any) (map[string]any, bool) {
+	switch m := raw.(type) {
+	case map[string]any:
+		return m, true
+	case Extras:
+		return m, true
+	}
+	return nil, false
+}
+
+// indirectStructType 去掉指针后为结构体时返回该类型，否则返回 nil
+func indirectStructType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil
+	}
+	return typ
+}
+
+// isBindLeaf 是否作为整体绑定的结构体（自定义了 JSON/文本解析，如 time.Time）
+func isBindLeaf(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	ptr := reflect.PointerTo(typ)
+	return ptr.Implements(jsonUnmarshalerType) || ptr.Implements(textUnmarshalerType)
+}
+
+// settableStruct 获取可写的结构体值（nil 指针按需分配；未导出的嵌入指针无法分配时返回无效值）
+func settableStruct(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			if !v.CanSet() {
+				return reflect.Value{}
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	return v
+}
@@ -0,0 +1,138 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"katydid-common-account/pkg/validator/errs"
+)
+
+// ============================================================================
+// 绑定到结构体测试
+// ============================================================================
+
+type bindRetry struct {
+	Max     int           `json:"max" default:"3"`
+	Backoff time.Duration `json:"backoff" default:"100ms"`
+}
+
+type bindBase struct {
+	Region string `json:"region" default:"cn"`
+}
+
+type bindConfig struct {
+	bindBase
+	Timeout  time.Duration `json:"timeout" default:"30s"`
+	Endpoint string        `json:"endpoint"`
+	Enabled  bool          `json:"enabled" default:"true"`
+	Rate     float64       `json:"rate"`
+	Tags     []string      `json:"tags" default:"[\"a\",\"b\"]"`
+	Retry    bindRetry     `json:"retry"`
+	Limit    *int          `json:"limit" default:"10"`
+	Since    time.Time     `json:"since"`
+	Ignored  string        `json:"-"`
+}
+
+// TestBindExtras 测试绑定与默认值
+func TestBindExtras(t *testing.T) {
+	e := Extras{
+		"timeout":  "5s",
+		"endpoint": "https://pay.example.com",
+		"rate":     0.5,
+		"retry":    map[string]any{"max": float64(5)},
+		"since":    "2025-01-02T03:04:05Z",
+		"extra":    1,
+	}
+
+	var cfg bindConfig
+	if err := BindExtras(e, &cfg, BindOptions{Defaults: true}); err != nil {
+		t.Fatalf("BindExtras() error = %v", err)
+	}
+
+	limit := 10
+	want := bindConfig{
+		bindBase: bindBase{Region: "cn"},
+		Timeout:  5 * time.Second,
+		Endpoint: "https://pay.example.com",
+		Enabled:  true,
+		Rate:     0.5,
+		Tags:     []string{"a", "b"},
+		Retry:    bindRetry{Max: 5, Backoff: 100 * time.Millisecond},
+		Limit:    &limit,
+		Since:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("BindExtras() = %+v, 期望 %+v", cfg, want)
+	}
+
+	// 不开启 Defaults 时未出现的字段保持原值
+	plain := bindConfig{Endpoint: "old", Enabled: false}
+	if err := BindExtras(Extras{"rate": 1}, &plain, BindOptions{}); err != nil {
+		t.Fatalf("BindExtras() error = %v", err)
+	}
+	if plain.Endpoint != "old" || plain.Enabled || plain.Timeout != 0 || plain.Rate != 1 {
+		t.Errorf("未开启 Defaults 时结果错误: %+v", plain)
+	}
+}
+
+// TestBindExtrasErrors 测试逐字段的绑定错误
+func TestBindExtrasErrors(t *testing.T) {
+	e := Extras{
+		"timeout": "soon",
+		"rate":    "fast",
+		"retry":   map[string]any{"max": 1.5, "jitter": true},
+		"unknown": "x",
+	}
+
+	var cfg bindConfig
+	err := BindExtras(e, &cfg, BindOptions{Defaults: true, ErrorOnUnknown: true})
+	ve, ok := errs.From(err)
+	if !ok {
+		t.Fatalf("BindExtras() error = %v, 期望 *errs.ValidationError", err)
+	}
+
+	got := make(map[string]string, len(ve.Errors))
+	for _, fe := range ve.Errors {
+		got[fe.Namespace] = fe.Tag
+		if fe.Field != errs.FieldName(fe.Namespace) {
+			t.Errorf("Field = %q, 命名空间 %q", fe.Field, fe.Namespace)
+		}
+	}
+	want := map[string]string{
+		"bindConfig.timeout":      BindTagType,
+		"bindConfig.rate":         BindTagType,
+		"bindConfig.retry.max":    BindTagType,
+		"bindConfig.retry.jitter": BindTagUnknown,
+		"bindConfig.unknown":      BindTagUnknown,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("错误 = %v, 期望 %v", got, want)
+	}
+
+	// 出错的字段之外仍然绑定
+	if cfg.Enabled != true || cfg.Region != "cn" {
+		t.Errorf("其他字段应正常绑定: %+v", cfg)
+	}
+}
+
+// TestBindExtrasInvalidDefault 测试无效的默认值
+func TestBindExtrasInvalidDefault(t *testing.T) {
+	var cfg struct {
+		Port int `json:"port" default:"http"`
+	}
+	ve, ok := errs.From(BindExtras(nil, &cfg, BindOptions{Defaults: true}))
+	if !ok || len(ve.Errors) != 1 || ve.Errors[0].Tag != BindTagDefault || ve.Errors[0].Param != "http" {
+		t.Fatalf("期望 default 错误, got %+v", ve)
+	}
+}
+
+// TestBindExtrasTarget 测试无效的目标
+func TestBindExtrasTarget(t *testing.T) {
+	var cfg bindConfig
+	for _, target := range []any{nil, cfg, (*bindConfig)(nil), new(int)} {
+		if err := BindExtras(Extras{}, target, BindOptions{}); err == nil {
+			t.Errorf("BindExtras(%T) 应返回错误", target)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/fnv"
+)
+
+// ============================================================================
+// 指纹
+// ============================================================================
+
+// Fingerprint 计算内容的 64 位指纹（FNV-1a），用于缓存和变更检测
+// 说明：
+// - 基于规范化的 JSON 序列化（encoding/json 按键名排序，包括嵌套 map），与键的插入顺序无关，跨进程稳定
+// - 按序列化结果比较：int(1) 与 float64(1) 的指纹相同，与从数据库读取（数值为 float64）的数据一致
+// - nil 和空 map 的指纹相同
+// - 值无法序列化（如 chan、func、NaN）时返回错误
+func (e Extras) Fingerprint() (uint64, error) {
+	h := fnv.New64a()
+	if err := e.writeCanonical(h); err != nil {
+		return 0, err
+	}
+	return h.Sum64(), nil
+}
+
+// FingerprintSHA256 计算内容的 SHA-256 指纹（十六进制），用于 ETag 等需要抗碰撞的场景
+// 说明：规范化规则同 Fingerprint
+func (e Extras) FingerprintSHA256() (string, error) {
+	h := sha256.New()
+	if err := e.writeCanonical(h); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeCanonical 将规范化的 JSON 写入哈希
+func (e Extras) writeCanonical(h hash.Hash) error {
+	if len(e) == 0 {
+		_, _ = h.Write([]byte("{}"))
+		return nil
+	}
+	data, err := json.Marshal(map[string]any(e))
+	if err != nil {
+		return fmt.Errorf("failed to marshal Extras for fingerprint: %w", err)
+	}
+	_, _ = h.Write(data)
+	return nil
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// ============================================================================
+// 指纹测试
+// ============================================================================
+
+// TestExtrasFingerprint 测试指纹与键顺序无关，内容变化时改变
+func TestExtrasFingerprint(t *testing.T) {
+	a := Extras{}
+	a.Set("name", "alice")
+	a.Set("age", 30)
+	a.Set("profile", Extras{"city": "Shanghai", "tags": []string{"x", "y"}})
+
+	b := Extras{}
+	b.Set("profile", map[string]any{"tags": []any{"x", "y"}, "city": "Shanghai"})
+	b.Set("age", 30)
+	b.Set("name", "alice")
+
+	fa, err := a.Fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fb, _ := b.Fingerprint()
+	if fa != fb {
+		t.Errorf("相同内容的指纹不同: %x != %x", fa, fb)
+	}
+
+	// 数据库往返后（数值为 float64）指纹不变
+	data, _ := a.ToJSON()
+	var loaded Extras
+	if err := loaded.FromJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	if fl, _ := loaded.Fingerprint(); fl != fa {
+		t.Errorf("往返后指纹变化: %x != %x", fl, fa)
+	}
+
+	b.Set("age", 31)
+	if fc, _ := b.Fingerprint(); fc == fa {
+		t.Error("内容变化后指纹应改变")
+	}
+
+	sa, _ := a.FingerprintSHA256()
+	sl, _ := loaded.FingerprintSHA256()
+	if len(sa) != 64 || sa != sl {
+		t.Errorf("FingerprintSHA256() = %q, %q", sa, sl)
+	}
+}
+
+// TestExtrasFingerprintStable 测试指纹跨进程稳定（固定值）
+func TestExtrasFingerprintStable(t *testing.T) {
+	var empty Extras
+	if f, _ := empty.Fingerprint(); f != 0x08f44b07b5901a25 {
+		t.Errorf("nil Extras 指纹 = %#x", f)
+	}
+	if f, _ := (Extras{}).Fingerprint(); f != 0x08f44b07b5901a25 {
+		t.Errorf("nil 和空 map 的指纹应相同: %#x", f)
+	}
+
+	e := Extras{"b": 2, "a": json.Number("1")}
+	if f, _ := e.Fingerprint(); f != 0xa0ebc03bdc71de7b {
+		t.Errorf("Fingerprint() = %#x", f)
+	}
+	if s, _ := e.FingerprintSHA256(); s != "43258cff783fe7036d8a43033f830adfc60ec037382473548ac742b888292777" {
+		t.Errorf("FingerprintSHA256() = %s", s)
+	}
+
+	if _, err := (Extras{"bad": make(chan int)}).Fingerprint(); err == nil {
+		t.Error("无法序列化的值应返回错误")
+	}
+}
@@ -0,0 +1,214 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExtrasVersionKey Extras 中保存数据版本的键（约定）
+// 说明：键不存在时视为版本 1（引入版本号之前写入的数据）
+const ExtrasVersionKey = "_version"
+
+// ExtrasMigration 版本迁移函数，将数据从某个版本升级到下一个版本
+// 说明：直接修改传入的 Extras；返回错误时整个迁移失败，原数据不变
+type ExtrasMigration func(e Extras) error
+
+// ExtrasMigrator 扩展字段的版本迁移器
+//
+// 设计说明：
+// - 存储的 Extras 结构会随业务演进（键改名、嵌套调整），旧数据无法一次性全部改写
+// - 调用方为每个版本注册到下一个版本的迁移（v1→v2、v2→v3），读取时按需升级到最新版本
+// - 版本号保存在 ExtrasVersionKey 键中，升级后的数据可以写回存储
+//
+// 注意事项：
+// - 注册在初始化阶段完成，之后 Apply / Upgrade 可并发调用（各自的 Extras 需独立）
+// - 迁移先作用于副本（嵌套的 Extras、map[string]any、[]any 被复制），全部成功后才写回
+type ExtrasMigrator struct {
+	versionKey string
+	steps      map[int]ExtrasMigration
+	latest     int
+}
+
+// NewExtrasMigrator 创建版本迁移器（版本键为 ExtrasVersionKey）
+func NewExtrasMigrator() *ExtrasMigrator {
+	return &ExtrasMigrator{
+		versionKey: ExtrasVersionKey,
+		steps:      make(map[int]ExtrasMigration),
+		latest:     1,
+	}
+}
+
+// WithVersionKey 使用自定义的版本键
+func (m *ExtrasMigrator) WithVersionKey(key string) *ExtrasMigrator {
+	if len(key) > 0 {
+		m.versionKey = key
+	}
+	return m
+}
+
+// Register 注册从 from 版本升级到 from+1 版本的迁移
+// 说明：版本从 1 开始；同一版本重复注册或 migration 为 nil 时返回错误
+func (m *ExtrasMigrator) Register(from int, migration ExtrasMigration) error {
+	if from < 1 {
+		return fmt.Errorf("migration version must be >= 1, got %d", from)
+	}
+	if migration == nil {
+		return fmt.Errorf("migration v%d->v%d cannot be nil", from, from+1)
+	}
+	if _, exists := m.steps[from]; exists {
+		return fmt.Errorf("migration v%d->v%d already registered", from, from+1)
+	}
+	m.steps[from] = migration
+	if from+1 > m.latest {
+		m.latest = from + 1
+	}
+	return nil
+}
+
+// MustRegister 注册迁移，失败时 panic（用于包初始化）
+func (m *ExtrasMigrator) MustRegister(from int, migration ExtrasMigration) *ExtrasMigrator {
+	if err := m.Register(from, migration); err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Latest 最新版本（最大的 from+1，没有注册迁移时为 1）
+func (m *ExtrasMigrator) Latest() int {
+	return m.latest
+}
+
+// Version 读取数据的版本（键不存在时为 1，值不是正整数时返回 false）
+func (m *ExtrasMigrator) Version(e Extras) (int, bool) {
+	value, exists := e[m.versionKey]
+	if !exists {
+		return 1, true
+	}
+	version, ok := convertToInt(value)
+	if !ok || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
+
+// Apply 将数据从 fromVersion 依次迁移到最新版本，并写入版本键
+// 返回值：
+// - fromVersion 大于最新版本（数据由更新的程序写入）或缺少中间版本的迁移时返回错误
+// - 任一迁移返回错误时 e 保持不变
+func (m *ExtrasMigrator) Apply(e Extras, fromVersion int) error {
+	if e == nil {
+		return fmt.Errorf("cannot migrate nil extras")
+	}
+	if fromVersion < 1 {
+		return fmt.Errorf("migration version must be >= 1, got %d", fromVersion)
+	}
+	if fromVersion > m.latest {
+		return fmt.Errorf("extras version %d is newer than latest version %d", fromVersion, m.latest)
+	}
+	for v := fromVersion; v < m.latest; v++ {
+		if _, exists := m.steps[v]; !exists {
+			return fmt.Errorf("missing migration v%d->v%d", v, v+1)
+		}
+	}
+
+	working := cloneNested(e).(Extras)
+	for v := fromVersion; v < m.latest; v++ {
+		if err := m.steps[v](working); err != nil {
+			return fmt.Errorf("migration v%d->v%d failed: %w", v, v+1, err)
+		}
+	}
+	working[m.versionKey] = m.latest
+
+	clear(e)
+	for k, v := range working {
+		e[k] = v
+	}
+	return nil
+}
+
+// Upgrade 读取版本键并迁移到最新版本（读取时按需升级）
+// 返回值：migrated 表示数据是否被修改（调用方可据此写回存储）
+func (m *ExtrasMigrator) Upgrade(e Extras) (migrated bool, err error) {
+	version, ok := m.Version(e)
+	if !ok {
+		return false, fmt.Errorf("invalid extras version %v", e[m.versionKey])
+	}
+	if version == m.latest {
+		return false, nil
+	}
+	if err := m.Apply(e, version); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ============================================================================
+// 常用迁移
+// ============================================================================
+
+// RenameKeyMigration 将路径 from 的值移动到路径 to（均支持点号路径）
+// 说明：from 不存在时跳过；to 已存在时返回错误，避免覆盖数据
+// 示例：RenameKeyMigration("tel", "phone")、RenameKeyMigration("city", "address.city")
+func RenameKeyMigration(from, to string) ExtrasMigration {
+	return func(e Extras) error {
+		value, exists := e.GetPath(from)
+		if !exists {
+			return nil
+		}
+		if _, exists := e.GetPath(to); exists {
+			return fmt.Errorf("cannot move %q to %q: target already exists", from, to)
+		}
+		if err := e.SetPath(to, value); err != nil {
+			return err
+		}
+		deletePath(e, from)
+		return nil
+	}
+}
+
+// deletePath 删除点号路径指向的值（中间节点不是 map 时忽略）
+func deletePath(e Extras, path string) {
+	parent, key := map[string]any(e), path
+	for {
+		head, rest, found := strings.Cut(key, ".")
+		if !found {
+			delete(parent, key)
+			return
+		}
+		switch next := parent[head].(type) {
+		case Extras:
+			parent = next
+		case map[string]any:
+			parent = next
+		default:
+			return
+		}
+		key = rest
+	}
+}
+
+// cloneNested 复制嵌套的 Extras、map[string]any 和 []any，其他值共享
+func cloneNested(value any) any {
+	switch v := value.(type) {
+	case Extras:
+		clone := make(Extras, len(v))
+		for k, item := range v {
+			clone[k] = cloneNested(item)
+		}
+		return clone
+	case map[string]any:
+		clone := make(map[string]any, len(v))
+		for k, item := range v {
+			clone[k] = cloneNested(item)
+		}
+		return clone
+	case []any:
+		clone := make([]any, len(v))
+		for i, item := range v {
+			clone[i] = cloneNested(item)
+		}
+		return clone
+	default:
+		return value
+	}
+}
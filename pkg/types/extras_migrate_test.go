@@ -0,0 +1,109 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// ============================================================================
+// 版本迁移测试
+// ============================================================================
+
+// newProfileMigrator v1→v2 重命名 tel，v2→v3 将 city 移到 address 下
+func newProfileMigrator() *ExtrasMigrator {
+	return NewExtrasMigrator().
+		MustRegister(1, RenameKeyMigration("tel", "phone")).
+		MustRegister(2, RenameKeyMigration("city", "address.city"))
+}
+
+// TestExtrasMigratorUpgrade 测试按版本键升级
+func TestExtrasMigratorUpgrade(t *testing.T) {
+	migrator := newProfileMigrator()
+	if migrator.Latest() != 3 {
+		t.Fatalf("Latest() = %d, 期望 3", migrator.Latest())
+	}
+
+	// 没有版本键的旧数据视为 v1
+	e := Extras{"tel": "13800138000", "city": "Shanghai", "address": map[string]any{"zip": "200000"}}
+	migrated, err := migrator.Upgrade(e)
+	if err != nil || !migrated {
+		t.Fatalf("Upgrade() = %v, %v", migrated, err)
+	}
+	want := Extras{
+		"phone":          "13800138000",
+		"address":        map[string]any{"zip": "200000", "city": "Shanghai"},
+		ExtrasVersionKey: 3,
+	}
+	if !reflect.DeepEqual(e, want) {
+		t.Errorf("升级结果 = %v, 期望 %v", e, want)
+	}
+
+	// 从存储读取的 v2 数据（版本号为 float64）
+	var stored Extras
+	if err := json.Unmarshal([]byte(`{"_version":2,"phone":"1","city":"Beijing"}`), &stored); err != nil {
+		t.Fatal(err)
+	}
+	if migrated, err := migrator.Upgrade(stored); err != nil || !migrated {
+		t.Fatalf("Upgrade(v2) = %v, %v", migrated, err)
+	}
+	if city, _ := stored.GetStringPath("address.city"); city != "Beijing" || stored.Has("city") {
+		t.Errorf("v2 升级结果 = %v", stored)
+	}
+
+	// 已是最新版本
+	if migrated, err := migrator.Upgrade(stored); err != nil || migrated {
+		t.Errorf("再次 Upgrade() = %v, %v", migrated, err)
+	}
+}
+
+// TestExtrasMigratorErrors 测试错误情况，失败时数据不变
+func TestExtrasMigratorErrors(t *testing.T) {
+	migrator := newProfileMigrator()
+
+	if err := migrator.Register(1, RenameKeyMigration("a", "b")); err == nil {
+		t.Error("重复注册应返回错误")
+	}
+	if err := migrator.Apply(Extras{}, 4); err == nil {
+		t.Error("高于最新版本应返回错误")
+	}
+	if _, err := migrator.Upgrade(Extras{ExtrasVersionKey: "x"}); err == nil {
+		t.Error("无效版本号应返回错误")
+	}
+
+	// 第二步失败（目标已存在）时第一步的修改也不生效
+	e := Extras{"tel": "1", "city": "Shanghai", "address": Extras{"city": "Beijing"}}
+	if err := migrator.Apply(e, 1); err == nil {
+		t.Fatal("目标已存在时应返回错误")
+	}
+	if !reflect.DeepEqual(e, Extras{"tel": "1", "city": "Shanghai", "address": Extras{"city": "Beijing"}}) {
+		t.Errorf("失败后数据被修改: %v", e)
+	}
+
+	// 缺少中间版本
+	gap := NewExtrasMigrator().MustRegister(2, func(e Extras) error { return nil })
+	if err := gap.Apply(Extras{}, 1); err == nil {
+		t.Error("缺少 v1->v2 应返回错误")
+	}
+
+	boom := errors.New("boom")
+	failing := NewExtrasMigrator().MustRegister(1, func(e Extras) error { return boom })
+	if err := failing.Apply(Extras{}, 1); !errors.Is(err, boom) {
+		t.Errorf("Apply() = %v, 期望包装迁移错误", err)
+	}
+}
+
+// TestExtrasMigratorVersionKey 测试自定义版本键
+func TestExtrasMigratorVersionKey(t *testing.T) {
+	migrator := NewExtrasMigrator().WithVersionKey("schema").
+		MustRegister(1, func(e Extras) error { e.Set("migrated", true); return nil })
+
+	e := Extras{"schema": 1}
+	if migrated, err := migrator.Upgrade(e); err != nil || !migrated {
+		t.Fatalf("Upgrade() = %v, %v", migrated, err)
+	}
+	if v, _ := e.GetInt("schema"); v != 2 || !e.GetBoolOr("migrated", false) {
+		t.Errorf("升级结果 = %v", e)
+	}
+}
@@ -0,0 +1,218 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// OrderedExtras 保留插入顺序的扩展字段
+//
+// 设计说明：
+// - Extras 基于 map，遍历顺序随机，JSON 序列化按键名排序，无法还原请求中的字段顺序
+// - 对序列化结果计算签名等场景需要稳定且与原始载荷一致的键顺序
+// - OrderedExtras 在 Extras 之外维护键的插入顺序：查询仍为 O(1)，遍历和序列化按插入顺序
+//
+// 注意事项：
+// - 覆盖已存在的键不改变其位置；删除为 O(n)
+// - 只保留顶层键的顺序，嵌套对象反序列化为 map[string]any，序列化时按键名排序
+// - 非线程安全，多协程并发读写需要外部加锁
+// - MySQL 等数据库的 JSON 列会重排键，需要保留顺序时请使用 TEXT / BLOB 列
+type OrderedExtras struct {
+	keys   []string
+	values Extras
+}
+
+// NewOrderedExtras 创建保留插入顺序的扩展字段
+func NewOrderedExtras(capacity int) *OrderedExtras {
+	return &OrderedExtras{
+		keys:   make([]string, 0, capacity),
+		values: NewExtras(capacity),
+	}
+}
+
+// Set 设置指定键的值，新键追加到末尾，已存在的键保持原位置
+func (o *OrderedExtras) Set(key string, value any) {
+	if len(key) == 0 {
+		return
+	}
+	if o.values == nil {
+		o.values = make(Extras)
+	}
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// Get 获取指定键的值
+func (o *OrderedExtras) Get(key string) (any, bool) {
+	value, exists := o.values[key]
+	return value, exists
+}
+
+// Has 检查键是否存在
+func (o *OrderedExtras) Has(key string) bool {
+	_, exists := o.values[key]
+	return exists
+}
+
+// Delete 删除指定键（O(n)）
+func (o *OrderedExtras) Delete(key string) {
+	if _, exists := o.values[key]; !exists {
+		return
+	}
+	delete(o.values, key)
+	if i := slices.Index(o.keys, key); i >= 0 {
+		o.keys = slices.Delete(o.keys, i, i+1)
+	}
+}
+
+// Clear 清空所有键值对
+func (o *OrderedExtras) Clear() {
+	clear(o.values)
+	o.keys = o.keys[:0]
+}
+
+// Len 返回键值对数量
+func (o *OrderedExtras) Len() int {
+	return len(o.keys)
+}
+
+// Keys 按插入顺序返回所有的键（副本）
+func (o *OrderedExtras) Keys() []string {
+	return slices.Clone(o.keys)
+}
+
+// Range 按插入顺序遍历所有键值对，返回 false 提前终止
+func (o *OrderedExtras) Range(fn func(key string, value any) bool) {
+	for _, key := range o.keys {
+		if !fn(key, o.values[key]) {
+			return
+		}
+	}
+}
+
+// Extras 返回底层的 Extras，可使用全部 GetXxx 方法
+// 说明：返回值与 OrderedExtras 共享数据，写入请通过 OrderedExtras 的方法，否则顺序信息会失效
+func (o *OrderedExtras) Extras() Extras {
+	return o.values
+}
+
+// Clone 创建一个浅拷贝
+func (o *OrderedExtras) Clone() *OrderedExtras {
+	return &OrderedExtras{
+		keys:   slices.Clone(o.keys),
+		values: maps.Clone(o.values),
+	}
+}
+
+// ToJSON 按插入顺序序列化为 JSON
+func (o *OrderedExtras) ToJSON() ([]byte, error) {
+	return o.MarshalJSON()
+}
+
+// MarshalJSON 实现 json.Marshaler 接口，按插入顺序输出键
+func (o *OrderedExtras) MarshalJSON() ([]byte, error) {
+	if o == nil || len(o.keys) == 0 {
+		return []byte("{}"), nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		keyData, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueData, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal OrderedExtras key %q: %w", key, err)
+		}
+		buf.Write(keyData)
+		buf.WriteByte(':')
+		buf.Write(valueData)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，按 JSON 中的顺序记录键
+// 说明：重复的键以最后一次出现的值为准，位置为第一次出现的位置
+func (o *OrderedExtras) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("empty JSON data")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal JSON into OrderedExtras: %w", err)
+	}
+	if token == nil {
+		o.keys, o.values = nil, nil
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("failed to unmarshal JSON into OrderedExtras: expected object, got %v", token)
+	}
+
+	result := NewOrderedExtras(0)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal JSON into OrderedExtras: %w", err)
+		}
+		key, _ := token.(string)
+
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return fmt.Errorf("failed to unmarshal JSON into OrderedExtras key %q: %w", key, err)
+		}
+		result.Set(key, value)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON into OrderedExtras: %w", err)
+	}
+
+	*o = *result
+	return nil
+}
+
+// Value 实现 driver.Valuer 接口，用于数据库存储
+func (o *OrderedExtras) Value() (driver.Value, error) {
+	data, err := o.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OrderedExtras to JSON for database storage: %w", err)
+	}
+	return data, nil
+}
+
+// Scan 实现 sql.Scanner 接口，用于数据库读取
+func (o *OrderedExtras) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		o.keys, o.values = nil, nil
+		return nil
+	case []byte:
+		if len(v) == 0 {
+			o.keys, o.values = nil, nil
+			return nil
+		}
+		return o.UnmarshalJSON(v)
+	case string:
+		if len(v) == 0 {
+			o.keys, o.values = nil, nil
+			return nil
+		}
+		return o.UnmarshalJSON(stringToBytes(v))
+	default:
+		return fmt.Errorf("failed to scan OrderedExtras: unsupported database type %T, expected []byte or string", value)
+	}
+}
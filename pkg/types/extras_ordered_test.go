@@ -0,0 +1,158 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// ============================================================================
+// 有序扩展字段测试
+// ============================================================================
+
+// TestOrderedExtrasInsertionOrder 测试插入顺序保留
+func TestOrderedExtrasInsertionOrder(t *testing.T) {
+	o := NewOrderedExtras(4)
+	o.Set("zeta", 1)
+	o.Set("alpha", "a")
+	o.Set("mid", true)
+	o.Set("alpha", "b") // 覆盖不改变位置
+	o.Set("", "ignored")
+
+	if got, want := o.Keys(), []string{"zeta", "alpha", "mid"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, 期望 %v", got, want)
+	}
+	if v, ok := o.Get("alpha"); !ok || v != "b" {
+		t.Errorf("Get(alpha) = %v, %v", v, ok)
+	}
+	if s, ok := o.Extras().GetString("alpha"); !ok || s != "b" {
+		t.Errorf("Extras().GetString(alpha) = %v, %v", s, ok)
+	}
+
+	var visited []string
+	o.Range(func(key string, _ any) bool {
+		visited = append(visited, key)
+		return key != "alpha"
+	})
+	if want := []string{"zeta", "alpha"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("Range 提前终止后访问 %v, 期望 %v", visited, want)
+	}
+
+	o.Delete("zeta")
+	o.Delete("missing")
+	if got, want := o.Keys(), []string{"alpha", "mid"}; !reflect.DeepEqual(got, want) || o.Len() != 2 || o.Has("zeta") {
+		t.Errorf("删除后 Keys() = %v, Len() = %d", got, o.Len())
+	}
+
+	clone := o.Clone()
+	clone.Set("extra", 1)
+	if o.Has("extra") || o.Len() != 2 {
+		t.Error("Clone 应与原实例相互独立")
+	}
+
+	o.Clear()
+	if o.Len() != 0 || len(o.Extras()) != 0 {
+		t.Error("Clear 后应为空")
+	}
+}
+
+// TestOrderedExtrasJSON 测试 JSON 序列化保持键顺序
+func TestOrderedExtrasJSON(t *testing.T) {
+	input := `{"sign_type":"md5","amount":100,"nested":{"b":1,"a":2},"app_id":"x","tags":["t"]}`
+
+	var o OrderedExtras
+	if err := json.Unmarshal([]byte(input), &o); err != nil {
+		t.Fatalf("Unmarshal 失败: %v", err)
+	}
+	if got, want := o.Keys(), []string{"sign_type", "amount", "nested", "app_id", "tags"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, 期望 %v", got, want)
+	}
+
+	data, err := o.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON 失败: %v", err)
+	}
+	// 顶层保持原顺序，嵌套对象按键名排序
+	if want := `{"sign_type":"md5","amount":100,"nested":{"a":2,"b":1},"app_id":"x","tags":["t"]}`; string(data) != want {
+		t.Errorf("ToJSON() = %s, 期望 %s", data, want)
+	}
+
+	// 作为结构体字段
+	wrapper := struct {
+		Extras *OrderedExtras `json:"extras"`
+	}{Extras: &o}
+	data, err = json.Marshal(wrapper)
+	if err != nil {
+		t.Fatalf("Marshal 失败: %v", err)
+	}
+	if want := `{"extras":{"sign_type":"md5","amount":100,"nested":{"a":2,"b":1},"app_id":"x","tags":["t"]}}`; string(data) != want {
+		t.Errorf("Marshal() = %s, 期望 %s", data, want)
+	}
+
+	// 重复键：值取最后一次，位置取第一次
+	if err := o.UnmarshalJSON([]byte(`{"a":1,"b":2,"a":3}`)); err != nil {
+		t.Fatalf("UnmarshalJSON 失败: %v", err)
+	}
+	if data, _ := o.MarshalJSON(); string(data) != `{"a":3,"b":2}` {
+		t.Errorf("重复键序列化 = %s", data)
+	}
+
+	for _, invalid := range []string{`[1,2]`, `{"a":}`, `{"a":1`, ``} {
+		if err := o.UnmarshalJSON([]byte(invalid)); err == nil {
+			t.Errorf("UnmarshalJSON(%q) 应返回错误", invalid)
+		}
+	}
+
+	var empty *OrderedExtras
+	if data, _ := empty.MarshalJSON(); string(data) != "{}" {
+		t.Errorf("nil 序列化 = %s", data)
+	}
+}
+
+// TestOrderedExtrasDatabase 测试数据库读写
+func TestOrderedExtrasDatabase(t *testing.T) {
+	o := NewOrderedExtras(2)
+	o.Set("b", 1)
+	o.Set("a", 2)
+
+	value, err := o.Value()
+	if err != nil {
+		t.Fatalf("Value 失败: %v", err)
+	}
+
+	var scanned OrderedExtras
+	if err := scanned.Scan(string(value.([]byte))); err != nil {
+		t.Fatalf("Scan 失败: %v", err)
+	}
+	if got, want := scanned.Keys(), []string{"b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan 后 Keys() = %v, 期望 %v", got, want)
+	}
+	if err := scanned.Scan(nil); err != nil || scanned.Len() != 0 {
+		t.Errorf("Scan(nil) = %v, Len() = %d", err, scanned.Len())
+	}
+	if err := scanned.Scan(1); err == nil {
+		t.Error("Scan 不支持的类型应返回错误")
+	}
+}
+
+// TestExtrasSortedKeys 测试按键名排序遍历
+func TestExtrasSortedKeys(t *testing.T) {
+	e := Extras{"c": 3, "a": 1, "b": 2}
+	if got, want := e.SortedKeys(), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedKeys() = %v, 期望 %v", got, want)
+	}
+
+	var visited []string
+	e.RangeSorted(func(key string, _ any) bool {
+		visited = append(visited, key)
+		return key != "b"
+	})
+	if want := []string{"a", "b"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("RangeSorted 访问 %v, 期望 %v", visited, want)
+	}
+
+	// MarshalJSON 的键顺序与 SortedKeys 一致
+	if data, _ := e.MarshalJSON(); string(data) != `{"a":1,"b":2,"c":3}` {
+		t.Errorf("MarshalJSON() = %s", data)
+	}
+}
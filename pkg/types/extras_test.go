@@ -777,6 +777,32 @@ func TestExtrasNilJSON(t *testing.T) {
 	}
 }
 
+// TestExtrasText 测试文本序列化和 String
+func TestExtrasText(t *testing.T) {
+	extras := Extras{"b": 2, "a": "x"}
+	data, err := extras.MarshalText()
+	if err != nil || string(data) != `{"a":"x","b":2}` {
+		t.Errorf("MarshalText() = %s, %v", data, err)
+	}
+	if got := fmt.Sprint(extras); got != `{"a":"x","b":2}` {
+		t.Errorf("String() = %s", got)
+	}
+	if got := (Extras{"ch": make(chan int)}).String(); !strings.HasPrefix(got, "map[ch:") {
+		t.Errorf("无法序列化时 String() = %s", got)
+	}
+
+	var decoded Extras
+	if err := decoded.UnmarshalText(data); err != nil || !decoded.Equal(Extras{"a": "x", "b": float64(2)}) {
+		t.Errorf("UnmarshalText() = %v, %v", decoded, err)
+	}
+	if err := decoded.UnmarshalText([]byte("  ")); err != nil || decoded == nil || decoded.Len() != 0 {
+		t.Errorf("空文本 UnmarshalText() = %v, %v", decoded, err)
+	}
+	if err := decoded.UnmarshalText([]byte("[1]")); err == nil {
+		t.Error("非对象应返回错误")
+	}
+}
+
 // ============================================================================
 // 数据库操作测试
 // ============================================================================
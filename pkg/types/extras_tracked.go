@@ -0,0 +1,243 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// TrackedExtras 记录修改的扩展字段
+//
+// 设计说明：
+// - 仓储保存模型时通常整列重写 Extras，并发修改不同键时后写入的会覆盖先写入的
+// - TrackedExtras 记录从加载（或上次 ResetDirty）以来被修改或删除的顶层键
+// - 仓储可据此只更新变化的键（MySQL JSON_SET / JSON_REMOVE，PostgreSQL jsonb_set / -）
+//
+// 注意事项：
+// - 只跟踪通过 TrackedExtras 方法进行的写入；通过 Extras() 或嵌套 Extras 直接修改不会被记录
+// - SetPath 将路径的第一段记为已修改，更新时整体写入该顶层键
+// - 非线程安全，多协程并发读写需要外部加锁
+type TrackedExtras struct {
+	values Extras
+	dirty  map[string]struct{}
+}
+
+// NewTrackedExtras 基于已加载的数据创建可跟踪修改的扩展字段（初始没有已修改的键）
+// 说明：直接持有 loaded，不做拷贝
+func NewTrackedExtras(loaded Extras) *TrackedExtras {
+	if loaded == nil {
+		loaded = make(Extras)
+	}
+	return &TrackedExtras{values: loaded}
+}
+
+// Set 设置指定键的值并标记为已修改
+func (t *TrackedExtras) Set(key string, value any) {
+	if len(key) == 0 {
+		return
+	}
+	t.ensure()
+	t.values[key] = value
+	t.markDirty(key)
+}
+
+// SetPath 按路径设置嵌套值，并将路径的第一段标记为已修改
+func (t *TrackedExtras) SetPath(path string, value any) error {
+	t.ensure()
+	if err := t.values.SetPath(path, value); err != nil {
+		return err
+	}
+	key, _, _ := strings.Cut(path, ".")
+	t.markDirty(key)
+	return nil
+}
+
+// Delete 删除指定键并标记为已修改（键不存在时不标记）
+func (t *TrackedExtras) Delete(key string) {
+	if _, exists := t.values[key]; !exists {
+		return
+	}
+	delete(t.values, key)
+	t.markDirty(key)
+}
+
+// Get 获取指定键的值
+func (t *TrackedExtras) Get(key string) (any, bool) {
+	value, exists := t.values[key]
+	return value, exists
+}
+
+// Extras 返回底层的 Extras，可使用全部 GetXxx 方法
+// 说明：返回值与 TrackedExtras 共享数据，写入请通过 TrackedExtras 的方法，否则不会被跟踪
+func (t *TrackedExtras) Extras() Extras {
+	return t.values
+}
+
+// IsDirty 是否存在已修改的键
+func (t *TrackedExtras) IsDirty() bool {
+	return len(t.dirty) > 0
+}
+
+// DirtyKeys 返回已修改或删除的顶层键（按键名排序）
+func (t *TrackedExtras) DirtyKeys() []string {
+	keys := make([]string, 0, len(t.dirty))
+	for key := range t.dirty {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Changes 返回需要写入的键值和需要删除的键（均按键名排序）
+func (t *TrackedExtras) Changes() (set Extras, removed []string) {
+	set = make(Extras, len(t.dirty))
+	for _, key := range t.DirtyKeys() {
+		if value, exists := t.values[key]; exists {
+			set[key] = value
+		} else {
+			removed = append(removed, key)
+		}
+	}
+	return set, removed
+}
+
+// ResetDirty 清空修改记录（通常在保存成功后调用）
+func (t *TrackedExtras) ResetDirty() {
+	clear(t.dirty)
+}
+
+// markDirty 标记已修改的键
+func (t *TrackedExtras) markDirty(key string) {
+	if t.dirty == nil {
+		t.dirty = make(map[string]struct{})
+	}
+	t.dirty[key] = struct{}{}
+}
+
+// ensure 确保底层 Extras 已初始化（零值 TrackedExtras 可直接使用）
+func (t *TrackedExtras) ensure() {
+	if t.values == nil {
+		t.values = make(Extras)
+	}
+}
+
+// ============================================================================
+// 部分更新语句
+// ============================================================================
+
+// MySQLUpdateExpr 生成只更新已修改键的 MySQL 表达式
+// 返回值：形如 JSON_REMOVE(JSON_SET(COALESCE(col, JSON_OBJECT()), ?, CAST(? AS JSON)), ?) 的表达式及参数；
+// 没有修改时返回 column 本身
+// 说明：column 直接拼接到表达式中，必须是可信的列名；键和值都以参数传递
+// 示例：
+//
+//	expr, args, err := user.Extras.MySQLUpdateExpr("extras")
+//	db.Model(&user).Update("extras", gorm.Expr(expr, args...))
+func (t *TrackedExtras) MySQLUpdateExpr(column string) (string, []any, error) {
+	set, removed := t.Changes()
+	if len(set) == 0 && len(removed) == 0 {
+		return column, nil, nil
+	}
+
+	var b strings.Builder
+	args := make([]any, 0, len(set)*2+len(removed))
+	expr := "COALESCE(" + column + ", JSON_OBJECT())"
+
+	if len(set) > 0 {
+		b.WriteString("JSON_SET(")
+		b.WriteString(expr)
+		for _, key := range set.SortedKeys() {
+			data, err := json.Marshal(set[key])
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to marshal TrackedExtras key %q: %w", key, err)
+			}
+			b.WriteString(", ?, CAST(? AS JSON)")
+			args = append(args, mysqlJSONPath(key), string(data))
+		}
+		b.WriteByte(')')
+		expr = b.String()
+		b.Reset()
+	}
+
+	if len(removed) > 0 {
+		b.WriteString("JSON_REMOVE(")
+		b.WriteString(expr)
+		for _, key := range removed {
+			b.WriteString(", ?")
+			args = append(args, mysqlJSONPath(key))
+		}
+		b.WriteByte(')')
+		expr = b.String()
+	}
+	return expr, args, nil
+}
+
+// PostgresUpdateExpr 生成只更新已修改键的 PostgreSQL（jsonb 列）表达式
+// 返回值：形如 (jsonb_set(COALESCE(col, '{}'::jsonb), ARRAY[?], ?::jsonb) - ?) 的表达式及参数；
+// 没有修改时返回 column 本身
+// 说明：column 直接拼接到表达式中，必须是可信的列名；键和值都以参数传递
+func (t *TrackedExtras) PostgresUpdateExpr(column string) (string, []any, error) {
+	set, removed := t.Changes()
+	if len(set) == 0 && len(removed) == 0 {
+		return column, nil, nil
+	}
+
+	args := make([]any, 0, len(set)*2+len(removed))
+	expr := "COALESCE(" + column + ", '{}'::jsonb)"
+	for _, key := range set.SortedKeys() {
+		data, err := json.Marshal(set[key])
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to marshal TrackedExtras key %q: %w", key, err)
+		}
+		expr = "jsonb_set(" + expr + ", ARRAY[?]::text[], ?::jsonb, true)"
+		args = append(args, key, string(data))
+	}
+	for _, key := range removed {
+		expr = "(" + expr + " - ?::text)"
+		args = append(args, key)
+	}
+	return expr, args, nil
+}
+
+// mysqlJSONPath 顶层键的 MySQL JSON 路径（键名加引号，转义 \ 和 "）
+func mysqlJSONPath(key string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(key)
+	return `$."` + escaped + `"`
+}
+
+// ============================================================================
+// 接口实现
+// ============================================================================
+
+// MarshalJSON 实现 json.Marshaler 接口（输出完整数据）
+func (t *TrackedExtras) MarshalJSON() ([]byte, error) {
+	if t == nil {
+		return []byte("{}"), nil
+	}
+	return t.values.MarshalJSON()
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，视为重新加载，清空修改记录
+func (t *TrackedExtras) UnmarshalJSON(data []byte) error {
+	if err := t.values.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	t.ResetDirty()
+	return nil
+}
+
+// Value 实现 driver.Valuer 接口，用于整列写入
+func (t *TrackedExtras) Value() (driver.Value, error) {
+	return t.values.Value()
+}
+
+// Scan 实现 sql.Scanner 接口，视为重新加载，清空修改记录
+func (t *TrackedExtras) Scan(value any) error {
+	if err := t.values.Scan(value); err != nil {
+		return err
+	}
+	t.ResetDirty()
+	return nil
+}
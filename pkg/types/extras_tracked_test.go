@@ -0,0 +1,96 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// ============================================================================
+// 修改跟踪测试
+// ============================================================================
+
+// TestTrackedExtrasDirtyKeys 测试修改记录
+func TestTrackedExtrasDirtyKeys(t *testing.T) {
+	tracked := NewTrackedExtras(Extras{"a": 1, "b": 2, "c": Extras{"x": 1}})
+	if tracked.IsDirty() {
+		t.Fatal("刚加载的数据不应有修改")
+	}
+
+	tracked.Set("a", 10)
+	tracked.Set("new", "v")
+	tracked.Delete("b")
+	tracked.Delete("missing")
+	if err := tracked.SetPath("c.y", 2); err != nil {
+		t.Fatalf("SetPath 失败: %v", err)
+	}
+
+	if got, want := tracked.DirtyKeys(), []string{"a", "b", "c", "new"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DirtyKeys() = %v, 期望 %v", got, want)
+	}
+
+	set, removed := tracked.Changes()
+	if !reflect.DeepEqual(set.SortedKeys(), []string{"a", "c", "new"}) || !reflect.DeepEqual(removed, []string{"b"}) {
+		t.Errorf("Changes() = %v, %v", set, removed)
+	}
+	if y, ok := tracked.Extras().GetIntPath("c.y"); !ok || y != 2 {
+		t.Errorf("GetIntPath(c.y) = %v, %v", y, ok)
+	}
+
+	tracked.ResetDirty()
+	if tracked.IsDirty() || len(tracked.DirtyKeys()) != 0 {
+		t.Error("ResetDirty 后不应有修改")
+	}
+
+	// 重新加载清空修改记录
+	tracked.Set("a", 1)
+	if err := json.Unmarshal([]byte(`{"z":1}`), tracked); err != nil {
+		t.Fatalf("Unmarshal 失败: %v", err)
+	}
+	if tracked.IsDirty() || !reflect.DeepEqual(tracked.Extras().Keys(), []string{"z"}) {
+		t.Errorf("重新加载后 = %v, dirty = %v", tracked.Extras(), tracked.DirtyKeys())
+	}
+
+	var zero TrackedExtras
+	zero.Set("k", 1)
+	if !zero.IsDirty() {
+		t.Error("零值 TrackedExtras 应可直接使用")
+	}
+}
+
+// TestTrackedExtrasUpdateExpr 测试部分更新表达式
+func TestTrackedExtrasUpdateExpr(t *testing.T) {
+	tracked := NewTrackedExtras(Extras{"a": 1, "b": 2})
+	if expr, args, err := tracked.MySQLUpdateExpr("extras"); err != nil || expr != "extras" || args != nil {
+		t.Errorf("没有修改时应返回列名: %q %v %v", expr, args, err)
+	}
+
+	tracked.Set("a", map[string]any{"n": 1})
+	tracked.Set(`q"k`, "v")
+	tracked.Delete("b")
+
+	expr, args, err := tracked.MySQLUpdateExpr("extras")
+	if err != nil {
+		t.Fatalf("MySQLUpdateExpr 失败: %v", err)
+	}
+	wantExpr := "JSON_REMOVE(JSON_SET(COALESCE(extras, JSON_OBJECT()), ?, CAST(? AS JSON), ?, CAST(? AS JSON)), ?)"
+	wantArgs := []any{`$."a"`, `{"n":1}`, `$."q\"k"`, `"v"`, `$."b"`}
+	if expr != wantExpr || !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("MySQLUpdateExpr() = %s %v", expr, args)
+	}
+
+	expr, args, err = tracked.PostgresUpdateExpr("extras")
+	if err != nil {
+		t.Fatalf("PostgresUpdateExpr 失败: %v", err)
+	}
+	wantExpr = "(jsonb_set(jsonb_set(COALESCE(extras, '{}'::jsonb), ARRAY[?]::text[], ?::jsonb, true), ARRAY[?]::text[], ?::jsonb, true) - ?::text)"
+	wantArgs = []any{"a", `{"n":1}`, `q"k`, `"v"`, "b"}
+	if expr != wantExpr || !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("PostgresUpdateExpr() = %s %v", expr, args)
+	}
+
+	tracked.Set("bad", func() {})
+	if _, _, err := tracked.MySQLUpdateExpr("extras"); err == nil {
+		t.Error("无法序列化的值应返回错误")
+	}
+}
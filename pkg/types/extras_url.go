@@ -0,0 +1,171 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ============================================================================
+// url.Values 转换
+// ============================================================================
+
+// URLValuesOption url.Values 转换选项
+type URLValuesOption func(*urlValuesConfig)
+
+// urlValuesConfig url.Values 转换配置
+type urlValuesConfig struct {
+	detectNumbers bool                // 是否将数字字符串转换为数值
+	sliceKeys     map[string]struct{} // 总是保存为切片的键
+}
+
+// WithNumberDetection 开启或关闭数字识别（默认关闭，所有值保持为字符串）
+// 说明：只识别规范格式，"42" -> int64(42)、"-1.5" -> float64(-1.5)；
+// "007"、"1e3"、"1.50" 等会丢失原始格式的字符串保持不变，避免破坏编号、邮编等字段
+func WithNumberDetection(enabled bool) URLValuesOption {
+	return func(c *urlValuesConfig) {
+		c.detectNumbers = enabled
+	}
+}
+
+// WithSliceKeys 指定总是保存为切片的键（如 tags），即使只有一个值
+func WithSliceKeys(keys ...string) URLValuesOption {
+	return func(c *urlValuesConfig) {
+		if c.sliceKeys == nil {
+			c.sliceKeys = make(map[string]struct{}, len(keys))
+		}
+		for _, key := range keys {
+			c.sliceKeys[key] = struct{}{}
+		}
+	}
+}
+
+// FromURLValues 从查询参数或表单解析（替换现有内容）
+// 说明：
+// - 单个值保存为标量，多个值（或 WithSliceKeys 指定的键）保存为切片
+// - 未开启数字识别时切片为 []string，开启后为 []any，可使用 GetIntSlice 等方法读取
+// - 没有值的键（如 "?flag"）保存为空字符串，与 url.Values.Get 一致
+// 示例：
+//
+//	var extras Extras
+//	extras.FromURLValues(r.URL.Query(), WithNumberDetection(true))
+//	page := extras.GetIntOr("page", 1)
+func (e *Extras) FromURLValues(values url.Values, opts ...URLValuesOption) {
+	var config urlValuesConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	result := make(Extras, len(values))
+	for key, vals := range values {
+		if len(key) == 0 {
+			continue
+		}
+
+		_, asSlice := config.sliceKeys[key]
+		if !asSlice && len(vals) <= 1 {
+			if len(vals) == 0 {
+				result[key] = ""
+			} else {
+				result[key] = config.convert(vals[0])
+			}
+			continue
+		}
+
+		if !config.detectNumbers {
+			result[key] = append([]string(nil), vals...)
+			continue
+		}
+		items := make([]any, len(vals))
+		for i, val := range vals {
+			items[i] = config.convert(val)
+		}
+		result[key] = items
+	}
+
+	*e = result
+}
+
+// ToURLValues 转换为查询参数或表单
+// 说明：
+// - 字符串、数字、布尔值转换为单个值，切片（[]string、[]int、[]any 等）转换为多个值
+// - 嵌套对象等其他类型编码为 JSON 字符串，无法编码时使用 fmt 格式化
+// - nil 值被跳过
+func (e Extras) ToURLValues() url.Values {
+	values := make(url.Values, len(e))
+	for key, value := range e {
+		switch val := value.(type) {
+		case nil:
+			continue
+		case []string:
+			values[key] = append([]string(nil), val...)
+		case []any:
+			items := make([]string, 0, len(val))
+			for _, item := range val {
+				if item != nil {
+					items = append(items, formatURLValue(item))
+				}
+			}
+			values[key] = items
+		case []int:
+			values[key] = formatURLSlice(val)
+		case []int64:
+			values[key] = formatURLSlice(val)
+		case []float64:
+			values[key] = formatURLSlice(val)
+		case []bool:
+			values[key] = formatURLSlice(val)
+		default:
+			values[key] = []string{formatURLValue(val)}
+		}
+	}
+	return values
+}
+
+// convert 按配置转换单个值
+func (c *urlValuesConfig) convert(s string) any {
+	if !c.detectNumbers {
+		return s
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && strconv.FormatInt(n, 10) == s {
+		return n
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && strconv.FormatFloat(f, 'f', -1, 64) == s {
+		return f
+	}
+	return s
+}
+
+// formatURLSlice 将切片的每个元素格式化为字符串
+func formatURLSlice[T any](items []T) []string {
+	result := make([]string, len(items))
+	for i, item := range items {
+		result[i] = formatURLValue(item)
+	}
+	return result
+}
+
+// formatURLValue 将单个值格式化为字符串
+func formatURLValue(value any) string {
+	switch val := value.(type) {
+	case string:
+		return val
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', -1, 32)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(val)
+	case []byte:
+		return string(val)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// ============================================================================
+// url.Values 转换测试
+// ============================================================================
+
+// TestExtrasFromURLValues 测试从查询参数解析
+func TestExtrasFromURLValues(t *testing.T) {
+	query, err := url.ParseQuery("page=2&price=9.5&code=007&exp=1e3&name=tom&tag=a&tag=b&ids=1&ids=x&flag&single=x")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var plain Extras
+	plain.FromURLValues(query, WithSliceKeys("single"))
+	want := Extras{
+		"page": "2", "price": "9.5", "code": "007", "exp": "1e3", "name": "tom", "flag": "",
+		"tag": []string{"a", "b"}, "ids": []string{"1", "x"}, "single": []string{"x"},
+	}
+	if !reflect.DeepEqual(plain, want) {
+		t.Errorf("FromURLValues() = %v, 期望 %v", plain, want)
+	}
+
+	var typed Extras
+	typed.FromURLValues(query, WithNumberDetection(true))
+	if page, ok := typed.GetInt("page"); !ok || page != 2 {
+		t.Errorf("GetInt(page) = %v, %v", page, ok)
+	}
+	if price, ok := typed.GetFloat64("price"); !ok || price != 9.5 {
+		t.Errorf("GetFloat64(price) = %v, %v", price, ok)
+	}
+	for _, key := range []string{"code", "exp", "name"} {
+		if _, ok := typed.GetString(key); !ok {
+			t.Errorf("%s 应保持为字符串: %#v", key, typed[key])
+		}
+	}
+	if got, want := typed["ids"], []any{int64(1), "x"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ids = %#v, 期望 %#v", got, want)
+	}
+
+	typed.FromURLValues(url.Values{"n": {"1", "2"}}, WithNumberDetection(true))
+	if ints, ok := typed.GetIntSlice("n"); !ok || !reflect.DeepEqual(ints, []int{1, 2}) || len(typed) != 1 {
+		t.Errorf("应替换现有内容并支持 GetIntSlice: %v %v %v", typed, ints, ok)
+	}
+}
+
+// TestExtrasToURLValues 测试转换为查询参数
+func TestExtrasToURLValues(t *testing.T) {
+	e := Extras{
+		"name":   "tom",
+		"page":   2,
+		"price":  9.5,
+		"active": true,
+		"tags":   []string{"a", "b"},
+		"ids":    []int{1, 2},
+		"mixed":  []any{"x", float64(3), nil},
+		"meta":   map[string]any{"k": "v"},
+		"none":   nil,
+	}
+
+	values := e.ToURLValues()
+	want := url.Values{
+		"name":   {"tom"},
+		"page":   {"2"},
+		"price":  {"9.5"},
+		"active": {"true"},
+		"tags":   {"a", "b"},
+		"ids":    {"1", "2"},
+		"mixed":  {"x", "3"},
+		"meta":   {`{"k":"v"}`},
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("ToURLValues() = %v, 期望 %v", values, want)
+	}
+
+	// 往返转换
+	var back Extras
+	back.FromURLValues(values, WithNumberDetection(true))
+	if page, _ := back.GetInt("page"); page != 2 || back.GetStringOr("name", "") != "tom" {
+		t.Errorf("往返转换结果 = %v", back)
+	}
+}
@@ -0,0 +1,180 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ============================================================================
+// MilliTime 毫秒时间
+// ============================================================================
+
+// MilliTime 毫秒精度的 UTC 时间
+//
+// 设计说明：
+// - JSON 序列化为 Unix 毫秒时间戳（int64），零值输出 null
+// - 数据库中存储为 UTC 时间，零值写入 NULL
+// - 设置时统一转换为 UTC 并截断到毫秒，保证 JSON 往返后相等
+type MilliTime struct {
+	time.Time
+}
+
+// NewMilliTime 创建毫秒时间（转换为 UTC 并截断到毫秒）
+func NewMilliTime(t time.Time) MilliTime {
+	if t.IsZero() {
+		return MilliTime{}
+	}
+	return MilliTime{Time: t.UTC().Truncate(time.Millisecond)}
+}
+
+// UnixMilli 毫秒时间戳，零值返回 0
+func (t MilliTime) UnixMilli() int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Time.UnixMilli()
+}
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (t MilliTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return strconv.AppendInt(nil, t.Time.UnixMilli(), 10), nil
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+// 说明：支持毫秒时间戳和 RFC 3339 字符串，null 和 0 表示零值
+func (t *MilliTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = MilliTime{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("failed to unmarshal MilliTime from JSON: %w", err)
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal MilliTime from JSON: %w", err)
+		}
+		*t = NewMilliTime(parsed)
+		return nil
+	}
+
+	ms, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal MilliTime from JSON: %w", err)
+	}
+	if ms == 0 {
+		*t = MilliTime{}
+		return nil
+	}
+	*t = NewMilliTime(time.UnixMilli(ms))
+	return nil
+}
+
+// Value 实现 driver.Valuer 接口
+func (t MilliTime) Value() (driver.Value, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+	return t.Time.UTC(), nil
+}
+
+// Scan 实现 sql.Scanner 接口
+// 说明：支持 time.Time 和毫秒时间戳
+func (t *MilliTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*t = MilliTime{}
+	case time.Time:
+		*t = NewMilliTime(v)
+	case int64:
+		if v == 0 {
+			*t = MilliTime{}
+		} else {
+			*t = NewMilliTime(time.UnixMilli(v))
+		}
+	default:
+		return fmt.Errorf("cannot scan type %T into MilliTime", value)
+	}
+	return nil
+}
+
+// ============================================================================
+// ModelTimes 模型时间戳
+// ============================================================================
+
+// nowFunc 当前时间（测试时可替换）
+var nowFunc = time.Now
+
+// ModelTimes 模型通用时间戳，嵌入到模型结构体中使用
+//
+// 设计说明：
+// - 统一 created_at / updated_at / deleted_at 三列的语义：UTC 存储、毫秒精度、JSON 输出毫秒时间戳
+// - 软删除与 Status 删除位联动：MarkDeleted 同时设置删除时间和删除状态位，Restore 同时清除
+// - deleted_at 为 NULL 表示未删除
+//
+// 使用示例：
+//
+//	type Account struct {
+//	    ID     int64        `json:"id"`
+//	    Status types.Status `json:"status"`
+//	    types.ModelTimes
+//	}
+type ModelTimes struct {
+	CreatedAt MilliTime `json:"created_at"` // 创建时间
+	UpdatedAt MilliTime `json:"updated_at"` // 更新时间
+	DeletedAt MilliTime `json:"deleted_at"` // 删除时间，未删除时为 null
+}
+
+// Touch 更新修改时间，创建时间为空时一并设置
+func (m *ModelTimes) Touch() {
+	now := NewMilliTime(nowFunc())
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	}
+	m.UpdatedAt = now
+}
+
+// MarkDeleted 标记为软删除
+// 参数：
+//   - status：模型的状态，会添加删除状态位
+//   - flag：删除状态位，必须是 StatusSysDeleted / StatusAdmDeleted / StatusUserDeleted 之一或组合
+func (m *ModelTimes) MarkDeleted(status *Status, flag Status) error {
+	if status == nil {
+		return fmt.Errorf("status cannot be nil")
+	}
+	if flag == StatusNone || flag&^StatusAllDeleted != 0 {
+		return fmt.Errorf("invalid delete flag %d: must be within StatusAllDeleted", flag)
+	}
+
+	now := NewMilliTime(nowFunc())
+	status.Add(flag)
+	if m.DeletedAt.IsZero() {
+		m.DeletedAt = now
+	}
+	m.UpdatedAt = now
+	return nil
+}
+
+// Restore 恢复软删除
+// 说明：清除所有删除状态位和删除时间
+func (m *ModelTimes) Restore(status *Status) {
+	if status != nil {
+		status.Del(StatusAllDeleted)
+	}
+	m.DeletedAt = MilliTime{}
+	m.UpdatedAt = NewMilliTime(nowFunc())
+}
+
+// IsDeleted 是否已软删除
+func (m ModelTimes) IsDeleted() bool {
+	return !m.DeletedAt.IsZero()
+}
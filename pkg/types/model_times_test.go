@@ -0,0 +1,120 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// ModelTimes 测试
+// ============================================================================
+
+// withNow 固定当前时间
+func withNow(t *testing.T, now time.Time) {
+	t.Helper()
+	original := nowFunc
+	nowFunc = func() time.Time { return now }
+	t.Cleanup(func() { nowFunc = original })
+}
+
+type timesAccount struct {
+	ID     int64  `json:"id"`
+	Status Status `json:"status"`
+	ModelTimes
+}
+
+// TestModelTimes_Touch 测试更新时间
+func TestModelTimes_Touch(t *testing.T) {
+	local := time.FixedZone("CST", 8*3600)
+	created := time.Date(2024, 1, 1, 8, 0, 0, 123456789, local)
+	withNow(t, created)
+
+	var m ModelTimes
+	m.Touch()
+	if !m.CreatedAt.Equal(created.Truncate(time.Millisecond)) || m.CreatedAt.Location() != time.UTC {
+		t.Errorf("CreatedAt = %v, want UTC %v", m.CreatedAt, created)
+	}
+
+	withNow(t, created.Add(time.Hour))
+	m.Touch()
+	if !m.CreatedAt.Equal(created.Truncate(time.Millisecond)) {
+		t.Errorf("Touch() should not change CreatedAt, got %v", m.CreatedAt)
+	}
+	if !m.UpdatedAt.Equal(created.Add(time.Hour).Truncate(time.Millisecond)) {
+		t.Errorf("UpdatedAt = %v", m.UpdatedAt)
+	}
+}
+
+// TestModelTimes_MarkDeleted 测试软删除与状态位联动
+func TestModelTimes_MarkDeleted(t *testing.T) {
+	withNow(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	var account timesAccount
+	if err := account.MarkDeleted(&account.Status, StatusUserDeleted); err != nil {
+		t.Fatalf("MarkDeleted() error = %v", err)
+	}
+	if !account.IsDeleted() || !account.Status.Has(StatusUserDeleted) {
+		t.Errorf("MarkDeleted() = (%v, %v), want deleted", account.DeletedAt, account.Status)
+	}
+
+	if err := account.MarkDeleted(&account.Status, StatusSysDisabled); err == nil {
+		t.Error("MarkDeleted() with non-delete flag should fail")
+	}
+	if err := account.MarkDeleted(nil, StatusUserDeleted); err == nil {
+		t.Error("MarkDeleted() with nil status should fail")
+	}
+
+	account.Restore(&account.Status)
+	if account.IsDeleted() || account.Status.IsDeleted() {
+		t.Errorf("Restore() = (%v, %v), want restored", account.DeletedAt, account.Status)
+	}
+}
+
+// TestModelTimes_JSON 测试毫秒时间戳序列化
+func TestModelTimes_JSON(t *testing.T) {
+	account := timesAccount{ID: 1}
+	account.CreatedAt = NewMilliTime(time.UnixMilli(1704067200123))
+	account.UpdatedAt = account.CreatedAt
+
+	data, err := json.Marshal(account)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"id":1,"status":0,"created_at":1704067200123,"updated_at":1704067200123,"deleted_at":null}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var decoded timesAccount
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !decoded.CreatedAt.Equal(account.CreatedAt.Time) || decoded.IsDeleted() {
+		t.Errorf("Unmarshal() = %+v", decoded.ModelTimes)
+	}
+
+	var fromString MilliTime
+	if err := json.Unmarshal([]byte(`"2024-01-01T00:00:00.123Z"`), &fromString); err != nil || fromString.UnixMilli() != 1704067200123 {
+		t.Errorf("Unmarshal(RFC3339) = (%v, %v)", fromString, err)
+	}
+}
+
+// TestMilliTime_ValueScan 测试数据库读写
+func TestMilliTime_ValueScan(t *testing.T) {
+	if v, err := (MilliTime{}).Value(); err != nil || v != nil {
+		t.Errorf("Value() of zero = (%v, %v), want nil", v, err)
+	}
+
+	var scanned MilliTime
+	local := time.Date(2024, 1, 1, 8, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	if err := scanned.Scan(local); err != nil || scanned.Location() != time.UTC || !scanned.Equal(local) {
+		t.Errorf("Scan(time.Time) = (%v, %v)", scanned, err)
+	}
+	if err := scanned.Scan(int64(1704067200123)); err != nil || scanned.UnixMilli() != 1704067200123 {
+		t.Errorf("Scan(int64) = (%v, %v)", scanned, err)
+	}
+	if err := scanned.Scan("bad"); err == nil {
+		t.Error("Scan(string) should fail")
+	}
+}
@@ -0,0 +1,455 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Money 金额类型，使用最小货币单位（如分）的整数存储，避免 float64 的精度问题
+//
+// 设计说明：
+// - 金额以 int64 最小单位存储，币种使用 ISO 4217 代码（如 CNY、USD、JPY）
+// - 小数位数由币种决定（CNY 为 2 位，JPY 为 0 位），可通过 RegisterCurrency 扩展
+// - 所有运算都检查币种一致性和 int64 溢出，失败时返回错误而不是静默截断
+// - 值类型，不可变：运算方法返回新值，不修改原值
+//
+// 序列化格式：
+// - JSON：{"amount":"99.99","currency":"CNY"}，金额使用字符串避免精度丢失
+// - 数据库：JSON 字符串，与 JSON 格式相同
+//
+// 注意事项：
+// - 零值 Money{} 没有币种，只能与零值或同币种金额运算
+// - 分配（Allocate/Split）使用最大余数法，保证各份之和等于原金额
+type Money struct {
+	units    int64  // 最小货币单位数量
+	currency string // 币种代码（大写）
+}
+
+// 默认小数位数（未注册的币种）
+const defaultCurrencyPrecision = 2
+
+var (
+	// ErrCurrencyMismatch 币种不一致
+	ErrCurrencyMismatch = errors.New("money: currency mismatch")
+	// ErrMoneyOverflow 金额溢出
+	ErrMoneyOverflow = errors.New("money: amount overflows int64")
+)
+
+// currencyPrecisions 币种 -> 小数位数
+var (
+	currencyMu         sync.RWMutex
+	currencyPrecisions = map[string]int{
+		"CNY": 2, "USD": 2, "EUR": 2, "GBP": 2, "HKD": 2, "TWD": 2, "SGD": 2, "AUD": 2, "CAD": 2,
+		"JPY": 0, "KRW": 0, "VND": 0,
+		"BHD": 3, "KWD": 3, "OMR": 3,
+	}
+)
+
+// RegisterCurrency 注册币种及其小数位数
+func RegisterCurrency(currency string, precision int) error {
+	if currency == "" {
+		return fmt.Errorf("money: currency cannot be empty")
+	}
+	if precision < 0 || precision > 8 {
+		return fmt.Errorf("money: invalid precision %d for currency %s", precision, currency)
+	}
+
+	currencyMu.Lock()
+	defer currencyMu.Unlock()
+	currencyPrecisions[strings.ToUpper(currency)] = precision
+	return nil
+}
+
+// CurrencyPrecision 获取币种的小数位数，未注册的币种为 2 位
+func CurrencyPrecision(currency string) int {
+	currencyMu.RLock()
+	defer currencyMu.RUnlock()
+	if precision, ok := currencyPrecisions[strings.ToUpper(currency)]; ok {
+		return precision
+	}
+	return defaultCurrencyPrecision
+}
+
+// ============================================================================
+// 创建
+// ============================================================================
+
+// NewMoney 使用最小货币单位创建金额
+// 示例：NewMoney(9999, "CNY") 表示 99.99 元
+func NewMoney(units int64, currency string) Money {
+	return Money{units: units, currency: strings.ToUpper(currency)}
+}
+
+// ParseMoney 解析十进制金额字符串
+// 示例：ParseMoney("99.99", "CNY")
+// 说明：小数位数超过币种精度时返回错误（末尾的 0 除外）
+func ParseMoney(amount, currency string) (Money, error) {
+	currency = strings.ToUpper(currency)
+	units, err := parseDecimal(amount, CurrencyPrecision(currency))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{units: units, currency: currency}, nil
+}
+
+// MustParseMoney 解析十进制金额字符串，失败时 panic
+func MustParseMoney(amount, currency string) Money {
+	m, err := ParseMoney(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// parseDecimal 将十进制字符串转换为指定精度的整数
+func parseDecimal(s string, precision int) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("money: empty amount")
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return 0, fmt.Errorf("money: invalid amount %q", s)
+	}
+
+	// 超出精度的小数位只允许为 0
+	if len(fracPart) > precision {
+		if strings.TrimRight(fracPart[precision:], "0") != "" {
+			return 0, fmt.Errorf("money: amount %q exceeds precision %d", s, precision)
+		}
+		fracPart = fracPart[:precision]
+	}
+	fracPart += strings.Repeat("0", precision-len(fracPart))
+
+	var units int64
+	for _, c := range intPart + fracPart {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("money: invalid amount %q", s)
+		}
+		if units > (math.MaxInt64-int64(c-'0'))/10 {
+			return 0, ErrMoneyOverflow
+		}
+		units = units*10 + int64(c-'0')
+	}
+
+	if negative {
+		units = -units
+	}
+	return units, nil
+}
+
+// ============================================================================
+// 查询
+// ============================================================================
+
+// Units 最小货币单位数量
+func (m Money) Units() int64 {
+	return m.units
+}
+
+// Currency 币种代码
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// Precision 小数位数
+func (m Money) Precision() int {
+	return CurrencyPrecision(m.currency)
+}
+
+// Amount 十进制金额字符串（如 "99.99"）
+func (m Money) Amount() string {
+	precision := m.Precision()
+
+	// 使用 uint64 处理 math.MinInt64 的绝对值
+	abs := uint64(m.units)
+	sign := ""
+	if m.units < 0 {
+		abs = uint64(-(m.units + 1)) + 1
+		sign = "-"
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if precision == 0 {
+		return sign + digits
+	}
+	if len(digits) <= precision {
+		digits = strings.Repeat("0", precision-len(digits)+1) + digits
+	}
+	cut := len(digits) - precision
+	return sign + digits[:cut] + "." + digits[cut:]
+}
+
+// String 实现 Stringer 接口，如 "99.99 CNY"
+func (m Money) String() string {
+	if m.currency == "" {
+		return m.Amount()
+	}
+	return m.Amount() + " " + m.currency
+}
+
+// IsZero 金额是否为 0
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+// IsPositive 金额是否大于 0
+func (m Money) IsPositive() bool {
+	return m.units > 0
+}
+
+// IsNegative 金额是否小于 0
+func (m Money) IsNegative() bool {
+	return m.units < 0
+}
+
+// ============================================================================
+// 运算
+// ============================================================================
+
+// sameCurrency 检查币种是否一致
+// 说明：零值 Money{} 视为与任意币种兼容
+func (m Money) sameCurrency(other Money) (string, error) {
+	switch {
+	case m.currency == other.currency:
+		return m.currency, nil
+	case m.currency == "" && m.units == 0:
+		return other.currency, nil
+	case other.currency == "" && other.units == 0:
+		return m.currency, nil
+	default:
+		return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+}
+
+// Add 加法
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.sameCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	sum := m.units + other.units
+	if (other.units > 0 && sum < m.units) || (other.units < 0 && sum > m.units) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{units: sum, currency: currency}, nil
+}
+
+// Sub 减法
+func (m Money) Sub(other Money) (Money, error) {
+	if other.units == math.MinInt64 {
+		return Money{}, ErrMoneyOverflow
+	}
+	return m.Add(Money{units: -other.units, currency: other.currency})
+}
+
+// Mul 乘以整数
+func (m Money) Mul(n int64) (Money, error) {
+	if m.units == 0 || n == 0 {
+		return Money{currency: m.currency}, nil
+	}
+	product := m.units * n
+	if product/n != m.units || (m.units == -1 && n == math.MinInt64) || (n == -1 && m.units == math.MinInt64) {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{units: product, currency: m.currency}, nil
+}
+
+// Neg 取反
+func (m Money) Neg() (Money, error) {
+	return m.Mul(-1)
+}
+
+// Abs 绝对值
+func (m Money) Abs() (Money, error) {
+	if m.units < 0 {
+		return m.Neg()
+	}
+	return m, nil
+}
+
+// Allocate 按比例分配金额（最大余数法）
+// 示例：100.00 按 1:1:1 分配为 33.34、33.33、33.33
+// 说明：各份之和严格等于原金额，余数按顺序分给前几份
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	if len(ratios) == 0 {
+		return nil, fmt.Errorf("money: no ratios to allocate")
+	}
+
+	var total int64
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, fmt.Errorf("money: negative ratio %d", ratio)
+		}
+		total += int64(ratio)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("money: ratios sum to zero")
+	}
+
+	// 使用大整数计算 units*ratio，避免溢出
+	units := big.NewInt(m.units)
+	bigTotal := big.NewInt(total)
+	parts := make([]Money, len(ratios))
+	var allocated int64
+	for i, ratio := range ratios {
+		share := new(big.Int).Mul(units, big.NewInt(int64(ratio)))
+		share.Quo(share, bigTotal)
+		parts[i] = Money{units: share.Int64(), currency: m.currency}
+		allocated += parts[i].units
+	}
+
+	// 余数逐个分给比例非 0 的份额
+	remainder := m.units - allocated
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].units += step
+		remainder -= step
+	}
+
+	return parts, nil
+}
+
+// Split 平均分成 n 份
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("money: invalid split count %d", n)
+	}
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// ============================================================================
+// 比较
+// ============================================================================
+
+// Compare 比较金额，返回 -1、0、1
+func (m Money) Compare(other Money) (int, error) {
+	if _, err := m.sameCurrency(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.units < other.units:
+		return -1, nil
+	case m.units > other.units:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Equal 金额和币种是否都相同
+func (m Money) Equal(other Money) bool {
+	return m.units == other.units && m.currency == other.currency
+}
+
+// CompareAmount 比较两个十进制金额字符串，返回 -1、0、1
+// 说明：精确比较，不经过 float64；供验证规则（如 money_gt=0）使用
+func CompareAmount(a, b string) (int, error) {
+	x, ok := new(big.Rat).SetString(strings.TrimSpace(a))
+	if !ok {
+		return 0, fmt.Errorf("money: invalid amount %q", a)
+	}
+	y, ok := new(big.Rat).SetString(strings.TrimSpace(b))
+	if !ok {
+		return 0, fmt.Errorf("money: invalid amount %q", b)
+	}
+	return x.Cmp(y), nil
+}
+
+// ============================================================================
+// JSON 接口实现
+// ============================================================================
+
+// moneyJSON JSON 结构
+type moneyJSON struct {
+	Amount   json.Number `json:"amount"`
+	Currency string      `json:"currency"`
+}
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{m.Amount(), m.currency})
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+// 说明：amount 支持字符串和数字两种格式
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*m = Money{}
+		return nil
+	}
+
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to unmarshal Money from JSON: %w", err)
+	}
+
+	amount := raw.Amount.String()
+	if amount == "" {
+		amount = "0"
+	}
+	parsed, err := ParseMoney(amount, raw.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// ============================================================================
+// 数据库接口实现
+// ============================================================================
+
+// Value 实现 driver.Valuer 接口，存储为 JSON 字符串
+func (m Money) Value() (driver.Value, error) {
+	data, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan 实现 sql.Scanner 接口
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = Money{}
+		return nil
+	case []byte:
+		return m.UnmarshalJSON(v)
+	case string:
+		return m.UnmarshalJSON([]byte(v))
+	default:
+		return fmt.Errorf("cannot scan type %T into Money", value)
+	}
+}
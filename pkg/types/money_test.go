@@ -0,0 +1,188 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+// ============================================================================
+// Money 测试
+// ============================================================================
+
+// TestMoney_ParseAndAmount 测试解析和格式化
+func TestMoney_ParseAndAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		currency string
+		units    int64
+		want     string
+		wantErr  bool
+	}{
+		{"两位小数", "99.99", "cny", 9999, "99.99", false},
+		{"整数", "100", "CNY", 10000, "100.00", false},
+		{"一位小数", "0.5", "USD", 50, "0.50", false},
+		{"负数", "-0.01", "CNY", -1, "-0.01", false},
+		{"末尾多余的 0", "1.500", "CNY", 150, "1.50", false},
+		{"日元无小数", "1000", "JPY", 1000, "1000", false},
+		{"三位小数币种", "1.234", "KWD", 1234, "1.234", false},
+		{"超出精度", "1.001", "CNY", 0, "", true},
+		{"非法字符", "1a", "CNY", 0, "", true},
+		{"空字符串", "", "CNY", 0, "", true},
+		{"溢出", "99999999999999999999", "CNY", 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseMoney(tt.amount, tt.currency)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMoney() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if m.Units() != tt.units {
+				t.Errorf("Units() = %d, want %d", m.Units(), tt.units)
+			}
+			if m.Amount() != tt.want {
+				t.Errorf("Amount() = %s, want %s", m.Amount(), tt.want)
+			}
+		})
+	}
+}
+
+// TestMoney_Arithmetic 测试运算
+func TestMoney_Arithmetic(t *testing.T) {
+	a := MustParseMoney("10.50", "CNY")
+	b := MustParseMoney("0.75", "CNY")
+
+	sum, err := a.Add(b)
+	if err != nil || sum.Amount() != "11.25" {
+		t.Errorf("Add() = (%v, %v), want 11.25", sum, err)
+	}
+	diff, err := b.Sub(a)
+	if err != nil || diff.Amount() != "-9.75" {
+		t.Errorf("Sub() = (%v, %v), want -9.75", diff, err)
+	}
+	product, err := a.Mul(3)
+	if err != nil || product.Amount() != "31.50" {
+		t.Errorf("Mul() = (%v, %v), want 31.50", product, err)
+	}
+
+	// 零值与任意币种兼容
+	if sum, err := (Money{}).Add(a); err != nil || !sum.Equal(a) {
+		t.Errorf("zero.Add() = (%v, %v), want %v", sum, err, a)
+	}
+
+	if _, err := a.Add(MustParseMoney("1", "USD")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add() with different currency error = %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := NewMoney(math.MaxInt64, "CNY").Add(NewMoney(1, "CNY")); !errors.Is(err, ErrMoneyOverflow) {
+		t.Errorf("Add() overflow error = %v, want ErrMoneyOverflow", err)
+	}
+	if _, err := NewMoney(math.MaxInt64/2+1, "CNY").Mul(2); !errors.Is(err, ErrMoneyOverflow) {
+		t.Errorf("Mul() overflow error = %v, want ErrMoneyOverflow", err)
+	}
+	if cmp, err := a.Compare(b); err != nil || cmp != 1 {
+		t.Errorf("Compare() = (%d, %v), want 1", cmp, err)
+	}
+}
+
+// TestMoney_Allocate 测试分配
+func TestMoney_Allocate(t *testing.T) {
+	tests := []struct {
+		name   string
+		money  Money
+		ratios []int
+		want   []int64
+	}{
+		{"平均分配有余数", NewMoney(10000, "CNY"), []int{1, 1, 1}, []int64{3334, 3333, 3333}},
+		{"按比例分配", NewMoney(100, "CNY"), []int{70, 30}, []int64{70, 30}},
+		{"负数金额", NewMoney(-100, "CNY"), []int{1, 1, 1}, []int64{-34, -33, -33}},
+		{"比例为 0 的份额", NewMoney(5, "CNY"), []int{0, 1, 1}, []int64{0, 3, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := tt.money.Allocate(tt.ratios...)
+			if err != nil {
+				t.Fatalf("Allocate() error = %v", err)
+			}
+			for i, part := range parts {
+				if part.Units() != tt.want[i] {
+					t.Errorf("Allocate()[%d] = %d, want %d", i, part.Units(), tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, err := NewMoney(100, "CNY").Split(0); err == nil {
+		t.Error("Split(0) should fail")
+	}
+}
+
+// TestMoney_JSON 测试 JSON 序列化
+func TestMoney_JSON(t *testing.T) {
+	m := MustParseMoney("99.99", "CNY")
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if got, want := string(data), `{"amount":"99.99","currency":"CNY"}`; got != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+
+	for _, input := range []string{`{"amount":"99.99","currency":"CNY"}`, `{"amount":99.99,"currency":"cny"}`} {
+		var got Money
+		if err := json.Unmarshal([]byte(input), &got); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", input, err)
+		}
+		if !got.Equal(m) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", input, got, m)
+		}
+	}
+
+	var bad Money
+	if err := json.Unmarshal([]byte(`{"amount":"1.001","currency":"CNY"}`), &bad); err == nil {
+		t.Error("Unmarshal() with excess precision should fail")
+	}
+}
+
+// TestMoney_ValueScan 测试数据库读写
+func TestMoney_ValueScan(t *testing.T) {
+	m := MustParseMoney("12.30", "USD")
+	v, err := m.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+
+	var got Money
+	if err := got.Scan([]byte(v.(string))); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if !got.Equal(m) {
+		t.Errorf("Scan() = %v, want %v", got, m)
+	}
+}
+
+// TestCompareAmount 测试金额字符串比较
+func TestCompareAmount(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"0.01", "0", 1},
+		{"99.99", "100", -1},
+		{"1.50", "1.5", 0},
+	}
+	for _, tt := range tests {
+		if got, err := CompareAmount(tt.a, tt.b); err != nil || got != tt.want {
+			t.Errorf("CompareAmount(%s, %s) = (%d, %v), want %d", tt.a, tt.b, got, err, tt.want)
+		}
+	}
+	if _, err := CompareAmount("abc", "1"); err == nil {
+		t.Error("CompareAmount() with invalid amount should fail")
+	}
+}
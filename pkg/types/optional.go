@@ -0,0 +1,218 @@
+package types
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Optional 可选值类型，区分"未提供"、"显式 null"和"提供了值"
+//
+// 设计说明：
+// - 主要用于部分更新（PATCH）模型，解决 omitempty 把 false/0 当作未提供的问题
+// - 未提供：Defined=false，JSON 中没有该字段
+// - 显式 null：Defined=true 且 Null=true，JSON 中为 null，数据库中为 NULL
+// - 提供了值：Defined=true 且 Null=false，Val 可以是零值
+// - 验证器识别该类型：未提供的字段在部分更新场景下跳过验证，零值按规则正常验证
+//
+// 注意事项：
+// - 序列化时未提供和 null 都输出 null；如需省略字段，请在外层结构体使用指针
+// - 所有修改方法都需要指针接收者才能生效
+type Optional[T any] struct {
+	Val     T    // 值（字段名避开 driver.Valuer 的 Value 方法）
+	Defined bool // 是否提供了该字段（显式 null 也算提供）
+	Null    bool // 是否为显式 null
+}
+
+// NewOptional 创建已提供值的 Optional
+func NewOptional[T any](value T) Optional[T] {
+	return Optional[T]{Val: value, Defined: true}
+}
+
+// NullOptional 创建显式 null 的 Optional
+func NullOptional[T any]() Optional[T] {
+	return Optional[T]{Defined: true, Null: true}
+}
+
+// ============================================================================
+// 查询和修改
+// ============================================================================
+
+// IsDefined 是否提供了该字段（显式 null 也算提供）
+func (o Optional[T]) IsDefined() bool {
+	return o.Defined
+}
+
+// IsNull 是否为显式 null
+func (o Optional[T]) IsNull() bool {
+	return o.Defined && o.Null
+}
+
+// IsPresent 是否提供了非 null 的值
+func (o Optional[T]) IsPresent() bool {
+	return o.Defined && !o.Null
+}
+
+// Get 获取值
+// 返回值：未提供或为 null 时返回零值和 false
+func (o Optional[T]) Get() (T, bool) {
+	if !o.IsPresent() {
+		var zero T
+		return zero, false
+	}
+	return o.Val, true
+}
+
+// OrElse 获取值，未提供或为 null 时返回默认值
+func (o Optional[T]) OrElse(defaultValue T) T {
+	if !o.IsPresent() {
+		return defaultValue
+	}
+	return o.Val
+}
+
+// OptionalValue 获取值（供验证器使用）
+// 返回值：valid 为 false 表示未提供或显式 null
+func (o Optional[T]) OptionalValue() (value any, valid bool) {
+	if !o.IsPresent() {
+		return nil, false
+	}
+	return o.Val, true
+}
+
+// Set 设置值
+func (o *Optional[T]) Set(value T) {
+	o.Val, o.Defined, o.Null = value, true, false
+}
+
+// SetNull 设置为显式 null
+func (o *Optional[T]) SetNull() {
+	var zero T
+	o.Val, o.Defined, o.Null = zero, true, true
+}
+
+// Unset 重置为未提供
+func (o *Optional[T]) Unset() {
+	*o = Optional[T]{}
+}
+
+// ============================================================================
+// JSON 接口实现
+// ============================================================================
+
+// MarshalJSON 实现 json.Marshaler 接口
+// 说明：未提供和显式 null 都输出 null
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.IsPresent() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Val)
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+// 说明：只有 JSON 中存在该字段时才会调用，因此调用即表示已提供
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("empty JSON data")
+	}
+
+	// 快速路径：处理 JSON null
+	if bytes.Equal(data, []byte("null")) {
+		o.SetNull()
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to unmarshal Optional from JSON: %w", err)
+	}
+	o.Set(value)
+	return nil
+}
+
+// ============================================================================
+// 数据库接口实现
+// ============================================================================
+
+// Value 实现 driver.Valuer 接口
+// 说明：未提供和显式 null 都写入 NULL
+func (o Optional[T]) Value() (driver.Value, error) {
+	if !o.IsPresent() {
+		return nil, nil
+	}
+	if valuer, ok := any(o.Val).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.Val)
+}
+
+// Scan 实现 sql.Scanner 接口
+// 说明：从数据库读取即表示已提供，NULL 对应显式 null
+func (o *Optional[T]) Scan(value interface{}) error {
+	if value == nil {
+		o.SetNull()
+		return nil
+	}
+
+	// 值类型自身实现了 Scanner（如 Status）
+	if scanner, ok := any(&o.Val).(sql.Scanner); ok {
+		if err := scanner.Scan(value); err != nil {
+			return err
+		}
+		o.Defined, o.Null = true, false
+		return nil
+	}
+
+	if err := scanInto(reflect.ValueOf(&o.Val).Elem(), value); err != nil {
+		return err
+	}
+	o.Defined, o.Null = true, false
+	return nil
+}
+
+// scanInto 将数据库值写入目标
+func scanInto(dst reflect.Value, value any) error {
+	src := reflect.ValueOf(value)
+
+	// 快速路径：类型直接匹配
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		// 数据库返回的字符串或 JSON 字节
+		if dst.Kind() == reflect.String {
+			dst.SetString(string(v))
+			return nil
+		}
+		if err := json.Unmarshal(v, dst.Addr().Interface()); err != nil {
+			return fmt.Errorf("cannot scan bytes into Optional[%s]: %w", dst.Type(), err)
+		}
+		return nil
+	case string:
+		if dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.SetBytes([]byte(v))
+			return nil
+		}
+	}
+
+	// 同类数值之间转换（int64 -> int32 等），避免 int -> string 这类错误转换
+	if (numericKind(src.Kind()) && numericKind(dst.Kind())) || src.Kind() == dst.Kind() {
+		if src.Type().ConvertibleTo(dst.Type()) {
+			dst.Set(src.Convert(dst.Type()))
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cannot scan type %T into Optional[%s]", value, dst.Type())
+}
+
+// numericKind 判断是否为数值类型
+func numericKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
@@ -0,0 +1,125 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// ============================================================================
+// Optional 测试
+// ============================================================================
+
+type optionalPatch struct {
+	Enabled Optional[bool]   `json:"enabled"`
+	Age     Optional[int]    `json:"age"`
+	Nick    Optional[string] `json:"nick"`
+}
+
+// TestOptional_UnmarshalJSON 测试区分未提供、null 和零值
+func TestOptional_UnmarshalJSON(t *testing.T) {
+	var patch optionalPatch
+	if err := json.Unmarshal([]byte(`{"enabled":false,"nick":null}`), &patch); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !patch.Enabled.IsPresent() || patch.Enabled.Val {
+		t.Errorf("Enabled = %+v, want present false", patch.Enabled)
+	}
+	if patch.Age.IsDefined() {
+		t.Errorf("Age = %+v, want undefined", patch.Age)
+	}
+	if !patch.Nick.IsNull() {
+		t.Errorf("Nick = %+v, want null", patch.Nick)
+	}
+}
+
+// TestOptional_MarshalJSON 测试序列化
+func TestOptional_MarshalJSON(t *testing.T) {
+	patch := optionalPatch{
+		Enabled: NewOptional(false),
+		Nick:    NullOptional[string](),
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if got, want := string(data), `{"enabled":false,"age":null,"nick":null}`; got != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+}
+
+// TestOptional_GetOrElse 测试取值
+func TestOptional_GetOrElse(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     Optional[int]
+		want    int
+		wantOk  bool
+		orElse  int
+		present bool
+	}{
+		{"未提供", Optional[int]{}, 0, false, 7, false},
+		{"显式 null", NullOptional[int](), 0, false, 7, false},
+		{"零值", NewOptional(0), 0, true, 0, true},
+		{"非零值", NewOptional(3), 3, true, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.opt.Get()
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("Get() = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantOk)
+			}
+			if got := tt.opt.OrElse(7); got != tt.orElse {
+				t.Errorf("OrElse() = %v, want %v", got, tt.orElse)
+			}
+			if got := tt.opt.IsPresent(); got != tt.present {
+				t.Errorf("IsPresent() = %v, want %v", got, tt.present)
+			}
+		})
+	}
+}
+
+// TestOptional_ValueScan 测试数据库读写
+func TestOptional_ValueScan(t *testing.T) {
+	if v, err := NullOptional[int]().Value(); err != nil || v != nil {
+		t.Errorf("Value() of null = (%v, %v), want (nil, nil)", v, err)
+	}
+	if v, err := NewOptional(int32(5)).Value(); err != nil || v != int64(5) {
+		t.Errorf("Value() = (%v, %v), want (5, nil)", v, err)
+	}
+	if v, err := NewOptional(StatusSysDeleted).Value(); err != nil || v != int64(StatusSysDeleted) {
+		t.Errorf("Value() of Status = (%v, %v), want (%d, nil)", v, err, StatusSysDeleted)
+	}
+
+	tests := []struct {
+		name  string
+		value any
+		want  Optional[int]
+	}{
+		{"NULL", nil, NullOptional[int]()},
+		{"int64", int64(42), NewOptional(42)},
+		{"bytes", []byte("42"), NewOptional(42)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Optional[int]
+			if err := got.Scan(tt.value); err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Scan() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	var s Optional[string]
+	if err := s.Scan(int64(1)); err == nil {
+		t.Errorf("Scan(int64) into Optional[string] should fail, got %+v", s)
+	}
+
+	var status Optional[Status]
+	if err := status.Scan(int64(StatusAdmDeleted)); err != nil || status.Val != StatusAdmDeleted {
+		t.Errorf("Scan() into Optional[Status] = (%+v, %v)", status, err)
+	}
+}
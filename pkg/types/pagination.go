@@ -0,0 +1,349 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// 分页参数
+// ============================================================================
+
+// 分页默认值
+const (
+	DefaultPerPage = 20  // 未指定每页条数时的默认值
+	MaxPerPage     = 100 // 每页条数的默认上限
+)
+
+// PageRequest 分页请求参数
+//
+// 设计说明：
+// - 统一列表接口的 page/per_page 解析和上限控制，避免各接口重复实现
+// - 页码从 1 开始；零值表示未指定，由 Normalize 填充默认值
+//
+// 使用示例：
+//
+//	page, err := types.ParsePageRequest(r.URL.Query(), types.PageOptions{MaxPerPage: 50})
+//	db.Offset(page.Offset()).Limit(page.Limit())
+type PageRequest struct {
+	Page    int `json:"page"`     // 页码（从 1 开始）
+	PerPage int `json:"per_page"` // 每页条数
+}
+
+// PageOptions 分页选项（零值使用 DefaultPerPage、MaxPerPage）
+type PageOptions struct {
+	DefaultPerPage int // 未指定每页条数时的默认值
+	MaxPerPage     int // 每页条数上限，超出时截断为上限
+}
+
+// withDefaults 填充选项默认值
+func (o PageOptions) withDefaults() PageOptions {
+	if o.MaxPerPage <= 0 {
+		o.MaxPerPage = MaxPerPage
+	}
+	if o.DefaultPerPage <= 0 {
+		o.DefaultPerPage = DefaultPerPage
+	}
+	if o.DefaultPerPage > o.MaxPerPage {
+		o.DefaultPerPage = o.MaxPerPage
+	}
+	return o
+}
+
+// ParsePageRequest 从查询参数解析分页（page、per_page）
+// 说明：参数缺失时使用默认值，per_page 超过上限时截断；返回值已 Normalize
+// 返回值：参数不是整数或为负数时返回错误
+func ParsePageRequest(values url.Values, opts PageOptions) (PageRequest, error) {
+	var p PageRequest
+	var err error
+	if p.Page, err = parsePageParam(values, "page"); err != nil {
+		return PageRequest{}, err
+	}
+	if p.PerPage, err = parsePageParam(values, "per_page"); err != nil {
+		return PageRequest{}, err
+	}
+	return p.Normalize(opts), nil
+}
+
+// parsePageParam 解析非负整数参数（缺失或为空时返回 0）
+func parsePageParam(values url.Values, key string) (int, error) {
+	raw := strings.TrimSpace(values.Get(key))
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("pagination: %s must be a non-negative integer, got %q", key, raw)
+	}
+	return n, nil
+}
+
+// Normalize 填充默认值并限制上限（页码小于 1 时为 1，每页条数为 0 时使用默认值、超过上限时截断）
+func (p PageRequest) Normalize(opts PageOptions) PageRequest {
+	opts = opts.withDefaults()
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	switch {
+	case p.PerPage <= 0:
+		p.PerPage = opts.DefaultPerPage
+	case p.PerPage > opts.MaxPerPage:
+		p.PerPage = opts.MaxPerPage
+	}
+	return p
+}
+
+// Offset 查询偏移量（(Page-1) * Limit，页码小于 1 时按第 1 页计算）
+func (p PageRequest) Offset() int {
+	if p.Page < 1 {
+		return 0
+	}
+	return (p.Page - 1) * p.Limit()
+}
+
+// Limit 查询条数（未指定时为 DefaultPerPage）
+func (p PageRequest) Limit() int {
+	if p.PerPage <= 0 {
+		return DefaultPerPage
+	}
+	return p.PerPage
+}
+
+// TotalPages 总页数
+func (p PageRequest) TotalPages(total int64) int {
+	limit := int64(p.Limit())
+	if total <= 0 {
+		return 0
+	}
+	return int((total + limit - 1) / limit)
+}
+
+// ============================================================================
+// 排序参数
+// ============================================================================
+
+// SortField 单个排序字段
+type SortField struct {
+	Field string // 字段名（API 中的名称，如 created_at）
+	Desc  bool   // 是否降序
+}
+
+// String 排序字段的文本形式（降序加 - 前缀，如 -created_at）
+func (f SortField) String() string {
+	if f.Desc {
+		return "-" + f.Field
+	}
+	return f.Field
+}
+
+// SortSpec 排序参数，按优先级排列
+//
+// 文本格式：逗号分隔的字段，"-" 前缀表示降序，也支持 field:asc / field:desc
+// 示例："-created_at,name"、"created_at:desc,name:asc"
+//
+// 序列化格式：
+// - JSON：字符串 "-created_at,name"；反序列化同时支持字符串数组 ["-created_at","name"]
+// - 查询参数：实现了 TextUnmarshaler，可直接用于表单绑定
+//
+// 注意事项：
+// - 字段名必须经过白名单检查（ParseSort 的 allowed 参数或验证规则 sortable）后才能拼接到 SQL
+type SortSpec []SortField
+
+// ParseSort 解析排序参数
+// 参数：allowed 为允许排序的字段白名单，为空时不检查（仍然检查字段名格式）
+// 返回值：格式错误、字段不在白名单或重复时返回错误
+func ParseSort(s string, allowed ...string) (SortSpec, error) {
+	spec, err := parseSortSpec(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(allowed) > 0 {
+		if err := spec.Check(allowed...); err != nil {
+			return nil, err
+		}
+	}
+	return spec, nil
+}
+
+// IsSortable 排序文本格式正确且所有字段都在白名单中（空文本视为有效，验证规则 sortable 使用）
+func IsSortable(s string, allowed ...string) bool {
+	spec, err := parseSortSpec(s)
+	return err == nil && spec.Check(allowed...) == nil
+}
+
+// parseSortSpec 解析排序文本（不检查白名单）
+func parseSortSpec(s string) (SortSpec, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, ",")
+	spec := make(SortSpec, 0, len(parts))
+	seen := make(map[string]struct{}, len(parts))
+	for _, part := range parts {
+		field, err := parseSortField(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := seen[field.Field]; dup {
+			return nil, fmt.Errorf("sort: duplicate field %q", field.Field)
+		}
+		seen[field.Field] = struct{}{}
+		spec = append(spec, field)
+	}
+	return spec, nil
+}
+
+// parseSortField 解析单个排序字段（-name、+name、name、name:asc、name:desc）
+func parseSortField(s string) (SortField, error) {
+	var field SortField
+	switch {
+	case strings.HasPrefix(s, "-"):
+		field = SortField{Field: s[1:], Desc: true}
+	case strings.HasPrefix(s, "+"):
+		field = SortField{Field: s[1:]}
+	default:
+		name, dir, hasDir := strings.Cut(s, ":")
+		field.Field = name
+		if hasDir {
+			switch strings.ToLower(strings.TrimSpace(dir)) {
+			case "asc":
+			case "desc":
+				field.Desc = true
+			default:
+				return SortField{}, fmt.Errorf("sort: invalid direction %q for field %q", dir, name)
+			}
+		}
+	}
+
+	field.Field = strings.TrimSpace(field.Field)
+	if !isSortFieldName(field.Field) {
+		return SortField{}, fmt.Errorf("sort: invalid field name %q", field.Field)
+	}
+	return field, nil
+}
+
+// isSortFieldName 字段名只允许字母、数字、下划线和点（关联字段，如 user.name）
+func isSortFieldName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// Check 检查所有字段都在白名单中
+func (s SortSpec) Check(allowed ...string) error {
+	for _, field := range s {
+		if !containsString(allowed, field.Field) {
+			return fmt.Errorf("sort: field %q is not sortable (allowed: %s)", field.Field, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// containsString 切片中是否包含字符串
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty 是否未指定排序
+func (s SortSpec) IsEmpty() bool {
+	return len(s) == 0
+}
+
+// Fields 排序字段名
+func (s SortSpec) Fields() []string {
+	fields := make([]string, len(s))
+	for i, field := range s {
+		fields[i] = field.Field
+	}
+	return fields
+}
+
+// Or 未指定排序时使用默认排序
+func (s SortSpec) Or(fallback SortSpec) SortSpec {
+	if s.IsEmpty() {
+		return fallback
+	}
+	return s
+}
+
+// String 文本形式（如 -created_at,name）
+func (s SortSpec) String() string {
+	parts := make([]string, len(s))
+	for i, field := range s {
+		parts[i] = field.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// OrderBy 生成 ORDER BY 子句内容（如 "created_at DESC, name ASC"）
+// 参数：columns 将 API 字段名映射为数据库列名，未映射的字段使用原名；调用前必须已检查白名单
+func (s SortSpec) OrderBy(columns map[string]string) string {
+	parts := make([]string, len(s))
+	for i, field := range s {
+		column := field.Field
+		if mapped, ok := columns[column]; ok {
+			column = mapped
+		}
+		if field.Desc {
+			parts[i] = column + " DESC"
+		} else {
+			parts[i] = column + " ASC"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// MarshalJSON 实现 json.Marshaler 接口（未指定排序时输出空字符串）
+func (s SortSpec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口（支持字符串和字符串数组，null 表示未指定）
+func (s *SortSpec) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = nil
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var fields []string
+		if json.Unmarshal(data, &fields) != nil {
+			return fmt.Errorf("sort: expected string or string array, got %s", data)
+		}
+		text = strings.Join(fields, ",")
+	}
+	return s.UnmarshalText([]byte(text))
+}
+
+// MarshalText 实现 encoding.TextMarshaler 接口
+func (s SortSpec) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler 接口（不检查白名单）
+func (s *SortSpec) UnmarshalText(text []byte) error {
+	spec, err := parseSortSpec(string(text))
+	if err != nil {
+		return err
+	}
+	*s = spec
+	return nil
+}
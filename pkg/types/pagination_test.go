@@ -0,0 +1,126 @@
+package types
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// ============================================================================
+// 分页参数测试
+// ============================================================================
+
+// TestParsePageRequest 测试分页参数解析
+func TestParsePageRequest(t *testing.T) {
+	tests := []struct {
+		query   string
+		opts    PageOptions
+		want    PageRequest
+		wantErr bool
+	}{
+		{query: "", want: PageRequest{Page: 1, PerPage: DefaultPerPage}},
+		{query: "page=3&per_page=10", want: PageRequest{Page: 3, PerPage: 10}},
+		{query: "page=0&per_page=1000", want: PageRequest{Page: 1, PerPage: MaxPerPage}},
+		{query: "per_page=80", opts: PageOptions{MaxPerPage: 50}, want: PageRequest{Page: 1, PerPage: 50}},
+		{query: "", opts: PageOptions{DefaultPerPage: 10}, want: PageRequest{Page: 1, PerPage: 10}},
+		{query: "page=-1", wantErr: true},
+		{query: "per_page=ten", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		values, _ := url.ParseQuery(tt.query)
+		got, err := ParsePageRequest(values, tt.opts)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePageRequest(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParsePageRequest(%q) = %+v, 期望 %+v", tt.query, got, tt.want)
+		}
+	}
+}
+
+// TestPageRequestOffset 测试偏移量和总页数
+func TestPageRequestOffset(t *testing.T) {
+	p := PageRequest{Page: 3, PerPage: 25}
+	if p.Offset() != 50 || p.Limit() != 25 {
+		t.Errorf("Offset/Limit = %d/%d", p.Offset(), p.Limit())
+	}
+	if got := p.TotalPages(101); got != 5 {
+		t.Errorf("TotalPages(101) = %d", got)
+	}
+	if got := p.TotalPages(0); got != 0 {
+		t.Errorf("TotalPages(0) = %d", got)
+	}
+
+	var zero PageRequest
+	if zero.Offset() != 0 || zero.Limit() != DefaultPerPage {
+		t.Errorf("零值 Offset/Limit = %d/%d", zero.Offset(), zero.Limit())
+	}
+}
+
+// ============================================================================
+// 排序参数测试
+// ============================================================================
+
+// TestParseSort 测试排序参数解析
+func TestParseSort(t *testing.T) {
+	got, err := ParseSort("-created_at, name:asc,views:DESC,+id", "created_at", "name", "views", "id")
+	if err != nil {
+		t.Fatalf("ParseSort() error = %v", err)
+	}
+	want := SortSpec{{Field: "created_at", Desc: true}, {Field: "name"}, {Field: "views", Desc: true}, {Field: "id"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSort() = %v, 期望 %v", got, want)
+	}
+	if got.String() != "-created_at,name,-views,id" {
+		t.Errorf("String() = %q", got.String())
+	}
+	if clause := got.OrderBy(map[string]string{"name": "users.name"}); clause != "created_at DESC, users.name ASC, views DESC, id ASC" {
+		t.Errorf("OrderBy() = %q", clause)
+	}
+
+	for _, s := range []string{"password", "name;drop", "name,name", "name:up", "-", "a,,b"} {
+		if _, err := ParseSort(s, "name", "a", "b"); err == nil {
+			t.Errorf("ParseSort(%q) 应返回错误", s)
+		}
+	}
+
+	if spec, err := ParseSort("  "); err != nil || !spec.IsEmpty() {
+		t.Errorf("ParseSort(空) = %v, %v", spec, err)
+	}
+	if !IsSortable("", "name") || !IsSortable("-name", "name") || IsSortable("email", "name") {
+		t.Error("IsSortable 结果错误")
+	}
+}
+
+// TestSortSpecJSON 测试排序参数序列化
+func TestSortSpecJSON(t *testing.T) {
+	var query struct {
+		Sort SortSpec `json:"sort"`
+	}
+	for _, data := range []string{`{"sort":"-created_at,name"}`, `{"sort":["-created_at","name"]}`} {
+		if err := json.Unmarshal([]byte(data), &query); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", data, err)
+		}
+		if query.Sort.String() != "-created_at,name" {
+			t.Errorf("Unmarshal(%s) = %v", data, query.Sort)
+		}
+	}
+
+	out, err := json.Marshal(query)
+	if err != nil || string(out) != `{"sort":"-created_at,name"}` {
+		t.Errorf("Marshal() = %s, %v", out, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"sort":1}`), &query); err == nil {
+		t.Error("非字符串应返回错误")
+	}
+	if err := json.Unmarshal([]byte(`{"sort":null}`), &query); err != nil || query.Sort != nil {
+		t.Errorf("null = %v, %v", query.Sort, err)
+	}
+	if got := query.Sort.Or(SortSpec{{Field: "id", Desc: true}}); got.String() != "-id" {
+		t.Errorf("Or() = %v", got)
+	}
+}
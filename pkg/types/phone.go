@@ -0,0 +1,344 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Phone 手机号类型，统一存储为 E.164 格式（如 +8613800138000）
+//
+// 设计说明：
+// - 基于 string，零值表示未填写
+// - 解析时支持常见的中国手机号写法：13800138000、138-0013-8000、+86 138 0013 8000、0086 13800138000 等
+// - 运营商、归属地等元数据通过 IPhoneMetadataProvider 扩展，默认只按号段识别中国大陆运营商
+// - 序列化为 E.164 字符串，反序列化时按中国大陆（CN）解析不带国家码的号码
+//
+// 注意事项：
+// - 只做格式校验，不保证号码真实存在
+// - 非中国大陆号码只校验 E.164 长度（国家码 + 号码共 8-15 位）
+type Phone string
+
+// DefaultPhoneRegion 默认地区
+const DefaultPhoneRegion = "CN"
+
+// regionCallingCodes 地区 -> 国家码
+var regionCallingCodes = map[string]string{
+	"CN": "86",
+	"HK": "852",
+	"MO": "853",
+	"TW": "886",
+	"US": "1",
+	"JP": "81",
+	"KR": "82",
+	"SG": "65",
+	"GB": "44",
+}
+
+// ParsePhone 解析手机号
+// 参数：
+//   - raw：原始号码，允许空格、"-"、括号等分隔符
+//   - region：号码不带国家码时使用的地区（如 CN），为空时使用 DefaultPhoneRegion
+//
+// 返回值：E.164 格式的手机号
+func ParsePhone(raw, region string) (Phone, error) {
+	if region == "" {
+		region = DefaultPhoneRegion
+	}
+	region = strings.ToUpper(region)
+
+	digits, international := normalizePhoneDigits(raw)
+	if digits == "" {
+		return "", fmt.Errorf("phone: empty number")
+	}
+
+	// 不带国家码时补全地区国家码；中国大陆 11 位手机号带 86 前缀但没有 "+" 时也按国际格式处理
+	if !international {
+		code, ok := regionCallingCodes[region]
+		if !ok {
+			return "", fmt.Errorf("phone: unknown region %q", region)
+		}
+		if !(code == "86" && len(digits) == 13 && strings.HasPrefix(digits, "86")) {
+			digits = code + digits
+		}
+	}
+
+	phone := Phone("+" + digits)
+	if err := phone.validate(); err != nil {
+		return "", err
+	}
+	return phone, nil
+}
+
+// MustParsePhone 解析手机号，失败时 panic
+func MustParsePhone(raw, region string) Phone {
+	p, err := ParsePhone(raw, region)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// IsValidPhone 判断号码在指定地区是否为合法手机号
+// 说明：region 为空时接受任意地区的合法号码，否则号码必须属于该地区（供验证规则 phone=CN 使用）
+func IsValidPhone(raw, region string) bool {
+	p, err := ParsePhone(raw, region)
+	if err != nil {
+		return false
+	}
+	return region == "" || p.Region() == strings.ToUpper(region)
+}
+
+// normalizePhoneDigits 去掉分隔符，识别国际前缀（+ 或 00）
+func normalizePhoneDigits(raw string) (digits string, international bool) {
+	var b strings.Builder
+	b.Grow(len(raw))
+	for _, c := range strings.TrimSpace(raw) {
+		switch {
+		case c >= '0' && c <= '9':
+			b.WriteRune(c)
+		case c == '+' && b.Len() == 0:
+			international = true
+		case c == ' ' || c == '-' || c == '(' || c == ')' || c == '.':
+		default:
+			// 非法字符：返回空串由调用方报错
+			return "", false
+		}
+	}
+
+	digits = b.String()
+	if !international && strings.HasPrefix(digits, "00") {
+		digits, international = digits[2:], true
+	}
+	return digits, international
+}
+
+// validate 校验 E.164 格式
+func (p Phone) validate() error {
+	s := string(p)
+	if len(s) < 2 || s[0] != '+' {
+		return fmt.Errorf("phone: %q is not in E.164 format", s)
+	}
+	digits := s[1:]
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return fmt.Errorf("phone: invalid length of %q", s)
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return fmt.Errorf("phone: invalid character in %q", s)
+		}
+	}
+
+	// 中国大陆手机号：11 位，1[3-9] 开头
+	if national, ok := strings.CutPrefix(digits, "86"); ok {
+		if len(national) != 11 || national[0] != '1' || national[1] < '3' {
+			return fmt.Errorf("phone: %q is not a valid CN mobile number", s)
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// 查询
+// ============================================================================
+
+// E164 E.164 格式（如 +8613800138000）
+func (p Phone) E164() string {
+	return string(p)
+}
+
+// String 实现 Stringer 接口
+func (p Phone) String() string {
+	return string(p)
+}
+
+// IsZero 是否未填写
+func (p Phone) IsZero() bool {
+	return p == ""
+}
+
+// CallingCode 国家码（如 86）
+func (p Phone) CallingCode() string {
+	digits := strings.TrimPrefix(string(p), "+")
+	// 国家码为 1-3 位，优先匹配已知国家码中最长的
+	best := ""
+	for _, code := range regionCallingCodes {
+		if strings.HasPrefix(digits, code) && len(code) > len(best) {
+			best = code
+		}
+	}
+	return best
+}
+
+// National 国内号码（不含国家码，如 13800138000）
+func (p Phone) National() string {
+	return strings.TrimPrefix(strings.TrimPrefix(string(p), "+"), p.CallingCode())
+}
+
+// Region 地区代码（如 CN），无法识别时为空
+func (p Phone) Region() string {
+	code := p.CallingCode()
+	for region, c := range regionCallingCodes {
+		if c == code {
+			return region
+		}
+	}
+	return ""
+}
+
+// Masked 脱敏后的号码（如 138****8000），用于日志和展示
+func (p Phone) Masked() string {
+	national := p.National()
+	if len(national) < 7 {
+		return strings.Repeat("*", len(national))
+	}
+	return national[:3] + strings.Repeat("*", len(national)-7) + national[len(national)-4:]
+}
+
+// ============================================================================
+// 元数据扩展
+// ============================================================================
+
+// PhoneMetadata 手机号元数据
+type PhoneMetadata struct {
+	Region   string `json:"region"`             // 地区代码（如 CN）
+	Carrier  string `json:"carrier,omitempty"`  // 运营商
+	Province string `json:"province,omitempty"` // 省份
+	City     string `json:"city,omitempty"`     // 城市
+}
+
+// IPhoneMetadataProvider 手机号元数据提供者
+// 说明：可接入号段库或第三方服务，补充运营商、归属地等信息
+type IPhoneMetadataProvider interface {
+	// Lookup 查询手机号元数据
+	Lookup(p Phone) (PhoneMetadata, bool)
+}
+
+var (
+	phoneMetadataMu       sync.RWMutex
+	phoneMetadataProvider IPhoneMetadataProvider = cnCarrierProvider{}
+)
+
+// SetPhoneMetadataProvider 设置手机号元数据提供者
+// 说明：传入 nil 时恢复默认的号段识别
+func SetPhoneMetadataProvider(provider IPhoneMetadataProvider) {
+	if provider == nil {
+		provider = cnCarrierProvider{}
+	}
+	phoneMetadataMu.Lock()
+	defer phoneMetadataMu.Unlock()
+	phoneMetadataProvider = provider
+}
+
+// Metadata 查询手机号元数据
+func (p Phone) Metadata() (PhoneMetadata, bool) {
+	phoneMetadataMu.RLock()
+	provider := phoneMetadataProvider
+	phoneMetadataMu.RUnlock()
+	return provider.Lookup(p)
+}
+
+// cnCarrierProvider 按号段识别中国大陆运营商
+type cnCarrierProvider struct{}
+
+// 中国大陆号段 -> 运营商
+var cnCarrierPrefixes = map[string]string{
+	"134": "China Mobile", "135": "China Mobile", "136": "China Mobile", "137": "China Mobile",
+	"138": "China Mobile", "139": "China Mobile", "147": "China Mobile", "150": "China Mobile",
+	"151": "China Mobile", "152": "China Mobile", "157": "China Mobile", "158": "China Mobile",
+	"159": "China Mobile", "172": "China Mobile", "178": "China Mobile", "182": "China Mobile",
+	"183": "China Mobile", "184": "China Mobile", "187": "China Mobile", "188": "China Mobile",
+	"195": "China Mobile", "197": "China Mobile", "198": "China Mobile",
+	"130": "China Unicom", "131": "China Unicom", "132": "China Unicom", "145": "China Unicom",
+	"155": "China Unicom", "156": "China Unicom", "166": "China Unicom", "175": "China Unicom",
+	"176": "China Unicom", "185": "China Unicom", "186": "China Unicom", "196": "China Unicom",
+	"133": "China Telecom", "149": "China Telecom", "153": "China Telecom", "173": "China Telecom",
+	"177": "China Telecom", "180": "China Telecom", "181": "China Telecom", "189": "China Telecom",
+	"190": "China Telecom", "191": "China Telecom", "193": "China Telecom", "199": "China Telecom",
+	"192": "China Broadnet",
+	"170": "Virtual", "171": "Virtual", "162": "Virtual", "165": "Virtual", "167": "Virtual",
+}
+
+// Lookup 实现 IPhoneMetadataProvider 接口
+func (cnCarrierProvider) Lookup(p Phone) (PhoneMetadata, bool) {
+	region := p.Region()
+	if region == "" {
+		return PhoneMetadata{}, false
+	}
+
+	meta := PhoneMetadata{Region: region}
+	if region == "CN" {
+		if national := p.National(); len(national) >= 3 {
+			meta.Carrier = cnCarrierPrefixes[national[:3]]
+		}
+	}
+	return meta, true
+}
+
+// ============================================================================
+// JSON 接口实现
+// ============================================================================
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (p Phone) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(p))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+// 说明：空字符串和 null 表示未填写；不带国家码的号码按 DefaultPhoneRegion 解析
+func (p *Phone) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*p = ""
+		return nil
+	}
+
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to unmarshal Phone from JSON: %w", err)
+	}
+	return p.set(raw)
+}
+
+// set 解析并设置号码
+func (p *Phone) set(raw string) error {
+	if strings.TrimSpace(raw) == "" {
+		*p = ""
+		return nil
+	}
+	parsed, err := ParsePhone(raw, DefaultPhoneRegion)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// ============================================================================
+// 数据库接口实现
+// ============================================================================
+
+// Value 实现 driver.Valuer 接口
+// 说明：未填写时写入 NULL
+func (p Phone) Value() (driver.Value, error) {
+	if p.IsZero() {
+		return nil, nil
+	}
+	return string(p), nil
+}
+
+// Scan 实现 sql.Scanner 接口
+func (p *Phone) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*p = ""
+		return nil
+	case []byte:
+		return p.set(string(v))
+	case string:
+		return p.set(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into Phone", value)
+	}
+}
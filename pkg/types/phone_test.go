@@ -0,0 +1,113 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// ============================================================================
+// Phone 测试
+// ============================================================================
+
+// TestParsePhone 测试解析常见写法
+func TestParsePhone(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		region  string
+		want    Phone
+		wantErr bool
+	}{
+		{"纯数字", "13800138000", "CN", "+8613800138000", false},
+		{"横线分隔", "138-0013-8000", "", "+8613800138000", false},
+		{"空格和国家码", "+86 138 0013 8000", "CN", "+8613800138000", false},
+		{"00 国际前缀", "0086 13800138000", "CN", "+8613800138000", false},
+		{"括号国家码", "(+86)13800138000", "CN", "+8613800138000", false},
+		{"不带加号的国家码", "8613800138000", "CN", "+8613800138000", false},
+		{"香港号码", "+852 6123 4567", "CN", "+85261234567", false},
+		{"美国号码", "(415) 555-2671", "US", "+14155552671", false},
+		{"位数不足", "123", "CN", "", true},
+		{"非手机号段", "12800138000", "CN", "", true},
+		{"非法字符", "138abc38000", "CN", "", true},
+		{"未知地区", "13800138000", "XX", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePhone(tt.raw, tt.region)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePhone() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePhone() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPhone_Accessors 测试查询方法
+func TestPhone_Accessors(t *testing.T) {
+	p := MustParsePhone("13800138000", "CN")
+	if p.CallingCode() != "86" || p.National() != "13800138000" || p.Region() != "CN" {
+		t.Errorf("accessors = (%s, %s, %s)", p.CallingCode(), p.National(), p.Region())
+	}
+	if got := p.Masked(); got != "138****8000" {
+		t.Errorf("Masked() = %s, want 138****8000", got)
+	}
+
+	meta, ok := p.Metadata()
+	if !ok || meta.Carrier != "China Mobile" {
+		t.Errorf("Metadata() = (%+v, %v), want China Mobile", meta, ok)
+	}
+
+	if !IsValidPhone("13800138000", "CN") || IsValidPhone("+85261234567", "CN") || !IsValidPhone("+85261234567", "") {
+		t.Error("IsValidPhone() region check failed")
+	}
+}
+
+// TestPhone_MetadataProvider 测试自定义元数据提供者
+func TestPhone_MetadataProvider(t *testing.T) {
+	SetPhoneMetadataProvider(phoneMetadataFunc(func(p Phone) (PhoneMetadata, bool) {
+		return PhoneMetadata{Region: p.Region(), Province: "广东"}, true
+	}))
+	defer SetPhoneMetadataProvider(nil)
+
+	meta, _ := MustParsePhone("13800138000", "CN").Metadata()
+	if meta.Province != "广东" {
+		t.Errorf("Metadata() = %+v, want province 广东", meta)
+	}
+}
+
+type phoneMetadataFunc func(p Phone) (PhoneMetadata, bool)
+
+func (f phoneMetadataFunc) Lookup(p Phone) (PhoneMetadata, bool) {
+	return f(p)
+}
+
+// TestPhone_JSONAndSQL 测试序列化
+func TestPhone_JSONAndSQL(t *testing.T) {
+	var holder struct {
+		Phone Phone `json:"phone"`
+	}
+	if err := json.Unmarshal([]byte(`{"phone":"138 0013 8000"}`), &holder); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if holder.Phone != "+8613800138000" {
+		t.Errorf("Unmarshal() = %s", holder.Phone)
+	}
+	data, _ := json.Marshal(holder)
+	if string(data) != `{"phone":"+8613800138000"}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+	if err := json.Unmarshal([]byte(`{"phone":"123"}`), &holder); err == nil {
+		t.Error("Unmarshal() with invalid phone should fail")
+	}
+
+	var scanned Phone
+	if err := scanned.Scan([]byte("13800138000")); err != nil || scanned != "+8613800138000" {
+		t.Errorf("Scan() = (%s, %v)", scanned, err)
+	}
+	if v, err := Phone("").Value(); err != nil || v != nil {
+		t.Errorf("Value() of empty = (%v, %v), want nil", v, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"unsafe"
 )
 
@@ -438,11 +439,34 @@ func (s *Status) Scan(value interface{}) error {
 			return fmt.Errorf("failed to unmarshal Status from bytes: %w", err)
 		}
 		return s.setFromInt64(num)
+	case string:
+		// VARCHAR / DECIMAL 等列返回的十进制字符串，如 "63"
+		return s.setFromDecimal(v)
 	default:
 		return fmt.Errorf("cannot scan type %T into Status", value)
 	}
 }
 
+// setFromDecimal 从十进制字符串设置状态（允许首尾空白）
+func (s *Status) setFromDecimal(v string) error {
+	v = strings.TrimSpace(v)
+	if strings.HasPrefix(v, "-") {
+		return fmt.Errorf("invalid Status value: negative number %s is not allowed", v)
+	}
+	num, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return fmt.Errorf("invalid Status value: %s exceeds maximum allowed value %d", v, MaxStatus)
+		}
+		return fmt.Errorf("invalid Status value: %q is not a decimal number", v)
+	}
+	if num > uint64(MaxStatus) {
+		return fmt.Errorf("invalid Status value: %d exceeds maximum allowed value %d", num, MaxStatus)
+	}
+	*s = Status(num)
+	return nil
+}
+
 //go:inline
 func (s *Status) setFromInt64(v int64) error {
 	if v < 0 {
@@ -483,3 +507,69 @@ func (s *Status) UnmarshalJSON(data []byte) error {
 
 	return s.setFromInt64(num)
 }
+
+// ============================================================================
+// 文本接口实现
+// ============================================================================
+
+// statusNames 预定义状态位和组合的名称（UnmarshalText 的名称格式，不区分大小写，忽略 "_" 和 "-"）
+var statusNames = map[string]Status{
+	"none":         StatusNone,
+	"sysdeleted":   StatusSysDeleted,
+	"admdeleted":   StatusAdmDeleted,
+	"userdeleted":  StatusUserDeleted,
+	"sysdisabled":  StatusSysDisabled,
+	"admdisabled":  StatusAdmDisabled,
+	"userdisabled": StatusUserDisabled,
+	"syshidden":    StatusSysHidden,
+	"admhidden":    StatusAdmHidden,
+	"userhidden":   StatusUserHidden,
+	"sysreview":    StatusSysReview,
+	"admreview":    StatusAdmReview,
+	"userreview":   StatusUserReview,
+	"alldeleted":   StatusAllDeleted,
+	"alldisabled":  StatusAllDisabled,
+	"allhidden":    StatusAllHidden,
+	"allreview":    StatusAllReview,
+}
+
+// MarshalText 实现 encoding.TextMarshaler 接口，输出十进制数字（与 JSON 一致）
+// 说明：作为 map 键时 encoding/json 优先使用 MarshalText，输出的键与之前的十进制键相同
+func (s Status) MarshalText() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(s), 10), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler 接口
+// 支持的格式（允许首尾空白）：
+// - 十进制数字：如 "3"
+// - 名称组合：预定义状态位或组合的名称，以 "|" 或 "," 分隔，如 "sys_deleted|adm_hidden"、"AllDeleted"
+// - String() 的输出：如 "Status(3)[2 bits]"
+func (s *Status) UnmarshalText(text []byte) error {
+	v := strings.TrimSpace(string(text))
+	if len(v) == 0 {
+		*s = StatusNone
+		return nil
+	}
+
+	if rest, ok := strings.CutPrefix(v, "Status("); ok {
+		if end := strings.IndexByte(rest, ')'); end > 0 {
+			return s.setFromDecimal(rest[:end])
+		}
+		return fmt.Errorf("invalid Status value: %q", v)
+	}
+	if v[0] >= '0' && v[0] <= '9' || v[0] == '-' {
+		return s.setFromDecimal(v)
+	}
+
+	var result Status
+	for _, name := range strings.FieldsFunc(v, func(r rune) bool { return r == '|' || r == ',' }) {
+		key := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+		flag, ok := statusNames[key]
+		if !ok {
+			return fmt.Errorf("invalid Status value: unknown status name %q", strings.TrimSpace(name))
+		}
+		result |= flag
+	}
+	*s = result
+	return nil
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// ============================================================================
+// 位类型列
+// ============================================================================
+//
+// Status 默认存储为 BIGINT，也可以直接扫描 VARCHAR / DECIMAL 列返回的十进制字符串。
+// 旧表结构中使用位类型列时，驱动返回的 "0101" 文本或原始字节与十进制数据无法区分，
+// 需要使用下面的列类型显式指定格式。两者都嵌入 Status，状态方法和 JSON 序列化不变。
+
+// PgBitStatus 存储在 PostgreSQL bit(63) / bit varying 列中的状态
+//
+// 示例：
+//
+//	type User struct {
+//	    ID     int64
+//	    Status types.PgBitStatus `gorm:"type:bit(63)"`
+//	}
+//
+//	user.Status.Add(types.StatusAdmDisabled)
+//	user.Status.IsDisable()
+type PgBitStatus struct {
+	Status
+}
+
+// pgBitWidth 写入时的位串宽度（与 bit(63) 列一致）
+const pgBitWidth = maxValidBit + 1
+
+// Value 实现 driver.Valuer 接口，输出 63 位定长位串（高位在前）
+func (p PgBitStatus) Value() (driver.Value, error) {
+	if _, err := p.Status.Value(); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, pgBitWidth)
+	for i := range buf {
+		if p.Status&(1<<(pgBitWidth-1-i)) != 0 {
+			buf[i] = '1'
+		} else {
+			buf[i] = '0'
+		}
+	}
+	return string(buf), nil
+}
+
+// Scan 实现 sql.Scanner 接口，解析 "0101" 格式的位串（高位在前，任意长度）
+// 说明：超过 63 位的高位必须为 0，否则返回溢出错误
+func (p *PgBitStatus) Scan(value interface{}) error {
+	var bits string
+	switch v := value.(type) {
+	case nil:
+		p.Status = StatusNone
+		return nil
+	case string:
+		bits = v
+	case []byte:
+		bits = string(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into PgBitStatus", value)
+	}
+
+	bits = strings.TrimSpace(bits)
+	var result Status
+	for i := 0; i < len(bits); i++ {
+		bit := bits[i]
+		if bit != '0' && bit != '1' {
+			return fmt.Errorf("invalid PgBitStatus value: %q is not a bit string", bits)
+		}
+		if result > MaxStatus>>1 {
+			return fmt.Errorf("invalid Status value: bit string %q exceeds maximum allowed value %d", bits, MaxStatus)
+		}
+		result = result<<1 | Status(bit-'0')
+	}
+	p.Status = result
+	return nil
+}
+
+// MySQLBitStatus 存储在 MySQL BIT(63) / BIT(64) 列中的状态
+//
+// 说明：驱动以大端序原始字节返回 BIT 列（BIT(64) 为 8 字节），写入时使用整数，MySQL 自动转换
+type MySQLBitStatus struct {
+	Status
+}
+
+// Value 实现 driver.Valuer 接口，以整数写入
+func (m MySQLBitStatus) Value() (driver.Value, error) {
+	return m.Status.Value()
+}
+
+// Scan 实现 sql.Scanner 接口，解析大端序原始字节
+// 说明：超过 8 字节时多出的高位字节必须为 0，数值超过 MaxStatus 时返回溢出错误；
+// 同时接受整数（部分驱动或查询中使用 col+0 时返回整数）
+func (m *MySQLBitStatus) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		m.Status = StatusNone
+		return nil
+	case []byte:
+		var num uint64
+		for i, b := range v {
+			if len(v)-i > 8 {
+				if b != 0 {
+					return fmt.Errorf("invalid Status value: %d-byte BIT value overflows 64 bits", len(v))
+				}
+				continue
+			}
+			num = num<<8 | uint64(b)
+		}
+		if num > uint64(MaxStatus) {
+			return fmt.Errorf("invalid Status value: %d exceeds maximum allowed value %d", num, MaxStatus)
+		}
+		m.Status = Status(num)
+		return nil
+	case string:
+		return m.Scan([]byte(v))
+	default:
+		return m.Status.Scan(value)
+	}
+}
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// 位类型列测试
+// ============================================================================
+
+// TestPgBitStatus 测试 PostgreSQL 位串列
+func TestPgBitStatus(t *testing.T) {
+	value, err := PgBitStatus{Status: StatusAllDeleted | StatusAdmHidden}.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+	want := strings.Repeat("0", 55) + "10000111"
+	if value != want {
+		t.Errorf("Value() = %v, want %v", value, want)
+	}
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    Status
+		wantErr bool
+	}{
+		{"nil值", nil, StatusNone, false},
+		{"定长位串", want, StatusAllDeleted | StatusAdmHidden, false},
+		{"变长位串", "111", StatusAllDeleted, false},
+		{"字节位串", []byte("1000"), StatusSysDisabled, false},
+		{"64位高位为0", "0" + strings.Repeat("1", 63), MaxStatus, false},
+		{"64位高位为1", "1" + strings.Repeat("0", 63), StatusNone, true},
+		{"非位串", "102", StatusNone, true},
+		{"不支持的类型", int64(1), StatusNone, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p PgBitStatus
+			err := p.Scan(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && p.Status != tt.want {
+				t.Errorf("Scan() = %v, want %v", p.Status, tt.want)
+			}
+		})
+	}
+
+	if _, err := (PgBitStatus{Status: Status(-1)}).Value(); err == nil {
+		t.Error("Value() 负数应返回错误")
+	}
+}
+
+// TestMySQLBitStatus 测试 MySQL BIT 列
+func TestMySQLBitStatus(t *testing.T) {
+	value, err := MySQLBitStatus{Status: StatusAllDeleted}.Value()
+	if err != nil || value != int64(7) {
+		t.Errorf("Value() = %v, %v", value, err)
+	}
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    Status
+		wantErr bool
+	}{
+		{"nil值", nil, StatusNone, false},
+		{"BIT(64)", []byte{0, 0, 0, 0, 0, 0, 0x01, 0x07}, Status(0x0107), false},
+		{"BIT(16)", []byte{0x01, 0x07}, Status(0x0107), false},
+		{"最大值", []byte{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, MaxStatus, false},
+		{"最高位为1", []byte{0x80, 0, 0, 0, 0, 0, 0, 0}, StatusNone, true},
+		{"超过8字节高位为0", []byte{0, 0, 0, 0, 0, 0, 0, 0, 0x07}, StatusAllDeleted, false},
+		{"超过8字节溢出", []byte{0x01, 0, 0, 0, 0, 0, 0, 0, 0}, StatusNone, true},
+		{"整数", int64(7), StatusAllDeleted, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m MySQLBitStatus
+			err := m.Scan(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && m.Status != tt.want {
+				t.Errorf("Scan() = %v, want %v", m.Status, tt.want)
+			}
+		})
+	}
+
+	// 嵌入的 Status 方法可直接使用
+	m := MySQLBitStatus{}
+	m.Add(StatusAdmDisabled)
+	if !m.IsDisable() {
+		t.Error("嵌入的 Status 方法应可直接使用")
+	}
+}
@@ -677,6 +677,12 @@ func TestStatus_Scan(t *testing.T) {
 		{"负数", int64(-1), StatusNone, true},
 		{"不支持的类型", "invalid", StatusNone, true},
 		{"无效JSON", []byte("invalid"), StatusNone, true},
+		{"十进制字符串", "63", Status(63), false},
+		{"带空白的十进制字符串", " 7 ", StatusAllDeleted, false},
+		{"最大值字符串", "9223372036854775807", MaxStatus, false},
+		{"负数字符串", "-1", StatusNone, true},
+		{"溢出字符串", "9223372036854775808", StatusNone, true},
+		{"超出uint64字符串", "99999999999999999999", StatusNone, true},
 	}
 
 	for _, tt := range tests {
@@ -791,6 +797,63 @@ func TestStatus_JSON_RoundTrip(t *testing.T) {
 	}
 }
 
+// TestStatus_Text 测试文本序列化（数字、名称组合和 String() 格式）
+func TestStatus_Text(t *testing.T) {
+	if got, _ := (StatusAllDeleted | StatusAdmHidden).MarshalText(); string(got) != "135" {
+		t.Errorf("MarshalText() = %s, want 135", got)
+	}
+
+	tests := []struct {
+		name    string
+		text    string
+		want    Status
+		wantErr bool
+	}{
+		{"空文本", "", StatusNone, false},
+		{"数字", " 7 ", StatusAllDeleted, false},
+		{"名称", "sys_deleted|AdmHidden", StatusSysDeleted | StatusAdmHidden, false},
+		{"组合名称", "all-deleted, user_review", StatusAllDeleted | StatusUserReview, false},
+		{"String格式", (StatusAllHidden).String(), StatusAllHidden, false},
+		{"未知名称", "sys_deleted|archived", StatusNone, true},
+		{"负数", "-1", StatusNone, true},
+		{"String格式缺少括号", "Status(7", StatusNone, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Status
+			err := s.UnmarshalText([]byte(tt.text))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalText(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && s != tt.want {
+				t.Errorf("UnmarshalText(%q) = %v, want %v", tt.text, s, tt.want)
+			}
+		})
+	}
+}
+
+// TestStatus_TextMapKey 测试作为 map 键时 JSON 输出与十进制键一致
+func TestStatus_TextMapKey(t *testing.T) {
+	counts := map[Status]int{StatusSysDeleted: 2, StatusAllHidden: 5}
+	data, err := json.Marshal(counts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"1":2,"448":5}` {
+		t.Errorf("Marshal = %s", data)
+	}
+
+	var decoded map[Status]int
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded[StatusAllHidden] != 5 || decoded[StatusSysDeleted] != 2 {
+		t.Errorf("Unmarshal = %v", decoded)
+	}
+}
+
 // ============================================================================
 // 边界条件测试
 // ============================================================================
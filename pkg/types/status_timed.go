@@ -0,0 +1,264 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TimedStatus 带过期时间的状态
+//
+// 设计说明：
+// - 在 Status 的基础上为状态位记录过期时间，用于"禁用到 2025-01-01"之类的临时状态
+// - 没有过期时间的状态位永久有效，与 Status 的语义一致
+// - 查询方法以 now 为准，读取时自动清除已过期（过期时间 <= now）的状态位
+//
+// 注意事项：
+// - 查询方法会修改实例（清除过期状态位），需要指针接收者
+// - 序列化输出当前保存的数据，不会清除过期状态位；需要时先调用 Prune
+// - 非线程安全，多协程并发读写需要外部加锁
+type TimedStatus struct {
+	flags   Status
+	expires map[Status]time.Time // 单个状态位 -> 过期时间
+}
+
+// NewTimedStatus 基于永久状态创建带过期时间的状态
+func NewTimedStatus(flags Status) TimedStatus {
+	return TimedStatus{flags: flags}
+}
+
+// ============================================================================
+// 状态修改方法
+// ============================================================================
+
+// Add 添加永久状态位（会移除这些状态位原有的过期时间）
+func (t *TimedStatus) Add(flag Status) {
+	t.flags |= flag
+	t.forEachExpiry(flag, func(bit Status) {
+		delete(t.expires, bit)
+	})
+}
+
+// AddUntil 添加状态位，until 之后自动失效
+// 说明：until 为零值时等同于 Add；已存在的状态位以本次设置的过期时间为准
+func (t *TimedStatus) AddUntil(flag Status, until time.Time) {
+	if until.IsZero() {
+		t.Add(flag)
+		return
+	}
+	t.flags |= flag
+	if t.expires == nil {
+		t.expires = make(map[Status]time.Time)
+	}
+	for _, bit := range flag.ActiveFlags() {
+		t.expires[bit] = until
+	}
+}
+
+// AddFor 添加状态位，从 now 起 d 之后自动失效
+func (t *TimedStatus) AddFor(flag Status, d time.Duration, now time.Time) {
+	t.AddUntil(flag, now.Add(d))
+}
+
+// Del 删除状态位及其过期时间
+func (t *TimedStatus) Del(flag Status) {
+	t.flags &^= flag
+	t.forEachExpiry(flag, func(bit Status) {
+		delete(t.expires, bit)
+	})
+}
+
+// Clear 清除所有状态位
+func (t *TimedStatus) Clear() {
+	t.flags = StatusNone
+	t.expires = nil
+}
+
+// Prune 清除在 now 时已过期的状态位，返回被清除的状态位
+func (t *TimedStatus) Prune(now time.Time) Status {
+	var expired Status
+	for bit, until := range t.expires {
+		if !now.Before(until) {
+			expired |= bit
+			delete(t.expires, bit)
+		}
+	}
+	t.flags &^= expired
+	return expired
+}
+
+// forEachExpiry 遍历 flag 中设置了过期时间的状态位
+func (t *TimedStatus) forEachExpiry(flag Status, fn func(bit Status)) {
+	if len(t.expires) == 0 {
+		return
+	}
+	for bit := range t.expires {
+		if flag&bit != 0 {
+			fn(bit)
+		}
+	}
+}
+
+// ============================================================================
+// 状态查询方法
+// ============================================================================
+
+// Status 返回在 now 时有效的状态（先清除过期状态位）
+func (t *TimedStatus) Status(now time.Time) Status {
+	t.Prune(now)
+	return t.flags
+}
+
+// ExpiresAt 返回状态位的过期时间；状态位未设置或永久有效时返回 false
+func (t *TimedStatus) ExpiresAt(flag Status) (time.Time, bool) {
+	until, ok := t.expires[flag]
+	return until, ok
+}
+
+// Has 检查在 now 时是否包含指定状态位（所有位都需要设置）
+func (t *TimedStatus) Has(flag Status, now time.Time) bool {
+	return t.Status(now).Has(flag)
+}
+
+// IsDeleted 检查在 now 时是否被标记为删除（任意级别）
+func (t *TimedStatus) IsDeleted(now time.Time) bool {
+	return t.Status(now).IsDeleted()
+}
+
+// IsDisable 检查在 now 时是否被禁用（任意级别）
+func (t *TimedStatus) IsDisable(now time.Time) bool {
+	return t.Status(now).IsDisable()
+}
+
+// IsHidden 检查在 now 时是否被隐藏（任意级别）
+func (t *TimedStatus) IsHidden(now time.Time) bool {
+	return t.Status(now).IsHidden()
+}
+
+// IsReview 检查在 now 时是否在审核中（任意级别）
+func (t *TimedStatus) IsReview(now time.Time) bool {
+	return t.Status(now).IsReview()
+}
+
+// CanEnable 检查在 now 时是否为可启用状态（未删除且未禁用）
+func (t *TimedStatus) CanEnable(now time.Time) bool {
+	return t.Status(now).CanEnable()
+}
+
+// CanVisible 检查在 now 时是否为可见状态（未删除、未禁用且未隐藏）
+func (t *TimedStatus) CanVisible(now time.Time) bool {
+	return t.Status(now).CanVisible()
+}
+
+// CanActive 检查在 now 时是否为完全激活状态（未删除、未禁用、未隐藏且已通过审核）
+func (t *TimedStatus) CanActive(now time.Time) bool {
+	return t.Status(now).CanActive()
+}
+
+// ============================================================================
+// JSON 接口实现
+// ============================================================================
+
+// timedStatusJSON JSON 结构：{"status": 24, "expires": {"16": "2025-01-01T00:00:00Z"}}
+type timedStatusJSON struct {
+	Status  Status               `json:"status"`
+	Expires map[string]time.Time `json:"expires,omitempty"` // 状态位（十进制）-> 过期时间
+}
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (t TimedStatus) MarshalJSON() ([]byte, error) {
+	data := timedStatusJSON{Status: t.flags}
+	if len(t.expires) > 0 {
+		data.Expires = make(map[string]time.Time, len(t.expires))
+		for bit, until := range t.expires {
+			data.Expires[strconv.FormatInt(int64(bit), 10)] = until
+		}
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+// 说明：同时兼容纯数字格式（普通 Status），便于从 Status 列迁移
+func (t *TimedStatus) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("empty JSON data")
+	}
+	if string(data) == "null" {
+		t.Clear()
+		return nil
+	}
+	if data[0] != '{' {
+		var flags Status
+		if err := flags.UnmarshalJSON(data); err != nil {
+			return fmt.Errorf("failed to unmarshal TimedStatus from JSON: %w", err)
+		}
+		*t = NewTimedStatus(flags)
+		return nil
+	}
+
+	var raw timedStatusJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to unmarshal TimedStatus from JSON: %w", err)
+	}
+
+	result := NewTimedStatus(raw.Status)
+	for key, until := range raw.Expires {
+		bit, err := strconv.ParseInt(key, 10, 64)
+		if err != nil || bit <= 0 || bit > int64(MaxStatus) || bit&(bit-1) != 0 {
+			return fmt.Errorf("invalid TimedStatus expiry key %q: must be a single status bit", key)
+		}
+		if raw.Status&Status(bit) == 0 {
+			continue
+		}
+		result.AddUntil(Status(bit), until)
+	}
+	*t = result
+	return nil
+}
+
+// ============================================================================
+// 数据库接口实现
+// ============================================================================
+
+// Value 实现 driver.Valuer 接口，以 JSON 存储
+func (t TimedStatus) Value() (driver.Value, error) {
+	if _, err := t.flags.Value(); err != nil {
+		return nil, err
+	}
+	data, err := t.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal TimedStatus to JSON for database storage: %w", err)
+	}
+	return data, nil
+}
+
+// Scan 实现 sql.Scanner 接口
+// 说明：支持 JSON（[]byte / string）和整数（从普通 Status 列迁移）
+func (t *TimedStatus) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		t.Clear()
+		return nil
+	case []byte:
+		if len(v) == 0 {
+			t.Clear()
+			return nil
+		}
+		return t.UnmarshalJSON(v)
+	case string:
+		if len(v) == 0 {
+			t.Clear()
+			return nil
+		}
+		return t.UnmarshalJSON([]byte(v))
+	default:
+		var flags Status
+		if err := flags.Scan(value); err != nil {
+			return fmt.Errorf("cannot scan type %T into TimedStatus: %w", value, err)
+		}
+		*t = NewTimedStatus(flags)
+		return nil
+	}
+}
@@ -0,0 +1,133 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// 带过期时间的状态测试
+// ============================================================================
+
+// TestTimedStatusExpiry 测试过期自动清除
+func TestTimedStatusExpiry(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	ts := NewTimedStatus(StatusUserHidden)
+	ts.AddUntil(StatusAdmDisabled, until)
+	ts.AddFor(StatusSysReview, time.Hour, now)
+
+	if !ts.IsDisable(now) || ts.CanEnable(now) || !ts.IsReview(now) {
+		t.Errorf("期限内应处于禁用和审核状态: %v", ts.Status(now))
+	}
+	if got, ok := ts.ExpiresAt(StatusAdmDisabled); !ok || !got.Equal(until) {
+		t.Errorf("ExpiresAt() = %v, %v", got, ok)
+	}
+	if _, ok := ts.ExpiresAt(StatusUserHidden); ok {
+		t.Error("永久状态位不应有过期时间")
+	}
+
+	// 审核状态过期，禁用仍有效
+	later := now.Add(2 * time.Hour)
+	if ts.IsReview(later) || !ts.IsDisable(later) {
+		t.Errorf("%v 时状态 = %v", later, ts.Status(later))
+	}
+
+	// 到达过期时间即失效
+	if ts.IsDisable(until) || !ts.CanEnable(until) || !ts.IsHidden(until) {
+		t.Errorf("%v 时状态 = %v", until, ts.Status(until))
+	}
+	if _, ok := ts.ExpiresAt(StatusAdmDisabled); ok {
+		t.Error("过期状态位应被清除")
+	}
+	if ts.Status(until) != StatusUserHidden {
+		t.Errorf("Status() = %v, 期望 %v", ts.Status(until), StatusUserHidden)
+	}
+}
+
+// TestTimedStatusModify 测试修改方法
+func TestTimedStatusModify(t *testing.T) {
+	now := time.Now()
+	var ts TimedStatus
+
+	ts.AddUntil(StatusAdmDisabled|StatusAdmHidden, now.Add(time.Hour))
+	ts.Add(StatusAdmHidden) // 转为永久
+	if _, ok := ts.ExpiresAt(StatusAdmHidden); ok {
+		t.Error("Add 应移除过期时间")
+	}
+	if _, ok := ts.ExpiresAt(StatusAdmDisabled); !ok {
+		t.Error("其他状态位的过期时间应保留")
+	}
+
+	ts.Del(StatusAdmDisabled)
+	if ts.Has(StatusAdmDisabled, now) {
+		t.Error("Del 后不应包含状态位")
+	}
+	if ts.Prune(now.Add(2*time.Hour)) != StatusNone || !ts.Has(StatusAdmHidden, now.Add(2*time.Hour)) {
+		t.Error("Del 应同时移除过期时间，永久状态位不受影响")
+	}
+
+	ts.AddUntil(StatusSysDeleted, time.Time{}) // 零值等同于永久
+	if _, ok := ts.ExpiresAt(StatusSysDeleted); ok || !ts.IsDeleted(now) {
+		t.Error("零值过期时间应等同于 Add")
+	}
+
+	ts.Clear()
+	if ts.Status(now) != StatusNone {
+		t.Error("Clear 后应为空")
+	}
+}
+
+// TestTimedStatusSerialization 测试 JSON 和数据库序列化
+func TestTimedStatusSerialization(t *testing.T) {
+	until := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := NewTimedStatus(StatusUserHidden)
+	ts.AddUntil(StatusAdmDisabled, until)
+
+	data, err := json.Marshal(ts)
+	if err != nil {
+		t.Fatalf("Marshal 失败: %v", err)
+	}
+	if want := `{"status":272,"expires":{"16":"2025-01-01T00:00:00Z"}}`; string(data) != want {
+		t.Errorf("Marshal() = %s, 期望 %s", data, want)
+	}
+
+	var decoded TimedStatus
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal 失败: %v", err)
+	}
+	if got, ok := decoded.ExpiresAt(StatusAdmDisabled); !ok || !got.Equal(until) || !decoded.IsHidden(until) {
+		t.Errorf("Unmarshal 结果 = %+v", decoded)
+	}
+
+	value, err := ts.Value()
+	if err != nil {
+		t.Fatalf("Value 失败: %v", err)
+	}
+	var scanned TimedStatus
+	if err := scanned.Scan(value); err != nil || !scanned.IsDisable(until.Add(-time.Second)) {
+		t.Errorf("Scan() = %+v, %v", scanned, err)
+	}
+
+	// 兼容普通 Status 的整数和 JSON 数字
+	if err := scanned.Scan(int64(StatusAdmDisabled)); err != nil || !scanned.IsDisable(until) {
+		t.Errorf("Scan(int64) = %+v, %v", scanned, err)
+	}
+	if err := json.Unmarshal([]byte(`8`), &scanned); err != nil || !scanned.Has(StatusSysDisabled, until) {
+		t.Errorf("Unmarshal(8) = %+v, %v", scanned, err)
+	}
+	if err := scanned.Scan(nil); err != nil || scanned.Status(until) != StatusNone {
+		t.Errorf("Scan(nil) = %+v, %v", scanned, err)
+	}
+
+	for _, invalid := range []string{`{"status":16,"expires":{"3":"2025-01-01T00:00:00Z"}}`, `{"status":-1}`, `-1`, `"x"`} {
+		if err := json.Unmarshal([]byte(invalid), &scanned); err == nil {
+			t.Errorf("Unmarshal(%s) 应返回错误", invalid)
+		}
+	}
+	if err := scanned.Scan(1.5); err == nil {
+		t.Error("Scan 不支持的类型应返回错误")
+	}
+}
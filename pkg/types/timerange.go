@@ -0,0 +1,229 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeRange 时间范围，左闭右开区间 [Start, End)
+//
+// 设计说明：
+// - 用于审计、查询、营业时间等需要起止时间的模型，统一 start<end 的校验和区间运算
+// - 左闭右开：相邻区间（前一个的 End 等于后一个的 Start）不重叠
+// - 零值表示未填写
+//
+// 序列化格式：
+// - JSON：{"start":"2024-01-01T00:00:00Z","end":"2024-01-02T00:00:00Z"}（RFC 3339）
+// - 数据库：与 JSON 格式相同的字符串；读取时也支持 PostgreSQL 的 tstzrange 字面量
+//
+// 注意事项：
+// - 反序列化不检查起止顺序，请使用验证规则 timerange
+type TimeRange struct {
+	Start time.Time `json:"start"` // 开始时间（包含）
+	End   time.Time `json:"end"`   // 结束时间（不包含）
+}
+
+// NewTimeRange 创建时间范围
+// 返回值：end 不晚于 start 时返回错误
+func NewTimeRange(start, end time.Time) (TimeRange, error) {
+	r := TimeRange{Start: start, End: end}
+	if !r.IsValid() {
+		return TimeRange{}, fmt.Errorf("timerange: start %s must be before end %s",
+			start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+	return r, nil
+}
+
+// NewDateRange 创建按天的时间范围，包含 startDate 和 endDate 两天
+// 示例：NewDateRange(1月1日, 1月31日) -> [1月1日 00:00, 2月1日 00:00)
+// 说明：使用 startDate 的时区计算当天零点
+func NewDateRange(startDate, endDate time.Time) (TimeRange, error) {
+	loc := startDate.Location()
+	start := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+	endDate = endDate.In(loc)
+	end := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	return NewTimeRange(start, end)
+}
+
+// ============================================================================
+// 查询
+// ============================================================================
+
+// IsZero 是否未填写
+func (r TimeRange) IsZero() bool {
+	return r.Start.IsZero() && r.End.IsZero()
+}
+
+// IsValid 开始时间是否早于结束时间
+func (r TimeRange) IsValid() bool {
+	return r.Start.Before(r.End)
+}
+
+// Duration 时长，无效范围返回 0
+func (r TimeRange) Duration() time.Duration {
+	if !r.IsValid() {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+// Contains 时间点是否在范围内（Start <= t < End）
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// ContainsRange 是否完整包含另一个范围
+func (r TimeRange) ContainsRange(other TimeRange) bool {
+	return other.IsValid() && !other.Start.Before(r.Start) && !other.End.After(r.End)
+}
+
+// Overlaps 是否与另一个范围重叠（相邻不算重叠）
+func (r TimeRange) Overlaps(other TimeRange) bool {
+	return r.IsValid() && other.IsValid() && r.Start.Before(other.End) && other.Start.Before(r.End)
+}
+
+// Intersect 计算交集
+// 返回值：不重叠时 ok 为 false
+func (r TimeRange) Intersect(other TimeRange) (TimeRange, bool) {
+	if !r.Overlaps(other) {
+		return TimeRange{}, false
+	}
+
+	start, end := r.Start, r.End
+	if other.Start.After(start) {
+		start = other.Start
+	}
+	if other.End.Before(end) {
+		end = other.End
+	}
+	return TimeRange{Start: start, End: end}, true
+}
+
+// UTC 转换为 UTC 时区
+func (r TimeRange) UTC() TimeRange {
+	return TimeRange{Start: r.Start.UTC(), End: r.End.UTC()}
+}
+
+// String 实现 Stringer 接口，如 [2024-01-01T00:00:00Z, 2024-01-02T00:00:00Z)
+func (r TimeRange) String() string {
+	return "[" + r.Start.Format(time.RFC3339) + ", " + r.End.Format(time.RFC3339) + ")"
+}
+
+// ============================================================================
+// JSON 接口实现
+// ============================================================================
+
+// timeRangeJSON 避免 MarshalJSON 递归
+type timeRangeJSON struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// MarshalJSON 实现 json.Marshaler 接口，零值输出 null
+func (r TimeRange) MarshalJSON() ([]byte, error) {
+	if r.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(timeRangeJSON(r))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+func (r *TimeRange) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*r = TimeRange{}
+		return nil
+	}
+
+	var raw timeRangeJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to unmarshal TimeRange from JSON: %w", err)
+	}
+	*r = TimeRange(raw)
+	return nil
+}
+
+// ============================================================================
+// 数据库接口实现
+// ============================================================================
+
+// Value 实现 driver.Valuer 接口
+// 说明：零值写入 NULL
+func (r TimeRange) Value() (driver.Value, error) {
+	if r.IsZero() {
+		return nil, nil
+	}
+	data, err := json.Marshal(timeRangeJSON(r))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan 实现 sql.Scanner 接口
+// 说明：支持 JSON 和 PostgreSQL 范围字面量（如 ["2024-01-01 00:00:00+00","2024-01-02 00:00:00+00")）
+func (r *TimeRange) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		*r = TimeRange{}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("cannot scan type %T into TimeRange", value)
+	}
+
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "{") {
+		return r.UnmarshalJSON([]byte(s))
+	}
+	return r.scanRangeLiteral(s)
+}
+
+// scanRangeLiteral 解析 PostgreSQL 范围字面量
+// 说明：只支持左闭右开的有界范围
+func (r *TimeRange) scanRangeLiteral(s string) error {
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ')' {
+		return fmt.Errorf("timerange: unsupported range literal %q", s)
+	}
+
+	startRaw, endRaw, found := strings.Cut(s[1:len(s)-1], ",")
+	if !found {
+		return fmt.Errorf("timerange: invalid range literal %q", s)
+	}
+
+	start, err := parseRangeBound(startRaw)
+	if err != nil {
+		return err
+	}
+	end, err := parseRangeBound(endRaw)
+	if err != nil {
+		return err
+	}
+	*r = TimeRange{Start: start, End: end}
+	return nil
+}
+
+// rangeBoundLayouts 范围边界的时间格式
+var rangeBoundLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// parseRangeBound 解析范围边界
+func parseRangeBound(raw string) (time.Time, error) {
+	raw = strings.Trim(strings.TrimSpace(raw), `"`)
+	for _, layout := range rangeBoundLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("timerange: invalid range bound %q", raw)
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// TimeRange 测试
+// ============================================================================
+
+func mustTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// TestNewTimeRange 测试创建
+func TestNewTimeRange(t *testing.T) {
+	start, end := mustTime("2024-01-01T00:00:00Z"), mustTime("2024-01-02T00:00:00Z")
+	if _, err := NewTimeRange(start, end); err != nil {
+		t.Errorf("NewTimeRange() error = %v", err)
+	}
+	if _, err := NewTimeRange(end, start); err == nil {
+		t.Error("NewTimeRange() with end before start should fail")
+	}
+	if _, err := NewTimeRange(start, start); err == nil {
+		t.Error("NewTimeRange() with empty range should fail")
+	}
+
+	r, err := NewDateRange(mustTime("2024-01-01T15:00:00Z"), mustTime("2024-01-31T08:00:00Z"))
+	if err != nil || !r.Start.Equal(mustTime("2024-01-01T00:00:00Z")) || !r.End.Equal(mustTime("2024-02-01T00:00:00Z")) {
+		t.Errorf("NewDateRange() = (%v, %v)", r, err)
+	}
+}
+
+// TestTimeRange_Relations 测试包含、重叠和交集
+func TestTimeRange_Relations(t *testing.T) {
+	day := TimeRange{Start: mustTime("2024-01-01T00:00:00Z"), End: mustTime("2024-01-02T00:00:00Z")}
+	morning := TimeRange{Start: mustTime("2024-01-01T09:00:00Z"), End: mustTime("2024-01-01T12:00:00Z")}
+	nextDay := TimeRange{Start: mustTime("2024-01-02T00:00:00Z"), End: mustTime("2024-01-03T00:00:00Z")}
+	span := TimeRange{Start: mustTime("2024-01-01T20:00:00Z"), End: mustTime("2024-01-02T04:00:00Z")}
+
+	if !day.Contains(day.Start) || day.Contains(day.End) {
+		t.Error("Contains() should be left-closed and right-open")
+	}
+	if !day.ContainsRange(morning) || day.ContainsRange(span) {
+		t.Error("ContainsRange() mismatch")
+	}
+	if day.Overlaps(nextDay) {
+		t.Error("adjacent ranges should not overlap")
+	}
+	if !day.Overlaps(span) {
+		t.Error("Overlaps() should be true for crossing ranges")
+	}
+
+	got, ok := day.Intersect(span)
+	want := TimeRange{Start: mustTime("2024-01-01T20:00:00Z"), End: mustTime("2024-01-02T00:00:00Z")}
+	if !ok || got != want {
+		t.Errorf("Intersect() = (%v, %v), want %v", got, ok, want)
+	}
+	if _, ok := day.Intersect(nextDay); ok {
+		t.Error("Intersect() of adjacent ranges should fail")
+	}
+	if day.Duration() != 24*time.Hour {
+		t.Errorf("Duration() = %v, want 24h", day.Duration())
+	}
+}
+
+// TestTimeRange_JSONAndSQL 测试序列化
+func TestTimeRange_JSONAndSQL(t *testing.T) {
+	r := TimeRange{Start: mustTime("2024-01-01T00:00:00Z"), End: mustTime("2024-01-02T00:00:00Z")}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if got, want := string(data), `{"start":"2024-01-01T00:00:00Z","end":"2024-01-02T00:00:00Z"}`; got != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+
+	var decoded TimeRange
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded != r {
+		t.Errorf("Unmarshal() = (%v, %v), want %v", decoded, err, r)
+	}
+
+	if data, _ := json.Marshal(TimeRange{}); string(data) != "null" {
+		t.Errorf("Marshal() of zero = %s, want null", data)
+	}
+
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"JSON", `{"start":"2024-01-01T00:00:00Z","end":"2024-01-02T00:00:00Z"}`},
+		{"范围字面量", []byte(`["2024-01-01 00:00:00+00","2024-01-02 00:00:00+00")`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var scanned TimeRange
+			if err := scanned.Scan(tt.value); err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+			if !scanned.Start.Equal(r.Start) || !scanned.End.Equal(r.End) {
+				t.Errorf("Scan() = %v, want %v", scanned, r)
+			}
+		})
+	}
+
+	var bad TimeRange
+	if err := bad.Scan("(2024-01-01,2024-01-02]"); err == nil {
+		t.Error("Scan() with unsupported bounds should fail")
+	}
+}
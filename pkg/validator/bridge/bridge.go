@@ -0,0 +1,156 @@
+// Package bridge 将 go-playground/validator 的错误转换为统一的字段错误结构
+//
+// 各版本验证器在回退到原生 validator.Struct 时，错误会以 go-playground 的格式返回，
+// 命名空间使用 Go 字段名且丢失统一的错误结构。本包负责：
+//   - 将 validator.ValidationErrors 转换为 []*FieldError（即统一结构 errs.FieldError）
+//   - 命名空间和字段名统一使用 JSON tag（包括嵌套结构体、切片下标和 map 键）
+package bridge
+
+import (
+	"errors"
+	"katydid-common-account/pkg/validator/errs"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError 统一的字段错误结构
+// 说明：即 errs.FieldError，保留别名以兼容已有调用方
+type FieldError = errs.FieldError
+
+// FromValidationErrors 将 go-playground 的验证错误转换为统一的字段错误列表
+// 参数：
+//   - err：validator.Struct / validator.Var 返回的错误
+//   - root：被验证的对象，用于将 Go 字段名转换为 JSON 名称；为 nil 时保留原命名空间
+//
+// 返回值：err 不是 validator.ValidationErrors 时 ok 为 false
+func FromValidationErrors(err error, root any) (fieldErrors []*FieldError, ok bool) {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil, false
+	}
+
+	rootType := reflect.TypeOf(root)
+	fieldErrors = make([]*FieldError, 0, len(validationErrors))
+	for _, e := range validationErrors {
+		fieldErrors = append(fieldErrors, FromFieldError(e, rootType))
+	}
+	return fieldErrors, true
+}
+
+// FromFieldError 转换单个 go-playground 字段错误
+// 说明：rootType 为 nil 时使用 e.Namespace()（调用方已注册 JSON tag 名称函数时结果相同）
+func FromFieldError(e validator.FieldError, rootType reflect.Type) *FieldError {
+	namespace := e.Namespace()
+	if rootType != nil {
+		namespace = JSONNamespace(rootType, e.StructNamespace())
+	}
+
+	field := errs.FieldName(namespace)
+	if field == "" {
+		field = e.Field()
+	}
+
+	return &FieldError{
+		Namespace: namespace,
+		Field:     field,
+		Tag:       e.Tag(),
+		Param:     e.Param(),
+		Value:     e.Value(),
+		Message:   e.Error(),
+	}
+}
+
+// JSONNamespace 将使用 Go 字段名的命名空间转换为使用 JSON 名称的命名空间
+// 示例：User.Profile.Emails[0] -> User.profile.emails[0]
+// 说明：首段为根类型名，保持不变；无法解析的后续段原样保留
+func JSONNamespace(rootType reflect.Type, structNamespace string) string {
+	segments := splitNamespace(structNamespace)
+	if len(segments) <= 1 {
+		return structNamespace
+	}
+
+	typ := indirectType(rootType)
+	var b strings.Builder
+	b.Grow(len(structNamespace))
+	b.WriteString(segments[0])
+
+	for _, segment := range segments[1:] {
+		b.WriteByte('.')
+
+		// 拆分字段名和下标部分，如 Emails[0][key]
+		name, suffix := segment, ""
+		if idx := strings.IndexByte(segment, '['); idx >= 0 {
+			name, suffix = segment[:idx], segment[idx:]
+		}
+
+		if typ == nil || typ.Kind() != reflect.Struct {
+			typ = nil
+			b.WriteString(segment)
+			continue
+		}
+
+		structField, found := typ.FieldByName(name)
+		if !found {
+			typ = nil
+			b.WriteString(segment)
+			continue
+		}
+
+		b.WriteString(jsonName(structField))
+		b.WriteString(suffix)
+
+		// 每个下标进入一层元素类型
+		typ = indirectType(structField.Type)
+		for i := strings.Count(suffix, "["); i > 0 && typ != nil; i-- {
+			switch typ.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				typ = indirectType(typ.Elem())
+			default:
+				typ = nil
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// jsonName 获取字段的 JSON 名称
+func jsonName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// indirectType 解引用指针类型
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+// splitNamespace 按 "." 拆分命名空间，忽略方括号内的 "."（如 map 键 [a.b]）
+func splitNamespace(namespace string) []string {
+	segments := make([]string, 0, 4)
+	depth, start := 0, 0
+	for i := 0; i < len(namespace); i++ {
+		switch namespace[i] {
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		case '.':
+			if depth == 0 {
+				segments = append(segments, namespace[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(segments, namespace[start:])
+}
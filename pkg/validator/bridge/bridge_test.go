@@ -0,0 +1,116 @@
+package bridge
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ============================================================================
+// 测试模型定义
+// ============================================================================
+
+type testAddress struct {
+	City string `json:"city" validate:"required"`
+}
+
+type testProfile struct {
+	Emails    []string                `json:"emails" validate:"dive,email"`
+	Addresses []*testAddress          `json:"addresses" validate:"dive"`
+	Labels    map[string]*testAddress `json:"labels" validate:"dive"`
+}
+
+type testUser struct {
+	Username string       `json:"username" validate:"required,min=3"`
+	Nickname string       `validate:"max=5"`
+	Profile  *testProfile `json:"profile"`
+}
+
+// ============================================================================
+// 转换测试
+// ============================================================================
+
+// TestFromValidationErrors 测试原生 validator.Struct 错误转换为 JSON 命名空间
+func TestFromValidationErrors(t *testing.T) {
+	user := &testUser{
+		Username: "ab",
+		Nickname: "too-long",
+		Profile: &testProfile{
+			Emails:    []string{"ok@example.com", "bad"},
+			Addresses: []*testAddress{{City: "sh"}, {}},
+			Labels:    map[string]*testAddress{"a.b": {}},
+		},
+	}
+
+	// 不注册 JSON tag 名称函数，模拟回退到原生验证器
+	err := validator.New().Struct(user)
+	fieldErrors, ok := FromValidationErrors(err, user)
+	if !ok {
+		t.Fatalf("FromValidationErrors() ok = false, err = %v", err)
+	}
+
+	want := map[string]string{
+		"testUser.username":                  "min",
+		"testUser.Nickname":                  "max",
+		"testUser.profile.emails[1]":         "email",
+		"testUser.profile.addresses[1].city": "required",
+		"testUser.profile.labels[a.b].city":  "required",
+	}
+	if len(fieldErrors) != len(want) {
+		t.Fatalf("got %d errors, want %d: %+v", len(fieldErrors), len(want), fieldErrors)
+	}
+	for _, fe := range fieldErrors {
+		tag, exists := want[fe.Namespace]
+		if !exists {
+			t.Errorf("unexpected namespace %q", fe.Namespace)
+			continue
+		}
+		if fe.Tag != tag {
+			t.Errorf("namespace %q tag = %q, want %q", fe.Namespace, fe.Tag, tag)
+		}
+	}
+}
+
+// TestFromValidationErrors_Field 测试字段名取命名空间最后一段
+func TestFromValidationErrors_Field(t *testing.T) {
+	user := &testUser{Username: "ok-name", Profile: &testProfile{Emails: []string{"bad"}}}
+	fieldErrors, _ := FromValidationErrors(validator.New().Struct(user), user)
+	if len(fieldErrors) != 1 || fieldErrors[0].Field != "emails[0]" {
+		t.Fatalf("got %+v, want field emails[0]", fieldErrors)
+	}
+}
+
+// TestFromValidationErrors_NotValidationErrors 测试非验证错误
+func TestFromValidationErrors_NotValidationErrors(t *testing.T) {
+	if _, ok := FromValidationErrors(errors.New("boom"), nil); ok {
+		t.Error("expected ok = false for plain error")
+	}
+	if _, ok := FromValidationErrors(nil, nil); ok {
+		t.Error("expected ok = false for nil error")
+	}
+}
+
+// TestJSONNamespace 测试命名空间转换
+func TestJSONNamespace(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"根类型", "testUser", "testUser"},
+		{"普通字段", "testUser.Username", "testUser.username"},
+		{"无json标签", "testUser.Nickname", "testUser.Nickname"},
+		{"嵌套切片", "testUser.Profile.Addresses[2].City", "testUser.profile.addresses[2].city"},
+		{"未知字段", "testUser.Unknown.City", "testUser.Unknown.City"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JSONNamespace(reflect.TypeOf(&testUser{}), tt.in); got != tt.want {
+				t.Errorf("JSONNamespace(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
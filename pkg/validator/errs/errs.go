@@ -0,0 +1,159 @@
+// Package errs 各版本验证器共享的统一错误结构
+//
+// v1、v5、v6 各自定义了字段错误和验证错误，结构互不相同，
+// 无法编写通用的 HTTP 错误处理。本包提供唯一的统一结构：
+//   - 各版本通过 Unified 方法 / ToUnified 函数转换为 *ValidationError
+//   - HTTP 处理器只需调用 From(err)，不依赖具体的验证器版本
+//
+// 本包不依赖任何版本的验证器，各版本单向依赖本包。
+package errs
+
+import (
+	"errors"
+	"strings"
+)
+
+// 错误级别（与 v6 的 core.Severity 字符串一致）
+const (
+	SeverityError   = "error"   // 错误：验证失败
+	SeverityWarning = "warning" // 警告：验证通过，但需提示
+	SeverityInfo    = "info"    // 信息：仅供参考
+)
+
+// FieldError 统一的字段错误结构
+type FieldError struct {
+	// Namespace 完整命名空间（如 User.profile.email）
+	Namespace string `json:"namespace"`
+
+	// Field 字段名，即命名空间的最后一段（如 email、emails[0]）
+	Field string `json:"field"`
+
+	// Tag 验证标签（如 required, email, min）
+	Tag string `json:"tag"`
+
+	// Param 验证参数（如 min=3 中的 "3"）
+	Param string `json:"param,omitempty"`
+
+	// Value 字段的实际值（各版本转换时已按各自规则脱敏或截断）
+	Value any `json:"value,omitempty"`
+
+	// Params 结构化参数（如 {"min": 1, "max": 100}，只有 v6 会产生）
+	Params map[string]any `json:"params,omitempty"`
+
+	// Message 用户友好的错误消息
+	Message string `json:"message,omitempty"`
+
+	// Severity 错误级别，空值等同于 SeverityError
+	Severity string `json:"severity,omitempty"`
+
+	// Code 业务错误码（由 RegisterCode 注册，HTTPMapper 填充）
+	Code string `json:"code,omitempty"`
+}
+
+// IsBlocking 是否为阻断验证的错误
+func (fe *FieldError) IsBlocking() bool {
+	return fe.Severity == "" || fe.Severity == SeverityError
+}
+
+// Error 实现 error 接口
+func (fe *FieldError) Error() string {
+	if fe.Message != "" {
+		return fe.Message
+	}
+	return "field '" + fe.Namespace + "' failed on '" + fe.Tag + "'"
+}
+
+// ValidationError 统一的验证错误结构
+type ValidationError struct {
+	// Message 总体错误消息（可选）
+	Message string `json:"message,omitempty"`
+
+	// Errors 字段错误列表
+	Errors []*FieldError `json:"errors"`
+
+	// Warnings 警告列表（不影响验证结果，只有 v6 会产生）
+	Warnings []*FieldError `json:"warnings,omitempty"`
+}
+
+// Error 实现 error 接口
+func (e *ValidationError) Error() string {
+	if len(e.Errors) == 0 {
+		if e.Message != "" {
+			return e.Message
+		}
+		return "validation failed"
+	}
+
+	var b strings.Builder
+	for i, fe := range e.Errors {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(fe.Error())
+	}
+	return b.String()
+}
+
+// HasErrors 是否有错误
+func (e *ValidationError) HasErrors() bool {
+	return len(e.Errors) > 0
+}
+
+// ByField 按命名空间分组错误
+func (e *ValidationError) ByField() map[string][]*FieldError {
+	groups := make(map[string][]*FieldError, len(e.Errors))
+	for _, fe := range e.Errors {
+		groups[fe.Namespace] = append(groups[fe.Namespace], fe)
+	}
+	return groups
+}
+
+// ============================================================================
+// 转换
+// ============================================================================
+
+// Converter 可以转换为统一结构的错误
+// 说明：v1 的 *ValidationContext、v5 的 *err.ValidationError、v6 的验证错误都实现了该接口
+type Converter interface {
+	Unified() *ValidationError
+}
+
+// From 将任意版本验证器返回的错误转换为统一结构
+// 返回值：err 为 nil 或不是验证错误时 ok 为 false
+func From(err error) (ve *ValidationError, ok bool) {
+	if err == nil {
+		return nil, false
+	}
+	if errors.As(err, &ve) {
+		return ve, ve != nil
+	}
+	var converter Converter
+	if errors.As(err, &converter) {
+		if ve = converter.Unified(); ve != nil {
+			return ve, true
+		}
+	}
+	return nil, false
+}
+
+// FieldName 获取命名空间的最后一段作为字段名
+// 示例：User.profile.emails[0] -> emails[0]，User.labels[a.b] -> labels[a.b]
+// 说明：方括号内的 "." 属于 map 键，不作为分隔符
+func FieldName(namespace string) string {
+	depth := 0
+	for i := len(namespace) - 1; i >= 0; i-- {
+		switch namespace[i] {
+		case ']':
+			depth++
+		case '[':
+			if depth > 0 {
+				depth--
+			}
+		case '.':
+			if depth == 0 {
+				return namespace[i+1:]
+			}
+		}
+	}
+	return namespace
+}
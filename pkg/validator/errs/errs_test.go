@@ -0,0 +1,163 @@
+package errs_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"katydid-common-account/pkg/validator/errs"
+	v1 "katydid-common-account/pkg/validator/v1"
+	v5core "katydid-common-account/pkg/validator/v5/core"
+	v5err "katydid-common-account/pkg/validator/v5/err"
+	v6core "katydid-common-account/pkg/validator/v6/core"
+	v6errors "katydid-common-account/pkg/validator/v6/errors"
+)
+
+// TestFieldName 测试从命名空间提取字段名
+func TestFieldName(t *testing.T) {
+	cases := map[string]string{
+		"":                       "",
+		"Username":               "Username",
+		"User.profile.email":     "email",
+		"User.profile.emails[0]": "emails[0]",
+		"User.labels[a.b]":       "labels[a.b]",
+		"User.labels[a.b].city":  "city",
+	}
+	for namespace, want := range cases {
+		if got := errs.FieldName(namespace); got != want {
+			t.Errorf("FieldName(%q) = %q, want %q", namespace, got, want)
+		}
+	}
+}
+
+// TestFrom 测试从 error 中提取统一结构
+func TestFrom(t *testing.T) {
+	if _, ok := errs.From(nil); ok {
+		t.Error("nil error should not convert")
+	}
+	if _, ok := errs.From(fmt.Errorf("boom")); ok {
+		t.Error("plain error should not convert")
+	}
+
+	ve := &errs.ValidationError{Errors: []*errs.FieldError{{Namespace: "User.name", Field: "name", Tag: "required"}}}
+	got, ok := errs.From(fmt.Errorf("wrapped: %w", ve))
+	if !ok || got != ve {
+		t.Fatalf("From should unwrap *ValidationError, got %v %v", got, ok)
+	}
+	if got.Error() != "field 'User.name' failed on 'required'" {
+		t.Errorf("unexpected message: %s", got.Error())
+	}
+
+	var typedNil *errs.ValidationError
+	if _, ok := errs.From(typedNil); ok {
+		t.Error("typed nil should not convert")
+	}
+}
+
+// TestFromV1 测试 v1 错误转换
+func TestFromV1(t *testing.T) {
+	ctx := v1.NewValidationContext(v1.ValidateScene(1))
+	defer v1.ReleaseValidationContext(ctx)
+	ctx.AddError(v1.NewFieldError("User.profile.email", "email", "").WithValue("bad").WithMessage("邮箱格式错误"))
+	ctx.SetMessage("请求参数错误")
+
+	ve, ok := errs.From(ctx)
+	if !ok {
+		t.Fatal("v1 ValidationContext should convert")
+	}
+	want := &errs.FieldError{
+		Namespace: "User.profile.email",
+		Field:     "email",
+		Tag:       "email",
+		Value:     "bad",
+		Message:   "邮箱格式错误",
+		Severity:  errs.SeverityError,
+	}
+	assertFieldError(t, ve, want)
+	if ve.Message != "请求参数错误" {
+		t.Errorf("message = %q", ve.Message)
+	}
+}
+
+// TestFromV5 测试 v5 错误转换
+func TestFromV5(t *testing.T) {
+	result := v5err.NewValidationError(tagFormatter{},
+		v5err.WithError(v5err.NewFieldError("User.profile.email", "email", v5err.WithValue("bad"))))
+
+	ve := v5err.ToUnified(result)
+	assertFieldError(t, ve, &errs.FieldError{
+		Namespace: "User.profile.email",
+		Field:     "email",
+		Tag:       "email",
+		Value:     "bad",
+		Message:   "User.profile.email: email",
+		Severity:  errs.SeverityError,
+	})
+}
+
+// tagFormatter v5 测试用格式化器
+type tagFormatter struct{}
+
+func (tagFormatter) Format(err v5core.IFieldError) string {
+	return err.Namespace() + ": " + err.Tag()
+}
+
+func (tagFormatter) FormatAll([]v5core.IFieldError) string {
+	return ""
+}
+
+// TestFromV6 测试 v6 错误转换（消息来自格式化器，敏感值脱敏）
+func TestFromV6(t *testing.T) {
+	result := v6errors.NewValidationError(
+		[]v6core.IFieldError{
+			v6errors.NewFieldError("User.profile.email", "email", "email", v6errors.WithValue("bad")),
+			v6errors.NewFieldError("User.password", "password", "min", v6errors.WithParam("8"), v6errors.WithValue("123")),
+		},
+		nil,
+		v6errors.WithWarnings([]v6core.IFieldError{
+			v6errors.NewFieldError("User.nickname", "nickname", "deprecated", v6errors.WithSeverity(v6core.SeverityWarning)),
+		}),
+	)
+
+	ve, ok := errs.From(result)
+	if !ok {
+		t.Fatal("v6 result should convert")
+	}
+	if len(ve.Errors) != 2 || len(ve.Warnings) != 1 {
+		t.Fatalf("unexpected errors/warnings: %d/%d", len(ve.Errors), len(ve.Warnings))
+	}
+	if ve.Errors[0].Message != result.Errors()[0] {
+		t.Errorf("message should come from formatter: %q", ve.Errors[0].Message)
+	}
+	if ve.Errors[1].Value != v6errors.RedactedValue {
+		t.Errorf("sensitive value should be redacted: %v", ve.Errors[1].Value)
+	}
+	if ve.Warnings[0].Severity != errs.SeverityWarning || ve.Warnings[0].IsBlocking() {
+		t.Errorf("warning severity = %q", ve.Warnings[0].Severity)
+	}
+
+	// 序列化往返后结构不变
+	data, err := json.Marshal(ve)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded errs.ValidationError
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Errors[1].Field != "password" || decoded.Errors[1].Param != "8" {
+		t.Errorf("unexpected decoded error: %+v", decoded.Errors[1])
+	}
+}
+
+// assertFieldError 断言只有一个字段错误且与期望一致
+func assertFieldError(t *testing.T, ve *errs.ValidationError, want *errs.FieldError) {
+	t.Helper()
+	if len(ve.Errors) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(ve.Errors))
+	}
+	if got := *ve.Errors[0]; !reflect.DeepEqual(got, *want) {
+		t.Errorf("got %+v, want %+v", got, *want)
+	}
+}
@@ -0,0 +1,197 @@
+package errs
+
+import (
+	"net/http"
+	"sync"
+)
+
+// ============================================================================
+// 错误码注册表
+// ============================================================================
+
+// Code 标签对应的错误码
+type Code struct {
+	// Code 业务错误码（如 USER_EMAIL_TAKEN），写入 FieldError.Code
+	Code string
+
+	// Status 指定的 HTTP 状态码（0 表示按默认规则映射）
+	Status int
+}
+
+var (
+	codeMu   sync.RWMutex
+	codeRegs = make(map[string]Code)
+)
+
+// RegisterCode 注册验证标签的错误码（重复注册时覆盖）
+// 说明：status 为 0 时状态码按默认规则映射，见 HTTPMapper.Map
+func RegisterCode(tag, code string, status int) {
+	codeMu.Lock()
+	defer codeMu.Unlock()
+	codeRegs[tag] = Code{Code: code, Status: status}
+}
+
+// LookupCode 查询验证标签的错误码
+func LookupCode(tag string) (Code, bool) {
+	codeMu.RLock()
+	defer codeMu.RUnlock()
+	code, ok := codeRegs[tag]
+	return code, ok
+}
+
+// ============================================================================
+// HTTP 状态码映射
+// ============================================================================
+
+// 响应体的总体错误码
+const (
+	CodeValidationFailed = "validation_failed" // 422：规则验证失败
+	CodeConflict         = "conflict"          // 409：与已有数据冲突（如唯一性）
+	CodeMalformedRequest = "malformed_request" // 400：请求格式错误
+)
+
+var (
+	// DefaultConflictTags 默认的冲突标签（仓储规则 unique=table.column、exists=table.column）
+	DefaultConflictTags = []string{"unique", "exists"}
+
+	// DefaultMalformedTags 默认的格式错误标签（v6 binding 的 decode、model）
+	DefaultMalformedTags = []string{"decode", "model"}
+)
+
+// HTTPBody 验证失败的响应体
+type HTTPBody struct {
+	// Code 总体错误码（validation_failed、conflict、malformed_request）
+	Code string `json:"code"`
+
+	// Message 总体错误消息
+	Message string `json:"message,omitempty"`
+
+	// Errors 字段错误列表（Code 由注册表填充）
+	Errors []*FieldError `json:"errors"`
+
+	// Warnings 警告列表
+	Warnings []*FieldError `json:"warnings,omitempty"`
+}
+
+// HTTPMapper 验证错误到 HTTP 响应的映射
+// 说明：创建后只读，可并发使用
+type HTTPMapper struct {
+	conflictTags  map[string]bool
+	malformedTags map[string]bool
+}
+
+// HTTPOption 映射选项
+type HTTPOption func(*HTTPMapper)
+
+// WithConflictTags 设置冲突标签（替换默认的 unique、exists）
+func WithConflictTags(tags ...string) HTTPOption {
+	return func(m *HTTPMapper) {
+		m.conflictTags = tagSet(tags)
+	}
+}
+
+// WithMalformedTags 设置格式错误标签（替换默认的 decode、model）
+func WithMalformedTags(tags ...string) HTTPOption {
+	return func(m *HTTPMapper) {
+		m.malformedTags = tagSet(tags)
+	}
+}
+
+// NewHTTPMapper 创建映射
+func NewHTTPMapper(opts ...HTTPOption) *HTTPMapper {
+	m := &HTTPMapper{
+		conflictTags:  tagSet(DefaultConflictTags),
+		malformedTags: tagSet(DefaultMalformedTags),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// defaultHTTPMapper 默认映射
+var defaultHTTPMapper = NewHTTPMapper()
+
+// HTTPResponse 使用默认映射转换验证错误，见 HTTPMapper.Map
+func HTTPResponse(err error) (int, *HTTPBody) {
+	return defaultHTTPMapper.Map(err)
+}
+
+// Map 将验证错误转换为状态码和响应体
+// 说明：
+//   - err 为 nil，或只有警告（没有阻断错误）时返回 200；响应体为 nil 或只包含警告
+//   - 不是验证错误（如 JSON 解码失败）时视为请求格式错误，返回 400，消息为 err.Error()
+//   - 有格式错误标签时返回 400；否则有冲突标签时返回 409；
+//     否则第一个注册了状态码的错误决定状态码；都没有时返回 422
+//   - 注册了错误码的标签，其字段错误的 Code 为注册的错误码（响应体中的错误是副本，不修改 err）
+func (m *HTTPMapper) Map(err error) (int, *HTTPBody) {
+	if err == nil {
+		return http.StatusOK, nil
+	}
+	ve, ok := From(err)
+	if !ok {
+		return http.StatusBadRequest, &HTTPBody{Code: CodeMalformedRequest, Message: err.Error(), Errors: []*FieldError{}}
+	}
+
+	body := &HTTPBody{
+		Code:     CodeValidationFailed,
+		Message:  ve.Message,
+		Errors:   withCodes(ve.Errors),
+		Warnings: withCodes(ve.Warnings),
+	}
+
+	var malformed, conflict, blocking bool
+	registered := 0
+	for _, fe := range body.Errors {
+		if !fe.IsBlocking() {
+			continue
+		}
+		blocking = true
+		malformed = malformed || m.malformedTags[fe.Tag]
+		conflict = conflict || m.conflictTags[fe.Tag]
+		if code, ok := LookupCode(fe.Tag); ok && code.Status != 0 && registered == 0 {
+			registered = code.Status
+		}
+	}
+
+	switch {
+	case !blocking:
+		body.Code = ""
+		return http.StatusOK, body
+	case malformed:
+		body.Code = CodeMalformedRequest
+		return http.StatusBadRequest, body
+	case conflict:
+		body.Code = CodeConflict
+		return http.StatusConflict, body
+	case registered != 0:
+		return registered, body
+	default:
+		return http.StatusUnprocessableEntity, body
+	}
+}
+
+// withCodes 复制字段错误并填充注册的错误码
+func withCodes(list []*FieldError) []*FieldError {
+	if list == nil {
+		return nil
+	}
+	out := make([]*FieldError, len(list))
+	for i, fe := range list {
+		copied := *fe
+		if code, ok := LookupCode(fe.Tag); ok && copied.Code == "" {
+			copied.Code = code.Code
+		}
+		out[i] = &copied
+	}
+	return out
+}
+
+// tagSet 标签集合
+func tagSet(tags []string) map[string]bool {
+	set := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		set[tag] = true
+	}
+	return set
+}
@@ -0,0 +1,85 @@
+package errs_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"katydid-common-account/pkg/validator/errs"
+	v6core "katydid-common-account/pkg/validator/v6/core"
+	v6errors "katydid-common-account/pkg/validator/v6/errors"
+)
+
+// newResult 构造只包含指定标签错误的统一结构
+func newResult(tags ...string) *errs.ValidationError {
+	ve := &errs.ValidationError{}
+	for _, tag := range tags {
+		ve.Errors = append(ve.Errors, &errs.FieldError{Namespace: "User." + tag, Field: tag, Tag: tag})
+	}
+	return ve
+}
+
+// TestHTTPResponse 测试默认映射的状态码
+func TestHTTPResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"通过", nil, http.StatusOK, ""},
+		{"规则错误", newResult("required", "min"), http.StatusUnprocessableEntity, errs.CodeValidationFailed},
+		{"冲突", newResult("required", "unique"), http.StatusConflict, errs.CodeConflict},
+		{"格式错误优先", newResult("exists", "decode"), http.StatusBadRequest, errs.CodeMalformedRequest},
+		{"非验证错误", fmt.Errorf("invalid character 'x'"), http.StatusBadRequest, errs.CodeMalformedRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := errs.HTTPResponse(tt.err)
+			if status != tt.status {
+				t.Errorf("status = %d, want %d", status, tt.status)
+			}
+			if (body == nil) != (tt.err == nil) || (body != nil && body.Code != tt.code) {
+				t.Errorf("body = %+v", body)
+			}
+		})
+	}
+}
+
+// TestHTTPResponse_Warnings 只有警告时返回 200
+func TestHTTPResponse_Warnings(t *testing.T) {
+	result := v6errors.NewValidationError(nil, nil, v6errors.WithWarnings([]v6core.IFieldError{
+		v6errors.NewFieldError("User.nickname", "nickname", "deprecated", v6errors.WithSeverity(v6core.SeverityWarning)),
+	}))
+	status, body := errs.HTTPResponse(result)
+	if status != http.StatusOK || body == nil || len(body.Warnings) != 1 || len(body.Errors) != 0 {
+		t.Errorf("status = %d, body = %+v", status, body)
+	}
+}
+
+// TestHTTPMapper_Registry 注册的错误码写入响应体，注册的状态码优先于 422
+func TestHTTPMapper_Registry(t *testing.T) {
+	errs.RegisterCode("http_test_quota", "QUOTA_EXCEEDED", http.StatusTooManyRequests)
+	errs.RegisterCode("http_test_taken", "EMAIL_TAKEN", 0)
+
+	ve := newResult("http_test_taken", "http_test_quota")
+	status, body := errs.HTTPResponse(ve)
+	if status != http.StatusTooManyRequests {
+		t.Errorf("status = %d", status)
+	}
+	if body.Errors[0].Code != "EMAIL_TAKEN" || body.Errors[1].Code != "QUOTA_EXCEEDED" {
+		t.Errorf("codes = %q %q", body.Errors[0].Code, body.Errors[1].Code)
+	}
+	if ve.Errors[0].Code != "" {
+		t.Error("原始错误不应被修改")
+	}
+
+	// 自定义冲突标签，注册的状态码低于冲突
+	mapper := errs.NewHTTPMapper(errs.WithConflictTags("http_test_taken"))
+	if status, body := mapper.Map(ve); status != http.StatusConflict || body.Code != errs.CodeConflict {
+		t.Errorf("status = %d, body = %+v", status, body)
+	}
+	if status, _ := mapper.Map(newResult("unique")); status != http.StatusUnprocessableEntity {
+		t.Errorf("unique 不再是冲突标签: status = %d", status)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"reflect"
 	"unsafe"
 
+	"katydid-common-account/pkg/validator/errs"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -606,3 +608,42 @@ func (vc *ValidationContext) SetMessage(message string) {
 func (vc *ValidationContext) IsEmpty() bool {
 	return len(vc.Errors) == 0 && vc.Message == ""
 }
+
+// Unified 转换为各版本共享的统一错误结构
+// 用于与其他版本验证器共用 HTTP 错误处理，见 errs.From
+func (fe *FieldError) Unified() *errs.FieldError {
+	return &errs.FieldError{
+		Namespace: fe.Namespace,
+		Field:     errs.FieldName(fe.Namespace),
+		Tag:       fe.Tag,
+		Param:     fe.Param,
+		Value:     fe.Value,
+		Message:   fe.Message,
+		Severity:  errs.SeverityError,
+	}
+}
+
+// Unified 转换为各版本共享的统一错误结构（实现 errs.Converter 接口）
+func (vc *ValidationContext) Unified() *errs.ValidationError {
+	ve := ToUnified(vc.Errors)
+	ve.Message = vc.Message
+	return ve
+}
+
+// ToUnified 将 Validate 返回的错误列表转换为统一错误结构
+// 参数：
+//
+//	errors: Validate / ValidateFields / ValidateExcept 的返回值
+//
+// 返回：
+//
+//	统一错误结构，errors 为空时 HasErrors 为 false
+func ToUnified(errors []*FieldError) *errs.ValidationError {
+	ve := &errs.ValidationError{Errors: make([]*errs.FieldError, 0, len(errors))}
+	for _, err := range errors {
+		if err != nil {
+			ve.Errors = append(ve.Errors, err.Unified())
+		}
+	}
+	return ve
+}
@@ -1,11 +1,16 @@
 package v1
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
+
+	"github.com/go-playground/validator/v10"
 )
 
 // MapValidators 场景化的Map验证器集合
@@ -41,6 +46,17 @@ type MapValidator struct {
 	// 支持复杂的业务验证逻辑
 	KeyValidators map[string]func(value any) error
 
+	// MaxValueSize 单个值的最大字节数（0 使用默认值 1MB，负数表示不限制）
+	// 字符串和 []byte 按长度计算，嵌套的 map/切片/结构体按 JSON 序列化后的长度计算
+	MaxValueSize int
+
+	// MaxTotalSize 整个 map 的字节预算（所有键名和值的大小之和；0 使用默认值 10MB，负数表示不限制）
+	MaxTotalSize int
+
+	// MaxDepth 值的最大嵌套深度（0 使用默认值 32，负数表示不限制）
+	// 标量深度为 0，每嵌套一层 map/切片/结构体深度加 1
+	MaxDepth int
+
 	// allowedKeysMap 内部缓存的允许键 map（性能优化）
 	// 使用 map 查找的时间复杂度为 O(1)，优于切片遍历的 O(n)
 	allowedKeysMap map[string]bool
@@ -59,6 +75,12 @@ const (
 
 	// maxMapValueSize 单个值的最大大小（字节），防止内存溢出
 	maxMapValueSize = 1024 * 1024 // 1MB
+
+	// maxMapTotalSize 整个 map 的最大大小（字节），防止大量中等大小的值绕过单值限制
+	maxMapTotalSize = 10 * 1024 * 1024 // 10MB
+
+	// maxMapDepth 值的最大嵌套深度，防止深层嵌套导致栈溢出或序列化开销过大
+	maxMapDepth = 32
 )
 
 // ValidateMaps 验证 map 字段（场景化）
@@ -108,6 +130,7 @@ func ValidateMaps(scene ValidateScene, kvs map[string]any, validators *MapValida
 
 // ValidateMap 验证 map[string]any 类型的扩展字段
 // 验证流程：
+//  0. 检查值的大小、总大小和嵌套深度（超限时只返回这些错误，不再执行后续验证）
 //  1. 检查必填键是否存在
 //  2. 检查是否包含非法键（白名单模式）
 //  3. 执行自定义键验证器
@@ -151,6 +174,14 @@ func ValidateMap(kvs map[string]any, v *MapValidator) []*FieldError {
 	ctx := NewValidationContext(0)
 	defer ReleaseValidationContext(ctx) // 使用后归还到池
 
+	// 0. 验证值大小和嵌套深度（安全性检查，防止超大负载）
+	// 超限的值可能让自定义验证函数开销过大，直接返回
+	if v.collectSizeErrors(kvs, ctx); ctx.HasErrors() {
+		errs := make([]*FieldError, len(ctx.Errors))
+		copy(errs, ctx.Errors)
+		return errs
+	}
+
 	// 1. 验证必填键（业务逻辑错误）
 	if len(v.RequiredKeys) > 0 {
 		v.collectRequiredKeyErrors(kvs, ctx)
@@ -296,15 +327,143 @@ func (mv *MapValidator) collectCustomKeyErrors(kvs map[string]any, ctx *Validati
 			}()
 
 			if err := validatorFunc(value); err != nil {
+				// 规则验证失败（KeyRules 或直接返回 validate.Var 的错误）时保留失败的标签
+				tag, param, message := "custom", "", err.Error()
+				var validationErrors validator.ValidationErrors
+				if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+					tag, param = validationErrors[0].Tag(), validationErrors[0].Param()
+					message = fmt.Sprintf("key '%s' failed on the '%s' rule", key, tag)
+				}
 				ctx.AddErrorByDetail(
-					mv.getNamespace(key), "custom", "", value,
-					err.Error(),
+					mv.getNamespace(key), tag, param, value,
+					message,
 				)
 			}
 		}()
 	}
 }
 
+// collectSizeErrors 收集值大小、总大小和嵌套深度错误
+// 检查顺序：嵌套深度 -> 单值大小 -> 总大小；深度超限的值不再计算大小，避免序列化深层嵌套的值
+// 错误标签：max_depth、value_size、total_size，Param 为对应的限制值，Value 为实际值
+func (mv *MapValidator) collectSizeErrors(kvs map[string]any, ctx *ValidationContext) {
+	maxValueSize := resolveMapLimit(mv.MaxValueSize, maxMapValueSize)
+	maxTotalSize := resolveMapLimit(mv.MaxTotalSize, maxMapTotalSize)
+	maxDepth := resolveMapLimit(mv.MaxDepth, maxMapDepth)
+
+	total := 0
+	for key, value := range kvs {
+		if len(ctx.Errors) >= maxValidationErrors {
+			return
+		}
+
+		// 嵌套深度（最多检查到 maxDepth+1 层）
+		if maxDepth >= 0 {
+			if depth := mapValueDepth(reflect.ValueOf(value), maxDepth+1); depth > maxDepth {
+				ctx.AddErrorByDetail(
+					mv.getNamespace(key), "max_depth", strconv.Itoa(maxDepth), depth,
+					fmt.Sprintf("value nesting depth exceeds maximum %d", maxDepth),
+				)
+				continue
+			}
+		}
+
+		size := mapValueSize(value)
+		if maxValueSize >= 0 && size > maxValueSize {
+			ctx.AddErrorByDetail(
+				mv.getNamespace(key), "value_size", strconv.Itoa(maxValueSize), size,
+				fmt.Sprintf("value size %d bytes exceeds maximum %d bytes", size, maxValueSize),
+			)
+		}
+		total += len(key) + size
+	}
+
+	if maxTotalSize >= 0 && total > maxTotalSize {
+		ctx.AddErrorByDetail(
+			"map", "total_size", strconv.Itoa(maxTotalSize), total,
+			fmt.Sprintf("map total size %d bytes exceeds maximum %d bytes", total, maxTotalSize),
+		)
+	}
+}
+
+// resolveMapLimit 解析限制值：0 使用默认值，负数表示不限制（返回 -1）
+func resolveMapLimit(limit, defaultLimit int) int {
+	switch {
+	case limit == 0:
+		return defaultLimit
+	case limit < 0:
+		return -1
+	default:
+		return limit
+	}
+}
+
+// mapValueSize 计算值的字节大小
+// 字符串和 []byte 按长度计算，标量按固定大小估算，嵌套值按 JSON 序列化后的长度计算
+func mapValueSize(value any) int {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case string:
+		return len(v)
+	case []byte:
+		return len(v)
+	case json.RawMessage:
+		return len(v)
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct, reflect.Ptr, reflect.Interface:
+		data, err := json.Marshal(value)
+		if err != nil {
+			// 无法序列化（如包含 chan、func）时退回内存估算
+			return estimateValueSize(value)
+		}
+		return len(data)
+	default:
+		return int(reflect.TypeOf(value).Size())
+	}
+}
+
+// mapValueDepth 计算值的嵌套深度
+// 参数 limit 为最大检查深度，超过后不再深入（防止超深嵌套导致栈溢出）
+func mapValueDepth(v reflect.Value, limit int) int {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return 0
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || limit <= 0 {
+		return 0
+	}
+
+	deepest := 0
+	switch v.Kind() {
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() && deepest < limit-1 {
+			deepest = max(deepest, mapValueDepth(iter.Value(), limit-1))
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return 0 // []byte 视为标量
+		}
+		for i := 0; i < v.Len() && deepest < limit-1; i++ {
+			deepest = max(deepest, mapValueDepth(v.Index(i), limit-1))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField() && deepest < limit-1; i++ {
+			if v.Type().Field(i).IsExported() {
+				deepest = max(deepest, mapValueDepth(v.Field(i), limit-1))
+			}
+		}
+	default:
+		return 0
+	}
+	return deepest + 1
+}
+
 // validateKeyName 验证键名的有效性
 // 防止注入攻击和非法字符
 // 参数：
@@ -902,6 +1061,45 @@ func (mv *MapValidator) WithKeyValidator(key string, validatorFunc func(value an
 	return mv
 }
 
+// WithMaxValueSize 设置单个值的最大字节数（链式调用）
+// 参数：
+//
+//	size: 最大字节数（0 使用默认值 1MB，负数表示不限制）
+//
+// 返回：
+//
+//	MapValidator 实例，支持链式调用
+func (mv *MapValidator) WithMaxValueSize(size int) *MapValidator {
+	mv.MaxValueSize = size
+	return mv
+}
+
+// WithMaxTotalSize 设置整个 map 的字节预算（链式调用）
+// 参数：
+//
+//	size: 最大字节数（0 使用默认值 10MB，负数表示不限制）
+//
+// 返回：
+//
+//	MapValidator 实例，支持链式调用
+func (mv *MapValidator) WithMaxTotalSize(size int) *MapValidator {
+	mv.MaxTotalSize = size
+	return mv
+}
+
+// WithMaxDepth 设置值的最大嵌套深度（链式调用）
+// 参数：
+//
+//	depth: 最大深度（0 使用默认值 32，负数表示不限制）
+//
+// 返回：
+//
+//	MapValidator 实例，支持链式调用
+func (mv *MapValidator) WithMaxDepth(depth int) *MapValidator {
+	mv.MaxDepth = depth
+	return mv
+}
+
 // AddRequiredKey 添加单个必填键
 // 参数：
 //
@@ -1015,3 +1213,103 @@ func (mvs *MapValidators) AddValidator(scene ValidateScene, validator *MapValida
 	mvs.Validators[scene] = validator
 	return mvs
 }
+
+// ============================================================================
+// 声明式 Map 规则
+// ============================================================================
+
+// MapSchema 声明式的 Map 验证规则
+// 设计目标：与 RuleValidation 的字段规则写在一起，由验证器自动构建 MapValidator
+type MapSchema struct {
+	// RequiredKeys 必填的键列表
+	RequiredKeys []string
+
+	// AllowedKeys 允许的键白名单（如果为空则不限制）
+	AllowedKeys []string
+
+	// KeyRules 特定键的验证规则，语法与字段规则相同（如 "required,min=3"）
+	// 验证失败时错误标签为规则中失败的标签（如 min），而不是 custom
+	KeyRules map[string]string
+
+	// KeyValidators 特定键的自定义验证函数，与 KeyRules 同时存在时先执行规则
+	KeyValidators map[string]func(value any) error
+
+	// MaxValueSize、MaxTotalSize、MaxDepth 大小与深度限制，含义同 MapValidator
+	MaxValueSize int
+	MaxTotalSize int
+	MaxDepth     int
+}
+
+// MapRuleProvider Map 规则提供者接口 - 声明模型中 map 字段的场景化规则
+// 设计目标：与 RuleValidator 风格一致，Extras 等 map 字段的规则与字段规则写在一起
+// 用途：实现该接口的模型在 Validate 时自动验证对应的 map 字段，无需手动调用 ValidateMaps
+//
+// 示例：
+//
+//	func (p *Product) ProvideMapRules() map[ValidateScene]map[string]MapSchema {
+//	    return map[ValidateScene]map[string]MapSchema{
+//	        SceneCreate: {"Extras": {RequiredKeys: []string{"brand"}, KeyRules: map[string]string{"brand": "min=2,max=50"}}},
+//	    }
+//	}
+type MapRuleProvider interface {
+	// ProvideMapRules 返回场景化的 map 字段规则
+	// 返回格式：map[场景标识][字段名]MapSchema，字段名支持 Go 字段名和 JSON 名称
+	ProvideMapRules() map[ValidateScene]map[string]MapSchema
+}
+
+// NewMapValidatorsFromRules 从声明式规则创建场景化的 Map 验证器集合
+// KeyRules 使用默认验证器的底层验证器执行（包括通过 RegisterAlias 注册的别名）
+// 参数：
+//
+//	rules: 场景到 Map 规则的映射
+//
+// 返回：
+//
+//	已初始化的 MapValidators 实例
+func NewMapValidatorsFromRules(rules map[ValidateScene]MapSchema) *MapValidators {
+	return newMapValidatorsFromRules(rules, Default().validate, "")
+}
+
+// newMapValidatorsFromRules 从声明式规则创建场景化的 Map 验证器集合
+func newMapValidatorsFromRules(rules map[ValidateScene]MapSchema, validate *validator.Validate, namespace string) *MapValidators {
+	mvs := NewMapValidators()
+	for scene, schema := range rules {
+		mvs.AddValidator(scene, newMapValidatorFromSchema(schema, validate).WithNameSpace(namespace))
+	}
+	return mvs
+}
+
+// newMapValidatorFromSchema 从声明式规则创建 Map 验证器
+func newMapValidatorFromSchema(schema MapSchema, validate *validator.Validate) *MapValidator {
+	mv := NewMapValidator().
+		WithRequiredKeys(schema.RequiredKeys...).
+		WithAllowedKeys(schema.AllowedKeys...).
+		WithMaxValueSize(schema.MaxValueSize).
+		WithMaxTotalSize(schema.MaxTotalSize).
+		WithMaxDepth(schema.MaxDepth)
+
+	for key, validatorFunc := range schema.KeyValidators {
+		mv.WithKeyValidator(key, validatorFunc)
+	}
+	for key, rule := range schema.KeyRules {
+		if rule == "" {
+			continue
+		}
+		mv.WithKeyValidator(key, ruleKeyValidator(validate, rule, schema.KeyValidators[key]))
+	}
+	return mv
+}
+
+// ruleKeyValidator 将验证规则转换为键验证函数
+// 参数 next 为同一个键的自定义验证函数（可选），规则通过后执行
+func ruleKeyValidator(validate *validator.Validate, rule string, next func(value any) error) func(value any) error {
+	return func(value any) error {
+		if err := validate.Var(value, rule); err != nil {
+			return err
+		}
+		if next != nil {
+			return next(value)
+		}
+		return nil
+	}
+}
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -494,6 +495,219 @@ func TestMapValidator_ChainedMethods(t *testing.T) {
 	}
 }
 
+// TestMapValidator_SizeGuards 测试值大小、总大小和嵌套深度限制
+func TestMapValidator_SizeGuards(t *testing.T) {
+	nested := func(depth int) any {
+		var value any = "leaf"
+		for i := 0; i < depth; i++ {
+			value = map[string]any{"child": value}
+		}
+		return value
+	}
+
+	tests := []struct {
+		name      string
+		extras    map[string]any
+		validator *MapValidator
+		wantTags  []string
+	}{
+		{
+			name:      "默认限制内通过",
+			extras:    map[string]any{"name": "alice", "tags": []any{"a", "b"}, "profile": nested(3)},
+			validator: NewMapValidator(),
+		},
+		{
+			name:      "字符串超过单值限制",
+			extras:    map[string]any{"bio": strings.Repeat("x", 11)},
+			validator: NewMapValidator().WithMaxValueSize(10),
+			wantTags:  []string{"value_size"},
+		},
+		{
+			name:      "[]byte 超过单值限制",
+			extras:    map[string]any{"avatar": make([]byte, 11)},
+			validator: NewMapValidator().WithMaxValueSize(10),
+			wantTags:  []string{"value_size"},
+		},
+		{
+			name:      "嵌套值按序列化后大小计算",
+			extras:    map[string]any{"profile": map[string]any{"city": "beijing"}},
+			validator: NewMapValidator().WithMaxValueSize(10), // {"city":"beijing"} 共 18 字节
+			wantTags:  []string{"value_size"},
+		},
+		{
+			name:      "超过总大小预算",
+			extras:    map[string]any{"a": "12345", "b": "12345"},
+			validator: NewMapValidator().WithMaxValueSize(10).WithMaxTotalSize(10),
+			wantTags:  []string{"total_size"},
+		},
+		{
+			name:      "超过嵌套深度",
+			extras:    map[string]any{"profile": nested(4)},
+			validator: NewMapValidator().WithMaxDepth(3),
+			wantTags:  []string{"max_depth"},
+		},
+		{
+			name:      "负数表示不限制",
+			extras:    map[string]any{"bio": strings.Repeat("x", 100), "profile": nested(64)},
+			validator: NewMapValidator().WithMaxValueSize(-1).WithMaxTotalSize(-1).WithMaxDepth(-1),
+		},
+		{
+			name:      "超限时跳过后续验证",
+			extras:    map[string]any{"bio": strings.Repeat("x", 11)},
+			validator: NewMapValidator().WithMaxValueSize(10).WithRequiredKeys("name"),
+			wantTags:  []string{"value_size"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.validator.WithNameSpace("User.Extras").Validate(tt.extras)
+			if len(errs) != len(tt.wantTags) {
+				t.Fatalf("Validate() = %v, want tags %v", errs, tt.wantTags)
+			}
+			for i, err := range errs {
+				if err.Tag != tt.wantTags[i] {
+					t.Errorf("errs[%d].Tag = %s, want %s", i, err.Tag, tt.wantTags[i])
+				}
+			}
+		})
+	}
+}
+
+// mapRulesProduct 使用声明式 map 规则的模型
+type mapRulesProduct struct {
+	Name   string         `json:"name"`
+	Extras map[string]any `json:"extras"`
+}
+
+const (
+	mapSceneCreate ValidateScene = 1 << iota
+	mapSceneUpdate
+)
+
+func (p *mapRulesProduct) ProvideMapRules() map[ValidateScene]map[string]MapSchema {
+	return map[ValidateScene]map[string]MapSchema{
+		mapSceneCreate: {
+			"extras": {
+				RequiredKeys: []string{"brand"},
+				KeyRules:     map[string]string{"brand": "min=2,max=10"},
+			},
+		},
+		mapSceneUpdate: {
+			"Extras": {KeyRules: map[string]string{"brand": "omitempty,max=10"}},
+		},
+	}
+}
+
+// TestMapRuleProvider 测试模型声明的 map 规则在 Validate 中自动生效
+func TestMapRuleProvider(t *testing.T) {
+	v := New()
+
+	tests := []struct {
+		name     string
+		product  *mapRulesProduct
+		scene    ValidateScene
+		wantTags []string
+		wantNs   string
+	}{
+		{
+			name:    "创建场景通过",
+			product: &mapRulesProduct{Extras: map[string]any{"brand": "acme"}},
+			scene:   mapSceneCreate,
+		},
+		{
+			name:     "创建场景缺少必填键",
+			product:  &mapRulesProduct{Extras: map[string]any{}},
+			scene:    mapSceneCreate,
+			wantTags: []string{"required"},
+			wantNs:   "mapRulesProduct.extras.brand",
+		},
+		{
+			name:     "规则失败时保留失败的标签",
+			product:  &mapRulesProduct{Extras: map[string]any{"brand": "a"}},
+			scene:    mapSceneCreate,
+			wantTags: []string{"min"},
+			wantNs:   "mapRulesProduct.extras.brand",
+		},
+		{
+			name:    "更新场景使用更新规则",
+			product: &mapRulesProduct{Extras: map[string]any{}},
+			scene:   mapSceneUpdate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := v.Validate(tt.product, tt.scene)
+			if len(errs) != len(tt.wantTags) {
+				t.Fatalf("Validate() = %v, want tags %v", errs, tt.wantTags)
+			}
+			for i, err := range errs {
+				if err.Tag != tt.wantTags[i] || err.Namespace != tt.wantNs {
+					t.Errorf("errs[%d] = %s/%s, want %s/%s", i, err.Namespace, err.Tag, tt.wantNs, tt.wantTags[i])
+				}
+			}
+		})
+	}
+
+	// 部分验证：只在指定 map 字段时验证
+	product := &mapRulesProduct{Extras: map[string]any{}}
+	if errs := v.ValidateFields(product, mapSceneCreate, "name"); len(errs) != 0 {
+		t.Errorf("ValidateFields() without extras = %v, want no errors", errs)
+	}
+	for _, field := range []string{"extras", "Extras"} {
+		if errs := v.ValidateFields(product, mapSceneCreate, field); len(errs) != 1 {
+			t.Errorf("ValidateFields(%s) = %v, want 1 error", field, errs)
+		}
+	}
+	if errs := v.ValidateExcept(product, mapSceneCreate, "extras"); len(errs) != 0 {
+		t.Errorf("ValidateExcept() = %v, want no errors", errs)
+	}
+}
+
+// TestNewMapValidatorsFromRules 测试从声明式规则创建场景化验证器
+func TestNewMapValidatorsFromRules(t *testing.T) {
+	mvs := NewMapValidatorsFromRules(map[ValidateScene]MapSchema{
+		mapSceneCreate: {
+			RequiredKeys: []string{"brand"},
+			AllowedKeys:  []string{"brand", "warranty"},
+			KeyRules:     map[string]string{"warranty": "min=1,max=60"},
+			KeyValidators: map[string]func(value any) error{
+				"warranty": func(value any) error {
+					if value.(int)%12 != 0 {
+						return fmt.Errorf("warranty must be whole years")
+					}
+					return nil
+				},
+			},
+		},
+	})
+
+	if errs := mvs.Validate(mapSceneCreate, map[string]any{"brand": "acme", "warranty": 24}); len(errs) != 0 {
+		t.Errorf("Validate() = %v, want no errors", errs)
+	}
+	if errs := mvs.Validate(mapSceneUpdate, map[string]any{}); len(errs) != 0 {
+		t.Errorf("unmatched scene should not validate, got %v", errs)
+	}
+
+	errs := mvs.Validate(mapSceneCreate, map[string]any{"warranty": 100, "color": "red"})
+	tags := make(map[string]bool, len(errs))
+	for _, err := range errs {
+		tags[err.Tag] = true
+	}
+	for _, tag := range []string{"required", "not_allowed", "max"} {
+		if !tags[tag] {
+			t.Errorf("missing %s error in %v", tag, errs)
+		}
+	}
+
+	// 规则通过后执行自定义验证函数
+	errs = mvs.Validate(mapSceneCreate, map[string]any{"brand": "acme", "warranty": 13})
+	if len(errs) != 1 || errs[0].Tag != "custom" {
+		t.Errorf("Validate() = %v, want 1 custom error", errs)
+	}
+}
+
 // ============================================================================
 // Map 验证性能基准测试
 // ============================================================================
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"katydid-common-account/pkg/pool"
 	"strings"
 	"sync"
 )
@@ -21,15 +22,23 @@ var (
 		},
 	}
 
+	// stringBuilderMetrics 字符串构建器池的统计
+	stringBuilderMetrics = pool.NewMetrics()
+
 	// stringBuilderPool strings.Builder 对象池
 	// 用途：复用字符串构建器，减少字符串拼接时的内存分配
-	stringBuilderPool = sync.Pool{
-		New: func() interface{} {
-			return &strings.Builder{}
-		},
-	}
+	stringBuilderPool = newStringBuilderPool()
 )
 
+// newStringBuilderPool 创建字符串构建器池（超过 10KB 的构建器不归还）
+func newStringBuilderPool() *pool.StringBuilderPool {
+	return pool.NewStringBuilderPool(
+		pool.WithName("v1.strings.Builder"),
+		pool.WithMaxCap(pool.DefaultMaxBuilderCap),
+		pool.WithHooks(stringBuilderMetrics),
+	)
+}
+
 // acquireValidationContext 从对象池获取 ValidationContext
 // 使用后必须调用 releaseValidationContext 归还
 // 参数：
@@ -75,26 +84,14 @@ func releaseValidationContext(ctx *ValidationContext) {
 // 返回：
 //   - 已重置的 strings.Builder 实例
 func acquireStringBuilder() *strings.Builder {
-	sb := stringBuilderPool.Get().(*strings.Builder)
-	sb.Reset()
-	return sb
+	return stringBuilderPool.Get()
 }
 
 // releaseStringBuilder 将 strings.Builder 归还到对象池
+// 说明：超过 10KB 的 Builder 不归还到池中，防止内存泄漏
 // 参数：
 //   - sb: 待归还的 strings.Builder
 func releaseStringBuilder(sb *strings.Builder) {
-	if sb == nil {
-		return
-	}
-
-	// 防止内存泄漏：重置过大的 Builder
-	if sb.Cap() > 10*1024 { // 超过 10KB
-		// 不归还到池中，让其被 GC 回收
-		return
-	}
-
-	sb.Reset()
 	stringBuilderPool.Put(sb)
 }
 
@@ -139,13 +136,13 @@ type PoolStats struct {
 }
 
 // GetPoolStats 获取对象池统计信息
-// 注意：sync.Pool 不提供精确的统计信息，这里只是估算
+// 注意：只有 StringBuilder 池（基于 pkg/pool）提供统计，其余为 sync.Pool，返回 0
 // 返回：
 //   - 对象池统计信息
 func GetPoolStats() PoolStats {
-	// sync.Pool 不提供统计信息，返回空结构
-	// 实际生产环境可以使用自定义的带统计功能的对象池
-	return PoolStats{}
+	return PoolStats{
+		StringBuilderPoolHits: int64(stringBuilderMetrics.Hits()),
+	}
 }
 
 // ResetPools 重置所有对象池
@@ -160,9 +157,6 @@ func ResetPools() {
 		},
 	}
 
-	stringBuilderPool = sync.Pool{
-		New: func() interface{} {
-			return &strings.Builder{}
-		},
-	}
+	stringBuilderPool = newStringBuilderPool()
+	stringBuilderMetrics.Reset()
 }
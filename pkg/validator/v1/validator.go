@@ -1,12 +1,14 @@
 package v1
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 	"strings"
 	"sync"
 
+	"katydid-common-account/pkg/types"
+	"katydid-common-account/pkg/validator/bridge"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -180,6 +182,21 @@ type typeCache struct {
 
 	// validationRules 缓存的验证规则（来自 RuleValidator）
 	validationRules map[ValidateScene]map[string]string
+
+	// mapValidators 缓存的 map 字段验证器（来自 MapRuleProvider）
+	mapValidators []mapFieldValidators
+}
+
+// mapFieldValidators 单个 map 字段的场景化验证器
+type mapFieldValidators struct {
+	// fieldName Go 字段名
+	fieldName string
+
+	// jsonName 字段的 JSON 名称（ValidateFields/ValidateExcept 按两种名称匹配）
+	jsonName string
+
+	// validators 场景化的 Map 验证器集合
+	validators *MapValidators
 }
 
 var (
@@ -257,6 +274,11 @@ func New() *Validator {
 		return name
 	})
 
+	// 注册手机号规则：phone=CN 表示中国大陆手机号，不带参数时接受任意地区
+	_ = v.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+		return fl.Field().Kind() == reflect.String && types.IsValidPhone(fl.Field().String(), fl.Param())
+	})
+
 	return &Validator{
 		validate:        v,
 		typeCache:       &sync.Map{},
@@ -380,6 +402,13 @@ func (v *Validator) Validate(obj any, scene ValidateScene) []*FieldError {
 		v.validateStructRules(obj, scene, ctx)
 	}
 
+	// ========================================================================
+	// 步骤5: 验证 map 字段（来自 MapRuleProvider 的声明式规则）
+	// ========================================================================
+	if len(cache.mapValidators) > 0 {
+		v.validateMapFields(obj, cache.mapValidators, ctx, nil, nil)
+	}
+
 	// 返回验证结果（需要复制错误列表，因为 ctx 会被归还到对象池）
 	return v.buildValidationResult(ctx)
 }
@@ -433,6 +462,11 @@ func (v *Validator) ValidateFields(obj any, scene ValidateScene, fields ...strin
 		v.validatePartialFieldsByTags(obj, ctx, fieldSet)
 	}
 
+	// map 字段只在被指定时验证
+	if len(cache.mapValidators) > 0 {
+		v.validateMapFields(obj, cache.mapValidators, ctx, fieldSet, nil)
+	}
+
 	// 注意：部分验证不执行 CustomValidator 和嵌套验证
 	// 因为这些验证可能依赖未验证的字段
 
@@ -498,6 +532,11 @@ func (v *Validator) ValidateExcept(obj any, scene ValidateScene, excludeFields .
 	// 递归验证嵌套结构（不受字段排除影响）
 	v.validateNestedStructs(obj, ctx, 0)
 
+	// 验证未排除的 map 字段
+	if len(cache.mapValidators) > 0 {
+		v.validateMapFields(obj, cache.mapValidators, ctx, nil, excludeSet)
+	}
+
 	return v.buildValidationResult(ctx)
 }
 
@@ -823,6 +862,118 @@ func (v *Validator) validateStructRules(obj any, scene ValidateScene, ctx *Valid
 	customValidator.CustomValidation(scene, report)
 }
 
+// buildMapValidators 将 MapRuleProvider 的规则构建为 map 字段验证器（每个类型只构建一次）
+// 命名空间与其他字段错误一致：类型名 + 字段的 JSON 名称（如 Product.extras）
+//
+// 参数：
+//
+//	typ: 对象类型
+//	rules: 场景化的 map 字段规则
+//
+// 返回：
+//
+//	各 map 字段的场景化验证器，规则中的字段不存在或不是 map 时忽略
+func (v *Validator) buildMapValidators(typ reflect.Type, rules map[ValidateScene]map[string]MapSchema) []mapFieldValidators {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if len(rules) == 0 || typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	// 按 Go 字段名重新分组：字段 -> 场景 -> 规则（规则中的字段名可以是 JSON 名称）
+	byField := make(map[string]map[ValidateScene]MapSchema)
+	for scene, fields := range rules {
+		for name, schema := range fields {
+			field, ok := structFieldByName(typ, name)
+			if !ok || field.Type.Kind() != reflect.Map {
+				continue
+			}
+			if byField[field.Name] == nil {
+				byField[field.Name] = make(map[ValidateScene]MapSchema)
+			}
+			byField[field.Name][scene] = schema
+		}
+	}
+
+	validators := make([]mapFieldValidators, 0, len(byField))
+	for fieldName, sceneRules := range byField {
+		namespace := bridge.JSONNamespace(typ, typ.Name()+"."+fieldName)
+		validators = append(validators, mapFieldValidators{
+			fieldName:  fieldName,
+			jsonName:   namespace[len(typ.Name())+1:],
+			validators: newMapValidatorsFromRules(sceneRules, v.validate, namespace),
+		})
+	}
+	return validators
+}
+
+// structFieldByName 按 Go 字段名或 JSON 名称查找字段
+func structFieldByName(typ reflect.Type, name string) (reflect.StructField, bool) {
+	if field, ok := typ.FieldByName(name); ok {
+		return field, true
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if strings.SplitN(field.Tag.Get("json"), ",", 2)[0] == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// validateMapFields 验证 map 字段
+// 参数：
+//
+//	obj: 待验证的对象
+//	validators: 各 map 字段的场景化验证器
+//	ctx: 验证上下文
+//	fieldSet: 只验证这些字段（nil 表示不限制，支持 Go 字段名和 JSON 名称）
+//	excludeSet: 跳过这些字段（nil 表示不排除，支持 Go 字段名和 JSON 名称）
+func (v *Validator) validateMapFields(obj any, validators []mapFieldValidators, ctx *ValidationContext, fieldSet, excludeSet map[string]bool) {
+	val := reflect.ValueOf(obj)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return
+	}
+
+	for _, mfv := range validators {
+		if len(ctx.Errors) >= maxValidationErrors {
+			return
+		}
+		if fieldSet != nil && !fieldSet[mfv.fieldName] && !fieldSet[mfv.jsonName] {
+			continue
+		}
+		if excludeSet[mfv.fieldName] || excludeSet[mfv.jsonName] {
+			continue
+		}
+
+		field := val.FieldByName(mfv.fieldName)
+		if !field.IsValid() || !field.CanInterface() {
+			continue
+		}
+
+		kvs, ok := field.Interface().(map[string]any)
+		if !ok && field.Type().ConvertibleTo(mapStringAnyType) {
+			// 命名类型（如 type Extras map[string]any）
+			kvs, ok = field.Convert(mapStringAnyType).Interface().(map[string]any)
+		}
+		if !ok {
+			continue
+		}
+
+		ctx.AddErrors(mfv.validators.Validate(ctx.Scene, kvs))
+	}
+}
+
+// mapStringAnyType map[string]any 的反射类型
+var mapStringAnyType = reflect.TypeOf(map[string]any(nil))
+
 // buildValidationResult 构建验证结果
 // 需要复制错误列表，因为 ctx 来自对象池会被归还
 // 内存优化：使用对象池减少切片分配
@@ -934,6 +1085,9 @@ func (v *Validator) getOrCacheTypeInfo(obj any) *typeCache {
 		cache.validationRules = ruleValidator.RuleValidation()
 	}
 	_, cache.isCustomValidator = obj.(CustomValidator)
+	if mapRuleProvider, ok := obj.(MapRuleProvider); ok {
+		cache.mapValidators = v.buildMapValidators(typ, mapRuleProvider.ProvideMapRules())
+	}
 
 	// 存入缓存（使用 LoadOrStore 避免并发时的重复存储）
 	actual, _ := v.typeCache.LoadOrStore(typ, cache)
@@ -944,10 +1098,10 @@ func (v *Validator) getOrCacheTypeInfo(obj any) *typeCache {
 // 适配器模式：将底层验证器的错误转换为内部错误类型
 // 参数：
 //
-//	obj: 验证的对象（未使用，保留用于未来扩展）
+//	obj: 验证的对象，用于将命名空间转换为 JSON 名称
 //	err: 底层验证器产生的错误
 //	ctx: 验证上下文
-func (v *Validator) addFieldErrors(_ any, err error, ctx *ValidationContext) {
+func (v *Validator) addFieldErrors(obj any, err error, ctx *ValidationContext) {
 	// 防御性编程：参数校验
 	if err == nil || ctx == nil {
 		return
@@ -958,9 +1112,8 @@ func (v *Validator) addFieldErrors(_ any, err error, ctx *ValidationContext) {
 		return
 	}
 
-	// 尝试转换为 ValidationErrors 类型
-	var validationErrors validator.ValidationErrors
-	ok := errors.As(err, &validationErrors)
+	// 通过统一桥接转换为字段错误（命名空间使用 JSON 名称）
+	fieldErrors, ok := bridge.FromValidationErrors(err, obj)
 	if !ok {
 		// 不是标准的验证错误，作为普通错误处理
 		ctx.AddErrorByDetail("", "validation_error", "", "", err.Error())
@@ -968,12 +1121,12 @@ func (v *Validator) addFieldErrors(_ any, err error, ctx *ValidationContext) {
 	}
 
 	// 逐个添加字段错误
-	for _, e := range validationErrors {
+	for _, fe := range fieldErrors {
 		// 防御性编程：防止收集过多错误
 		if len(ctx.Errors) >= maxValidationErrors {
 			return
 		}
-		ctx.AddErrorByValidator(e)
+		ctx.AddErrorByDetail(fe.Namespace, fe.Tag, fe.Param, fe.Value, fe.Message)
 	}
 }
 
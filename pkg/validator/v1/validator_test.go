@@ -35,14 +35,14 @@ func (u *TestUser) RuleValidation() map[ValidateScene]map[string]string {
 			"Email":    "required,email",
 			"Password": "required,min=6,max=20",
 			"Age":      "omitempty,gte=0,lte=150",
-			"Phone":    "omitempty,len=11,numeric",
+			"Phone":    "omitempty,phone=CN",
 		},
 		SceneUpdate: {
 			"Username": "omitempty,min=3,max=20,alphanum",
 			"Email":    "omitempty,email",
 			"Password": "omitempty,min=6,max=20",
 			"Age":      "omitempty,gte=0,lte=150",
-			"Phone":    "omitempty,len=11,numeric",
+			"Phone":    "omitempty,phone=CN",
 		},
 	}
 }
@@ -502,12 +502,12 @@ func (u *BenchmarkUser) RuleValidation() map[ValidateScene]map[string]string {
 			"Username": "required,min=3,max=20",
 			"Email":    "required,email",
 			"Password": "required,min=6",
-			"Phone":    "len=11",
+			"Phone":    "phone=CN",
 			"Age":      "gte=0,lte=150",
 		},
 		SceneUpdate: {
 			"Email": "omitempty,email",
-			"Phone": "omitempty,len=11",
+			"Phone": "omitempty,phone=CN",
 			"Age":   "omitempty,gte=0,lte=150",
 		},
 	}
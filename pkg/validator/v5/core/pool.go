@@ -1,37 +1,18 @@
 package core
 
 import (
+	"katydid-common-account/pkg/pool"
 	"strings"
-	"sync"
-)
-
-var (
-	// stringBuilderPool 字符串构建器对象池
-	stringBuilderPool = sync.Pool{
-		New: func() interface{} {
-			return &strings.Builder{}
-		},
-	}
 )
 
 // AcquireStringBuilder 从对象池获取字符串构建器
+// 说明：使用 pkg/pool 的默认池，与其他模块共享
 func AcquireStringBuilder() *strings.Builder {
-	sb := stringBuilderPool.Get().(*strings.Builder)
-	sb.Reset()
-	return sb
+	return pool.GetStringBuilder()
 }
 
 // ReleaseStringBuilder 归还字符串构建器到对象池
+// 说明：超过 10KB 的 Builder 不归还，防止内存泄漏
 func ReleaseStringBuilder(sb *strings.Builder) {
-	if sb == nil {
-		return
-	}
-
-	// 防止内存泄漏：不归还过大的Builder
-	if sb.Cap() > 10*1024 { // 超过10KB
-		return
-	}
-
-	sb.Reset()
-	stringBuilderPool.Put(sb)
+	pool.PutStringBuilder(sb)
 }
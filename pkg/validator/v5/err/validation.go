@@ -1,6 +1,10 @@
 package err
 
-import "katydid-common-account/pkg/validator/v5/core"
+import (
+	"katydid-common-account/pkg/validator/errs"
+	"katydid-common-account/pkg/validator/v5/core"
+	"strings"
+)
 
 // ValidationError 验证错误集合
 // 职责：包装多个字段错误
@@ -72,3 +76,55 @@ func (ve *ValidationError) Formatter() []string {
 
 	return formatters
 }
+
+// Unified 转换为各版本共享的统一错误结构（实现 errs.Converter 接口）
+// 说明：字段错误的消息使用格式化器生成的消息
+func (ve *ValidationError) Unified() *errs.ValidationError {
+	unified := &errs.ValidationError{
+		Message: ve.message,
+		Errors:  make([]*errs.FieldError, 0, len(ve.errors)),
+	}
+	for _, err := range ve.errors {
+		if err == nil {
+			continue
+		}
+		message := err.Message()
+		if ve.formatter != nil {
+			message = ve.formatter.Format(err)
+		}
+		unified.Errors = append(unified.Errors, ToUnifiedField(err, message))
+	}
+	return unified
+}
+
+// ToUnified 将验证错误转换为统一错误结构
+// 说明：不是本包创建的验证错误时，只保留格式化后的消息
+func ToUnified(ve core.IValidationError) *errs.ValidationError {
+	if ve == nil {
+		return nil
+	}
+	if converter, ok := ve.(errs.Converter); ok {
+		return converter.Unified()
+	}
+	return &errs.ValidationError{
+		Message: strings.Join(ve.Formatter(), "; "),
+		Errors:  []*errs.FieldError{},
+	}
+}
+
+// ToUnifiedField 将字段错误转换为统一结构
+// 参数 message 为空时使用字段错误自身的消息
+func ToUnifiedField(err core.IFieldError, message string) *errs.FieldError {
+	if message == "" {
+		message = err.Message()
+	}
+	return &errs.FieldError{
+		Namespace: err.Namespace(),
+		Field:     errs.FieldName(err.Namespace()),
+		Tag:       err.Tag(),
+		Param:     err.Param(),
+		Value:     err.Value(),
+		Message:   message,
+		Severity:  errs.SeverityError,
+	}
+}
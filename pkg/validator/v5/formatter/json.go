@@ -21,7 +21,7 @@ func (f *JSONErrorFormatter) Format(err core.IFieldError) string {
 	}
 
 	builder := core.AcquireStringBuilder()
-	core.ReleaseStringBuilder(builder)
+	defer core.ReleaseStringBuilder(builder)
 
 	builder.WriteString("{")
 	builder.WriteString(fmt.Sprintf(`"namespace":"%s"`, err.Namespace()))
@@ -46,7 +46,7 @@ func (f *JSONErrorFormatter) FormatAll(errs []core.IFieldError) string {
 	}
 
 	builder := core.AcquireStringBuilder()
-	core.ReleaseStringBuilder(builder)
+	defer core.ReleaseStringBuilder(builder)
 
 	builder.WriteString("[")
 
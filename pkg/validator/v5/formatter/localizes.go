@@ -21,7 +21,7 @@ func (f *LocalizesErrorFormatter) Format(err core.IFieldError) string {
 
 	// 生成国际化模板消息
 	builder := core.AcquireStringBuilder()
-	core.ReleaseStringBuilder(builder)
+	defer core.ReleaseStringBuilder(builder)
 
 	builder.Grow(ErrorMessageEstimatedLength / 2)
 
@@ -47,7 +47,7 @@ func (f *LocalizesErrorFormatter) FormatAll(errs []core.IFieldError) string {
 	}
 
 	builder := core.AcquireStringBuilder()
-	core.ReleaseStringBuilder(builder)
+	defer core.ReleaseStringBuilder(builder)
 
 	builder.Grow(len(errs) * (ErrorMessageEstimatedLength / 2))
 
@@ -29,7 +29,7 @@ func (f *NormalErrorFormatter) Format(err core.IFieldError) string {
 
 	// 生成普通消息
 	builder := core.AcquireStringBuilder()
-	core.ReleaseStringBuilder(builder)
+	defer core.ReleaseStringBuilder(builder)
 
 	builder.Grow(ErrorMessageEstimatedLength)
 
@@ -70,7 +70,7 @@ func (f *NormalErrorFormatter) FormatAll(errs []core.IFieldError) string {
 	}
 
 	builder := core.AcquireStringBuilder()
-	core.ReleaseStringBuilder(builder)
+	defer core.ReleaseStringBuilder(builder)
 
 	builder.Grow(len(errs) * ErrorMessageEstimatedLength)
 
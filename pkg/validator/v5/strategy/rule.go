@@ -1,13 +1,12 @@
 package strategy
 
 import (
+	"katydid-common-account/pkg/validator/bridge"
 	"katydid-common-account/pkg/validator/v5/context"
 	"katydid-common-account/pkg/validator/v5/core"
 	"katydid-common-account/pkg/validator/v5/err"
 	"reflect"
 	"strings"
-
-	"github.com/go-playground/validator/v10"
 )
 
 // RuleStrategy 规则验证策略
@@ -192,17 +191,18 @@ func (s *RuleStrategy) validateByTags(target any, rules map[string]string, ctx c
 
 // addValidationErrors 添加验证错误
 func (s *RuleStrategy) addValidationErrors(error error, ctx core.IValidationContext) bool {
-	validationErrors, ok := error.(validator.ValidationErrors)
+	// 通过统一桥接转换（已注册 JSON tag 名称函数，命名空间保持 JSON 名称）
+	fieldErrors, ok := bridge.FromValidationErrors(error, nil)
 	if !ok {
 		return ctx.AddError(err.NewFieldErrorWithMessage(error.Error()))
 	}
 
-	for _, e := range validationErrors {
+	for _, fe := range fieldErrors {
 		if !ctx.AddError(err.NewFieldError(
-			e.Namespace(), e.Tag(),
-			err.WithParam(e.Param()),
-			err.WithValue(e.Value()),
-			err.WithMessage(e.Error()),
+			fe.Namespace, fe.Tag,
+			err.WithParam(fe.Param),
+			err.WithValue(fe.Value),
+			err.WithMessage(fe.Message),
 		)) {
 			return false
 		}
@@ -0,0 +1,25 @@
+//go:build !race
+
+package v6_test
+
+import (
+	"testing"
+
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// TestValidateZeroAllocs 验证通过时（类型信息已缓存）不产生分配
+// 说明：竞态检测下对象池会随机丢弃对象，因此不在 -race 下运行
+func TestValidateZeroAllocs(t *testing.T) {
+	validator := newBenchValidator()
+	valid := &benchAccount{Username: "alice", Email: "alice@example.com", Age: 30}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := validator.Validate(valid, core.SceneAll); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("Validate allocs = %v, want 0", allocs)
+	}
+}
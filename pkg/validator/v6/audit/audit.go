@@ -0,0 +1,305 @@
+// Package audit 记录验证结果，供合规审计（如被拒绝的账户变更）
+//
+// 接收器通过 Builder.WithAuditSink 注册，每次顶层验证结束后收到结果。内置接收器在 Record 中
+// 只生成记录快照并放入缓冲区，由后台协程批量写入日志或 Webhook，不阻塞验证；
+// 缓冲区满时丢弃记录并计数（Dropped），服务退出前调用 Close 刷新缓冲区。
+package audit
+
+import (
+	"context"
+	"fmt"
+	v6context "katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ============================================================================
+// 审计记录
+// ============================================================================
+
+// FieldEntry 审计记录中的字段错误
+// 说明：不包含字段值，避免审计日志中出现密码、证件号等敏感数据
+type FieldEntry struct {
+	Namespace string `json:"namespace"`
+	Field     string `json:"field"`
+	Tag       string `json:"tag"`
+	Param     string `json:"param,omitempty"`
+	Message   string `json:"message"`
+}
+
+// Entry 一次验证的审计记录
+type Entry struct {
+	Time        time.Time    `json:"time"`
+	Model       string       `json:"model"`
+	Scene       string       `json:"scene"`
+	Rejected    bool         `json:"rejected"` // 是否存在错误（警告不算）
+	Errors      []FieldEntry `json:"errors,omitempty"`
+	RuleVersion string       `json:"rule_version,omitempty"`
+	ActorID     string       `json:"actor_id,omitempty"`
+	TenantID    string       `json:"tenant_id,omitempty"`
+	IP          string       `json:"ip,omitempty"`
+	UserAgent   string       `json:"user_agent,omitempty"`
+	RequestID   string       `json:"request_id,omitempty"`
+}
+
+// NewEntry 生成审计记录快照
+// 说明：操作人、租户等从 Go 上下文中的请求元数据读取（见 v6.WithRequestMetadata）
+func NewEntry(ctx context.Context, model string, scene core.Scene, result core.IValidationError) Entry {
+	entry := Entry{
+		Time:  time.Now(),
+		Model: model,
+		Scene: scene.String(),
+	}
+	if md, ok := v6context.RequestMetadataFromGoContext(ctx); ok {
+		entry.ActorID = md.ActorID
+		entry.TenantID = md.TenantID
+		entry.IP = md.IP
+		entry.UserAgent = md.UserAgent
+		entry.RequestID = md.RequestID
+	}
+	if result == nil {
+		return entry
+	}
+
+	entry.RuleVersion = result.RuleVersion()
+	if !result.HasErrors() {
+		return entry
+	}
+	entry.Rejected = true
+	fieldErrors := result.FieldErrors()
+	entry.Errors = make([]FieldEntry, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		entry.Errors[i] = FieldEntry{
+			Namespace: fe.Namespace(),
+			Field:     fe.Field(),
+			Tag:       fe.Tag(),
+			Param:     fe.Param(),
+			Message:   fe.Message(),
+		}
+	}
+	return entry
+}
+
+// ============================================================================
+// 异步缓冲接收器
+// ============================================================================
+
+const (
+	defaultBufferSize = 1024
+	defaultBatchSize  = 100
+)
+
+// WriteFunc 批量写入审计记录（由后台协程串行调用）
+// 说明：ctx 在 Close 超时后取消，实现应尽快返回
+type WriteFunc func(ctx context.Context, entries []Entry) error
+
+// Sink 异步缓冲审计接收器
+// 职责：实现 IAuditSink，Record 只生成快照放入缓冲区，后台协程批量调用 WriteFunc
+type Sink struct {
+	config
+	write   WriteFunc
+	entries chan Entry
+	done    chan struct{}
+	cancel  context.CancelFunc
+	dropped atomic.Int64
+
+	mu     sync.RWMutex // 保护 closed 与发送，避免向已关闭的通道发送
+	closed bool
+}
+
+// NewSink 创建异步缓冲审计接收器，write 写入自定义目的地（如审计表）
+func NewSink(write WriteFunc, opts ...Option) *Sink {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Sink{
+		config:  cfg,
+		write:   write,
+		entries: make(chan Entry, cfg.bufferSize),
+		done:    make(chan struct{}),
+		cancel:  cancel,
+	}
+	go s.run(ctx)
+	return s
+}
+
+// Record 实现 IAuditSink 接口
+// 说明：缓冲区满或已关闭时丢弃记录，不阻塞验证
+func (s *Sink) Record(ctx context.Context, model string, scene core.Scene, result core.IValidationError) {
+	if s.rejectedOnly && (result == nil || !result.HasErrors()) {
+		return
+	}
+	entry := NewEntry(ctx, model, scene, result)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		s.dropped.Add(1)
+		return
+	}
+	select {
+	case s.entries <- entry:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Dropped 因缓冲区满或已关闭而丢弃的记录数
+func (s *Sink) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close 停止接收记录并等待缓冲区写完
+// 说明：ctx 到期时取消正在进行的写入（如 Webhook 重试）并返回 ctx.Err()；可重复调用
+func (s *Sink) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.entries)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-s.done
+		return ctx.Err()
+	}
+}
+
+// run 后台协程：取出缓冲区中已有的记录（最多 batchSize 条）批量写入
+func (s *Sink) run(ctx context.Context) {
+	defer close(s.done)
+	defer s.cancel()
+
+	batch := make([]Entry, 0, s.batchSize)
+	for entry := range s.entries {
+		batch = append(batch[:0], entry)
+	fill:
+		for len(batch) < s.batchSize {
+			select {
+			case next, ok := <-s.entries:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, next)
+			default:
+				break fill
+			}
+		}
+
+		if err := s.safeWrite(ctx, batch); err != nil && s.onError != nil {
+			s.onError(err, len(batch))
+		}
+	}
+}
+
+// safeWrite 调用 WriteFunc，panic 转换为错误（后台协程退出会导致 Close 无法返回）
+func (s *Sink) safeWrite(ctx context.Context, batch []Entry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("audit: write panic: %v", r)
+		}
+	}()
+	return s.write(ctx, batch)
+}
+
+// ============================================================================
+// 选项
+// ============================================================================
+
+// config 接收器配置（Webhook 相关字段只对 NewWebhookSink 生效）
+type config struct {
+	bufferSize   int
+	batchSize    int
+	rejectedOnly bool
+	onError      func(err error, entries int)
+
+	client     *http.Client
+	headers    http.Header
+	maxRetries int
+	backoff    time.Duration
+}
+
+// defaultConfig 默认配置
+func defaultConfig() config {
+	return config{
+		bufferSize: defaultBufferSize,
+		batchSize:  defaultBatchSize,
+		client:     &http.Client{Timeout: defaultWebhookTimeout},
+		headers:    make(http.Header),
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+	}
+}
+
+// Option 接收器选项
+type Option func(*config)
+
+// WithBufferSize 设置缓冲区容量（默认 1024），缓冲区满时丢弃新记录
+func WithBufferSize(size int) Option {
+	return func(c *config) {
+		if size > 0 {
+			c.bufferSize = size
+		}
+	}
+}
+
+// WithBatchSize 设置每次写入的最大记录数（默认 100）
+func WithBatchSize(size int) Option {
+	return func(c *config) {
+		if size > 0 {
+			c.batchSize = size
+		}
+	}
+}
+
+// WithRejectedOnly 只记录验证失败的结果
+func WithRejectedOnly() Option {
+	return func(c *config) {
+		c.rejectedOnly = true
+	}
+}
+
+// WithErrorHandler 设置写入失败的回调（重试耗尽后调用，entries 为丢失的记录数）
+func WithErrorHandler(handler func(err error, entries int)) Option {
+	return func(c *config) {
+		c.onError = handler
+	}
+}
+
+// WithHTTPClient 设置 Webhook 使用的 HTTP 客户端（默认超时 5s）
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) {
+		if client != nil {
+			c.client = client
+		}
+	}
+}
+
+// WithHeader 设置 Webhook 请求头（如 Authorization）
+func WithHeader(key, value string) Option {
+	return func(c *config) {
+		c.headers.Set(key, value)
+	}
+}
+
+// WithRetry 设置 Webhook 失败后的重试次数和首次重试间隔（之后每次翻倍），默认 3 次、500ms
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *config) {
+		if maxRetries >= 0 {
+			c.maxRetries = maxRetries
+		}
+		if backoff > 0 {
+			c.backoff = backoff
+		}
+	}
+}
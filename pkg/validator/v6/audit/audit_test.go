@@ -0,0 +1,238 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	v6context "katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	v6errors "katydid-common-account/pkg/validator/v6/errors"
+	"katydid-common-account/pkg/validator/v6/v6test"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// 测试数据
+// ============================================================================
+
+// rejected 一个字段错误的验证结果
+func rejected() core.IValidationError {
+	return v6test.NewResult(v6test.NewError("User.password", "min", v6errors.WithParam("8"), v6errors.WithValue("secret")))
+}
+
+// memorySink 记录写入内容的接收器
+type memorySink struct {
+	mu      sync.Mutex
+	entries []Entry
+	batches int
+}
+
+func (m *memorySink) write(_ context.Context, entries []Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = append(m.entries, entries...)
+	m.batches++
+	return nil
+}
+
+// closeSink 关闭接收器并等待缓冲区写完
+func closeSink(t *testing.T, sink *Sink) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sink.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
+
+// ============================================================================
+// 测试
+// ============================================================================
+
+func TestNewEntry(t *testing.T) {
+	ctx := v6context.WithRequestMetadata(context.Background(), core.RequestMetadata{ActorID: "u-1", RequestID: "req-9"})
+	entry := NewEntry(ctx, "User", core.SceneAll, rejected())
+
+	if !entry.Rejected || entry.Model != "User" || entry.ActorID != "u-1" || entry.RequestID != "req-9" {
+		t.Errorf("entry = %+v", entry)
+	}
+	want := FieldEntry{Namespace: "User.password", Field: "password", Tag: "min", Param: "8"}
+	if len(entry.Errors) != 1 || entry.Errors[0].Tag != want.Tag || entry.Errors[0].Param != want.Param || entry.Errors[0].Namespace != want.Namespace {
+		t.Errorf("errors = %+v", entry.Errors)
+	}
+
+	// 不包含字段值
+	data, _ := json.Marshal(entry)
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("审计记录包含字段值: %s", data)
+	}
+
+	if passed := NewEntry(context.Background(), "User", core.SceneAll, nil); passed.Rejected || passed.Errors != nil {
+		t.Errorf("passed entry = %+v", passed)
+	}
+}
+
+func TestSink_RecordAndClose(t *testing.T) {
+	mem := &memorySink{}
+	sink := NewSink(mem.write, WithBatchSize(10))
+	for i := 0; i < 25; i++ {
+		sink.Record(context.Background(), "User", core.SceneAll, rejected())
+	}
+	sink.Record(context.Background(), "User", core.SceneAll, nil)
+	closeSink(t, sink)
+
+	if len(mem.entries) != 26 {
+		t.Errorf("entries = %d, want 26", len(mem.entries))
+	}
+	if mem.entries[25].Rejected {
+		t.Error("验证通过的记录 Rejected 应为 false")
+	}
+
+	// 关闭后丢弃
+	sink.Record(context.Background(), "User", core.SceneAll, rejected())
+	if sink.Dropped() != 1 {
+		t.Errorf("Dropped() = %d, want 1", sink.Dropped())
+	}
+	closeSink(t, sink) // 可重复调用
+}
+
+func TestSink_RejectedOnly(t *testing.T) {
+	mem := &memorySink{}
+	sink := NewSink(mem.write, WithRejectedOnly())
+	sink.Record(context.Background(), "User", core.SceneAll, nil)
+	sink.Record(context.Background(), "User", core.SceneAll, v6test.NewResult())
+	sink.Record(context.Background(), "User", core.SceneAll, rejected())
+	closeSink(t, sink)
+
+	if len(mem.entries) != 1 || !mem.entries[0].Rejected {
+		t.Errorf("entries = %+v", mem.entries)
+	}
+}
+
+func TestSink_DropWhenFull(t *testing.T) {
+	release := make(chan struct{})
+	var written atomic.Int64
+	sink := NewSink(func(_ context.Context, entries []Entry) error {
+		<-release
+		written.Add(int64(len(entries)))
+		return nil
+	}, WithBufferSize(2), WithBatchSize(1))
+
+	// 后台协程阻塞在第一条记录上，缓冲区再放两条，其余丢弃
+	for i := 0; i < 10; i++ {
+		sink.Record(context.Background(), "User", core.SceneAll, rejected())
+	}
+	close(release)
+	closeSink(t, sink)
+
+	if got := written.Load() + sink.Dropped(); got != 10 {
+		t.Errorf("written + dropped = %d, want 10", got)
+	}
+	if sink.Dropped() < 7 {
+		t.Errorf("Dropped() = %d, want >= 7", sink.Dropped())
+	}
+}
+
+func TestSink_WritePanic(t *testing.T) {
+	var failed atomic.Int64
+	sink := NewSink(func(context.Context, []Entry) error {
+		panic("boom")
+	}, WithErrorHandler(func(err error, entries int) { failed.Add(int64(entries)) }))
+	sink.Record(context.Background(), "User", core.SceneAll, rejected())
+	closeSink(t, sink)
+
+	if failed.Load() != 1 {
+		t.Errorf("failed = %d, want 1", failed.Load())
+	}
+}
+
+func TestLogSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewLogSink(log.New(&buf, "", 0))
+	sink.Record(context.Background(), "User", core.SceneAll, rejected())
+	closeSink(t, sink)
+
+	line := strings.TrimSpace(buf.String())
+	payload, ok := strings.CutPrefix(line, "validation audit ")
+	if !ok {
+		t.Fatalf("log line = %q", line)
+	}
+	var entry Entry
+	if err := json.Unmarshal([]byte(payload), &entry); err != nil || entry.Model != "User" || !entry.Rejected {
+		t.Errorf("entry = %+v, err = %v", entry, err)
+	}
+}
+
+func TestWebhookSink_Retry(t *testing.T) {
+	var attempts atomic.Int64
+	var received []Entry
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, WithHeader("Authorization", "Bearer token"), WithRetry(3, time.Millisecond))
+	sink.Record(context.Background(), "User", core.SceneAll, rejected())
+	closeSink(t, sink)
+
+	if attempts.Load() != 3 || len(received) != 1 || received[0].Model != "User" {
+		t.Errorf("attempts = %d, received = %+v", attempts.Load(), received)
+	}
+}
+
+func TestWebhookSink_NoRetryOnClientError(t *testing.T) {
+	var attempts atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	var lastErr error
+	var mu sync.Mutex
+	sink := NewWebhookSink(server.URL, WithRetry(3, time.Millisecond), WithErrorHandler(func(err error, _ int) {
+		mu.Lock()
+		lastErr = err
+		mu.Unlock()
+	}))
+	sink.Record(context.Background(), "User", core.SceneAll, rejected())
+	closeSink(t, sink)
+
+	var werr *webhookError
+	if attempts.Load() != 1 || !errors.As(lastErr, &werr) || werr.status != http.StatusBadRequest {
+		t.Errorf("attempts = %d, err = %v", attempts.Load(), lastErr)
+	}
+}
+
+func TestWebhookSink_CloseTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, WithRetry(10, time.Hour))
+	sink.Record(context.Background(), "User", core.SceneAll, rejected())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := sink.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Close() error = %v, want DeadlineExceeded", err)
+	}
+}
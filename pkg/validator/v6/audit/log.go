@@ -0,0 +1,29 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+)
+
+// ============================================================================
+// 日志接收器
+// ============================================================================
+
+// NewLogSink 创建日志审计接收器，每条记录写为一行 JSON
+// 说明：logger 为 nil 时使用 log.Default()；由日志采集系统转存到审计存储
+func NewLogSink(logger *log.Logger, opts ...Option) *Sink {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return NewSink(func(_ context.Context, entries []Entry) error {
+		for _, entry := range entries {
+			line, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			logger.Printf("validation audit %s", line)
+		}
+		return nil
+	}, opts...)
+}
@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ============================================================================
+// Webhook 接收器
+// ============================================================================
+
+const (
+	defaultWebhookTimeout = 5 * time.Second
+	defaultMaxRetries     = 3
+	defaultBackoff        = 500 * time.Millisecond
+)
+
+// webhookError Webhook 返回非 2xx 状态码
+type webhookError struct {
+	status    int
+	retryable bool
+}
+
+// Error 实现 error 接口
+func (e *webhookError) Error() string {
+	return fmt.Sprintf("audit: webhook returned status %d", e.status)
+}
+
+// NewWebhookSink 创建 Webhook 审计接收器
+// 说明：
+//   - 每批记录以 JSON 数组 POST 到 url（Content-Type: application/json）
+//   - 网络错误、429 和 5xx 按 WithRetry 重试（间隔翻倍），其他 4xx 不重试；重试耗尽后调用 WithErrorHandler
+//   - 认证信息通过 WithHeader 设置（如 Authorization: Bearer ...）
+func NewWebhookSink(url string, opts ...Option) *Sink {
+	// 最后一个选项取得应用全部选项后的配置（在后台协程启动前执行）
+	var cfg config
+	opts = append(opts[:len(opts):len(opts)], func(c *config) { cfg = *c })
+
+	return NewSink(func(ctx context.Context, entries []Entry) error {
+		body, err := json.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		return postWithRetry(ctx, &cfg, url, body)
+	}, opts...)
+}
+
+// postWithRetry 发送请求，失败时按指数退避重试
+func postWithRetry(ctx context.Context, cfg *config, url string, body []byte) error {
+	backoff := cfg.backoff
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = post(ctx, cfg, url, body); err == nil {
+			return nil
+		}
+		if werr, ok := err.(*webhookError); ok && !werr.retryable {
+			return err
+		}
+		if attempt >= cfg.maxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// post 发送一次请求
+func post(ctx context.Context, cfg *config, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range cfg.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := cfg.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return &webhookError{
+		status:    resp.StatusCode,
+		retryable: resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500,
+	}
+}
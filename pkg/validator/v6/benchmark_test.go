@@ -0,0 +1,64 @@
+package v6_test
+
+import (
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// benchAccount 基准测试模型（规则为包级变量，避免模型自身的分配）
+type benchAccount struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Age      int    `json:"age"`
+}
+
+var benchAccountRules = map[string]string{
+	"username": "required,min=3,max=20",
+	"email":    "required,email",
+	"age":      "gte=18,lte=120",
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (a *benchAccount) ValidateRules(_ core.Scene) map[string]string {
+	return benchAccountRules
+}
+
+// ValidateBusiness 实现 IBusinessValidator 接口
+func (a *benchAccount) ValidateBusiness(_ core.Scene, collector core.IErrorCollector) {
+	if a.Username == "admin" {
+		collector.Collect(v6.NewFieldError("benchAccount.Username", "username", "duplicate"))
+	}
+}
+
+func newBenchValidator() core.IValidator {
+	return v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithBusinessStrategy(20).
+		Build()
+}
+
+func BenchmarkValidate(b *testing.B) {
+	validator := newBenchValidator()
+	valid := &benchAccount{Username: "alice", Email: "alice@example.com", Age: 30}
+	invalid := &benchAccount{Username: "admin", Email: "invalid", Age: 10}
+
+	b.Run("valid", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := validator.Validate(valid, core.SceneAll); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("invalid", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := validator.Validate(invalid, core.SceneAll); err == nil {
+				b.Fatal("expected errors")
+			}
+		}
+	})
+}
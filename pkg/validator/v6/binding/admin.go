@@ -0,0 +1,329 @@
+package binding
+
+import (
+	"katydid-common-account/pkg/validator/v6/core"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// 验证耗时记录
+// ============================================================================
+
+// defaultLatencySamples 默认保留的耗时样本数
+const defaultLatencySamples = 1024
+
+// LatencyStats 验证耗时分位数
+type LatencyStats struct {
+	Count   int64         `json:"count"`   // 累计验证次数
+	Samples int           `json:"samples"` // 参与计算的样本数（最近 N 次）
+	P50     time.Duration `json:"p50"`
+	P90     time.Duration `json:"p90"`
+	P99     time.Duration `json:"p99"`
+	Max     time.Duration `json:"max"`
+}
+
+// LatencyRecorder 验证耗时记录器
+// 职责：作为监听器注册到 Builder，用环形缓冲区保存最近 N 次验证的耗时，供管理端计算分位数
+// 说明：只在收到 ValidationEnd 事件时加锁写入一个样本，分位数在查看时才排序计算
+type LatencyRecorder struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	full    bool
+	count   int64
+}
+
+// NewLatencyRecorder 创建验证耗时记录器
+// 参数：size 为保留的样本数，<= 0 时使用默认值 1024
+func NewLatencyRecorder(size int) *LatencyRecorder {
+	if size <= 0 {
+		size = defaultLatencySamples
+	}
+	return &LatencyRecorder{samples: make([]time.Duration, size)}
+}
+
+// OnValidationStart 实现 IValidationListener 接口
+func (r *LatencyRecorder) OnValidationStart(core.IContext, any) {}
+
+// OnValidationEnd 实现 IValidationListener 接口（耗时从 OnEvent 获取）
+func (r *LatencyRecorder) OnValidationEnd(core.IContext, any, error) {}
+
+// OnError 实现 IValidationListener 接口
+func (r *LatencyRecorder) OnError(core.IContext, core.IFieldError) {}
+
+// OnEvent 实现 IEventListener 接口
+func (r *LatencyRecorder) OnEvent(_ core.IContext, event core.Event) {
+	if event.Type == core.EventTypeValidationEnd {
+		r.Record(event.Duration)
+	}
+}
+
+// Record 记录一次验证耗时
+func (r *LatencyRecorder) Record(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples[r.next] = d
+	r.next++
+	if r.next == len(r.samples) {
+		r.next = 0
+		r.full = true
+	}
+	r.count++
+}
+
+// Stats 计算最近 N 次验证的耗时分位数
+func (r *LatencyRecorder) Stats() LatencyStats {
+	r.mu.Lock()
+	n := r.next
+	if r.full {
+		n = len(r.samples)
+	}
+	samples := make([]time.Duration, n)
+	copy(samples, r.samples[:n])
+	stats := LatencyStats{Count: r.count, Samples: n}
+	r.mu.Unlock()
+
+	if n == 0 {
+		return stats
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i] < samples[j]
+	})
+	stats.P50 = percentile(samples, 0.50)
+	stats.P90 = percentile(samples, 0.90)
+	stats.P99 = percentile(samples, 0.99)
+	stats.Max = samples[n-1]
+	return stats
+}
+
+// Reset 清空已记录的样本
+func (r *LatencyRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clear(r.samples)
+	r.next, r.full, r.count = 0, false, 0
+}
+
+// percentile 最近秩法计算分位数（samples 已升序排序）
+func percentile(samples []time.Duration, p float64) time.Duration {
+	index := int(math.Ceil(p*float64(len(samples)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return samples[index]
+}
+
+// ============================================================================
+// 管理端点
+// ============================================================================
+
+// AdminOption 管理端点选项
+type AdminOption func(*adminHandler)
+
+// WithLatencyRecorder 设置验证耗时记录器（需同时通过 Builder.WithListener 注册）
+func WithLatencyRecorder(recorder *LatencyRecorder) AdminOption {
+	return func(h *adminHandler) {
+		h.latency = recorder
+	}
+}
+
+// adminHandler 管理端点处理器
+type adminHandler struct {
+	registry  *ModelRegistry
+	validator core.IValidator
+	latency   *LatencyRecorder
+}
+
+// modelView 模型查看视图
+type modelView struct {
+	Name   string                       `json:"name"`
+	Type   string                       `json:"type"`
+	Routes []string                     `json:"routes"`
+	Scenes map[string]core.Scene        `json:"scenes"`
+	Rules  map[string]map[string]string `json:"rules"`
+}
+
+// cacheView 类型缓存统计视图
+type cacheView struct {
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	Size    int     `json:"size"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// pluginView 插件视图
+type pluginView struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// summaryView 管理端汇总视图
+type summaryView struct {
+	Models    []modelView   `json:"models"`
+	Cache     *cacheView    `json:"cache,omitempty"`
+	Plugins   []pluginView  `json:"plugins"`
+	Listeners []string      `json:"listeners"`
+	Latency   *LatencyStats `json:"latency,omitempty"`
+}
+
+// AdminHandler 创建调试/管理端点
+// 说明：
+//   - 只读，只接受 GET；建议挂载在内网或加鉴权的路径下
+//   - validator 为 Builder 构建的验证器时可查看缓存统计、插件与监听器，为 nil 时只提供模型信息
+//
+// 路由（相对挂载路径）：
+//   - /              汇总以下全部信息
+//   - /models        已注册模型及各场景下的规则
+//   - /models/{name} 单个模型
+//   - /cache         类型信息缓存统计与命中率
+//   - /plugins       插件及启用状态
+//   - /listeners     监听器类型
+//   - /latency       最近验证耗时的 p50 / p90 / p99
+func AdminHandler(registry *ModelRegistry, validator core.IValidator, opts ...AdminOption) http.Handler {
+	h := &adminHandler{registry: registry, validator: validator}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// MountAdmin 将管理端点挂载到 mux 的 prefix 路径下，如 MountAdmin(mux, "/debug/validator", handler)
+func MountAdmin(mux *http.ServeMux, prefix string, handler http.Handler) {
+	prefix = "/" + strings.Trim(prefix, "/")
+	if prefix == "/" {
+		mux.Handle("/", handler)
+		return
+	}
+	mux.Handle(prefix, http.StripPrefix(prefix, handler))
+	mux.Handle(prefix+"/", http.StripPrefix(prefix, handler))
+}
+
+// ServeHTTP 实现 http.Handler 接口
+func (h *adminHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	path := strings.Trim(req.URL.Path, "/")
+	switch {
+	case path == "":
+		writeJSON(w, http.StatusOK, summaryView{
+			Models:    modelViews(h.registry),
+			Cache:     h.cache(),
+			Plugins:   h.plugins(),
+			Listeners: h.listeners(),
+			Latency:   h.latencyStats(),
+		})
+	case path == "models":
+		writeJSON(w, http.StatusOK, modelViews(h.registry))
+	case strings.HasPrefix(path, "models/"):
+		info, ok := h.registry.Get(strings.TrimPrefix(path, "models/"))
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "model not found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, newModelView(info))
+	case path == "cache":
+		cache := h.cache()
+		if cache == nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "cache stats not available"})
+			return
+		}
+		writeJSON(w, http.StatusOK, cache)
+	case path == "plugins":
+		writeJSON(w, http.StatusOK, h.plugins())
+	case path == "listeners":
+		writeJSON(w, http.StatusOK, h.listeners())
+	case path == "latency":
+		latency := h.latencyStats()
+		if latency == nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "latency recorder not configured"})
+			return
+		}
+		writeJSON(w, http.StatusOK, latency)
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "not found"})
+	}
+}
+
+// cache 类型信息缓存统计，验证器未实现 IValidatorIntrospector 时为 nil
+func (h *adminHandler) cache() *cacheView {
+	introspector, ok := h.validator.(core.IValidatorIntrospector)
+	if !ok {
+		return nil
+	}
+
+	stats := introspector.CacheStats()
+	view := &cacheView{Hits: stats.Hits, Misses: stats.Misses, Size: stats.Size}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		view.HitRate = float64(stats.Hits) / float64(total)
+	}
+	return view
+}
+
+// plugins 插件及启用状态
+func (h *adminHandler) plugins() []pluginView {
+	manager, ok := h.validator.(core.IPluginManager)
+	if !ok {
+		return []pluginView{}
+	}
+
+	names := manager.Plugins()
+	views := make([]pluginView, 0, len(names))
+	for _, name := range names {
+		views = append(views, pluginView{Name: name, Enabled: manager.IsPluginEnabled(name)})
+	}
+	return views
+}
+
+// listeners 监听器类型名称
+func (h *adminHandler) listeners() []string {
+	introspector, ok := h.validator.(core.IValidatorIntrospector)
+	if !ok {
+		return []string{}
+	}
+	return introspector.Listeners()
+}
+
+// latencyStats 验证耗时分位数，未配置记录器时为 nil
+func (h *adminHandler) latencyStats() *LatencyStats {
+	if h.latency == nil {
+		return nil
+	}
+	stats := h.latency.Stats()
+	return &stats
+}
+
+// modelViews 所有已注册模型的视图
+func modelViews(registry *ModelRegistry) []modelView {
+	models := registry.List()
+	views := make([]modelView, 0, len(models))
+	for _, info := range models {
+		views = append(views, newModelView(info))
+	}
+	return views
+}
+
+// newModelView 创建模型视图
+func newModelView(info *ModelInfo) modelView {
+	routes := make([]string, 0, len(info.Routes))
+	for _, route := range info.Routes {
+		routes = append(routes, route.String())
+	}
+	return modelView{
+		Name:   info.Name,
+		Type:   info.Type.String(),
+		Routes: routes,
+		Scenes: info.Scenes,
+		Rules:  info.Rules(),
+	}
+}
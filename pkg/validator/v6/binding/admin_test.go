@@ -0,0 +1,116 @@
+package binding
+
+import (
+	"encoding/json"
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+	"net/http"
+	"net/http/httptest"
+	stdtesting "testing"
+	"time"
+)
+
+// adminUser 管理端测试模型
+type adminUser struct {
+	Name string `json:"name"`
+}
+
+func (u *adminUser) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{"Name": "required"}
+}
+
+func TestLatencyRecorder_Stats(t *stdtesting.T) {
+	recorder := NewLatencyRecorder(100)
+	if stats := recorder.Stats(); stats.Samples != 0 || stats.P99 != 0 {
+		t.Fatalf("empty recorder stats = %+v", stats)
+	}
+
+	// 写满后继续写入，只保留最近 100 个样本（101..200ms）
+	for i := 1; i <= 200; i++ {
+		recorder.Record(time.Duration(i) * time.Millisecond)
+	}
+	stats := recorder.Stats()
+	want := LatencyStats{
+		Count:   200,
+		Samples: 100,
+		P50:     150 * time.Millisecond,
+		P90:     190 * time.Millisecond,
+		P99:     199 * time.Millisecond,
+		Max:     200 * time.Millisecond,
+	}
+	if stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+
+	recorder.Reset()
+	if stats := recorder.Stats(); stats.Count != 0 || stats.Samples != 0 {
+		t.Errorf("stats after reset = %+v", stats)
+	}
+}
+
+func TestAdminHandler(t *stdtesting.T) {
+	registry := NewModelRegistry()
+	registry.SetMethodScene(http.MethodPost, fuzzSceneCreate)
+	registry.MustRegister("User", &adminUser{}, WithRoute(http.MethodPost, "/users"))
+
+	recorder := NewLatencyRecorder(0)
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithListener(recorder).
+		Build()
+	_ = validator.Validate(&adminUser{}, fuzzSceneCreate)
+	_ = validator.Validate(&adminUser{Name: "tom"}, fuzzSceneCreate)
+
+	mux := http.NewServeMux()
+	MountAdmin(mux, "/debug/validator/", AdminHandler(registry, validator, WithLatencyRecorder(recorder)))
+
+	get := func(path string, wantStatus int, body any) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != wantStatus {
+			t.Fatalf("GET %s status = %d, want %d: %s", path, rec.Code, wantStatus, rec.Body.String())
+		}
+		if body != nil {
+			if err := json.Unmarshal(rec.Body.Bytes(), body); err != nil {
+				t.Fatalf("GET %s: %v", path, err)
+			}
+		}
+	}
+
+	var summary summaryView
+	get("/debug/validator", http.StatusOK, &summary)
+	if len(summary.Models) != 1 || summary.Models[0].Rules[http.MethodPost]["Name"] != "required" {
+		t.Errorf("unexpected models: %+v", summary.Models)
+	}
+	if summary.Cache == nil || summary.Cache.Hits+summary.Cache.Misses == 0 {
+		t.Errorf("unexpected cache stats: %+v", summary.Cache)
+	}
+	if len(summary.Listeners) != 1 || summary.Listeners[0] != "*binding.LatencyRecorder" {
+		t.Errorf("unexpected listeners: %v", summary.Listeners)
+	}
+	if summary.Latency == nil || summary.Latency.Count != 2 {
+		t.Errorf("unexpected latency: %+v", summary.Latency)
+	}
+
+	var model modelView
+	get("/debug/validator/models/User", http.StatusOK, &model)
+	if model.Name != "User" || model.Routes[0] != "POST /users" {
+		t.Errorf("unexpected model: %+v", model)
+	}
+	get("/debug/validator/models/Order", http.StatusNotFound, nil)
+	get("/debug/validator/unknown", http.StatusNotFound, nil)
+
+	// 未提供验证器时只有模型信息
+	bare := AdminHandler(registry, nil)
+	rec := httptest.NewRecorder()
+	bare.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cache", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("cache without validator status = %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	bare.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d", rec.Code)
+	}
+}
@@ -0,0 +1,41 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"mime"
+	"net/http"
+)
+
+// ============================================================================
+// 表单验证
+// ============================================================================
+
+// DefaultMaxFormMemory 解析 multipart 表单时保存在内存中的最大字节数，超出部分写入临时文件
+const DefaultMaxFormMemory = 32 << 20
+
+// ValidateForm 解析请求中的表单并按规则验证
+// 说明：
+//   - 支持 application/x-www-form-urlencoded 与 multipart/form-data；字段值包含 URL 查询参数
+//   - 表单格式错误或验证器不支持表单验证时返回 error（通常响应 400），验证失败时返回验证错误
+//   - 文件规则（max_size、mimes、max_files）只检查元数据和内容类型，文件内容由调用方读取
+func ValidateForm(req *http.Request, validator core.IValidator, rules map[string]string) (core.IValidationError, error) {
+	fv, ok := validator.(core.IFormValidator)
+	if !ok {
+		return nil, errors.New("binding: validator does not support form validation")
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		if err := req.ParseMultipartForm(DefaultMaxFormMemory); err != nil {
+			return nil, fmt.Errorf("binding: invalid multipart form: %w", err)
+		}
+		return fv.ValidateForm(req.Form, req.MultipartForm.File, rules), nil
+	}
+
+	if err := req.ParseForm(); err != nil {
+		return nil, fmt.Errorf("binding: invalid form: %w", err)
+	}
+	return fv.ValidateForm(req.Form, nil, rules), nil
+}
@@ -0,0 +1,116 @@
+package binding
+
+import (
+	"bytes"
+	v6 "katydid-common-account/pkg/validator/v6"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	stdtesting "testing"
+)
+
+// pngHeader PNG 文件签名（http.DetectContentType 识别为 image/png）
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// avatarRules 头像上传的规则
+var avatarRules = map[string]string{
+	"nickname": "required,min=2,max=20",
+	"age":      "omitempty,gte=18",
+	"avatar":   "required,max_size=1KB,mimes=image/png image/jpeg",
+}
+
+// newUploadRequest 构造 multipart 上传请求
+func newUploadRequest(t *stdtesting.T, fields map[string]string, files map[string][]byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for name, value := range fields {
+		_ = mw.WriteField(name, value)
+	}
+	for name, content := range files {
+		// 客户端声明的 Content-Type 不可信，类型按内容检测
+		part, err := mw.CreateFormFile(name, name+".png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = part.Write(content)
+	}
+	_ = mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/avatar", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestValidateForm_Multipart(t *stdtesting.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+
+	tests := []struct {
+		name   string
+		fields map[string]string
+		files  map[string][]byte
+		want   []string // 期望的错误（命名空间.标签）
+	}{
+		{"通过", map[string]string{"nickname": "alice", "age": "25"}, map[string][]byte{"avatar": pngHeader}, nil},
+		{"缺少文件", map[string]string{"nickname": "alice"}, nil, []string{"avatar.required"}},
+		{"文件过大", map[string]string{"nickname": "alice"},
+			map[string][]byte{"avatar": append(append([]byte{}, pngHeader...), make([]byte, 2048)...)}, []string{"avatar.max_size"}},
+		{"类型不允许", map[string]string{"nickname": "alice"}, map[string][]byte{"avatar": []byte("%PDF-1.7\n")}, []string{"avatar.mimes"}},
+		{"字段错误", map[string]string{"nickname": "a", "age": "12"}, map[string][]byte{"avatar": pngHeader}, []string{"age.gte", "nickname.min"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *stdtesting.T) {
+			result, err := ValidateForm(newUploadRequest(t, tt.fields, tt.files), validator, avatarRules)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			if result != nil {
+				for _, fe := range result.FieldErrors() {
+					got = append(got, fe.Namespace()+"."+fe.Tag())
+				}
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateForm_URLEncoded(t *stdtesting.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+	rules := map[string]string{
+		"age":  "required,gte=18",
+		"tags": "required,dive,alpha",
+	}
+
+	form := url.Values{"age": {"30"}, "tags": {"go"}}
+	req := httptest.NewRequest(http.MethodPost, "/profile", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	result, err := ValidateForm(req, validator, rules)
+	if err != nil || result != nil {
+		t.Fatalf("result = %v, err = %v", result, err)
+	}
+
+	form = url.Values{"age": {"x"}, "tags": {"go", "4"}}
+	req = httptest.NewRequest(http.MethodPost, "/profile", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	result, err = ValidateForm(req, validator, rules)
+	if err != nil || result == nil || len(result.FieldErrors()) != 2 {
+		t.Fatalf("result = %v, err = %v", result, err)
+	}
+	if ns := result.FieldErrors()[1].Namespace(); ns != "tags[1]" {
+		t.Errorf("namespace = %s", ns)
+	}
+}
+
+func TestValidateForm_MalformedBody(t *stdtesting.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+	req := httptest.NewRequest(http.MethodPost, "/avatar", strings.NewReader("garbage"))
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=xyz")
+	if _, err := ValidateForm(req, validator, avatarRules); err == nil {
+		t.Error("格式错误的表单应返回 error")
+	}
+}
@@ -0,0 +1,151 @@
+package binding
+
+import (
+	"encoding/json"
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ============================================================================
+// 模糊测试入口
+// ============================================================================
+
+// 结构化错误的标签
+const (
+	TagUnknownModel = "model"  // 模型未注册
+	TagDecode       = "decode" // JSON 解码失败
+	TagPanic        = "panic"  // 解码或验证过程中发生 panic
+)
+
+// FuzzTarget 模糊测试目标
+// 职责：将 JSON 字节解码为已注册模型的新实例并验证，供 go test -fuzz 或 go-fuzz 使用
+// 设计原则：
+//   - 确定性：相同输入得到相同输出（错误按 namespace、tag 排序）
+//   - 不 panic：模型未注册、解码失败、验证 panic 都转换为结构化的字段错误
+type FuzzTarget struct {
+	registry  *ModelRegistry
+	validator core.IValidator
+}
+
+// NewFuzzTarget 创建模糊测试目标
+func NewFuzzTarget(registry *ModelRegistry, validator core.IValidator) *FuzzTarget {
+	return &FuzzTarget{registry: registry, validator: validator}
+}
+
+// ValidateBytes 解码并验证
+// 返回值：验证通过时返回 nil
+//
+// 使用示例：
+//
+//	func FuzzUser(f *testing.F) {
+//	    target := binding.NewFuzzTarget(registry, v6.Facade())
+//	    seeds, _ := target.CorpusSeeds("User")
+//	    for _, seed := range seeds {
+//	        f.Add(seed)
+//	    }
+//	    f.Fuzz(func(t *testing.T, data []byte) {
+//	        if result := target.ValidateBytes("User", data, SceneCreate); result != nil {
+//	            for _, fe := range result.FieldErrors() {
+//	                if fe.Tag() == binding.TagPanic {
+//	                    t.Fatal(fe.Message())
+//	                }
+//	            }
+//	        }
+//	    })
+//	}
+func (f *FuzzTarget) ValidateBytes(modelName string, payload []byte, scene core.Scene) (result core.IValidationError) {
+	info, ok := f.registry.Get(modelName)
+	if !ok {
+		return newStructuredError(modelName, TagUnknownModel, fmt.Sprintf("model %q not registered", modelName))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result = newStructuredError(modelName, TagPanic, fmt.Sprintf("panic: %v", r))
+		}
+	}()
+
+	// 步骤1：解码到新实例
+	target := info.New()
+	if err := json.Unmarshal(payload, target); err != nil {
+		return newStructuredError(modelName, TagDecode, "invalid payload: "+err.Error())
+	}
+
+	// 步骤2：验证
+	validateErr := f.validator.Validate(target, scene)
+	if validateErr == nil {
+		return nil
+	}
+	return sortedResult(validateErr)
+}
+
+// CorpusSeeds 生成模型的初始语料
+// 说明：包含空对象、null、零值 JSON，以及每个 JSON 字段分别为 null、空字符串、0、false 的变体
+func (f *FuzzTarget) CorpusSeeds(modelName string) ([][]byte, error) {
+	info, ok := f.registry.Get(modelName)
+	if !ok {
+		return nil, fmt.Errorf("model %q not registered", modelName)
+	}
+
+	seeds := [][]byte{[]byte(`{}`), []byte(`null`)}
+	if zero, err := json.Marshal(info.New()); err == nil {
+		seeds = append(seeds, zero)
+	}
+
+	for _, name := range jsonFieldNames(info.Type) {
+		key, _ := json.Marshal(name)
+		for _, value := range []string{`null`, `""`, `0`, `false`} {
+			seeds = append(seeds, []byte("{"+string(key)+":"+value+"}"))
+		}
+	}
+	return seeds, nil
+}
+
+// newStructuredError 创建结构化错误结果
+func newStructuredError(modelName, tag, message string) core.IValidationError {
+	return errors.NewValidationError([]core.IFieldError{
+		errors.NewFieldError(modelName, "", tag, errors.WithMessage(message)),
+	}, nil)
+}
+
+// sortedResult 按 namespace、tag 排序错误，保证输出确定
+func sortedResult(result core.IValidationError) core.IValidationError {
+	fieldErrors := append([]core.IFieldError(nil), result.FieldErrors()...)
+	sort.SliceStable(fieldErrors, func(i, j int) bool {
+		if fieldErrors[i].Namespace() != fieldErrors[j].Namespace() {
+			return fieldErrors[i].Namespace() < fieldErrors[j].Namespace()
+		}
+		return fieldErrors[i].Tag() < fieldErrors[j].Tag()
+	})
+	return errors.NewValidationError(fieldErrors, nil,
+		errors.WithWarnings(result.Warnings()),
+		errors.WithRuleVersion(result.RuleVersion()),
+		errors.WithTruncated(result.Truncated()))
+}
+
+// jsonFieldNames 获取结构体导出字段的 JSON 名称
+func jsonFieldNames(typ reflect.Type) []string {
+	names := make([]string, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,119 @@
+package binding_test
+
+import (
+	"katydid-common-account/pkg/validator/v6/binding"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/v6test"
+	stdtesting "testing"
+)
+
+// ============================================================================
+// 测试模型
+// ============================================================================
+
+const fuzzSceneCreate core.Scene = 1
+
+type fuzzUser struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	Age   int    `json:"age"`
+	Note  string `json:"-"`
+}
+
+// fuzzValidator 按字段预设错误的验证器，Name 为 "boom" 时 panic
+type fuzzValidator struct {
+	core.IValidator
+}
+
+func (v fuzzValidator) Validate(target any, scene core.Scene, opts ...core.ValidateOption) core.IValidationError {
+	u := target.(*fuzzUser)
+	if u.Name == "boom" {
+		panic("boom")
+	}
+	var errs []core.IFieldError
+	if u.Age < 0 {
+		errs = append(errs, v6test.NewError("fuzzUser.age", "gte"))
+	}
+	if u.Name == "" {
+		errs = append(errs, v6test.NewError("fuzzUser.name", "required"))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return v6test.NewResult(errs...)
+}
+
+func newFuzzTarget(t stdtesting.TB) *binding.FuzzTarget {
+	registry := binding.NewModelRegistry()
+	if err := registry.Register("User", &fuzzUser{}); err != nil {
+		t.Fatal(err)
+	}
+	return binding.NewFuzzTarget(registry, fuzzValidator{})
+}
+
+// ============================================================================
+// 测试
+// ============================================================================
+
+func TestFuzzTarget_ValidateBytes(t *stdtesting.T) {
+	target := newFuzzTarget(t)
+
+	tests := []struct {
+		name    string
+		model   string
+		payload string
+		want    string // 期望的第一个错误标签，空表示通过
+	}{
+		{"valid", "User", `{"name":"tom","age":3}`, ""},
+		{"unknown model", "Order", `{}`, binding.TagUnknownModel},
+		{"bad json", "User", `{"name":`, binding.TagDecode},
+		{"wrong type", "User", `{"age":"x"}`, binding.TagDecode},
+		{"panic", "User", `{"name":"boom"}`, binding.TagPanic},
+		{"sorted", "User", `{"age":-1}`, "gte"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *stdtesting.T) {
+			result := target.ValidateBytes(tt.model, []byte(tt.payload), fuzzSceneCreate)
+			if tt.want == "" {
+				v6test.AssertNoError(t, result)
+				return
+			}
+			if result == nil || result.FieldErrors()[0].Tag() != tt.want {
+				t.Fatalf("ValidateBytes() = %v, want first tag %q", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuzzTarget_CorpusSeeds(t *stdtesting.T) {
+	target := newFuzzTarget(t)
+
+	seeds, err := target.CorpusSeeds("User")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// {}、null、零值 + 3 个字段 × 4 个变体（json:"-" 的字段被跳过）
+	if len(seeds) != 15 {
+		t.Errorf("len(seeds) = %d, want 15", len(seeds))
+	}
+	if _, err := target.CorpusSeeds("Order"); err == nil {
+		t.Error("CorpusSeeds() for unknown model should fail")
+	}
+}
+
+func FuzzValidateBytes(f *stdtesting.F) {
+	target := newFuzzTarget(f)
+	seeds, _ := target.CorpusSeeds("User")
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *stdtesting.T, data []byte) {
+		first := target.ValidateBytes("User", data, fuzzSceneCreate)
+		second := target.ValidateBytes("User", data, fuzzSceneCreate)
+		if (first == nil) != (second == nil) || (first != nil && first.Error() != second.Error()) {
+			t.Fatalf("ValidateBytes() is not deterministic: %v vs %v", first, second)
+		}
+	})
+}
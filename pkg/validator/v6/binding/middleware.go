@@ -0,0 +1,190 @@
+package binding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	v6context "katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"net"
+	"net/http"
+)
+
+// ============================================================================
+// HTTP 中间件
+// ============================================================================
+
+// contextKey 请求上下文键
+type contextKey struct{}
+
+// ModelFromContext 获取中间件已解析并验证通过的模型实例
+func ModelFromContext(ctx context.Context) (any, bool) {
+	target := ctx.Value(contextKey{})
+	return target, target != nil
+}
+
+// errorResponse 验证失败的响应体
+type errorResponse struct {
+	Model       string             `json:"model,omitempty"`
+	Errors      []core.IFieldError `json:"errors"`
+	Warnings    []core.IFieldError `json:"warnings,omitempty"`
+	RuleVersion string             `json:"rule_version,omitempty"`
+}
+
+// DefaultMaxBodyBytes 默认的请求体大小上限（1MB）
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+// BindingOption 中间件与验证端点选项
+type BindingOption func(*bindingConfig)
+
+// bindingConfig 中间件与验证端点配置
+type bindingConfig struct {
+	maxBodyBytes int64
+}
+
+// WithMaxBodyBytes 设置请求体大小上限，超出时返回 413；n <= 0 时使用 DefaultMaxBodyBytes
+func WithMaxBodyBytes(n int64) BindingOption {
+	return func(c *bindingConfig) {
+		if n > 0 {
+			c.maxBodyBytes = n
+		}
+	}
+}
+
+// newBindingConfig 创建配置并应用选项
+func newBindingConfig(opts []BindingOption) *bindingConfig {
+	c := &bindingConfig{maxBodyBytes: DefaultMaxBodyBytes}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Middleware 创建验证中间件
+// 说明：
+//   - 请求匹配到已注册的路由时，将 JSON 请求体解码为模型实例并按场景验证
+//   - 请求体超过上限（默认 DefaultMaxBodyBytes，见 WithMaxBodyBytes）时返回 413
+//   - 验证失败返回 400 与错误列表；通过后模型实例可用 ModelFromContext 获取
+//   - 请求元数据（IP、User-Agent、X-Request-ID）与请求上下文中已有的元数据合并后传给策略，见 RequestMetadataFromHTTP
+//   - 未匹配的请求直接交给下一个处理器
+func Middleware(registry *ModelRegistry, validator core.IValidator, opts ...BindingOption) func(http.Handler) http.Handler {
+	config := newBindingConfig(opts)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			target, scene, info, ok := registry.Resolve(req.Method, req.URL.Path)
+			if !ok {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			if !decodeAndValidate(w, req, validator, config, info, target, scene) {
+				return
+			}
+
+			next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), contextKey{}, target)))
+		})
+	}
+}
+
+// ValidateHandler 通用验证端点
+// 请求：POST ?model=User&method=POST（或 &scene=1、&scene=create|update，名称见 core.RegisterScene），请求体为模型 JSON
+// 响应：验证通过返回 200 与警告列表，失败返回 400 与错误列表，请求体超过上限返回 413
+func ValidateHandler(registry *ModelRegistry, validator core.IValidator, opts ...BindingOption) http.Handler {
+	config := newBindingConfig(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+
+		info, ok := registry.Get(query.Get("model"))
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "model not found"})
+			return
+		}
+
+		// 优先使用显式场景，其次按 HTTP 方法映射
+		var scene core.Scene
+		if raw := query.Get("scene"); raw != "" {
+			value, err := core.ParseScene(raw)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid scene"})
+				return
+			}
+			scene = value
+		} else if scene, ok = info.SceneFor(query.Get("method")); !ok {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unknown scene"})
+			return
+		}
+
+		target := info.New()
+		if !decodeAndValidate(w, req, validator, config, info, target, scene) {
+			return
+		}
+		writeJSON(w, http.StatusOK, errorResponse{Model: info.Name, Errors: []core.IFieldError{}})
+	})
+}
+
+// IntrospectHandler 模型查看端点
+// 响应：所有已注册模型的名称、路由、场景映射和规则
+func IntrospectHandler(registry *ModelRegistry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		writeJSON(w, http.StatusOK, modelViews(registry))
+	})
+}
+
+// decodeAndValidate 解码请求体并验证
+// 返回值：验证是否通过（失败时已写入响应）
+func decodeAndValidate(w http.ResponseWriter, req *http.Request, validator core.IValidator,
+	config *bindingConfig, info *ModelInfo, target any, scene core.Scene) bool {
+	if req.Body != nil && req.ContentLength != 0 {
+		body := http.MaxBytesReader(w, req.Body, config.maxBodyBytes)
+		if err := json.NewDecoder(body).Decode(target); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeJSON(w, http.StatusRequestEntityTooLarge, map[string]string{"error": "request body too large"})
+				return false
+			}
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body: " + err.Error()})
+			return false
+		}
+	}
+
+	// 上下文中已有的元数据（认证中间件写入）优先
+	md, _ := v6context.RequestMetadataFromGoContext(req.Context())
+	ctx := v6context.WithRequestMetadata(req.Context(), md.Merge(RequestMetadataFromHTTP(req)))
+	if err := validator.Validate(target, scene, core.WithContext(ctx)); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{
+			Model:       info.Name,
+			Errors:      err.FieldErrors(),
+			Warnings:    err.Warnings(),
+			RuleVersion: err.RuleVersion(),
+		})
+		return false
+	}
+	return true
+}
+
+// HeaderRequestID 请求 ID 请求头
+const HeaderRequestID = "X-Request-ID"
+
+// RequestMetadataFromHTTP 从 HTTP 请求解析请求元数据（IP、User-Agent、请求 ID）
+// 说明：
+//   - IP 取 RemoteAddr 的主机部分，不信任 X-Forwarded-For 等可伪造的请求头；
+//     部署在代理之后时由前置的 RealIP 中间件改写 RemoteAddr，或通过 WithRequestMetadata 写入
+//   - ActorID、TenantID 由认证中间件通过 v6.WithRequestMetadata 写入请求上下文，与本函数的结果合并
+func RequestMetadataFromHTTP(req *http.Request) core.RequestMetadata {
+	ip := req.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return core.RequestMetadata{
+		IP:        ip,
+		UserAgent: req.UserAgent(),
+		RequestID: req.Header.Get(HeaderRequestID),
+	}
+}
+
+// writeJSON 写入 JSON 响应
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,114 @@
+package binding
+
+import (
+	stdcontext "context"
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	stdtesting "testing"
+)
+
+// fuzzSceneCreate 测试使用的创建场景（与 fuzz_test.go 中的取值一致）
+const fuzzSceneCreate core.Scene = 1
+
+// metadataRecorder 记录策略读取到的请求元数据
+type metadataRecorder struct {
+	got core.RequestMetadata
+}
+
+func (s *metadataRecorder) Type() core.StrategyType { return core.StrategyType("metadata_recorder") }
+func (s *metadataRecorder) Name() string            { return "metadata_recorder" }
+
+func (s *metadataRecorder) Validate(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	s.got = context.RequestMetadata(ctx)
+	return nil
+}
+
+func TestMiddleware_RequestMetadata(t *stdtesting.T) {
+	registry := NewModelRegistry()
+	registry.SetMethodScene(http.MethodPost, fuzzSceneCreate)
+	registry.MustRegister("User", &adminUser{}, WithRoute(http.MethodPost, "/users"))
+
+	recorder := &metadataRecorder{}
+	validator := v6.NewBuilder().WithRuleStrategy(10).WithStrategy(recorder, 20).Build()
+	handler := Middleware(registry, validator)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"alice"}`))
+	req.RemoteAddr = "203.0.113.7:51234"
+	req.Header.Set("User-Agent", "test-agent")
+	req.Header.Set(HeaderRequestID, "req-1")
+	// 认证中间件写入的字段优先
+	req = req.WithContext(v6.WithRequestMetadata(req.Context(), v6.RequestMetadata{ActorID: "u-1", TenantID: "t-1", IP: "198.51.100.1"}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	want := core.RequestMetadata{ActorID: "u-1", TenantID: "t-1", IP: "198.51.100.1", UserAgent: "test-agent", RequestID: "req-1"}
+	if recorder.got != want {
+		t.Errorf("metadata = %+v, want %+v", recorder.got, want)
+	}
+}
+
+func TestRequestMetadataFromHTTP(t *stdtesting.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "[2001:db8::1]:443"
+	req.Header.Set("X-Forwarded-For", "10.0.0.1")
+	if md := RequestMetadataFromHTTP(req); md.IP != "2001:db8::1" {
+		t.Errorf("IP = %q", md.IP)
+	}
+}
+
+func TestTenantIDFallsBackToRequestMetadata(t *stdtesting.T) {
+	goCtx := context.WithRequestMetadata(stdcontext.Background(), core.RequestMetadata{TenantID: "t-1"})
+	ctx := context.NewContext(fuzzSceneCreate, context.WithGoContext(goCtx))
+	defer ctx.Release()
+	if tenantID := context.TenantID(ctx); tenantID != "t-1" {
+		t.Errorf("TenantID = %q", tenantID)
+	}
+
+	ctx2 := context.NewContext(fuzzSceneCreate, context.WithGoContext(context.WithTenantID(goCtx, "t-2")))
+	defer ctx2.Release()
+	if tenantID := context.TenantID(ctx2); tenantID != "t-2" {
+		t.Errorf("explicit TenantID = %q", tenantID)
+	}
+}
+
+func TestMiddleware_MaxBodyBytes(t *stdtesting.T) {
+	registry := NewModelRegistry()
+	registry.SetMethodScene(http.MethodPost, fuzzSceneCreate)
+	registry.MustRegister("User", &adminUser{}, WithRoute(http.MethodPost, "/users"))
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		body    string
+		want    int
+	}{
+		{"上限以内", Middleware(registry, validator, WithMaxBodyBytes(32))(next), `{"name":"alice"}`, http.StatusNoContent},
+		{"超出上限", Middleware(registry, validator, WithMaxBodyBytes(32))(next), `{"name":"` + strings.Repeat("a", 64) + `"}`, http.StatusRequestEntityTooLarge},
+		{"默认上限", Middleware(registry, validator)(next), `{"name":"` + strings.Repeat("a", int(DefaultMaxBodyBytes)) + `"}`, http.StatusRequestEntityTooLarge},
+		{"验证端点", ValidateHandler(registry, validator, WithMaxBodyBytes(32)), `{"name":"` + strings.Repeat("a", 64) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *stdtesting.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users?model=User&method=POST", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			tt.handler.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d, body = %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
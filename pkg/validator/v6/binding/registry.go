@@ -0,0 +1,312 @@
+package binding
+
+import (
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ============================================================================
+// 模型注册表
+// ============================================================================
+
+// Route 路由
+type Route struct {
+	Method  string // HTTP 方法，如 POST
+	Pattern string // 路径模式，如 /users/{id}，{xxx} 匹配任意单段路径
+}
+
+// shape 路由形状：参数名替换为 {}，形状相同的路由匹配同一组路径
+func (r Route) shape() string {
+	segs := splitPath(r.Pattern)
+	for i, seg := range segs {
+		if isParam(seg) {
+			segs[i] = "{}"
+		}
+	}
+	return r.Method + " /" + strings.Join(segs, "/")
+}
+
+// String 实现 Stringer 接口
+func (r Route) String() string {
+	return r.Method + " " + r.Pattern
+}
+
+// ModelInfo 已注册模型的信息
+type ModelInfo struct {
+	Name   string                // 模型名称
+	Type   reflect.Type          // 模型结构体类型
+	Scenes map[string]core.Scene // HTTP 方法 -> 场景
+	Routes []Route               // 绑定的路由
+}
+
+// New 创建模型的新实例（指针）
+func (m *ModelInfo) New() any {
+	return reflect.New(m.Type).Interface()
+}
+
+// SceneFor 获取 HTTP 方法对应的场景
+func (m *ModelInfo) SceneFor(method string) (core.Scene, bool) {
+	scene, ok := m.Scenes[strings.ToUpper(method)]
+	return scene, ok
+}
+
+// ModelOption 模型注册选项
+type ModelOption func(*ModelInfo)
+
+// WithMethodScene 设置 HTTP 方法对应的场景（覆盖注册表默认映射）
+func WithMethodScene(method string, scene core.Scene) ModelOption {
+	return func(m *ModelInfo) {
+		m.Scenes[strings.ToUpper(method)] = scene
+	}
+}
+
+// WithRoute 绑定路由，如 WithRoute("POST", "/users")
+func WithRoute(method, pattern string) ModelOption {
+	return func(m *ModelInfo) {
+		m.Routes = append(m.Routes, Route{Method: strings.ToUpper(method), Pattern: pattern})
+	}
+}
+
+// ModelRegistry 模型注册表
+// 职责：服务启动时一次性注册模型，之后可按 "POST /users" 解析出 &User{} 和 SceneCreate
+// 设计原则：注册通常在启动阶段完成，读多写少，使用读写锁保护
+type ModelRegistry struct {
+	mu           sync.RWMutex
+	models       map[string]*ModelInfo
+	methodScenes map[string]core.Scene // 默认的 HTTP 方法 -> 场景映射
+}
+
+// NewModelRegistry 创建模型注册表
+func NewModelRegistry() *ModelRegistry {
+	return &ModelRegistry{
+		models:       make(map[string]*ModelInfo),
+		methodScenes: make(map[string]core.Scene),
+	}
+}
+
+// SetMethodScene 设置默认的 HTTP 方法 -> 场景映射
+// 说明：只影响之后注册的模型；如 SetMethodScene("POST", SceneCreate)
+func (r *ModelRegistry) SetMethodScene(method string, scene core.Scene) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.methodScenes[strings.ToUpper(method)] = scene
+}
+
+// Register 注册模型
+// 参数：prototype 为模型的零值实例，如 &User{}
+// 说明：严格标签模式（core.SetStrictTags）下，规则包含未注册的标签时返回错误
+func (r *ModelRegistry) Register(name string, prototype any, opts ...ModelOption) error {
+	if name == "" {
+		return fmt.Errorf("model name cannot be empty")
+	}
+
+	typ := reflect.TypeOf(prototype)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return fmt.Errorf("model %q prototype must be a struct or pointer to struct", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.models[name]; exists {
+		return fmt.Errorf("model %q already registered", name)
+	}
+
+	info := &ModelInfo{
+		Name:   name,
+		Type:   typ,
+		Scenes: make(map[string]core.Scene, len(r.methodScenes)),
+	}
+	for method, scene := range r.methodScenes {
+		info.Scenes[method] = scene
+	}
+
+	// 应用选项
+	for _, opt := range opts {
+		opt(info)
+	}
+
+	// 检查路由冲突：形状相同（仅参数名不同）的路由无法按字面段优先区分，视为歧义
+	for i, route := range info.Routes {
+		for _, other := range info.Routes[:i] {
+			if other.shape() == route.shape() {
+				return fmt.Errorf("route %s is ambiguous with route %s of model %q", route, other, name)
+			}
+		}
+		for _, model := range r.models {
+			for _, other := range model.Routes {
+				if other == route {
+					return fmt.Errorf("route %s already bound to model %q", route, model.Name)
+				}
+				if other.shape() == route.shape() {
+					return fmt.Errorf("route %s is ambiguous with route %s of model %q", route, other, model.Name)
+				}
+			}
+		}
+	}
+
+	// 严格标签模式：规则中的标签必须已注册
+	if core.StrictTags() {
+		if err := checkModelTags(info); err != nil {
+			return err
+		}
+	}
+
+	r.models[name] = info
+	return nil
+}
+
+// checkModelTags 检查模型在各个已映射场景下的规则标签（按 HTTP 方法排序，报告第一个错误）
+func checkModelTags(info *ModelInfo) error {
+	rules := info.Rules()
+	methods := make([]string, 0, len(rules))
+	for method := range rules {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	for _, method := range methods {
+		if err := core.CheckRuleTags(rules[method]); err != nil {
+			return fmt.Errorf("model %q %s: %w", info.Name, method, err)
+		}
+	}
+	return nil
+}
+
+// MustRegister 注册模型，失败时 panic
+func (r *ModelRegistry) MustRegister(name string, prototype any, opts ...ModelOption) {
+	if err := r.Register(name, prototype, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// Get 获取模型信息
+func (r *ModelRegistry) Get(name string) (*ModelInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.models[name]
+	return info, ok
+}
+
+// List 列出所有已注册的模型（按名称排序）
+func (r *ModelRegistry) List() []*ModelInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]*ModelInfo, 0, len(r.models))
+	for _, info := range r.models {
+		list = append(list, info)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// Resolve 根据 HTTP 方法和路径解析模型与场景
+// 说明：多个路由匹配时取最具体的一个——从左到右第一个不同的段上，字面段优先于 {param}，
+// 如 /users/me 优先于 /users/{id}；注册时已拒绝无法区分的路由，结果与注册顺序无关
+// 返回值：模型的新实例、对应场景、模型信息；未匹配时 ok 为 false
+func (r *ModelRegistry) Resolve(method, path string) (target any, scene core.Scene, info *ModelInfo, ok bool) {
+	method = strings.ToUpper(method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var best *ModelInfo
+	var bestPattern string
+	for _, model := range r.models {
+		for _, route := range model.Routes {
+			if route.Method != method || !matchPath(route.Pattern, path) {
+				continue
+			}
+			if best == nil || moreSpecific(route.Pattern, bestPattern) {
+				best, bestPattern = model, route.Pattern
+			}
+		}
+	}
+	if best == nil {
+		return nil, core.SceneNone, nil, false
+	}
+
+	scene, ok = best.SceneFor(method)
+	if !ok {
+		return nil, core.SceneNone, nil, false
+	}
+	return best.New(), scene, best, true
+}
+
+// Rules 获取模型在各个已映射场景下的规则（用于管理端查看）
+// 返回值：HTTP 方法 -> 字段规则；模型未实现 IRuleValidator 时为空
+func (m *ModelInfo) Rules() map[string]map[string]string {
+	provider, ok := m.New().(core.IRuleValidator)
+	if !ok {
+		return map[string]map[string]string{}
+	}
+
+	result := make(map[string]map[string]string, len(m.Scenes))
+	for method, scene := range m.Scenes {
+		if rules := provider.ValidateRules(scene); len(rules) > 0 {
+			result[method] = rules
+		}
+	}
+	return result
+}
+
+// splitPath 拆分路径段
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// isParam 是否为 {xxx} 参数段
+func isParam(seg string) bool {
+	return strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}")
+}
+
+// moreSpecific 匹配同一路径的两个模式中 a 是否比 b 更具体：第一个不同的段上 a 为字面段而 b 为参数
+func moreSpecific(a, b string) bool {
+	aSegs, bSegs := splitPath(a), splitPath(b)
+	for i := range aSegs {
+		if i >= len(bSegs) {
+			break
+		}
+		if aParam, bParam := isParam(aSegs[i]), isParam(bSegs[i]); aParam != bParam {
+			return bParam
+		}
+	}
+	return false
+}
+
+// matchPath 路径匹配，{xxx} 匹配任意单段路径
+func matchPath(pattern, path string) bool {
+	patternSegs := splitPath(pattern)
+	pathSegs := splitPath(path)
+	if len(patternSegs) != len(pathSegs) {
+		return false
+	}
+
+	for i, seg := range patternSegs {
+		if isParam(seg) {
+			if pathSegs[i] == "" {
+				return false
+			}
+			continue
+		}
+		if seg != pathSegs[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package binding
+
+import (
+	"net/http"
+	"strings"
+	stdtesting "testing"
+)
+
+// meUser 与 adminUser 路由重叠的测试模型
+type meUser struct {
+	Name string `json:"name"`
+}
+
+func TestModelRegistry_ResolveLiteralFirst(t *stdtesting.T) {
+	routes := []struct {
+		name    string
+		model   any
+		pattern string
+	}{
+		{"User", &adminUser{}, "/users/{id}"},
+		{"Me", &meUser{}, "/users/me"},
+		{"Role", &adminUser{}, "/{tenant}/roles"},
+		{"TenantUser", &meUser{}, "/{tenant}/users"},
+		{"UserRoles", &meUser{}, "/users/{id}/roles"},
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/users/me", "Me"},
+		{"/users/42", "User"},
+		{"/users/me/roles", "UserRoles"},
+		{"/t1/roles", "Role"},
+		{"/users/users", "User"}, // 第一段为字面段的 /users/{id} 优先于 /{tenant}/users
+	}
+
+	// 不同注册顺序下结果一致，多次解析结果稳定
+	for _, reverse := range []bool{false, true} {
+		registry := NewModelRegistry()
+		registry.SetMethodScene(http.MethodGet, fuzzSceneCreate)
+		for i := range routes {
+			r := routes[i]
+			if reverse {
+				r = routes[len(routes)-1-i]
+			}
+			registry.MustRegister(r.name, r.model, WithRoute(http.MethodGet, r.pattern))
+		}
+
+		for _, tt := range tests {
+			for i := 0; i < 20; i++ {
+				_, _, info, ok := registry.Resolve(http.MethodGet, tt.path)
+				if !ok || info.Name != tt.want {
+					t.Fatalf("reverse=%t: Resolve(%s) = %v, %t, want %s", reverse, tt.path, info, ok, tt.want)
+				}
+			}
+		}
+	}
+}
+
+func TestModelRegistry_RegisterAmbiguous(t *stdtesting.T) {
+	registry := NewModelRegistry()
+	registry.MustRegister("User", &adminUser{}, WithRoute(http.MethodGet, "/users/{id}"))
+
+	tests := []struct {
+		name    string
+		opts    []ModelOption
+		wantErr string
+	}{
+		{"相同路由", []ModelOption{WithRoute(http.MethodGet, "/users/{id}")}, "already bound"},
+		{"仅参数名不同", []ModelOption{WithRoute(http.MethodGet, "/users/{name}")}, "ambiguous"},
+		{"模型内部歧义", []ModelOption{
+			WithRoute(http.MethodGet, "/groups/{id}"),
+			WithRoute(http.MethodGet, "/groups/{slug}"),
+		}, "ambiguous"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *stdtesting.T) {
+			err := registry.Register("Other", &meUser{}, tt.opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Register() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// 字面段不同或方法不同的路由可以共存
+	if err := registry.Register("Me", &meUser{},
+		WithRoute(http.MethodGet, "/users/me"),
+		WithRoute(http.MethodPut, "/users/{name}"),
+	); err != nil {
+		t.Errorf("Register() = %v", err)
+	}
+}
@@ -2,17 +2,26 @@ package context
 
 import (
 	"context"
+	"fmt"
 	"katydid-common-account/pkg/validator/v6/core"
+	"reflect"
 	"sync"
+	"sync/atomic"
 )
 
 // validationContext 验证上下文实现
 // 设计原则：单一职责 - 只管理上下文信息，不管理错误
+// 说明：元数据和阶段耗时缓冲区随上下文一起复用，记忆化缓存在第一次使用时创建，验证通过时不产生分配
 type validationContext struct {
 	goCtx    context.Context
 	scene    core.Scene
 	depth    int
 	metadata core.IMetadata
+	memo     atomic.Pointer[memoStore] // 派生上下文共享，懒创建
+	visits   *visitNode                // 递归路径上的对象（检测循环引用）
+
+	ownMetadata *metadata          // 自有元数据（metadata 可能指向共享的元数据）
+	timings     []core.StageTiming // 阶段耗时缓冲区
 }
 
 // NewContext 创建新的验证上下文
@@ -21,7 +30,7 @@ func NewContext(scene core.Scene, opts ...ContextOption) core.IContext {
 	ctx.goCtx = context.Background()
 	ctx.scene = scene
 	ctx.depth = 0
-	ctx.metadata = NewMetadata()
+	ctx.metadata = ctx.ownMetadata
 
 	// 应用选项
 	for _, opt := range opts {
@@ -55,6 +64,53 @@ func WithMetadata(key string, value any) ContextOption {
 	}
 }
 
+// WithSharedMetadata 共享已有的元数据
+// 说明：派生上下文与原上下文读写同一份元数据
+func WithSharedMetadata(md core.IMetadata) ContextOption {
+	return func(c *validationContext) {
+		if md != nil {
+			c.metadata = md
+		}
+	}
+}
+
+// WithSharedMemo 共享已有上下文的记忆化缓存
+// 说明：派生上下文（如超时包装器的内部上下文）与原上下文共享 Memo 结果
+func WithSharedMemo(parent core.IContext) ContextOption {
+	return func(c *validationContext) {
+		if p, ok := parent.(*validationContext); ok {
+			c.memo.Store(p.memoStore())
+		}
+	}
+}
+
+// WithVisits 继承父上下文的递归路径，并把 objs 加入路径（用于嵌套验证检测循环引用）
+// 说明：只记录非 nil 指针，按类型和地址区分对象；已在路径上的对象不重复加入
+func WithVisits(parent core.IContext, objs ...any) ContextOption {
+	return func(c *validationContext) {
+		if p, ok := parent.(*validationContext); ok {
+			c.visits = p.visits
+		}
+		for _, obj := range objs {
+			key, ok := visitKeyOf(obj)
+			if ok && !c.visits.contains(key) {
+				c.visits = &visitNode{key: key, parent: c.visits}
+			}
+		}
+	}
+}
+
+// WithListeners 设置验证监听器
+// 说明：监听器保存在 Go 标准上下文中，派生上下文和并行策略都能安全读取；
+// 需要放在 WithGoContext 之后，否则会被覆盖
+func WithListeners(listeners ...core.IValidationListener) ContextOption {
+	return func(c *validationContext) {
+		if len(listeners) > 0 {
+			c.goCtx = context.WithValue(c.goCtx, listenersKey{}, listeners)
+		}
+	}
+}
+
 // GoContext 实现 IContext 接口
 func (c *validationContext) GoContext() context.Context {
 	return c.goCtx
@@ -82,10 +138,34 @@ func (c *validationContext) WithDepth(depth int) core.IContext {
 	newCtx.goCtx = c.goCtx
 	newCtx.scene = c.scene
 	newCtx.depth = depth
-	newCtx.metadata = c.metadata // 共享元数据
+	newCtx.metadata = c.metadata     // 共享元数据
+	newCtx.memo.Store(c.memoStore()) // 共享记忆化缓存
+	newCtx.visits = c.visits
 	return newCtx
 }
 
+// Memo 实现 IContext 接口
+func (c *validationContext) Memo(key string, fn func() error) error {
+	_, err := c.MemoValue(key, func() (any, error) {
+		return nil, fn()
+	})
+	return err
+}
+
+// MemoValue 实现 IContext 接口
+func (c *validationContext) MemoValue(key string, fn func() (any, error)) (any, error) {
+	return c.memoStore().do(key, fn)
+}
+
+// memoStore 获取记忆化缓存，不存在时创建（并行策略可能同时调用）
+func (c *validationContext) memoStore() *memoStore {
+	if store := c.memo.Load(); store != nil {
+		return store
+	}
+	c.memo.CompareAndSwap(nil, &memoStore{})
+	return c.memo.Load()
+}
+
 // Release 实现 IContext 接口
 func (c *validationContext) Release() {
 	releaseContext(c)
@@ -96,13 +176,19 @@ func (c *validationContext) Release() {
 // ============================================================================
 
 // metadata 元数据实现
+// 说明：并行执行的策略共享同一个上下文（如都会记录规则版本号），读写需要加锁
 type metadata struct {
 	data map[string]any
-	//mu   sync.RWMutex // context不需要线程安全
+	mu   sync.RWMutex
 }
 
 // NewMetadata 创建新的元数据
 func NewMetadata() core.IMetadata {
+	return newMetadata()
+}
+
+// newMetadata 创建新的元数据
+func newMetadata() *metadata {
 	return &metadata{
 		data: make(map[string]any),
 	}
@@ -110,45 +196,45 @@ func NewMetadata() core.IMetadata {
 
 // Get 获取元数据
 func (m *metadata) Get(key string) (any, bool) {
-	//m.mu.RLock()
-	//defer m.mu.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	val, ok := m.data[key]
 	return val, ok
 }
 
 // Set 设置元数据
 func (m *metadata) Set(key string, value any) {
-	//m.mu.Lock()
-	//defer m.mu.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.data[key] = value
 }
 
 // Has 检查是否存在
 func (m *metadata) Has(key string) bool {
-	//m.mu.RLock()
-	//defer m.mu.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	_, ok := m.data[key]
 	return ok
 }
 
 // Delete 删除元数据
 func (m *metadata) Delete(key string) {
-	//m.mu.Lock()
-	//defer m.mu.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	delete(m.data, key)
 }
 
-// Clear 清空所有元数据
+// Clear 清空所有元数据（保留容量，便于复用）
 func (m *metadata) Clear() {
-	//m.mu.Lock()
-	//defer m.mu.Unlock()
-	m.data = make(map[string]any)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.data)
 }
 
 // All 获取所有元数据
 func (m *metadata) All() map[string]any {
-	//m.mu.RLock()
-	//defer m.mu.RUnlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	// 返回副本
 	result := make(map[string]any, len(m.data))
 	for k, v := range m.data {
@@ -157,17 +243,61 @@ func (m *metadata) All() map[string]any {
 	return result
 }
 
+// ============================================================================
+// 记忆化缓存
+// ============================================================================
+
+// memoEntry 记忆化条目
+type memoEntry struct {
+	once  sync.Once
+	value any
+	err   error
+}
+
+// memoStore 单次验证的记忆化缓存
+// 说明：并行策略可能同时访问，同一 key 的并发调用会等待第一次执行完成
+type memoStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoEntry
+}
+
+// do 执行或返回缓存结果
+func (m *memoStore) do(key string, fn func() (any, error)) (any, error) {
+	m.mu.Lock()
+	if m.entries == nil {
+		m.entries = make(map[string]*memoEntry)
+	}
+	entry, ok := m.entries[key]
+	if !ok {
+		entry = &memoEntry{}
+		m.entries[key] = entry
+	}
+	m.mu.Unlock()
+
+	entry.once.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				entry.err = fmt.Errorf("memo %q panicked: %v", key, r)
+			}
+		}()
+		entry.value, entry.err = fn()
+	})
+	return entry.value, entry.err
+}
+
 // ============================================================================
 // 上下文对象池
 // ============================================================================
 
 var contextPool = sync.Pool{
 	New: func() any {
+		md := newMetadata()
 		return &validationContext{
-			goCtx:    context.Background(),
-			scene:    core.SceneNone,
-			depth:    0,
-			metadata: NewMetadata(),
+			goCtx:       context.Background(),
+			scene:       core.SceneNone,
+			depth:       0,
+			metadata:    md,
+			ownMetadata: md,
 		}
 	},
 }
@@ -178,14 +308,212 @@ func acquireContext() *validationContext {
 }
 
 // releaseContext 释放上下文到对象池
+// 说明：只清空自有元数据，共享的元数据（WithDepth、WithSharedMetadata）属于其他上下文
 func releaseContext(ctx *validationContext) {
-	ctx.metadata.Clear()
+	ctx.ownMetadata.Clear()
+	ctx.metadata = ctx.ownMetadata
+	ctx.memo.Store(nil) // 不清空内容：超时的策略可能仍在后台使用
+	ctx.visits = nil
+	ctx.timings = ctx.timings[:0]
 	ctx.depth = 0
 	ctx.scene = core.SceneNone
 	ctx.goCtx = context.Background()
 	contextPool.Put(ctx)
 }
 
+// ============================================================================
+// 循环引用检测
+// ============================================================================
+
+// visitKey 对象标识（类型 + 地址；结构体与其第一个字段地址相同，因此需要类型区分）
+type visitKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// visitNode 递归路径节点
+// 说明：不可变链表，派生上下文共享父路径，并发验证的兄弟节点互不影响
+type visitNode struct {
+	key    visitKey
+	parent *visitNode
+}
+
+// contains 路径上是否存在该对象
+func (n *visitNode) contains(key visitKey) bool {
+	for ; n != nil; n = n.parent {
+		if n.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// visitKeyOf 获取对象标识（非指针或 nil 指针返回 false）
+func visitKeyOf(obj any) (visitKey, bool) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return visitKey{}, false
+	}
+	return visitKey{typ: v.Type(), ptr: v.Pointer()}, true
+}
+
+// Visiting 对象是否已在当前递归路径上（即再次进入会形成循环引用）
+// 说明：只有指针能形成循环；同一对象被多个兄弟字段引用（共享而非循环）时不算；自定义 IContext 始终返回 false
+func Visiting(ctx core.IContext, obj any) bool {
+	c, ok := ctx.(*validationContext)
+	if !ok {
+		return false
+	}
+	key, ok := visitKeyOf(obj)
+	return ok && c.visits.contains(key)
+}
+
+// ============================================================================
+// 阶段耗时
+// ============================================================================
+
+// StageTimingBuffer 获取上下文中可复用的阶段耗时缓冲区（长度为 0）
+// 说明：编排器追加后通过 SetStageTimings 记录，避免每次验证分配切片
+func StageTimingBuffer(ctx core.IContext) []core.StageTiming {
+	if c, ok := ctx.(*validationContext); ok {
+		return c.timings[:0]
+	}
+	return nil
+}
+
+// SetStageTimings 记录阶段耗时
+func SetStageTimings(ctx core.IContext, timings []core.StageTiming) {
+	if len(timings) == 0 {
+		return
+	}
+	if c, ok := ctx.(*validationContext); ok {
+		c.timings = timings
+		return
+	}
+	ctx.Metadata().Set(MetadataKeyStageTimings, timings)
+}
+
+// StageTimings 获取记录的阶段耗时
+// 注意：返回的切片随上下文回收复用，需要在上下文释放后使用时先复制
+func StageTimings(ctx core.IContext) []core.StageTiming {
+	if c, ok := ctx.(*validationContext); ok {
+		if len(c.timings) == 0 {
+			return nil
+		}
+		return c.timings
+	}
+	if timings, ok := ctx.Metadata().Get(MetadataKeyStageTimings); ok {
+		if t, ok := timings.([]core.StageTiming); ok {
+			return t
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// 租户
+// ============================================================================
+
+// tenantKey Go 上下文中租户 ID 的键
+type tenantKey struct{}
+
+// WithTenantID 在 Go 上下文中设置租户 ID
+// 说明：通过 v6.WithContext 传给验证器后，租户规则覆盖按该租户生效
+func WithTenantID(goCtx context.Context, tenantID string) context.Context {
+	return context.WithValue(goCtx, tenantKey{}, tenantID)
+}
+
+// TenantID 获取验证上下文中的租户 ID（未设置时使用请求元数据中的租户，都未设置时为空）
+func TenantID(ctx core.IContext) string {
+	if ctx == nil || ctx.GoContext() == nil {
+		return ""
+	}
+	if tenantID, _ := ctx.GoContext().Value(tenantKey{}).(string); tenantID != "" {
+		return tenantID
+	}
+	return RequestMetadata(ctx).TenantID
+}
+
+// ============================================================================
+// 角色
+// ============================================================================
+
+// roleKey Go 上下文中调用方角色的键
+type roleKey struct{}
+
+// WithRole 在 Go 上下文中设置调用方角色
+// 说明：通过 v6.WithContext 传给验证器后，查询字段策略按该角色限制可过滤的字段
+func WithRole(goCtx context.Context, role string) context.Context {
+	return context.WithValue(goCtx, roleKey{}, role)
+}
+
+// Role 获取验证上下文中的调用方角色（未设置时为空）
+func Role(ctx core.IContext) string {
+	if ctx == nil || ctx.GoContext() == nil {
+		return ""
+	}
+	role, _ := ctx.GoContext().Value(roleKey{}).(string)
+	return role
+}
+
+// ============================================================================
+// 请求元数据
+// ============================================================================
+
+// requestMetadataKey Go 上下文中请求元数据的键
+type requestMetadataKey struct{}
+
+// WithRequestMetadata 在 Go 上下文中设置请求元数据
+// 说明：上下文中已有元数据时合并，新值的非空字段覆盖旧值（接入层和认证中间件可以分别设置）
+func WithRequestMetadata(goCtx context.Context, md core.RequestMetadata) context.Context {
+	if existing, ok := RequestMetadataFromGoContext(goCtx); ok {
+		md = md.Merge(existing)
+	}
+	return context.WithValue(goCtx, requestMetadataKey{}, md)
+}
+
+// RequestMetadataFromGoContext 获取 Go 上下文中的请求元数据
+func RequestMetadataFromGoContext(goCtx context.Context) (core.RequestMetadata, bool) {
+	if goCtx == nil {
+		return core.RequestMetadata{}, false
+	}
+	md, ok := goCtx.Value(requestMetadataKey{}).(core.RequestMetadata)
+	return md, ok
+}
+
+// RequestMetadata 获取验证上下文中的请求元数据（未设置时为零值）
+func RequestMetadata(ctx core.IContext) core.RequestMetadata {
+	if ctx == nil {
+		return core.RequestMetadata{}
+	}
+	md, _ := RequestMetadataFromGoContext(ctx.GoContext())
+	return md
+}
+
+// FieldSet 获取本次验证的有效字段（来自 MetadataKeyValidateFields、MetadataKeyExcludeFields）
+// 说明：自定义策略据此跳过无关字段；没有过滤条件时 All() 为 true
+func FieldSet(ctx core.IContext) core.FieldSet {
+	if ctx == nil {
+		return core.FieldSet{}
+	}
+	include, _ := metadataStrings(ctx, MetadataKeyValidateFields)
+	exclude, _ := metadataStrings(ctx, MetadataKeyExcludeFields)
+	if len(include) == 0 && len(exclude) == 0 {
+		return core.FieldSet{}
+	}
+	return core.NewFieldSet(include, exclude)
+}
+
+// metadataStrings 获取字符串切片类型的元数据
+func metadataStrings(ctx core.IContext, key string) ([]string, bool) {
+	value, ok := ctx.Metadata().Get(key)
+	if !ok {
+		return nil, false
+	}
+	list, ok := value.([]string)
+	return list, ok
+}
+
 // ============================================================================
 // 预定义元数据键
 // ============================================================================
@@ -193,4 +521,50 @@ func releaseContext(ctx *validationContext) {
 const (
 	MetadataKeyValidateFields = "validate_fields" // 指定验证字段
 	MetadataKeyExcludeFields  = "exclude_fields"  // 排除验证字段
+	MetadataKeyRuleVersion    = "rule_version"    // 外部规则版本号
+	MetadataKeyStageTimings   = "stage_timings"   // 阶段执行耗时（[]core.StageTiming），仅自定义 IContext 使用，见 StageTimings
+	MetadataKeyProfile        = "profile"         // 本次验证使用的配置档名称
 )
+
+// ============================================================================
+// 事件分发
+// ============================================================================
+
+// listenersKey 监听器在 Go 标准上下文中的键
+type listenersKey struct{}
+
+// listeners 获取上下文中的监听器
+func listeners(ctx core.IContext) []core.IValidationListener {
+	if ctx == nil || ctx.GoContext() == nil {
+		return nil
+	}
+	l, _ := ctx.GoContext().Value(listenersKey{}).([]core.IValidationListener)
+	return l
+}
+
+// HasListeners 上下文中是否有监听器
+// 说明：事件构造有开销时，可先判断再调用 Emit
+func HasListeners(ctx core.IContext) bool {
+	return len(listeners(ctx)) > 0
+}
+
+// Emit 向上下文中的监听器分发事件
+// 说明：
+//   - 实现了 IEventListener 的监听器接收所有事件
+//   - ValidationStart/ValidationEnd/ErrorAdded 同时转发给 IValidationListener 的对应方法
+func Emit(ctx core.IContext, event core.Event) {
+	for _, listener := range listeners(ctx) {
+		if el, ok := listener.(core.IEventListener); ok {
+			el.OnEvent(ctx, event)
+		}
+
+		switch event.Type {
+		case core.EventTypeValidationStart:
+			listener.OnValidationStart(ctx, event.Target)
+		case core.EventTypeValidationEnd:
+			listener.OnValidationEnd(ctx, event.Target, event.Err)
+		case core.EventTypeErrorAdded:
+			listener.OnError(ctx, event.FieldError)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package context
+
+import (
+	stderrors "errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// testScene 测试场景
+const testScene core.Scene = 1
+
+// TestMemo_Once 测试同一 key 只执行一次，错误和结果值都被缓存
+func TestMemo_Once(t *testing.T) {
+	ctx := NewContext(testScene)
+	defer ctx.Release()
+
+	calls := 0
+	notFound := stderrors.New("product 7 not found")
+	check := func() error {
+		calls++
+		return notFound
+	}
+	for i := 0; i < 3; i++ {
+		if err := ctx.Memo("product:7", check); !stderrors.Is(err, notFound) {
+			t.Fatalf("Memo() = %v, want %v", err, notFound)
+		}
+	}
+	if err := ctx.Memo("product:8", func() error { calls++; return nil }); err != nil {
+		t.Fatalf("Memo(product:8) = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+
+	lookups := 0
+	for i := 0; i < 3; i++ {
+		value, err := ctx.MemoValue("price:7", func() (any, error) {
+			lookups++
+			return 1999, nil
+		})
+		if err != nil || value != 1999 {
+			t.Fatalf("MemoValue() = %v, %v", value, err)
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("lookups = %d, want 1", lookups)
+	}
+}
+
+// TestMemo_Concurrent 测试并发调用同一 key 时只执行一次，其余调用等待结果
+func TestMemo_Concurrent(t *testing.T) {
+	ctx := NewContext(testScene)
+	defer ctx.Release()
+
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, _ := ctx.MemoValue("shared", func() (any, error) {
+				calls.Add(1)
+				return "v", nil
+			})
+			if value != "v" {
+				t.Errorf("MemoValue() = %v", value)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
+
+// TestMemo_Panic 测试 fn panic 时转换为缓存的错误
+func TestMemo_Panic(t *testing.T) {
+	ctx := NewContext(testScene)
+	defer ctx.Release()
+
+	for i := 0; i < 2; i++ {
+		err := ctx.Memo("boom", func() error { panic("db closed") })
+		if err == nil || !strings.Contains(err.Error(), `memo "boom" panicked: db closed`) {
+			t.Fatalf("Memo() = %v", err)
+		}
+	}
+}
+
+// TestMemo_Scope 测试派生上下文共享缓存，不同验证之间不共享
+func TestMemo_Scope(t *testing.T) {
+	parent := NewContext(testScene)
+	calls := 0
+	fn := func() error { calls++; return nil }
+	_ = parent.Memo("k", fn)
+
+	nested := parent.WithDepth(1)
+	_ = nested.Memo("k", fn)
+	derived := NewContext(testScene, WithSharedMemo(parent))
+	_ = derived.Memo("k", fn)
+	if calls != 1 {
+		t.Errorf("派生上下文 calls = %d, want 1", calls)
+	}
+
+	// 派生上下文中的结果对原上下文可见
+	_ = derived.Memo("from_derived", fn)
+	_ = parent.Memo("from_derived", fn)
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	nested.Release()
+	derived.Release()
+	parent.Release()
+
+	// 新的验证（即使复用了对象池中的上下文）重新执行
+	next := NewContext(testScene)
+	defer next.Release()
+	_ = next.Memo("k", fn)
+	if calls != 3 {
+		t.Errorf("新验证 calls = %d, want 3", calls)
+	}
+}
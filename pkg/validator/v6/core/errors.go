@@ -0,0 +1,18 @@
+package core
+
+import "errors"
+
+// ============================================================================
+// 哨兵错误
+// ============================================================================
+
+var (
+	// ErrStrategyTimeout 策略执行超时
+	ErrStrategyTimeout = errors.New("strategy execution timeout")
+
+	// ErrCircuitOpen 策略熔断器处于打开状态，拒绝执行
+	ErrCircuitOpen = errors.New("strategy circuit breaker is open")
+
+	// ErrRepositoryStrategyRequired 规则中使用了 unique/exists，但未启用仓储验证策略
+	ErrRepositoryStrategyRequired = errors.New("unique/exists rules require the repository strategy")
+)
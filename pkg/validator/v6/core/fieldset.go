@@ -0,0 +1,115 @@
+package core
+
+import "strings"
+
+// ============================================================================
+// 有效字段集合
+// ============================================================================
+
+// FieldSet 本次验证的有效字段集合（WithFields / WithExcludeFields、ValidateFields / ValidateFieldsExcept）
+// 说明：零值表示验证全部字段；创建后只读，可并发使用
+type FieldSet struct {
+	include    []string
+	exclude    []string
+	includeSet map[string]bool
+	excludeSet map[string]bool
+}
+
+// NewFieldSet 创建有效字段集合
+// 参数：include 为空表示全部字段；exclude 在 include 选择的基础上排除
+func NewFieldSet(include, exclude []string) FieldSet {
+	return FieldSet{
+		include:    include,
+		exclude:    exclude,
+		includeSet: stringSet(include),
+		excludeSet: stringSet(exclude),
+	}
+}
+
+// All 是否验证全部字段（没有过滤条件）
+func (s FieldSet) All() bool {
+	return len(s.include) == 0 && len(s.exclude) == 0
+}
+
+// Included 只验证的字段（为空表示全部字段）
+func (s FieldSet) Included() []string {
+	return s.include
+}
+
+// Excluded 不验证的字段
+func (s FieldSet) Excluded() []string {
+	return s.exclude
+}
+
+// Includes 规则键或字段是否需要验证
+// 说明：
+//   - 指定路径 profile.email 时，规则键 profile 需要验证（嵌套字段所在的字段）；指定 profile 时，规则键 profile.email 也需要验证；
+//     指定 tags[1] 时，规则键 tags 需要验证（dive 规则按整个字段验证）
+//   - 排除 profile 时，规则键 profile.email 同样排除
+func (s FieldSet) Includes(field string) bool {
+	return s.IncludesAny(field)
+}
+
+// IncludesAny 同一字段的多个名称（如 json 名称和 Go 字段名）是否需要验证
+// 说明：任一名称被选择且所有名称都未被排除时需要验证
+func (s FieldSet) IncludesAny(names ...string) bool {
+	if len(s.include) > 0 {
+		selected := false
+		for _, name := range names {
+			if s.includeSet[name] || relatedPath(name, s.include) {
+				selected = true
+				break
+			}
+		}
+		if !selected {
+			return false
+		}
+	}
+	for _, name := range names {
+		if s.excludeSet[name] || underPath(name, s.exclude) {
+			return false
+		}
+	}
+	return true
+}
+
+// relatedPath 规则键与任一路径相关（互为前缀或路径指向元素）
+func relatedPath(field string, paths []string) bool {
+	for _, path := range paths {
+		if strings.HasPrefix(path, field+".") || strings.HasPrefix(path, field+"[") ||
+			strings.HasPrefix(field, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// underPath 规则键位于任一路径之下
+func underPath(field string, paths []string) bool {
+	for _, path := range paths {
+		if strings.HasPrefix(field, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// stringSet 字符串集合（为空时返回 nil）
+func stringSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// IFieldAwareBusinessValidator 感知有效字段的业务验证器接口
+// 职责：只验证部分字段时（如 PATCH 请求），业务验证跳过无关的检查（如不修改邮箱时不查询邮箱是否被占用）
+// 说明：实现该接口时业务策略调用 ValidateBusinessFields 而不是 ValidateBusiness
+type IFieldAwareBusinessValidator interface {
+	// ValidateBusinessFields 执行业务验证，fields 为本次验证的有效字段（fields.All() 表示全部字段）
+	ValidateBusinessFields(scene Scene, fields FieldSet, collector IErrorCollector)
+}
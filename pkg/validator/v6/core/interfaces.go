@@ -1,6 +1,11 @@
 package core
 
-import "context"
+import (
+	"context"
+	"mime/multipart"
+	"net/url"
+	"time"
+)
 
 // ============================================================================
 // 业务层接口 - 由业务模型实现
@@ -35,6 +40,32 @@ type ILifecycleHooks interface {
 	AfterValidation(ctx IContext) error
 }
 
+// ISelfValidator 自验证接口
+// 职责：值对象自行检查有效性（如金额、日期区间），适合不便用规则表达的类型
+// 说明：返回 IValidationError 或 IFieldError 时按字段错误收集，其他错误作为该值的 self 错误
+type ISelfValidator interface {
+	// ValidateSelf 执行自验证，有效时返回 nil
+	ValidateSelf(scene Scene) error
+}
+
+// IFieldPolicy 查询字段策略接口
+// 职责：声明各角色在列表查询中可以作为过滤条件的字段（由查询模型实现）
+// 说明：返回 nil 表示不限制；返回空切片表示不允许任何过滤字段；字段名为 Go 字段名或 json 名称
+type IFieldPolicy interface {
+	// AllowedQueryFields 获取角色可以过滤的字段
+	AllowedQueryFields(role string) []string
+}
+
+// IOptional 可选值接口
+// 职责：区分"未提供"、"显式为 null"和"提供了值（包括零值）"
+// 说明：用于部分更新场景，如 types.Optional[T]
+type IOptional interface {
+	// IsDefined 是否提供了该字段（显式 null 也算提供）
+	IsDefined() bool
+	// OptionalValue 获取值，valid 为 false 表示显式 null
+	OptionalValue() (value any, valid bool)
+}
+
 // ============================================================================
 // 框架层接口 - 验证器核心接口
 // ============================================================================
@@ -44,7 +75,12 @@ type ILifecycleHooks interface {
 // 设计原则：接口隔离 - 只包含验证相关方法
 type IValidator interface {
 	// Validate 执行完整验证
-	Validate(target any, scene Scene) IValidationError
+	// 验证通过时返回 nil（即使存在警告）；opts 可选择配置档，如 WithProfile("import")
+	Validate(target any, scene Scene, opts ...ValidateOption) IValidationError
+
+	// Check 执行完整验证并始终返回结果
+	// 通过 HasErrors() 判断是否通过，通过 Warnings() 获取警告
+	Check(target any, scene Scene, opts ...ValidateOption) IValidationError
 
 	// ValidateWithContext 使用自定义上下文执行验证
 	ValidateWithContext(target any, ctx IContext) error
@@ -53,13 +89,44 @@ type IValidator interface {
 // IFieldValidator 字段级验证器接口
 // 职责：提供字段级别的验证功能
 // 设计原则：接口隔离 - 分离字段验证职责
-// TODO:GG 没人实现？
+// 说明：字段名可以是规则中的键，也可以是嵌套路径（如 profile.email 会匹配规则键 profile）
 type IFieldValidator interface {
 	// ValidateFields 验证指定字段
 	ValidateFields(target any, scene Scene, fields ...string) IValidationError
 
 	// ValidateFieldsExcept 验证除指定字段外的所有字段
 	ValidateFieldsExcept(target any, scene Scene, excludeFields ...string) IValidationError
+
+	// ValidateChanges 只验证 new 相对 old 发生变化的字段（用于 Update 场景）
+	// 没有变化时返回 nil
+	ValidateChanges(old, new any, scene Scene) IValidationError
+}
+
+// IMapValidator Map 验证器接口
+// 职责：按运行时规则验证无结构体模型的请求体（如无模式 JSON）
+// 设计原则：接口隔离 - 分离 Map 验证职责
+type IMapValidator interface {
+	// ValidateMapWithRules 按规则（字段路径 -> go-playground 规则）验证 map，验证通过时返回 nil
+	// 说明：弱类型模式下发生类型转换时，即使验证通过也返回带 coerced 提示（Warnings）的结果
+	ValidateMapWithRules(payload map[string]any, rules map[string]string) IValidationError
+}
+
+// IFormValidator 表单验证器接口
+// 职责：按规则验证 HTML 表单和 multipart 上传（字段值都是字符串，另有文件部分）
+// 设计原则：接口隔离 - 分离表单验证职责
+type IFormValidator interface {
+	// ValidateForm 按规则（字段名 -> 规则）验证表单，验证通过时返回 nil
+	// 说明：字段值按规则转换类型后验证；files 为 multipart 表单的文件部分（可为 nil），按文件规则验证大小和类型
+	ValidateForm(values url.Values, files map[string][]*multipart.FileHeader, rules map[string]string) IValidationError
+}
+
+// ISliceValidator 批量验证器接口
+// 职责：验证整批数据（如批量导入），逐条验证后执行批量策略（如批内唯一性）
+// 设计原则：接口隔离 - 分离批量验证职责
+type ISliceValidator interface {
+	// ValidateSlice 验证切片中的每条记录，results[i] 对应第 i 条记录（通过时为 nil）
+	// 说明：结果可直接交给 report 包导出错误清单
+	ValidateSlice(items any, scene Scene, opts ...ValidateOption) []IValidationError
 }
 
 // IStrategyManager 策略管理器接口
@@ -111,6 +178,13 @@ type IContext interface {
 	// WithDepth 创建新的上下文，增加深度
 	WithDepth(depth int) IContext
 
+	// Memo 单次验证内的记忆化：同一 key 的 fn 只执行一次，之后直接返回缓存的错误
+	// 说明：用于批量验证时去重昂贵的检查（如 200 个订单项重复检查同一商品是否存在）；并发安全
+	Memo(key string, fn func() error) error
+
+	// MemoValue 单次验证内的记忆化，同时缓存结果值
+	MemoValue(key string, fn func() (any, error)) (any, error)
+
 	// Release 释放上下文资源
 	Release()
 }
@@ -164,6 +238,9 @@ type IFieldError interface {
 	// Message 用户友好的错误消息
 	Message() string
 
+	// Severity 错误级别（默认 SeverityError）
+	Severity() Severity
+
 	// Error 实现 error 接口
 	Error() string
 }
@@ -179,6 +256,13 @@ type IErrorCollector interface {
 	// CollectAll 批量收集错误
 	CollectAll(errs []IFieldError) bool
 
+	// AddWarning 收集警告（不会使验证失败，也不占用最大错误数）
+	// 返回 false 表示警告数已达上限
+	AddWarning(err IFieldError) bool
+
+	// Warnings 获取所有警告（含 Info 级别）
+	Warnings() []IFieldError
+
 	// Errors 获取所有错误
 	Errors() []IFieldError
 
@@ -195,6 +279,13 @@ type IErrorCollector interface {
 	MaxErrors() int
 }
 
+// ITruncationAware 可报告是否丢弃过错误的收集器（可选）
+// 职责：供引擎设置验证结果的 Truncated 标记
+type ITruncationAware interface {
+	// Truncated 是否有错误因达到最大错误数被拒绝收集（Clear 后重置）
+	Truncated() bool
+}
+
 // IErrorFormatter 错误格式化器接口
 // 职责：格式化错误信息
 // 设计原则：单一职责
@@ -206,6 +297,17 @@ type IErrorFormatter interface {
 	FormatAll(errs []IFieldError) []string
 }
 
+// IClock 时钟接口
+// 职责：提供当前时间和用户时区，本地化消息中的日期按该时区显示
+// 说明：可按请求实现（如从用户资料读取时区），测试中可固定当前时间
+type IClock interface {
+	// Now 当前时间
+	Now() time.Time
+
+	// Location 用户时区
+	Location() *time.Location
+}
+
 // IValidationError 验证错误接口
 // 职责：封装验证结果和错误列表
 // 设计原则：值对象模式
@@ -224,6 +326,35 @@ type IValidationError interface {
 
 	// First 获取第一个错误
 	First() string
+
+	// Warnings 获取所有警告（含 Info 级别）
+	Warnings() []IFieldError
+
+	// RuleVersion 生成本结果所用的外部规则版本号（未使用外部规则时为空）
+	RuleVersion() string
+
+	// Truncated 是否有错误因达到上限被丢弃（错误列表不完整）
+	// 说明：达到上限后策略提前停止，未执行的验证不计入
+	Truncated() bool
+
+	// StageTimings 各阶段（策略）的执行耗时，按执行顺序排列
+	StageTimings() []StageTiming
+
+	// IsValid 是否验证通过（等价于 !HasErrors()，警告不影响结果）
+	IsValid() bool
+
+	// ByField 按字段命名空间分组错误
+	ByField() map[string][]IFieldError
+
+	// ByTag 按验证标签分组错误
+	ByTag() map[string][]IFieldError
+
+	// GroupJSON 按字段分组的机器可读 JSON（敏感值自动脱敏）
+	GroupJSON() ([]byte, error)
+
+	// Merge 合并另一个结果，返回新的结果（用于汇总多个对象的验证）
+	// other 为 nil 时返回自身
+	Merge(other IValidationError) IValidationError
 }
 
 // ============================================================================
@@ -234,10 +365,14 @@ type IValidationError interface {
 type StrategyType string
 
 const (
-	StrategyTypeRule     StrategyType = "rule"     // 规则验证
-	StrategyTypeBusiness StrategyType = "business" // 业务验证
-	StrategyTypeNested   StrategyType = "nested"   // 嵌套验证
-	StrategyTypeCustom   StrategyType = "custom"   // 自定义验证
+	StrategyTypeRule           StrategyType = "rule"            // 规则验证
+	StrategyTypeBusiness       StrategyType = "business"        // 业务验证
+	StrategyTypeNested         StrategyType = "nested"          // 嵌套验证
+	StrategyTypeCustom         StrategyType = "custom"          // 自定义验证
+	StrategyTypeRepository     StrategyType = "repository"      // 仓储验证（unique/exists）
+	StrategyTypeEmail          StrategyType = "email"           // 邮箱深度验证（MX 记录、一次性邮箱）
+	StrategyTypeUsernamePolicy StrategyType = "username_policy" // 用户名策略（保留名、敏感词、形近字）
+	StrategyTypeQueryPolicy    StrategyType = "query_policy"    // 查询字段策略（按角色限制可过滤字段）
 )
 
 // IValidationStrategy 验证策略接口
@@ -255,6 +390,67 @@ type IValidationStrategy interface {
 	Validate(target any, ctx IContext, collector IErrorCollector) error
 }
 
+// IBatchStrategy 批量验证策略接口
+// 职责：检查记录之间的约束（如同一批次中邮箱不能重复），单条记录的策略无法看到其他记录
+// 说明：只在 ValidateSlice 中执行，在逐条验证之后
+type IBatchStrategy interface {
+	// Name 策略名称
+	Name() string
+
+	// ValidateBatch 验证整批记录，通过 report(i, err) 将错误记录到第 i 条
+	ValidateBatch(items []any, ctx IContext, report func(index int, err IFieldError))
+}
+
+// IStrategyDependencies 策略依赖声明（可选）
+// 说明：
+//   - RunsAfter 返回必须先执行的策略名称（管道中为阶段名称），如依赖 password_strength 计算的规范化值
+//   - 编排器注册时按依赖拓扑排序，没有依赖约束的策略保持优先级顺序；循环依赖在注册时报错
+//   - 未注册或当前场景未执行的前置策略忽略
+type IStrategyDependencies interface {
+	RunsAfter() []string
+}
+
+// StageTiming 阶段执行耗时
+type StageTiming struct {
+	Name     string        `json:"name"`              // 阶段名称
+	Duration time.Duration `json:"duration"`          // 执行耗时
+	Errors   int           `json:"errors"`            // 该阶段新增的错误数
+	Skipped  bool          `json:"skipped,omitempty"` // 时间预算不足被跳过
+}
+
+// StageBudget 阶段时间预算
+type StageBudget struct {
+	// Weight 权重：请求带截止时间时，按权重把剩余时间分配给尚未执行的阶段；<= 0 表示不参与分配
+	Weight int
+	// Min 最低预算：分到的时间低于该值时跳过该阶段
+	Min time.Duration
+}
+
+// Budget 请求级时间预算（阶段/策略名称 -> 阶段预算）
+// 说明：只在 GoContext 带截止时间时生效；未配置的阶段不缩短、不跳过
+type Budget map[string]StageBudget
+
+// SceneStrategies 按场景筛选策略（场景 -> 策略/阶段名称）
+// 说明：验证场景与配置的场景有交集时只执行列出的策略，多个配置匹配时取并集；没有匹配的配置时执行全部策略
+type SceneStrategies map[Scene][]string
+
+// Allows 检查场景下是否执行指定名称的策略
+func (s SceneStrategies) Allows(scene Scene, name string) bool {
+	matched := false
+	for configured, names := range s {
+		if configured&scene == 0 {
+			continue
+		}
+		for _, n := range names {
+			if n == name {
+				return true
+			}
+		}
+		matched = true
+	}
+	return !matched
+}
+
 // IStrategyOrchestrator 策略编排器接口
 // 职责：管理和编排验证策略的执行顺序
 // 设计原则：责任链模式 + 策略模式
@@ -270,6 +466,23 @@ type IStrategyOrchestrator interface {
 
 	// SetExecutionMode 设置执行模式（串行/并行）
 	SetExecutionMode(mode ExecutionMode)
+
+	// SetSceneStrategies 设置按场景筛选策略（nil 表示所有场景执行全部策略）
+	SetSceneStrategies(strategies SceneStrategies)
+}
+
+// IRePanicOrchestrator 可设置策略 panic 处理方式的编排器（可选）
+// 职责：供 Builder.WithRePanic 配置开发模式
+type IRePanicOrchestrator interface {
+	// SetRePanic 设置策略 panic 时是否重新抛出（默认转换为 strategy_panic 错误，开发模式可开启）
+	SetRePanic(enabled bool)
+}
+
+// IBudgetOrchestrator 支持请求级时间预算的编排器（可选）
+// 职责：供 Builder.WithDeadlineBudget 配置阶段预算
+type IBudgetOrchestrator interface {
+	// SetBudget 设置请求级时间预算（nil 表示不限制）
+	SetBudget(budget Budget)
 }
 
 // ExecutionMode 策略执行模式
@@ -280,6 +493,14 @@ const (
 	ExecutionModeParallel                        // 并行执行
 )
 
+// CyclePolicy 嵌套验证遇到循环引用时的处理方式
+type CyclePolicy int
+
+const (
+	CyclePolicySkip   CyclePolicy = iota // 静默跳过（对象已在外层验证过）
+	CyclePolicyReport                    // 报告 circular_reference 错误
+)
+
 // ============================================================================
 // 拦截器接口
 // ============================================================================
@@ -306,6 +527,140 @@ type IInterceptorChain interface {
 	Clear()
 }
 
+// ============================================================================
+// 监听器接口
+// ============================================================================
+
+// EventType 验证事件类型
+type EventType string
+
+const (
+	EventTypeValidationStart EventType = "validation_start" // 验证开始
+	EventTypeValidationEnd   EventType = "validation_end"   // 验证结束
+	EventTypeStrategyStart   EventType = "strategy_start"   // 策略开始执行
+	EventTypeStrategyEnd     EventType = "strategy_end"     // 策略执行结束
+	EventTypeErrorAdded      EventType = "error_added"      // 收集到错误（含警告）
+	EventTypeCacheHit        EventType = "cache_hit"        // 类型信息缓存命中
+	EventTypeCacheMiss       EventType = "cache_miss"       // 类型信息缓存未命中
+	EventTypeRulesSkipped    EventType = "rules_skipped"    // 使用了 WithSkips 跳过规则（审计）
+)
+
+// Event 验证事件
+// 说明：不同类型的事件只填充相关字段
+type Event struct {
+	Type       EventType     // 事件类型
+	Target     any           // 验证目标（ValidationStart/End）
+	Strategy   string        // 策略名称（StrategyStart/End）
+	TypeName   string        // 类型名称（CacheHit/Miss）
+	FieldError IFieldError   // 字段错误（ErrorAdded）
+	Duration   time.Duration // 耗时（ValidationEnd/StrategyEnd）
+	Errors     int           // 新增的错误数（StrategyEnd）
+	Err        error         // 执行错误（ValidationEnd/StrategyEnd）
+
+	Skips         []string      // 请求跳过的规则（RulesSkipped）
+	Justification string        // 跳过规则的理由（RulesSkipped）
+	Skipped       []IFieldError // 实际被跳过的错误（RulesSkipped）
+}
+
+// IValidationListener 验证监听器接口
+// 职责：监听验证过程中的事件（观察者模式）
+// 注意：并行执行模式下 OnError 可能被并发调用，实现需要自行保证线程安全
+type IValidationListener interface {
+	// OnValidationStart 验证开始
+	OnValidationStart(ctx IContext, target any)
+
+	// OnValidationEnd 验证结束，err 为 nil 表示验证通过
+	OnValidationEnd(ctx IContext, target any, err error)
+
+	// OnError 收集到错误
+	OnError(ctx IContext, fieldErr IFieldError)
+}
+
+// IEventListener 事件监听器接口（可选）
+// 职责：接收全部细粒度事件（策略生命周期、缓存命中等），用于日志和指标
+// 说明：监听器同时实现该接口时，每个事件都会调用 OnEvent
+type IEventListener interface {
+	// OnEvent 处理事件
+	OnEvent(ctx IContext, event Event)
+}
+
+// IAuditSink 验证结果审计接口
+// 职责：记录每次验证的结果（如被拒绝的账户变更），供合规审计
+// 说明：通过 Builder.WithAuditSink 注册，在验证完成后同步调用；
+// 实现不应阻塞验证（内置实现见 audit 包，异步缓冲写入）
+type IAuditSink interface {
+	// Record 记录一次验证结果
+	// ctx 为调用方的 Go 上下文（可读取请求元数据），model 为模型类型名，result 为 nil 表示验证通过
+	Record(ctx context.Context, model string, scene Scene, result IValidationError)
+}
+
+// ============================================================================
+// 插件接口
+// ============================================================================
+
+// IPlugin 验证插件接口
+// 职责：打包一组规则、别名和策略，一次性安装到验证器
+type IPlugin interface {
+	// Name 插件名称（唯一）
+	Name() string
+
+	// Install 安装插件
+	Install(host IPluginHost) error
+}
+
+// IPluginPriority 插件优先级（可选）
+// 说明：数字越小越先安装，未实现时为 0；依赖关系优先于优先级
+type IPluginPriority interface {
+	Priority() int
+}
+
+// IPluginRequires 插件依赖声明（可选）
+// 说明：被依赖的插件先安装；依赖缺失或循环依赖时构建失败
+type IPluginRequires interface {
+	Requires() []string
+}
+
+// IPluginHost 插件安装宿主
+// 职责：为插件提供注册能力，并记录每条规则属于哪个插件
+type IPluginHost interface {
+	// RegisterAlias 注册规则别名，与其他插件重名时返回错误
+	RegisterAlias(alias, tags string) error
+
+	// RegisterValidation 注册自定义验证函数，与其他插件重名时返回错误
+	RegisterValidation(tag string, fn ValidationFunc) error
+
+	// RegisterStrategy 注册验证策略
+	RegisterStrategy(strategy IValidationStrategy, priority int)
+}
+
+// IPluginManager 插件管理接口
+// 职责：运行时启用/禁用插件
+// 说明：禁用后插件注册的规则直接通过、策略跳过执行；别名仍然展开为底层规则
+type IPluginManager interface {
+	// EnablePlugin 启用插件，依赖的插件必须已启用
+	EnablePlugin(name string) error
+
+	// DisablePlugin 禁用插件，被其他已启用插件依赖时返回错误
+	DisablePlugin(name string) error
+
+	// IsPluginEnabled 插件是否已启用
+	IsPluginEnabled(name string) bool
+
+	// Plugins 按安装顺序返回插件名称
+	Plugins() []string
+}
+
+// IValidatorIntrospector 验证器运行时信息接口（可选）
+// 职责：供管理端查看类型缓存统计与已注册的监听器
+// 说明：Builder 构建的验证器实现了该接口，通过类型断言获取
+type IValidatorIntrospector interface {
+	// CacheStats 类型信息缓存统计
+	CacheStats() CacheStats
+
+	// Listeners 按注册顺序返回监听器的类型名称
+	Listeners() []string
+}
+
 // ============================================================================
 // 基础设施接口
 // ============================================================================
@@ -324,6 +679,13 @@ type ITypeInspector interface {
 	Stats() CacheStats
 }
 
+// ICacheAwareInspector 可报告缓存命中的类型检查器（可选）
+// 职责：供策略发出 CacheHit/CacheMiss 事件
+type ICacheAwareInspector interface {
+	// InspectWithCache 检查类型信息，hit 表示是否命中缓存
+	InspectWithCache(target any) (info ITypeInfo, hit bool)
+}
+
 // ITypeInfo 类型信息接口
 // 职责：封装类型的验证能力信息
 // 设计原则：值对象模式
@@ -343,10 +705,57 @@ type ITypeInfo interface {
 	// FieldAccessor 获取字段访问器
 	FieldAccessor(fieldName string) FieldAccessor
 
+	// IsSensitive 字段是否带有 `sensitive:"true"` 标签（错误输出时脱敏）
+	IsSensitive(fieldName string) bool
+
 	// TypeName 类型名称
 	TypeName() string
 }
 
+// IRuleCache 类型信息的规则缓存（可选，ITypeInfo 实现）
+// 职责：按场景缓存模型内置规则的预解析结果，避免每次验证都调用 ValidateRules 并重新解析
+// 说明：缓存的规则只读，查找不复制
+type IRuleCache interface {
+	// LoadRules 获取场景已缓存的预解析规则，generation 不一致视为未缓存
+	LoadRules(scene Scene, generation uint64) (*CompiledRules, bool)
+
+	// StoreRules 缓存场景的预解析规则
+	StoreRules(scene Scene, compiled *CompiledRules)
+
+	// CachedRules 已缓存的各场景预解析规则（调试用）
+	CachedRules() map[Scene]*CompiledRules
+}
+
+// IRuleCacheInspector 规则缓存调试接口
+// 说明：Build 返回的验证器实现了该接口，如 validator.(v6.RuleCacheInspector).CachedRules(&User{})
+type IRuleCacheInspector interface {
+	// CachedRules 返回类型已缓存的各场景预解析规则；类型不是结构体时返回 nil
+	CachedRules(target any) map[Scene]*CompiledRules
+}
+
+// IDynamicRules 规则随实例变化的模型（可选）
+// 说明：DynamicRules 返回 true 时每次验证都调用 ValidateRules，不缓存到类型信息
+type IDynamicRules interface {
+	DynamicRules() bool
+}
+
+// CompiledRules 场景下预解析的规则
+type CompiledRules struct {
+	Generation uint64            // 编译时的规则版本（规则别名变化时递增）
+	Rules      map[string]string // 字段 -> 规则（别名已展开，只读）
+	Fields     []CompiledRule    // 按字段名排序的预解析规则（只读）
+	Repository []string          // 使用 unique/exists 仓储规则的字段（只读）
+}
+
+// CompiledRule 预解析的字段规则
+type CompiledRule struct {
+	Field    string // 规则键（字段名或路径）
+	Rule     string // 交给依赖库的规则（已去除 unique/exists 仓储规则）
+	Rest     string // 去除 required/omitempty 后的规则（字段区分"未提供"和"零值"时使用）
+	Required bool   // 是否包含 required
+	HasParam bool   // 是否包含 ${key} 参数引用（需要每次展开后重新解析）
+}
+
 // FieldAccessor 字段访问器类型
 // 通过预编译的访问器避免运行时 FieldByName 查找
 type FieldAccessor func(value any) (fieldValue any, ok bool)
@@ -385,6 +794,93 @@ type IDependencyEngine interface {
 // ValidationFunc 自定义验证函数类型
 type ValidationFunc func(value any, param string) bool
 
+// ============================================================================
+// 外部规则接口
+// ============================================================================
+
+// RuleSet 外部规则集
+// 格式：模型名 -> 场景 -> 字段名 -> 规则字符串
+type RuleSet struct {
+	Version string                                 `json:"version"` // 规则版本号
+	Models  map[string]map[Scene]map[string]string `json:"models"`  // 模型规则
+}
+
+// IRuleSource 外部规则源接口
+// 职责：从文件、etcd、HTTP 等外部配置加载规则集
+// 设计原则：依赖倒置 - 规则来源可自由替换
+type IRuleSource interface {
+	// Load 加载完整规则集
+	Load(ctx context.Context) (*RuleSet, error)
+}
+
+// IRuleProvider 外部规则提供者接口
+// 职责：为规则策略提供可热更新的规则
+type IRuleProvider interface {
+	// Rules 获取模型在指定场景下合并后的规则
+	// ok 为 false 表示该模型没有外部规则，应回退到模型内置的 ValidateRules
+	Rules(model string, scene Scene) (rules map[string]string, version string, ok bool)
+
+	// Version 当前生效的规则版本号
+	Version() string
+}
+
+// ITenantRuleProvider 租户规则覆盖提供者接口
+// 职责：多租户场景下，按租户返回覆盖在模型规则之上的规则（如租户 A 创建时要求手机号，租户 B 不要求）
+// 说明：优先级 模型 ValidateRules < IRuleProvider 外部规则 < 租户覆盖；覆盖值为空字符串表示移除该字段的规则
+type ITenantRuleProvider interface {
+	// TenantRules 获取租户在指定场景下的覆盖规则
+	// ok 为 false 表示该租户没有覆盖规则
+	TenantRules(tenantID, model string, scene Scene) (overlay map[string]string, ok bool)
+}
+
+// TenantRuleProviderFunc 租户规则覆盖函数，实现 ITenantRuleProvider 接口
+type TenantRuleProviderFunc func(tenantID, model string, scene Scene) (map[string]string, bool)
+
+// TenantRules 实现 ITenantRuleProvider 接口
+func (f TenantRuleProviderFunc) TenantRules(tenantID, model string, scene Scene) (map[string]string, bool) {
+	return f(tenantID, model, scene)
+}
+
+// ILegacyAdapter 旧版模型适配器接口
+// 职责：识别实现了旧版验证器接口的模型，映射为 IRuleValidator / IBusinessValidator，迁移引擎时无需修改模型
+// 说明：模型同时实现了 v6 接口时，优先使用 v6 接口
+type ILegacyAdapter interface {
+	// RuleValidator 模型实现了旧版规则接口时返回适配后的规则提供者
+	RuleValidator(target any) (IRuleValidator, bool)
+
+	// BusinessValidator 模型实现了旧版业务验证接口时返回适配后的业务验证器
+	BusinessValidator(target any) (IBusinessValidator, bool)
+}
+
+// ============================================================================
+// 仓储检查接口
+// ============================================================================
+
+// IUniquenessChecker 唯一性检查接口
+// 职责：为 unique=table.column 规则批量查询已被占用的值
+// 设计原则：适配器模式 - 由业务方对接数据库、Redis 等存储
+type IUniquenessChecker interface {
+	// CheckUnique 批量查询 values 中已被占用的值
+	// 参数：exclude 为被验证记录自身的标识（见 IRecordIdentifier），不为 nil 时该记录占用的值不算冲突
+	// 返回值：已被占用的值集合（未出现在结果中的值视为唯一）
+	CheckUnique(ctx context.Context, table, column string, values []any, exclude any) (taken map[any]bool, err error)
+}
+
+// IRecordIdentifier 记录标识接口（模型可选实现）
+// 职责：更新场景下提供记录自身的标识，唯一性检查据此排除自身，避免未修改的值被判为冲突
+type IRecordIdentifier interface {
+	// RecordID 记录标识（如主键）；新记录返回 nil 或零值
+	RecordID() any
+}
+
+// IExistenceChecker 存在性检查接口
+// 职责：为 exists=table.column 规则批量查询存在的值（如外键）
+type IExistenceChecker interface {
+	// CheckExists 批量查询 values 中存在的值
+	// 返回值：存在的值集合（未出现在结果中的值视为不存在）
+	CheckExists(ctx context.Context, table, column string, values []any) (found map[any]bool, err error)
+}
+
 // ============================================================================
 // 缓存相关接口
 // ============================================================================
@@ -0,0 +1,23 @@
+package core
+
+import "context"
+
+// ============================================================================
+// 规则参数引用
+// ============================================================================
+
+// IParamResolver 规则参数解析器接口
+// 职责：解析规则参数中的 ${key} 引用（如 max=${limits.username_max}），使不同租户使用不同的限制
+// 说明：ctx 为调用方通过 WithContext 传入的上下文（可从中取租户等信息），未传入时为 context.Background()
+type IParamResolver interface {
+	// ResolveParam 解析参数引用，ok 为 false 表示未配置该值
+	ResolveParam(ctx context.Context, key string) (value string, ok bool)
+}
+
+// ParamResolverFunc 参数解析函数，实现 IParamResolver 接口
+type ParamResolverFunc func(ctx context.Context, key string) (string, bool)
+
+// ResolveParam 实现 IParamResolver 接口
+func (f ParamResolverFunc) ResolveParam(ctx context.Context, key string) (string, bool) {
+	return f(ctx, key)
+}
@@ -0,0 +1,128 @@
+package core
+
+import "context"
+
+// ============================================================================
+// 验证配置档
+// ============================================================================
+
+// 内置配置档名称
+const (
+	ProfileStrict = "strict" // 遇到第一个错误即停止，不返回警告（适合 API 请求）
+	ProfileImport = "import" // 最多收集 100 个错误，返回警告（适合批量导入）
+)
+
+// Profile 验证配置档
+// 职责：同一个验证器实例在不同调用路径上使用不同的行为（如 API 和批量导入）
+// 设计原则：值对象模式，零值字段表示沿用验证器的默认配置
+type Profile struct {
+	// Name 配置档名称，调用时通过 WithProfile(name) 选择
+	Name string
+
+	// MaxErrors 最大错误数（0 表示使用验证器的默认值，1 即 fail-fast）
+	MaxErrors int
+
+	// DisableWarnings 不返回警告（Warning/Info 级别的错误被丢弃）
+	DisableWarnings bool
+}
+
+// DefaultProfiles 内置配置档
+func DefaultProfiles() []Profile {
+	return []Profile{
+		{Name: ProfileStrict, MaxErrors: 1, DisableWarnings: true},
+		{Name: ProfileImport, MaxErrors: 100},
+	}
+}
+
+// ValidateOptions 单次验证的选项
+type ValidateOptions struct {
+	// Profile 使用的配置档名称（为空表示使用验证器的默认配置）
+	Profile string
+
+	// Context 本次验证的 Go 上下文（策略通过 IContext.GoContext() 获取）
+	Context context.Context
+
+	// Skips 本次验证跳过的规则（"命名空间:标签"，如 "User.Username:reserved"）
+	Skips []string
+
+	// SkipJustification 跳过规则的理由（设置 Skips 时必填，随审计事件发出）
+	SkipJustification string
+
+	// Fields 只验证的字段（规则键或字段路径，为空表示全部字段）
+	Fields []string
+
+	// ExcludeFields 不验证的字段
+	ExcludeFields []string
+}
+
+// ValidateOption 单次验证选项
+type ValidateOption func(*ValidateOptions)
+
+// WithProfile 使用指定的配置档执行本次验证
+func WithProfile(name string) ValidateOption {
+	return func(o *ValidateOptions) {
+		o.Profile = name
+	}
+}
+
+// WithContext 设置本次验证的 Go 上下文
+// 说明：用于传递租户、请求 ID、超时等信息，如规则参数解析器按租户返回不同的限制
+func WithContext(ctx context.Context) ValidateOption {
+	return func(o *ValidateOptions) {
+		o.Context = ctx
+	}
+}
+
+// WithSkips 跳过指定规则（管理工具的逃生通道，如为内部账号使用保留用户名）
+// 说明：
+//   - 格式为 "命名空间:标签"，命名空间不区分大小写；标签也可以是错误参数（如 username_policy 的违规原因 reserved）
+//   - 必须同时通过 WithSkipJustification 提供理由，否则验证直接返回 skip 错误
+//   - 每次使用都会向监听器发出 EventTypeRulesSkipped 审计事件
+//
+// 使用示例：
+//
+//	validator.Validate(user, SceneCreate,
+//	    v6.WithSkips("User.Username:reserved"),
+//	    v6.WithSkipJustification("internal account, ticket OPS-123"))
+func WithSkips(skips ...string) ValidateOption {
+	return func(o *ValidateOptions) {
+		o.Skips = append(o.Skips, skips...)
+	}
+}
+
+// WithSkipJustification 设置跳过规则的理由
+func WithSkipJustification(justification string) ValidateOption {
+	return func(o *ValidateOptions) {
+		o.SkipJustification = justification
+	}
+}
+
+// WithFields 只验证指定字段（json 名称、Go 字段名或路径，如 "profile.email"）
+// 说明：规则选择阶段即排除其他字段，不会编译和执行它们的规则；业务验证器和自定义策略通过 FieldSet 获取有效字段
+func WithFields(fields ...string) ValidateOption {
+	return func(o *ValidateOptions) {
+		o.Fields = append(o.Fields, fields...)
+	}
+}
+
+// WithExcludeFields 不验证指定字段（与 WithFields 同时使用时，先按 WithFields 选择再排除）
+func WithExcludeFields(fields ...string) ValidateOption {
+	return func(o *ValidateOptions) {
+		o.ExcludeFields = append(o.ExcludeFields, fields...)
+	}
+}
+
+// ApplyValidateOptions 应用单次验证选项
+func ApplyValidateOptions(opts []ValidateOption) ValidateOptions {
+	if len(opts) == 0 {
+		return ValidateOptions{}
+	}
+
+	var options ValidateOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&options)
+		}
+	}
+	return options
+}
@@ -0,0 +1,39 @@
+package core
+
+// ============================================================================
+// 请求元数据
+// ============================================================================
+
+// RequestMetadata 请求元数据
+// 职责：把调用方信息（操作人、租户、来源 IP 等）传给策略，用于风控、限流、审计等检查，
+// 不必为此在模型中添加字段
+// 说明：由 HTTP/gRPC 接入层写入 Go 上下文（见 context.WithRequestMetadata），
+// 通过 WithContext 传给验证器后，策略使用 context.RequestMetadata(ctx) 读取；零值字段表示未知
+type RequestMetadata struct {
+	ActorID   string // 操作人 ID（已认证的用户或服务账号）
+	TenantID  string // 租户 ID
+	IP        string // 客户端 IP
+	UserAgent string // 客户端 User-Agent
+	RequestID string // 请求 ID（用于日志关联）
+}
+
+// IsZero 是否未设置任何字段
+func (m RequestMetadata) IsZero() bool {
+	return m == RequestMetadata{}
+}
+
+// Merge 用 other 的非空字段填充空字段（已有字段优先）
+// 说明：认证中间件写入的 ActorID、TenantID 与接入层解析的 IP、RequestID 合并时使用
+func (m RequestMetadata) Merge(other RequestMetadata) RequestMetadata {
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fill(&m.ActorID, other.ActorID)
+	fill(&m.TenantID, other.TenantID)
+	fill(&m.IP, other.IP)
+	fill(&m.UserAgent, other.UserAgent)
+	fill(&m.RequestID, other.RequestID)
+	return m
+}
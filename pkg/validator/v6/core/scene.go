@@ -1,5 +1,13 @@
 package core
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
 // Scene 验证场景，使用位运算支持场景组合
 // 设计原则：值对象模式，不可变且线程安全
 type Scene int64
@@ -8,8 +16,28 @@ type Scene int64
 const (
 	SceneNone Scene = 0  // 无场景
 	SceneAll  Scene = -1 // 所有场景（全 1）
+
+	// SceneAuto 自动场景：由验证器配置的 ISceneResolver 根据对象状态决定场景
+	// 说明：占用最高位，业务场景不要使用第 63 位
+	SceneAuto Scene = -1 << 63
 )
 
+// ISceneResolver 场景解析器接口
+// 职责：调用方传入 SceneAuto 时，根据对象状态决定实际场景（如 ID 为零值视为创建）
+// 说明：返回 SceneNone 或 SceneAuto 表示无法解析，验证器会返回 scene 错误
+type ISceneResolver interface {
+	// ResolveScene 解析对象的验证场景
+	ResolveScene(obj any) Scene
+}
+
+// SceneResolverFunc 场景解析函数，实现 ISceneResolver 接口
+type SceneResolverFunc func(obj any) Scene
+
+// ResolveScene 实现 ISceneResolver 接口
+func (f SceneResolverFunc) ResolveScene(obj any) Scene {
+	return f(obj)
+}
+
 // Has 检查是否包含指定场景
 // 使用位运算，性能优异 O(1)
 func (s Scene) Has(scene Scene) bool {
@@ -30,3 +58,211 @@ func (s Scene) Add(scene Scene) Scene {
 func (s Scene) Remove(scene Scene) Scene {
 	return s &^ scene
 }
+
+// ============================================================================
+// 场景名称注册表
+// ============================================================================
+
+// 内置场景名称（保留，不能注册）
+const (
+	sceneNameNone = "none"
+	sceneNameAll  = "all"
+	sceneNameAuto = "auto"
+)
+
+var (
+	sceneNamesMu sync.RWMutex
+	sceneNames   = make(map[Scene]string) // 单个场景位 -> 名称
+	sceneByName  = make(map[string]Scene) // 名称 -> 场景位
+)
+
+// RegisterScene 注册场景名称，用于日志输出、HTTP 参数和 JSON 中的场景名称
+// 说明：
+//   - scene 必须是单个位（组合场景由名称用 "|" 拼接表示）；名称不能为空，不能包含 "|" 和空白，
+//     不能使用保留名称 none、all、auto
+//   - 同一场景重复注册相同名称时忽略；名称或场景已被占用时返回错误
+//
+// 示例：
+//
+//	RegisterScene(SceneCreate, "create")
+//	RegisterScene(SceneUpdate, "update")
+//	(SceneCreate | SceneUpdate).String() // "create|update"
+func RegisterScene(scene Scene, name string) error {
+	if scene == SceneNone || scene == SceneAuto || scene&(scene-1) != 0 {
+		return fmt.Errorf("scene %d must be a single bit", int64(scene))
+	}
+	if name == "" || strings.ContainsAny(name, "| \t\r\n") {
+		return fmt.Errorf("scene name %q cannot be empty or contain '|' or spaces", name)
+	}
+	if isReservedSceneName(name) {
+		return fmt.Errorf("scene name %q is reserved", name)
+	}
+
+	sceneNamesMu.Lock()
+	defer sceneNamesMu.Unlock()
+
+	if existing, ok := sceneByName[name]; ok && existing != scene {
+		return fmt.Errorf("scene name %q is already registered for scene %d", name, int64(existing))
+	}
+	if existing, ok := sceneNames[scene]; ok && existing != name {
+		return fmt.Errorf("scene %d is already registered as %q", int64(scene), existing)
+	}
+	sceneNames[scene] = name
+	sceneByName[name] = scene
+	return nil
+}
+
+// MustRegisterScene 注册场景名称，失败时 panic（用于包初始化）
+func MustRegisterScene(scene Scene, name string) {
+	if err := RegisterScene(scene, name); err != nil {
+		panic(err)
+	}
+}
+
+// UnregisterScene 注销场景名称（未注册时忽略）
+func UnregisterScene(scene Scene) {
+	sceneNamesMu.Lock()
+	defer sceneNamesMu.Unlock()
+
+	if name, ok := sceneNames[scene]; ok {
+		delete(sceneNames, scene)
+		delete(sceneByName, name)
+	}
+}
+
+// LookupScene 按名称查找已注册的场景（含内置名称 none、all、auto）
+func LookupScene(name string) (Scene, bool) {
+	switch name {
+	case sceneNameNone:
+		return SceneNone, true
+	case sceneNameAll:
+		return SceneAll, true
+	case sceneNameAuto:
+		return SceneAuto, true
+	}
+
+	sceneNamesMu.RLock()
+	defer sceneNamesMu.RUnlock()
+	scene, ok := sceneByName[name]
+	return scene, ok
+}
+
+// ParseScene 解析场景字符串
+// 说明：
+//   - 支持名称组合（"create|update"）、十进制数值（"3"）以及二者混用，各部分按位或
+//   - 名称区分大小写，前后空白忽略
+//
+// 返回值：为空或包含未注册的名称时返回错误
+func ParseScene(s string) (Scene, error) {
+	if strings.TrimSpace(s) == "" {
+		return SceneNone, fmt.Errorf("scene cannot be empty")
+	}
+
+	var scene Scene
+	for _, part := range strings.Split(s, "|") {
+		part = strings.TrimSpace(part)
+		if named, ok := LookupScene(part); ok {
+			scene |= named
+			continue
+		}
+		value, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return SceneNone, fmt.Errorf("unknown scene %q", part)
+		}
+		scene |= Scene(value)
+	}
+	return scene, nil
+}
+
+// String 实现 fmt.Stringer 接口
+// 说明：已注册的位输出名称并按位从低到高用 "|" 拼接，未注册的位输出数值（如 "create|8"）；
+// 没有任何已注册的位时输出十进制数值，与未注册时的日志保持一致
+func (s Scene) String() string {
+	name, _ := s.name()
+	return name
+}
+
+// name 场景名称，named 表示所有位都有名称
+func (s Scene) name() (name string, named bool) {
+	switch s {
+	case SceneNone:
+		return sceneNameNone, true
+	case SceneAll:
+		return sceneNameAll, true
+	case SceneAuto:
+		return sceneNameAuto, true
+	}
+
+	sceneNamesMu.RLock()
+	defer sceneNamesMu.RUnlock()
+
+	if len(sceneNames) == 0 {
+		return strconv.FormatInt(int64(s), 10), false
+	}
+
+	parts := make([]string, 0, 4)
+	named = true
+	anyNamed := false
+	for bit := 0; bit < 64; bit++ {
+		b := Scene(1) << bit
+		if s&b == 0 {
+			continue
+		}
+		if n, ok := sceneNames[b]; ok {
+			parts = append(parts, n)
+			anyNamed = true
+			continue
+		}
+		parts = append(parts, strconv.FormatUint(uint64(b), 10))
+		named = false
+	}
+	if !anyNamed {
+		return strconv.FormatInt(int64(s), 10), false
+	}
+	return strings.Join(parts, "|"), named
+}
+
+// MarshalJSON 实现 json.Marshaler 接口
+// 说明：所有位都注册了名称时输出名称字符串（如 "create|update"），否则输出数值（内置场景同样输出数值），
+// 兼容未注册名称的调用方
+func (s Scene) MarshalJSON() ([]byte, error) {
+	if s != SceneNone && s != SceneAll && s != SceneAuto {
+		if name, named := s.name(); named {
+			return json.Marshal(name)
+		}
+	}
+	return []byte(strconv.FormatInt(int64(s), 10)), nil
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，同时接受数值和字符串（见 ParseScene）
+func (s *Scene) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		return s.UnmarshalText([]byte(text))
+	}
+
+	var value int64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("invalid scene %s: %w", data, err)
+	}
+	*s = Scene(value)
+	return nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler 接口（用于查询参数、表单和配置文件）
+func (s *Scene) UnmarshalText(text []byte) error {
+	scene, err := ParseScene(string(text))
+	if err != nil {
+		return err
+	}
+	*s = scene
+	return nil
+}
+
+// isReservedSceneName 是否为内置场景名称
+func isReservedSceneName(name string) bool {
+	return name == sceneNameNone || name == sceneNameAll || name == sceneNameAuto
+}
@@ -0,0 +1,30 @@
+package core
+
+// Severity 错误级别
+// 说明：只有 SeverityError 会使验证失败，Warning/Info 仅作为提示返回
+type Severity int
+
+const (
+	SeverityError   Severity = iota // 错误：验证失败
+	SeverityWarning                 // 警告：验证通过，但需提示（如弱密码、字段已废弃）
+	SeverityInfo                    // 信息：仅供参考
+)
+
+// String 实现 Stringer 接口
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+// IsBlocking 是否阻断验证（即是否为错误级别）
+func (s Severity) IsBlocking() bool {
+	return s == SeverityError
+}
@@ -0,0 +1,215 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ============================================================================
+// 验证标签
+// ============================================================================
+
+// Tag 验证标签（规则中的标签名，也是字段错误 Tag() 的值）
+// 说明：IFieldError.Tag() 仍返回 string，比较时转换：core.Tag(fe.Tag()) == core.TagRequired
+type Tag string
+
+// String 实现 fmt.Stringer 接口
+func (t Tag) String() string {
+	return string(t)
+}
+
+// 常用标签（go-playground 内置规则与 v6 规则）
+const (
+	TagRequired  Tag = "required"
+	TagMin       Tag = "min"
+	TagMax       Tag = "max"
+	TagLen       Tag = "len"
+	TagEq        Tag = "eq"
+	TagNe        Tag = "ne"
+	TagGt        Tag = "gt"
+	TagGte       Tag = "gte"
+	TagLt        Tag = "lt"
+	TagLte       Tag = "lte"
+	TagOneOf     Tag = "oneof"
+	TagEmail     Tag = "email"
+	TagURL       Tag = "url"
+	TagUUID      Tag = "uuid"
+	TagAlpha     Tag = "alpha"
+	TagAlphaNum  Tag = "alphanum"
+	TagNumeric   Tag = "numeric"
+	TagE164      Tag = "e164"
+	TagEqField   Tag = "eqfield"
+	TagUnique    Tag = "unique" // 切片元素不重复，或仓储规则 unique=table.column
+	TagExists    Tag = "exists" // 仓储规则 exists=table.column
+	TagEnum      Tag = "enum"
+	TagRegex     Tag = "regex"
+	TagDatetime  Tag = "datetime"
+	TagLowercase Tag = "lowercase"
+	TagUppercase Tag = "uppercase"
+)
+
+// builtinTags 预先注册的标签：go-playground v10 内置规则、别名，以及 v6 各包报告的错误标签
+var builtinTags = []string{
+	// go-playground 内置规则
+	"required", "required_if", "required_unless", "skip_unless", "required_with", "required_with_all",
+	"required_without", "required_without_all", "excluded_if", "excluded_unless", "excluded_with",
+	"excluded_with_all", "excluded_without", "excluded_without_all", "isdefault", "len", "min", "max",
+	"eq", "eq_ignore_case", "ne", "ne_ignore_case", "lt", "lte", "gt", "gte", "eqfield", "eqcsfield",
+	"necsfield", "gtcsfield", "gtecsfield", "ltcsfield", "ltecsfield", "nefield", "gtefield", "gtfield",
+	"ltefield", "ltfield", "fieldcontains", "fieldexcludes", "alpha", "alphanum", "alphaunicode",
+	"alphanumunicode", "boolean", "numeric", "number", "hexadecimal", "hexcolor", "rgb", "rgba", "hsl",
+	"hsla", "e164", "email", "url", "http_url", "uri", "urn_rfc2141", "file", "filepath", "base64",
+	"base64url", "base64rawurl", "contains", "containsany", "containsrune", "excludes", "excludesall",
+	"excludesrune", "startswith", "endswith", "startsnotwith", "endsnotwith", "image", "isbn", "isbn10",
+	"isbn13", "eth_addr", "eth_addr_checksum", "btc_addr", "btc_addr_bech32", "uuid", "uuid3", "uuid4",
+	"uuid5", "uuid_rfc4122", "uuid3_rfc4122", "uuid4_rfc4122", "uuid5_rfc4122", "ulid", "md4", "md5",
+	"sha256", "sha384", "sha512", "ripemd128", "ripemd160", "tiger128", "tiger160", "tiger192", "ascii",
+	"printascii", "multibyte", "datauri", "latitude", "longitude", "ssn", "ipv4", "ipv6", "ip", "cidrv4",
+	"cidrv6", "cidr", "tcp4_addr", "tcp6_addr", "tcp_addr", "udp4_addr", "udp6_addr", "udp_addr",
+	"ip4_addr", "ip6_addr", "ip_addr", "unix_addr", "mac", "hostname", "hostname_rfc1123", "fqdn",
+	"unique", "oneof", "html", "html_encoded", "url_encoded", "dir", "dirpath", "json", "jwt",
+	"hostname_port", "lowercase", "uppercase", "datetime", "timezone", "iso3166_1_alpha2",
+	"iso3166_1_alpha3", "iso3166_1_alpha_numeric", "iso3166_2", "iso4217", "iso4217_numeric",
+	"bcp47_language_tag", "postcode_iso3166_alpha2", "postcode_iso3166_alpha2_field", "bic", "semver",
+	"dns_rfc1035_label", "credit_card", "cve", "luhn_checksum", "mongodb", "cron",
+	// go-playground 内置别名
+	"iscolor", "country_code",
+	// v6 规则
+	"enum", "regex", "exists", "max_size", "mimes", "max_files", "money_gt", "money_gte", "money_lt",
+	"money_lte", "phone", "timerange", "sortable", "username_policy",
+	// v6 报告的错误标签（见各包的 Tag 常量）
+	"coerced", "param", "self", "scene", "skip", "diff", "profile", "rule_engine", "more_errors",
+	"strategy_panic", "validation_degraded", "nested_panic", "circular_reference", "batch_duplicate",
+	"field_not_queryable", "email_undeliverable", "email_disposable", "model", "decode", "panic", "degraded",
+}
+
+// ruleKeywords 规则中的控制关键字（不是验证标签，检查规则时跳过）
+var ruleKeywords = map[string]bool{
+	"omitempty": true, "omitnil": true, "dive": true, "keys": true, "endkeys": true,
+	"structonly": true, "nostructlevel": true, "-": true,
+}
+
+var (
+	tagsMu     sync.RWMutex
+	tagSet     = make(map[string]bool)
+	strictTags atomic.Bool
+)
+
+func init() {
+	for _, tag := range builtinTags {
+		tagSet[tag] = true
+	}
+}
+
+// RegisterTag 注册自定义标签（自定义规则、业务策略报告的错误标签）
+// 说明：
+//   - 标签不能为空，不能包含 ","、"|"、"=" 和空白；重复注册时忽略
+//   - RegisterValidation、RegisterRuleAlias 注册的名称自动注册为标签
+func RegisterTag(tags ...Tag) error {
+	for _, tag := range tags {
+		if tag == "" || strings.ContainsAny(string(tag), ",|= \t\r\n") {
+			return fmt.Errorf("tag %q cannot be empty or contain ',', '|', '=' or spaces", tag)
+		}
+		if ruleKeywords[string(tag)] {
+			return fmt.Errorf("tag %q is a reserved rule keyword", tag)
+		}
+	}
+
+	tagsMu.Lock()
+	defer tagsMu.Unlock()
+	for _, tag := range tags {
+		tagSet[string(tag)] = true
+	}
+	return nil
+}
+
+// MustRegisterTag 注册自定义标签，失败时 panic（用于包初始化）
+func MustRegisterTag(tags ...Tag) {
+	if err := RegisterTag(tags...); err != nil {
+		panic(err)
+	}
+}
+
+// IsRegisteredTag 标签是否已注册（含预先注册的内置标签）
+func IsRegisteredTag(tag string) bool {
+	tagsMu.RLock()
+	defer tagsMu.RUnlock()
+	return tagSet[tag]
+}
+
+// RegisteredTags 所有已注册的标签（按名称排序）
+func RegisteredTags() []Tag {
+	tagsMu.RLock()
+	defer tagsMu.RUnlock()
+
+	tags := make([]Tag, 0, len(tagSet))
+	for tag := range tagSet {
+		tags = append(tags, Tag(tag))
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+	return tags
+}
+
+// ParseTag 解析已注册的标签（如客户端收到的错误标签）
+func ParseTag(s string) (Tag, error) {
+	if !IsRegisteredTag(s) {
+		return "", fmt.Errorf("unknown tag %q", s)
+	}
+	return Tag(s), nil
+}
+
+// SetStrictTags 设置严格标签模式
+// 说明：开启后，规则中包含未注册标签时注册阶段即返回错误（RegisterRuleAlias、binding.ModelRegistry.Register），
+// 拼写错误（如 requried）在启动时发现，而不是在验证时 panic 或返回客户端无法识别的标签
+func SetStrictTags(strict bool) {
+	strictTags.Store(strict)
+}
+
+// StrictTags 是否开启严格标签模式
+func StrictTags() bool {
+	return strictTags.Load()
+}
+
+// UnregisteredTags 规则中未注册的标签（按出现顺序去重）
+// 说明：规则格式为 "required,min=3,email|e164"，跳过 omitempty、dive 等控制关键字，参数不检查
+func UnregisteredTags(rule string) []string {
+	var unknown []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(rule, ",") {
+		for _, tag := range strings.Split(part, "|") {
+			name, _, _ := strings.Cut(strings.TrimSpace(tag), "=")
+			if name == "" || ruleKeywords[name] || seen[name] {
+				continue
+			}
+			seen[name] = true
+			if !IsRegisteredTag(name) {
+				unknown = append(unknown, name)
+			}
+		}
+	}
+	return unknown
+}
+
+// CheckRuleTags 检查规则表中的标签是否都已注册
+// 返回值：有未注册的标签时返回错误（按字段名排序，列出字段和标签）
+func CheckRuleTags(rules map[string]string) error {
+	fields := make([]string, 0, len(rules))
+	for field := range rules {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var problems []string
+	for _, field := range fields {
+		if unknown := UnregisteredTags(rules[field]); len(unknown) > 0 {
+			problems = append(problems, fmt.Sprintf("%s: %s", field, strings.Join(unknown, ", ")))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("unregistered tags (%s)", strings.Join(problems, "; "))
+	}
+	return nil
+}
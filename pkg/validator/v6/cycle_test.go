@@ -0,0 +1,107 @@
+package v6_test
+
+import (
+	"reflect"
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// graphNode 可能自引用的模型（Manager、Mentor 为接口类型，由嵌套策略按运行时类型验证）
+type graphNode struct {
+	Name    string `json:"name"`
+	Manager any    `json:"manager"`
+	Mentor  any    `json:"mentor"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (n *graphNode) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{"name": "required"}
+}
+
+// graphErrors 验证并返回 "命名空间: 标签" 列表
+func graphErrors(t *testing.T, policy core.CyclePolicy, root *graphNode) []string {
+	t.Helper()
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithNestedStrategy(20).
+		WithCyclePolicy(policy).
+		Build()
+
+	var got []string
+	if err := validator.Validate(root, core.SceneAll); err != nil {
+		for _, fe := range err.FieldErrors() {
+			got = append(got, fe.Namespace()+": "+fe.Tag())
+		}
+	}
+	return got
+}
+
+// TestCycle_SelfReference 模型引用自身
+func TestCycle_SelfReference(t *testing.T) {
+	root := &graphNode{}
+	root.Manager = root
+
+	if got, want := graphErrors(t, v6.CyclePolicySkip, root), []string{"graphNode.name: required"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("skip: got %v, want %v", got, want)
+	}
+
+	want := []string{
+		"graphNode.name: required",
+		"graphNode.manager(graphNode): circular_reference",
+	}
+	if got := graphErrors(t, v6.CyclePolicyReport, root); !reflect.DeepEqual(got, want) {
+		t.Errorf("report: got %v, want %v", got, want)
+	}
+}
+
+// TestCycle_TwoNodes 两个模型互相引用
+func TestCycle_TwoNodes(t *testing.T) {
+	a := &graphNode{Name: "a"}
+	b := &graphNode{Manager: a}
+	a.Manager = b
+
+	want := []string{
+		"graphNode.manager(graphNode).name: required",
+		"graphNode.manager(graphNode).manager(graphNode): circular_reference",
+	}
+	if got := graphErrors(t, v6.CyclePolicyReport, a); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// TestCycle_SharedIsNotCycle 同一对象被多个字段引用时分别验证，不算循环
+func TestCycle_SharedIsNotCycle(t *testing.T) {
+	shared := &graphNode{}
+	root := &graphNode{Name: "root", Manager: shared, Mentor: shared}
+
+	want := []string{
+		"graphNode.manager(graphNode).name: required",
+		"graphNode.mentor(graphNode).name: required",
+	}
+	if got := graphErrors(t, v6.CyclePolicyReport, root); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// TestCycle_LongChain 没有循环的长链按深度限制正常验证
+func TestCycle_LongChain(t *testing.T) {
+	root := &graphNode{Name: "0"}
+	node := root
+	for i := 0; i < 3; i++ {
+		next := &graphNode{Name: "n"}
+		node.Manager = next
+		node = next
+	}
+	node.Name = ""
+	node.Manager = root // 链尾指回链首
+
+	want := []string{
+		"graphNode.manager(graphNode).manager(graphNode).manager(graphNode).name: required",
+		"graphNode.manager(graphNode).manager(graphNode).manager(graphNode).manager(graphNode): circular_reference",
+	}
+	if got := graphErrors(t, v6.CyclePolicyReport, root); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
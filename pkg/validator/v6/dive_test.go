@@ -0,0 +1,86 @@
+package v6_test
+
+import (
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// post 带切片和 map 元素规则的模型（规则键使用 Go 字段名）
+type post struct {
+	Title  string            `json:"title"`
+	Tags   []string          `json:"tags"`
+	Labels map[string]string `json:"labels"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (p *post) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{
+		"Title":  "required",
+		"Tags":   "required,dive,min=2,max=8",
+		"Labels": "dive,keys,min=2,endkeys,required",
+	}
+}
+
+// diveErrors 错误的 "命名空间 字段 标签"
+func diveErrors(err v6.ValidationError) []string {
+	if err == nil {
+		return nil
+	}
+	var got []string
+	for _, fe := range err.FieldErrors() {
+		got = append(got, fe.Namespace()+" "+fe.Field()+" "+fe.Tag())
+	}
+	return got
+}
+
+// TestDive_ElementIndex 测试 dive 元素错误的命名空间和字段带下标
+func TestDive_ElementIndex(t *testing.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+
+	tests := []struct {
+		name  string
+		model *post
+		want  []string
+	}{
+		{"切片元素", &post{Title: "t", Tags: []string{"go", "x", "toolongtag"}},
+			[]string{"post.Tags[1] Tags[1] min", "post.Tags[2] Tags[2] max"}},
+		{"map 键和值", &post{Title: "t", Tags: []string{"go"}, Labels: map[string]string{"k": "v"}},
+			[]string{"post.Labels[k] Labels[k] min"}},
+		{"map 值", &post{Title: "t", Tags: []string{"go"}, Labels: map[string]string{"env": ""}},
+			[]string{"post.Labels[env] Labels[env] required"}},
+		{"dive 之前的 required 作用于字段本身", &post{Title: "t"},
+			[]string{"post.Tags Tags required"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diveErrors(validator.Validate(tt.model, SceneCreate))
+			if !equalStrings(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDive_FieldPaths 测试带下标的字段路径匹配规则键，按整个字段验证
+func TestDive_FieldPaths(t *testing.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+	fv := validator.(v6.FieldValidator)
+
+	model := &post{Tags: []string{"go", "x"}}
+	want := []string{"post.Tags[1] Tags[1] min"}
+
+	for _, field := range []string{"Tags[1]", "Tags"} {
+		if got := diveErrors(fv.ValidateFields(model, SceneCreate, field)); !equalStrings(got, want) {
+			t.Errorf("ValidateFields(%q) = %v, want %v", field, got, want)
+		}
+	}
+
+	// 变化的 json 路径 tags[1] 匹配规则键 Tags，未变化的 Title 不验证
+	old := &post{Tags: []string{"go", "ok"}}
+	if got := diveErrors(fv.ValidateChanges(old, model, SceneCreate)); !equalStrings(got, want) {
+		t.Errorf("ValidateChanges() = %v, want %v", got, want)
+	}
+}
@@ -1,9 +1,18 @@
 package engine
 
 import (
+	stdcontext "context"
+	"fmt"
+	"katydid-common-account/pkg/types"
 	"katydid-common-account/pkg/validator/v6/context"
 	"katydid-common-account/pkg/validator/v6/core"
 	"katydid-common-account/pkg/validator/v6/errors"
+	"katydid-common-account/pkg/validator/v6/strategy"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
 )
 
 // validatorEngine 验证引擎实现
@@ -21,8 +30,30 @@ type validatorEngine struct {
 	errorFormatter core.IErrorFormatter
 	// 最大错误数
 	maxErrors int
+	// 保留详情的错误数（0 表示全部保留，超出部分汇总为一条错误）
+	detailLimit int
 	// 最大验证深度
 	maxDepth int
+	// 验证监听器
+	listeners []core.IValidationListener
+	// 插件管理器
+	plugins core.IPluginManager
+	// 类型检查器（只用于运行时信息查看）
+	inspector core.ITypeInspector
+	// 场景解析器（处理 SceneAuto）
+	sceneResolver core.ISceneResolver
+	// 配置档（名称 -> 配置档）
+	profiles map[string]core.Profile
+	// 快速路径场景（跳过拦截器和监听器）
+	fastPathScenes core.Scene
+	// 规则引擎（Map 验证使用）
+	ruleEngine core.IDependencyEngine
+	// Map 验证使用弱类型模式（表单请求体）
+	weakTyping bool
+	// 批量验证策略（ValidateSlice 使用）
+	batchStrategies []core.IBatchStrategy
+	// 默认上下文选项（没有单次验证选项时复用，避免分配）
+	baseContextOptions []context.ContextOption
 }
 
 // NewValidatorEngine 创建验证引擎
@@ -45,6 +76,9 @@ func NewValidatorEngine(
 	if engine.errorFormatter == nil {
 		engine.errorFormatter = errors.NewDefaultFormatter()
 	}
+	if len(engine.listeners) > 0 {
+		engine.baseContextOptions = []context.ContextOption{context.WithListeners(engine.listeners...)}
+	}
 
 	return engine
 }
@@ -73,6 +107,28 @@ func WithMaxErrors(maxErrors int) EngineOption {
 	}
 }
 
+// WithWeakTyping 设置 Map 验证使用弱类型模式（数字和布尔字符串在验证前转换，见 strategy.WithWeakTyping）
+func WithWeakTyping(weakTyping bool) EngineOption {
+	return func(e *validatorEngine) {
+		e.weakTyping = weakTyping
+	}
+}
+
+// WithBatchStrategies 添加批量验证策略（ValidateSlice 在逐条验证后按顺序执行）
+func WithBatchStrategies(strategies ...core.IBatchStrategy) EngineOption {
+	return func(e *validatorEngine) {
+		e.batchStrategies = append(e.batchStrategies, strategies...)
+	}
+}
+
+// WithErrorDetailLimit 设置保留详情的错误数
+// 说明：小于最大错误数时使用有界收集器，超出部分只计数并汇总为一条 more_errors 错误
+func WithErrorDetailLimit(detailLimit int) EngineOption {
+	return func(e *validatorEngine) {
+		e.detailLimit = detailLimit
+	}
+}
+
 // WithMaxDepth 设置最大深度
 func WithMaxDepth(maxDepth int) EngineOption {
 	return func(e *validatorEngine) {
@@ -80,9 +136,321 @@ func WithMaxDepth(maxDepth int) EngineOption {
 	}
 }
 
+// WithListeners 添加验证监听器
+func WithListeners(listeners ...core.IValidationListener) EngineOption {
+	return func(e *validatorEngine) {
+		e.listeners = append(e.listeners, listeners...)
+	}
+}
+
+// WithPluginManager 设置插件管理器
+func WithPluginManager(plugins core.IPluginManager) EngineOption {
+	return func(e *validatorEngine) {
+		e.plugins = plugins
+	}
+}
+
+// WithTypeInspector 设置类型检查器（用于暴露缓存统计）
+func WithTypeInspector(inspector core.ITypeInspector) EngineOption {
+	return func(e *validatorEngine) {
+		e.inspector = inspector
+	}
+}
+
+// WithSceneResolver 设置场景解析器
+func WithSceneResolver(resolver core.ISceneResolver) EngineOption {
+	return func(e *validatorEngine) {
+		e.sceneResolver = resolver
+	}
+}
+
+// WithProfiles 添加配置档（同名覆盖）
+func WithProfiles(profiles ...core.Profile) EngineOption {
+	return func(e *validatorEngine) {
+		if e.profiles == nil {
+			e.profiles = make(map[string]core.Profile, len(profiles))
+		}
+		for _, profile := range profiles {
+			e.profiles[profile.Name] = profile
+		}
+	}
+}
+
+// WithFastPathScenes 设置快速路径场景
+// 说明：验证场景的所有位都属于快速路径场景时，跳过拦截器和监听器（不发出任何事件）
+func WithFastPathScenes(scenes core.Scene) EngineOption {
+	return func(e *validatorEngine) {
+		e.fastPathScenes = scenes
+	}
+}
+
+// WithRuleEngine 设置规则引擎（Map 验证使用）
+func WithRuleEngine(ruleEngine core.IDependencyEngine) EngineOption {
+	return func(e *validatorEngine) {
+		e.ruleEngine = ruleEngine
+	}
+}
+
 // Validate 执行完整验证
+// 说明：验证通过时返回 nil（即使存在警告），不构造验证结果
+func (e *validatorEngine) Validate(target any, scene core.Scene, opts ...core.ValidateOption) core.IValidationError {
+	settings, err := e.resolveSettings(core.ApplyValidateOptions(opts))
+	if err != nil {
+		return err
+	}
+	settings.passNil = true
+	return e.check(target, scene, settings)
+}
+
+// Check 执行完整验证并始终返回结果
+func (e *validatorEngine) Check(target any, scene core.Scene, opts ...core.ValidateOption) core.IValidationError {
+	settings, err := e.resolveSettings(core.ApplyValidateOptions(opts))
+	if err != nil {
+		return err
+	}
+	return e.check(target, scene, settings)
+}
+
+// callSettings 单次验证的设置（由配置档决定）
+type callSettings struct {
+	goCtx           stdcontext.Context
+	profile         string
+	maxErrors       int
+	disableWarnings bool
+	passNil         bool // 验证通过时返回 nil
+	fastPath        bool // 快速路径：跳过拦截器和监听器
+
+	skips             []errors.SkipRule // 跳过的规则（WithSkips）
+	skipJustification string            // 跳过规则的理由
+
+	fields        []string // 只验证的字段（WithFields）
+	excludeFields []string // 不验证的字段（WithExcludeFields）
+}
+
+// defaultSettings 验证器的默认设置
+func (e *validatorEngine) defaultSettings() callSettings {
+	return callSettings{maxErrors: e.maxErrors}
+}
+
+// passNilSettings 验证通过时返回 nil 的默认设置
+func (e *validatorEngine) passNilSettings() callSettings {
+	return callSettings{maxErrors: e.maxErrors, passNil: true}
+}
+
+// resolveSettings 根据单次验证选项解析设置
+// 返回值：配置档未注册时返回 profile 错误；跳过规则格式错误或缺少理由时返回 skip 错误
+func (e *validatorEngine) resolveSettings(options core.ValidateOptions) (callSettings, core.IValidationError) {
+	settings := e.defaultSettings()
+	settings.goCtx = options.Context
+	settings.fields = options.Fields
+	settings.excludeFields = options.ExcludeFields
+	if len(options.Skips) > 0 {
+		if err := e.resolveSkips(&settings, options); err != nil {
+			return settings, err
+		}
+	}
+	if options.Profile == "" {
+		return settings, nil
+	}
+
+	profile, ok := e.profiles[options.Profile]
+	if !ok {
+		return settings, errors.NewValidationError([]core.IFieldError{
+			errors.NewFieldError("Struct", "", "profile",
+				errors.WithParam(options.Profile),
+				errors.WithMessage(fmt.Sprintf("validation profile %q not registered", options.Profile))),
+		}, e.errorFormatter)
+	}
+
+	settings.profile = profile.Name
+	if profile.MaxErrors > 0 {
+		settings.maxErrors = profile.MaxErrors
+	}
+	settings.disableWarnings = profile.DisableWarnings
+	return settings, nil
+}
+
+// resolveSkips 解析跳过规则（必须提供非空理由）
+func (e *validatorEngine) resolveSkips(settings *callSettings, options core.ValidateOptions) core.IValidationError {
+	justification := strings.TrimSpace(options.SkipJustification)
+	if justification == "" {
+		return errors.NewValidationError([]core.IFieldError{
+			errors.NewFieldError("Struct", "", "skip",
+				errors.WithParam(strings.Join(options.Skips, ",")),
+				errors.WithMessage("skipping validation rules requires a non-empty justification")),
+		}, e.errorFormatter)
+	}
+
+	rules, err := errors.ParseSkipRules(options.Skips)
+	if err != nil {
+		return errors.NewValidationError([]core.IFieldError{
+			errors.NewFieldError("Struct", "", "skip",
+				errors.WithParam(strings.Join(options.Skips, ",")),
+				errors.WithMessage(err.Error())),
+		}, e.errorFormatter)
+	}
+
+	settings.skips = rules
+	settings.skipJustification = justification
+	return nil
+}
+
+// ValidateFields 实现 IFieldValidator 接口
+func (e *validatorEngine) ValidateFields(target any, scene core.Scene, fields ...string) core.IValidationError {
+	return e.check(target, scene, e.passNilSettings(), context.WithMetadata(context.MetadataKeyValidateFields, fields))
+}
+
+// ValidateFieldsExcept 实现 IFieldValidator 接口
+func (e *validatorEngine) ValidateFieldsExcept(target any, scene core.Scene, excludeFields ...string) core.IValidationError {
+	return e.check(target, scene, e.passNilSettings(), context.WithMetadata(context.MetadataKeyExcludeFields, excludeFields))
+}
+
+// ValidateChanges 实现 IFieldValidator 接口
+// 说明：字段路径由 types.Diff 计算（json 名称），并补充 Go 字段名，兼容两种写法的规则键
+func (e *validatorEngine) ValidateChanges(old, new any, scene core.Scene) core.IValidationError {
+	changed, err := types.Diff(old, new)
+	if err != nil {
+		return errors.NewValidationError([]core.IFieldError{
+			errors.NewFieldError("Struct", "", "diff", errors.WithMessage(err.Error())),
+		}, e.errorFormatter)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	return e.ValidateFields(new, scene, withGoFieldNames(new, changed)...)
+}
+
+// ValidateMapWithRules 实现 IMapValidator 接口
+// 说明：不经过策略编排、拦截器和监听器，只执行规则验证
+func (e *validatorEngine) ValidateMapWithRules(payload map[string]any, rules map[string]string) core.IValidationError {
+	if e.ruleEngine == nil {
+		return errors.NewValidationError([]core.IFieldError{
+			errors.NewFieldError("Map", "", "rule_engine", errors.WithMessage("validator has no rule engine for map validation")),
+		}, e.errorFormatter)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	collector := e.acquireCollector(e.maxErrors)
+	defer errors.ReleaseListCollector(collector)
+
+	var opts []strategy.MapOption
+	if e.weakTyping {
+		opts = append(opts, strategy.WithWeakTyping())
+	}
+	strategy.ValidateMapRules(e.ruleEngine, payload, rules, collector, opts...)
+	if !collector.HasErrors() && len(collector.Warnings()) == 0 {
+		return nil
+	}
+	return errors.NewValidationError(cloneFieldErrors(collector.Errors()), e.errorFormatter,
+		errors.WithWarnings(cloneFieldErrors(collector.Warnings())),
+		errors.WithTruncated(truncated(collector)))
+}
+
+// ValidateForm 实现 IFormValidator 接口
+// 说明：不经过策略编排、拦截器和监听器；字段值总是按弱类型转换，不返回 coerced 提示
+func (e *validatorEngine) ValidateForm(values url.Values, files map[string][]*multipart.FileHeader, rules map[string]string) core.IValidationError {
+	if e.ruleEngine == nil {
+		return errors.NewValidationError([]core.IFieldError{
+			errors.NewFieldError("Form", "", "rule_engine", errors.WithMessage("validator has no rule engine for form validation")),
+		}, e.errorFormatter)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	collector := e.acquireCollector(e.maxErrors)
+	defer errors.ReleaseListCollector(collector)
+
+	strategy.ValidateFormRules(e.ruleEngine, values, files, rules, collector)
+	if !collector.HasErrors() && len(collector.Warnings()) == 0 {
+		return nil
+	}
+	return errors.NewValidationError(cloneFieldErrors(collector.Errors()), e.errorFormatter,
+		errors.WithWarnings(cloneFieldErrors(collector.Warnings())),
+		errors.WithTruncated(truncated(collector)))
+}
+
+// ValidateSlice 实现 ISliceValidator 接口
+// 说明：
+//   - 每条记录按 Validate 完整验证（拦截器、监听器照常执行），结构体切片传入元素指针，指针接收者的规则方法也可调用
+//   - 之后执行批量策略，批量策略的错误合并到对应记录的结果中
+//   - items 不是切片或数组时按单条记录验证，返回一个结果；验证选项无效（如配置档未注册）时只返回该错误
+func (e *validatorEngine) ValidateSlice(items any, scene core.Scene, opts ...core.ValidateOption) []core.IValidationError {
+	settings, err := e.resolveSettings(core.ApplyValidateOptions(opts))
+	if err != nil {
+		return []core.IValidationError{err}
+	}
+	settings.passNil = true
+
+	records := sliceRecords(items)
+	results := make([]core.IValidationError, len(records))
+	for i, record := range records {
+		results[i] = e.check(record, scene, settings)
+	}
+	if len(e.batchStrategies) == 0 || len(records) == 0 {
+		return results
+	}
+
+	var ctxOpts []context.ContextOption
+	if settings.goCtx != nil {
+		ctxOpts = append(ctxOpts, context.WithGoContext(settings.goCtx))
+	}
+	ctx := context.NewContext(scene, ctxOpts...)
+	defer ctx.Release()
+
+	batchErrors := make(map[int][]core.IFieldError)
+	for _, batch := range e.batchStrategies {
+		batch.ValidateBatch(records, ctx, func(index int, err core.IFieldError) {
+			if index >= 0 && index < len(records) && err != nil {
+				batchErrors[index] = append(batchErrors[index], err)
+			}
+		})
+	}
+	for index, errs := range batchErrors {
+		batchResult := errors.NewValidationError(errs, e.errorFormatter)
+		if results[index] == nil {
+			results[index] = batchResult
+			continue
+		}
+		results[index] = results[index].Merge(batchResult)
+	}
+	return results
+}
+
+// sliceRecords 展开切片或数组中的记录（结构体元素取地址；非切片按单条记录处理）
+func sliceRecords(items any) []any {
+	v := reflect.ValueOf(items)
+	for v.Kind() == reflect.Ptr && !v.IsNil() && (v.Elem().Kind() == reflect.Slice || v.Elem().Kind() == reflect.Array) {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return []any{items}
+	}
+
+	records := make([]any, v.Len())
+	for i := range records {
+		elem := v.Index(i)
+		switch {
+		case elem.Kind() == reflect.Struct && elem.CanAddr():
+			records[i] = elem.Addr().Interface()
+		case elem.Kind() == reflect.Struct:
+			ptr := reflect.New(elem.Type())
+			ptr.Elem().Set(elem)
+			records[i] = ptr.Interface()
+		case (elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface) && elem.IsNil():
+			records[i] = nil
+		default:
+			records[i] = elem.Interface()
+		}
+	}
+	return records
+}
+
+// check 执行验证
 // 模板方法：定义验证流程
-func (e *validatorEngine) Validate(target any, scene core.Scene) core.IValidationError {
+func (e *validatorEngine) check(target any, scene core.Scene, settings callSettings, opts ...context.ContextOption) core.IValidationError {
 	if target == nil {
 		return errors.NewValidationError(
 			[]core.IFieldError{
@@ -90,35 +458,278 @@ func (e *validatorEngine) Validate(target any, scene core.Scene) core.IValidatio
 			}, e.errorFormatter)
 	}
 
+	// 解析自动场景
+	if scene == core.SceneAuto {
+		resolved, err := e.resolveScene(target)
+		if err != nil {
+			return err
+		}
+		scene = resolved
+	}
+	settings.fastPath = len(settings.skips) == 0 && e.isFastPath(scene)
+
 	// 创建上下文
-	ctx := context.NewContext(scene)
+	ctx := context.NewContext(scene, e.contextOptions(settings, opts)...)
 	defer ctx.Release()
 
 	// 创建错误收集器
-	collector := errors.AcquireListCollector(e.maxErrors)
+	collector := e.acquireCollector(settings.maxErrors)
 	defer errors.ReleaseListCollector(collector)
 
+	// 有监听器时通过装饰器发出 ErrorAdded 事件
+	observed := collector
+	if len(e.listeners) > 0 && !settings.fastPath {
+		observed = errors.NewNotifyingCollector(collector, func(err core.IFieldError) {
+			context.Emit(ctx, core.Event{Type: core.EventTypeErrorAdded, FieldError: err})
+		})
+	}
+
+	// 跳过规则在最外层丢弃错误，被跳过的错误不会发出 ErrorAdded 事件
+	var skipping *errors.SkippingCollector
+	strategyCollector := observed
+	if len(settings.skips) > 0 {
+		skipping = errors.NewSkippingCollector(observed, settings.skips)
+		strategyCollector = skipping
+	}
+
+	start := time.Now()
+	context.Emit(ctx, core.Event{Type: core.EventTypeValidationStart, Target: target})
+
 	// 执行验证（带拦截器）
 	var validateErr error
-	if e.interceptorChain != nil {
+	if e.interceptorChain != nil && !settings.fastPath {
 		validateErr = e.interceptorChain.Execute(ctx, target, func() error {
-			return e.orchestrator.Execute(target, ctx, collector)
+			return e.execute(target, ctx, strategyCollector)
 		})
 	} else {
-		validateErr = e.orchestrator.Execute(target, ctx, collector)
+		validateErr = e.execute(target, ctx, strategyCollector)
+	}
+
+	// 使用了跳过规则时发出审计事件（无论是否实际跳过了错误）
+	if skipping != nil {
+		context.Emit(ctx, core.Event{
+			Type:          core.EventTypeRulesSkipped,
+			Target:        target,
+			Skips:         skipRuleStrings(settings.skips),
+			Justification: settings.skipJustification,
+			Skipped:       cloneFieldErrors(skipping.Skipped()),
+		})
 	}
 
 	// 如果有执行错误，添加到收集器
 	if validateErr != nil {
-		collector.Collect(errors.NewFieldErrorWithMessage(validateErr.Error()))
+		observed.Collect(errors.NewFieldErrorWithMessage(validateErr.Error()))
 	}
 
-	// 返回验证结果
-	if collector.HasErrors() {
-		return errors.NewValidationError(collector.Errors(), e.errorFormatter)
+	// 验证通过且不需要结果时直接返回（热路径不构造结果）
+	if settings.passNil && !collector.HasErrors() {
+		context.Emit(ctx, core.Event{Type: core.EventTypeValidationEnd, Target: target, Duration: time.Since(start)})
+		return nil
 	}
 
-	return nil
+	// 返回验证结果（收集器和上下文会归还对象池，需复制错误列表和阶段耗时）
+	var warnings []core.IFieldError
+	if !settings.disableWarnings {
+		warnings = cloneFieldErrors(collector.Warnings())
+	}
+	result := errors.NewValidationError(cloneFieldErrors(collector.Errors()), e.errorFormatter,
+		errors.WithWarnings(warnings),
+		errors.WithRuleVersion(ruleVersion(ctx)),
+		errors.WithTruncated(truncated(collector)),
+		errors.WithStageTimings(cloneStageTimings(context.StageTimings(ctx))))
+
+	end := core.Event{Type: core.EventTypeValidationEnd, Target: target, Duration: time.Since(start)}
+	if result.HasErrors() {
+		end.Err = result
+	}
+	context.Emit(ctx, end)
+
+	return result
+}
+
+// execute 执行策略，模型实现 ILifecycleHooks 时在前后调用钩子
+// 说明：
+//   - 钩子在拦截器内部执行，策略产生的错误事件在 AfterValidation 之前发出
+//   - BeforeValidation 返回错误时不执行策略；策略执行出错时不调用 AfterValidation
+//   - 钩子返回的错误与策略执行错误一样作为验证错误返回
+func (e *validatorEngine) execute(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	hooks, ok := target.(core.ILifecycleHooks)
+	if !ok {
+		return e.orchestrator.Execute(target, ctx, collector)
+	}
+
+	if err := hooks.BeforeValidation(ctx); err != nil {
+		return err
+	}
+	if err := e.orchestrator.Execute(target, ctx, collector); err != nil {
+		return err
+	}
+	return hooks.AfterValidation(ctx)
+}
+
+// truncated 收集器是否因达到上限丢弃过错误
+func truncated(collector core.IErrorCollector) bool {
+	aware, ok := collector.(core.ITruncationAware)
+	return ok && aware.Truncated()
+}
+
+// acquireCollector 获取错误收集器
+// 说明：保留详情的错误数小于上限时使用有界收集器（不走对象池，ReleaseListCollector 对其无效果）
+func (e *validatorEngine) acquireCollector(maxErrors int) core.IErrorCollector {
+	if e.detailLimit > 0 && e.detailLimit < maxErrors {
+		return errors.NewBoundedCollector(e.detailLimit, maxErrors)
+	}
+	return errors.AcquireListCollector(maxErrors)
+}
+
+// contextOptions 组装上下文选项
+// 说明：没有单次验证选项时复用默认选项；Go 上下文需在监听器之前设置；快速路径不设置监听器
+func (e *validatorEngine) contextOptions(settings callSettings, opts []context.ContextOption) []context.ContextOption {
+	base := e.baseContextOptions
+	if settings.fastPath {
+		base = nil
+	}
+	if settings.goCtx == nil && settings.profile == "" && len(opts) == 0 &&
+		len(settings.fields) == 0 && len(settings.excludeFields) == 0 {
+		return base
+	}
+
+	options := make([]context.ContextOption, 0, len(opts)+len(base)+4)
+	if settings.goCtx != nil {
+		options = append(options, context.WithGoContext(settings.goCtx))
+	}
+	options = append(options, opts...)
+	options = append(options, base...)
+	if settings.profile != "" {
+		options = append(options, context.WithMetadata(context.MetadataKeyProfile, settings.profile))
+	}
+	if len(settings.fields) > 0 {
+		options = append(options, context.WithMetadata(context.MetadataKeyValidateFields, settings.fields))
+	}
+	if len(settings.excludeFields) > 0 {
+		options = append(options, context.WithMetadata(context.MetadataKeyExcludeFields, settings.excludeFields))
+	}
+	return options
+}
+
+// isFastPath 检查场景是否走快速路径（场景的所有位都属于快速路径场景）
+func (e *validatorEngine) isFastPath(scene core.Scene) bool {
+	return e.fastPathScenes != core.SceneNone && scene != core.SceneNone && scene&^e.fastPathScenes == 0
+}
+
+// resolveScene 通过场景解析器解析 SceneAuto
+// 返回值：未配置解析器或无法解析时返回 scene 错误
+func (e *validatorEngine) resolveScene(target any) (core.Scene, core.IValidationError) {
+	message := "scene resolver not configured"
+	if e.sceneResolver != nil {
+		scene := e.sceneResolver.ResolveScene(target)
+		if scene != core.SceneNone && scene != core.SceneAuto {
+			return scene, nil
+		}
+		message = fmt.Sprintf("cannot resolve scene for %T", target)
+	}
+
+	return core.SceneNone, errors.NewValidationError([]core.IFieldError{
+		errors.NewFieldError("Struct", "", "scene", errors.WithMessage(message)),
+	}, e.errorFormatter)
+}
+
+// withGoFieldNames 为 json 路径补充 Go 字段名写法
+// 说明：如 profile.nickname 补充 Profile.nickname、tags[1] 补充 Tags[1]，使规则键 Profile、Tags 也能匹配；
+// 匿名嵌入的结构体会被展开
+func withGoFieldNames(target any, paths []string) []string {
+	typ := reflect.TypeOf(target)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return paths
+	}
+
+	names := make(map[string]string)
+	collectGoFieldNames(typ, names)
+
+	result := make([]string, 0, len(paths)*2)
+	for _, path := range paths {
+		result = append(result, path)
+		head, rest, nested := strings.Cut(path, ".")
+		head, index, _ := strings.Cut(head, "[")
+		if goName, ok := names[head]; ok && goName != head {
+			if index != "" {
+				goName += "[" + index
+			}
+			if nested {
+				goName += "." + rest
+			}
+			result = append(result, goName)
+		}
+	}
+	return result
+}
+
+// collectGoFieldNames 收集 json 名称 -> Go 字段名
+func collectGoFieldNames(typ reflect.Type, names map[string]string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, hasTag := field.Tag.Lookup("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			collectGoFieldNames(fieldType, names)
+			continue
+		}
+
+		if !hasTag || name == "" {
+			name = field.Name
+		}
+		names[name] = field.Name
+	}
+}
+
+// skipRuleStrings 将跳过规则转换为字符串列表
+func skipRuleStrings(rules []errors.SkipRule) []string {
+	result := make([]string, len(rules))
+	for i, rule := range rules {
+		result[i] = rule.String()
+	}
+	return result
+}
+
+// cloneFieldErrors 复制错误列表
+func cloneFieldErrors(errs []core.IFieldError) []core.IFieldError {
+	if len(errs) == 0 {
+		return nil
+	}
+	return append([]core.IFieldError(nil), errs...)
+}
+
+// ruleVersion 获取规则策略记录在上下文中的外部规则版本号
+func ruleVersion(ctx core.IContext) string {
+	if version, ok := ctx.Metadata().Get(context.MetadataKeyRuleVersion); ok {
+		if v, ok := version.(string); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// cloneStageTimings 复制阶段耗时
+func cloneStageTimings(timings []core.StageTiming) []core.StageTiming {
+	if len(timings) == 0 {
+		return nil
+	}
+	return append([]core.StageTiming(nil), timings...)
 }
 
 // ValidateWithContext 使用自定义上下文执行验证
@@ -132,11 +743,11 @@ func (e *validatorEngine) ValidateWithContext(target any, ctx core.IContext) err
 	}
 
 	// 创建错误收集器
-	collector := errors.AcquireListCollector(e.maxErrors)
+	collector := e.acquireCollector(e.maxErrors)
 	defer errors.ReleaseListCollector(collector)
 
 	// 执行验证
-	err := e.orchestrator.Execute(target, ctx, collector)
+	err := e.execute(target, ctx, collector)
 	if err != nil {
 		return err
 	}
@@ -148,3 +759,74 @@ func (e *validatorEngine) ValidateWithContext(target any, ctx core.IContext) err
 
 	return nil
 }
+
+// ============================================================================
+// 插件管理
+// ============================================================================
+
+// EnablePlugin 实现 IPluginManager 接口
+func (e *validatorEngine) EnablePlugin(name string) error {
+	if e.plugins == nil {
+		return fmt.Errorf("plugin %q not found", name)
+	}
+	return e.plugins.EnablePlugin(name)
+}
+
+// DisablePlugin 实现 IPluginManager 接口
+func (e *validatorEngine) DisablePlugin(name string) error {
+	if e.plugins == nil {
+		return fmt.Errorf("plugin %q not found", name)
+	}
+	return e.plugins.DisablePlugin(name)
+}
+
+// IsPluginEnabled 实现 IPluginManager 接口
+func (e *validatorEngine) IsPluginEnabled(name string) bool {
+	return e.plugins != nil && e.plugins.IsPluginEnabled(name)
+}
+
+// Plugins 实现 IPluginManager 接口
+func (e *validatorEngine) Plugins() []string {
+	if e.plugins == nil {
+		return nil
+	}
+	return e.plugins.Plugins()
+}
+
+// ============================================================================
+// 运行时信息
+// ============================================================================
+
+// CacheStats 实现 IValidatorIntrospector 接口
+func (e *validatorEngine) CacheStats() core.CacheStats {
+	if e.inspector == nil {
+		return core.CacheStats{}
+	}
+	return e.inspector.Stats()
+}
+
+// CachedRules 实现 IRuleCacheInspector 接口
+// 说明：类型尚未检查过时会先检查并加入类型缓存；返回的规则只读
+func (e *validatorEngine) CachedRules(target any) map[core.Scene]*core.CompiledRules {
+	if e.inspector == nil {
+		return nil
+	}
+	info := e.inspector.Inspect(target)
+	if info == nil {
+		return nil
+	}
+	cache, ok := info.(core.IRuleCache)
+	if !ok {
+		return nil
+	}
+	return cache.CachedRules()
+}
+
+// Listeners 实现 IValidatorIntrospector 接口
+func (e *validatorEngine) Listeners() []string {
+	names := make([]string, 0, len(e.listeners))
+	for _, listener := range e.listeners {
+		names = append(names, reflect.TypeOf(listener).String())
+	}
+	return names
+}
@@ -0,0 +1,34 @@
+package errors
+
+import (
+	"katydid-common-account/pkg/validator/bridge"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// FromValidationErrors 将 go-playground 的验证错误转换为字段错误列表
+// 参数：
+//   - err：validator.Struct / validator.Var 返回的错误
+//   - root：被验证的对象，命名空间和字段名统一转换为 JSON 名称；为 nil 时保留原命名空间
+//   - opts：附加到每个字段错误的选项
+//
+// 说明：不保留 go-playground 的原始消息，优先使用消息模板，其次使用默认消息
+func FromValidationErrors(err error, root any, opts ...FieldErrorOption) ([]core.IFieldError, bool) {
+	fieldErrors, ok := bridge.FromValidationErrors(err, root)
+	if !ok {
+		return nil, false
+	}
+
+	result := make([]core.IFieldError, 0, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		result = append(result, fromBridgeError(fe, opts...))
+	}
+	return result, true
+}
+
+// fromBridgeError 转换单个桥接字段错误
+func fromBridgeError(fe *bridge.FieldError, opts ...FieldErrorOption) core.IFieldError {
+	fieldOpts := make([]FieldErrorOption, 0, len(opts)+2)
+	fieldOpts = append(fieldOpts, opts...)
+	fieldOpts = append(fieldOpts, WithParam(fe.Param), WithValue(fe.Value))
+	return NewFieldError(fe.Namespace, fe.Field, fe.Tag, fieldOpts...)
+}
@@ -1,7 +1,9 @@
 package errors
 
 import (
+	"fmt"
 	"katydid-common-account/pkg/validator/v6/core"
+	"strings"
 	"sync"
 )
 
@@ -14,7 +16,9 @@ import (
 // 缺点：查找特定字段错误较慢
 type listErrorCollector struct {
 	errors    []core.IFieldError
+	warnings  []core.IFieldError
 	maxErrors int
+	truncated bool
 }
 
 // NewListErrorCollector 创建列表错误收集器
@@ -29,8 +33,13 @@ func NewListErrorCollector(maxErrors int) core.IErrorCollector {
 }
 
 // Collect 收集错误
+// 说明：Warning/Info 级别的错误转交 AddWarning
 func (c *listErrorCollector) Collect(err core.IFieldError) bool {
+	if !err.Severity().IsBlocking() {
+		return c.AddWarning(err)
+	}
 	if c.Count() >= c.maxErrors {
+		c.truncated = true
 		return false
 	}
 	c.errors = append(c.errors, err)
@@ -47,6 +56,20 @@ func (c *listErrorCollector) CollectAll(errs []core.IFieldError) bool {
 	return true
 }
 
+// AddWarning 收集警告
+func (c *listErrorCollector) AddWarning(err core.IFieldError) bool {
+	if len(c.warnings) >= c.maxErrors {
+		return false
+	}
+	c.warnings = append(c.warnings, AsWarning(err))
+	return true
+}
+
+// Warnings 获取所有警告
+func (c *listErrorCollector) Warnings() []core.IFieldError {
+	return c.warnings
+}
+
 // Errors 获取所有错误
 func (c *listErrorCollector) Errors() []core.IFieldError {
 	return c.errors
@@ -65,6 +88,8 @@ func (c *listErrorCollector) Count() int {
 // Clear 清空错误
 func (c *listErrorCollector) Clear() {
 	c.errors = c.errors[:0]
+	c.warnings = c.warnings[:0]
+	c.truncated = false
 }
 
 // MaxErrors 最大错误数
@@ -72,6 +97,11 @@ func (c *listErrorCollector) MaxErrors() int {
 	return c.maxErrors
 }
 
+// Truncated 实现 ITruncationAware 接口
+func (c *listErrorCollector) Truncated() bool {
+	return c.truncated
+}
+
 // ============================================================================
 // Map 错误收集器 - 按字段分组
 // ============================================================================
@@ -81,8 +111,10 @@ func (c *listErrorCollector) MaxErrors() int {
 // 缺点：不保证错误顺序
 type mapErrorCollector struct {
 	errors    map[string][]core.IFieldError
+	warnings  []core.IFieldError
 	count     int
 	maxErrors int
+	truncated bool
 }
 
 // NewMapErrorCollector 创建 Map 错误收集器
@@ -97,8 +129,13 @@ func NewMapErrorCollector(maxErrors int) core.IErrorCollector {
 }
 
 // Collect 收集错误
+// 说明：Warning/Info 级别的错误转交 AddWarning
 func (c *mapErrorCollector) Collect(err core.IFieldError) bool {
+	if !err.Severity().IsBlocking() {
+		return c.AddWarning(err)
+	}
 	if c.count >= c.maxErrors {
+		c.truncated = true
 		return false
 	}
 
@@ -118,6 +155,20 @@ func (c *mapErrorCollector) CollectAll(errs []core.IFieldError) bool {
 	return true
 }
 
+// AddWarning 收集警告
+func (c *mapErrorCollector) AddWarning(err core.IFieldError) bool {
+	if len(c.warnings) >= c.maxErrors {
+		return false
+	}
+	c.warnings = append(c.warnings, AsWarning(err))
+	return true
+}
+
+// Warnings 获取所有警告
+func (c *mapErrorCollector) Warnings() []core.IFieldError {
+	return c.warnings
+}
+
 // Errors 获取所有错误（展平）
 func (c *mapErrorCollector) Errors() []core.IFieldError {
 	result := make([]core.IFieldError, 0, c.count)
@@ -145,7 +196,9 @@ func (c *mapErrorCollector) Count() int {
 // Clear 清空错误
 func (c *mapErrorCollector) Clear() {
 	c.errors = make(map[string][]core.IFieldError)
+	c.warnings = c.warnings[:0]
 	c.count = 0
+	c.truncated = false
 }
 
 // MaxErrors 最大错误数
@@ -153,6 +206,485 @@ func (c *mapErrorCollector) MaxErrors() int {
 	return c.maxErrors
 }
 
+// Truncated 实现 ITruncationAware 接口
+func (c *mapErrorCollector) Truncated() bool {
+	return c.truncated
+}
+
+// ============================================================================
+// 通知收集器 - 收集错误时回调
+// ============================================================================
+
+// notifyingCollector 在收集成功后回调的错误收集器装饰器
+// 说明：用于向监听器发出 ErrorAdded 事件，其余方法直接委托给内部收集器
+type notifyingCollector struct {
+	core.IErrorCollector
+	notify func(err core.IFieldError)
+}
+
+// NewNotifyingCollector 创建通知收集器
+// 说明：notify 只在错误（或警告）被实际收集时调用；内部收集器仍由调用方负责归还对象池
+func NewNotifyingCollector(inner core.IErrorCollector, notify func(err core.IFieldError)) core.IErrorCollector {
+	if notify == nil {
+		return inner
+	}
+	return &notifyingCollector{IErrorCollector: inner, notify: notify}
+}
+
+// Collect 收集错误
+func (c *notifyingCollector) Collect(err core.IFieldError) bool {
+	if !c.IErrorCollector.Collect(err) {
+		return false
+	}
+	c.notify(err)
+	return true
+}
+
+// CollectAll 批量收集错误
+func (c *notifyingCollector) CollectAll(errs []core.IFieldError) bool {
+	for _, err := range errs {
+		if !c.Collect(err) {
+			return false
+		}
+	}
+	return true
+}
+
+// AddWarning 收集警告
+func (c *notifyingCollector) AddWarning(err core.IFieldError) bool {
+	if !c.IErrorCollector.AddWarning(err) {
+		return false
+	}
+	c.notify(AsWarning(err))
+	return true
+}
+
+// ============================================================================
+// 同步收集器 - 并行策略共享
+// ============================================================================
+
+// syncCollector 加锁的错误收集器装饰器
+// 说明：并行执行的策略共享同一个收集器，所有方法串行访问内部收集器
+type syncCollector struct {
+	mu    sync.Mutex
+	inner core.IErrorCollector
+}
+
+// NewSyncCollector 创建同步收集器
+// 说明：内部收集器仍由调用方负责归还对象池；内部收集器实现 ITruncationAware 时同样透传
+func NewSyncCollector(inner core.IErrorCollector) core.IErrorCollector {
+	return &syncCollector{inner: inner}
+}
+
+// Collect 收集错误
+func (c *syncCollector) Collect(err core.IFieldError) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.Collect(err)
+}
+
+// CollectAll 批量收集错误
+func (c *syncCollector) CollectAll(errs []core.IFieldError) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.CollectAll(errs)
+}
+
+// AddWarning 收集警告
+func (c *syncCollector) AddWarning(err core.IFieldError) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.AddWarning(err)
+}
+
+// Warnings 获取所有警告（副本）
+func (c *syncCollector) Warnings() []core.IFieldError {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]core.IFieldError(nil), c.inner.Warnings()...)
+}
+
+// Errors 获取所有错误（副本）
+func (c *syncCollector) Errors() []core.IFieldError {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]core.IFieldError(nil), c.inner.Errors()...)
+}
+
+// HasErrors 是否有错误
+func (c *syncCollector) HasErrors() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.HasErrors()
+}
+
+// Count 错误数量
+func (c *syncCollector) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.inner.Count()
+}
+
+// Clear 清空错误
+func (c *syncCollector) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inner.Clear()
+}
+
+// MaxErrors 最大错误数
+func (c *syncCollector) MaxErrors() int {
+	return c.inner.MaxErrors()
+}
+
+// Truncated 实现 ITruncationAware 接口
+func (c *syncCollector) Truncated() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	aware, ok := c.inner.(core.ITruncationAware)
+	return ok && aware.Truncated()
+}
+
+// ============================================================================
+// 有界收集器 - 超出部分汇总
+// ============================================================================
+
+// TagMoreErrors 有界收集器汇总错误的标签（参数为未保留详情的错误数）
+const TagMoreErrors = "more_errors"
+
+// maxSpilledFields 汇总时最多记录的不同字段数（超出后只计数，字段数显示为下限）
+const maxSpilledFields = 1024
+
+// BoundedCollector 有界错误收集器
+// 说明：
+//   - 保留前 detailLimit 个错误的详情，之后的错误只记录数量和涉及的字段（不区分下标），结果末尾追加一条汇总错误：
+//     "and 1,243 more errors across 17 fields"
+//   - Count 包含汇总的错误数，达到 maxErrors 后不再收集，策略照常提前停止
+//   - 警告最多保留 detailLimit 个，超出部分丢弃
+//   - 用于异常输入（如上万行的批量导入）产生大量错误时限制内存，同时保留错误规模
+type BoundedCollector struct {
+	errors      []core.IFieldError
+	warnings    []core.IFieldError
+	detailLimit int
+	maxErrors   int
+	spilled     int
+	fields      map[string]struct{}
+	truncated   bool
+}
+
+// NewBoundedCollector 创建有界错误收集器
+func NewBoundedCollector(detailLimit, maxErrors int) *BoundedCollector {
+	if detailLimit <= 0 {
+		detailLimit = 100
+	}
+	if maxErrors < detailLimit {
+		maxErrors = detailLimit
+	}
+	return &BoundedCollector{
+		errors:      make([]core.IFieldError, 0, detailLimit),
+		detailLimit: detailLimit,
+		maxErrors:   maxErrors,
+	}
+}
+
+// Collect 收集错误
+// 说明：Warning/Info 级别的错误转交 AddWarning
+func (c *BoundedCollector) Collect(err core.IFieldError) bool {
+	if !err.Severity().IsBlocking() {
+		return c.AddWarning(err)
+	}
+	if c.Count() >= c.maxErrors {
+		c.truncated = true
+		return false
+	}
+	if len(c.errors) < c.detailLimit {
+		c.errors = append(c.errors, err)
+		return true
+	}
+
+	c.spilled++
+	if c.fields == nil {
+		c.fields = make(map[string]struct{})
+	}
+	if len(c.fields) < maxSpilledFields {
+		c.fields[spilledField(err.Namespace())] = struct{}{}
+	}
+	return true
+}
+
+// CollectAll 批量收集错误
+func (c *BoundedCollector) CollectAll(errs []core.IFieldError) bool {
+	for _, err := range errs {
+		if !c.Collect(err) {
+			return false
+		}
+	}
+	return true
+}
+
+// AddWarning 收集警告
+func (c *BoundedCollector) AddWarning(err core.IFieldError) bool {
+	if len(c.warnings) >= c.detailLimit {
+		return false
+	}
+	c.warnings = append(c.warnings, AsWarning(err))
+	return true
+}
+
+// Warnings 获取保留的警告
+func (c *BoundedCollector) Warnings() []core.IFieldError {
+	return c.warnings
+}
+
+// Errors 获取保留详情的错误，有汇总时末尾追加汇总错误
+func (c *BoundedCollector) Errors() []core.IFieldError {
+	summary := c.Summary()
+	if summary == nil {
+		return c.errors
+	}
+	return append(c.errors[:len(c.errors):len(c.errors)], summary)
+}
+
+// Summary 汇总错误（没有超出 detailLimit 时为 nil）
+func (c *BoundedCollector) Summary() core.IFieldError {
+	if c.spilled == 0 {
+		return nil
+	}
+
+	fields := formatCount(len(c.fields))
+	if len(c.fields) >= maxSpilledFields {
+		fields += "+"
+	}
+	noun := "errors"
+	if c.spilled == 1 {
+		noun = "error"
+	}
+	fieldNoun := "fields"
+	if len(c.fields) == 1 {
+		fieldNoun = "field"
+	}
+	return NewFieldError("", "", TagMoreErrors,
+		WithParam(fmt.Sprint(c.spilled)),
+		WithMessage(fmt.Sprintf("and %s more %s across %s %s", formatCount(c.spilled), noun, fields, fieldNoun)))
+}
+
+// Spilled 未保留详情的错误数
+func (c *BoundedCollector) Spilled() int {
+	return c.spilled
+}
+
+// HasErrors 是否有错误
+func (c *BoundedCollector) HasErrors() bool {
+	return len(c.errors) > 0
+}
+
+// Count 错误数量（含未保留详情的错误）
+func (c *BoundedCollector) Count() int {
+	return len(c.errors) + c.spilled
+}
+
+// Clear 清空错误
+func (c *BoundedCollector) Clear() {
+	c.errors = c.errors[:0]
+	c.warnings = c.warnings[:0]
+	c.spilled = 0
+	c.fields = nil
+	c.truncated = false
+}
+
+// MaxErrors 最大错误数（含未保留详情的错误）
+func (c *BoundedCollector) MaxErrors() int {
+	return c.maxErrors
+}
+
+// Truncated 实现 ITruncationAware 接口
+func (c *BoundedCollector) Truncated() bool {
+	return c.truncated
+}
+
+// spilledField 汇总时的字段标识：去掉命名空间中的下标和键，rows[3].name 与 rows[8].name 算同一字段
+func spilledField(namespace string) string {
+	if !strings.Contains(namespace, "[") {
+		return namespace
+	}
+
+	var b strings.Builder
+	depth := 0
+	for _, r := range namespace {
+		switch {
+		case r == '[':
+			if depth == 0 {
+				b.WriteRune(r)
+			}
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+			if depth == 0 {
+				b.WriteRune(r)
+			}
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// formatCount 千分位格式化（1243 -> 1,243）
+func formatCount(n int) string {
+	s := fmt.Sprint(n)
+	if len(s) <= 3 {
+		return s
+	}
+
+	var b strings.Builder
+	head := len(s) % 3
+	if head > 0 {
+		b.WriteString(s[:head])
+	}
+	for i := head; i < len(s); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(s[i : i+3])
+	}
+	return b.String()
+}
+
+// ============================================================================
+// 命名空间收集器 - 收集时改写命名空间
+// ============================================================================
+
+// namespaceCollector 收集前改写错误命名空间的收集器装饰器
+// 说明：用于嵌套验证，内部模型的错误挂到外层字段路径下；其余方法直接委托给内部收集器
+type namespaceCollector struct {
+	core.IErrorCollector
+	rewrite func(namespace string) string
+}
+
+// NewNamespaceCollector 创建命名空间收集器
+func NewNamespaceCollector(inner core.IErrorCollector, rewrite func(namespace string) string) core.IErrorCollector {
+	if rewrite == nil {
+		return inner
+	}
+	return &namespaceCollector{IErrorCollector: inner, rewrite: rewrite}
+}
+
+// Collect 收集错误
+func (c *namespaceCollector) Collect(err core.IFieldError) bool {
+	return c.IErrorCollector.Collect(ReplaceNamespace(err, c.rewrite(err.Namespace())))
+}
+
+// CollectAll 批量收集错误
+func (c *namespaceCollector) CollectAll(errs []core.IFieldError) bool {
+	for _, err := range errs {
+		if !c.Collect(err) {
+			return false
+		}
+	}
+	return true
+}
+
+// AddWarning 收集警告
+func (c *namespaceCollector) AddWarning(err core.IFieldError) bool {
+	return c.IErrorCollector.AddWarning(ReplaceNamespace(err, c.rewrite(err.Namespace())))
+}
+
+// ============================================================================
+// 跳过收集器 - 按规则丢弃错误（审计逃生通道）
+// ============================================================================
+
+// SkipRule 跳过规则（"命名空间:标签"）
+type SkipRule struct {
+	Namespace string // 错误命名空间，如 User.Username（不区分大小写）
+	Tag       string // 验证标签或错误参数，如 reserved
+}
+
+// String 返回 "命名空间:标签" 格式
+func (r SkipRule) String() string {
+	return r.Namespace + ":" + r.Tag
+}
+
+// Matches 检查错误是否命中跳过规则
+// 说明：命名空间不区分大小写（规则键可以是 json 名称或 Go 字段名）；标签匹配 Tag() 或 Param()
+func (r SkipRule) Matches(err core.IFieldError) bool {
+	if err == nil || !strings.EqualFold(err.Namespace(), r.Namespace) {
+		return false
+	}
+	return err.Tag() == r.Tag || err.Param() == r.Tag
+}
+
+// ParseSkipRules 解析跳过规则
+// 返回值：格式不是 "命名空间:标签" 或任一部分为空时返回错误
+func ParseSkipRules(skips []string) ([]SkipRule, error) {
+	rules := make([]SkipRule, 0, len(skips))
+	for _, skip := range skips {
+		idx := strings.LastIndex(skip, ":")
+		if idx <= 0 || idx == len(skip)-1 {
+			return nil, fmt.Errorf("invalid skip rule %q, expected \"Namespace:tag\"", skip)
+		}
+		rules = append(rules, SkipRule{
+			Namespace: strings.TrimSpace(skip[:idx]),
+			Tag:       strings.TrimSpace(skip[idx+1:]),
+		})
+	}
+	return rules, nil
+}
+
+// SkippingCollector 丢弃命中跳过规则的错误并记录，其余方法直接委托给内部收集器
+// 说明：被跳过的错误不计入错误数，也不会触发外层装饰器（如通知收集器）
+type SkippingCollector struct {
+	core.IErrorCollector
+	rules   []SkipRule
+	skipped []core.IFieldError
+}
+
+// NewSkippingCollector 创建跳过收集器
+func NewSkippingCollector(inner core.IErrorCollector, rules []SkipRule) *SkippingCollector {
+	return &SkippingCollector{IErrorCollector: inner, rules: rules}
+}
+
+// Collect 收集错误
+func (c *SkippingCollector) Collect(err core.IFieldError) bool {
+	if c.skip(err) {
+		return true
+	}
+	return c.IErrorCollector.Collect(err)
+}
+
+// CollectAll 批量收集错误
+func (c *SkippingCollector) CollectAll(errs []core.IFieldError) bool {
+	for _, err := range errs {
+		if !c.Collect(err) {
+			return false
+		}
+	}
+	return true
+}
+
+// AddWarning 收集警告
+func (c *SkippingCollector) AddWarning(err core.IFieldError) bool {
+	if c.skip(err) {
+		return true
+	}
+	return c.IErrorCollector.AddWarning(err)
+}
+
+// Skipped 返回被跳过的错误
+func (c *SkippingCollector) Skipped() []core.IFieldError {
+	return c.skipped
+}
+
+// skip 检查并记录被跳过的错误
+func (c *SkippingCollector) skip(err core.IFieldError) bool {
+	for _, rule := range c.rules {
+		if rule.Matches(err) {
+			c.skipped = append(c.skipped, err)
+			return true
+		}
+	}
+	return false
+}
+
 // ============================================================================
 // 错误收集器对象池
 // ============================================================================
@@ -179,6 +711,9 @@ var (
 
 // AcquireListCollector 从对象池获取列表收集器
 func AcquireListCollector(maxErrors int) core.IErrorCollector {
+	if maxErrors <= 0 {
+		maxErrors = 100
+	}
 	c := listCollectorPool.Get().(*listErrorCollector)
 	c.maxErrors = maxErrors
 	c.Clear()
@@ -195,6 +730,9 @@ func ReleaseListCollector(collector core.IErrorCollector) {
 
 // AcquireMapCollector 从对象池获取 Map 收集器
 func AcquireMapCollector(maxErrors int) core.IErrorCollector {
+	if maxErrors <= 0 {
+		maxErrors = 100
+	}
 	c := mapCollectorPool.Get().(*mapErrorCollector)
 	c.maxErrors = maxErrors
 	c.Clear()
@@ -3,6 +3,7 @@ package errors
 import (
 	"fmt"
 	"katydid-common-account/pkg/validator/v6/core"
+	"reflect"
 )
 
 // fieldError 字段错误实现
@@ -14,6 +15,9 @@ type fieldError struct {
 	param     string // 验证参数
 	value     any    // 字段值
 	message   string // 错误消息
+	severity  core.Severity
+	sensitive bool           // 值是否敏感（输出时脱敏）
+	params    map[string]any // 结构化参数（消息模板占位符与 JSON 输出）
 }
 
 // NewFieldError 创建字段错误
@@ -29,7 +33,10 @@ func NewFieldError(namespace, field, tag string, opts ...FieldErrorOption) core.
 		opt(err)
 	}
 
-	// 如果没有自定义消息，生成默认消息
+	// 如果没有自定义消息，优先使用消息模板，其次生成默认消息
+	if err.message == "" {
+		err.message = renderMessage(err)
+	}
 	if err.message == "" {
 		err.message = err.defaultMessage()
 	}
@@ -62,12 +69,47 @@ func WithParam(param string) FieldErrorOption {
 }
 
 // WithValue 设置字段值
+// 说明：字符串、数字等值会被复制（字段访问器返回的值直接引用结构体字段，见零拷贝字段读取）
 func WithValue(value any) FieldErrorOption {
+	value = detachValue(value)
 	return func(e *fieldError) {
 		e.value = value
 	}
 }
 
+// detachValue 复制字符串、布尔、数字值，使其不再引用结构体字段
+func detachValue(value any) any {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		copied := reflect.New(rv.Type()).Elem()
+		copied.Set(rv)
+		return copied.Interface()
+	default:
+		return value
+	}
+}
+
+// WithParams 设置结构化参数
+// 说明：参数作为消息模板的占位符（{min}、{max} 等，内置占位符优先），并随 JSON 输出为 params；map 会被复制
+//
+// 示例：
+//
+//	RegisterMessage("", "between", "{field} must be between {min} and {max}")
+//	NewFieldError("Order.amount", "amount", "between", WithParams(map[string]any{"min": 1, "max": 100}))
+func WithParams(params map[string]any) FieldErrorOption {
+	copied := make(map[string]any, len(params))
+	for k, v := range params {
+		copied[k] = v
+	}
+	return func(e *fieldError) {
+		e.params = copied
+	}
+}
+
 // WithMessage 设置自定义消息
 func WithMessage(message string) FieldErrorOption {
 	return func(e *fieldError) {
@@ -75,6 +117,13 @@ func WithMessage(message string) FieldErrorOption {
 	}
 }
 
+// WithSeverity 设置错误级别
+func WithSeverity(severity core.Severity) FieldErrorOption {
+	return func(e *fieldError) {
+		e.severity = severity
+	}
+}
+
 // Namespace 实现 IFieldError 接口
 func (e *fieldError) Namespace() string {
 	return e.namespace
@@ -105,6 +154,21 @@ func (e *fieldError) Message() string {
 	return e.message
 }
 
+// Severity 实现 IFieldError 接口
+func (e *fieldError) Severity() core.Severity {
+	return e.severity
+}
+
+// Sensitive 实现 ISensitive 接口
+func (e *fieldError) Sensitive() bool {
+	return e.sensitive
+}
+
+// Params 实现 IParams 接口
+func (e *fieldError) Params() map[string]any {
+	return e.params
+}
+
 // Error 实现 error 接口
 func (e *fieldError) Error() string {
 	return e.message
@@ -119,3 +183,104 @@ func (e *fieldError) defaultMessage() string {
 	return fmt.Sprintf("Field '%s' failed validation on tag '%s'",
 		e.field, e.tag)
 }
+
+// ============================================================================
+// 警告
+// ============================================================================
+
+// warningError 警告包装
+// 说明：将任意字段错误降级为警告，其余信息保持不变
+type warningError struct {
+	core.IFieldError
+}
+
+// Severity 实现 IFieldError 接口
+func (w warningError) Severity() core.Severity {
+	return core.SeverityWarning
+}
+
+// Params 实现 IParams 接口
+func (w warningError) Params() map[string]any {
+	return ParamsOf(w.IFieldError)
+}
+
+// AsWarning 将字段错误转换为警告
+// 说明：已经是 Warning/Info 级别的错误原样返回
+func AsWarning(err core.IFieldError) core.IFieldError {
+	if !err.Severity().IsBlocking() {
+		return err
+	}
+	return warningError{IFieldError: err}
+}
+
+// ============================================================================
+// 命名空间替换
+// ============================================================================
+
+// namespacedError 替换命名空间的字段错误包装
+type namespacedError struct {
+	core.IFieldError
+	namespace string
+}
+
+// Namespace 实现 IFieldError 接口
+func (e namespacedError) Namespace() string {
+	return e.namespace
+}
+
+// Sensitive 实现 ISensitive 接口（保留内部错误的脱敏标记）
+func (e namespacedError) Sensitive() bool {
+	s, ok := e.IFieldError.(ISensitive)
+	return ok && s.Sensitive()
+}
+
+// Params 实现 IParams 接口
+func (e namespacedError) Params() map[string]any {
+	return ParamsOf(e.IFieldError)
+}
+
+// ReplaceNamespace 替换字段错误的命名空间，其余信息保持不变
+// 说明：用于嵌套验证时把内部模型的命名空间挂到外层字段路径下
+func ReplaceNamespace(err core.IFieldError, namespace string) core.IFieldError {
+	if err.Namespace() == namespace {
+		return err
+	}
+	return namespacedError{IFieldError: err, namespace: namespace}
+}
+
+// ============================================================================
+// 结构化参数
+// ============================================================================
+
+// IParams 结构化参数接口
+// 说明：WithParams 创建的字段错误实现该接口；自定义的 IFieldError 实现也可以实现该接口
+type IParams interface {
+	Params() map[string]any
+}
+
+// ParamsOf 获取字段错误的结构化参数（没有时为 nil，只读）
+func ParamsOf(err core.IFieldError) map[string]any {
+	if p, ok := err.(IParams); ok {
+		return p.Params()
+	}
+	return nil
+}
+
+// Report 收集一次检查产生的多个字段错误（如区间检查同时报告下限和上限）
+// 说明：阻断错误通过 Collect 收集，Warning/Info 级别通过 AddWarning 收集；nil 忽略
+// 返回值：收集器已满时返回 false，调用方应停止检查
+func Report(collector core.IErrorCollector, errs ...core.IFieldError) bool {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if !err.Severity().IsBlocking() {
+			collector.AddWarning(err)
+			continue
+		}
+		if !collector.Collect(err) {
+			return false
+		}
+	}
+	return true
+}
@@ -46,7 +46,7 @@ func NewJSONFormatter() core.IErrorFormatter {
 // Format 格式化单个错误为 JSON 风格
 func (f *jsonFormatter) Format(err core.IFieldError) string {
 	return fmt.Sprintf(`{"namespace":"%s","field":"%s","tag":"%s","param":"%s","value":"%v","message":"%s"}`,
-		err.Namespace(), err.Field(), err.Tag(), err.Param(), err.Value(), err.Message())
+		err.Namespace(), err.Field(), err.Tag(), err.Param(), RedactValue(err), err.Message())
 }
 
 // FormatAll 格式化所有错误为 JSON 数组风格
@@ -73,7 +73,7 @@ func NewDetailedFormatter() core.IErrorFormatter {
 // Format 格式化单个错误（包含详细信息）
 func (f *detailedFormatter) Format(err core.IFieldError) string {
 	return fmt.Sprintf("[%s] %s (tag=%s, param=%s, value=%v)",
-		err.Namespace(), err.Message(), err.Tag(), err.Param(), err.Value())
+		err.Namespace(), err.Message(), err.Tag(), err.Param(), RedactValue(err))
 }
 
 // FormatAll 格式化所有错误
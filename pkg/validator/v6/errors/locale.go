@@ -0,0 +1,299 @@
+package errors
+
+import (
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// 区域格式
+// ============================================================================
+
+// Locale 区域格式：数字分隔符和日期布局
+type Locale struct {
+	Tag              string // 语言标签（如 en、zh-CN、de）
+	GroupSeparator   string // 千分位分隔符（如 ","）
+	DecimalSeparator string // 小数点（如 "."）
+	DateLayout       string // 日期布局（time.Format 格式）
+	DateTimeLayout   string // 日期时间布局
+}
+
+// defaultLocaleTag 未注册的语言使用的区域
+const defaultLocaleTag = "en"
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]Locale{
+		"en":    {Tag: "en", GroupSeparator: ",", DecimalSeparator: ".", DateLayout: "2006-01-02", DateTimeLayout: "2006-01-02 15:04"},
+		"en-us": {Tag: "en-US", GroupSeparator: ",", DecimalSeparator: ".", DateLayout: "01/02/2006", DateTimeLayout: "01/02/2006 3:04 PM"},
+		"en-gb": {Tag: "en-GB", GroupSeparator: ",", DecimalSeparator: ".", DateLayout: "02/01/2006", DateTimeLayout: "02/01/2006 15:04"},
+		"zh":    {Tag: "zh", GroupSeparator: ",", DecimalSeparator: ".", DateLayout: "2006-01-02", DateTimeLayout: "2006-01-02 15:04"},
+		"ja":    {Tag: "ja", GroupSeparator: ",", DecimalSeparator: ".", DateLayout: "2006/01/02", DateTimeLayout: "2006/01/02 15:04"},
+		"de":    {Tag: "de", GroupSeparator: ".", DecimalSeparator: ",", DateLayout: "02.01.2006", DateTimeLayout: "02.01.2006 15:04"},
+		"fr":    {Tag: "fr", GroupSeparator: " ", DecimalSeparator: ",", DateLayout: "02/01/2006", DateTimeLayout: "02/01/2006 15:04"},
+		"es":    {Tag: "es", GroupSeparator: ".", DecimalSeparator: ",", DateLayout: "02/01/2006", DateTimeLayout: "02/01/2006 15:04"},
+	}
+)
+
+// RegisterLocale 注册或覆盖区域格式（标签不区分大小写，"_" 等同于 "-"）
+func RegisterLocale(locale Locale) error {
+	if locale.Tag == "" {
+		return fmt.Errorf("locale tag cannot be empty")
+	}
+	if locale.DecimalSeparator == "" || locale.DateLayout == "" || locale.DateTimeLayout == "" {
+		return fmt.Errorf("locale %s: decimal separator and date layouts are required", locale.Tag)
+	}
+
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[normalizeLocaleTag(locale.Tag)] = locale
+	return nil
+}
+
+// LookupLocale 查找区域格式
+// 说明：先精确匹配（de-AT），再匹配语言（de），都未注册时使用 en；
+// 可直接传入 Accept-Language 请求头，只使用第一个语言
+func LookupLocale(tag string) Locale {
+	tag, _, _ = strings.Cut(tag, ",")
+	tag, _, _ = strings.Cut(tag, ";")
+	tag = normalizeLocaleTag(tag)
+
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	if locale, ok := locales[tag]; ok {
+		return locale
+	}
+	if lang, _, ok := strings.Cut(tag, "-"); ok {
+		if locale, ok := locales[lang]; ok {
+			return locale
+		}
+	}
+	return locales[defaultLocaleTag]
+}
+
+// normalizeLocaleTag 规范化语言标签（小写，"_" 替换为 "-"）
+func normalizeLocaleTag(tag string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(tag)), "_", "-")
+}
+
+// FormatNumber 按区域格式化十进制数字文本（如 1234567.5 -> 1,234,567.5）
+// 说明：不是十进制数字（如 "3 5"、"1e9"、"CN"）时原样返回
+func (l Locale) FormatNumber(s string) string {
+	sign, digits := "", s
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		sign, digits = digits[:1], digits[1:]
+	}
+	intPart, fracPart, hasFrac := strings.Cut(digits, ".")
+	if !isDigits(intPart) || (hasFrac && !isDigits(fracPart)) {
+		return s
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i := 0; i < len(intPart); i++ {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(l.GroupSeparator)
+		}
+		b.WriteByte(intPart[i])
+	}
+	if hasFrac {
+		b.WriteString(l.DecimalSeparator)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
+
+// FormatDate 按区域格式化日期
+func (l Locale) FormatDate(t time.Time) string {
+	return t.Format(l.DateLayout)
+}
+
+// FormatDateTime 按区域格式化日期时间
+func (l Locale) FormatDateTime(t time.Time) string {
+	return t.Format(l.DateTimeLayout)
+}
+
+// isDigits 非空且只包含 ASCII 数字
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ============================================================================
+// 时钟
+// ============================================================================
+
+// systemClock 系统时钟（指定时区）
+type systemClock struct {
+	loc *time.Location
+}
+
+// NewSystemClock 创建系统时钟，loc 为用户时区（nil 时使用 UTC）
+func NewSystemClock(loc *time.Location) core.IClock {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return systemClock{loc: loc}
+}
+
+// Now 实现 IClock 接口
+func (c systemClock) Now() time.Time {
+	return time.Now().In(c.loc)
+}
+
+// Location 实现 IClock 接口
+func (c systemClock) Location() *time.Location {
+	return c.loc
+}
+
+// fixedClock 固定时间的时钟（测试用）
+type fixedClock struct {
+	now time.Time
+}
+
+// NewFixedClock 创建固定时间的时钟，时区为 now 的时区
+func NewFixedClock(now time.Time) core.IClock {
+	return fixedClock{now: now}
+}
+
+// Now 实现 IClock 接口
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+// Location 实现 IClock 接口
+func (c fixedClock) Location() *time.Location {
+	return c.now.Location()
+}
+
+// ============================================================================
+// 本地化格式化器
+// ============================================================================
+
+// layoutParamTags 参数本身是时间布局的标签（参数不按日期格式化）
+var layoutParamTags = map[string]struct{}{
+	"datetime": {},
+}
+
+// localeFormatter 本地化格式化器
+type localeFormatter struct {
+	locale Locale
+	clock  core.IClock
+}
+
+// LocaleOption 本地化格式化器选项
+type LocaleOption func(*localeFormatter)
+
+// WithClock 设置时钟（提供 {now} 占位符和用户时区）
+func WithClock(clock core.IClock) LocaleOption {
+	return func(f *localeFormatter) {
+		if clock != nil {
+			f.clock = clock
+		}
+	}
+}
+
+// WithTimeZone 设置用户时区（使用系统时间）
+func WithTimeZone(loc *time.Location) LocaleOption {
+	return WithClock(NewSystemClock(loc))
+}
+
+// NewLocaleFormatter 创建本地化格式化器
+// 说明：
+//   - 按区域重新渲染消息模板（见 RegisterMessage）：{param}、{value} 中的数字按区域分组（1,000 / 1.000），
+//     日期按区域布局显示，带时区的时间转换到用户时区；额外支持 {now} 占位符（时钟的当前日期时间）
+//   - 没有消息模板的错误使用原消息
+//   - 区域在构建时确定；按请求本地化时为每个请求创建格式化器，对 FieldErrors() 调用 FormatAll
+//
+// 示例：
+//
+//	formatter := NewLocaleFormatter(r.Header.Get("Accept-Language"), WithTimeZone(userLoc))
+//	messages := formatter.FormatAll(err.FieldErrors())
+func NewLocaleFormatter(tag string, opts ...LocaleOption) core.IErrorFormatter {
+	f := &localeFormatter{
+		locale: LookupLocale(tag),
+		clock:  NewSystemClock(time.UTC),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Format 格式化单个错误
+func (f *localeFormatter) Format(err core.IFieldError) string {
+	template, ok := lookupMessage(err.Namespace(), err.Field(), err.Tag())
+	if !ok {
+		return err.Message()
+	}
+
+	return newTemplateReplacer(err, f.formatValue,
+		"{field}", err.Field(),
+		"{param}", f.formatParam(err.Tag(), err.Param()),
+		"{value}", f.formatValue(RedactValue(err)),
+		"{tag}", err.Tag(),
+		"{namespace}", err.Namespace(),
+		"{now}", f.locale.FormatDateTime(f.clock.Now().In(f.clock.Location())),
+	).Replace(template)
+}
+
+// FormatAll 格式化所有错误
+func (f *localeFormatter) FormatAll(errs []core.IFieldError) []string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = f.Format(err)
+	}
+	return messages
+}
+
+// formatParam 格式化规则参数（数字、RFC 3339 时间、YYYY-MM-DD 日期，其他原样返回）
+func (f *localeFormatter) formatParam(tag, param string) string {
+	if _, ok := layoutParamTags[tag]; ok {
+		return param
+	}
+	if t, err := time.Parse(time.RFC3339, param); err == nil {
+		return f.locale.FormatDateTime(t.In(f.clock.Location()))
+	}
+	if t, err := time.Parse(time.DateOnly, param); err == nil {
+		// 日历日期没有时区，不做转换
+		return f.locale.FormatDate(t)
+	}
+	return f.locale.FormatNumber(param)
+}
+
+// formatValue 格式化字段值（数值、时间按区域格式化，字符串不做数字分组）
+func (f *localeFormatter) formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return f.locale.FormatDateTime(v.In(f.clock.Location()))
+	case fmt.Stringer:
+		// 枚举等自定义类型使用自身的文本形式（time.Duration 显示为 1h30m0s）
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return f.locale.FormatNumber(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return f.locale.FormatNumber(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		return f.locale.FormatNumber(strconv.FormatFloat(rv.Float(), 'f', -1, rv.Type().Bits()))
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// 消息模板注册表
+// ============================================================================
+
+// messageKey 消息模板键
+type messageKey struct {
+	field string // 命名空间（如 user.username）、字段名（如 username）或空（所有字段）
+	tag   string // 验证标签
+}
+
+var (
+	messagesMu sync.RWMutex
+	messages   = make(map[messageKey]string)
+)
+
+// RegisterMessage 注册消息模板
+// 参数：
+//   - field：命名空间（如 "User.Username"）、字段名（如 "username"）或空字符串（该标签的所有字段），不区分大小写
+//   - tag：验证标签（如 "min"）
+//   - template：消息模板，支持 {field}、{param}、{value}、{tag}、{namespace}、{now} 占位符（{now} 默认为 UTC 的 RFC 3339 时间，本地化格式化器按区域和用户时区显示），
+//     以及 WithParams 设置的结构化参数（如 {min}、{max}）
+//
+// 示例：RegisterMessage("User.Username", "min", "用户名长度不能少于{param}个字符")
+func RegisterMessage(field, tag, template string) {
+	messagesMu.Lock()
+	defer messagesMu.Unlock()
+	messages[messageKey{field: strings.ToLower(field), tag: tag}] = template
+}
+
+// UnregisterMessage 注销消息模板
+func UnregisterMessage(field, tag string) {
+	messagesMu.Lock()
+	defer messagesMu.Unlock()
+	delete(messages, messageKey{field: strings.ToLower(field), tag: tag})
+}
+
+// ClearMessages 清空所有消息模板
+func ClearMessages() {
+	messagesMu.Lock()
+	defer messagesMu.Unlock()
+	messages = make(map[messageKey]string)
+}
+
+// renderMessage 渲染消息模板
+// 查找顺序：命名空间 > 字段名 > 标签通用模板；未找到时返回空字符串
+func renderMessage(err core.IFieldError) string {
+	template, ok := lookupMessage(err.Namespace(), err.Field(), err.Tag())
+	if !ok {
+		return ""
+	}
+
+	value := ""
+	if v := RedactValue(err); v != nil {
+		value = fmt.Sprint(v)
+	}
+
+	return newTemplateReplacer(err, func(v any) string { return fmt.Sprint(v) },
+		"{field}", err.Field(),
+		"{param}", err.Param(),
+		"{value}", value,
+		"{tag}", err.Tag(),
+		"{namespace}", err.Namespace(),
+		"{now}", time.Now().UTC().Format(time.RFC3339),
+	).Replace(template)
+}
+
+// newTemplateReplacer 创建模板替换器：内置占位符之后追加结构化参数的 {key} 占位符（同名时内置占位符优先）
+func newTemplateReplacer(err core.IFieldError, format func(any) string, builtin ...string) *strings.Replacer {
+	params := ParamsOf(err)
+	if len(params) == 0 {
+		return strings.NewReplacer(builtin...)
+	}
+
+	pairs := make([]string, 0, len(builtin)+len(params)*2)
+	pairs = append(pairs, builtin...)
+	for key, value := range params {
+		pairs = append(pairs, "{"+key+"}", format(value))
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// lookupMessage 查找消息模板
+func lookupMessage(namespace, field, tag string) (string, bool) {
+	messagesMu.RLock()
+	defer messagesMu.RUnlock()
+
+	if len(messages) == 0 {
+		return "", false
+	}
+
+	for _, key := range [...]string{namespace, field, ""} {
+		if template, ok := messages[messageKey{field: strings.ToLower(key), tag: tag}]; ok {
+			return template, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"testing"
+)
+
+// TestRegisterMessage_LookupOrder 测试消息模板查找顺序：命名空间 > 字段名 > 标签通用模板
+func TestRegisterMessage_LookupOrder(t *testing.T) {
+	t.Cleanup(ClearMessages)
+	RegisterMessage("", "min", "generic {field} >= {param}")
+	RegisterMessage("username", "min", "field {field} >= {param}")
+	RegisterMessage("User.Username", "min", "namespace {namespace} >= {param}")
+
+	tests := []struct {
+		name      string
+		namespace string
+		field     string
+		tag       string
+		want      string
+	}{
+		{"命名空间优先", "User.username", "username", "min", "namespace User.username >= 3"},
+		{"命名空间不区分大小写", "user.USERNAME", "username", "min", "namespace user.USERNAME >= 3"},
+		{"其次字段名", "Admin.username", "Username", "min", "field Username >= 3"},
+		{"最后通用模板", "User.nickname", "nickname", "min", "generic nickname >= 3"},
+		{"其他标签使用默认消息", "User.username", "username", "max", "Field 'username' failed validation on tag 'max' with param '3'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewFieldError(tt.namespace, tt.field, tt.tag, WithParam("3"))
+			if got := err.Message(); got != tt.want {
+				t.Errorf("Message() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// 注销后回退到下一级模板
+	UnregisterMessage("USER.USERNAME", "min")
+	if got := NewFieldError("User.username", "username", "min", WithParam("3")).Message(); got != "field username >= 3" {
+		t.Errorf("注销后 Message() = %q", got)
+	}
+
+	// 自定义消息优先于模板
+	if got := NewFieldError("User.username", "username", "min", WithMessage("custom")).Message(); got != "custom" {
+		t.Errorf("自定义消息 Message() = %q", got)
+	}
+}
+
+// TestRegisterMessage_Placeholders 测试占位符替换
+func TestRegisterMessage_Placeholders(t *testing.T) {
+	t.Cleanup(ClearMessages)
+	RegisterMessage("", "between", "{field}={value} ({tag}) must be between {min} and {max}")
+	RegisterMessage("", "eq", "{field}={value} must equal {param}")
+
+	err := NewFieldError("Order.amount", "amount", "between",
+		WithValue(120), WithParams(map[string]any{"min": 1, "max": 100}))
+	if want := "amount=120 (between) must be between 1 and 100"; err.Message() != want {
+		t.Errorf("Message() = %q, want %q", err.Message(), want)
+	}
+
+	// 敏感值在模板中脱敏
+	err = NewFieldError("User.token", "token", "eq", WithValue("abc"), WithParam("x"), WithSensitive())
+	if want := "token=****** must equal x"; err.Message() != want {
+		t.Errorf("Message() = %q, want %q", err.Message(), want)
+	}
+}
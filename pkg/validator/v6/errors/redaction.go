@@ -0,0 +1,141 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"regexp"
+	"sync"
+)
+
+// ============================================================================
+// 敏感值脱敏
+// ============================================================================
+
+// RedactedValue 脱敏后的占位值
+const RedactedValue = "******"
+
+// defaultSensitivePattern 默认敏感字段名规则
+const defaultSensitivePattern = `(?i)(password|passwd|pwd|secret|token|credential|api_?key)`
+
+var (
+	sensitiveMu       sync.RWMutex
+	sensitivePatterns = []*regexp.Regexp{regexp.MustCompile(defaultSensitivePattern)}
+)
+
+// ISensitive 敏感标记接口
+// 说明：字段带有 `sensitive:"true"` 标签时，字段错误会实现该接口并返回 true
+type ISensitive interface {
+	Sensitive() bool
+}
+
+// WithSensitive 标记字段值为敏感值
+func WithSensitive() FieldErrorOption {
+	return func(e *fieldError) {
+		e.sensitive = true
+	}
+}
+
+// SetSensitivePatterns 替换敏感字段名规则（正则表达式，匹配字段名或命名空间）
+// 说明：不传参数表示清空所有规则，仅依赖 sensitive 标签
+func SetSensitivePatterns(patterns ...string) error {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid sensitive pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+
+	sensitiveMu.Lock()
+	defer sensitiveMu.Unlock()
+	sensitivePatterns = compiled
+	return nil
+}
+
+// AddSensitivePattern 追加敏感字段名规则
+func AddSensitivePattern(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid sensitive pattern %q: %w", pattern, err)
+	}
+
+	sensitiveMu.Lock()
+	defer sensitiveMu.Unlock()
+	sensitivePatterns = append(sensitivePatterns, re)
+	return nil
+}
+
+// IsSensitive 判断字段错误的值是否需要脱敏
+func IsSensitive(err core.IFieldError) bool {
+	if s, ok := err.(ISensitive); ok && s.Sensitive() {
+		return true
+	}
+
+	sensitiveMu.RLock()
+	defer sensitiveMu.RUnlock()
+
+	for _, re := range sensitivePatterns {
+		if re.MatchString(err.Field()) || re.MatchString(err.Namespace()) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactValue 获取脱敏后的字段值
+// 说明：序列化、格式化输出时应使用该方法，而不是直接使用 Value()
+func RedactValue(err core.IFieldError) any {
+	if err.Value() == nil {
+		return nil
+	}
+	if IsSensitive(err) {
+		return RedactedValue
+	}
+	return err.Value()
+}
+
+// MarshalJSON 实现 json.Marshaler 接口（敏感值自动脱敏）
+func (e *fieldError) MarshalJSON() ([]byte, error) {
+	return marshalFieldError(e)
+}
+
+// Sensitive 实现 ISensitive 接口
+func (w warningError) Sensitive() bool {
+	s, ok := w.IFieldError.(ISensitive)
+	return ok && s.Sensitive()
+}
+
+// MarshalJSON 实现 json.Marshaler 接口（敏感值自动脱敏）
+func (w warningError) MarshalJSON() ([]byte, error) {
+	return marshalFieldError(w)
+}
+
+// marshalFieldError 序列化字段错误
+func marshalFieldError(err core.IFieldError) ([]byte, error) {
+	return marshalFieldErrorWithMessage(err, err.Message())
+}
+
+// marshalFieldErrorWithMessage 序列化字段错误，使用指定的消息（如格式化器生成的消息）
+func marshalFieldErrorWithMessage(err core.IFieldError, message string) ([]byte, error) {
+	return json.Marshal(struct {
+		Namespace string         `json:"namespace,omitempty"`
+		Field     string         `json:"field,omitempty"`
+		Tag       string         `json:"tag,omitempty"`
+		Param     string         `json:"param,omitempty"`
+		Value     any            `json:"value,omitempty"`
+		Params    map[string]any `json:"params,omitempty"`
+		Message   string         `json:"message"`
+		Severity  string         `json:"severity"`
+	}{
+		Namespace: err.Namespace(),
+		Field:     err.Field(),
+		Tag:       err.Tag(),
+		Param:     err.Param(),
+		Value:     RedactValue(err),
+		Params:    ParamsOf(err),
+		Message:   message,
+		Severity:  err.Severity().String(),
+	})
+}
@@ -0,0 +1,120 @@
+package errors
+
+import (
+	"encoding/json"
+	"katydid-common-account/pkg/validator/v6/core"
+	"strings"
+	"testing"
+)
+
+// restoreSensitivePatterns 测试结束后恢复敏感字段名规则
+func restoreSensitivePatterns(t *testing.T) {
+	t.Helper()
+	sensitiveMu.RLock()
+	saved := sensitivePatterns
+	sensitiveMu.RUnlock()
+	t.Cleanup(func() {
+		sensitiveMu.Lock()
+		defer sensitiveMu.Unlock()
+		sensitivePatterns = saved
+	})
+}
+
+// TestRedactValue 测试默认规则、标签标记与自定义规则
+func TestRedactValue(t *testing.T) {
+	restoreSensitivePatterns(t)
+
+	tests := []struct {
+		name string
+		err  func() any
+		want any
+	}{
+		{"默认规则匹配字段名", func() any {
+			return RedactValue(NewFieldError("User.password", "password", "min", WithValue("123")))
+		}, RedactedValue},
+		{"默认规则匹配命名空间", func() any {
+			return RedactValue(NewFieldError("User.credentials.pin", "pin", "len", WithValue("1234")))
+		}, RedactedValue},
+		{"不区分大小写", func() any {
+			return RedactValue(NewFieldError("User.ApiKey", "ApiKey", "len", WithValue("k")))
+		}, RedactedValue},
+		{"sensitive 标签", func() any {
+			return RedactValue(NewFieldError("User.id_card", "id_card", "len", WithValue("110101"), WithSensitive()))
+		}, RedactedValue},
+		{"警告保留敏感标记", func() any {
+			return RedactValue(AsWarning(NewFieldError("User.id_card", "id_card", "len", WithValue("110101"), WithSensitive())))
+		}, RedactedValue},
+		{"普通字段", func() any {
+			return RedactValue(NewFieldError("User.username", "username", "min", WithValue("al")))
+		}, "al"},
+		{"没有值", func() any {
+			return RedactValue(NewFieldError("User.password", "password", "required"))
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err(); got != tt.want {
+				t.Errorf("RedactValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 追加规则
+	phone := NewFieldError("User.mobile", "mobile", "e164", WithValue("+8613800000000"))
+	if RedactValue(phone) != "+8613800000000" {
+		t.Fatal("追加规则前不应脱敏")
+	}
+	if err := AddSensitivePattern(`(?i)phone|mobile`); err != nil {
+		t.Fatal(err)
+	}
+	if RedactValue(phone) != RedactedValue {
+		t.Error("追加规则后应脱敏")
+	}
+
+	// 替换规则：清空后只依赖 sensitive 标签
+	if err := SetSensitivePatterns(); err != nil {
+		t.Fatal(err)
+	}
+	if got := RedactValue(NewFieldError("User.password", "password", "min", WithValue("123"))); got != "123" {
+		t.Errorf("清空规则后 RedactValue() = %v", got)
+	}
+	if got := RedactValue(NewFieldError("User.id_card", "id_card", "len", WithValue("110101"), WithSensitive())); got != RedactedValue {
+		t.Errorf("清空规则后 sensitive 标签 RedactValue() = %v", got)
+	}
+
+	if err := SetSensitivePatterns(`(`); err == nil {
+		t.Error("无效的正则表达式应返回错误")
+	}
+	if err := AddSensitivePattern(`[`); err == nil {
+		t.Error("无效的正则表达式应返回错误")
+	}
+}
+
+// TestRedaction_Output 测试 JSON 序列化与格式化器输出脱敏，Value() 保留原值
+func TestRedaction_Output(t *testing.T) {
+	restoreSensitivePatterns(t)
+	err := NewFieldError("User.password", "password", "min", WithParam("8"), WithValue("hunter2"))
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	if strings.Contains(string(data), "hunter2") || !strings.Contains(string(data), RedactedValue) {
+		t.Errorf("MarshalJSON() = %s", data)
+	}
+
+	formatters := map[string]core.IErrorFormatter{
+		"json":     NewJSONFormatter(),
+		"detailed": NewDetailedFormatter(),
+		"locale":   NewLocaleFormatter("en"),
+	}
+	for name, formatter := range formatters {
+		if out := formatter.Format(err); strings.Contains(out, "hunter2") {
+			t.Errorf("%s formatter = %q", name, out)
+		}
+	}
+
+	if err.Value() != "hunter2" {
+		t.Errorf("Value() = %v, want the original value", err.Value())
+	}
+}
@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"katydid-common-account/pkg/validator/errs"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// ============================================================================
+// 统一错误结构
+// ============================================================================
+
+// Unified 转换为各版本共享的统一错误结构（实现 errs.Converter 接口）
+// 说明：错误消息使用格式化器生成的消息，敏感值自动脱敏
+func (e *validationError) Unified() *errs.ValidationError {
+	return &errs.ValidationError{
+		Errors:   unifiedFieldErrors(e.fieldErrors, e.messages, e.formatter),
+		Warnings: unifiedFieldErrors(e.warnings, nil, e.formatter),
+	}
+}
+
+// ToUnified 将验证错误转换为统一错误结构
+// 说明：自定义的 IValidationError 实现按 FieldErrors 与 Errors 一一对应转换
+func ToUnified(err core.IValidationError) *errs.ValidationError {
+	if err == nil {
+		return nil
+	}
+	if converter, ok := err.(errs.Converter); ok {
+		return converter.Unified()
+	}
+	return &errs.ValidationError{
+		Errors:   unifiedFieldErrors(err.FieldErrors(), err.Errors(), nil),
+		Warnings: unifiedFieldErrors(err.Warnings(), nil, nil),
+	}
+}
+
+// ToUnifiedField 将字段错误转换为统一结构
+// 参数 message 为空时使用字段错误自身的消息
+func ToUnifiedField(err core.IFieldError, message string) *errs.FieldError {
+	if message == "" {
+		message = err.Message()
+	}
+	return &errs.FieldError{
+		Namespace: err.Namespace(),
+		Field:     err.Field(),
+		Tag:       err.Tag(),
+		Param:     err.Param(),
+		Value:     RedactValue(err),
+		Params:    ParamsOf(err),
+		Message:   message,
+		Severity:  err.Severity().String(),
+	}
+}
+
+// unifiedFieldErrors 批量转换字段错误
+// 说明：messages 为预先格式化的消息（与 fieldErrors 一一对应），缺失时使用格式化器生成
+func unifiedFieldErrors(fieldErrors []core.IFieldError, messages []string, formatter core.IErrorFormatter) []*errs.FieldError {
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+
+	result := make([]*errs.FieldError, 0, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		var message string
+		switch {
+		case i < len(messages):
+			message = messages[i]
+		case formatter != nil:
+			message = formatter.Format(fe)
+		}
+		result = append(result, ToUnifiedField(fe, message))
+	}
+	return result
+}
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"encoding/json"
 	"katydid-common-account/pkg/validator/v6/core"
 	"strings"
 )
@@ -8,12 +9,47 @@ import (
 // validationError 验证错误实现
 type validationError struct {
 	fieldErrors []core.IFieldError
+	warnings    []core.IFieldError
 	formatter   core.IErrorFormatter
 	messages    []string // 缓存格式化后的消息
+	ruleVersion string   // 外部规则版本号
+	truncated   bool     // 错误数是否达到上限
+	timings     []core.StageTiming
+}
+
+// ValidationErrorOption 验证错误选项
+type ValidationErrorOption func(*validationError)
+
+// WithRuleVersion 设置外部规则版本号
+func WithRuleVersion(version string) ValidationErrorOption {
+	return func(e *validationError) {
+		e.ruleVersion = version
+	}
+}
+
+// WithWarnings 设置警告
+func WithWarnings(warnings []core.IFieldError) ValidationErrorOption {
+	return func(e *validationError) {
+		e.warnings = warnings
+	}
+}
+
+// WithTruncated 标记错误列表因达到上限而被截断
+func WithTruncated(truncated bool) ValidationErrorOption {
+	return func(e *validationError) {
+		e.truncated = truncated
+	}
+}
+
+// WithStageTimings 设置阶段执行耗时
+func WithStageTimings(timings []core.StageTiming) ValidationErrorOption {
+	return func(e *validationError) {
+		e.timings = timings
+	}
 }
 
 // NewValidationError 创建验证错误
-func NewValidationError(fieldErrors []core.IFieldError, formatter core.IErrorFormatter) core.IValidationError {
+func NewValidationError(fieldErrors []core.IFieldError, formatter core.IErrorFormatter, opts ...ValidationErrorOption) core.IValidationError {
 	if formatter == nil {
 		formatter = NewDefaultFormatter()
 	}
@@ -23,6 +59,11 @@ func NewValidationError(fieldErrors []core.IFieldError, formatter core.IErrorFor
 		formatter:   formatter,
 	}
 
+	// 应用选项
+	for _, opt := range opts {
+		opt(ve)
+	}
+
 	// 预先格式化所有错误
 	if len(fieldErrors) > 0 {
 		ve.messages = formatter.FormatAll(fieldErrors)
@@ -54,6 +95,11 @@ func (e *validationError) FieldErrors() []core.IFieldError {
 	return e.fieldErrors
 }
 
+// Warnings 获取所有警告
+func (e *validationError) Warnings() []core.IFieldError {
+	return e.warnings
+}
+
 // First 获取第一个错误
 func (e *validationError) First() string {
 	if len(e.messages) == 0 {
@@ -61,3 +107,146 @@ func (e *validationError) First() string {
 	}
 	return e.messages[0]
 }
+
+// RuleVersion 获取外部规则版本号
+func (e *validationError) RuleVersion() string {
+	return e.ruleVersion
+}
+
+// Truncated 是否有错误因达到上限被丢弃
+func (e *validationError) Truncated() bool {
+	return e.truncated
+}
+
+// StageTimings 获取阶段执行耗时
+func (e *validationError) StageTimings() []core.StageTiming {
+	return e.timings
+}
+
+// IsValid 是否验证通过
+func (e *validationError) IsValid() bool {
+	return len(e.fieldErrors) == 0
+}
+
+// ByField 按字段命名空间分组错误
+func (e *validationError) ByField() map[string][]core.IFieldError {
+	return groupFieldErrors(e.fieldErrors, core.IFieldError.Namespace)
+}
+
+// ByTag 按验证标签分组错误
+func (e *validationError) ByTag() map[string][]core.IFieldError {
+	return groupFieldErrors(e.fieldErrors, core.IFieldError.Tag)
+}
+
+// GroupJSON 按字段分组的机器可读 JSON
+// 格式：{"valid":false,"errors":{"User.email":[{...}]},"warnings":{...},"truncated":false}
+// 说明：错误消息使用格式化器生成的消息，map 键按字典序输出，结果是确定的
+func (e *validationError) GroupJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Valid       bool                             `json:"valid"`
+		Errors      map[string][]formattedFieldError `json:"errors,omitempty"`
+		Warnings    map[string][]formattedFieldError `json:"warnings,omitempty"`
+		Truncated   bool                             `json:"truncated,omitempty"`
+		RuleVersion string                           `json:"rule_version,omitempty"`
+	}{
+		Valid:       e.IsValid(),
+		Errors:      e.groupFormatted(e.fieldErrors, e.messages),
+		Warnings:    e.groupFormatted(e.warnings, nil),
+		Truncated:   e.truncated,
+		RuleVersion: e.ruleVersion,
+	})
+}
+
+// Merge 合并另一个结果
+// 说明：错误和警告按顺序拼接，截断标记取或，阶段耗时拼接；规则版本优先使用自身的
+func (e *validationError) Merge(other core.IValidationError) core.IValidationError {
+	if other == nil {
+		return e
+	}
+
+	ruleVersion := e.ruleVersion
+	if ruleVersion == "" {
+		ruleVersion = other.RuleVersion()
+	}
+
+	return NewValidationError(
+		concatFieldErrors(e.fieldErrors, other.FieldErrors()),
+		e.formatter,
+		WithWarnings(concatFieldErrors(e.warnings, other.Warnings())),
+		WithTruncated(e.truncated || other.Truncated()),
+		WithRuleVersion(ruleVersion),
+		WithStageTimings(append(append([]core.StageTiming(nil), e.timings...), other.StageTimings()...)),
+	)
+}
+
+// groupFormatted 按命名空间分组
+// 说明：messages 为预先格式化的消息（与 fieldErrors 一一对应），缺失时使用格式化器生成
+func (e *validationError) groupFormatted(fieldErrors []core.IFieldError, messages []string) map[string][]formattedFieldError {
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+
+	groups := make(map[string][]formattedFieldError)
+	for i, fe := range fieldErrors {
+		var message string
+		if i < len(messages) {
+			message = messages[i]
+		} else {
+			message = e.formatter.Format(fe)
+		}
+		groups[fe.Namespace()] = append(groups[fe.Namespace()], formattedFieldError{fe, message})
+	}
+	return groups
+}
+
+// formattedFieldError 带格式化消息的字段错误（序列化时敏感值脱敏）
+type formattedFieldError struct {
+	err     core.IFieldError
+	message string
+}
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (f formattedFieldError) MarshalJSON() ([]byte, error) {
+	return marshalFieldErrorWithMessage(f.err, f.message)
+}
+
+// groupFieldErrors 按 key 分组错误，保持原有顺序
+func groupFieldErrors(fieldErrors []core.IFieldError, key func(core.IFieldError) string) map[string][]core.IFieldError {
+	groups := make(map[string][]core.IFieldError)
+	for _, fe := range fieldErrors {
+		k := key(fe)
+		groups[k] = append(groups[k], fe)
+	}
+	return groups
+}
+
+// concatFieldErrors 拼接错误列表（不修改原切片）
+func concatFieldErrors(a, b []core.IFieldError) []core.IFieldError {
+	if len(a)+len(b) == 0 {
+		return nil
+	}
+	result := make([]core.IFieldError, 0, len(a)+len(b))
+	result = append(result, a...)
+	return append(result, b...)
+}
+
+// ============================================================================
+// 结果汇总
+// ============================================================================
+
+// MergeResults 汇总多个验证结果，忽略 nil（Validate 通过时返回 nil）
+// 返回值：全部为 nil 时返回 nil
+func MergeResults(results ...core.IValidationError) core.IValidationError {
+	var merged core.IValidationError
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		if merged == nil {
+			merged = result
+			continue
+		}
+		merged = merged.Merge(result)
+	}
+	return merged
+}
@@ -0,0 +1,146 @@
+package errors
+
+import (
+	"testing"
+
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// resultErrors 测试用字段错误：User.email 两条，User.name 一条
+func resultErrors() []core.IFieldError {
+	return []core.IFieldError{
+		NewFieldError("User.email", "email", "required", WithMessage("email required")),
+		NewFieldError("User.name", "name", "min", WithParam("3"), WithMessage("name too short")),
+		NewFieldError("User.email", "email", "email", WithMessage("email invalid")),
+	}
+}
+
+// TestValidationError_Grouping 测试 ByField/ByTag 分组并保持原有顺序
+func TestValidationError_Grouping(t *testing.T) {
+	result := NewValidationError(resultErrors(), nil)
+
+	byField := result.ByField()
+	if len(byField) != 2 {
+		t.Fatalf("ByField() 分组数 = %d, want 2", len(byField))
+	}
+	var tags []string
+	for _, fe := range byField["User.email"] {
+		tags = append(tags, fe.Tag())
+	}
+	if len(tags) != 2 || tags[0] != "required" || tags[1] != "email" {
+		t.Errorf("ByField()[User.email] tags = %v, want [required email]", tags)
+	}
+	if len(byField["User.name"]) != 1 {
+		t.Errorf("ByField()[User.name] = %v, want 1 error", byField["User.name"])
+	}
+
+	byTag := result.ByTag()
+	if len(byTag) != 3 || byTag["min"][0].Namespace() != "User.name" {
+		t.Errorf("ByTag() = %v", byTag)
+	}
+
+	// 无错误时返回空分组
+	if got := NewValidationError(nil, nil).ByField(); len(got) != 0 {
+		t.Errorf("无错误 ByField() = %v, want empty", got)
+	}
+}
+
+// TestValidationError_GroupJSON 测试分组 JSON 格式稳定，警告按字段分组
+func TestValidationError_GroupJSON(t *testing.T) {
+	warning := NewFieldError("User.nickname", "nickname", "max",
+		WithSeverity(core.SeverityWarning), WithMessage("nickname long"))
+	result := NewValidationError(resultErrors(), nil,
+		WithWarnings([]core.IFieldError{warning}),
+		WithTruncated(true),
+		WithRuleVersion("v2"),
+	)
+
+	want := `{"valid":false,"errors":{` +
+		`"User.email":[` +
+		`{"namespace":"User.email","field":"email","tag":"required","message":"email required","severity":"error"},` +
+		`{"namespace":"User.email","field":"email","tag":"email","message":"email invalid","severity":"error"}],` +
+		`"User.name":[{"namespace":"User.name","field":"name","tag":"min","param":"3","message":"name too short","severity":"error"}]},` +
+		`"warnings":{"User.nickname":[{"namespace":"User.nickname","field":"nickname","tag":"max","message":"nickname long","severity":"warning"}]},` +
+		`"truncated":true,"rule_version":"v2"}`
+
+	// 多次序列化结果一致
+	for i := 0; i < 3; i++ {
+		data, err := result.GroupJSON()
+		if err != nil {
+			t.Fatalf("GroupJSON() error = %v", err)
+		}
+		if string(data) != want {
+			t.Fatalf("GroupJSON() =\n%s\nwant\n%s", data, want)
+		}
+	}
+
+	// 通过时只有 valid 字段
+	data, err := NewValidationError(nil, nil).GroupJSON()
+	if err != nil || string(data) != `{"valid":true}` {
+		t.Errorf("通过时 GroupJSON() = %s, %v", data, err)
+	}
+}
+
+// TestValidationError_Merge 测试合并：错误和警告拼接、截断取或、规则版本优先自身
+func TestValidationError_Merge(t *testing.T) {
+	errs := resultErrors()
+	warning := NewFieldError("Order.note", "note", "max", WithSeverity(core.SeverityWarning))
+
+	first := NewValidationError(errs[:1], nil,
+		WithStageTimings([]core.StageTiming{{Name: "rule"}}))
+	second := NewValidationError(errs[1:], nil,
+		WithWarnings([]core.IFieldError{warning}),
+		WithTruncated(true),
+		WithRuleVersion("v3"),
+		WithStageTimings([]core.StageTiming{{Name: "business"}}))
+
+	merged := first.Merge(second)
+	if got := merged.FieldErrors(); len(got) != 3 || got[0] != errs[0] || got[2] != errs[2] {
+		t.Errorf("Merge() FieldErrors = %v, want 3 errors in order", got)
+	}
+	if got := merged.Errors(); len(got) != 3 || got[1] != "name too short" {
+		t.Errorf("Merge() Errors = %v", got)
+	}
+	if len(merged.Warnings()) != 1 || !merged.Truncated() {
+		t.Errorf("Merge() warnings = %d truncated = %t", len(merged.Warnings()), merged.Truncated())
+	}
+	if merged.RuleVersion() != "v3" {
+		t.Errorf("Merge() RuleVersion = %q, want v3（自身为空时取对方）", merged.RuleVersion())
+	}
+	if timings := merged.StageTimings(); len(timings) != 2 || timings[0].Name != "rule" || timings[1].Name != "business" {
+		t.Errorf("Merge() StageTimings = %v", timings)
+	}
+
+	// 原结果不受影响
+	if len(first.FieldErrors()) != 1 || len(second.FieldErrors()) != 2 {
+		t.Error("Merge() 不应修改原结果")
+	}
+
+	// 规则版本优先使用自身的
+	own := NewValidationError(errs[:1], nil, WithRuleVersion("v1"))
+	if got := own.Merge(second).RuleVersion(); got != "v1" {
+		t.Errorf("Merge() RuleVersion = %q, want v1", got)
+	}
+
+	// 合并 nil 返回自身
+	if got := first.Merge(nil); got != first {
+		t.Errorf("Merge(nil) = %v, want self", got)
+	}
+}
+
+// TestMergeResults 测试汇总多个结果时忽略 nil
+func TestMergeResults(t *testing.T) {
+	if got := MergeResults(nil, nil); got != nil {
+		t.Errorf("MergeResults(nil, nil) = %v, want nil", got)
+	}
+
+	errs := resultErrors()
+	a := NewValidationError(errs[:1], nil)
+	b := NewValidationError(errs[1:], nil)
+	if got := MergeResults(nil, a, nil); got != a {
+		t.Errorf("MergeResults(nil, a, nil) = %v, want a", got)
+	}
+	if got := MergeResults(a, nil, b); len(got.FieldErrors()) != 3 {
+		t.Errorf("MergeResults(a, nil, b) FieldErrors = %v, want 3", got.FieldErrors())
+	}
+}
@@ -1,9 +1,15 @@
 package v6_test
 
 import (
+	stdcontext "context"
+	"encoding/json"
 	"fmt"
+	"katydid-common-account/pkg/types"
 	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/audit"
 	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/rules"
+	"time"
 )
 
 // 定义场景
@@ -94,6 +100,8 @@ func Example_basic() {
 	}
 
 	// Output:
+	// 验证前处理: scene=1
+	// 验证后处理: scene=1
 	// 验证失败:
 	//   - Field 'username' failed validation on tag 'min' with param '3'
 	//   - Field 'email' failed validation on tag 'email'
@@ -125,6 +133,8 @@ func Example_builder() {
 	}
 
 	// Output:
+	// 验证前处理: scene=1
+	// 验证后处理: scene=1
 	// 验证通过
 }
 
@@ -192,9 +202,9 @@ func Example_listener() {
 	// Output:
 	// 监听器: 验证开始
 	// 验证前处理: scene=1
-	// 验证后处理: scene=1
 	// 监听器: 发现错误 - username
 	// 监听器: 发现错误 - email
+	// 验证后处理: scene=1
 	// 监听器: 验证结束
 }
 
@@ -215,5 +225,670 @@ func Example_facade() {
 	}
 
 	// Output:
+	// 验证前处理: scene=1
+	// 验证后处理: scene=1
 	// 验证通过
 }
+
+// Example_fastPath 删除场景的快速路径
+func Example_fastPath() {
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithBusinessStrategy(20).
+		WithInterceptor(v6.InterceptorFunc(func(ctx core.IContext, target any, next func() error) error {
+			fmt.Printf("拦截器: scene=%v\n", ctx.Scene())
+			return next()
+		})).
+		WithFastPath(SceneDelete, "rule").
+		Build()
+
+	// 删除场景：跳过拦截器，只执行规则策略
+	if err := validator.Validate(&User{ID: 1}, SceneDelete); err == nil {
+		fmt.Println("删除验证通过")
+	}
+
+	// 其他场景仍执行完整流程（业务策略发现弱密码）
+	user := &User{Username: "john", Email: "john@example.com", Password: "123456", Age: 25}
+	if err := validator.Validate(user, SceneCreate); err != nil {
+		fmt.Printf("创建验证失败: %s\n", err.Errors()[0])
+	}
+
+	// Output:
+	// 验证前处理: scene=4
+	// 验证后处理: scene=4
+	// 删除验证通过
+	// 拦截器: scene=1
+	// 验证前处理: scene=1
+	// 验证后处理: scene=1
+	// 创建验证失败: 密码过于简单
+}
+
+// auditListener 记录跳过规则的审计监听器
+type auditListener struct{}
+
+func (l *auditListener) OnValidationStart(ctx core.IContext, target any)          {}
+func (l *auditListener) OnValidationEnd(ctx core.IContext, target any, err error) {}
+func (l *auditListener) OnError(ctx core.IContext, fieldErr core.IFieldError)     {}
+
+func (l *auditListener) OnEvent(ctx core.IContext, event core.Event) {
+	if event.Type == core.EventTypeRulesSkipped {
+		fmt.Printf("审计: skips=%v justification=%q skipped=%d\n", event.Skips, event.Justification, len(event.Skipped))
+	}
+}
+
+// Example_skips 管理工具跳过指定规则（带审计记录）
+func Example_skips() {
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithUsernamePolicyStrategy(20).
+		WithListener(&auditListener{}).
+		Build()
+
+	user := &User{Username: "admin", Email: "admin@example.com", Password: "password123", Age: 30}
+	if err := validator.Validate(user, SceneCreate); err != nil {
+		fmt.Printf("验证失败: %s=%s\n", err.FieldErrors()[0].Tag(), err.FieldErrors()[0].Param())
+	}
+
+	// 缺少理由时拒绝跳过
+	if err := validator.Validate(user, SceneCreate, v6.WithSkips("User.Username:reserved")); err != nil {
+		fmt.Printf("验证失败: %s\n", err.FieldErrors()[0].Tag())
+	}
+
+	err := validator.Validate(user, SceneCreate,
+		v6.WithSkips("User.Username:reserved"),
+		v6.WithSkipJustification("internal account"))
+	fmt.Printf("跳过后验证通过: %v\n", err == nil)
+
+	// Output:
+	// 验证前处理: scene=1
+	// 验证后处理: scene=1
+	// 验证失败: username_policy=reserved
+	// 验证失败: skip
+	// 验证前处理: scene=1
+	// 验证后处理: scene=1
+	// 审计: skips=[User.Username:reserved] justification="internal account" skipped=1
+	// 跳过后验证通过: true
+}
+
+// stepStrategy 打印执行顺序的自定义策略
+type stepStrategy struct{ name string }
+
+func (s *stepStrategy) Type() core.StrategyType { return core.StrategyType(s.name) }
+func (s *stepStrategy) Name() string            { return s.name }
+
+func (s *stepStrategy) Validate(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	fmt.Printf("执行策略: %s\n", s.name)
+	return nil
+}
+
+// Example_runsAfter 自定义策略的依赖顺序
+func Example_runsAfter() {
+	// password_history 依赖 password_strength 计算的规范化密码，优先级更高也会排在后面
+	validator := v6.NewBuilder().
+		WithStrategy(v6.RunsAfter(&stepStrategy{name: "password_history"}, "password_strength"), 10).
+		WithStrategy(&stepStrategy{name: "password_strength"}, 20).
+		WithStrategy(&stepStrategy{name: "audit"}, 30).
+		Build()
+
+	validator.Validate(&User{}, SceneCreate)
+
+	// Output:
+	// 验证前处理: scene=1
+	// 执行策略: password_strength
+	// 执行策略: password_history
+	// 执行策略: audit
+	// 验证后处理: scene=1
+}
+
+// Example_mapRules 验证无结构体模型的 JSON 请求体
+func Example_mapRules() {
+	var payload map[string]any
+	_ = json.Unmarshal([]byte(`{"age": "16", "level": 2, "tags": ["a", ""], "address": {"city": ""}}`), &payload)
+
+	err := v6.ValidateMapWithRules(payload, map[string]string{
+		"name":         "required",
+		"age":          "required,numeric,gte=18",
+		"level":        "oneof=1 2 3",
+		"tags":         "dive,required",
+		"address.city": "required",
+	})
+	for _, fe := range err.FieldErrors() {
+		fmt.Printf("%s: %s\n", fe.Namespace(), fe.Tag())
+	}
+
+	// Output:
+	// address.city: required
+	// age: gte
+	// name: required
+	// tags[1]: required
+}
+
+// Example_ruleAlias 规则别名
+func Example_ruleAlias() {
+	_ = v6.RegisterRuleAlias("username_std", "required,min=3,max=20,alphanum")
+	_ = v6.RegisterRuleAlias("handle", "username_std,lowercase")
+	defer func() {
+		_ = v6.UnregisterRuleAlias("handle")
+		_ = v6.UnregisterRuleAlias("username_std")
+	}()
+
+	// 循环引用在注册时报错
+	_ = v6.RegisterRuleAlias("loop_a", "loop_b")
+	if err := v6.RegisterRuleAlias("loop_b", "loop_a"); err != nil {
+		fmt.Println(err)
+	}
+	_ = v6.UnregisterRuleAlias("loop_a")
+
+	err := v6.ValidateMapWithRules(map[string]any{"username": "jo", "handle": "John_1"}, map[string]string{
+		"username": "username_std",
+		"handle":   "handle",
+	})
+	for _, fe := range err.FieldErrors() {
+		fmt.Printf("%s: %s\n", fe.Namespace(), fe.Tag())
+	}
+
+	// Output:
+	// rule alias cycle: loop_a -> loop_b -> loop_a
+	// handle: alphanum
+	// username: min
+}
+
+// Example_cachedRules 查看类型信息中缓存的预解析规则
+func Example_cachedRules() {
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+	validator.Validate(&User{Username: "john", Email: "john@example.com", Password: "password123", Age: 25}, SceneCreate)
+
+	cached := validator.(v6.RuleCacheInspector).CachedRules(&User{})
+	for _, field := range cached[SceneCreate].Fields {
+		fmt.Printf("%s: %s (required=%v)\n", field.Field, field.Rest, field.Required)
+	}
+
+	// Output:
+	// 验证前处理: scene=1
+	// 验证后处理: scene=1
+	// username: min=3,max=20 (required=true)
+	// email: email (required=true)
+	// password: min=6 (required=true)
+	// age: gte=18,lte=120 (required=true)
+}
+
+// BaseModel 公共字段
+type BaseModel struct {
+	ID        int64  `json:"id"`
+	CreatedBy string `json:"created_by"`
+}
+
+// ValidateRules 公共字段的规则，嵌入它的模型自动合并
+func (b *BaseModel) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{
+		"id":         "required,gt=0",
+		"created_by": "required,min=2",
+	}
+}
+
+// Article 嵌入 BaseModel 的模型
+type Article struct {
+	BaseModel
+	Title string `json:"title"`
+}
+
+// ValidateRules 外层规则优先：id 覆盖嵌入规则
+func (a *Article) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{
+		"id":    "omitempty,gt=0",
+		"title": "required,min=3",
+	}
+}
+
+// Example_embeddedRules 合并嵌入结构体的规则
+func Example_embeddedRules() {
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+	err := validator.Validate(&Article{BaseModel: BaseModel{CreatedBy: "x"}, Title: "Go"}, SceneCreate)
+	for _, fe := range err.FieldErrors() {
+		fmt.Printf("%s: %s\n", fe.Namespace(), fe.Tag())
+	}
+
+	// Output:
+	// Article.BaseModel.created_by: min
+	// Article.title: min
+}
+
+// CardPayment 银行卡支付
+type CardPayment struct {
+	Number string `json:"number"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (p *CardPayment) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{"number": "required,len=16,numeric"}
+}
+
+// Amount 金额值对象
+type Amount struct {
+	Cents    int64
+	Currency string
+}
+
+// ValidateSelf 实现 ISelfValidator 接口
+func (a Amount) ValidateSelf(scene core.Scene) error {
+	if a.Cents <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+	return nil
+}
+
+// Order 带接口类型字段的订单
+type Order struct {
+	Payment any   `json:"payment"`
+	Lines   []any `json:"lines"`
+}
+
+// Example_nestedDynamic 按运行时类型验证接口类型字段
+func Example_nestedDynamic() {
+	validator := v6.NewBuilder().WithRuleStrategy(10).WithNestedStrategy(20).Build()
+	err := validator.Validate(&Order{
+		Payment: &CardPayment{Number: "4111"},
+		Lines:   []any{Amount{Cents: 100}, Amount{Cents: 0}},
+	}, SceneCreate)
+	for _, fe := range err.FieldErrors() {
+		fmt.Printf("%s: %s\n", fe.Namespace(), fe.Tag())
+	}
+
+	// Output:
+	// Order.payment(CardPayment).number: len
+	// Order.lines[1](Amount): self
+}
+
+// Row 批量导入的一行
+type Row struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Import 批量导入
+type Import struct {
+	Rows []Row `json:"rows"`
+}
+
+// ValidateBusiness 逐行检查
+func (i *Import) ValidateBusiness(scene core.Scene, collector core.IErrorCollector) {
+	for idx, row := range i.Rows {
+		if row.Name == "" && !collector.Collect(v6.NewFieldError(fmt.Sprintf("Import.rows[%d].name", idx), "name", "required")) {
+			return
+		}
+		if row.Email == "" && !collector.Collect(v6.NewFieldError(fmt.Sprintf("Import.rows[%d].email", idx), "email", "required")) {
+			return
+		}
+	}
+}
+
+// Example_errorDetailLimit 大量错误时只保留部分详情，其余汇总
+func Example_errorDetailLimit() {
+	validator := v6.NewBuilder().
+		WithBusinessStrategy(10).
+		WithMaxErrors(100000).
+		WithErrorDetailLimit(2).
+		Build()
+
+	err := validator.Validate(&Import{Rows: make([]Row, 1200)}, SceneCreate)
+	for _, fe := range err.FieldErrors() {
+		fmt.Printf("%s %s: %s\n", fe.Namespace(), fe.Tag(), fe.Message())
+	}
+
+	// Output:
+	// Import.rows[0].name required: Field 'name' failed validation on tag 'required'
+	// Import.rows[0].email required: Field 'email' failed validation on tag 'required'
+	//  more_errors: and 2,398 more errors across 2 fields
+}
+
+// 归档相关场景（示例使用独立的位，不影响其他示例的场景输出）
+const (
+	SceneArchive core.Scene = 1 << 10
+	SceneRestore core.Scene = 1 << 11
+)
+
+// Example_sceneNames 场景名称的注册、输出和解析
+func Example_sceneNames() {
+	v6.MustRegisterScene(SceneArchive, "archive")
+	v6.MustRegisterScene(SceneRestore, "restore")
+	defer core.UnregisterScene(SceneArchive)
+	defer core.UnregisterScene(SceneRestore)
+
+	fmt.Println(SceneArchive | SceneRestore)
+
+	scene, _ := v6.ParseScene("archive|restore")
+	fmt.Println(scene == SceneArchive|SceneRestore)
+
+	data, _ := json.Marshal(struct {
+		Scene core.Scene `json:"scene"`
+	}{Scene: SceneRestore})
+	fmt.Println(string(data))
+
+	if _, err := v6.ParseScene("archive|purge"); err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// archive|restore
+	// true
+	// {"scene":"restore"}
+	// unknown scene "purge"
+}
+
+// SceneQuery 列表查询场景
+const SceneQuery core.Scene = 1 << 3
+
+// UserQuery 用户列表的过滤条件
+type UserQuery struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Phone    string `json:"phone"`
+}
+
+// AllowedQueryFields 实现 IFieldPolicy 接口：普通用户只能按用户名过滤
+func (q *UserQuery) AllowedQueryFields(role string) []string {
+	if role == "admin" {
+		return nil
+	}
+	return []string{"username"}
+}
+
+// Example_queryPolicy 按角色限制列表接口的过滤字段
+func Example_queryPolicy() {
+	validator := v6.NewBuilder().WithQueryPolicyStrategy(10, SceneQuery).Build()
+	query := &UserQuery{Username: "jo", Phone: "138"}
+
+	err := validator.Validate(query, SceneQuery, v6.WithContext(v6.WithRole(stdcontext.Background(), "member")))
+	for _, fe := range err.FieldErrors() {
+		fmt.Printf("%s: %s (%s)\n", fe.Namespace(), fe.Tag(), fe.Param())
+	}
+
+	if err := validator.Validate(query, SceneQuery, v6.WithContext(v6.WithRole(stdcontext.Background(), "admin"))); err == nil {
+		fmt.Println("admin: ok")
+	}
+
+	// 宽松模式：忽略无权使用的过滤条件
+	fmt.Println(v6.StripQueryFields(query, "member"), query.Phone == "")
+
+	// Output:
+	// UserQuery.phone: field_not_queryable (member)
+	// admin: ok
+	// [phone] true
+}
+
+// Example_weakTyping 表单请求体的弱类型验证
+func Example_weakTyping() {
+	validator := v6.NewBuilder().WithWeakTyping().Build()
+	form := map[string]any{"age": "25", "agree": "true", "count": "0", "zip": "01234"}
+
+	result := validator.(v6.MapValidator).ValidateMapWithRules(form, map[string]string{
+		"age":   "required,gte=18,lte=120",
+		"agree": "required,eq=true",
+		"count": "required,gte=0",
+		"zip":   "required,len=5,numeric",
+	})
+	fmt.Println(result.HasErrors())
+	for _, note := range result.Warnings() {
+		fmt.Printf("%s: %s\n", note.Namespace(), note.Message())
+	}
+
+	// Output:
+	// false
+	// age: coerced "25" to int64
+	// agree: coerced "true" to bool
+	// count: coerced "0" to int64
+}
+
+// Member 使用规则构建器声明规则的会员
+type Member struct {
+	Username string `json:"username"`
+	Age      int    `json:"age"`
+	Plan     string `json:"plan"`
+}
+
+// memberRules 构建一次后复用
+var memberRules = rules.New().
+	Field("username", rules.Required(), rules.MinLen(3), rules.MaxLen(20), rules.AlphaNum()).
+	Field("age", rules.Omitempty(), rules.Gte(18), rules.Lte(120)).
+	Field("plan", rules.Required(), rules.OneOf("free", "pro")).
+	Build()
+
+// ValidateRules 实现 IRuleValidator 接口
+func (m *Member) ValidateRules(scene core.Scene) map[string]string {
+	return memberRules
+}
+
+// Example_ruleBuilder 用类型安全的构建器代替规则标签字符串
+func Example_ruleBuilder() {
+	fmt.Println(memberRules["username"])
+
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+	err := validator.Validate(&Member{Username: "al", Age: 16, Plan: "gold"}, SceneCreate)
+	for _, fe := range err.FieldErrors() {
+		fmt.Printf("%s: %s\n", fe.Namespace(), fe.Tag())
+	}
+
+	// Output:
+	// required,min=3,max=20,alphanum
+	// Member.username: min
+	// Member.age: gte
+	// Member.plan: oneof
+}
+
+// Example_batchUniqueness 批量导入时检查同一批次中的重复记录
+func Example_batchUniqueness() {
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithBatchUniqueness(v6.UniqueFieldFold("username")).
+		Build()
+
+	members := []Member{
+		{Username: "alice", Plan: "free"},
+		{Username: "bob", Plan: "pro"},
+		{Username: "Alice", Plan: "gold"},
+	}
+	results := validator.(v6.SliceValidator).ValidateSlice(members, SceneCreate)
+	for i, result := range results {
+		if result == nil {
+			fmt.Printf("row %d: ok\n", i)
+			continue
+		}
+		for _, fe := range result.FieldErrors() {
+			fmt.Printf("row %d: %s %s=%s\n", i, fe.Namespace(), fe.Tag(), fe.Param())
+		}
+	}
+
+	// Output:
+	// row 0: ok
+	// row 1: ok
+	// row 2: Member.plan oneof=free pro
+	// row 2: Member.username batch_duplicate=0
+}
+
+// ArticleQuery 文章列表查询
+type ArticleQuery struct {
+	types.PageRequest
+	Sort types.SortSpec `json:"sort"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (q *ArticleQuery) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{
+		"sort": "omitempty,sortable=title created_at",
+	}
+}
+
+// Example_pagination 分页和排序参数
+func Example_pagination() {
+	var query ArticleQuery
+	_ = json.Unmarshal([]byte(`{"page":3,"per_page":500,"sort":"-created_at,views"}`), &query)
+
+	page := query.Normalize(types.PageOptions{MaxPerPage: 50})
+	fmt.Println(page.Offset(), page.Limit())
+
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+	err := validator.Validate(&query, SceneQuery)
+	for _, fe := range err.FieldErrors() {
+		fmt.Printf("%s: %s\n", fe.Namespace(), fe.Tag())
+	}
+
+	query.Sort = query.Sort[:1]
+	fmt.Println(validator.Validate(&query, SceneQuery) == nil, query.Sort.OrderBy(nil))
+
+	// Output:
+	// 100 50
+	// ArticleQuery.sort: sortable
+	// true created_at DESC
+}
+
+// MemberRole 成员角色
+type MemberRole string
+
+const (
+	MemberRoleOwner  MemberRole = "owner"
+	MemberRoleEditor MemberRole = "editor"
+)
+
+// MemberRoles 成员角色枚举（验证规则 enum=MemberRole）
+var MemberRoles = types.NewEnum[MemberRole]("MemberRole").
+	Add(MemberRoleOwner, "所有者").
+	Add(MemberRoleEditor, "编辑")
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+func (r *MemberRole) UnmarshalJSON(b []byte) error { return MemberRoles.DecodeJSON(b, r) }
+
+// Invitation 邀请成员
+type Invitation struct {
+	Email string     `json:"email"`
+	Role  MemberRole `json:"role"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (i *Invitation) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{
+		"email": "required,email",
+		"role":  "required,enum=MemberRole",
+	}
+}
+
+// Example_enum 枚举规则
+func Example_enum() {
+	var invitation Invitation
+	err := json.Unmarshal([]byte(`{"email":"a@example.com","role":"admin"}`), &invitation)
+	fmt.Println(err)
+
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+	invitation = Invitation{Email: "a@example.com", Role: "admin"}
+	for _, fe := range validator.Validate(&invitation, SceneCreate).FieldErrors() {
+		fmt.Printf("%s: %s\n", fe.Namespace(), fe.Tag())
+	}
+
+	invitation.Role = MemberRoleEditor
+	fmt.Println(validator.Validate(&invitation, SceneCreate) == nil, MemberRoles.Label(invitation.Role))
+
+	// Output:
+	// enum: invalid value "admin" for MemberRole
+	// Invitation.role: enum
+	// true 编辑
+}
+
+// Booking 预订
+type Booking struct {
+	Budget float64 `json:"budget"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (b *Booking) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{
+		"budget": "lte=10000",
+	}
+}
+
+// Example_localeFormatter 按区域和用户时区显示消息中的数字和日期
+func Example_localeFormatter() {
+	v6.RegisterMessage("Booking.budget", "lte", "budget {value} exceeds {param} ({now})")
+
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+	err := validator.Validate(&Booking{Budget: 25000.5}, SceneCreate)
+
+	now := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	for _, lang := range []string{"en-US", "de-AT,de;q=0.9", "zh-CN"} {
+		formatter := v6.NewLocaleFormatter(lang,
+			v6.WithClock(v6.NewFixedClock(now.In(time.FixedZone("UTC+8", 8*3600)))))
+		fmt.Println(formatter.FormatAll(err.FieldErrors())[0])
+	}
+
+	// Output:
+	// budget 25,000.5 exceeds 10,000 (03/01/2026 8:30 PM)
+	// budget 25.000,5 exceeds 10.000 (01.03.2026 20:30)
+	// budget 25,000.5 exceeds 10,000 (2026-03-01 20:30)
+}
+
+// signupRiskStrategy 注册风控策略：拒绝黑名单 IP
+type signupRiskStrategy struct {
+	blockedIPs map[string]bool
+}
+
+func (s *signupRiskStrategy) Type() core.StrategyType { return core.StrategyType("signup_risk") }
+func (s *signupRiskStrategy) Name() string            { return "signup_risk" }
+
+func (s *signupRiskStrategy) Validate(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	md := v6.RequestMetadataOf(ctx)
+	if s.blockedIPs[md.IP] {
+		collector.Collect(v6.NewFieldError("Member", "", "blocked_ip",
+			v6.WithMessage(fmt.Sprintf("signup from %s rejected (request %s)", md.IP, md.RequestID))))
+	}
+	return nil
+}
+
+// Example_requestMetadata 策略读取请求元数据
+func Example_requestMetadata() {
+	validator := v6.NewBuilder().
+		WithStrategy(&signupRiskStrategy{blockedIPs: map[string]bool{"203.0.113.7": true}}, 10).
+		Build()
+
+	// 接入层（HTTP 中间件、gRPC 拦截器）写入元数据，通过 WithContext 传给验证器
+	ctx := v6.WithRequestMetadata(stdcontext.Background(), v6.RequestMetadata{
+		ActorID:   "u-42",
+		IP:        "203.0.113.7",
+		RequestID: "req-9",
+	})
+	err := validator.Validate(&Member{Username: "alice"}, SceneCreate, v6.WithContext(ctx))
+	fmt.Println(err.First())
+
+	ctx = v6.WithRequestMetadata(ctx, v6.RequestMetadata{IP: "198.51.100.1"})
+	fmt.Println(validator.Validate(&Member{Username: "alice"}, SceneCreate, v6.WithContext(ctx)) == nil)
+
+	// Output:
+	// signup from 203.0.113.7 rejected (request req-9)
+	// true
+}
+
+// Example_auditSink 记录被拒绝的验证结果（合规审计）
+func Example_auditSink() {
+	// 内置 audit.NewLogSink、audit.NewWebhookSink；NewSink 写入自定义目的地（如审计表）
+	var entries []audit.Entry
+	sink := audit.NewSink(func(_ stdcontext.Context, batch []audit.Entry) error {
+		entries = append(entries, batch...)
+		return nil
+	}, audit.WithRejectedOnly())
+
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithAuditSink(sink).
+		Build()
+
+	ctx := v6.WithRequestMetadata(stdcontext.Background(), v6.RequestMetadata{ActorID: "admin-7"})
+	validator.Validate(&Member{Username: "al", Plan: "free"}, SceneCreate, v6.WithContext(ctx))
+	validator.Validate(&Member{Username: "alice", Plan: "pro"}, SceneCreate, v6.WithContext(ctx))
+
+	// 服务退出前刷新缓冲区
+	_ = sink.Close(stdcontext.Background())
+
+	for _, entry := range entries {
+		fmt.Println(entry.Model, entry.Scene, entry.ActorID, entry.Errors[0].Namespace, entry.Errors[0].Tag)
+	}
+
+	// Output:
+	// Member 1 admin-7 Member.username min
+}
@@ -1,9 +1,17 @@
 package v6
 
 import (
+	stdcontext "context"
+	"katydid-common-account/pkg/validator/errs"
+	"katydid-common-account/pkg/validator/v6/context"
 	"katydid-common-account/pkg/validator/v6/core"
 	"katydid-common-account/pkg/validator/v6/errors"
+	"katydid-common-account/pkg/validator/v6/infrastructure"
 	"katydid-common-account/pkg/validator/v6/orchestration"
+	"katydid-common-account/pkg/validator/v6/strategy"
+	"net/http"
+	"regexp"
+	"time"
 )
 
 // ============================================================================
@@ -25,11 +33,86 @@ func WithValue(value any) errors.FieldErrorOption {
 	return errors.WithValue(value)
 }
 
+// WithParams 设置结构化参数（消息模板中的 {key} 占位符，JSON 输出的 params）
+// 示例：NewFieldError("User.age", "age", "between", WithParams(map[string]any{"min": 18, "max": 60}))
+func WithParams(params map[string]any) errors.FieldErrorOption {
+	return errors.WithParams(params)
+}
+
+// ParamsOf 获取字段错误的结构化参数（没有时返回 nil）
+func ParamsOf(err core.IFieldError) map[string]any {
+	return errors.ParamsOf(err)
+}
+
+// Report 报告字段错误：阻断错误收集为错误，非阻断错误收集为警告
+// 返回值：达到最大错误数时返回 false
+func Report(collector core.IErrorCollector, errs ...core.IFieldError) bool {
+	return errors.Report(collector, errs...)
+}
+
+// WithSeverity 设置错误级别
+func WithSeverity(severity Severity) errors.FieldErrorOption {
+	return errors.WithSeverity(severity)
+}
+
 // WithMessage 设置自定义消息
 func WithMessage(message string) errors.FieldErrorOption {
 	return errors.WithMessage(message)
 }
 
+// RegisterMessage 注册消息模板
+// 示例：RegisterMessage("User.Username", "min", "用户名长度不能少于{param}个字符")
+func RegisterMessage(field, tag, template string) {
+	errors.RegisterMessage(field, tag, template)
+}
+
+// RegisterRuleAlias 注册规则别名，支持别名嵌套，循环引用时返回错误
+// 示例：RegisterRuleAlias("username_std", "required,min=3,max=20,alphanum")
+func RegisterRuleAlias(name, rules string) error {
+	return strategy.RegisterRuleAlias(name, rules)
+}
+
+// UnregisterRuleAlias 注销规则别名，仍被其他别名引用时返回错误
+func UnregisterRuleAlias(name string) error {
+	return strategy.UnregisterRuleAlias(name)
+}
+
+// WithSensitive 标记字段值为敏感值（输出时脱敏）
+func WithSensitive() errors.FieldErrorOption {
+	return errors.WithSensitive()
+}
+
+// SetSensitivePatterns 替换敏感字段名规则（正则表达式）
+func SetSensitivePatterns(patterns ...string) error {
+	return errors.SetSensitivePatterns(patterns...)
+}
+
+// AddSensitivePattern 追加敏感字段名规则（正则表达式）
+func AddSensitivePattern(pattern string) error {
+	return errors.AddSensitivePattern(pattern)
+}
+
+// RedactValue 获取脱敏后的字段值
+func RedactValue(err core.IFieldError) any {
+	return errors.RedactValue(err)
+}
+
+// FromValidationErrors 将 go-playground 的验证错误转换为字段错误列表
+// 说明：root 为被验证的对象，命名空间统一转换为 JSON 名称
+func FromValidationErrors(err error, root any) ([]core.IFieldError, bool) {
+	return errors.FromValidationErrors(err, root)
+}
+
+// ToUnified 转换为各版本共享的统一错误结构（见 errs 包）
+func ToUnified(err core.IValidationError) *errs.ValidationError {
+	return errors.ToUnified(err)
+}
+
+// MergeResults 汇总多个验证结果，忽略 nil；全部为 nil 时返回 nil
+func MergeResults(results ...core.IValidationError) core.IValidationError {
+	return errors.MergeResults(results...)
+}
+
 // NewListErrorCollector 创建列表错误收集器
 func NewListErrorCollector(maxErrors int) core.IErrorCollector {
 	return errors.NewListErrorCollector(maxErrors)
@@ -60,6 +143,85 @@ func NewSpliceFormatter() core.IErrorFormatter {
 	return errors.NewSpliceFormatter()
 }
 
+// RegexPolicy 正则表达式安全限制别名
+type RegexPolicy = infrastructure.RegexPolicy
+
+// ErrRegexTooComplex 正则表达式超出安全限制
+var ErrRegexTooComplex = infrastructure.ErrRegexTooComplex
+
+var (
+	// DefaultRegexPolicy 开发者编写的模式（regex 规则默认使用）
+	DefaultRegexPolicy = infrastructure.DefaultRegexPolicy
+
+	// UntrustedRegexPolicy 用户或租户提供的模式（长度、重复次数和嵌套受限，只接受 POSIX ERE 语法）
+	UntrustedRegexPolicy = infrastructure.UntrustedRegexPolicy
+)
+
+// CompileRegex 按 DefaultRegexPolicy 编译正则表达式（带缓存），自定义规则中使用以避免每次编译
+func CompileRegex(pattern string) (*regexp.Regexp, error) {
+	return infrastructure.CompileRegex(pattern)
+}
+
+// CompileRegexWithPolicy 按指定限制编译正则表达式（带缓存），保存用户提供的模式前可用于校验
+func CompileRegexWithPolicy(pattern string, policy RegexPolicy) (*regexp.Regexp, error) {
+	return infrastructure.CompileRegexWithPolicy(pattern, policy)
+}
+
+// SetRegexCacheSize 设置正则表达式缓存容量（默认 1024，LRU 淘汰）
+func SetRegexCacheSize(size int) {
+	infrastructure.SetRegexCacheSize(size)
+}
+
+// RegexCacheStats 正则表达式缓存统计
+func RegexCacheStats() core.CacheStats {
+	return infrastructure.RegexCacheStats()
+}
+
+// Locale 区域格式（数字分隔符和日期布局）
+type Locale = errors.Locale
+
+// LocaleOption 本地化格式化器选项
+type LocaleOption = errors.LocaleOption
+
+// Clock 时钟（当前时间和用户时区）
+type Clock = core.IClock
+
+// NewLocaleFormatter 创建本地化格式化器（消息模板中的数字和日期按区域、用户时区显示）
+// 示例：NewLocaleFormatter(r.Header.Get("Accept-Language"), WithTimeZone(userLoc))
+func NewLocaleFormatter(tag string, opts ...LocaleOption) core.IErrorFormatter {
+	return errors.NewLocaleFormatter(tag, opts...)
+}
+
+// RegisterLocale 注册或覆盖区域格式
+func RegisterLocale(locale Locale) error {
+	return errors.RegisterLocale(locale)
+}
+
+// LookupLocale 查找区域格式（精确匹配 > 语言 > en）
+func LookupLocale(tag string) Locale {
+	return errors.LookupLocale(tag)
+}
+
+// WithClock 设置本地化格式化器的时钟
+func WithClock(clock Clock) LocaleOption {
+	return errors.WithClock(clock)
+}
+
+// WithTimeZone 设置本地化格式化器的用户时区
+func WithTimeZone(loc *time.Location) LocaleOption {
+	return errors.WithTimeZone(loc)
+}
+
+// NewSystemClock 创建系统时钟（loc 为用户时区）
+func NewSystemClock(loc *time.Location) Clock {
+	return errors.NewSystemClock(loc)
+}
+
+// NewFixedClock 创建固定时间的时钟（测试用）
+func NewFixedClock(now time.Time) Clock {
+	return errors.NewFixedClock(now)
+}
+
 // ============================================================================
 // 导出拦截器相关类型
 // ============================================================================
@@ -67,6 +229,91 @@ func NewSpliceFormatter() core.IErrorFormatter {
 // InterceptorFunc 拦截器函数类型
 type InterceptorFunc = orchestration.InterceptorFunc
 
+// ============================================================================
+// 导出验证管道相关
+// ============================================================================
+
+// Pipeline 验证管道别名
+type Pipeline = orchestration.Pipeline
+
+// StageTiming 阶段执行耗时别名
+type StageTiming = core.StageTiming
+
+// NewPipeline 创建验证管道
+func NewPipeline() *Pipeline {
+	return orchestration.NewPipeline()
+}
+
+// TagStrategyPanic 策略 panic 时报告的错误标签
+const TagStrategyPanic = orchestration.TagStrategyPanic
+
+// Budget 请求级时间预算别名
+type Budget = core.Budget
+
+// StageBudget 阶段时间预算别名
+type StageBudget = core.StageBudget
+
+// SceneStrategies 按场景筛选策略别名
+type SceneStrategies = core.SceneStrategies
+
+// TagValidationDegraded 阶段因时间预算不足被跳过时收集的警告标签
+const TagValidationDegraded = orchestration.TagValidationDegraded
+
+// TagCoerced 弱类型模式下字符串被转换时的提示标签（Info 级别）
+const TagCoerced = strategy.TagCoerced
+
+// TagMoreErrors 超出 WithErrorDetailLimit 的错误汇总标签（参数为汇总的错误数）
+const TagMoreErrors = errors.TagMoreErrors
+
+// ============================================================================
+// 导出外部规则相关
+// ============================================================================
+
+// RuleSet 外部规则集别名
+type RuleSet = core.RuleSet
+
+// RuleSource 外部规则源接口别名
+type RuleSource = core.IRuleSource
+
+// RuleManager 规则管理器别名
+type RuleManager = infrastructure.RuleManager
+
+// NewRuleManager 创建规则管理器
+func NewRuleManager(source core.IRuleSource) *RuleManager {
+	return infrastructure.NewRuleManager(source, nil)
+}
+
+// NewFileRuleSource 创建文件规则源
+func NewFileRuleSource(path string) core.IRuleSource {
+	return infrastructure.NewFileRuleSource(path)
+}
+
+// NewHTTPRuleSource 创建 HTTP 规则源
+func NewHTTPRuleSource(url string, client *http.Client) core.IRuleSource {
+	return infrastructure.NewHTTPRuleSource(url, client)
+}
+
+// NewKVRuleSource 创建键值（etcd 等）规则源
+func NewKVRuleSource(getter infrastructure.IKeyValueGetter, key string) core.IRuleSource {
+	return infrastructure.NewKVRuleSource(getter, key)
+}
+
+// ============================================================================
+// 导出仓储检查相关
+// ============================================================================
+
+// UniquenessChecker 唯一性检查接口别名
+type UniquenessChecker = core.IUniquenessChecker
+
+// ExistenceChecker 存在性检查接口别名
+type ExistenceChecker = core.IExistenceChecker
+
+// RecordIdentifier 记录标识接口别名（唯一性检查排除自身）
+type RecordIdentifier = core.IRecordIdentifier
+
+// Optional 可选值接口别名（区分未提供、null 和零值）
+type Optional = core.IOptional
+
 // ============================================================================
 // 导出常量
 // ============================================================================
@@ -75,14 +322,110 @@ type InterceptorFunc = orchestration.InterceptorFunc
 const (
 	SceneNone = core.SceneNone
 	SceneAll  = core.SceneAll
+	SceneAuto = core.SceneAuto
+)
+
+// RegisterScene 注册场景名称（单个位），String、ParseScene 和 JSON 使用该名称
+// 示例：RegisterScene(SceneCreate, "create")
+func RegisterScene(scene Scene, name string) error {
+	return core.RegisterScene(scene, name)
+}
+
+// MustRegisterScene 注册场景名称，失败时 panic
+func MustRegisterScene(scene Scene, name string) {
+	core.MustRegisterScene(scene, name)
+}
+
+// ParseScene 解析场景字符串，如 "create|update"、"3"
+func ParseScene(s string) (Scene, error) {
+	return core.ParseScene(s)
+}
+
+// Tag 验证标签类型别名（字段错误的 Tag() 可转换后与常量比较：v6.Tag(fe.Tag()) == v6.TagRequired）
+type Tag = core.Tag
+
+// 重新导出常用标签
+const (
+	TagRequired  = core.TagRequired
+	TagMin       = core.TagMin
+	TagMax       = core.TagMax
+	TagLen       = core.TagLen
+	TagEq        = core.TagEq
+	TagNe        = core.TagNe
+	TagGt        = core.TagGt
+	TagGte       = core.TagGte
+	TagLt        = core.TagLt
+	TagLte       = core.TagLte
+	TagOneOf     = core.TagOneOf
+	TagEmail     = core.TagEmail
+	TagURL       = core.TagURL
+	TagUUID      = core.TagUUID
+	TagAlpha     = core.TagAlpha
+	TagAlphaNum  = core.TagAlphaNum
+	TagNumeric   = core.TagNumeric
+	TagE164      = core.TagE164
+	TagEqField   = core.TagEqField
+	TagUnique    = core.TagUnique
+	TagExists    = core.TagExists
+	TagEnum      = core.TagEnum
+	TagRegex     = core.TagRegex
+	TagDatetime  = core.TagDatetime
+	TagLowercase = core.TagLowercase
+	TagUppercase = core.TagUppercase
 )
 
+// RegisterTag 注册自定义标签（业务策略报告的错误标签等；RegisterValidation、RegisterRuleAlias 自动注册）
+func RegisterTag(tags ...Tag) error {
+	return core.RegisterTag(tags...)
+}
+
+// MustRegisterTag 注册自定义标签，失败时 panic
+func MustRegisterTag(tags ...Tag) {
+	core.MustRegisterTag(tags...)
+}
+
+// IsRegisteredTag 标签是否已注册
+func IsRegisteredTag(tag string) bool {
+	return core.IsRegisteredTag(tag)
+}
+
+// RegisteredTags 所有已注册的标签（按名称排序，可生成客户端的标签枚举）
+func RegisteredTags() []Tag {
+	return core.RegisteredTags()
+}
+
+// ParseTag 解析已注册的标签，未注册时返回错误
+func ParseTag(s string) (Tag, error) {
+	return core.ParseTag(s)
+}
+
+// SetStrictTags 设置严格标签模式：规则包含未注册的标签时，RegisterRuleAlias 和 binding 的模型注册返回错误
+func SetStrictTags(strict bool) {
+	core.SetStrictTags(strict)
+}
+
+// CheckRuleTags 检查规则表中的标签是否都已注册（可在测试中检查模型各场景的规则）
+func CheckRuleTags(rules map[string]string) error {
+	return core.CheckRuleTags(rules)
+}
+
 // 重新导出策略类型
 const (
-	StrategyTypeRule     = core.StrategyTypeRule
-	StrategyTypeBusiness = core.StrategyTypeBusiness
-	StrategyTypeNested   = core.StrategyTypeNested
-	StrategyTypeCustom   = core.StrategyTypeCustom
+	StrategyTypeRule           = core.StrategyTypeRule
+	StrategyTypeBusiness       = core.StrategyTypeBusiness
+	StrategyTypeNested         = core.StrategyTypeNested
+	StrategyTypeCustom         = core.StrategyTypeCustom
+	StrategyTypeRepository     = core.StrategyTypeRepository
+	StrategyTypeEmail          = core.StrategyTypeEmail
+	StrategyTypeUsernamePolicy = core.StrategyTypeUsernamePolicy
+	StrategyTypeQueryPolicy    = core.StrategyTypeQueryPolicy
+)
+
+// 重新导出错误级别
+const (
+	SeverityError   = core.SeverityError
+	SeverityWarning = core.SeverityWarning
+	SeverityInfo    = core.SeverityInfo
 )
 
 // 重新导出执行模式
@@ -91,6 +434,18 @@ const (
 	ExecutionModeParallel   = core.ExecutionModeParallel
 )
 
+// 重新导出循环引用处理方式
+const (
+	CyclePolicySkip   = core.CyclePolicySkip
+	CyclePolicyReport = core.CyclePolicyReport
+)
+
+// 重新导出内置配置档名称
+const (
+	ProfileStrict = core.ProfileStrict
+	ProfileImport = core.ProfileImport
+)
+
 // ============================================================================
 // 类型别名
 // ============================================================================
@@ -101,15 +456,24 @@ type Scene = core.Scene
 // StrategyType 策略类型别名
 type StrategyType = core.StrategyType
 
+// Severity 错误级别别名
+type Severity = core.Severity
+
 // ExecutionMode 执行模式别名
 type ExecutionMode = core.ExecutionMode
 
+// CyclePolicy 循环引用处理方式别名
+type CyclePolicy = core.CyclePolicy
+
 // Validator 验证器接口别名
 type Validator = core.IValidator
 
 // ValidationError 验证错误接口别名
 type ValidationError = core.IValidationError
 
+// Result 验证结果别名（与 ValidationError 相同）
+type Result = core.IValidationError
+
 // FieldError 字段错误接口别名
 type FieldError = core.IFieldError
 
@@ -124,3 +488,268 @@ type ValidationStrategy = core.IValidationStrategy
 
 // Interceptor 拦截器接口别名
 type Interceptor = core.IInterceptor
+
+// FieldValidator 字段级验证器接口别名
+// 说明：Build 返回的验证器实现了该接口，如 validator.(v6.FieldValidator).ValidateChanges(old, new, SceneUpdate)
+type FieldValidator = core.IFieldValidator
+
+// RuleCacheInspector 规则缓存调试接口别名
+// 说明：Build 返回的验证器实现了该接口，如 validator.(v6.RuleCacheInspector).CachedRules(&User{})
+type RuleCacheInspector = core.IRuleCacheInspector
+
+// CompiledRules 场景下预解析的规则别名
+type CompiledRules = core.CompiledRules
+
+// DynamicRules 规则随实例变化的模型接口别名（实现后不缓存规则）
+type DynamicRules = core.IDynamicRules
+
+// SelfValidator 自验证接口别名（嵌套策略验证接口类型字段中的值对象）
+type SelfValidator = core.ISelfValidator
+
+// MapValidator Map 验证器接口别名
+type MapValidator = core.IMapValidator
+
+// FormValidator 表单验证器接口别名
+type FormValidator = core.IFormValidator
+
+// SliceValidator 批量验证器接口别名
+// 说明：Build 返回的验证器实现了该接口，如 validator.(v6.SliceValidator).ValidateSlice(rows, scene)
+type SliceValidator = core.ISliceValidator
+
+// BatchStrategy 批量验证策略接口别名
+type BatchStrategy = core.IBatchStrategy
+
+// BatchKey 批内唯一键别名
+type BatchKey = strategy.BatchKey
+
+// TagBatchDuplicate 与同一批次中前面的记录重复时的错误标签（参数为首次出现的记录下标）
+const TagBatchDuplicate = strategy.TagBatchDuplicate
+
+// TagCircularReference 嵌套验证遇到循环引用时的错误标签（CyclePolicyReport）
+const TagCircularReference = strategy.TagCircularReference
+
+// TagNestedPanic 并发验证集合元素时 panic 的错误标签
+const TagNestedPanic = strategy.TagNestedPanic
+
+// UniqueField 按字段值检查批内唯一（零值不参与检查）
+func UniqueField(field string) BatchKey {
+	return strategy.UniqueField(field)
+}
+
+// UniqueFieldFold 按字段值检查批内唯一，忽略大小写和首尾空白
+func UniqueFieldFold(field string) BatchKey {
+	return strategy.UniqueFieldFold(field)
+}
+
+// UniqueBy 使用自定义函数提取批内唯一键（如组合键）
+func UniqueBy(field string, extract func(item any) (string, bool)) BatchKey {
+	return strategy.UniqueBy(field, extract)
+}
+
+// SceneResolver 场景解析器接口别名
+type SceneResolver = core.ISceneResolver
+
+// SceneResolverFunc 场景解析函数类型
+type SceneResolverFunc = core.SceneResolverFunc
+
+// NewIDSceneResolver 创建 ID 场景解析器（field 为空时使用 "ID"）
+func NewIDSceneResolver(create, update Scene, field string) SceneResolver {
+	return infrastructure.NewIDSceneResolver(create, update, field)
+}
+
+// Profile 验证配置档别名
+type Profile = core.Profile
+
+// ValidateOption 单次验证选项别名
+type ValidateOption = core.ValidateOption
+
+// WithProfile 使用指定的配置档执行本次验证，如 Validate(obj, scene, v6.WithProfile(v6.ProfileImport))
+func WithProfile(name string) ValidateOption {
+	return core.WithProfile(name)
+}
+
+// WithContext 设置本次验证的 Go 上下文（租户、请求 ID 等），策略通过 ctx.GoContext() 获取
+func WithContext(ctx stdcontext.Context) ValidateOption {
+	return core.WithContext(ctx)
+}
+
+// WithSkips 跳过指定规则（"命名空间:标签"），必须配合 WithSkipJustification，并向监听器发出审计事件
+func WithSkips(skips ...string) ValidateOption {
+	return core.WithSkips(skips...)
+}
+
+// WithSkipJustification 设置跳过规则的理由
+func WithSkipJustification(justification string) ValidateOption {
+	return core.WithSkipJustification(justification)
+}
+
+// WithFields 只验证指定字段（规则选择阶段即排除其他字段），如 v6.WithFields("email", "profile.nickname")
+func WithFields(fields ...string) ValidateOption {
+	return core.WithFields(fields...)
+}
+
+// WithExcludeFields 不验证指定字段（排除 profile 时 profile.xxx 同样排除）
+func WithExcludeFields(fields ...string) ValidateOption {
+	return core.WithExcludeFields(fields...)
+}
+
+// WithTenantID 在 Go 上下文中设置租户 ID，配合 WithContext 使租户规则覆盖生效
+func WithTenantID(ctx stdcontext.Context, tenantID string) stdcontext.Context {
+	return context.WithTenantID(ctx, tenantID)
+}
+
+// WithRole 在 Go 上下文中设置调用方角色，配合 WithContext 使查询字段策略生效
+func WithRole(ctx stdcontext.Context, role string) stdcontext.Context {
+	return context.WithRole(ctx, role)
+}
+
+// RequestMetadata 请求元数据（操作人、租户、IP、User-Agent、请求 ID）
+type RequestMetadata = core.RequestMetadata
+
+// WithRequestMetadata 在 Go 上下文中设置请求元数据，配合 WithContext 传给策略
+// 说明：已有元数据时合并，新值的非空字段覆盖旧值；TenantID 同时作为租户规则覆盖的租户
+func WithRequestMetadata(ctx stdcontext.Context, md RequestMetadata) stdcontext.Context {
+	return context.WithRequestMetadata(ctx, md)
+}
+
+// RequestMetadataOf 获取验证上下文中的请求元数据（策略、拦截器中使用）
+func RequestMetadataOf(ctx Context) RequestMetadata {
+	return context.RequestMetadata(ctx)
+}
+
+// FieldSet 有效字段集合类型别名
+type FieldSet = core.FieldSet
+
+// FieldAwareBusinessValidator 感知有效字段的业务验证器接口别名
+// 说明：模型实现 ValidateBusinessFields(scene, fields, collector) 后，只验证部分字段时可跳过无关的业务检查
+type FieldAwareBusinessValidator = core.IFieldAwareBusinessValidator
+
+// FieldSetOf 获取本次验证的有效字段（自定义策略中使用，fields.Includes("email")）
+func FieldSetOf(ctx Context) FieldSet {
+	return context.FieldSet(ctx)
+}
+
+// FieldPolicy 查询字段策略接口别名
+type FieldPolicy = core.IFieldPolicy
+
+// TagFieldNotQueryable 角色不允许按该字段过滤时的错误标签
+const TagFieldNotQueryable = strategy.TagFieldNotQueryable
+
+// StripQueryFields 清除角色不允许过滤的字段，返回被清除的字段
+func StripQueryFields(target any, role string) []string {
+	return strategy.StripQueryFields(target, role)
+}
+
+// TenantRuleProvider 租户规则覆盖提供者接口别名
+type TenantRuleProvider = core.ITenantRuleProvider
+
+// TenantRuleProviderFunc 租户规则覆盖函数类型
+type TenantRuleProviderFunc = core.TenantRuleProviderFunc
+
+// LegacyAdapter 旧版模型适配器接口别名
+type LegacyAdapter = core.ILegacyAdapter
+
+// ParamResolver 规则参数解析器接口别名
+type ParamResolver = core.IParamResolver
+
+// ParamResolverFunc 规则参数解析函数类型
+type ParamResolverFunc = core.ParamResolverFunc
+
+// NewMapParamResolver 创建基于配置值的参数解析器
+func NewMapParamResolver(values map[string]string) *infrastructure.MapParamResolver {
+	return infrastructure.NewMapParamResolver(values)
+}
+
+// NewChainParamResolver 创建组合参数解析器（按顺序解析，第一个命中的生效）
+func NewChainParamResolver(resolvers ...ParamResolver) ParamResolver {
+	return infrastructure.NewChainParamResolver(resolvers...)
+}
+
+// Plugin 插件接口别名
+type Plugin = core.IPlugin
+
+// PluginHost 插件安装宿主接口别名
+type PluginHost = core.IPluginHost
+
+// PluginManager 插件管理接口别名
+// 说明：Build 返回的验证器实现了该接口，如 validator.(v6.PluginManager).DisablePlugin("audit")
+type PluginManager = core.IPluginManager
+
+// ValidatorIntrospector 验证器运行时信息接口别名
+// 说明：Build 返回的验证器实现了该接口，管理端可查看缓存统计与监听器
+type ValidatorIntrospector = core.IValidatorIntrospector
+
+// Listener 验证监听器接口别名
+type Listener = core.IValidationListener
+
+// EventListener 事件监听器接口别名
+type EventListener = core.IEventListener
+
+// AuditSink 验证结果审计接口别名（内置实现见 audit 包）
+type AuditSink = core.IAuditSink
+
+// Event 验证事件别名
+type Event = core.Event
+
+// EventType 事件类型别名
+type EventType = core.EventType
+
+// 事件类型
+const (
+	EventTypeValidationStart = core.EventTypeValidationStart
+	EventTypeValidationEnd   = core.EventTypeValidationEnd
+	EventTypeStrategyStart   = core.EventTypeStrategyStart
+	EventTypeStrategyEnd     = core.EventTypeStrategyEnd
+	EventTypeErrorAdded      = core.EventTypeErrorAdded
+	EventTypeCacheHit        = core.EventTypeCacheHit
+	EventTypeCacheMiss       = core.EventTypeCacheMiss
+	EventTypeRulesSkipped    = core.EventTypeRulesSkipped
+)
+
+// ============================================================================
+// 导出策略依赖相关
+// ============================================================================
+
+// StrategyDependencies 策略依赖声明接口别名
+type StrategyDependencies = core.IStrategyDependencies
+
+// RunsAfter 为策略声明前置策略，如 v6.RunsAfter(historyStrategy, "password_strength")
+func RunsAfter(s core.IValidationStrategy, names ...string) core.IValidationStrategy {
+	return strategy.RunsAfter(s, names...)
+}
+
+// ============================================================================
+// 导出策略包装器相关
+// ============================================================================
+
+// StrategyWrapper 策略包装器别名
+type StrategyWrapper = strategy.StrategyWrapper
+
+// DegradeMode 降级模式别名
+type DegradeMode = strategy.DegradeMode
+
+// 重新导出降级模式
+const (
+	DegradeFail = strategy.DegradeFail
+	DegradeSkip = strategy.DegradeSkip
+)
+
+// NewStrategyWrapper 创建策略包装器
+func NewStrategyWrapper(inner core.IValidationStrategy, opts ...strategy.WrapperOption) *StrategyWrapper {
+	return strategy.NewStrategyWrapper(inner, opts...)
+}
+
+// WithStrategyTimeout 设置策略执行超时
+func WithStrategyTimeout(timeout time.Duration) strategy.WrapperOption {
+	return strategy.WithTimeout(timeout)
+}
+
+// WithCircuitBreaker 设置策略熔断器
+func WithCircuitBreaker(threshold int, cooldown time.Duration) strategy.WrapperOption {
+	return strategy.WithCircuitBreaker(threshold, cooldown)
+}
+
+// WithDegradeMode 设置策略降级模式
+func WithDegradeMode(mode DegradeMode) strategy.WrapperOption {
+	return strategy.WithDegradeMode(mode)
+}
@@ -0,0 +1,87 @@
+package v6_test
+
+import (
+	"testing"
+
+	"katydid-common-account/pkg/types"
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// productProfile 嵌套结构体
+type productProfile struct {
+	Nickname string `json:"nickname"`
+}
+
+// product 规则键指向 map 键的模型
+type product struct {
+	Name    string          `json:"name"`
+	Extras  types.Extras    `json:"extras"`
+	Profile *productProfile `json:"profile"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (p *product) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{
+		"Extras.brand":      "required,min=2",
+		"Extras.color":      "oneof=red blue",
+		"Extras.prefs.lang": "required",
+		"Profile.Nickname":  "max=5",
+	}
+}
+
+// TestExtras_DottedKeys 测试规则键按路径进入 map 和嵌套结构体
+func TestExtras_DottedKeys(t *testing.T) {
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithPartialScenes(SceneUpdate).
+		Build()
+
+	prefs := map[string]any{"lang": "zh"}
+	tests := []struct {
+		name  string
+		model *product
+		scene core.Scene
+		want  []string
+	}{
+		{"全部通过", &product{Extras: types.Extras{"brand": "acme", "prefs": prefs}}, SceneCreate, nil},
+		{"键不存在视为未提供", &product{Extras: types.Extras{"prefs": prefs}}, SceneCreate,
+			[]string{"product.Extras.brand brand required"}},
+		{"值为 null 视为显式 null", &product{Extras: types.Extras{"brand": nil, "prefs": prefs}}, SceneCreate,
+			[]string{"product.Extras.brand brand required"}},
+		{"提供了值按剩余规则验证", &product{Extras: types.Extras{"brand": "a", "color": "green", "prefs": prefs}}, SceneCreate,
+			[]string{"product.Extras.brand brand min", "product.Extras.color color oneof"}},
+		{"嵌套 map 逐层查找", &product{Extras: types.Extras{"brand": "acme", "prefs": map[string]any{}}}, SceneCreate,
+			[]string{"product.Extras.prefs.lang lang required"}},
+		{"map 为 nil", &product{}, SceneCreate,
+			[]string{"product.Extras.brand brand required", "product.Extras.prefs.lang lang required"}},
+		{"部分更新场景跳过未提供的键", &product{Extras: types.Extras{"color": "red"}}, SceneUpdate, nil},
+		{"部分更新场景显式 null 仍检查 required", &product{Extras: types.Extras{"brand": nil}}, SceneUpdate,
+			[]string{"product.Extras.brand brand required"}},
+		{"结构体路径按普通字段验证", &product{Extras: types.Extras{"brand": "acme", "prefs": prefs}, Profile: &productProfile{Nickname: "toolong"}}, SceneCreate,
+			[]string{"product.Profile.Nickname Nickname max"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diveErrors(validator.Validate(tt.model, tt.scene))
+			if !equalStrings(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExtras_Changes 测试 Diff 生成的 extras.brand 匹配规则键 Extras.brand
+func TestExtras_Changes(t *testing.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+	fv := validator.(v6.FieldValidator)
+
+	// 只有 brand 变化：未变化的 prefs.lang 缺失也不报错
+	old := &product{Extras: types.Extras{"brand": "acme"}}
+	changed := &product{Extras: types.Extras{"brand": "a"}}
+	want := []string{"product.Extras.brand brand min"}
+	if got := diveErrors(fv.ValidateChanges(old, changed, SceneCreate)); !equalStrings(got, want) {
+		t.Errorf("ValidateChanges() = %v, want %v", got, want)
+	}
+}
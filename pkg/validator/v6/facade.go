@@ -1,37 +1,139 @@
 package v6
 
 import (
+	"context"
+	"fmt"
 	"katydid-common-account/pkg/validator/v6/core"
 	"katydid-common-account/pkg/validator/v6/engine"
 	"katydid-common-account/pkg/validator/v6/errors"
 	"katydid-common-account/pkg/validator/v6/infrastructure"
 	"katydid-common-account/pkg/validator/v6/orchestration"
 	"katydid-common-account/pkg/validator/v6/strategy"
-	"sync"
+	"mime/multipart"
+	"net/url"
+	"sync/atomic"
+	"time"
 )
 
 // ============================================================================
 // 全局门面
 // ============================================================================
 
-var (
-	defaultValidator core.IValidator
-	once             sync.Once
-)
+// validatorHolder 默认验证器的持有者（atomic.Pointer 不能直接存放接口）
+type validatorHolder struct {
+	validator core.IValidator
+}
 
-// Facade 获取默认验证器实例（单例）
+// defaultValidator 全局默认验证器，首次使用时延迟创建，可通过 SetDefault 原子替换
+var defaultValidator atomic.Pointer[validatorHolder]
+
+// Default 获取默认验证器实例
+// 说明：未通过 SetDefault 设置时延迟创建 NewDefaultValidator()；线程安全
+func Default() core.IValidator {
+	if holder := defaultValidator.Load(); holder != nil {
+		return holder.validator
+	}
+	// 并发首次调用时只有一个实例会被保存
+	defaultValidator.CompareAndSwap(nil, &validatorHolder{validator: NewDefaultValidator()})
+	return defaultValidator.Load().validator
+}
+
+// SetDefault 原子替换默认验证器，返回之前的验证器（未创建时为 nil）
+// 说明：传入 nil 会重置为延迟创建的默认验证器；适用于配置重载或测试中替换为 mock
+//
+// 使用示例：
+//
+//	prev := v6.SetDefault(mock)
+//	defer v6.SetDefault(prev)
+func SetDefault(validator core.IValidator) core.IValidator {
+	var holder *validatorHolder
+	if validator != nil {
+		holder = &validatorHolder{validator: validator}
+	}
+	if prev := defaultValidator.Swap(holder); prev != nil {
+		return prev.validator
+	}
+	return nil
+}
+
+// Facade 获取默认验证器实例，等价于 Default()
 // 设计模式：单例模式 + 门面模式
 func Facade() core.IValidator {
-	once.Do(func() {
-		defaultValidator = NewDefaultValidator()
-	})
-	return defaultValidator
+	return Default()
 }
 
 // Validate 使用默认验证器执行验证
 // 便捷方法：简化调用
-func Validate(target any, scene core.Scene) core.IValidationError {
-	return Facade().Validate(target, scene)
+func Validate(target any, scene core.Scene, opts ...core.ValidateOption) core.IValidationError {
+	return Default().Validate(target, scene, opts...)
+}
+
+// ============================================================================
+// 上下文注入
+// ============================================================================
+
+// validatorKey 上下文中验证器的键
+type validatorKey struct{}
+
+// WithValidator 将验证器注入到 context 中（请求级或模块级覆盖）
+// 说明：validator 为 nil 时返回原 context
+func WithValidator(ctx context.Context, validator core.IValidator) context.Context {
+	if validator == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, validatorKey{}, validator)
+}
+
+// FromContext 获取 context 中注入的验证器，未注入时返回默认验证器
+func FromContext(ctx context.Context) core.IValidator {
+	if ctx != nil {
+		if validator, ok := ctx.Value(validatorKey{}).(core.IValidator); ok {
+			return validator
+		}
+	}
+	return Default()
+}
+
+// ValidateContext 使用 context 中注入的验证器（未注入时使用默认验证器）执行验证
+// 说明：ctx 同时作为本次验证的 Go 上下文（等价于追加 WithContext(ctx)）
+func ValidateContext(ctx context.Context, target any, scene core.Scene, opts ...core.ValidateOption) core.IValidationError {
+	return FromContext(ctx).Validate(target, scene, append([]core.ValidateOption{core.WithContext(ctx)}, opts...)...)
+}
+
+// ValidateChanges 使用默认验证器只验证发生变化的字段
+// 便捷方法：Update 场景中用旧模型和新模型计算变化字段（types.Diff），只验证这些字段
+func ValidateChanges(old, new any, scene core.Scene) core.IValidationError {
+	fv, ok := Default().(core.IFieldValidator)
+	if !ok {
+		return errors.NewValidationError([]core.IFieldError{
+			errors.NewFieldError("Struct", "", "diff", errors.WithMessage("default validator does not support field validation")),
+		}, nil)
+	}
+	return fv.ValidateChanges(old, new, scene)
+}
+
+// ValidateMapWithRules 使用默认验证器按规则验证 map（无结构体模型的请求体）
+// 便捷方法：规则为字段路径 -> go-playground 规则，如 {"age": "required,gte=18", "address.city": "required"}
+func ValidateMapWithRules(payload map[string]any, rules map[string]string) core.IValidationError {
+	mv, ok := Default().(core.IMapValidator)
+	if !ok {
+		return errors.NewValidationError([]core.IFieldError{
+			errors.NewFieldError("Map", "", "rule_engine", errors.WithMessage("default validator does not support map validation")),
+		}, nil)
+	}
+	return mv.ValidateMapWithRules(payload, rules)
+}
+
+// ValidateForm 使用默认验证器按规则验证表单（url.Values 和 multipart 文件部分）
+// 便捷方法：如 {"age": "required,gte=18", "avatar": "required,max_size=2MB,mimes=image/png image/jpeg"}
+func ValidateForm(values url.Values, files map[string][]*multipart.FileHeader, rules map[string]string) core.IValidationError {
+	fv, ok := Default().(core.IFormValidator)
+	if !ok {
+		return errors.NewValidationError([]core.IFieldError{
+			errors.NewFieldError("Form", "", "rule_engine", errors.WithMessage("default validator does not support form validation")),
+		}, nil)
+	}
+	return fv.ValidateForm(values, files, rules)
 }
 
 // ============================================================================
@@ -46,22 +148,65 @@ type Builder struct {
 	inspector        core.ITypeInspector
 	sceneMatcher     core.ISceneMatcher
 	dependencyEngine core.IDependencyEngine
+	engineOptions    []infrastructure.DependencyEngineOption
+	ruleProvider     core.IRuleProvider
+	ruleSource       core.IRuleSource
+	ruleInterval     time.Duration
+	ruleManager      *infrastructure.RuleManager
+	paramResolver    core.IParamResolver
+	tenantRules      core.ITenantRuleProvider
+	legacyAdapter    core.ILegacyAdapter
+	partialScenes    core.Scene
 
 	// 编排组件
 	orchestrator     core.IStrategyOrchestrator
 	interceptorChain core.IInterceptorChain
+	listeners        []core.IValidationListener
+	plugins          []core.IPlugin
+	sceneResolver    core.ISceneResolver
+	profiles         []core.Profile
+	pipelineConfigs  []func(p *orchestration.Pipeline) error
 
 	// 策略
 	strategies map[core.StrategyType]struct {
 		strategy core.IValidationStrategy
 		priority int
 	}
+	wrappers map[core.StrategyType][]strategy.WrapperOption
+
+	// 邮箱深度验证选项
+	emailOptions []strategy.EmailOption
+
+	// 用户名策略及其检查的字段
+	usernamePolicy *strategy.UsernamePolicy
+	usernameFields []string
+
+	// 查询字段策略生效的场景
+	queryScenes core.Scene
+
+	// 批量验证策略（ValidateSlice 使用）
+	batchStrategies []core.IBatchStrategy
+
+	// 仓储检查器（table -> checker）
+	uniquenessCheckers map[string]core.IUniquenessChecker
+	existenceCheckers  map[string]core.IExistenceChecker
 
 	// 配置
-	errorFormatter core.IErrorFormatter
-	maxErrors      int
-	maxDepth       int
-	executionMode  core.ExecutionMode
+	errorFormatter   core.IErrorFormatter
+	maxErrors        int
+	errorDetailLimit int
+	weakTyping       bool
+	maxDepth         int
+	cyclePolicy      core.CyclePolicy
+	nestedWorkers    int
+	nestedMinItems   int
+	executionMode    core.ExecutionMode
+	rePanic          bool
+	budget           core.Budget
+
+	// 按场景筛选策略与快速路径
+	sceneStrategies core.SceneStrategies
+	fastPathScenes  core.Scene
 }
 
 // NewBuilder 创建构建器
@@ -71,9 +216,13 @@ func NewBuilder() *Builder {
 			strategy core.IValidationStrategy
 			priority int
 		}),
-		maxErrors:     100,
-		maxDepth:      50,
-		executionMode: core.ExecutionModeSequential,
+		wrappers:           make(map[core.StrategyType][]strategy.WrapperOption),
+		uniquenessCheckers: make(map[string]core.IUniquenessChecker),
+		existenceCheckers:  make(map[string]core.IExistenceChecker),
+		profiles:           core.DefaultProfiles(),
+		maxErrors:          100,
+		maxDepth:           50,
+		executionMode:      core.ExecutionModeSequential,
 	}
 }
 
@@ -101,12 +250,82 @@ func (b *Builder) WithRuleEngine(engine core.IDependencyEngine) *Builder {
 	return b
 }
 
+// WithRegexPolicy 设置 regex 规则编译模式时的安全限制（默认 DefaultRegexPolicy）
+// 说明：规则来自租户或后台配置（见 WithTenantRuleProvider）时使用 UntrustedRegexPolicy；
+// 通过 WithRuleEngine 指定规则引擎时不生效
+func (b *Builder) WithRegexPolicy(policy RegexPolicy) *Builder {
+	b.engineOptions = append(b.engineOptions, infrastructure.WithRegexPolicy(policy))
+	return b
+}
+
 // WithSceneMatcher 设置场景匹配器
 func (b *Builder) WithSceneMatcher(matcher core.ISceneMatcher) *Builder {
 	b.sceneMatcher = matcher
 	return b
 }
 
+// WithRuleProvider 设置外部规则提供者
+// 说明：外部规则按字段覆盖模型内置规则，可配合 RuleManager 实现热更新；会替换 WithRuleSource 的设置
+func (b *Builder) WithRuleProvider(provider core.IRuleProvider) *Builder {
+	b.ruleProvider = provider
+	b.ruleSource = nil
+	return b
+}
+
+// WithRuleSource 从外部规则源加载规则并定时热更新
+// 说明：
+//   - Build 时加载一次并启动轮询，失败时回退到模型内置规则，后续轮询成功后自动生效；
+//     首次加载的错误可通过 RuleManager().LastError() 获取
+//   - 同一个 Builder 多次 Build 共用一个规则管理器，不会重复启动轮询；不再使用时调用 RuleManager().Stop()
+//   - interval<=0 表示只加载一次，不轮询
+func (b *Builder) WithRuleSource(source core.IRuleSource, interval time.Duration) *Builder {
+	if b.ruleManager != nil {
+		b.ruleManager.Stop()
+		b.ruleManager = nil
+	}
+	b.ruleSource = source
+	b.ruleInterval = interval
+	b.ruleProvider = nil
+	return b
+}
+
+// RuleManager 获取 WithRuleSource 创建的规则管理器（Build 之后可用，否则为 nil）
+// 说明：用于停止轮询（Stop）或检查最近一次加载的错误（LastError）
+func (b *Builder) RuleManager() *infrastructure.RuleManager {
+	return b.ruleManager
+}
+
+// WithTenantRuleProvider 设置租户规则覆盖提供者
+// 说明：调用时通过 v6.WithContext(v6.WithTenantID(ctx, tenantID)) 传入租户；
+// 优先级 模型规则 < 外部规则 < 租户覆盖，覆盖值为空字符串表示移除该字段的规则
+func (b *Builder) WithTenantRuleProvider(provider core.ITenantRuleProvider) *Builder {
+	b.tenantRules = provider
+	return b
+}
+
+// WithLegacyAdapter 设置旧版模型适配器
+// 说明：实现旧版验证器接口的模型（如 legacy.NewV1Adapter 识别的 v1 模型）无需修改即可使用 v6 引擎，
+// 规则、仓储、邮箱和业务策略都会通过适配器获取规则和业务验证
+func (b *Builder) WithLegacyAdapter(adapter core.ILegacyAdapter) *Builder {
+	b.legacyAdapter = adapter
+	return b
+}
+
+// WithParamResolver 设置规则参数解析器
+// 说明：规则中的 ${key}（如 "max=${limits.username_max}"）在验证时解析，同一次验证内每个 key 只解析一次；
+// 解析器收到 Validate 时通过 v6.WithContext 传入的上下文，可按租户返回不同的值
+func (b *Builder) WithParamResolver(resolver core.IParamResolver) *Builder {
+	b.paramResolver = resolver
+	return b
+}
+
+// WithPartialScenes 设置部分更新场景（如 SceneUpdate）
+// 说明：这些场景下 *T、sql.NullX、Optional 字段未提供时表示不修改，跳过包括 required 在内的所有规则
+func (b *Builder) WithPartialScenes(scenes core.Scene) *Builder {
+	b.partialScenes = scenes
+	return b
+}
+
 // WithRuleStrategy 添加规则验证策略
 func (b *Builder) WithRuleStrategy(priority int) *Builder {
 	b.strategies[core.StrategyTypeRule] = struct {
@@ -125,6 +344,122 @@ func (b *Builder) WithBusinessStrategy(priority int) *Builder {
 	return b
 }
 
+// WithNestedStrategy 添加嵌套验证策略（验证接口类型和泛型容器字段中的运行时值）
+// 说明：运行时值按具体类型的规则、业务验证和 ISelfValidator 验证，嵌套深度受 WithMaxDepth 限制，
+// 循环引用按 WithCyclePolicy 处理
+func (b *Builder) WithNestedStrategy(priority int) *Builder {
+	b.strategies[core.StrategyTypeNested] = struct {
+		strategy core.IValidationStrategy
+		priority int
+	}{priority: priority}
+	return b
+}
+
+// WithRepositoryStrategy 添加仓储验证策略（处理 unique/exists 规则）
+// 说明：未启用时规则中出现 unique=表.列 或 exists=表.列 会使验证返回 ErrRepositoryStrategyRequired；
+// 注册了检查器（WithUniquenessChecker / WithExistenceChecker）时自动启用
+func (b *Builder) WithRepositoryStrategy(priority int) *Builder {
+	b.strategies[core.StrategyTypeRepository] = struct {
+		strategy core.IValidationStrategy
+		priority int
+	}{priority: priority}
+	return b
+}
+
+// WithEmailDeepStrategy 添加邮箱深度验证策略（检查 MX 记录和一次性邮箱黑名单）
+// 说明：只检查规则中带 email 标签的字段，错误标签为 email_undeliverable / email_disposable；
+// 建议优先级低于规则策略，并配合 WithStrategyWrapper 设置超时
+func (b *Builder) WithEmailDeepStrategy(priority int, opts ...strategy.EmailOption) *Builder {
+	b.strategies[core.StrategyTypeEmail] = struct {
+		strategy core.IValidationStrategy
+		priority int
+	}{priority: priority}
+	b.emailOptions = opts
+	return b
+}
+
+// WithUsernamePolicy 设置用户名策略，并注册 username_policy 验证标签
+// 说明：规则中使用 username_policy 标签时只能得到通过/失败；需要违规原因（reserved、profanity 等）时使用 WithUsernamePolicyStrategy
+//
+// 使用示例：
+//
+//	policy := strategy.NewUsernamePolicy(strategy.WithReservedNames("katydid"), strategy.WithProfanity(words...))
+//	builder.WithUsernamePolicy(policy)
+func (b *Builder) WithUsernamePolicy(policy *strategy.UsernamePolicy) *Builder {
+	b.usernamePolicy = policy
+	return b
+}
+
+// WithUsernamePolicyStrategy 添加用户名策略验证（错误标签为 username_policy，Param 为违规原因）
+// 说明：fields 为检查的字段，为空时检查 Username 字段；未设置 WithUsernamePolicy 时使用默认策略
+func (b *Builder) WithUsernamePolicyStrategy(priority int, fields ...string) *Builder {
+	b.strategies[core.StrategyTypeUsernamePolicy] = struct {
+		strategy core.IValidationStrategy
+		priority int
+	}{priority: priority}
+	b.usernameFields = fields
+	return b
+}
+
+// WithQueryPolicyStrategy 添加查询字段策略（按调用方角色限制列表接口的过滤字段）
+// 说明：queryScenes 中的场景下，查询模型实现 IFieldPolicy 时，提供了值但角色不允许过滤的字段
+// 报告 field_not_queryable 错误；角色通过 v6.WithContext(v6.WithRole(ctx, role)) 传入
+func (b *Builder) WithQueryPolicyStrategy(priority int, queryScenes core.Scene) *Builder {
+	b.strategies[core.StrategyTypeQueryPolicy] = struct {
+		strategy core.IValidationStrategy
+		priority int
+	}{priority: priority}
+	b.queryScenes = queryScenes
+	return b
+}
+
+// WithUniquenessChecker 注册 unique=table.column 规则使用的唯一性检查器
+// 说明：table 为空表示默认检查器
+func (b *Builder) WithUniquenessChecker(table string, checker core.IUniquenessChecker) *Builder {
+	b.uniquenessCheckers[table] = checker
+	return b
+}
+
+// WithExistenceChecker 注册 exists=table.column 规则使用的存在性检查器
+// 说明：table 为空表示默认检查器
+func (b *Builder) WithExistenceChecker(table string, checker core.IExistenceChecker) *Builder {
+	b.existenceCheckers[table] = checker
+	return b
+}
+
+// WithStrategy 添加自定义验证策略
+// 说明：同类型的策略只保留最后一次设置
+func (b *Builder) WithStrategy(s core.IValidationStrategy, priority int) *Builder {
+	b.strategies[s.Type()] = struct {
+		strategy core.IValidationStrategy
+		priority int
+	}{strategy: s, priority: priority}
+	return b
+}
+
+// WithStrategyWrapper 为指定类型的策略添加超时、熔断和降级保护
+// 适用场景：依赖数据库、Redis 等外部资源的策略
+func (b *Builder) WithStrategyWrapper(strategyType core.StrategyType, opts ...strategy.WrapperOption) *Builder {
+	b.wrappers[strategyType] = append(b.wrappers[strategyType], opts...)
+	return b
+}
+
+// WithPipeline 使用验证管道编排策略
+// 说明：
+//   - 已添加的策略以策略名称（rule、business、repository）作为阶段名称，按优先级排列
+//   - configure 在默认阶段注册后执行，可按名称插入、替换或移除阶段
+//   - 配置错误属于编程错误，Build 时会 panic
+//
+// 示例：
+//
+//	builder.WithPipeline(func(p *orchestration.Pipeline) error {
+//	    return p.InsertBefore("rule", "sanitize", sanitizeStrategy)
+//	})
+func (b *Builder) WithPipeline(configure func(p *orchestration.Pipeline) error) *Builder {
+	b.pipelineConfigs = append(b.pipelineConfigs, configure)
+	return b
+}
+
 // WithInterceptor 添加拦截器
 func (b *Builder) WithInterceptor(interceptor core.IInterceptor) *Builder {
 	if b.interceptorChain == nil {
@@ -134,6 +469,40 @@ func (b *Builder) WithInterceptor(interceptor core.IInterceptor) *Builder {
 	return b
 }
 
+// WithListener 添加验证监听器
+// 说明：监听器同时实现 IEventListener 时，还会收到策略生命周期、缓存命中等细粒度事件
+func (b *Builder) WithListener(listener core.IValidationListener) *Builder {
+	b.listeners = append(b.listeners, listener)
+	return b
+}
+
+// WithAuditSink 添加验证结果审计接收器（如 audit.NewWebhookSink）
+// 说明：每次顶层验证结束后调用 sink.Record，验证通过时 result 为 nil；
+// 内置实现异步缓冲写入，服务退出前需调用其 Close 刷新缓冲区
+func (b *Builder) WithAuditSink(sink core.IAuditSink) *Builder {
+	return b.WithListener(orchestration.NewAuditListener(sink))
+}
+
+// WithSceneResolver 设置场景解析器
+// 说明：调用方传入 SceneAuto 时，由解析器根据对象状态决定场景
+func (b *Builder) WithSceneResolver(resolver core.ISceneResolver) *Builder {
+	b.sceneResolver = resolver
+	return b
+}
+
+// WithIDSceneResolver 按 ID 字段是否为零值解析场景（零值为 create，否则为 update）
+func (b *Builder) WithIDSceneResolver(create, update core.Scene) *Builder {
+	return b.WithSceneResolver(infrastructure.NewIDSceneResolver(create, update, ""))
+}
+
+// WithPlugins 添加插件
+// 说明：插件按 Requires 依赖和 Priority 排序后安装，与注册顺序无关；
+// 构建后可通过 v6.PluginManager 接口在运行时启用/禁用
+func (b *Builder) WithPlugins(plugins ...core.IPlugin) *Builder {
+	b.plugins = append(b.plugins, plugins...)
+	return b
+}
+
 // WithErrorFormatter 设置错误格式化器
 func (b *Builder) WithErrorFormatter(formatter core.IErrorFormatter) *Builder {
 	b.errorFormatter = formatter
@@ -141,17 +510,138 @@ func (b *Builder) WithErrorFormatter(formatter core.IErrorFormatter) *Builder {
 }
 
 // WithMaxErrors 设置最大错误数
+// 说明：错误数达到上限后停止执行剩余的字段和策略，结果标记为截断（Truncated）
 func (b *Builder) WithMaxErrors(maxErrors int) *Builder {
 	b.maxErrors = maxErrors
 	return b
 }
 
+// WithErrorDetailLimit 设置保留详情的错误数（超出部分汇总）
+// 说明：
+//   - 前 detailLimit 个错误保留详情，之后的错误只计数，结果末尾追加一条 more_errors 汇总错误，
+//     如 "and 1,243 more errors across 17 fields"；错误总数仍受 WithMaxErrors 限制
+//   - 用于批量导入等可能产生大量错误的场景，放宽 WithMaxErrors 统计错误规模的同时限制内存
+//
+// 示例：
+//
+//	NewBuilder().WithMaxErrors(100000).WithErrorDetailLimit(50)
+func (b *Builder) WithErrorDetailLimit(detailLimit int) *Builder {
+	b.errorDetailLimit = detailLimit
+	return b
+}
+
+// WithWeakTyping Map 验证使用弱类型模式（表单提交的请求体）
+// 说明：ValidateMapWithRules 在验证前将数字和布尔字符串转换为对应类型（如 "25" 满足 gte=18），
+// 每次转换在结果的 Warnings 中记录一条 Info 级别的 coerced 提示；结构体验证不受影响
+func (b *Builder) WithWeakTyping() *Builder {
+	b.weakTyping = true
+	return b
+}
+
+// WithBatchStrategy 添加批量验证策略（只在 ValidateSlice 中执行，按添加顺序）
+func (b *Builder) WithBatchStrategy(batch core.IBatchStrategy) *Builder {
+	b.batchStrategies = append(b.batchStrategies, batch)
+	return b
+}
+
+// WithBatchUniqueness 添加批内唯一性检查（批量导入时同一批次中的重复记录）
+// 说明：ValidateSlice 逐条验证后，按每个唯一键检查重复，重复记录的结果中追加 batch_duplicate 错误
+// （参数为首次出现的记录下标）；数据库中的重复仍由仓储策略检查
+//
+// 示例：
+//
+//	NewBuilder().WithRuleStrategy(10).
+//	    WithBatchUniqueness(v6.UniqueFieldFold("email"), v6.UniqueField("phone"))
+func (b *Builder) WithBatchUniqueness(keys ...strategy.BatchKey) *Builder {
+	return b.WithBatchStrategy(strategy.NewBatchUniquenessStrategy(keys...))
+}
+
+// WithFailFast 遇到第一个错误即停止验证
+// 说明：等价于 WithMaxErrors(1)
+func (b *Builder) WithFailFast() *Builder {
+	return b.WithMaxErrors(1)
+}
+
+// WithProfile 添加命名配置档（同名覆盖，包括内置的 strict 和 import）
+// 说明：调用时通过 Validate(obj, scene, v6.WithProfile(name)) 选择
+//
+// 使用示例：
+//
+//	builder.WithProfile(v6.Profile{Name: "admin", MaxErrors: 20})
+func (b *Builder) WithProfile(profile core.Profile) *Builder {
+	b.profiles = append(b.profiles, profile)
+	return b
+}
+
 // WithMaxDepth 设置最大深度
 func (b *Builder) WithMaxDepth(maxDepth int) *Builder {
 	b.maxDepth = maxDepth
 	return b
 }
 
+// WithNestedConcurrency 并发验证嵌套策略中的大集合
+// 说明：集合元素数达到 minItems（<= 0 时为 64）时使用 workers 个协程验证各元素，按元素顺序合并错误，
+// 输出与串行验证相同；元素 panic 时报告 nested_panic 错误，其他元素继续验证
+func (b *Builder) WithNestedConcurrency(workers, minItems int) *Builder {
+	b.nestedWorkers = workers
+	b.nestedMinItems = minItems
+	return b
+}
+
+// WithCyclePolicy 设置嵌套验证遇到循环引用时的处理方式
+// 说明：默认 CyclePolicySkip 静默跳过；CyclePolicyReport 报告 circular_reference 错误（适合不允许循环的数据）
+func (b *Builder) WithCyclePolicy(policy core.CyclePolicy) *Builder {
+	b.cyclePolicy = policy
+	return b
+}
+
+// WithRePanic 开发模式：策略 panic 时重新抛出
+// 说明：默认策略的 panic 会被隔离，转换为 strategy_panic 错误（Param 为策略名称），其他策略继续执行；
+// 开发和测试环境开启后可以尽早暴露问题
+func (b *Builder) WithRePanic() *Builder {
+	b.rePanic = true
+	return b
+}
+
+// WithDeadlineBudget 设置请求级时间预算
+// 说明：GoContext 带截止时间时（如 v6.WithContext(r.Context())），按权重把剩余时间分配给各阶段；
+// 分到的时间低于最低预算的阶段被跳过，并收集一条 validation_degraded 警告
+// 示例：WithDeadlineBudget(v6.Budget{"business": {Weight: 1}, "repository": {Weight: 3, Min: 20 * time.Millisecond}})
+func (b *Builder) WithDeadlineBudget(budget core.Budget) *Builder {
+	b.budget = budget
+	return b
+}
+
+// WithSceneStrategies 指定场景下只执行的策略（策略/阶段名称，如 "rule"、"business"）
+// 说明：同一场景多次设置时追加；验证场景与多个配置有交集时取并集，没有配置的场景执行全部策略
+//
+// 使用示例：
+//
+//	builder.WithSceneStrategies(SceneDelete, "rule")
+func (b *Builder) WithSceneStrategies(scene core.Scene, strategies ...string) *Builder {
+	if b.sceneStrategies == nil {
+		b.sceneStrategies = make(core.SceneStrategies)
+	}
+	b.sceneStrategies[scene] = append(b.sceneStrategies[scene], strategies...)
+	return b
+}
+
+// WithFastPath 为轻量场景（如删除只需要 "id required,min=1"）开启快速路径
+// 说明：
+//   - 验证场景的所有位都属于 scene 时跳过拦截器和监听器，不发出任何事件
+//   - strategies 非空时同时调用 WithSceneStrategies，只执行这些策略
+//
+// 使用示例：
+//
+//	builder.WithFastPath(SceneDelete, "rule")
+func (b *Builder) WithFastPath(scene core.Scene, strategies ...string) *Builder {
+	b.fastPathScenes |= scene
+	if len(strategies) > 0 {
+		b.WithSceneStrategies(scene, strategies...)
+	}
+	return b
+}
+
 // WithExecutionMode 设置策略执行模式
 func (b *Builder) WithExecutionMode(mode core.ExecutionMode) *Builder {
 	b.executionMode = mode
@@ -169,16 +659,47 @@ func (b *Builder) Build() core.IValidator {
 	// 注册策略
 	b.registerStrategies()
 
+	// 安装插件（在管道配置之前，便于管道引用插件注册的阶段）
+	plugins := b.installPlugins()
+
+	// 配置管道阶段
+	b.configurePipeline()
+
 	// 创建引擎
 	return engine.NewValidatorEngine(
 		b.orchestrator,
 		engine.WithInterceptorChain(b.interceptorChain),
+		engine.WithListeners(b.listeners...),
+		engine.WithPluginManager(plugins),
+		engine.WithTypeInspector(b.inspector),
+		engine.WithSceneResolver(b.sceneResolver),
+		engine.WithProfiles(b.profiles...),
 		engine.WithErrorFormatter(b.errorFormatter),
 		engine.WithMaxErrors(b.maxErrors),
+		engine.WithErrorDetailLimit(b.errorDetailLimit),
 		engine.WithMaxDepth(b.maxDepth),
+		engine.WithFastPathScenes(b.fastPathScenes),
+		engine.WithRuleEngine(b.dependencyEngine),
+		engine.WithWeakTyping(b.weakTyping),
+		engine.WithBatchStrategies(b.batchStrategies...),
 	)
 }
 
+// initRuleSource 创建规则管理器，加载一次并启动轮询（多次 Build 只创建一次）
+func (b *Builder) initRuleSource() {
+	if b.ruleSource == nil {
+		return
+	}
+	if b.ruleManager == nil {
+		manager := infrastructure.NewRuleManager(b.ruleSource, nil)
+		// 失败时记录在 LastError 中，回退到模型内置规则
+		_ = manager.Reload(context.Background())
+		manager.Start(b.ruleInterval)
+		b.ruleManager = manager
+	}
+	b.ruleProvider = b.ruleManager
+}
+
 // initInfrastructure 初始化基础设施组件
 func (b *Builder) initInfrastructure() {
 	// 缓存管理器
@@ -197,37 +718,153 @@ func (b *Builder) initInfrastructure() {
 		b.sceneMatcher = infrastructure.NewCachedSceneMatcher(matcher, b.cache)
 	}
 
+	// 外部规则源
+	b.initRuleSource()
+
 	// 规则引擎
 	if b.dependencyEngine == nil {
-		b.dependencyEngine = infrastructure.NewDependencyEngine()
+		b.dependencyEngine = infrastructure.NewDependencyEngine(b.engineOptions...)
+	}
+
+	// 用户名策略标签
+	if b.usernamePolicy != nil {
+		if err := b.dependencyEngine.RegisterValidation(strategy.TagUsernamePolicy, b.usernamePolicy.ValidationFunc()); err != nil {
+			panic(fmt.Errorf("validator username policy: %w", err))
+		}
 	}
 }
 
 // initOrchestration 初始化编排组件
 func (b *Builder) initOrchestration() {
-	// 策略编排器
+	// 策略编排器：配置了管道时使用管道
 	if b.orchestrator == nil {
-		b.orchestrator = orchestration.NewStrategyOrchestrator()
+		if len(b.pipelineConfigs) > 0 {
+			b.orchestrator = orchestration.NewPipeline()
+		} else {
+			b.orchestrator = orchestration.NewStrategyOrchestrator()
+		}
 		b.orchestrator.SetExecutionMode(b.executionMode)
 	}
+	if o, ok := b.orchestrator.(core.IRePanicOrchestrator); ok {
+		o.SetRePanic(b.rePanic)
+	}
+	if o, ok := b.orchestrator.(core.IBudgetOrchestrator); ok {
+		o.SetBudget(b.budget)
+	}
+	b.orchestrator.SetSceneStrategies(b.sceneStrategies)
+}
+
+// configurePipeline 配置管道阶段
+func (b *Builder) configurePipeline() {
+	pipeline, ok := b.orchestrator.(*orchestration.Pipeline)
+	if !ok {
+		return
+	}
+	for _, configure := range b.pipelineConfigs {
+		if err := configure(pipeline); err != nil {
+			panic(fmt.Errorf("validator pipeline: %w", err))
+		}
+	}
+}
+
+// installPlugins 安装插件
+// 说明：依赖缺失、循环依赖、规则重名属于编程错误，直接 panic
+func (b *Builder) installPlugins() core.IPluginManager {
+	if len(b.plugins) == 0 {
+		return nil
+	}
+
+	manager := orchestration.NewPluginManager()
+	if err := manager.Add(b.plugins...); err != nil {
+		panic(fmt.Errorf("validator plugins: %w", err))
+	}
+	if err := manager.Install(b.dependencyEngine, b.orchestrator); err != nil {
+		panic(fmt.Errorf("validator plugins: %w", err))
+	}
+	return manager
 }
 
 // registerStrategies 注册策略
+// 说明：注册了仓储检查器但未启用仓储策略时自动启用，优先级紧随规则策略
 func (b *Builder) registerStrategies() {
+	if _, ok := b.strategies[core.StrategyTypeRepository]; !ok && (len(b.uniquenessCheckers) > 0 || len(b.existenceCheckers) > 0) {
+		b.WithRepositoryStrategy(b.strategies[core.StrategyTypeRule].priority + 1)
+	}
+
 	for strategyType, entry := range b.strategies {
-		var s core.IValidationStrategy
+		// 优先使用通过 WithStrategy 设置的自定义策略
+		s := entry.strategy
+		if s == nil {
+			switch strategyType {
+			case core.StrategyTypeRule:
+				s = b.newRuleStrategy()
+			case core.StrategyTypeBusiness:
+				s = b.newBusinessStrategy()
+			case core.StrategyTypeNested:
+				s = strategy.NewNestedStrategy(b.newRuleStrategy(), b.newBusinessStrategy(),
+					strategy.WithNestedMaxDepth(b.maxDepth), strategy.WithNestedCyclePolicy(b.cyclePolicy),
+					strategy.WithNestedConcurrency(b.nestedWorkers, b.nestedMinItems))
+			case core.StrategyTypeRepository:
+				s = b.newRepositoryStrategy()
+			case core.StrategyTypeEmail:
+				email := strategy.NewEmailDeepStrategy(b.inspector, b.ruleProvider, infrastructure.NewLRUCache(1024), b.emailOptions...)
+				email.SetTenantRuleProvider(b.tenantRules)
+				email.SetLegacyAdapter(b.legacyAdapter)
+				s = email
+			case core.StrategyTypeUsernamePolicy:
+				s = strategy.NewUsernamePolicyStrategy(b.usernamePolicy, b.inspector, b.usernameFields...)
+			case core.StrategyTypeQueryPolicy:
+				s = strategy.NewQueryPolicyStrategy(b.inspector, b.queryScenes)
+			}
+		}
 
-		switch strategyType {
-		case core.StrategyTypeRule:
-			s = strategy.NewRuleStrategy(b.dependencyEngine, b.inspector, b.sceneMatcher)
-		case core.StrategyTypeBusiness:
-			s = strategy.NewBusinessStrategy(b.inspector)
+		if s == nil {
+			continue
 		}
 
-		if s != nil {
-			b.orchestrator.Register(s, entry.priority)
+		// 包装超时、熔断和降级保护
+		if opts, ok := b.wrappers[strategyType]; ok {
+			s = strategy.NewStrategyWrapper(s, opts...)
 		}
+
+		b.orchestrator.Register(s, entry.priority)
+	}
+}
+
+// newRuleStrategy 创建规则验证策略
+func (b *Builder) newRuleStrategy() core.IValidationStrategy {
+	return strategy.NewRuleStrategy(b.dependencyEngine, b.inspector, b.sceneMatcher,
+		strategy.WithRuleProvider(b.ruleProvider),
+		strategy.WithPartialScenes(b.partialScenes),
+		strategy.WithParamResolver(b.paramResolver),
+		strategy.WithTenantRuleProvider(b.tenantRules),
+		strategy.WithLegacyAdapter(b.legacyAdapter),
+		strategy.WithRepositoryRules(b.hasRepositoryStrategy()))
+}
+
+// hasRepositoryStrategy 是否启用了仓储验证策略（含 WithStrategy 设置的自定义策略）
+func (b *Builder) hasRepositoryStrategy() bool {
+	_, ok := b.strategies[core.StrategyTypeRepository]
+	return ok
+}
+
+// newBusinessStrategy 创建业务验证策略
+func (b *Builder) newBusinessStrategy() core.IValidationStrategy {
+	return strategy.NewBusinessStrategy(b.inspector, strategy.WithBusinessLegacyAdapter(b.legacyAdapter))
+}
+
+// newRepositoryStrategy 创建仓储验证策略并注册检查器
+func (b *Builder) newRepositoryStrategy() core.IValidationStrategy {
+	s := strategy.NewRepositoryStrategy(b.inspector, b.ruleProvider)
+	s.SetTenantRuleProvider(b.tenantRules)
+	s.SetLegacyAdapter(b.legacyAdapter)
+	for table, checker := range b.uniquenessCheckers {
+		s.RegisterUniquenessChecker(table, checker)
+	}
+	for table, checker := range b.existenceCheckers {
+		s.RegisterExistenceChecker(table, checker)
 	}
+	return s
 }
 
 // ============================================================================
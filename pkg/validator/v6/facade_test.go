@@ -0,0 +1,129 @@
+package v6_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// stubValidator 记录调用的验证器
+type stubValidator struct {
+	name  string
+	calls int
+	opts  int
+}
+
+func (s *stubValidator) Validate(target any, scene core.Scene, opts ...core.ValidateOption) core.IValidationError {
+	s.calls++
+	s.opts = len(opts)
+	return nil
+}
+
+func (s *stubValidator) Check(target any, scene core.Scene, opts ...core.ValidateOption) core.IValidationError {
+	return s.Validate(target, scene, opts...)
+}
+
+func (s *stubValidator) ValidateWithContext(target any, ctx core.IContext) error {
+	s.calls++
+	return nil
+}
+
+// TestSetDefault 测试原子替换默认验证器并返回之前的验证器
+func TestSetDefault(t *testing.T) {
+	original := v6.Default()
+	t.Cleanup(func() { v6.SetDefault(original) })
+
+	mock := &stubValidator{name: "mock"}
+	if prev := v6.SetDefault(mock); prev != original {
+		t.Errorf("SetDefault() = %v, want 之前的默认验证器", prev)
+	}
+	if v6.Default() != mock || v6.Facade() != mock {
+		t.Fatal("SetDefault 之后 Default()/Facade() 应返回新验证器")
+	}
+
+	v6.Validate(&User{}, SceneCreate)
+	if mock.calls != 1 {
+		t.Errorf("v6.Validate 调用次数 = %d, want 1", mock.calls)
+	}
+
+	// 传入 nil 重置为延迟创建的默认验证器
+	if prev := v6.SetDefault(nil); prev != mock {
+		t.Errorf("SetDefault(nil) = %v, want mock", prev)
+	}
+	rebuilt := v6.Default()
+	if rebuilt == nil || rebuilt == mock {
+		t.Fatalf("重置后 Default() = %v, want 新建的默认验证器", rebuilt)
+	}
+	if v6.Default() != rebuilt {
+		t.Error("延迟创建的默认验证器应只创建一次")
+	}
+	if err := v6.Validate(&User{}, SceneCreate); err == nil {
+		t.Error("重置后的默认验证器应按规则验证")
+	}
+}
+
+// TestDefault_Concurrent 测试重置后并发首次调用只保存一个实例
+func TestDefault_Concurrent(t *testing.T) {
+	original := v6.SetDefault(nil)
+	t.Cleanup(func() { v6.SetDefault(original) })
+
+	const n = 16
+	got := make([]core.IValidator, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			got[i] = v6.Default()
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 1; i < n; i++ {
+		if got[i] != got[0] {
+			t.Fatalf("Default() 并发调用返回了不同实例")
+		}
+	}
+}
+
+// TestFromContext 测试 context 注入的验证器优先于默认验证器
+func TestFromContext(t *testing.T) {
+	original := v6.Default()
+	t.Cleanup(func() { v6.SetDefault(original) })
+	fallback := &stubValidator{name: "default"}
+	v6.SetDefault(fallback)
+
+	injected := &stubValidator{name: "injected"}
+	ctx := v6.WithValidator(context.Background(), injected)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want core.IValidator
+	}{
+		{"已注入", ctx, injected},
+		{"未注入", context.Background(), fallback},
+		{"nil context", nil, fallback},
+		{"注入 nil 返回原 context", v6.WithValidator(context.Background(), nil), fallback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v6.FromContext(tt.ctx); got != tt.want {
+				t.Errorf("FromContext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// ValidateContext 使用注入的验证器，并追加 WithContext 选项
+	v6.ValidateContext(ctx, &User{}, SceneCreate, v6.WithFields("email"))
+	if injected.calls != 1 || fallback.calls != 0 {
+		t.Errorf("calls injected = %d default = %d, want 1/0", injected.calls, fallback.calls)
+	}
+	if injected.opts != 2 {
+		t.Errorf("ValidateContext 选项数 = %d, want 2", injected.opts)
+	}
+}
@@ -0,0 +1,111 @@
+package v6_test
+
+import (
+	"reflect"
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// fieldsetAddress 嵌套结构体
+type fieldsetAddress struct {
+	City string `json:"city"`
+}
+
+// fieldsetAccount 部分字段验证的模型
+type fieldsetAccount struct {
+	Name    string          `json:"name"`
+	Email   string          `json:"email"`
+	Legacy  string          `json:"legacy"`
+	Address fieldsetAddress `json:"address"`
+
+	gotFields core.FieldSet // 业务验证收到的有效字段
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (a *fieldsetAccount) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{
+		"name":         "required,min=3",
+		"email":        "required,email",
+		"legacy":       "fieldset_test_undefined", // 未注册的验证标签，执行时 go-playground 会 panic
+		"address.city": "required",
+	}
+}
+
+// ValidateBusinessFields 实现 IFieldAwareBusinessValidator 接口
+func (a *fieldsetAccount) ValidateBusinessFields(scene core.Scene, fields core.FieldSet, collector core.IErrorCollector) {
+	a.gotFields = fields
+	if fields.Includes("email") && a.Email == "taken@example.com" {
+		collector.Collect(v6.NewFieldError("fieldsetAccount.email", "email", "email_taken"))
+	}
+}
+
+// TestFieldSet_RuleSelection 只编译和执行有效字段的规则
+func TestFieldSet_RuleSelection(t *testing.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).WithBusinessStrategy(20).Build()
+	account := &fieldsetAccount{Name: "al", Email: "taken@example.com"}
+
+	// legacy 被排除，不会执行未注册的标签
+	err := validator.Validate(account, SceneCreate, v6.WithExcludeFields("legacy", "address"))
+	if got := fieldErrorKeys(err); !reflect.DeepEqual(got, []string{"name.min", "email.email_taken"}) {
+		t.Errorf("errors = %v", got)
+	}
+	if !reflect.DeepEqual(account.gotFields.Excluded(), []string{"legacy", "address"}) {
+		t.Errorf("business fields = %+v", account.gotFields)
+	}
+
+	// 只验证 name，业务验证跳过邮箱检查
+	err = validator.Validate(account, SceneCreate, v6.WithFields("name"))
+	if got := fieldErrorKeys(err); !reflect.DeepEqual(got, []string{"name.min"}) {
+		t.Errorf("errors = %v", got)
+	}
+
+	// 嵌套路径：指定 address.city 时只验证该规则
+	err = validator.Validate(account, SceneCreate, v6.WithFields("address.city"))
+	if got := fieldErrorKeys(err); !reflect.DeepEqual(got, []string{"city.required"}) {
+		t.Errorf("errors = %v", got)
+	}
+
+	// 同时使用：先选择再排除
+	err = validator.Validate(account, SceneCreate, v6.WithFields("name", "email"), v6.WithExcludeFields("name"))
+	if got := fieldErrorKeys(err); !reflect.DeepEqual(got, []string{"email.email_taken"}) {
+		t.Errorf("errors = %v", got)
+	}
+}
+
+// TestFieldSet_Includes 字段路径匹配
+func TestFieldSet_Includes(t *testing.T) {
+	if !(core.FieldSet{}).All() || !(core.FieldSet{}).Includes("anything") {
+		t.Error("零值应包含所有字段")
+	}
+
+	include := core.NewFieldSet([]string{"profile.email", "tags[1]"}, nil)
+	for field, want := range map[string]bool{"profile": true, "profile.email": true, "tags": true, "name": false} {
+		if got := include.Includes(field); got != want {
+			t.Errorf("include.Includes(%q) = %v", field, got)
+		}
+	}
+
+	exclude := core.NewFieldSet(nil, []string{"profile"})
+	for field, want := range map[string]bool{"profile": false, "profile.email": false, "profiles": true} {
+		if got := exclude.Includes(field); got != want {
+			t.Errorf("exclude.Includes(%q) = %v", field, got)
+		}
+	}
+	if exclude.IncludesAny("Profile", "profile") {
+		t.Error("任一名称被排除时不应包含")
+	}
+}
+
+// fieldErrorKeys 错误的字段名和标签
+func fieldErrorKeys(err v6.Result) []string {
+	if err == nil {
+		return nil
+	}
+	var keys []string
+	for _, fe := range err.FieldErrors() {
+		keys = append(keys, fe.Field()+"."+fe.Tag())
+	}
+	return keys
+}
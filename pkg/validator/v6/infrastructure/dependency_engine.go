@@ -18,8 +18,29 @@ type dependencyEngine struct {
 	validator *validator.Validate
 }
 
+// DependencyEngineOption 规则引擎选项
+type DependencyEngineOption func(*dependencyEngineConfig)
+
+// dependencyEngineConfig 规则引擎配置
+type dependencyEngineConfig struct {
+	regexPolicy RegexPolicy
+}
+
+// WithRegexPolicy 设置 regex 规则编译模式时的安全限制（默认 DefaultRegexPolicy）
+// 说明：规则来自租户或后台配置时使用 UntrustedRegexPolicy
+func WithRegexPolicy(policy RegexPolicy) DependencyEngineOption {
+	return func(c *dependencyEngineConfig) {
+		c.regexPolicy = policy
+	}
+}
+
 // NewDependencyEngine 创建 依赖库 规则引擎
-func NewDependencyEngine() core.IDependencyEngine {
+func NewDependencyEngine(opts ...DependencyEngineOption) core.IDependencyEngine {
+	cfg := dependencyEngineConfig{regexPolicy: DefaultRegexPolicy}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	v := validator.New()
 
 	// 注册 JSON tag 作为字段名
@@ -31,6 +52,12 @@ func NewDependencyEngine() core.IDependencyEngine {
 		return name
 	})
 
+	// 注册 pkg/types 值类型的规则（money_gt 等）
+	registerTypeRules(v)
+
+	// 正则规则：regex=^[A-Z]{3}$，编译结果按模式缓存
+	_ = v.RegisterValidation(tagRegex, validateRegex(cfg.regexPolicy))
+
 	return &dependencyEngine{
 		validator: v,
 	}
@@ -57,14 +84,18 @@ func (e *dependencyEngine) ValidateMap(data map[string]interface{}, rules map[st
 // TODO:GG 外部怎么调用这个方法？
 func (e *dependencyEngine) RegisterAlias(alias, tags string) {
 	e.validator.RegisterAlias(alias, tags)
+	_ = core.RegisterTag(core.Tag(alias))
 }
 
 // RegisterValidation 注册自定义验证函数
 // TODO:GG 外部怎么调用这个方法？
 func (e *dependencyEngine) RegisterValidation(tag string, fn core.ValidationFunc) error {
-	return e.validator.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+	if err := e.validator.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
 		return fn(fl.Field().Interface(), fl.Param())
-	})
+	}); err != nil {
+		return err
+	}
+	return core.RegisterTag(core.Tag(tag))
 }
 
 // GetValidator 获取底层 validator 实例（用于高级用法）
@@ -0,0 +1,78 @@
+package infrastructure
+
+import (
+	"context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"sync"
+)
+
+// ============================================================================
+// 配置值参数解析器
+// ============================================================================
+
+// MapParamResolver 基于配置值的参数解析器
+// 说明：保存全局配置值（如 limits.username_max=32），可在配置重载后通过 Set/Replace 更新；并发安全
+type MapParamResolver struct {
+	mu     sync.RWMutex
+	values map[string]string
+}
+
+// NewMapParamResolver 创建配置值参数解析器
+func NewMapParamResolver(values map[string]string) *MapParamResolver {
+	r := &MapParamResolver{}
+	r.Replace(values)
+	return r
+}
+
+// Set 设置单个配置值
+func (r *MapParamResolver) Set(key, value string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values[key] = value
+}
+
+// Replace 替换全部配置值
+func (r *MapParamResolver) Replace(values map[string]string) {
+	copied := make(map[string]string, len(values))
+	for key, value := range values {
+		copied[key] = value
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values = copied
+}
+
+// ResolveParam 实现 IParamResolver 接口
+func (r *MapParamResolver) ResolveParam(_ context.Context, key string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	value, ok := r.values[key]
+	return value, ok
+}
+
+// ============================================================================
+// 组合参数解析器
+// ============================================================================
+
+// chainParamResolver 依次尝试多个解析器
+type chainParamResolver []core.IParamResolver
+
+// NewChainParamResolver 创建组合参数解析器
+// 说明：按顺序解析，第一个返回 ok 的结果生效（如先查租户配置，再回退到全局配置）
+func NewChainParamResolver(resolvers ...core.IParamResolver) core.IParamResolver {
+	return chainParamResolver(resolvers)
+}
+
+// ResolveParam 实现 IParamResolver 接口
+func (c chainParamResolver) ResolveParam(ctx context.Context, key string) (string, bool) {
+	for _, resolver := range c {
+		if resolver == nil {
+			continue
+		}
+		if value, ok := resolver.ResolveParam(ctx, key); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
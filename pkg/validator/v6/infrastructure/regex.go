@@ -0,0 +1,176 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"sync/atomic"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ============================================================================
+// 正则表达式编译缓存
+// ============================================================================
+
+// ErrRegexTooComplex 正则表达式超出安全限制
+var ErrRegexTooComplex = errors.New("regex: pattern too complex")
+
+// RegexPolicy 正则表达式的安全限制（零值字段表示不限制）
+// 说明：Go 的 regexp 是 RE2 实现，匹配耗时与输入长度成线性关系，不存在回溯爆炸；
+// 恶意模式的风险在于模式本身过大（编译耗时、程序体积和每次匹配的内存随之增长），因此按模式的结构限制
+type RegexPolicy struct {
+	MaxLength       int  // 模式最大长度（字节）
+	MaxRepeat       int  // 计数重复 {n,m} 的最大次数（Go 本身上限为 1000）
+	MaxNesting      int  // 重复的最大嵌套层数（如 (a+)+ 为 2）
+	MaxInstructions int  // 编译后程序的最大指令数（决定匹配时的内存和耗时系数）
+	POSIX           bool // 只接受 POSIX ERE 语法并使用最左最长匹配（regexp.CompilePOSIX），拒绝 Perl 扩展
+}
+
+var (
+	// DefaultRegexPolicy 开发者编写的模式（代码和规则中的 regex=...）
+	DefaultRegexPolicy = RegexPolicy{MaxLength: 4096, MaxInstructions: 20000}
+
+	// UntrustedRegexPolicy 用户或租户提供的模式（如后台配置的字段格式）
+	UntrustedRegexPolicy = RegexPolicy{MaxLength: 256, MaxRepeat: 100, MaxNesting: 2, MaxInstructions: 2000, POSIX: true}
+)
+
+// defaultRegexCacheSize 默认缓存的模式数
+const defaultRegexCacheSize = 1024
+
+// regexCache 按 (模式, 限制) 缓存编译结果（包括失败结果，恶意模式不会被反复解析）
+var regexCache atomic.Pointer[regexCacheHolder]
+
+// regexCacheHolder atomic.Pointer 不能直接存放接口
+type regexCacheHolder struct {
+	cache core.ICacheManager
+}
+
+// regexKey 缓存键
+type regexKey struct {
+	pattern string
+	policy  RegexPolicy
+}
+
+// regexEntry 缓存值
+type regexEntry struct {
+	re  *regexp.Regexp
+	err error
+}
+
+func init() {
+	SetRegexCacheSize(defaultRegexCacheSize)
+}
+
+// SetRegexCacheSize 设置正则表达式缓存容量（LRU 淘汰），并清空已缓存的模式
+func SetRegexCacheSize(size int) {
+	if size <= 0 {
+		size = defaultRegexCacheSize
+	}
+	regexCache.Store(&regexCacheHolder{cache: NewLRUCache(size)})
+}
+
+// RegexCacheStats 正则表达式缓存统计
+func RegexCacheStats() core.CacheStats {
+	return regexCache.Load().cache.Stats()
+}
+
+// CompileRegex 按 DefaultRegexPolicy 编译正则表达式（带缓存）
+// 说明：自定义规则中不要每次调用 regexp.MustCompile，使用该函数或在包级变量中编译一次
+func CompileRegex(pattern string) (*regexp.Regexp, error) {
+	return CompileRegexWithPolicy(pattern, DefaultRegexPolicy)
+}
+
+// CompileRegexWithPolicy 按指定限制编译正则表达式（带缓存）
+// 说明：超出限制时返回 ErrRegexTooComplex；返回的 *regexp.Regexp 可并发使用
+func CompileRegexWithPolicy(pattern string, policy RegexPolicy) (*regexp.Regexp, error) {
+	cache := regexCache.Load().cache
+	key := regexKey{pattern: pattern, policy: policy}
+	if cached, ok := cache.Get(key); ok {
+		entry := cached.(regexEntry)
+		return entry.re, entry.err
+	}
+
+	re, err := compileRegex(pattern, policy)
+	cache.Set(key, regexEntry{re: re, err: err})
+	return re, err
+}
+
+// compileRegex 检查限制后编译
+func compileRegex(pattern string, policy RegexPolicy) (*regexp.Regexp, error) {
+	if policy.MaxLength > 0 && len(pattern) > policy.MaxLength {
+		return nil, fmt.Errorf("%w: length %d exceeds %d", ErrRegexTooComplex, len(pattern), policy.MaxLength)
+	}
+
+	flags := syntax.Perl
+	if policy.POSIX {
+		flags = syntax.POSIX
+	}
+	parsed, err := syntax.Parse(pattern, flags)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRegexTree(parsed, policy, 0); err != nil {
+		return nil, err
+	}
+	if policy.MaxInstructions > 0 {
+		prog, err := syntax.Compile(parsed.Simplify())
+		if err != nil {
+			return nil, err
+		}
+		if len(prog.Inst) > policy.MaxInstructions {
+			return nil, fmt.Errorf("%w: %d instructions exceed %d", ErrRegexTooComplex, len(prog.Inst), policy.MaxInstructions)
+		}
+	}
+
+	if policy.POSIX {
+		return regexp.CompilePOSIX(pattern)
+	}
+	return regexp.Compile(pattern)
+}
+
+// checkRegexTree 检查计数重复的次数和重复的嵌套层数
+func checkRegexTree(re *syntax.Regexp, policy RegexPolicy, depth int) error {
+	switch re.Op {
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		depth++
+		if policy.MaxNesting > 0 && depth > policy.MaxNesting {
+			return fmt.Errorf("%w: repetition nested %d levels exceeds %d", ErrRegexTooComplex, depth, policy.MaxNesting)
+		}
+		if re.Op == syntax.OpRepeat && policy.MaxRepeat > 0 && max(re.Min, re.Max) > policy.MaxRepeat {
+			return fmt.Errorf("%w: repeat count %d exceeds %d", ErrRegexTooComplex, max(re.Min, re.Max), policy.MaxRepeat)
+		}
+	}
+	for _, sub := range re.Sub {
+		if err := checkRegexTree(sub, policy, depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// regex 规则
+// ============================================================================
+
+// tagRegex 正则规则标签：regex=^[A-Z]{3}$（模式中的逗号写作 0x2C，竖线写作 0x7C）
+const tagRegex = "regex"
+
+// validateRegex 创建正则规则，模式按 policy 编译并缓存；模式无效或超出限制时验证失败
+func validateRegex(policy RegexPolicy) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		re, err := CompileRegexWithPolicy(fl.Param(), policy)
+		if err != nil {
+			return false
+		}
+
+		field := fl.Field()
+		if field.Kind() != reflect.String {
+			return false
+		}
+		return re.MatchString(field.String())
+	}
+}
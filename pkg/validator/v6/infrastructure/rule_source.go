@@ -0,0 +1,274 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"katydid-common-account/pkg/validator/v6/core"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ============================================================================
+// 文件规则源
+// ============================================================================
+
+// fileRuleSource 基于 JSON 文件的规则源
+type fileRuleSource struct {
+	path string
+}
+
+// NewFileRuleSource 创建文件规则源
+// 文件格式：{"version":"v1","models":{"User":{"1":{"username":"required,min=3"}}}}
+// 场景键为 Scene 的十进制值
+func NewFileRuleSource(path string) core.IRuleSource {
+	return &fileRuleSource{path: path}
+}
+
+// Load 实现 IRuleSource 接口
+func (s *fileRuleSource) Load(_ context.Context) (*core.RuleSet, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("read rule file: %w", err)
+	}
+	return decodeRuleSet(data)
+}
+
+// ============================================================================
+// HTTP 规则源
+// ============================================================================
+
+// httpRuleSource 基于 HTTP 的规则源
+type httpRuleSource struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPRuleSource 创建 HTTP 规则源
+// 说明：client 为 nil 时使用 5 秒超时的默认客户端
+func NewHTTPRuleSource(url string, client *http.Client) core.IRuleSource {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &httpRuleSource{url: url, client: client}
+}
+
+// Load 实现 IRuleSource 接口
+func (s *httpRuleSource) Load(ctx context.Context) (*core.RuleSet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch rules: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch rules: unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read rules: %w", err)
+	}
+	return decodeRuleSet(data)
+}
+
+// ============================================================================
+// 键值规则源（etcd 等）
+// ============================================================================
+
+// IKeyValueGetter 键值存储读取接口
+// 说明：用于适配 etcd、Consul 等配置中心，避免直接依赖具体客户端
+type IKeyValueGetter interface {
+	// Get 读取键的值
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// kvRuleSource 基于键值存储的规则源
+type kvRuleSource struct {
+	getter IKeyValueGetter
+	key    string
+}
+
+// NewKVRuleSource 创建键值规则源
+func NewKVRuleSource(getter IKeyValueGetter, key string) core.IRuleSource {
+	return &kvRuleSource{getter: getter, key: key}
+}
+
+// Load 实现 IRuleSource 接口
+func (s *kvRuleSource) Load(ctx context.Context) (*core.RuleSet, error) {
+	data, err := s.getter.Get(ctx, s.key)
+	if err != nil {
+		return nil, fmt.Errorf("get rules from key %q: %w", s.key, err)
+	}
+	return decodeRuleSet(data)
+}
+
+// decodeRuleSet 解析 JSON 规则集
+func decodeRuleSet(data []byte) (*core.RuleSet, error) {
+	ruleSet := &core.RuleSet{}
+	if err := json.Unmarshal(data, ruleSet); err != nil {
+		return nil, fmt.Errorf("decode rules: %w", err)
+	}
+	return ruleSet, nil
+}
+
+// ============================================================================
+// 规则管理器 - 热更新
+// ============================================================================
+
+// ruleSnapshot 规则快照
+// 说明：每次热更新替换整个快照，合并结果缓存随快照一起失效
+type ruleSnapshot struct {
+	ruleSet *core.RuleSet
+	merged  sync.Map // ruleCacheKey -> map[string]string
+}
+
+// ruleCacheKey 合并规则缓存键
+type ruleCacheKey struct {
+	model string
+	scene core.Scene
+}
+
+// RuleManager 规则管理器
+// 职责：从规则源加载规则并原子替换，支持定时轮询热更新
+// 设计原则：加载失败时保留上一次成功的规则，首次加载失败则回退到模型内置规则
+type RuleManager struct {
+	source   core.IRuleSource
+	matcher  core.ISceneMatcher
+	snapshot atomic.Pointer[ruleSnapshot]
+	lastErr  atomic.Pointer[error]
+
+	mu     sync.Mutex
+	stopCh chan struct{}
+}
+
+// NewRuleManager 创建规则管理器
+// 说明：matcher 为 nil 时使用位运算场景匹配器
+func NewRuleManager(source core.IRuleSource, matcher core.ISceneMatcher) *RuleManager {
+	if matcher == nil {
+		matcher = NewBitSceneMatcher()
+	}
+	return &RuleManager{
+		source:  source,
+		matcher: matcher,
+	}
+}
+
+// Reload 从规则源重新加载规则
+// 说明：版本号未变化时不替换快照
+func (m *RuleManager) Reload(ctx context.Context) error {
+	ruleSet, err := m.source.Load(ctx)
+	if err != nil {
+		m.lastErr.Store(&err)
+		return err
+	}
+	m.lastErr.Store(nil)
+
+	if current := m.snapshot.Load(); current != nil &&
+		ruleSet.Version != "" && current.ruleSet.Version == ruleSet.Version {
+		return nil
+	}
+
+	m.Swap(ruleSet)
+	return nil
+}
+
+// Swap 原子替换规则集
+func (m *RuleManager) Swap(ruleSet *core.RuleSet) {
+	m.snapshot.Store(&ruleSnapshot{ruleSet: ruleSet})
+}
+
+// Start 启动定时轮询
+// 说明：重复调用会先停止之前的轮询
+func (m *RuleManager) Start(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stopCh != nil {
+		close(m.stopCh)
+	}
+	m.stopCh = make(chan struct{})
+
+	go m.pollLoop(interval, m.stopCh)
+}
+
+// Stop 停止定时轮询
+func (m *RuleManager) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stopCh != nil {
+		close(m.stopCh)
+		m.stopCh = nil
+	}
+}
+
+// pollLoop 定时轮询
+func (m *RuleManager) pollLoop(interval time.Duration, stopCh chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			_ = m.Reload(ctx)
+			cancel()
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+// LastError 最近一次加载的错误（成功时为 nil）
+func (m *RuleManager) LastError() error {
+	if err := m.lastErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// Version 实现 IRuleProvider 接口
+func (m *RuleManager) Version() string {
+	if snapshot := m.snapshot.Load(); snapshot != nil {
+		return snapshot.ruleSet.Version
+	}
+	return ""
+}
+
+// Rules 实现 IRuleProvider 接口
+func (m *RuleManager) Rules(model string, scene core.Scene) (map[string]string, string, bool) {
+	snapshot := m.snapshot.Load()
+	if snapshot == nil {
+		return nil, "", false
+	}
+
+	sceneRules, ok := snapshot.ruleSet.Models[model]
+	if !ok || len(sceneRules) == 0 {
+		return nil, "", false
+	}
+
+	// 合并结果按快照缓存
+	key := ruleCacheKey{model: model, scene: scene}
+	if cached, ok := snapshot.merged.Load(key); ok {
+		return cached.(map[string]string), snapshot.ruleSet.Version, true
+	}
+
+	rules := m.matcher.MergeRules(scene, sceneRules)
+	snapshot.merged.Store(key, rules)
+
+	return rules, snapshot.ruleSet.Version, true
+}
@@ -0,0 +1,169 @@
+package infrastructure
+
+import (
+	"context"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// ============================================================================
+// 测试数据
+// ============================================================================
+
+const (
+	sceneCreate core.Scene = 1 << iota
+	sceneUpdate
+)
+
+// stubRuleSource 可替换返回值的规则源（测试用）
+type stubRuleSource struct {
+	mu      sync.Mutex
+	ruleSet *core.RuleSet
+	err     error
+	loads   int
+}
+
+func (s *stubRuleSource) set(ruleSet *core.RuleSet, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ruleSet, s.err = ruleSet, err
+}
+
+func (s *stubRuleSource) Load(context.Context) (*core.RuleSet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loads++
+	return s.ruleSet, s.err
+}
+
+// userRules 创建只包含 User 模型的规则集
+func userRules(version, usernameRule string) *core.RuleSet {
+	return &core.RuleSet{
+		Version: version,
+		Models: map[string]map[core.Scene]map[string]string{
+			"User": {
+				sceneCreate: {"username": usernameRule},
+				sceneUpdate: {"email": "omitempty,email"},
+			},
+		},
+	}
+}
+
+// ============================================================================
+// 规则管理器
+// ============================================================================
+
+// TestRuleManager_Reload 测试加载、版本号不变时不替换以及失败时保留上一次的规则
+func TestRuleManager_Reload(t *testing.T) {
+	source := &stubRuleSource{}
+	manager := NewRuleManager(source, nil)
+
+	if _, _, ok := manager.Rules("User", sceneCreate); ok {
+		t.Fatal("加载前不应返回规则")
+	}
+
+	source.set(userRules("v1", "required,min=3"), nil)
+	if err := manager.Reload(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	rules, version, ok := manager.Rules("User", sceneCreate)
+	if !ok || version != "v1" || rules["username"] != "required,min=3" {
+		t.Fatalf("Rules() = %v, %q, %t", rules, version, ok)
+	}
+
+	// 场景组合合并多个场景的规则
+	rules, _, _ = manager.Rules("User", sceneCreate|sceneUpdate)
+	if want := map[string]string{"username": "required,min=3", "email": "omitempty,email"}; !reflect.DeepEqual(rules, want) {
+		t.Errorf("Rules(create|update) = %v, want %v", rules, want)
+	}
+	if _, _, ok := manager.Rules("Order", sceneCreate); ok {
+		t.Error("未配置的模型不应返回规则")
+	}
+
+	// 版本号不变时保留原快照（内容不同也不替换）
+	source.set(userRules("v1", "required,min=5"), nil)
+	if err := manager.Reload(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if rules, _, _ := manager.Rules("User", sceneCreate); rules["username"] != "required,min=3" {
+		t.Errorf("相同版本号不应替换规则: %v", rules)
+	}
+
+	// 加载失败时保留上一次成功的规则
+	loadErr := stderrors.New("etcd unavailable")
+	source.set(nil, loadErr)
+	if err := manager.Reload(context.Background()); !stderrors.Is(err, loadErr) {
+		t.Fatalf("Reload() = %v, want %v", err, loadErr)
+	}
+	if !stderrors.Is(manager.LastError(), loadErr) || manager.Version() != "v1" {
+		t.Errorf("LastError() = %v, Version() = %q", manager.LastError(), manager.Version())
+	}
+
+	// 新版本生效，合并结果缓存随快照失效
+	source.set(userRules("v2", "required,min=5"), nil)
+	if err := manager.Reload(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	rules, version, _ = manager.Rules("User", sceneCreate)
+	if version != "v2" || rules["username"] != "required,min=5" || manager.LastError() != nil {
+		t.Errorf("Rules() = %v, %q, LastError() = %v", rules, version, manager.LastError())
+	}
+}
+
+// TestRuleManager_Poll 测试定时轮询热更新与停止
+func TestRuleManager_Poll(t *testing.T) {
+	source := &stubRuleSource{ruleSet: userRules("v1", "required")}
+	manager := NewRuleManager(source, nil)
+	manager.Start(5 * time.Millisecond)
+	defer manager.Stop()
+
+	waitVersion(t, manager, "v1")
+	source.set(userRules("v2", "required,min=3"), nil)
+	waitVersion(t, manager, "v2")
+
+	manager.Stop()
+	source.set(userRules("v3", "required,min=4"), nil)
+	time.Sleep(30 * time.Millisecond)
+	if manager.Version() != "v2" {
+		t.Errorf("停止轮询后 Version() = %q, want v2", manager.Version())
+	}
+}
+
+// TestFileRuleSource 测试文件规则源（场景键为十进制值）
+func TestFileRuleSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(`{"version":"v1","models":{"User":{"1":{"username":"required"}}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ruleSet, err := NewFileRuleSource(path).Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ruleSet.Version != "v1" || ruleSet.Models["User"][sceneCreate]["username"] != "required" {
+		t.Errorf("Load() = %+v", ruleSet)
+	}
+
+	if _, err := NewFileRuleSource(filepath.Join(t.TempDir(), "missing.json")).Load(context.Background()); err == nil {
+		t.Error("文件不存在时应返回错误")
+	}
+}
+
+// waitVersion 等待规则管理器加载到指定版本
+func waitVersion(t *testing.T, manager *RuleManager, version string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for manager.Version() != version {
+		if time.Now().After(deadline) {
+			t.Fatalf("Version() = %q, want %q", manager.Version(), version)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
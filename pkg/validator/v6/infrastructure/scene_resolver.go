@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"katydid-common-account/pkg/validator/v6/core"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ============================================================================
+// ID 场景解析器
+// ============================================================================
+
+// idSceneResolver 按 ID 字段是否为零值解析场景
+// 说明：ID 为零值视为创建，否则视为更新；字段位置按类型缓存
+type idSceneResolver struct {
+	create core.Scene
+	update core.Scene
+	field  string
+	// 类型 -> 字段索引（nil 表示没有 ID 字段）
+	indexes sync.Map
+}
+
+// NewIDSceneResolver 创建 ID 场景解析器
+// 参数：
+//   - create：ID 为零值时的场景
+//   - update：ID 不为零值时的场景
+//   - field：ID 字段名，可以是 Go 字段名或 json 名，为空时使用 "ID"
+//
+// 说明：对象没有 ID 字段时返回 SceneNone（验证器会报告 scene 错误）
+func NewIDSceneResolver(create, update core.Scene, field string) core.ISceneResolver {
+	if field == "" {
+		field = "ID"
+	}
+	return &idSceneResolver{create: create, update: update, field: field}
+}
+
+// ResolveScene 实现 ISceneResolver 接口
+func (r *idSceneResolver) ResolveScene(obj any) core.Scene {
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return core.SceneNone
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return core.SceneNone
+	}
+
+	index := r.fieldIndex(v.Type())
+	if index == nil {
+		return core.SceneNone
+	}
+
+	id, err := v.FieldByIndexErr(index)
+	if err != nil || id.IsZero() {
+		return r.create
+	}
+	return r.update
+}
+
+// fieldIndex 查找 ID 字段的位置（支持匿名嵌入的结构体）
+func (r *idSceneResolver) fieldIndex(typ reflect.Type) []int {
+	if cached, ok := r.indexes.Load(typ); ok {
+		return cached.([]int)
+	}
+
+	var index []int
+	if field, ok := typ.FieldByName(r.field); ok {
+		index = field.Index
+	} else if field, ok := typ.FieldByNameFunc(func(name string) bool {
+		f, _ := typ.FieldByName(name)
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		return tag == r.field
+	}); ok {
+		index = field.Index
+	}
+
+	r.indexes.Store(typ, index)
+	return index
+}
@@ -3,6 +3,10 @@ package infrastructure
 import (
 	"katydid-common-account/pkg/validator/v6/core"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unsafe"
 )
 
 // typeInspector 类型检查器实现
@@ -23,14 +27,20 @@ func NewTypeInspector(cache core.ICacheManager) core.ITypeInspector {
 
 // Inspect 检查类型信息
 func (i *typeInspector) Inspect(target any) core.ITypeInfo {
+	info, _ := i.InspectWithCache(target)
+	return info
+}
+
+// InspectWithCache 实现 ICacheAwareInspector 接口
+func (i *typeInspector) InspectWithCache(target any) (core.ITypeInfo, bool) {
 	if target == nil {
-		return nil
+		return nil, false
 	}
 
 	// 获取类型
 	typ := reflect.TypeOf(target)
 	if typ == nil {
-		return nil
+		return nil, false
 	}
 
 	// 处理指针类型
@@ -40,12 +50,12 @@ func (i *typeInspector) Inspect(target any) core.ITypeInfo {
 
 	// 只处理结构体
 	if typ.Kind() != reflect.Struct {
-		return nil
+		return nil, false
 	}
 
 	// 尝试从缓存获取
 	if info, ok := i.cache.Get(typ); ok {
-		return info.(core.ITypeInfo)
+		return info.(core.ITypeInfo), true
 	}
 
 	// 创建类型信息
@@ -54,7 +64,7 @@ func (i *typeInspector) Inspect(target any) core.ITypeInfo {
 	// 存入缓存
 	i.cache.Set(typ, info)
 
-	return info
+	return info, false
 }
 
 // buildTypeInfo 构建类型信息
@@ -62,6 +72,7 @@ func (i *typeInspector) buildTypeInfo(target any, typ reflect.Type) core.ITypeIn
 	info := &typeInfo{
 		typeName:  typ.Name(),
 		accessors: make(map[string]core.FieldAccessor),
+		sensitive: make(map[string]bool),
 	}
 
 	// 检查接口实现（懒加载）
@@ -69,15 +80,7 @@ func (i *typeInspector) buildTypeInfo(target any, typ reflect.Type) core.ITypeIn
 	info.isBusinessValidator = i.implementsBusinessValidator(target)
 	info.isLifecycleHooks = i.implementsLifecycleHooks(target)
 
-	// 如果实现了 IRuleValidator，获取规则
-	if info.isRuleProvider {
-		if _, ok := target.(core.IRuleValidator); ok {
-			// 缓存所有场景的规则
-			info.rulesCache = make(map[core.Scene]map[string]string)
-			// 这里只是占位，实际规则在调用 ValidateRules 时获取
-			// TODO:GG 怎么个事?
-		}
-	}
+	// 规则在首次验证对应场景时由规则策略编译并缓存（见 CompiledRules）
 
 	// 如果实现了 IBusinessValidator，注册结构体
 	if info.isBusinessValidator {
@@ -119,7 +122,12 @@ func (i *typeInspector) buildFieldAccessors(typ reflect.Type, info *typeInfo) {
 
 		// 创建访问器（闭包捕获索引）
 		fieldIndex := idx
+		view := newFieldView(typ, field)
 		accessor := func(value any) (any, bool) {
+			if fieldValue, ok := view.read(value); ok {
+				return fieldValue, true
+			}
+
 			v := reflect.ValueOf(value)
 			if v.Kind() == reflect.Ptr {
 				if v.IsNil() {
@@ -149,6 +157,108 @@ func (i *typeInspector) buildFieldAccessors(typ reflect.Type, info *typeInfo) {
 		if jsonTag != "" && jsonTag != field.Name {
 			info.accessors[jsonTag] = accessor
 		}
+
+		// 记录敏感字段
+		if field.Tag.Get("sensitive") == "true" {
+			info.sensitive[field.Name] = true
+			if jsonTag != "" {
+				info.sensitive[jsonTag] = true
+			}
+		}
+
+		// 嵌入结构体的敏感字段按路径记录（规则策略合并的嵌入规则键如 BaseModel.password）
+		if field.Anonymous {
+			markEmbeddedSensitive(field.Name, field.Type, info, 0)
+		}
+	}
+}
+
+// maxEmbeddedDepth 记录嵌入结构体敏感字段的最大深度（防止自引用类型无限递归）
+const maxEmbeddedDepth = 8
+
+// markEmbeddedSensitive 记录嵌入结构体中的敏感字段（字段名和 JSON tag 两种路径）
+func markEmbeddedSensitive(prefix string, typ reflect.Type, info *typeInfo, depth int) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || depth >= maxEmbeddedDepth {
+		return
+	}
+
+	for idx := 0; idx < typ.NumField(); idx++ {
+		field := typ.Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous {
+			markEmbeddedSensitive(prefix+"."+field.Name, field.Type, info, depth+1)
+		}
+		if field.Tag.Get("sensitive") != "true" {
+			continue
+		}
+		info.sensitive[prefix+"."+field.Name] = true
+		if jsonTag, _, _ := strings.Cut(field.Tag.Get("json"), ","); jsonTag != "" && jsonTag != "-" {
+			info.sensitive[prefix+"."+jsonTag] = true
+		}
+	}
+}
+
+// ============================================================================
+// 零拷贝字段读取
+// ============================================================================
+
+// eface 空接口的内存布局
+type eface struct {
+	typ  unsafe.Pointer
+	data unsafe.Pointer
+}
+
+// fieldView 零拷贝字段读取
+// 说明：
+//   - reflect 读取可寻址的字段（目标为结构体指针时）会复制值并分配内存，字符串、数字字段每次读取都会分配
+//   - 字符串、布尔、数字类型的接口数据字本身就是指向值的指针，直接指向结构体中的字段即可得到等价的接口值
+//   - 返回的值引用字段内存，只在验证期间使用；需要保留时（如保存到错误中）应复制，见 errors.WithValue
+type fieldView struct {
+	structType reflect.Type
+	offset     uintptr
+	typ        unsafe.Pointer // 字段类型的接口类型字，nil 表示不支持零拷贝
+}
+
+// newFieldView 创建字段的零拷贝读取
+func newFieldView(structType reflect.Type, field reflect.StructField) fieldView {
+	view := fieldView{structType: structType, offset: field.Offset}
+	if zeroCopyKind(field.Type.Kind()) {
+		zero := reflect.Zero(field.Type).Interface()
+		view.typ = (*eface)(unsafe.Pointer(&zero)).typ
+	}
+	return view
+}
+
+// read 读取字段，只处理结构体指针目标和支持零拷贝的字段类型
+func (f fieldView) read(target any) (any, bool) {
+	if f.typ == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Type().Elem() != f.structType {
+		return nil, false
+	}
+
+	var out any
+	*(*eface)(unsafe.Pointer(&out)) = eface{typ: f.typ, data: unsafe.Add(v.UnsafePointer(), f.offset)}
+	return out, true
+}
+
+// zeroCopyKind 是否支持零拷贝读取（接口数据字为指向值的指针的类型）
+func zeroCopyKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
 	}
 }
 
@@ -158,8 +268,11 @@ func (i *typeInspector) implementsRuleProvider(target any) bool {
 	return ok
 }
 
-// implementsBusinessValidator 检查是否实现了 IBusinessValidator
+// implementsBusinessValidator 检查是否实现了 IBusinessValidator 或 IFieldAwareBusinessValidator
 func (i *typeInspector) implementsBusinessValidator(target any) bool {
+	if _, ok := target.(core.IFieldAwareBusinessValidator); ok {
+		return true
+	}
 	_, ok := target.(core.IBusinessValidator)
 	return ok
 }
@@ -190,8 +303,10 @@ type typeInfo struct {
 	isRuleProvider      bool
 	isBusinessValidator bool
 	isLifecycleHooks    bool
-	rulesCache          map[core.Scene]map[string]string
+	compiled            atomic.Pointer[map[core.Scene]*core.CompiledRules] // 预解析规则（写时复制，读取无锁无分配）
+	compiledMu          sync.Mutex                                         // 串行化规则缓存的写入
 	accessors           map[string]core.FieldAccessor
+	sensitive           map[string]bool // 敏感字段（字段名和 JSON tag）
 }
 
 // IsRuleValidator 实现 ITypeInfo 接口
@@ -209,17 +324,59 @@ func (t *typeInfo) IsLifecycleHooks() bool {
 	return t.isLifecycleHooks
 }
 
-// ValidateRules 实现 ITypeInfo 接口
+// ValidateRules 实现 ITypeInfo 接口，返回已缓存的规则（未缓存时为 nil）
 func (t *typeInfo) ValidateRules(scene core.Scene) map[string]string {
-	// 如果有缓存，直接返回
-	if t.rulesCache != nil {
-		if rules, ok := t.rulesCache[scene]; ok {
-			return rules
+	if cache := t.compiled.Load(); cache != nil {
+		if compiled, ok := (*cache)[scene]; ok {
+			return compiled.Rules
 		}
 	}
 	return nil
 }
 
+// LoadRules 实现 IRuleCache 接口
+func (t *typeInfo) LoadRules(scene core.Scene, generation uint64) (*core.CompiledRules, bool) {
+	cache := t.compiled.Load()
+	if cache == nil {
+		return nil, false
+	}
+	compiled, ok := (*cache)[scene]
+	if !ok || compiled.Generation != generation {
+		return nil, false
+	}
+	return compiled, true
+}
+
+// StoreRules 实现 IRuleCache 接口
+// 说明：并发首次编译时可能写入多次，结果相同，以最后一次为准
+func (t *typeInfo) StoreRules(scene core.Scene, compiled *core.CompiledRules) {
+	t.compiledMu.Lock()
+	defer t.compiledMu.Unlock()
+
+	var cache map[core.Scene]*core.CompiledRules
+	if current := t.compiled.Load(); current != nil {
+		cache = make(map[core.Scene]*core.CompiledRules, len(*current)+1)
+		for s, c := range *current {
+			cache[s] = c
+		}
+	} else {
+		cache = make(map[core.Scene]*core.CompiledRules, 1)
+	}
+	cache[scene] = compiled
+	t.compiled.Store(&cache)
+}
+
+// CachedRules 实现 IRuleCache 接口（返回副本）
+func (t *typeInfo) CachedRules() map[core.Scene]*core.CompiledRules {
+	result := make(map[core.Scene]*core.CompiledRules)
+	if current := t.compiled.Load(); current != nil {
+		for scene, compiled := range *current {
+			result[scene] = compiled
+		}
+	}
+	return result
+}
+
 // FieldAccessor 实现 ITypeInfo 接口
 func (t *typeInfo) FieldAccessor(fieldName string) core.FieldAccessor {
 	return t.accessors[fieldName]
@@ -229,3 +386,8 @@ func (t *typeInfo) FieldAccessor(fieldName string) core.FieldAccessor {
 func (t *typeInfo) TypeName() string {
 	return t.typeName
 }
+
+// IsSensitive 实现 ITypeInfo 接口
+func (t *typeInfo) IsSensitive(fieldName string) bool {
+	return t.sensitive[fieldName]
+}
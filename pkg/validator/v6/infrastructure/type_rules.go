@@ -0,0 +1,121 @@
+package infrastructure
+
+import (
+	"katydid-common-account/pkg/types"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ============================================================================
+// pkg/types 类型的验证规则
+// ============================================================================
+
+// registerTypeRules 注册 pkg/types 中值类型的验证规则
+// 说明：依赖库不会对结构体类型执行自定义规则，因此先通过 CustomTypeFunc 转换为基础类型
+func registerTypeRules(v *validator.Validate) {
+	// Money -> 十进制金额字符串；没有币种的零值视为未填写（required 失败）
+	v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		m, ok := field.Interface().(types.Money)
+		if !ok || (m.Currency() == "" && m.IsZero()) {
+			return ""
+		}
+		return m.Amount()
+	}, types.Money{})
+
+	_ = v.RegisterValidation("money_gt", moneyCompare(func(c int) bool { return c > 0 }))
+	_ = v.RegisterValidation("money_gte", moneyCompare(func(c int) bool { return c >= 0 }))
+	_ = v.RegisterValidation("money_lt", moneyCompare(func(c int) bool { return c < 0 }))
+	_ = v.RegisterValidation("money_lte", moneyCompare(func(c int) bool { return c <= 0 }))
+
+	// 手机号：phone=CN 表示中国大陆手机号，不带参数时接受任意地区
+	_ = v.RegisterValidation("phone", validatePhone)
+
+	// TimeRange -> 时长（End - Start，可能为负）；零值视为未填写
+	v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		r, ok := field.Interface().(types.TimeRange)
+		if !ok || r.IsZero() {
+			return nil
+		}
+		return r.End.Sub(r.Start)
+	}, types.TimeRange{})
+
+	// 时间范围：timerange 要求开始早于结束，timerange=720h 同时限制最大时长
+	_ = v.RegisterValidation("timerange", validateTimeRange)
+
+	// SortSpec -> 排序文本（如 -created_at,name）；未指定排序为空字符串
+	v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		s, ok := field.Interface().(types.SortSpec)
+		if !ok {
+			return ""
+		}
+		return s.String()
+	}, types.SortSpec{})
+
+	// 排序白名单：sortable=name created_at，适用于 string 和 types.SortSpec
+	_ = v.RegisterValidation("sortable", validateSortable)
+
+	// 枚举：enum=UserRole 检查值属于通过 types.NewEnum 注册的枚举，适用于字符串和整数类型
+	_ = v.RegisterValidation("enum", validateEnum)
+}
+
+// validateEnum 枚举规则（枚举未注册时验证失败）
+func validateEnum(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		return types.IsEnumValue(fl.Param(), field.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return types.IsEnumValue(fl.Param(), field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return types.IsEnumValue(fl.Param(), field.Uint())
+	default:
+		return false
+	}
+}
+
+// validateSortable 排序白名单规则
+// 说明：白名单以空格或逗号分隔（规则中的逗号需写作 0x2C，规则构建器会自动转义）；空值视为有效，必填时配合 required
+func validateSortable(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	allowed := strings.FieldsFunc(fl.Param(), func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	return types.IsSortable(fl.Field().String(), allowed...)
+}
+
+// validateTimeRange 时间范围规则
+func validateTimeRange(fl validator.FieldLevel) bool {
+	if fl.Field().Type() != reflect.TypeOf(time.Duration(0)) {
+		return false
+	}
+	duration := time.Duration(fl.Field().Int())
+	if duration <= 0 {
+		return false
+	}
+	if fl.Param() == "" {
+		return true
+	}
+	limit, err := time.ParseDuration(fl.Param())
+	return err == nil && duration <= limit
+}
+
+// validatePhone 手机号规则，适用于 string 和 types.Phone
+func validatePhone(fl validator.FieldLevel) bool {
+	return fl.Field().Kind() == reflect.String && types.IsValidPhone(fl.Field().String(), fl.Param())
+}
+
+// moneyCompare 金额比较规则，如 money_gt=0、money_lte=9999.99
+func moneyCompare(accept func(cmp int) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		if fl.Field().Kind() != reflect.String || fl.Field().String() == "" {
+			return false
+		}
+		cmp, err := types.CompareAmount(fl.Field().String(), fl.Param())
+		return err == nil && accept(cmp)
+	}
+}
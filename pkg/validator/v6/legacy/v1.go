@@ -0,0 +1,280 @@
+// Package legacy 旧版验证器模型适配
+//
+// 大量模型仍实现 v1 的 RuleValidator（RuleValidation）和 CustomValidator（CustomValidation）接口。
+// 本包将这些接口映射为 v6 的 IRuleValidator / IBusinessValidator（MapRuleProvider 声明的 Extras 等 map 字段规则
+// 并入业务验证），通过 Builder.WithLegacyAdapter 注册后，
+// 迁移到 v6 引擎时无需逐个修改模型。
+package legacy
+
+import (
+	"katydid-common-account/pkg/validator/errs"
+	v1 "katydid-common-account/pkg/validator/v1"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ============================================================================
+// v1 模型适配器
+// ============================================================================
+
+// SceneMapper 场景映射函数（v6 场景 -> v1 场景）
+type SceneMapper func(scene core.Scene) v1.ValidateScene
+
+// v1Adapter v1 模型适配器
+// 说明：
+//   - v1 的规则按场景位运算（scene & current != 0）合并，与 v1 行为一致
+//   - v1 规则的字段名可以是 Go 字段名或 JSON 名称，统一转换为 Go 字段名
+//   - CustomValidation 的 report(namespace, tag, param) 转换为字段错误，字段名取命名空间最后一段
+//   - MapRuleProvider 的 map 字段按类型定位并缓存，在 CustomValidation 之后自动验证，无需在其中手动调用 ValidateMaps
+type v1Adapter struct {
+	sceneMapper SceneMapper
+	fieldNames  sync.Map // reflect.Type -> map[string]string（JSON 名称 -> Go 字段名）
+	mapFields   sync.Map // reflect.Type -> []v1MapField
+}
+
+// V1Option v1 模型适配器选项
+type V1Option func(*v1Adapter)
+
+// WithSceneMapper 设置场景映射
+// 说明：默认按数值直接转换；v1 和 v6 的场景常量取值不同时需要设置
+func WithSceneMapper(mapper SceneMapper) V1Option {
+	return func(a *v1Adapter) {
+		if mapper != nil {
+			a.sceneMapper = mapper
+		}
+	}
+}
+
+// NewV1Adapter 创建 v1 模型适配器
+func NewV1Adapter(opts ...V1Option) core.ILegacyAdapter {
+	a := &v1Adapter{
+		sceneMapper: func(scene core.Scene) v1.ValidateScene {
+			return v1.ValidateScene(scene)
+		},
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// RuleValidator 实现 ILegacyAdapter 接口
+func (a *v1Adapter) RuleValidator(target any) (core.IRuleValidator, bool) {
+	model, ok := target.(v1.RuleValidator)
+	if !ok {
+		return nil, false
+	}
+	return &v1RuleValidator{adapter: a, model: model, typ: reflect.TypeOf(target)}, true
+}
+
+// BusinessValidator 实现 ILegacyAdapter 接口
+// 说明：模型实现 CustomValidator 或 MapRuleProvider（规则中至少有一个 map 字段）时返回
+func (a *v1Adapter) BusinessValidator(target any) (core.IBusinessValidator, bool) {
+	model, _ := target.(v1.CustomValidator)
+	var mapFields []v1MapField
+	if provider, ok := target.(v1.MapRuleProvider); ok {
+		mapFields = a.mapFieldsOf(reflect.TypeOf(target), provider)
+	}
+	if model == nil && len(mapFields) == 0 {
+		return nil, false
+	}
+	return &v1BusinessValidator{adapter: a, model: model, target: target, mapFields: mapFields}, true
+}
+
+// mapFieldsOf 获取类型的 map 字段验证器（每个类型只调用一次 ProvideMapRules）
+func (a *v1Adapter) mapFieldsOf(typ reflect.Type, provider v1.MapRuleProvider) []v1MapField {
+	if cached, ok := a.mapFields.Load(typ); ok {
+		return cached.([]v1MapField)
+	}
+	cached, _ := a.mapFields.LoadOrStore(typ, a.buildMapFields(typ, provider.ProvideMapRules()))
+	return cached.([]v1MapField)
+}
+
+// buildMapFields 将 MapRuleProvider 的规则按字段分组并构建验证器
+// 说明：规则中的字段名可以是 Go 字段名或 JSON 名称；字段不存在或不是 map 时忽略；
+// 命名空间为 类型名.字段的 JSON 名称（如 Product.extras），与规则策略的错误一致
+func (a *v1Adapter) buildMapFields(typ reflect.Type, rules map[v1.ValidateScene]map[string]v1.MapSchema) []v1MapField {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if len(rules) == 0 || typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	byField := make(map[string]map[v1.ValidateScene]v1.MapSchema)
+	for scene, fields := range rules {
+		for name, schema := range fields {
+			field, ok := typ.FieldByName(a.goFieldName(typ, name))
+			if !ok || !field.IsExported() || !field.Type.ConvertibleTo(mapStringAnyType) {
+				continue
+			}
+			if byField[field.Name] == nil {
+				byField[field.Name] = make(map[v1.ValidateScene]v1.MapSchema)
+			}
+			byField[field.Name][scene] = schema
+		}
+	}
+
+	fields := make([]v1MapField, 0, len(byField))
+	for goName, sceneRules := range byField {
+		field, _ := typ.FieldByName(goName)
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			name = goName
+		}
+
+		validators := v1.NewMapValidatorsFromRules(sceneRules)
+		for _, mv := range validators.Validators {
+			mv.WithNameSpace(typ.Name() + "." + name)
+		}
+		fields = append(fields, v1MapField{index: field.Index, validators: validators})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return lessIndex(fields[i].index, fields[j].index)
+	})
+	return fields
+}
+
+// lessIndex 字段索引路径的声明顺序
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// goFieldName 将 v1 规则中的字段名转换为 Go 字段名
+func (a *v1Adapter) goFieldName(typ reflect.Type, name string) string {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return name
+	}
+	if _, ok := typ.FieldByName(name); ok {
+		return name
+	}
+
+	cached, ok := a.fieldNames.Load(typ)
+	if !ok {
+		cached, _ = a.fieldNames.LoadOrStore(typ, jsonFieldNames(typ))
+	}
+	if goName, ok := cached.(map[string]string)[name]; ok {
+		return goName
+	}
+	return name
+}
+
+// jsonFieldNames 构建 JSON 名称到 Go 字段名的映射
+func jsonFieldNames(typ reflect.Type) map[string]string {
+	names := make(map[string]string, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name != "" && name != "-" {
+			names[name] = field.Name
+		}
+	}
+	return names
+}
+
+// ============================================================================
+// 接口映射
+// ============================================================================
+
+// v1RuleValidator RuleValidation -> ValidateRules
+type v1RuleValidator struct {
+	adapter *v1Adapter
+	model   v1.RuleValidator
+	typ     reflect.Type
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+// 说明：合并所有与当前场景有交集的场景规则
+func (r *v1RuleValidator) ValidateRules(scene core.Scene) map[string]string {
+	current := r.adapter.sceneMapper(scene)
+
+	var merged map[string]string
+	for ruleScene, rules := range r.model.RuleValidation() {
+		if ruleScene&current == 0 {
+			continue
+		}
+		if merged == nil {
+			merged = make(map[string]string, len(rules))
+		}
+		for field, rule := range rules {
+			merged[r.adapter.goFieldName(r.typ, field)] = rule
+		}
+	}
+	return merged
+}
+
+// v1MapField MapRuleProvider 声明的 map 字段
+type v1MapField struct {
+	index      []int             // 字段索引路径（含嵌入结构体中的提升字段）
+	validators *v1.MapValidators // 场景化的验证器
+}
+
+// mapStringAnyType map[string]any 的反射类型（types.Extras 等命名 map 类型可转换为该类型）
+var mapStringAnyType = reflect.TypeOf(map[string]any(nil))
+
+// v1BusinessValidator CustomValidation + ProvideMapRules -> ValidateBusiness
+type v1BusinessValidator struct {
+	adapter   *v1Adapter
+	model     v1.CustomValidator // 未实现 CustomValidator 时为 nil
+	target    any
+	mapFields []v1MapField
+}
+
+// ValidateBusiness 实现 IBusinessValidator 接口
+func (b *v1BusinessValidator) ValidateBusiness(scene core.Scene, collector core.IErrorCollector) {
+	current := b.adapter.sceneMapper(scene)
+	if b.model != nil {
+		b.model.CustomValidation(current, func(namespace, tag, param string) {
+			collector.Collect(errors.NewFieldError(namespace, errs.FieldName(namespace), tag, errors.WithParam(param)))
+		})
+	}
+	if len(b.mapFields) > 0 {
+		b.validateMapFields(current, collector)
+	}
+}
+
+// validateMapFields 验证 MapRuleProvider 声明的 map 字段（nil 指针嵌入中的字段跳过）
+func (b *v1BusinessValidator) validateMapFields(scene v1.ValidateScene, collector core.IErrorCollector) {
+	v := reflect.ValueOf(b.target)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	for _, field := range b.mapFields {
+		value, err := v.FieldByIndexErr(field.index)
+		if err != nil {
+			continue
+		}
+		kvs, _ := value.Convert(mapStringAnyType).Interface().(map[string]any)
+		for _, fe := range field.validators.Validate(scene, kvs) {
+			opts := []errors.FieldErrorOption{errors.WithParam(fe.Param)}
+			if fe.Message != "" {
+				opts = append(opts, errors.WithMessage(fe.Message))
+			}
+			if fe.Value != nil {
+				opts = append(opts, errors.WithValue(fe.Value))
+			}
+			if !collector.Collect(errors.NewFieldError(fe.Namespace, errs.FieldName(fe.Namespace), fe.Tag, opts...)) {
+				return
+			}
+		}
+	}
+}
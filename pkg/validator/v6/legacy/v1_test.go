@@ -0,0 +1,342 @@
+package legacy
+
+import (
+	"reflect"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	v1 "katydid-common-account/pkg/validator/v1"
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// ============================================================================
+// 测试模型定义（与 v1 包的 TestUser 相同）
+// ============================================================================
+
+// v1 场景常量
+const (
+	SceneCreate v1.ValidateScene = 1 << 0 // 创建场景 (1)
+	SceneUpdate v1.ValidateScene = 1 << 1 // 更新场景 (2)
+)
+
+// TestUser 测试用户模型
+type TestUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Age      int    `json:"age"`
+}
+
+// RuleValidation 实现 v1.RuleValidator 接口
+func (u *TestUser) RuleValidation() map[v1.ValidateScene]map[string]string {
+	return map[v1.ValidateScene]map[string]string{
+		SceneCreate: {
+			"Username": "required,min=3,max=20,alphanum",
+			"Email":    "required,email",
+			"Password": "required,min=6,max=20",
+			"Age":      "omitempty,gte=0,lte=150",
+		},
+		SceneUpdate: {
+			"Username": "omitempty,min=3,max=20,alphanum",
+			"Email":    "omitempty,email",
+			"Password": "omitempty,min=6,max=20",
+			"Age":      "omitempty,gte=0,lte=150",
+		},
+	}
+}
+
+// CustomValidation 实现 v1.CustomValidator 接口
+func (u *TestUser) CustomValidation(scene v1.ValidateScene, reportError v1.FuncReportError) {
+	if scene&SceneCreate != 0 && u.Username == "admin" {
+		reportError("TestUser.Username", "reserved", "admin")
+	}
+}
+
+// JSONUser 规则使用 JSON 名称的模型
+type JSONUser struct {
+	UserName string `json:"user_name"`
+	Nick     string `json:"nick,omitempty"`
+	Ignored  string `json:"-"`
+	internal string
+}
+
+// RuleValidation 实现 v1.RuleValidator 接口
+func (u *JSONUser) RuleValidation() map[v1.ValidateScene]map[string]string {
+	return map[v1.ValidateScene]map[string]string{
+		SceneCreate: {"user_name": "required,min=3", "nick": "max=5"},
+	}
+}
+
+// ProductMeta 嵌入的 map 字段
+type ProductMeta struct {
+	Extras map[string]any `json:"extras"`
+}
+
+// Product 通过嵌入结构体声明 map 字段的模型
+type Product struct {
+	*ProductMeta
+	Name  string         `json:"name"`
+	Specs map[string]any `json:"specs"`
+
+	provided *atomic.Int32 // ProvideMapRules 调用次数
+}
+
+// ProvideMapRules 实现 v1.MapRuleProvider 接口
+func (p *Product) ProvideMapRules() map[v1.ValidateScene]map[string]v1.MapSchema {
+	if p.provided != nil {
+		p.provided.Add(1)
+	}
+	return map[v1.ValidateScene]map[string]v1.MapSchema{
+		SceneCreate: {
+			"Extras":  {RequiredKeys: []string{"brand"}, KeyRules: map[string]string{"brand": "min=2,max=10"}},
+			"Name":    {RequiredKeys: []string{"ignored"}}, // 不是 map，忽略
+			"Missing": {RequiredKeys: []string{"ignored"}}, // 字段不存在，忽略
+		},
+		SceneUpdate: {
+			"Extras": {KeyRules: map[string]string{"brand": "omitempty,max=10"}},
+			"specs":  {KeyRules: map[string]string{"color": "omitempty,max=5"}},
+		},
+	}
+}
+
+// newV6 创建使用 v1 适配器的 v6 验证器
+func newV6(opts ...V1Option) core.IValidator {
+	return v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithBusinessStrategy(20).
+		WithLegacyAdapter(NewV1Adapter(opts...)).
+		Build()
+}
+
+// v1Signatures v1 错误的 "标签=参数" 列表（排序）
+// 说明：v1 按字段规则验证时命名空间为空，只能比较标签和参数
+func v1Signatures(errs []*v1.FieldError) []string {
+	var got []string
+	for _, fe := range errs {
+		got = append(got, fe.Tag+"="+fe.Param)
+	}
+	sort.Strings(got)
+	return got
+}
+
+// v6Signatures v6 错误的 "标签=参数" 列表（排序）
+func v6Signatures(err core.IValidationError) []string {
+	if err == nil {
+		return nil
+	}
+	var got []string
+	for _, fe := range err.FieldErrors() {
+		got = append(got, fe.Tag()+"="+fe.Param())
+	}
+	sort.Strings(got)
+	return got
+}
+
+// ============================================================================
+// 与 v1 结果对比
+// ============================================================================
+
+// TestV1Adapter_MatchesV1 测试 v1 模型经适配后在 v6 中的错误与 v1 一致
+func TestV1Adapter_MatchesV1(t *testing.T) {
+	tests := []struct {
+		name  string
+		user  *TestUser
+		scene v1.ValidateScene
+	}{
+		{"有效的创建数据", &TestUser{Username: "testuser", Email: "test@example.com", Password: "password123", Age: 25}, SceneCreate},
+		{"缺少必填字段", &TestUser{Email: "test@example.com"}, SceneCreate},
+		{"无效的邮箱和短密码", &TestUser{Username: "testuser", Email: "invalid-email", Password: "123"}, SceneCreate},
+		{"非字母数字的用户名和超出范围的年龄", &TestUser{Username: "test_user", Email: "a@b.co", Password: "password123", Age: 200}, SceneCreate},
+		{"保留的用户名", &TestUser{Username: "admin", Email: "a@b.co", Password: "password123"}, SceneCreate},
+		{"更新场景部分字段", &TestUser{Email: "bad"}, SceneUpdate},
+		{"更新场景空数据", &TestUser{}, SceneUpdate},
+	}
+
+	validator := newV6()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := v1Signatures(v1.Validate(tt.user, tt.scene))
+			got := v6Signatures(validator.Validate(tt.user, core.Scene(tt.scene)))
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("v6 = %v, v1 = %v", got, want)
+			}
+		})
+	}
+}
+
+// TestV1Adapter_FieldNames 测试错误的字段名和自定义错误的命名空间
+func TestV1Adapter_FieldNames(t *testing.T) {
+	err := newV6().Validate(&TestUser{Username: "admin", Email: "bad", Password: "password123"}, core.Scene(SceneCreate))
+	if err == nil {
+		t.Fatal("Validate() = nil, want errors")
+	}
+
+	got := make(map[string]string)
+	for _, fe := range err.FieldErrors() {
+		got[fe.Tag()] = fe.Namespace() + "|" + fe.Field()
+	}
+	want := map[string]string{
+		"email":    "TestUser.Email|Email",
+		"reserved": "TestUser.Username|Username",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("errors = %v, want %v", got, want)
+	}
+}
+
+// ============================================================================
+// 场景映射
+// ============================================================================
+
+// TestV1Adapter_SceneMapper 测试 v6 场景映射为 v1 场景
+func TestV1Adapter_SceneMapper(t *testing.T) {
+	const v6Create core.Scene = 1 << 4
+	user := &TestUser{Email: "bad"}
+
+	// 默认按数值转换：v6 场景 16 不匹配任何 v1 规则
+	if err := newV6().Validate(user, v6Create); err != nil {
+		t.Errorf("默认映射 Validate() = %v, want nil", v6Signatures(err))
+	}
+
+	mapped := newV6(WithSceneMapper(func(scene core.Scene) v1.ValidateScene {
+		if scene&v6Create != 0 {
+			return SceneCreate
+		}
+		return v1.SceneNone
+	}))
+	want := v1Signatures(v1.Validate(user, SceneCreate))
+	if got := v6Signatures(mapped.Validate(user, v6Create)); !reflect.DeepEqual(got, want) {
+		t.Errorf("映射后 v6 = %v, v1 = %v", got, want)
+	}
+
+	// nil 映射函数保留默认映射
+	if a := NewV1Adapter(WithSceneMapper(nil)).(*v1Adapter); a.sceneMapper(core.Scene(SceneUpdate)) != SceneUpdate {
+		t.Error("WithSceneMapper(nil) 应保留默认映射")
+	}
+}
+
+// ============================================================================
+// 字段名转换
+// ============================================================================
+
+// TestJSONFieldNames 测试 JSON 名称到 Go 字段名的映射
+func TestJSONFieldNames(t *testing.T) {
+	got := jsonFieldNames(reflect.TypeOf(JSONUser{}))
+	want := map[string]string{"user_name": "UserName", "nick": "Nick"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("jsonFieldNames() = %v, want %v", got, want)
+	}
+}
+
+// TestGoFieldName 测试 v1 规则字段名转换为 Go 字段名
+func TestGoFieldName(t *testing.T) {
+	a := NewV1Adapter().(*v1Adapter)
+	typ := reflect.TypeOf(&JSONUser{})
+
+	tests := []struct {
+		name string
+		typ  reflect.Type
+		in   string
+		want string
+	}{
+		{"Go 字段名", typ, "UserName", "UserName"},
+		{"JSON 名称", typ, "user_name", "UserName"},
+		{"带选项的 JSON 名称", typ, "nick", "Nick"},
+		{"未知名称保持原样", typ, "unknown", "unknown"},
+		{"非结构体保持原样", reflect.TypeOf(""), "user_name", "user_name"},
+		{"nil 类型保持原样", nil, "user_name", "user_name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.goFieldName(tt.typ, tt.in); got != tt.want {
+				t.Errorf("goFieldName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	// JSON 名称规则在 v6 中定位到对应字段
+	err := newV6().Validate(&JSONUser{UserName: "ab", Nick: "toolong"}, core.Scene(SceneCreate))
+	if got := v6Signatures(err); !reflect.DeepEqual(got, []string{"max=5", "min=3"}) {
+		t.Errorf("Validate() = %v", got)
+	}
+}
+
+// ============================================================================
+// map 字段
+// ============================================================================
+
+// TestV1Adapter_MapFields 测试 MapRuleProvider 声明的 map 字段的定位和验证
+func TestV1Adapter_MapFields(t *testing.T) {
+	var provided atomic.Int32
+	product := func(extras map[string]any) *Product {
+		return &Product{ProductMeta: &ProductMeta{Extras: extras}, Name: "p", provided: &provided}
+	}
+
+	// 嵌入结构体中的提升字段按 Go 字段名定位，顶层字段可用 JSON 名称；非 map 和不存在的字段忽略
+	a := NewV1Adapter().(*v1Adapter)
+	fields := a.mapFieldsOf(reflect.TypeOf(product(nil)), product(nil))
+	if len(fields) != 2 || !reflect.DeepEqual(fields[0].index, []int{0, 0}) || !reflect.DeepEqual(fields[1].index, []int{2}) {
+		t.Fatalf("mapFieldsOf() = %+v, want Extras and Specs", fields)
+	}
+
+	tests := []struct {
+		name    string
+		product *Product
+		scene   v1.ValidateScene
+		want    []string // 命名空间|标签
+	}{
+		{"通过", product(map[string]any{"brand": "acme"}), SceneCreate, nil},
+		{"缺少必填键", product(map[string]any{}), SceneCreate, []string{"Product.extras.brand|required"}},
+		{"键规则失败", product(map[string]any{"brand": "a"}), SceneCreate, []string{"Product.extras.brand|min"}},
+		{"更新场景", product(map[string]any{"brand": "a-very-long-brand"}), SceneUpdate, []string{"Product.extras.brand|max"}},
+		{"JSON 名称定位", &Product{ProductMeta: &ProductMeta{}, Specs: map[string]any{"color": "crimson"}, provided: &provided}, SceneUpdate, []string{"Product.specs.color|max"}},
+		{"nil 指针嵌入跳过", &Product{Name: "p", provided: &provided}, SceneCreate, nil},
+	}
+
+	validator := newV6()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			if err := validator.Validate(tt.product, core.Scene(tt.scene)); err != nil {
+				for _, fe := range err.FieldErrors() {
+					got = append(got, fe.Namespace()+"|"+fe.Tag())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+			if tt.product.ProductMeta == nil {
+				return
+			}
+			// 与 v1 对比（v1 的调用不计入 ProvideMapRules 次数）
+			legacyProduct := *tt.product
+			legacyProduct.provided = nil
+			var want []string
+			for _, fe := range v1.Validate(&legacyProduct, tt.scene) {
+				want = append(want, fe.Namespace+"|"+fe.Tag)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("v6 = %v, v1 = %v", got, want)
+			}
+		})
+	}
+
+	// 每个类型只调用一次 ProvideMapRules
+	if n := provided.Load(); n != 2 {
+		t.Errorf("ProvideMapRules 调用 %d 次, want 2（每个适配器一次）", n)
+	}
+}
+
+// TestV1Adapter_NotLegacy 测试非 v1 模型不被适配
+func TestV1Adapter_NotLegacy(t *testing.T) {
+	a := NewV1Adapter()
+	if _, ok := a.RuleValidator(struct{}{}); ok {
+		t.Error("RuleValidator() 不应适配非 v1 模型")
+	}
+	if _, ok := a.BusinessValidator(&JSONUser{}); ok {
+		t.Error("BusinessValidator() 不应适配只实现 RuleValidator 的模型")
+	}
+}
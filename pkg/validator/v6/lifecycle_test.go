@@ -0,0 +1,107 @@
+package v6_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// hookedAccount 实现生命周期钩子的模型，记录钩子调用顺序
+type hookedAccount struct {
+	Username string `json:"username"`
+
+	beforeErr error
+	afterErr  error
+	calls     []string
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (a *hookedAccount) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{"username": "required,min=3"}
+}
+
+// ValidateBusiness 实现 IBusinessValidator 接口
+func (a *hookedAccount) ValidateBusiness(scene core.Scene, collector core.IErrorCollector) {
+	a.calls = append(a.calls, "business")
+}
+
+// BeforeValidation 实现 ILifecycleHooks 接口，预处理用户名
+func (a *hookedAccount) BeforeValidation(ctx core.IContext) error {
+	a.calls = append(a.calls, "before")
+	a.Username = strings.TrimSpace(a.Username)
+	return a.beforeErr
+}
+
+// AfterValidation 实现 ILifecycleHooks 接口
+func (a *hookedAccount) AfterValidation(ctx core.IContext) error {
+	a.calls = append(a.calls, "after")
+	return a.afterErr
+}
+
+// TestLifecycleHooks 测试钩子在策略前后执行，钩子错误作为验证错误返回
+func TestLifecycleHooks(t *testing.T) {
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithBusinessStrategy(20).
+		Build()
+
+	tests := []struct {
+		name      string
+		account   *hookedAccount
+		wantCalls []string
+		wantTags  []string
+		wantMsg   string
+	}{
+		{"预处理后通过规则", &hookedAccount{Username: "  bob  "},
+			[]string{"before", "business", "after"}, nil, ""},
+		{"策略错误不影响 AfterValidation", &hookedAccount{Username: " x "},
+			[]string{"before", "business", "after"}, []string{"username: min"}, ""},
+		{"BeforeValidation 出错时不执行策略", &hookedAccount{Username: "x", beforeErr: errors.New("locked")},
+			[]string{"before"}, nil, "locked"},
+		{"AfterValidation 出错", &hookedAccount{Username: "alice", afterErr: errors.New("audit failed")},
+			[]string{"before", "business", "after"}, nil, "audit failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.account, SceneCreate)
+			if strings.Join(tt.account.calls, ",") != strings.Join(tt.wantCalls, ",") {
+				t.Errorf("calls = %v, want %v", tt.account.calls, tt.wantCalls)
+			}
+			if tt.wantMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+					t.Errorf("Validate() = %v, want contains %q", err, tt.wantMsg)
+				}
+				return
+			}
+			if got := tags(err); !equalStrings(got, tt.wantTags) {
+				t.Errorf("errors = %v, want %v", got, tt.wantTags)
+			}
+		})
+	}
+}
+
+// TestLifecycleHooks_Interceptor 测试钩子在拦截器内部执行
+func TestLifecycleHooks_Interceptor(t *testing.T) {
+	account := &hookedAccount{Username: "alice"}
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithInterceptor(v6.InterceptorFunc(func(ctx core.IContext, target any, next func() error) error {
+			account.calls = append(account.calls, "intercept")
+			err := next()
+			account.calls = append(account.calls, "intercepted")
+			return err
+		})).
+		Build()
+
+	if err := validator.Validate(account, SceneCreate); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+	want := "intercept,before,after,intercepted"
+	if got := strings.Join(account.calls, ","); got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+package v6_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// batchItem 集合元素（Panic 为 true 时业务验证 panic）
+type batchItem struct {
+	SKU   string `json:"sku"`
+	Qty   int    `json:"qty"`
+	Panic bool   `json:"-"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (i *batchItem) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{"sku": "required", "qty": "gte=1"}
+}
+
+// ValidateBusiness 实现 IBusinessValidator 接口
+func (i *batchItem) ValidateBusiness(scene core.Scene, collector core.IErrorCollector) {
+	if i.Panic {
+		panic("broken item")
+	}
+}
+
+// batchAggregate 包含大集合的聚合
+type batchAggregate struct {
+	Items []any `json:"items"`
+}
+
+// newBatchAggregate 创建 n 个元素的聚合，每 7 个元素有一个缺少 SKU、每 11 个元素有一个数量为 0
+func newBatchAggregate(n int) *batchAggregate {
+	agg := &batchAggregate{Items: make([]any, n)}
+	for i := range agg.Items {
+		item := &batchItem{SKU: fmt.Sprintf("sku-%d", i), Qty: 1}
+		if i%7 == 0 {
+			item.SKU = ""
+		}
+		if i%11 == 0 {
+			item.Qty = 0
+		}
+		agg.Items[i] = item
+	}
+	return agg
+}
+
+// nestedErrors 验证并返回 "命名空间: 标签" 列表
+func nestedErrors(validator v6.Validator, target any) []string {
+	var got []string
+	if err := validator.Validate(target, core.SceneAll); err != nil {
+		for _, fe := range err.FieldErrors() {
+			got = append(got, fe.Namespace()+": "+fe.Tag())
+		}
+	}
+	return got
+}
+
+// TestNestedConcurrency_SameAsSequential 并发验证的输出与串行验证相同
+func TestNestedConcurrency_SameAsSequential(t *testing.T) {
+	for _, maxErrors := range []int{2000, 25} {
+		build := func(workers int) v6.Validator {
+			return v6.NewBuilder().
+				WithRuleStrategy(10).
+				WithNestedStrategy(20).
+				WithMaxErrors(maxErrors).
+				WithNestedConcurrency(workers, 16).
+				Build()
+		}
+		agg := newBatchAggregate(2000)
+
+		want := nestedErrors(build(1), agg)
+		if len(want) == 0 {
+			t.Fatal("sequential validation found no errors")
+		}
+		for run := 0; run < 3; run++ {
+			if got := nestedErrors(build(8), agg); !reflect.DeepEqual(got, want) {
+				t.Fatalf("maxErrors=%d run %d: concurrent errors differ from sequential (%d vs %d)", maxErrors, run, len(got), len(want))
+			}
+		}
+	}
+}
+
+// TestNestedConcurrency_PanicIsolation 元素 panic 不影响其他元素
+func TestNestedConcurrency_PanicIsolation(t *testing.T) {
+	agg := &batchAggregate{Items: []any{
+		&batchItem{SKU: "a", Qty: 1},
+		&batchItem{SKU: "b", Qty: 1, Panic: true},
+		&batchItem{Qty: 1},
+	}}
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithNestedStrategy(20).
+		WithNestedConcurrency(4, 2).
+		Build()
+
+	want := []string{
+		"batchAggregate.items[1]: nested_panic",
+		"batchAggregate.items[2](batchItem).sku: required",
+	}
+	if got := nestedErrors(validator, agg); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,176 @@
+package v6_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// nullableContact 通过指针嵌套的结构体
+type nullableContact struct {
+	Phone *string `json:"phone"`
+}
+
+// nullableProfile 指针、多级指针和 sql.NullX 字段
+type nullableProfile struct {
+	Nick     *string          `json:"nick"`
+	Alias    **string         `json:"alias"`
+	Bio      sql.NullString   `json:"bio"`
+	Age      *sql.NullInt64   `json:"age"`
+	Score    sql.NullFloat64  `json:"score"`
+	Birthday sql.NullTime     `json:"birthday"`
+	Contact  *nullableContact `json:"contact"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (p *nullableProfile) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{
+		"nick":          "required,min=2",
+		"alias":         "min=2",
+		"bio":           "max=5",
+		"age":           "required,gte=18",
+		"score":         "gte=0,lte=100",
+		"birthday":      "required",
+		"contact.phone": "len=11",
+	}
+}
+
+// nullableErrors 验证并返回 "字段: 标签" 列表
+func nullableErrors(t *testing.T, scene core.Scene, profile *nullableProfile) []string {
+	t.Helper()
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithPartialScenes(SceneUpdate).
+		Build()
+
+	var got []string
+	if err := validator.Validate(profile, scene); err != nil {
+		for _, fe := range err.FieldErrors() {
+			got = append(got, fe.Field()+": "+fe.Tag())
+		}
+	}
+	return got
+}
+
+// ptr 取地址
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// TestNullable_Absent nil 指针和 Valid=false 视为未提供：required 失败，其他规则跳过
+func TestNullable_Absent(t *testing.T) {
+	profile := &nullableProfile{
+		Alias:   new(*string), // 外层非 nil，内层 nil
+		Age:     &sql.NullInt64{},
+		Contact: &nullableContact{},
+	}
+
+	want := []string{"nick: required", "age: required", "birthday: required"}
+	if got := nullableErrors(t, SceneCreate, profile); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// TestNullable_Present 提供了值时按解引用后的值验证（零值也验证）
+func TestNullable_Present(t *testing.T) {
+	profile := &nullableProfile{
+		Nick:     ptr("a"),
+		Alias:    ptr(ptr("b")),
+		Bio:      sql.NullString{String: "too long", Valid: true},
+		Age:      &sql.NullInt64{Int64: 0, Valid: true},
+		Score:    sql.NullFloat64{Float64: 120, Valid: true},
+		Birthday: sql.NullTime{Time: time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true},
+		Contact:  &nullableContact{Phone: ptr("123")},
+	}
+
+	want := []string{
+		"nick: min",
+		"alias: min",
+		"bio: max",
+		"age: gte",
+		"score: lte",
+		"phone: len",
+	}
+	if got := nullableErrors(t, SceneCreate, profile); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	valid := &nullableProfile{
+		Nick:     ptr("ab"),
+		Alias:    ptr(ptr("cd")),
+		Bio:      sql.NullString{String: "", Valid: true},
+		Age:      &sql.NullInt64{Int64: 18, Valid: true},
+		Birthday: sql.NullTime{Time: time.Now(), Valid: true},
+		Contact:  &nullableContact{Phone: ptr("13800138000")},
+	}
+	if got := nullableErrors(t, SceneCreate, valid); got != nil {
+		t.Errorf("valid profile: got %v", got)
+	}
+}
+
+// TestNullable_PartialScene 部分更新场景下 nil 指针表示不修改，Valid=false 是显式 null
+func TestNullable_PartialScene(t *testing.T) {
+	profile := &nullableProfile{
+		Age: &sql.NullInt64{},
+	}
+
+	want := []string{"age: required", "birthday: required"}
+	if got := nullableErrors(t, SceneUpdate, profile); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// nullName 实现 driver.Valuer 的自定义 NullX 类型（如数据库驱动提供的 NullTime）
+type nullName struct {
+	Name  string
+	Valid bool
+}
+
+// Value 实现 driver.Valuer 接口
+func (n nullName) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Name, nil
+}
+
+// nullableAccount 使用自定义 NullX 类型的模型
+type nullableAccount struct {
+	Name nullName `json:"name"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (a *nullableAccount) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{"name": "required,min=3"}
+}
+
+// TestNullable_CustomNullType 与 sql.NullX 结构相同且实现 driver.Valuer 的类型按相同语义处理
+func TestNullable_CustomNullType(t *testing.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+
+	tests := []struct {
+		name string
+		in   nullName
+		want string
+	}{
+		{"null", nullName{}, "required"},
+		{"short", nullName{Name: "ab", Valid: true}, "min"},
+		{"valid", nullName{Name: "abc", Valid: true}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			if err := validator.Validate(&nullableAccount{Name: tt.in}, SceneCreate); err != nil {
+				got = err.FieldErrors()[0].Tag()
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
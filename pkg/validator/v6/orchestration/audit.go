@@ -0,0 +1,49 @@
+package orchestration
+
+import (
+	"katydid-common-account/pkg/validator/v6/core"
+	"reflect"
+)
+
+// ============================================================================
+// 审计监听器
+// ============================================================================
+
+// auditListener 将验证结束事件转发给审计接收器
+// 设计模式：适配器模式 - IAuditSink 只关心结果，不需要实现完整的监听器接口
+type auditListener struct {
+	sink core.IAuditSink
+}
+
+// NewAuditListener 创建审计监听器
+// 说明：每次顶层验证结束时调用一次 sink.Record（嵌套验证不单独记录）
+func NewAuditListener(sink core.IAuditSink) core.IValidationListener {
+	return &auditListener{sink: sink}
+}
+
+// OnValidationStart 实现 IValidationListener 接口
+func (l *auditListener) OnValidationStart(core.IContext, any) {}
+
+// OnValidationEnd 实现 IValidationListener 接口
+func (l *auditListener) OnValidationEnd(ctx core.IContext, target any, err error) {
+	result, _ := err.(core.IValidationError)
+	l.sink.Record(ctx.GoContext(), modelName(target), ctx.Scene(), result)
+}
+
+// OnError 实现 IValidationListener 接口
+func (l *auditListener) OnError(core.IContext, core.IFieldError) {}
+
+// modelName 模型类型名（与错误命名空间的根一致，如 User；map 等未命名类型使用类型字符串）
+func modelName(target any) string {
+	typ := reflect.TypeOf(target)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil {
+		return ""
+	}
+	if name := typ.Name(); name != "" {
+		return name
+	}
+	return typ.String()
+}
@@ -0,0 +1,111 @@
+package orchestration
+
+import (
+	stdcontext "context"
+	stderrors "errors"
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"time"
+)
+
+// ============================================================================
+// 请求级时间预算
+// ============================================================================
+
+// TagValidationDegraded 阶段因时间预算不足被跳过（或超出分配的预算）时收集的警告标签
+const TagValidationDegraded = "validation_degraded"
+
+// budgetTracker 单次验证的时间预算跟踪
+// 职责：按权重把截止时间前的剩余时间分配给各阶段，预算不足时跳过阶段并收集警告
+// 说明：
+//   - 串行执行时每个阶段分到 剩余时间 × 权重 / 未执行阶段的权重之和，前面阶段省下的时间自动留给后面
+//   - 并行执行时各阶段同时运行，每个阶段都可使用全部剩余时间
+//   - 阶段在派生的 GoContext 中运行，超出分配的预算后 GoContext 被取消，策略应尽快退出
+type budgetTracker struct {
+	budget   core.Budget
+	deadline time.Time
+	weight   int              // 未执行阶段的权重之和
+	parallel bool             // 并行执行，不按权重切分
+	now      func() time.Time // 当前时间（测试可替换）
+}
+
+// newBudgetTracker 创建时间预算跟踪
+// 返回值：未配置预算或请求没有截止时间时为 nil（按原方式执行）
+func newBudgetTracker(ctx core.IContext, budget core.Budget, stages []string, parallel bool) *budgetTracker {
+	deadline, ok := ctx.GoContext().Deadline()
+	if !ok {
+		return nil
+	}
+
+	t := &budgetTracker{budget: budget, deadline: deadline, parallel: parallel, now: time.Now}
+	for _, name := range stages {
+		if stage, ok := budget[name]; ok && stage.Weight > 0 {
+			t.weight += stage.Weight
+		}
+	}
+	if t.weight == 0 {
+		return nil
+	}
+	return t
+}
+
+// runStage 按预算执行阶段（t 为 nil 时直接执行）
+func (t *budgetTracker) runStage(name string, strategy core.IValidationStrategy, target any, ctx core.IContext, collector core.IErrorCollector, rePanic bool) (core.StageTiming, error) {
+	if t == nil {
+		return runStage(name, strategy, target, ctx, collector, rePanic)
+	}
+	stage, ok := t.budget[name]
+	if !ok || stage.Weight <= 0 {
+		return runStage(name, strategy, target, ctx, collector, rePanic)
+	}
+
+	allotted := t.allot(stage.Weight)
+	if allotted <= 0 || allotted < stage.Min {
+		collectDegraded(collector, name, fmt.Sprintf("stage '%s' skipped: budget %v below minimum %v", name, allotted, stage.Min))
+		return core.StageTiming{Name: name, Skipped: true}, nil
+	}
+
+	goCtx, cancel := stdcontext.WithTimeout(ctx.GoContext(), allotted)
+	defer cancel()
+
+	// 阶段同步执行，返回后即可回收派生上下文
+	stageCtx := context.NewContext(ctx.Scene(),
+		context.WithGoContext(goCtx),
+		context.WithDepth(ctx.Depth()),
+		context.WithSharedMetadata(ctx.Metadata()),
+		context.WithSharedMemo(ctx),
+	)
+	defer stageCtx.Release()
+
+	timing, err := runStage(name, strategy, target, stageCtx, collector, rePanic)
+	if err != nil && goCtx.Err() != nil &&
+		(stderrors.Is(err, stdcontext.DeadlineExceeded) || stderrors.Is(err, core.ErrStrategyTimeout)) {
+		// 超出分配的预算：降级为警告，不中断验证
+		collectDegraded(collector, name, fmt.Sprintf("stage '%s' exceeded budget %v: %v", name, allotted, err))
+		return timing, nil
+	}
+	return timing, err
+}
+
+// allot 计算阶段可用的时间
+func (t *budgetTracker) allot(weight int) time.Duration {
+	remaining := t.deadline.Sub(t.now())
+	if t.parallel || remaining <= 0 {
+		return remaining
+	}
+
+	allotted := remaining * time.Duration(weight) / time.Duration(t.weight)
+	t.weight -= weight
+	return allotted
+}
+
+// collectDegraded 收集降级警告（命名空间与字段名为阶段名称）
+func collectDegraded(collector core.IErrorCollector, name, message string) {
+	collector.AddWarning(errors.NewFieldError(name, name, TagValidationDegraded,
+		errors.WithParam(name),
+		errors.WithMessage(message),
+		errors.WithSeverity(core.SeverityWarning),
+	))
+}
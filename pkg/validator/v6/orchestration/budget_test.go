@@ -0,0 +1,283 @@
+package orchestration
+
+import (
+	stdcontext "context"
+	stderrors "errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+)
+
+// ============================================================================
+// 测试数据
+// ============================================================================
+
+// testScene 测试场景
+const testScene core.Scene = 1
+
+// funcStrategy 以函数实现的策略（测试用）
+type funcStrategy struct {
+	name string
+	fn   func(ctx core.IContext, collector core.IErrorCollector) error
+}
+
+func (s *funcStrategy) Type() core.StrategyType { return core.StrategyType(s.name) }
+func (s *funcStrategy) Name() string            { return s.name }
+
+func (s *funcStrategy) Validate(_ any, ctx core.IContext, collector core.IErrorCollector) error {
+	return s.fn(ctx, collector)
+}
+
+// fixedClock 返回固定时间的时钟，advance 前进指定时长
+func fixedClock(base time.Time) (now func() time.Time, advance func(time.Duration)) {
+	var mu sync.Mutex
+	current := base
+	now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return current
+	}
+	advance = func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		current = current.Add(d)
+	}
+	return now, advance
+}
+
+// newTestTracker 创建截止时间为 base+remaining、时钟固定在 base 的预算跟踪
+func newTestTracker(t *testing.T, budget core.Budget, stages []string, parallel bool, remaining time.Duration) (*budgetTracker, func(time.Duration)) {
+	t.Helper()
+	base := time.Now()
+	goCtx, cancel := stdcontext.WithDeadline(stdcontext.Background(), base.Add(remaining))
+	t.Cleanup(cancel)
+	ctx := context.NewContext(testScene, context.WithGoContext(goCtx))
+	t.Cleanup(ctx.Release)
+
+	tracker := newBudgetTracker(ctx, budget, stages, parallel)
+	if tracker == nil {
+		t.Fatal("newBudgetTracker() = nil")
+	}
+	now, advance := fixedClock(base)
+	tracker.now = now
+	return tracker, advance
+}
+
+// ============================================================================
+// 预算分配
+// ============================================================================
+
+// TestNewBudgetTracker 测试不需要跟踪时返回 nil
+func TestNewBudgetTracker(t *testing.T) {
+	budget := core.Budget{"rule": {Weight: 1}, "business": {Weight: 0}}
+
+	noDeadline := context.NewContext(testScene)
+	defer noDeadline.Release()
+	if newBudgetTracker(noDeadline, budget, []string{"rule"}, false) != nil {
+		t.Error("没有截止时间时应返回 nil")
+	}
+
+	goCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	ctx := context.NewContext(testScene, context.WithGoContext(goCtx))
+	defer ctx.Release()
+	if newBudgetTracker(ctx, budget, []string{"business", "other"}, false) != nil {
+		t.Error("没有正权重的阶段时应返回 nil")
+	}
+	if tracker := newBudgetTracker(ctx, budget, []string{"rule", "business"}, false); tracker == nil || tracker.weight != 1 {
+		t.Errorf("newBudgetTracker() = %+v, want weight 1", tracker)
+	}
+}
+
+// TestBudgetTracker_AllotWeights 测试串行执行时按权重切分剩余时间
+func TestBudgetTracker_AllotWeights(t *testing.T) {
+	budget := core.Budget{"rule": {Weight: 1}, "repository": {Weight: 3}}
+	tracker, advance := newTestTracker(t, budget, []string{"rule", "repository", "business"}, false, 100*time.Millisecond)
+
+	if got := tracker.allot(1); got != 25*time.Millisecond {
+		t.Errorf("allot(rule) = %v, want 25ms", got)
+	}
+
+	// 前一阶段只用了 10ms，省下的时间全部留给后面的阶段
+	advance(10 * time.Millisecond)
+	if got := tracker.allot(3); got != 90*time.Millisecond {
+		t.Errorf("allot(repository) = %v, want 90ms", got)
+	}
+	if tracker.weight != 0 {
+		t.Errorf("weight = %d, want 0", tracker.weight)
+	}
+
+	// 截止时间已过
+	advance(time.Second)
+	if got := tracker.allot(1); got >= 0 {
+		t.Errorf("超过截止时间后 allot() = %v, want negative", got)
+	}
+}
+
+// TestBudgetTracker_AllotParallel 测试并行执行时每个阶段都可使用全部剩余时间
+func TestBudgetTracker_AllotParallel(t *testing.T) {
+	budget := core.Budget{"rule": {Weight: 1}, "repository": {Weight: 3}}
+	tracker, _ := newTestTracker(t, budget, []string{"rule", "repository"}, true, 100*time.Millisecond)
+
+	for _, weight := range []int{1, 3} {
+		if got := tracker.allot(weight); got != 100*time.Millisecond {
+			t.Errorf("allot(%d) = %v, want 100ms", weight, got)
+		}
+	}
+}
+
+// ============================================================================
+// 阶段执行
+// ============================================================================
+
+// TestBudgetTracker_SkipBelowMin 测试分到的时间低于最低预算时跳过阶段
+func TestBudgetTracker_SkipBelowMin(t *testing.T) {
+	budget := core.Budget{
+		"rule":       {Weight: 1},
+		"repository": {Weight: 1, Min: 80 * time.Millisecond},
+	}
+	tracker, _ := newTestTracker(t, budget, []string{"rule", "repository"}, false, 100*time.Millisecond)
+	tracker.allot(1) // rule 分到 50ms，之后剩余时间全部留给 repository
+
+	called := false
+	s := &funcStrategy{name: "repository", fn: func(core.IContext, core.IErrorCollector) error {
+		called = true
+		return nil
+	}}
+
+	// 时钟前进 30ms 后 repository 只能分到 70ms，低于 80ms
+	tracker.now = func() time.Time { return tracker.deadline.Add(-70 * time.Millisecond) }
+	ctx := context.NewContext(testScene)
+	defer ctx.Release()
+	collector := errors.NewListErrorCollector(10)
+
+	timing, err := tracker.runStage("repository", s, nil, ctx, collector, false)
+	if err != nil || !timing.Skipped || called {
+		t.Fatalf("runStage() = %+v, %v, called=%t, want skipped", timing, err, called)
+	}
+	assertDegraded(t, collector, "repository", "below minimum 80ms")
+}
+
+// TestBudgetTracker_TimeoutWarning 测试超出分配的预算时降级为警告
+func TestBudgetTracker_TimeoutWarning(t *testing.T) {
+	budget := core.Budget{"email": {Weight: 1}}
+	tests := []struct {
+		name    string
+		err     func(ctx core.IContext) error
+		wantErr bool
+	}{
+		{"Go 上下文超时", func(ctx core.IContext) error { return ctx.GoContext().Err() }, false},
+		{"包装器超时", func(core.IContext) error { return core.ErrStrategyTimeout }, false},
+		{"其他错误不降级", func(core.IContext) error { return stderrors.New("smtp down") }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker, _ := newTestTracker(t, budget, []string{"email"}, false, 5*time.Millisecond)
+			s := &funcStrategy{name: "email", fn: func(ctx core.IContext, _ core.IErrorCollector) error {
+				<-ctx.GoContext().Done()
+				return tt.err(ctx)
+			}}
+
+			ctx := context.NewContext(testScene)
+			defer ctx.Release()
+			collector := errors.NewListErrorCollector(10)
+			timing, err := tracker.runStage("email", s, nil, ctx, collector, false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runStage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if timing.Skipped {
+				t.Error("执行过的阶段不应标记为跳过")
+			}
+			if tt.wantErr {
+				if len(collector.Warnings()) != 0 {
+					t.Errorf("Warnings() = %v, want none", collector.Warnings())
+				}
+				return
+			}
+			assertDegraded(t, collector, "email", "exceeded budget 5ms")
+		})
+	}
+}
+
+// TestBudgetTracker_Unbudgeted 测试未配置预算的阶段不缩短、不跳过
+func TestBudgetTracker_Unbudgeted(t *testing.T) {
+	tracker, _ := newTestTracker(t, core.Budget{"rule": {Weight: 1}}, []string{"rule", "business"}, false, 100*time.Millisecond)
+	goCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Hour)
+	defer cancel()
+	ctx := context.NewContext(testScene, context.WithGoContext(goCtx))
+	defer ctx.Release()
+
+	var deadline time.Time
+	s := &funcStrategy{name: "business", fn: func(ctx core.IContext, _ core.IErrorCollector) error {
+		deadline, _ = ctx.GoContext().Deadline()
+		return nil
+	}}
+	if _, err := tracker.runStage("business", s, nil, ctx, errors.NewListErrorCollector(10), false); err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := goCtx.Deadline(); !deadline.Equal(want) {
+		t.Errorf("deadline = %v, want the request deadline %v", deadline, want)
+	}
+}
+
+// TestStrategyOrchestrator_ParallelBudget 测试并行执行时各阶段都以请求截止时间为限
+func TestStrategyOrchestrator_ParallelBudget(t *testing.T) {
+	o := NewStrategyOrchestrator().(*strategyOrchestrator)
+	o.SetExecutionMode(core.ExecutionModeParallel)
+	o.SetBudget(core.Budget{"rule": {Weight: 1}, "repository": {Weight: 3}})
+
+	var mu sync.Mutex
+	deadlines := make(map[string]time.Time)
+	record := func(name string) *funcStrategy {
+		return &funcStrategy{name: name, fn: func(ctx core.IContext, _ core.IErrorCollector) error {
+			deadline, _ := ctx.GoContext().Deadline()
+			mu.Lock()
+			defer mu.Unlock()
+			deadlines[name] = deadline
+			return nil
+		}}
+	}
+	o.Register(record("rule"), 10)
+	o.Register(record("repository"), 20)
+
+	goCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), time.Second)
+	defer cancel()
+	ctx := context.NewContext(testScene, context.WithGoContext(goCtx))
+	defer ctx.Release()
+	if err := o.Execute(nil, ctx, errors.NewListErrorCollector(10)); err != nil {
+		t.Fatal(err)
+	}
+
+	// 不按权重切分：两个阶段的截止时间都接近请求截止时间（串行时 rule 只能分到 1/4）
+	requestDeadline, _ := goCtx.Deadline()
+	for _, name := range []string{"rule", "repository"} {
+		if gap := requestDeadline.Sub(deadlines[name]); gap < 0 || gap > 100*time.Millisecond {
+			t.Errorf("%s deadline is %v before the request deadline", name, gap)
+		}
+	}
+}
+
+// assertDegraded 检查收集了一条阶段降级警告
+func assertDegraded(t *testing.T, collector core.IErrorCollector, stage, message string) {
+	t.Helper()
+	warnings := collector.Warnings()
+	if len(warnings) != 1 {
+		t.Fatalf("Warnings() = %v, want one warning", warnings)
+	}
+	w := warnings[0]
+	if w.Tag() != TagValidationDegraded || w.Namespace() != stage || w.Param() != stage {
+		t.Errorf("warning = %s %s %s", w.Namespace(), w.Tag(), w.Param())
+	}
+	if !strings.Contains(w.Message(), message) {
+		t.Errorf("Message() = %q, want %q", w.Message(), message)
+	}
+	if collector.HasErrors() {
+		t.Errorf("降级不应收集错误: %v", collector.Errors())
+	}
+}
@@ -0,0 +1,82 @@
+package orchestration
+
+import (
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// ============================================================================
+// 策略依赖排序
+// ============================================================================
+
+// strategyRunsAfter 获取策略声明的前置策略（未实现 IStrategyDependencies 时为 nil）
+func strategyRunsAfter(strategy core.IValidationStrategy) []string {
+	if d, ok := strategy.(core.IStrategyDependencies); ok {
+		return d.RunsAfter()
+	}
+	return nil
+}
+
+// orderByDependencies 按依赖拓扑排序，返回新顺序下的原下标
+// 说明：
+//   - names[i] 的前置策略为 after[i]，同名的多个策略都需要先执行
+//   - 每次从可执行的策略中选原顺序最靠前的，没有依赖约束时保持原顺序
+//   - 未注册的前置策略忽略；自身依赖或循环依赖返回错误
+func orderByDependencies(names []string, after [][]string) ([]int, error) {
+	byName := make(map[string][]int, len(names))
+	for i, name := range names {
+		byName[name] = append(byName[name], i)
+	}
+
+	pending := make([]int, len(names)) // 策略 -> 未执行的前置策略数
+	dependents := make([][]int, len(names))
+	constrained := false
+	for i := range names {
+		for _, dep := range after[i] {
+			for _, j := range byName[dep] {
+				pending[i]++
+				dependents[j] = append(dependents[j], i)
+				constrained = true
+			}
+		}
+	}
+
+	order := make([]int, 0, len(names))
+	if !constrained {
+		for i := range names {
+			order = append(order, i)
+		}
+		return order, nil
+	}
+
+	done := make([]bool, len(names))
+	for len(order) < len(names) {
+		next := -1
+		for i := range names {
+			if !done[i] && pending[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			break
+		}
+
+		done[next] = true
+		order = append(order, next)
+		for _, dependent := range dependents[next] {
+			pending[dependent]--
+		}
+	}
+
+	if len(order) != len(names) {
+		cyclic := make([]string, 0)
+		for i, name := range names {
+			if !done[i] {
+				cyclic = append(cyclic, name)
+			}
+		}
+		return nil, fmt.Errorf("strategy dependency cycle among %v", cyclic)
+	}
+	return order, nil
+}
@@ -0,0 +1,323 @@
+package orchestration
+
+import (
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"time"
+)
+
+// ============================================================================
+// 验证管道 - 有序的命名阶段
+// ============================================================================
+
+// pipelineStage 管道阶段
+type pipelineStage struct {
+	name     string
+	strategy core.IValidationStrategy
+	priority int // 通过 Register 注册时的优先级，用于确定插入位置
+}
+
+// Pipeline 验证管道
+// 职责：按阶段名称组织策略，如 "sanitize → rule → business → repository"
+// 设计原则：
+//   - 阶段按顺序串行执行，可按名称在指定阶段前后插入、替换或移除
+//   - 实现 IStrategyOrchestrator 接口，可直接替换默认的策略编排器
+//   - 每个阶段的执行耗时会记录到验证结果中（StageTimings）
+//   - 策略实现 IStrategyDependencies 时，阶段按依赖拓扑排序，前置阶段总是先执行
+//
+// 注意：管道在构建阶段配置，配置完成后不应再修改
+type Pipeline struct {
+	stages  []pipelineStage
+	rePanic bool
+	budget  core.Budget
+	scenes  core.SceneStrategies
+}
+
+// NewPipeline 创建验证管道
+func NewPipeline() *Pipeline {
+	return &Pipeline{
+		stages: make([]pipelineStage, 0, 4),
+	}
+}
+
+// AddStage 在末尾添加阶段
+func (p *Pipeline) AddStage(name string, strategy core.IValidationStrategy) error {
+	if err := p.checkNew(name, strategy); err != nil {
+		return err
+	}
+
+	priority := 0
+	if n := len(p.stages); n > 0 {
+		priority = p.stages[n-1].priority
+	}
+	previous := p.snapshot()
+	p.stages = append(p.stages, pipelineStage{name: name, strategy: strategy, priority: priority})
+	return p.sortByDependencies(previous)
+}
+
+// InsertBefore 在指定阶段之前插入阶段
+func (p *Pipeline) InsertBefore(before, name string, strategy core.IValidationStrategy) error {
+	idx := p.indexOf(before)
+	if idx < 0 {
+		return fmt.Errorf("pipeline stage %q not found", before)
+	}
+	if err := p.checkNew(name, strategy); err != nil {
+		return err
+	}
+
+	previous := p.snapshot()
+	p.insertAt(idx, pipelineStage{name: name, strategy: strategy, priority: p.stages[idx].priority})
+	return p.sortByDependencies(previous)
+}
+
+// InsertAfter 在指定阶段之后插入阶段
+func (p *Pipeline) InsertAfter(after, name string, strategy core.IValidationStrategy) error {
+	idx := p.indexOf(after)
+	if idx < 0 {
+		return fmt.Errorf("pipeline stage %q not found", after)
+	}
+	if err := p.checkNew(name, strategy); err != nil {
+		return err
+	}
+
+	previous := p.snapshot()
+	p.insertAt(idx+1, pipelineStage{name: name, strategy: strategy, priority: p.stages[idx].priority})
+	return p.sortByDependencies(previous)
+}
+
+// ReplaceStage 替换指定阶段的策略，保持位置不变
+func (p *Pipeline) ReplaceStage(name string, strategy core.IValidationStrategy) error {
+	if strategy == nil {
+		return fmt.Errorf("pipeline stage %q strategy cannot be nil", name)
+	}
+	idx := p.indexOf(name)
+	if idx < 0 {
+		return fmt.Errorf("pipeline stage %q not found", name)
+	}
+
+	previous := p.snapshot()
+	p.stages[idx].strategy = strategy
+	return p.sortByDependencies(previous)
+}
+
+// RemoveStage 移除指定阶段
+func (p *Pipeline) RemoveStage(name string) error {
+	idx := p.indexOf(name)
+	if idx < 0 {
+		return fmt.Errorf("pipeline stage %q not found", name)
+	}
+
+	p.stages = append(p.stages[:idx], p.stages[idx+1:]...)
+	return nil
+}
+
+// Stages 获取所有阶段名称（按执行顺序）
+func (p *Pipeline) Stages() []string {
+	names := make([]string, len(p.stages))
+	for i, stage := range p.stages {
+		names[i] = stage.name
+	}
+	return names
+}
+
+// checkNew 检查新阶段
+func (p *Pipeline) checkNew(name string, strategy core.IValidationStrategy) error {
+	if name == "" {
+		return fmt.Errorf("pipeline stage name cannot be empty")
+	}
+	if strategy == nil {
+		return fmt.Errorf("pipeline stage %q strategy cannot be nil", name)
+	}
+	if p.indexOf(name) >= 0 {
+		return fmt.Errorf("pipeline stage %q already exists", name)
+	}
+	return nil
+}
+
+// indexOf 查找阶段位置
+func (p *Pipeline) indexOf(name string) int {
+	for i, stage := range p.stages {
+		if stage.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// snapshot 复制当前阶段列表（修改失败时恢复）
+func (p *Pipeline) snapshot() []pipelineStage {
+	return append([]pipelineStage(nil), p.stages...)
+}
+
+// sortByDependencies 按阶段策略声明的依赖重排阶段
+// 说明：存在循环依赖时恢复为 previous 并返回错误
+func (p *Pipeline) sortByDependencies(previous []pipelineStage) error {
+	names := make([]string, len(p.stages))
+	after := make([][]string, len(p.stages))
+	for i, stage := range p.stages {
+		names[i] = stage.name
+		after[i] = strategyRunsAfter(stage.strategy)
+	}
+
+	order, err := orderByDependencies(names, after)
+	if err != nil {
+		p.stages = previous
+		return fmt.Errorf("pipeline: %w", err)
+	}
+
+	sorted := make([]pipelineStage, len(order))
+	for i, idx := range order {
+		sorted[i] = p.stages[idx]
+	}
+	p.stages = sorted
+	return nil
+}
+
+// insertAt 在指定位置插入阶段
+func (p *Pipeline) insertAt(idx int, stage pipelineStage) {
+	p.stages = append(p.stages, pipelineStage{})
+	copy(p.stages[idx+1:], p.stages[idx:])
+	p.stages[idx] = stage
+}
+
+// ============================================================================
+// IStrategyOrchestrator 接口实现
+// ============================================================================
+
+// Register 实现 IStrategyOrchestrator 接口
+// 说明：
+//   - 以策略名称作为阶段名称，按优先级插入到第一个优先级更大的阶段之前；同名阶段会被替换
+//   - 循环依赖属于编程错误，直接 panic（Build 时暴露）
+func (p *Pipeline) Register(strategy core.IValidationStrategy, priority int) {
+	previous := p.snapshot()
+	if idx := p.indexOf(strategy.Name()); idx >= 0 {
+		p.stages[idx].strategy = strategy
+		p.mustSortByDependencies(previous)
+		return
+	}
+
+	idx := len(p.stages)
+	for i, stage := range p.stages {
+		if stage.priority > priority {
+			idx = i
+			break
+		}
+	}
+	p.insertAt(idx, pipelineStage{name: strategy.Name(), strategy: strategy, priority: priority})
+	p.mustSortByDependencies(previous)
+}
+
+// mustSortByDependencies 按依赖重排阶段，循环依赖时 panic
+func (p *Pipeline) mustSortByDependencies(previous []pipelineStage) {
+	if err := p.sortByDependencies(previous); err != nil {
+		panic(err)
+	}
+}
+
+// Unregister 实现 IStrategyOrchestrator 接口，移除该类型的所有阶段
+func (p *Pipeline) Unregister(strategyType core.StrategyType) {
+	filtered := p.stages[:0]
+	for _, stage := range p.stages {
+		if stage.strategy.Type() != strategyType {
+			filtered = append(filtered, stage)
+		}
+	}
+	p.stages = filtered
+}
+
+// SetExecutionMode 实现 IStrategyOrchestrator 接口
+// 说明：管道阶段之间存在先后依赖，始终串行执行，忽略该设置
+func (p *Pipeline) SetExecutionMode(_ core.ExecutionMode) {}
+
+// SetRePanic 实现 IRePanicOrchestrator 接口
+func (p *Pipeline) SetRePanic(enabled bool) {
+	p.rePanic = enabled
+}
+
+// SetBudget 实现 IBudgetOrchestrator 接口，预算按阶段名称配置
+func (p *Pipeline) SetBudget(budget core.Budget) {
+	p.budget = budget
+}
+
+// SetSceneStrategies 实现 IStrategyOrchestrator 接口，按阶段名称筛选
+func (p *Pipeline) SetSceneStrategies(strategies core.SceneStrategies) {
+	p.scenes = strategies
+}
+
+// activeStages 当前场景执行的阶段名称（按执行顺序）
+func (p *Pipeline) activeStages(scene core.Scene) []string {
+	names := make([]string, 0, len(p.stages))
+	for _, stage := range p.stages {
+		if p.scenes.Allows(scene, stage.name) {
+			names = append(names, stage.name)
+		}
+	}
+	return names
+}
+
+// Execute 实现 IStrategyOrchestrator 接口，按顺序执行各阶段
+func (p *Pipeline) Execute(target any, ctx core.IContext, collector core.IErrorCollector) (err error) {
+	timings := context.StageTimingBuffer(ctx)
+	defer func() {
+		context.SetStageTimings(ctx, timings)
+		// 兜底：阶段之外（如收集器）的 panic；开发模式下不拦截
+		if p.rePanic {
+			return
+		}
+		if r := recover(); r != nil {
+			err = fmt.Errorf("strategy panic: %v", r)
+		}
+	}()
+
+	var tracker *budgetTracker
+	if len(p.budget) > 0 {
+		tracker = newBudgetTracker(ctx, p.budget, p.activeStages(ctx.Scene()), false)
+	}
+
+	for _, stage := range p.stages {
+		// 跳过当前场景不执行的阶段
+		if !p.scenes.Allows(ctx.Scene(), stage.name) {
+			continue
+		}
+
+		// 检查是否已达到最大错误数
+		if collector.Count() >= collector.MaxErrors() {
+			break
+		}
+
+		timing, stageErr := tracker.runStage(stage.name, stage.strategy, target, ctx, collector, p.rePanic)
+		timings = append(timings, timing)
+		if stageErr != nil {
+			return fmt.Errorf("pipeline stage %q: %w", stage.name, stageErr)
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// 阶段耗时
+// ============================================================================
+
+// runStage 执行阶段并记录耗时和新增错误数
+func runStage(name string, strategy core.IValidationStrategy, target any, ctx core.IContext, collector core.IErrorCollector, rePanic bool) (core.StageTiming, error) {
+	context.Emit(ctx, core.Event{Type: core.EventTypeStrategyStart, Strategy: name})
+
+	before := collector.Count()
+	start := time.Now()
+	err := safeValidate(name, strategy, target, ctx, collector, rePanic)
+	timing := core.StageTiming{
+		Name:     name,
+		Duration: time.Since(start),
+		Errors:   collector.Count() - before,
+	}
+
+	context.Emit(ctx, core.Event{
+		Type:     core.EventTypeStrategyEnd,
+		Strategy: name,
+		Duration: timing.Duration,
+		Errors:   timing.Errors,
+		Err:      err,
+	})
+	return timing, err
+}
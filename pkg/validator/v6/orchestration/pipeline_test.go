@@ -0,0 +1,237 @@
+package orchestration
+
+import (
+	stderrors "errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+)
+
+// ============================================================================
+// 测试数据
+// ============================================================================
+
+// recordingStrategy 记录执行顺序、收集指定数量错误的策略
+func recordingStrategy(name string, order *[]string, errs int) *funcStrategy {
+	return &funcStrategy{name: name, fn: func(_ core.IContext, collector core.IErrorCollector) error {
+		*order = append(*order, name)
+		for i := 0; i < errs; i++ {
+			collector.Collect(errors.NewFieldError(name, name, "invalid"))
+		}
+		return nil
+	}}
+}
+
+// dependentStrategy 声明前置阶段的策略
+type dependentStrategy struct {
+	*funcStrategy
+	after []string
+}
+
+func (s *dependentStrategy) RunsAfter() []string { return s.after }
+
+// newTestPipeline 创建包含 rule、business、repository 三个阶段的管道
+func newTestPipeline(t *testing.T, order *[]string) *Pipeline {
+	t.Helper()
+	p := NewPipeline()
+	for _, name := range []string{"rule", "business", "repository"} {
+		if err := p.AddStage(name, recordingStrategy(name, order, 0)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return p
+}
+
+// ============================================================================
+// 阶段配置
+// ============================================================================
+
+// TestPipeline_StageOperations 测试按名称插入、替换和移除阶段
+func TestPipeline_StageOperations(t *testing.T) {
+	var order []string
+	p := newTestPipeline(t, &order)
+
+	if err := p.InsertBefore("rule", "sanitize", recordingStrategy("sanitize", &order, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.InsertAfter("business", "email", recordingStrategy("email", &order, 0)); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"sanitize", "rule", "business", "email", "repository"}
+	if got := p.Stages(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Stages() = %v, want %v", got, want)
+	}
+
+	// 替换保持位置不变
+	if err := p.ReplaceStage("business", recordingStrategy("business_v2", &order, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.RemoveStage("repository"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := p.Stages(), []string{"sanitize", "rule", "business", "email"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Stages() = %v, want %v", got, want)
+	}
+
+	ctx := context.NewContext(testScene)
+	defer ctx.Release()
+	if err := p.Execute(nil, ctx, errors.NewListErrorCollector(10)); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"sanitize", "rule", "business_v2", "email"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("执行顺序 = %v, want %v", order, want)
+	}
+}
+
+// TestPipeline_StageErrors 测试无效的阶段操作返回错误且不修改管道
+func TestPipeline_StageErrors(t *testing.T) {
+	var order []string
+	p := newTestPipeline(t, &order)
+	s := recordingStrategy("x", &order, 0)
+
+	tests := []struct {
+		name    string
+		op      func() error
+		wantErr string
+	}{
+		{"名称为空", func() error { return p.AddStage("", s) }, "name cannot be empty"},
+		{"策略为空", func() error { return p.AddStage("x", nil) }, "strategy cannot be nil"},
+		{"名称重复", func() error { return p.InsertAfter("rule", "business", s) }, "already exists"},
+		{"插入位置不存在", func() error { return p.InsertBefore("missing", "x", s) }, `"missing" not found`},
+		{"替换不存在的阶段", func() error { return p.ReplaceStage("missing", s) }, `"missing" not found`},
+		{"替换为空策略", func() error { return p.ReplaceStage("rule", nil) }, "strategy cannot be nil"},
+		{"移除不存在的阶段", func() error { return p.RemoveStage("missing") }, `"missing" not found`},
+		{"循环依赖", func() error {
+			return p.InsertBefore("business", "normalize", &dependentStrategy{
+				funcStrategy: recordingStrategy("normalize", &order, 0), after: []string{"normalize"},
+			})
+		}, "pipeline:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.op(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+			if got, want := p.Stages(), []string{"rule", "business", "repository"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("失败后 Stages() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestPipeline_Dependencies 测试声明前置阶段时插入位置之前的依赖也会被满足
+func TestPipeline_Dependencies(t *testing.T) {
+	var order []string
+	p := newTestPipeline(t, &order)
+
+	// 插入到 rule 之前，但依赖 business
+	audit := &dependentStrategy{funcStrategy: recordingStrategy("audit", &order, 0), after: []string{"business"}}
+	if err := p.InsertBefore("rule", "audit", audit); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := p.Stages(), []string{"rule", "business", "audit", "repository"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Stages() = %v, want %v", got, want)
+	}
+}
+
+// TestPipeline_Register 测试按优先级注册，同名策略替换原阶段
+func TestPipeline_Register(t *testing.T) {
+	var order []string
+	p := NewPipeline()
+	p.Register(recordingStrategy("business", &order, 0), 20)
+	p.Register(recordingStrategy("rule", &order, 0), 10)
+	p.Register(recordingStrategy("repository", &order, 0), 30)
+	p.Register(recordingStrategy("rule", &order, 0), 99)
+
+	if got, want := p.Stages(), []string{"rule", "business", "repository"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Stages() = %v, want %v", got, want)
+	}
+
+	// 通过 AddStage 添加的阶段沿用末尾阶段的优先级
+	if err := p.AddStage("audit", recordingStrategy("audit", &order, 0)); err != nil {
+		t.Fatal(err)
+	}
+	p.Register(recordingStrategy("email", &order, 25), 25)
+	if got, want := p.Stages(), []string{"rule", "business", "email", "repository", "audit"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Stages() = %v, want %v", got, want)
+	}
+}
+
+// ============================================================================
+// 执行
+// ============================================================================
+
+// TestPipeline_StageTimings 测试每个执行的阶段记录耗时与新增错误数
+func TestPipeline_StageTimings(t *testing.T) {
+	var order []string
+	p := NewPipeline()
+	_ = p.AddStage("rule", recordingStrategy("rule", &order, 2))
+	_ = p.AddStage("business", recordingStrategy("business", &order, 0))
+	_ = p.AddStage("repository", recordingStrategy("repository", &order, 1))
+
+	ctx := context.NewContext(testScene)
+	defer ctx.Release()
+	if err := p.Execute(nil, ctx, errors.NewListErrorCollector(10)); err != nil {
+		t.Fatal(err)
+	}
+
+	timings := context.StageTimings(ctx)
+	names := make([]string, len(timings))
+	counts := make([]int, len(timings))
+	for i, timing := range timings {
+		names[i], counts[i] = timing.Name, timing.Errors
+		if timing.Duration < 0 || timing.Skipped {
+			t.Errorf("timing = %+v", timing)
+		}
+	}
+	if want := []string{"rule", "business", "repository"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("timing names = %v, want %v", names, want)
+	}
+	if want := []int{2, 0, 1}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("timing errors = %v, want %v", counts, want)
+	}
+}
+
+// TestPipeline_Stop 测试阶段出错或达到最大错误数时停止执行后续阶段
+func TestPipeline_Stop(t *testing.T) {
+	t.Run("阶段出错", func(t *testing.T) {
+		var order []string
+		p := newTestPipeline(t, &order)
+		stageErr := stderrors.New("db down")
+		_ = p.ReplaceStage("business", &funcStrategy{name: "business", fn: func(core.IContext, core.IErrorCollector) error {
+			return stageErr
+		}})
+
+		ctx := context.NewContext(testScene)
+		defer ctx.Release()
+		err := p.Execute(nil, ctx, errors.NewListErrorCollector(10))
+		if !stderrors.Is(err, stageErr) || !strings.Contains(err.Error(), `pipeline stage "business"`) {
+			t.Errorf("Execute() = %v", err)
+		}
+		if want := []string{"rule"}; !reflect.DeepEqual(order, want) {
+			t.Errorf("执行顺序 = %v, want %v", order, want)
+		}
+		if got := len(context.StageTimings(ctx)); got != 2 {
+			t.Errorf("len(StageTimings) = %d, want 2", got)
+		}
+	})
+
+	t.Run("达到最大错误数", func(t *testing.T) {
+		var order []string
+		p := newTestPipeline(t, &order)
+		_ = p.ReplaceStage("rule", recordingStrategy("rule", &order, 1))
+
+		ctx := context.NewContext(testScene)
+		defer ctx.Release()
+		if err := p.Execute(nil, ctx, errors.NewListErrorCollector(1)); err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"rule"}; !reflect.DeepEqual(order, want) {
+			t.Errorf("执行顺序 = %v, want %v", order, want)
+		}
+	})
+}
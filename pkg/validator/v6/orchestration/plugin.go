@@ -0,0 +1,287 @@
+package orchestration
+
+import (
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ============================================================================
+// 插件管理器
+// ============================================================================
+
+// pluginEntry 插件条目
+type pluginEntry struct {
+	plugin   core.IPlugin
+	name     string
+	priority int
+	requires []string
+	index    int // 注册顺序，优先级相同时保持稳定
+	enabled  atomic.Bool
+}
+
+// PluginManager 插件管理器
+// 职责：解析插件安装顺序、检测规则重名，并在运行时启用/禁用插件
+// 设计原则：
+//   - 安装顺序：先满足 Requires 依赖，再按 Priority 升序，最后按注册顺序
+//   - 两个插件注册同名规则或别名时安装失败，避免后者静默覆盖前者
+//   - 禁用只影响插件注册的规则和策略，不会从底层引擎中移除
+type PluginManager struct {
+	mu      sync.RWMutex
+	entries []*pluginEntry          // 安装顺序
+	byName  map[string]*pluginEntry // 名称 -> 插件
+	rules   map[string]string       // 规则名/别名 -> 插件名
+}
+
+// NewPluginManager 创建插件管理器
+func NewPluginManager() *PluginManager {
+	return &PluginManager{
+		byName: make(map[string]*pluginEntry),
+		rules:  make(map[string]string),
+	}
+}
+
+// Add 添加插件（尚未安装）
+func (m *PluginManager) Add(plugins ...core.IPlugin) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, plugin := range plugins {
+		if plugin == nil {
+			return fmt.Errorf("plugin cannot be nil")
+		}
+		name := plugin.Name()
+		if name == "" {
+			return fmt.Errorf("plugin name cannot be empty")
+		}
+		if _, exists := m.byName[name]; exists {
+			return fmt.Errorf("plugin %q already added", name)
+		}
+
+		entry := &pluginEntry{plugin: plugin, name: name, index: len(m.entries)}
+		if p, ok := plugin.(core.IPluginPriority); ok {
+			entry.priority = p.Priority()
+		}
+		if r, ok := plugin.(core.IPluginRequires); ok {
+			entry.requires = r.Requires()
+		}
+		entry.enabled.Store(true)
+
+		m.entries = append(m.entries, entry)
+		m.byName[name] = entry
+	}
+	return nil
+}
+
+// Install 按依赖和优先级顺序安装所有插件
+func (m *PluginManager) Install(engine core.IDependencyEngine, orchestrator core.IStrategyOrchestrator) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 步骤1：解析安装顺序
+	ordered, err := m.resolveOrder()
+	if err != nil {
+		return err
+	}
+	m.entries = ordered
+
+	// 步骤2：依次安装
+	for _, entry := range m.entries {
+		host := &pluginHost{manager: m, entry: entry, engine: engine, orchestrator: orchestrator}
+		if err := entry.plugin.Install(host); err != nil {
+			return fmt.Errorf("plugin %q: %w", entry.name, err)
+		}
+	}
+	return nil
+}
+
+// resolveOrder 拓扑排序，同一层中按优先级和注册顺序排列
+func (m *PluginManager) resolveOrder() ([]*pluginEntry, error) {
+	pending := make(map[string]int, len(m.entries)) // 插件 -> 未满足的依赖数
+	dependents := make(map[string][]*pluginEntry, len(m.entries))
+	for _, entry := range m.entries {
+		for _, dep := range entry.requires {
+			if _, ok := m.byName[dep]; !ok {
+				return nil, fmt.Errorf("plugin %q requires missing plugin %q", entry.name, dep)
+			}
+			pending[entry.name]++
+			dependents[dep] = append(dependents[dep], entry)
+		}
+	}
+
+	ready := make([]*pluginEntry, 0, len(m.entries))
+	for _, entry := range m.entries {
+		if pending[entry.name] == 0 {
+			ready = append(ready, entry)
+		}
+	}
+
+	ordered := make([]*pluginEntry, 0, len(m.entries))
+	for len(ready) > 0 {
+		sort.SliceStable(ready, func(i, j int) bool {
+			if ready[i].priority != ready[j].priority {
+				return ready[i].priority < ready[j].priority
+			}
+			return ready[i].index < ready[j].index
+		})
+
+		next := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, next)
+
+		for _, dependent := range dependents[next.name] {
+			pending[dependent.name]--
+			if pending[dependent.name] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(ordered) != len(m.entries) {
+		cyclic := make([]string, 0)
+		for _, entry := range m.entries {
+			if pending[entry.name] > 0 {
+				cyclic = append(cyclic, entry.name)
+			}
+		}
+		return nil, fmt.Errorf("plugin dependency cycle among %v", cyclic)
+	}
+	return ordered, nil
+}
+
+// claimRule 记录规则归属，与其他插件重名时返回错误
+func (m *PluginManager) claimRule(name, plugin string) error {
+	if owner, exists := m.rules[name]; exists && owner != plugin {
+		return fmt.Errorf("rule %q already registered by plugin %q", name, owner)
+	}
+	m.rules[name] = plugin
+	return nil
+}
+
+// EnablePlugin 实现 IPluginManager 接口
+func (m *PluginManager) EnablePlugin(name string) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entry, ok := m.byName[name]
+	if !ok {
+		return fmt.Errorf("plugin %q not found", name)
+	}
+	for _, dep := range entry.requires {
+		if !m.byName[dep].enabled.Load() {
+			return fmt.Errorf("plugin %q requires disabled plugin %q", name, dep)
+		}
+	}
+	entry.enabled.Store(true)
+	return nil
+}
+
+// DisablePlugin 实现 IPluginManager 接口
+func (m *PluginManager) DisablePlugin(name string) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entry, ok := m.byName[name]
+	if !ok {
+		return fmt.Errorf("plugin %q not found", name)
+	}
+	for _, other := range m.entries {
+		if !other.enabled.Load() {
+			continue
+		}
+		for _, dep := range other.requires {
+			if dep == name {
+				return fmt.Errorf("plugin %q is required by enabled plugin %q", name, other.name)
+			}
+		}
+	}
+	entry.enabled.Store(false)
+	return nil
+}
+
+// IsPluginEnabled 实现 IPluginManager 接口
+func (m *PluginManager) IsPluginEnabled(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entry, ok := m.byName[name]
+	return ok && entry.enabled.Load()
+}
+
+// Plugins 实现 IPluginManager 接口
+func (m *PluginManager) Plugins() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	names := make([]string, len(m.entries))
+	for i, entry := range m.entries {
+		names[i] = entry.name
+	}
+	return names
+}
+
+// ============================================================================
+// 插件安装宿主
+// ============================================================================
+
+// pluginHost 插件安装宿主实现
+// 说明：注册的规则和策略都会被包装，插件禁用时直接放行
+type pluginHost struct {
+	manager      *PluginManager
+	entry        *pluginEntry
+	engine       core.IDependencyEngine
+	orchestrator core.IStrategyOrchestrator
+}
+
+// RegisterAlias 实现 IPluginHost 接口
+func (h *pluginHost) RegisterAlias(alias, tags string) error {
+	if err := h.manager.claimRule(alias, h.entry.name); err != nil {
+		return err
+	}
+	h.engine.RegisterAlias(alias, tags)
+	return nil
+}
+
+// RegisterValidation 实现 IPluginHost 接口
+func (h *pluginHost) RegisterValidation(tag string, fn core.ValidationFunc) error {
+	if fn == nil {
+		return fmt.Errorf("rule %q validation func cannot be nil", tag)
+	}
+	if err := h.manager.claimRule(tag, h.entry.name); err != nil {
+		return err
+	}
+
+	entry := h.entry
+	return h.engine.RegisterValidation(tag, func(value any, param string) bool {
+		if !entry.enabled.Load() {
+			return true
+		}
+		return fn(value, param)
+	})
+}
+
+// RegisterStrategy 实现 IPluginHost 接口
+func (h *pluginHost) RegisterStrategy(strategy core.IValidationStrategy, priority int) {
+	h.orchestrator.Register(&pluginStrategy{IValidationStrategy: strategy, entry: h.entry}, priority)
+}
+
+// pluginStrategy 插件策略，插件禁用时跳过执行
+type pluginStrategy struct {
+	core.IValidationStrategy
+	entry *pluginEntry
+}
+
+// RunsAfter 实现 IStrategyDependencies 接口（与插件策略一致）
+func (s *pluginStrategy) RunsAfter() []string {
+	return strategyRunsAfter(s.IValidationStrategy)
+}
+
+// Validate 实现 IValidationStrategy 接口
+func (s *pluginStrategy) Validate(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	if !s.entry.enabled.Load() {
+		return nil
+	}
+	return s.IValidationStrategy.Validate(target, ctx, collector)
+}
@@ -0,0 +1,35 @@
+package orchestration
+
+import (
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+)
+
+// ============================================================================
+// 策略沙箱 - panic 隔离
+// ============================================================================
+
+// TagStrategyPanic 策略 panic 时报告的错误标签，Param 为策略名称
+const TagStrategyPanic = "strategy_panic"
+
+// safeValidate 执行策略并隔离 panic
+// 说明：
+//   - panic 转换为 strategy_panic 错误，归属到该策略，其他策略继续执行
+//   - rePanic 为 true（开发模式）时重新抛出，便于尽早发现问题
+func safeValidate(name string, strategy core.IValidationStrategy, target any, ctx core.IContext, collector core.IErrorCollector, rePanic bool) error {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		if rePanic {
+			panic(r)
+		}
+		collector.Collect(errors.NewFieldError(name, name, TagStrategyPanic,
+			errors.WithParam(name),
+			errors.WithMessage(fmt.Sprintf("strategy '%s' panicked: %v", name, r)),
+		))
+	}()
+	return strategy.Validate(target, ctx, collector)
+}
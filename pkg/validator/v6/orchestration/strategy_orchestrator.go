@@ -2,7 +2,9 @@ package orchestration
 
 import (
 	"fmt"
+	"katydid-common-account/pkg/validator/v6/context"
 	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
 	"sort"
 	"sync"
 )
@@ -19,6 +21,9 @@ type strategyEntry struct {
 type strategyOrchestrator struct {
 	strategies    []strategyEntry
 	executionMode core.ExecutionMode
+	rePanic       bool
+	budget        core.Budget
+	scenes        core.SceneStrategies
 	//mu            sync.RWMutex // 一般就初始化，不必枷锁
 }
 
@@ -31,10 +36,15 @@ func NewStrategyOrchestrator() core.IStrategyOrchestrator {
 }
 
 // Register 注册策略
+// 说明：
+//   - 先按优先级排序，再按 IStrategyDependencies 声明的依赖拓扑排序（并行模式下依赖不生效）
+//   - 循环依赖属于编程错误，直接 panic（Build 时暴露）
 func (o *strategyOrchestrator) Register(strategy core.IValidationStrategy, priority int) {
 	//o.mu.Lock()
 	//defer o.mu.Unlock()
 
+	previous := append([]strategyEntry(nil), o.strategies...)
+
 	// 添加策略
 	o.strategies = append(o.strategies, strategyEntry{
 		strategy: strategy,
@@ -42,9 +52,37 @@ func (o *strategyOrchestrator) Register(strategy core.IValidationStrategy, prior
 	})
 
 	// 按优先级排序
-	sort.Slice(o.strategies, func(i, j int) bool {
+	sort.SliceStable(o.strategies, func(i, j int) bool {
 		return o.strategies[i].priority < o.strategies[j].priority
 	})
+
+	// 按依赖排序
+	if err := o.sortByDependencies(); err != nil {
+		o.strategies = previous
+		panic(err)
+	}
+}
+
+// sortByDependencies 按策略声明的依赖重排策略
+func (o *strategyOrchestrator) sortByDependencies() error {
+	names := make([]string, len(o.strategies))
+	after := make([][]string, len(o.strategies))
+	for i, entry := range o.strategies {
+		names[i] = entry.strategy.Name()
+		after[i] = strategyRunsAfter(entry.strategy)
+	}
+
+	order, err := orderByDependencies(names, after)
+	if err != nil {
+		return err
+	}
+
+	sorted := make([]strategyEntry, len(order))
+	for i, idx := range order {
+		sorted[i] = o.strategies[idx]
+	}
+	o.strategies = sorted
+	return nil
 }
 
 // Unregister 注销策略
@@ -79,7 +117,11 @@ func (o *strategyOrchestrator) Execute(target any, ctx core.IContext, collector
 	//o.mu.RLock()
 	//defer o.mu.RUnlock()
 
+	// 兜底：策略之外（如收集器）的 panic；开发模式下不拦截
 	defer func() {
+		if o.rePanic {
+			return
+		}
 		if r := recover(); r != nil {
 			err = fmt.Errorf("strategy panic: %v", r)
 		}
@@ -93,14 +135,25 @@ func (o *strategyOrchestrator) Execute(target any, ctx core.IContext, collector
 
 // executeSequential 串行执行策略
 func (o *strategyOrchestrator) executeSequential(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	timings := context.StageTimingBuffer(ctx)
+	defer func() { context.SetStageTimings(ctx, timings) }()
+
+	tracker := o.budgetTracker(ctx, false)
 	for _, entry := range o.strategies {
+		// 跳过当前场景不执行的策略
+		if !o.scenes.Allows(ctx.Scene(), entry.strategy.Name()) {
+			continue
+		}
+
 		// 检查是否已达到最大错误数
 		if collector.Count() >= collector.MaxErrors() {
 			break
 		}
 
 		// 执行策略
-		if err := entry.strategy.Validate(target, ctx, collector); err != nil {
+		timing, err := tracker.runStage(entry.strategy.Name(), entry.strategy, target, ctx, collector, o.rePanic)
+		timings = append(timings, timing)
+		if err != nil {
 			// 策略执行出错，中断当前执行
 			return err
 		}
@@ -109,11 +162,20 @@ func (o *strategyOrchestrator) executeSequential(target any, ctx core.IContext,
 }
 
 // executeParallel 并行执行策略
+// 说明：并行执行时各阶段的错误数为近似值；策略共享加锁的收集器
 func (o *strategyOrchestrator) executeParallel(target any, ctx core.IContext, collector core.IErrorCollector) (err error) {
 	var wg sync.WaitGroup
 	var mu sync.Mutex
+	collector = errors.NewSyncCollector(collector)
+	timings := context.StageTimingBuffer(ctx)
+	tracker := o.budgetTracker(ctx, true)
 
 	for _, entry := range o.strategies {
+		// 跳过当前场景不执行的策略
+		if !o.scenes.Allows(ctx.Scene(), entry.strategy.Name()) {
+			continue
+		}
+
 		wg.Add(1)
 
 		// 并行执行每个策略
@@ -121,15 +183,18 @@ func (o *strategyOrchestrator) executeParallel(target any, ctx core.IContext, co
 			defer wg.Done()
 
 			// 检查是否已达到最大错误数
-			mu.Lock()
 			if collector.Count() >= collector.MaxErrors() {
-				mu.Unlock()
 				return
 			}
-			mu.Unlock()
 
 			// 执行策略
-			err = s.Validate(target, ctx, collector)
+			timing, stageErr := tracker.runStage(s.Name(), s, target, ctx, collector, o.rePanic)
+			mu.Lock()
+			timings = append(timings, timing)
+			if stageErr != nil {
+				err = stageErr
+			}
+			mu.Unlock()
 		}(entry.strategy)
 
 		// 策略执行出错，中断当前执行
@@ -140,6 +205,7 @@ func (o *strategyOrchestrator) executeParallel(target any, ctx core.IContext, co
 	}
 
 	wg.Wait()
+	context.SetStageTimings(ctx, timings)
 	return err
 }
 
@@ -149,3 +215,33 @@ func (o *strategyOrchestrator) SetExecutionMode(mode core.ExecutionMode) {
 	//defer o.mu.Unlock()
 	o.executionMode = mode
 }
+
+// SetRePanic 设置策略 panic 时是否重新抛出
+func (o *strategyOrchestrator) SetRePanic(enabled bool) {
+	o.rePanic = enabled
+}
+
+// SetBudget 设置请求级时间预算
+func (o *strategyOrchestrator) SetBudget(budget core.Budget) {
+	o.budget = budget
+}
+
+// SetSceneStrategies 设置按场景筛选策略
+func (o *strategyOrchestrator) SetSceneStrategies(strategies core.SceneStrategies) {
+	o.scenes = strategies
+}
+
+// budgetTracker 创建本次验证的时间预算跟踪（未配置预算时为 nil）
+// 说明：只有当前场景执行的策略参与预算分配
+func (o *strategyOrchestrator) budgetTracker(ctx core.IContext, parallel bool) *budgetTracker {
+	if len(o.budget) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(o.strategies))
+	for _, entry := range o.strategies {
+		if name := entry.strategy.Name(); o.scenes.Allows(ctx.Scene(), name) {
+			names = append(names, name)
+		}
+	}
+	return newBudgetTracker(ctx, o.budget, names, parallel)
+}
@@ -0,0 +1,73 @@
+package v6_test
+
+import (
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// panicStrategy 总是 panic 的策略
+type panicStrategy struct{}
+
+func (panicStrategy) Type() core.StrategyType { return core.StrategyTypeCustom }
+func (panicStrategy) Name() string            { return "exploding" }
+
+func (panicStrategy) Validate(any, core.IContext, core.IErrorCollector) error {
+	panic("boom")
+}
+
+// panicModel 用于 panic 测试的模型
+type panicModel struct {
+	Name string `json:"name"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (m *panicModel) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{"name": "required"}
+}
+
+// TestStrategyPanic_Isolated 测试策略 panic 转换为 strategy_panic 错误，其他策略继续执行
+func TestStrategyPanic_Isolated(t *testing.T) {
+	for _, pipeline := range []bool{false, true} {
+		builder := v6.NewBuilder().
+			WithRuleStrategy(20).
+			WithStrategy(panicStrategy{}, 10)
+		if pipeline {
+			builder.WithPipeline(func(p *v6.Pipeline) error { return nil })
+		}
+		validator := builder.Build()
+
+		err := validator.Validate(&panicModel{}, SceneCreate)
+		if err == nil {
+			t.Fatalf("pipeline=%t: Validate() = nil, want errors", pipeline)
+		}
+		got := make(map[string]string)
+		for _, fe := range err.FieldErrors() {
+			got[fe.Tag()] = fe.Param()
+		}
+		if got[v6.TagStrategyPanic] != "exploding" {
+			t.Errorf("pipeline=%t: errors = %v, want strategy_panic for exploding", pipeline, got)
+		}
+		if _, ok := got["required"]; !ok {
+			t.Errorf("pipeline=%t: panic 之后的策略应继续执行: %v", pipeline, got)
+		}
+	}
+}
+
+// TestStrategyPanic_RePanic 测试开发模式下策略 panic 重新抛出
+func TestStrategyPanic_RePanic(t *testing.T) {
+	validator := v6.NewBuilder().
+		WithRuleStrategy(20).
+		WithStrategy(panicStrategy{}, 10).
+		WithRePanic().
+		Build()
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("recover() = %v, want boom", r)
+		}
+	}()
+	validator.Validate(&panicModel{}, SceneCreate)
+	t.Error("WithRePanic 时 Validate 应 panic")
+}
@@ -0,0 +1,96 @@
+package v6_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// paramsOrder 使用结构化参数报告区间错误的模型
+type paramsOrder struct {
+	Amount   int `json:"amount"`
+	Quantity int `json:"quantity"`
+}
+
+// ValidateBusiness 实现 IBusinessValidator 接口
+func (o *paramsOrder) ValidateBusiness(scene core.Scene, collector core.IErrorCollector) {
+	between := map[string]any{"min": 1, "max": 100}
+	var reported []core.IFieldError
+	if o.Amount < 1 || o.Amount > 100 {
+		reported = append(reported, v6.NewFieldError("paramsOrder.amount", "amount", "params_test_between",
+			v6.WithValue(o.Amount), v6.WithParams(between)))
+	}
+	if o.Quantity > 10 {
+		reported = append(reported, v6.NewFieldError("paramsOrder.quantity", "quantity", "params_test_between",
+			v6.WithValue(o.Quantity), v6.WithParams(map[string]any{"min": 1, "max": 10}),
+			v6.WithSeverity(core.SeverityWarning)))
+	}
+	v6.Report(collector, reported...)
+}
+
+// TestParams_MessageAndJSON 结构化参数用于消息模板并随 JSON 输出
+func TestParams_MessageAndJSON(t *testing.T) {
+	v6.RegisterMessage("", "params_test_between", "{field} must be between {min} and {max}, got {value}")
+
+	validator := v6.NewBuilder().WithBusinessStrategy(10).Build()
+	err := validator.Validate(&paramsOrder{Amount: 250, Quantity: 12}, SceneCreate)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	fieldErrors := err.FieldErrors()
+	if len(fieldErrors) != 1 {
+		t.Fatalf("errors = %d", len(fieldErrors))
+	}
+	if got := fieldErrors[0].Message(); got != "amount must be between 1 and 100, got 250" {
+		t.Errorf("message = %q", got)
+	}
+	if got := v6.ParamsOf(fieldErrors[0]); !reflect.DeepEqual(got, map[string]any{"min": 1, "max": 100}) {
+		t.Errorf("params = %v", got)
+	}
+
+	// 非阻断错误收集为警告
+	warnings := err.Warnings()
+	if len(warnings) != 1 || warnings[0].Message() != "quantity must be between 1 and 10, got 12" {
+		t.Errorf("warnings = %v", warnings)
+	}
+	if got := v6.ParamsOf(warnings[0]); got["max"] != 10 {
+		t.Errorf("warning params = %v", got)
+	}
+
+	data, jsonErr := json.Marshal(fieldErrors[0])
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+	if !strings.Contains(string(data), `"params":{"max":100,"min":1}`) {
+		t.Errorf("json = %s", data)
+	}
+
+	unified := v6.ToUnified(err)
+	if got := unified.Errors[0].Params; !reflect.DeepEqual(got, map[string]any{"min": 1, "max": 100}) {
+		t.Errorf("unified params = %v", got)
+	}
+}
+
+// TestParams_BuiltinPlaceholdersWin 内置占位符优先于同名参数，参数 map 被复制
+func TestParams_BuiltinPlaceholdersWin(t *testing.T) {
+	v6.RegisterMessage("", "params_test_shadow", "{field}: {min}")
+
+	params := map[string]any{"field": "ignored", "min": 3}
+	fe := v6.NewFieldError("Shadow.name", "name", "params_test_shadow", v6.WithParams(params))
+	params["min"] = 99
+
+	if got := fe.Message(); got != "name: 3" {
+		t.Errorf("message = %q", got)
+	}
+
+	formatter := v6.NewLocaleFormatter("de-DE")
+	big := v6.NewFieldError("Shadow.name", "name", "params_test_shadow", v6.WithParams(map[string]any{"min": 12000}))
+	if got := formatter.Format(big); got != "name: 12.000" {
+		t.Errorf("locale message = %q", got)
+	}
+}
@@ -0,0 +1,209 @@
+package v6_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// ============================================================================
+// 测试数据
+// ============================================================================
+
+// testPlugin 可配置依赖、优先级和安装内容的插件
+type testPlugin struct {
+	name     string
+	priority int
+	requires []string
+	install  func(host core.IPluginHost) error
+	order    *[]string
+}
+
+func (p *testPlugin) Name() string       { return p.name }
+func (p *testPlugin) Priority() int      { return p.priority }
+func (p *testPlugin) Requires() []string { return p.requires }
+
+func (p *testPlugin) Install(host core.IPluginHost) error {
+	if p.order != nil {
+		*p.order = append(*p.order, p.name)
+	}
+	if p.install == nil {
+		return nil
+	}
+	return p.install(host)
+}
+
+// slugModel 使用插件规则的模型
+type slugModel struct {
+	Slug string `json:"slug"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (m *slugModel) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{"slug": "slug_std"}
+}
+
+// slugPlugins 注册 slug 规则的插件和依赖它的别名插件
+func slugPlugins() []core.IPlugin {
+	return []core.IPlugin{
+		&testPlugin{name: "slug_alias", requires: []string{"slug"}, install: func(host core.IPluginHost) error {
+			return host.RegisterAlias("slug_std", "required,slug")
+		}},
+		&testPlugin{name: "slug", install: func(host core.IPluginHost) error {
+			return host.RegisterValidation("slug", func(value any, _ string) bool {
+				s, _ := value.(string)
+				return s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyz0123456789-") == ""
+			})
+		}},
+	}
+}
+
+// ============================================================================
+// 安装顺序
+// ============================================================================
+
+// TestPlugins_InstallOrder 测试先满足依赖，再按优先级，最后按注册顺序安装
+func TestPlugins_InstallOrder(t *testing.T) {
+	var order []string
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithPlugins(
+			&testPlugin{name: "audit", priority: -1, requires: []string{"metrics"}, order: &order},
+			&testPlugin{name: "metrics", priority: 5, order: &order},
+			&testPlugin{name: "locale", priority: 1, order: &order},
+			&testPlugin{name: "geo", priority: 1, order: &order},
+		).
+		Build()
+
+	want := []string{"locale", "geo", "metrics", "audit"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("安装顺序 = %v, want %v", order, want)
+	}
+	if got := validator.(v6.PluginManager).Plugins(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Plugins() = %v, want %v", got, want)
+	}
+}
+
+// TestPlugins_BuildErrors 测试依赖缺失、循环依赖和规则重名在构建时报错
+func TestPlugins_BuildErrors(t *testing.T) {
+	claim := func(name string) func(core.IPluginHost) error {
+		return func(host core.IPluginHost) error { return host.RegisterAlias(name, "required") }
+	}
+
+	tests := []struct {
+		name    string
+		plugins []core.IPlugin
+		wantErr string
+	}{
+		{"依赖缺失", []core.IPlugin{&testPlugin{name: "a", requires: []string{"b"}}}, `requires missing plugin "b"`},
+		{"循环依赖", []core.IPlugin{
+			&testPlugin{name: "a", requires: []string{"b"}},
+			&testPlugin{name: "b", requires: []string{"a"}},
+		}, "dependency cycle"},
+		{"名称重复", []core.IPlugin{&testPlugin{name: "a"}, &testPlugin{name: "a"}}, "already added"},
+		{"规则重名", []core.IPlugin{
+			&testPlugin{name: "a", install: claim("plugin_conflict")},
+			&testPlugin{name: "b", install: claim("plugin_conflict")},
+		}, `rule "plugin_conflict" already registered by plugin "a"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if err, ok := r.(error); !ok || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Build() panic = %v, want %q", r, tt.wantErr)
+				}
+			}()
+			v6.NewBuilder().WithRuleStrategy(10).WithPlugins(tt.plugins...).Build()
+		})
+	}
+}
+
+// ============================================================================
+// 运行时启用/禁用
+// ============================================================================
+
+// TestPlugins_EnableDisable 测试禁用后规则直接通过，依赖约束阻止不一致的状态
+func TestPlugins_EnableDisable(t *testing.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).WithPlugins(slugPlugins()...).Build()
+	manager := validator.(v6.PluginManager)
+	bad := &slugModel{Slug: "Not A Slug"}
+
+	// 别名规则失败时标签为别名
+	if got := tags(validator.Validate(bad, SceneCreate)); !reflect.DeepEqual(got, []string{"slug: slug_std"}) {
+		t.Fatalf("Validate() = %v, want slug_std error", got)
+	}
+
+	// 被已启用的插件依赖时不能禁用
+	if err := manager.DisablePlugin("slug"); err == nil || !strings.Contains(err.Error(), `required by enabled plugin "slug_alias"`) {
+		t.Errorf("DisablePlugin(slug) = %v", err)
+	}
+	if err := manager.DisablePlugin("slug_alias"); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.DisablePlugin("slug"); err != nil {
+		t.Fatal(err)
+	}
+	if manager.IsPluginEnabled("slug") {
+		t.Error("IsPluginEnabled(slug) = true after disable")
+	}
+
+	// 禁用后规则直接通过，别名仍展开为底层规则（required 仍生效）
+	if err := validator.Validate(bad, SceneCreate); err != nil {
+		t.Errorf("禁用后 Validate() = %v", tags(err))
+	}
+	if got := tags(validator.Validate(&slugModel{}, SceneCreate)); !reflect.DeepEqual(got, []string{"slug: slug_std"}) {
+		t.Errorf("禁用后 Validate(empty) = %v, want slug_std", got)
+	}
+
+	// 依赖的插件未启用时不能启用
+	if err := manager.EnablePlugin("slug_alias"); err == nil || !strings.Contains(err.Error(), `requires disabled plugin "slug"`) {
+		t.Errorf("EnablePlugin(slug_alias) = %v", err)
+	}
+	if err := manager.EnablePlugin("slug"); err != nil {
+		t.Fatal(err)
+	}
+	if got := tags(validator.Validate(bad, SceneCreate)); !reflect.DeepEqual(got, []string{"slug: slug_std"}) {
+		t.Errorf("重新启用后 Validate() = %v", got)
+	}
+
+	if err := manager.EnablePlugin("missing"); err == nil {
+		t.Error("EnablePlugin(missing) = nil, want error")
+	}
+}
+
+// TestPlugins_Strategy 测试插件注册的策略随插件禁用而跳过
+func TestPlugins_Strategy(t *testing.T) {
+	calls := 0
+	audit := &testPlugin{name: "audit", install: func(host core.IPluginHost) error {
+		host.RegisterStrategy(&countingStrategy{calls: &calls}, 50)
+		return nil
+	}}
+	validator := v6.NewBuilder().WithRuleStrategy(10).WithPlugins(audit).Build()
+
+	_ = validator.Validate(&slugModel{Slug: "ok"}, SceneCreate)
+	if err := validator.(v6.PluginManager).DisablePlugin("audit"); err != nil {
+		t.Fatal(err)
+	}
+	_ = validator.Validate(&slugModel{Slug: "ok"}, SceneCreate)
+	if calls != 1 {
+		t.Errorf("strategy calls = %d, want 1", calls)
+	}
+}
+
+// countingStrategy 记录调用次数的策略
+type countingStrategy struct {
+	calls *int
+}
+
+func (s *countingStrategy) Type() core.StrategyType { return core.StrategyType("plugin_audit") }
+func (s *countingStrategy) Name() string            { return "plugin_audit" }
+
+func (s *countingStrategy) Validate(any, core.IContext, core.IErrorCollector) error {
+	*s.calls++
+	return nil
+}
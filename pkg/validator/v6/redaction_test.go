@@ -0,0 +1,51 @@
+package v6_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+)
+
+// identity 带敏感标签的模型
+type identity struct {
+	Name     string `json:"name"`
+	IDCard   string `json:"id_card" sensitive:"true"`
+	Password string `json:"password"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (m *identity) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{
+		"name":     "min=3",
+		"id_card":  "len=18",
+		"password": "min=8",
+	}
+}
+
+// TestSensitiveTag 测试 sensitive 标签和默认字段名规则在验证结果中脱敏
+func TestSensitiveTag(t *testing.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+	err := validator.Validate(&identity{Name: "al", IDCard: "110101", Password: "hunter2"}, SceneCreate)
+	if err == nil || len(err.FieldErrors()) != 3 {
+		t.Fatalf("Validate() = %v, want three errors", err)
+	}
+
+	want := map[string]any{"name": "al", "id_card": errors.RedactedValue, "password": errors.RedactedValue}
+	for _, fe := range err.FieldErrors() {
+		if got := v6.RedactValue(fe); got != want[fe.Field()] {
+			t.Errorf("RedactValue(%s) = %v, want %v", fe.Field(), got, want[fe.Field()])
+		}
+	}
+
+	data, marshalErr := json.Marshal(err.FieldErrors())
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	if out := string(data); strings.Contains(out, "110101") || strings.Contains(out, "hunter2") || !strings.Contains(out, `"al"`) {
+		t.Errorf("MarshalJSON() = %s", out)
+	}
+}
@@ -0,0 +1,118 @@
+package v6_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/rules"
+)
+
+// TestRegex_Cache 相同模式只编译一次，失败结果同样缓存
+func TestRegex_Cache(t *testing.T) {
+	v6.SetRegexCacheSize(16)
+
+	a, err := v6.CompileRegex(`^[a-z]+$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := v6.CompileRegex(`^[a-z]+$`)
+	if a != b {
+		t.Error("相同模式应返回缓存的 *regexp.Regexp")
+	}
+
+	_, err1 := v6.CompileRegex(`(`)
+	_, err2 := v6.CompileRegex(`(`)
+	if err1 == nil || err1 != err2 {
+		t.Errorf("无效模式: err1=%v err2=%v", err1, err2)
+	}
+
+	if stats := v6.RegexCacheStats(); stats.Size != 2 || stats.Hits != 2 {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	// 不同限制分别缓存
+	c, err := v6.CompileRegexWithPolicy(`^[a-z]+$`, v6.UntrustedRegexPolicy)
+	if err != nil || c == a {
+		t.Errorf("不同限制应分别编译: %v", err)
+	}
+
+	// LRU 淘汰
+	for i := 0; i < 32; i++ {
+		_, _ = v6.CompileRegex(strings.Repeat("a", i+1))
+	}
+	if stats := v6.RegexCacheStats(); stats.Size != 16 {
+		t.Errorf("Size = %d, want 16", stats.Size)
+	}
+}
+
+// TestRegex_UntrustedPolicy 用户提供的模式超出限制时拒绝
+func TestRegex_UntrustedPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		tooBig  bool // 超出限制（ErrRegexTooComplex），否则为语法错误
+	}{
+		{"长度", strings.Repeat("a", 300), true},
+		{"重复次数", `a{500}`, true},
+		{"嵌套", `((a+)+)+`, true},
+		{"指令数", `((ab|cd|ef|gh){90}){4}`, true},
+		{"Perl 扩展", `\d+`, false},
+		{"标志", `(?i)abc`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v6.CompileRegexWithPolicy(tt.pattern, v6.UntrustedRegexPolicy)
+			if err == nil {
+				t.Fatal("应该拒绝")
+			}
+			if got := errors.Is(err, v6.ErrRegexTooComplex); got != tt.tooBig {
+				t.Errorf("err = %v", err)
+			}
+			// 开发者模式不受这些限制（长度和指令数在默认上限内）
+			if _, err := v6.CompileRegex(tt.pattern); err != nil {
+				t.Errorf("CompileRegex(%q) error = %v", tt.pattern, err)
+			}
+		})
+	}
+
+	if _, err := v6.CompileRegexWithPolicy(`^[A-Z]{2}-[0-9]{4,8}$`, v6.UntrustedRegexPolicy); err != nil {
+		t.Errorf("普通模式 error = %v", err)
+	}
+}
+
+// skuItem 使用 regex 规则的模型
+type skuItem struct {
+	SKU  string `json:"sku"`
+	Code string `json:"code"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (s *skuItem) ValidateRules(scene core.Scene) map[string]string {
+	return rules.New().
+		Field("sku", rules.Required(), rules.Regex(`^(AB|CD)-[0-9]{2,4}$`)).
+		Field("code", rules.Omitempty(), rules.Regex(`^\d+$`)).
+		Build()
+}
+
+// TestRegex_Rule regex 规则（逗号和竖线由 rules.Regex 转义）
+func TestRegex_Rule(t *testing.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).Build()
+
+	if err := validator.Validate(&skuItem{SKU: "CD-123", Code: "42"}, SceneCreate); err != nil {
+		t.Errorf("valid: %v", err)
+	}
+	err := validator.Validate(&skuItem{SKU: "XY-1", Code: "4a"}, SceneCreate)
+	if err == nil || len(err.FieldErrors()) != 2 || err.FieldErrors()[0].Tag() != "regex" {
+		t.Fatalf("invalid: %v", err)
+	}
+
+	// 不可信模式限制下 \d 不被接受，规则验证失败
+	strict := v6.NewBuilder().WithRuleStrategy(10).WithRegexPolicy(v6.UntrustedRegexPolicy).Build()
+	err = strict.Validate(&skuItem{SKU: "CD-123", Code: "42"}, SceneCreate)
+	if err == nil || len(err.FieldErrors()) != 1 || err.FieldErrors()[0].Field() != "code" {
+		t.Errorf("strict: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// ============================================================================
+// CSV
+// ============================================================================
+
+// utf8BOM UTF-8 字节顺序标记
+const utf8BOM = "\ufeff"
+
+// WriteCSV 将报告以 CSV 格式写入 w（含表头）
+// 说明：开头写入 UTF-8 BOM，Excel 直接打开时中文不会乱码
+func (e *Exporter) WriteCSV(w io.Writer, results []core.IValidationError) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(e.Header()); err != nil {
+		return err
+	}
+
+	err := e.eachRow(results, func(row Row) error {
+		cells := e.cells(row)
+		for i := range cells {
+			cells[i] = escapeFormula(cells[i])
+		}
+		return cw.Write(cells)
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// escapeFormula 防止 CSV 注入：以公式字符开头的单元格加 ' 前缀
+func escapeFormula(cell string) string {
+	if cell == "" {
+		return cell
+	}
+	switch cell[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + cell
+	}
+	return cell
+}
@@ -0,0 +1,131 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"katydid-common-account/pkg/validator/v6/v6test"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// 测试数据
+// ============================================================================
+
+// batchResults 三条记录：第 0 条两个错误，第 1 条通过，第 2 条一个错误和一个警告
+func batchResults() []core.IValidationError {
+	return []core.IValidationError{
+		v6test.NewResult(
+			v6test.NewError("User.email", "email"),
+			v6test.NewError("User.Extras.brand", "required"),
+		),
+		nil,
+		v6test.NewResult(
+			v6test.NewError("User.name", "min", errors.WithParam("2")),
+			v6test.NewError("User.nick", "len", errors.WithSeverity(core.SeverityWarning)),
+		),
+	}
+}
+
+// ============================================================================
+// 测试
+// ============================================================================
+
+func TestExporter_Rows(t *testing.T) {
+	rows := NewExporter(WithRecordOffset(2)).Rows(batchResults())
+
+	got := make([]string, 0, len(rows))
+	for _, row := range rows {
+		got = append(got, strings.Join([]string{strconv.Itoa(row.Record), row.Field, row.Tag}, "|"))
+	}
+	want := []string{"2|email|email", "2|Extras.brand|required", "4|name|min"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("rows = %v, want %v", got, want)
+	}
+	if rows[2].Message == "" {
+		t.Errorf("expected formatted message, got empty")
+	}
+}
+
+func TestExporter_WriteCSV(t *testing.T) {
+	results := append(batchResults(), v6test.NewResult(
+		v6test.NewError("User.note", "custom", errors.WithMessage("=HYPERLINK(\"x\")")),
+	))
+
+	var buf bytes.Buffer
+	exporter := NewExporter(WithHeader("行号", "字段", "规则", "错误信息"), WithWarnings("级别"))
+	if err := exporter.WriteCSV(&buf, results); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+
+	data := buf.Bytes()
+	if !bytes.HasPrefix(data, []byte(utf8BOM)) {
+		t.Fatalf("expected UTF-8 BOM")
+	}
+	records, err := csv.NewReader(bytes.NewReader(data[len(utf8BOM):])).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+
+	if want := []string{"行号", "字段", "规则", "错误信息", "级别"}; !reflect.DeepEqual(records[0], want) {
+		t.Errorf("header = %v, want %v", records[0], want)
+	}
+	if len(records) != 6 {
+		t.Fatalf("got %d records, want 6 (header + 3 errors + 1 warning + 1 injected): %v", len(records), records)
+	}
+	if warning := records[4]; warning[1] != "nick" || warning[4] != "warning" {
+		t.Errorf("warning row = %v", warning)
+	}
+	if injected := records[5][3]; !strings.HasPrefix(injected, "'=") {
+		t.Errorf("formula not escaped: %q", injected)
+	}
+}
+
+func TestExporter_WriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewExporter().WriteXLSX(&buf, batchResults()); err != nil {
+		t.Fatalf("WriteXLSX: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("open xlsx: %v", err)
+	}
+
+	var sheet string
+	for _, f := range zr.File {
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open sheet: %v", err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		sheet = string(data)
+	}
+
+	if strings.Count(sheet, "<row ") != 4 {
+		t.Errorf("expected header + 3 rows, got sheet %s", sheet)
+	}
+	for _, want := range []string{`<c r="A2"><v>0</v></c>`, `>Extras.brand<`, `<c r="A4"><v>2</v></c>`} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %s", want)
+		}
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 4: "E", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(index); got != want {
+			t.Errorf("columnName(%d) = %s, want %s", index, got, want)
+		}
+	}
+}
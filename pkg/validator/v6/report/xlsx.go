@@ -0,0 +1,138 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"katydid-common-account/pkg/pool"
+	"katydid-common-account/pkg/validator/v6/core"
+	"strconv"
+)
+
+// ============================================================================
+// XLSX
+// ============================================================================
+
+// xlsxStaticParts XLSX 中与数据无关的部分
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="errors" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// WriteXLSX 将报告以 XLSX 格式写入 w（单个工作表 errors，含表头）
+// 说明：只使用标准库生成最小的 SpreadsheetML；记录序号为数字单元格，其余为内联字符串
+// （内联字符串不会被当作公式，无需转义）
+func (e *Exporter) WriteXLSX(w io.Writer, results []core.IValidationError) error {
+	zw := zip.NewWriter(w)
+
+	// 步骤1：写入固定部分
+	for _, part := range xlsxStaticParts {
+		fw, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, part.content); err != nil {
+			return err
+		}
+	}
+
+	// 步骤2：逐行写入工作表
+	fw, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	buf := pool.GetBuffer()
+	defer pool.PutBuffer(buf)
+	sheet := &sheetWriter{w: fw, buf: buf}
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	sheet.writeRow(e.Header(), false)
+	err = e.eachRow(results, func(row Row) error {
+		sheet.writeRow(e.cells(row), true)
+		return sheet.err
+	})
+	if err != nil {
+		return err
+	}
+
+	sheet.WriteString(`</sheetData></worksheet>`)
+	if sheet.err != nil {
+		return sheet.err
+	}
+
+	// 步骤3：写入 zip 目录
+	return zw.Close()
+}
+
+// sheetWriter 工作表写入器（记录第一个写入错误）
+type sheetWriter struct {
+	w   io.Writer
+	buf *bytes.Buffer // 行缓冲区，逐行复用
+	row int
+	err error
+}
+
+// WriteString 写入字符串
+func (s *sheetWriter) WriteString(str string) {
+	if s.err != nil {
+		return
+	}
+	_, s.err = io.WriteString(s.w, str)
+}
+
+// writeRow 写入一行；numericFirst 为 true 时第一列写为数字
+func (s *sheetWriter) writeRow(cells []string, numericFirst bool) {
+	if s.err != nil {
+		return
+	}
+	s.row++
+	b := s.buf
+	b.Reset()
+	b.WriteString(`<row r="`)
+	b.WriteString(strconv.Itoa(s.row))
+	b.WriteString(`">`)
+	for i, cell := range cells {
+		ref := columnName(i) + strconv.Itoa(s.row)
+		if i == 0 && numericFirst {
+			b.WriteString(`<c r="` + ref + `"><v>` + cell + `</v></c>`)
+			continue
+		}
+		b.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">`)
+		_ = xml.EscapeText(b, []byte(cell))
+		b.WriteString(`</t></is></c>`)
+	}
+	b.WriteString(`</row>`)
+	_, s.err = s.w.Write(b.Bytes())
+}
+
+// columnName 列序号转列名（0 -> A，25 -> Z，26 -> AA）
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
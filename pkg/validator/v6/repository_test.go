@@ -0,0 +1,182 @@
+package v6_test
+
+import (
+	stdcontext "context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// ============================================================================
+// 测试数据
+// ============================================================================
+
+// account 使用仓储规则的模型
+type account struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Alias    string `json:"alias"`
+	Nick     string `json:"nick"`
+	RoleID   int    `json:"role_id"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (a *account) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{
+		"username": "required,unique=users.username",
+		"alias":    "unique=users.username",
+		"nick":     "unique=profiles.nick",
+		"role_id":  "exists=roles.id",
+	}
+}
+
+// RecordID 实现 IRecordIdentifier 接口
+func (a *account) RecordID() any {
+	return a.ID
+}
+
+// repoCall 一次检查器调用
+type repoCall struct {
+	table, column string
+	values        []any
+	exclude       any
+}
+
+// fakeRepo 内存中的检查器：owners 记录已占用的值及其所属记录
+type fakeRepo struct {
+	owners map[any]int64
+
+	mu    sync.Mutex
+	calls []repoCall
+}
+
+func (r *fakeRepo) record(table, column string, values []any, exclude any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, repoCall{table: table, column: column, values: values, exclude: exclude})
+}
+
+func (r *fakeRepo) CheckUnique(_ stdcontext.Context, table, column string, values []any, exclude any) (map[any]bool, error) {
+	r.record(table, column, values, exclude)
+	taken := make(map[any]bool)
+	for _, v := range values {
+		if owner, ok := r.owners[v]; ok && owner != exclude {
+			taken[v] = true
+		}
+	}
+	return taken, nil
+}
+
+func (r *fakeRepo) CheckExists(_ stdcontext.Context, table, column string, values []any) (map[any]bool, error) {
+	r.record(table, column, values, nil)
+	found := make(map[any]bool)
+	for _, v := range values {
+		if _, ok := r.owners[v]; ok {
+			found[v] = true
+		}
+	}
+	return found, nil
+}
+
+// tags 返回 "字段: 标签" 列表
+func tags(err core.IValidationError) []string {
+	if err == nil {
+		return nil
+	}
+	var got []string
+	for _, fe := range err.FieldErrors() {
+		got = append(got, fe.Field()+": "+fe.Tag())
+	}
+	return got
+}
+
+// ============================================================================
+// 仓储规则
+// ============================================================================
+
+// TestRepository_Batching 测试相同 表.列 的值合并为一次查询，并按表查找检查器
+func TestRepository_Batching(t *testing.T) {
+	users := &fakeRepo{owners: map[any]int64{"taken": 9}}
+	fallback := &fakeRepo{owners: map[any]int64{}}
+	roles := &fakeRepo{owners: map[any]int64{1: 0}}
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithRepositoryStrategy(30).
+		WithUniquenessChecker("users", users).
+		WithUniquenessChecker("", fallback).
+		WithExistenceChecker("", roles).
+		Build()
+
+	err := validator.Validate(&account{Username: "taken", Alias: "free", Nick: "bob", RoleID: 2}, SceneCreate)
+	want := []string{"username: unique", "role_id: exists"}
+	if got := tags(err); !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() = %v, want %v", got, want)
+	}
+
+	// users.username 的两个值合并为一次查询
+	if len(users.calls) != 1 || users.calls[0].column != "username" || len(users.calls[0].values) != 2 {
+		t.Errorf("users calls = %+v, want one batched call", users.calls)
+	}
+	// 未单独注册的表使用默认检查器
+	if len(fallback.calls) != 1 || fallback.calls[0].table != "profiles" {
+		t.Errorf("default checker calls = %+v, want profiles", fallback.calls)
+	}
+	if len(roles.calls) != 1 || roles.calls[0].table != "roles" {
+		t.Errorf("existence calls = %+v, want roles", roles.calls)
+	}
+}
+
+// TestRepository_ExcludeSelf 测试更新时记录自身占用的值不算冲突
+func TestRepository_ExcludeSelf(t *testing.T) {
+	repo := &fakeRepo{owners: map[any]int64{"alice": 7, "bob": 8, 1: 0}}
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithUniquenessChecker("", repo).
+		WithExistenceChecker("", repo).
+		Build()
+
+	if err := validator.Validate(&account{ID: 7, Username: "alice", RoleID: 1}, SceneUpdate); err != nil {
+		t.Errorf("自身的值不应冲突: %v", tags(err))
+	}
+	if got := tags(validator.Validate(&account{ID: 7, Username: "bob", RoleID: 1}, SceneUpdate)); !reflect.DeepEqual(got, []string{"username: unique"}) {
+		t.Errorf("他人的值应冲突: %v", got)
+	}
+	if got := tags(validator.Validate(&account{Username: "alice", RoleID: 1}, SceneCreate)); !reflect.DeepEqual(got, []string{"username: unique"}) {
+		t.Errorf("新记录不排除任何值: %v", got)
+	}
+
+	for _, call := range repo.calls {
+		if call.table == "users" && call.exclude != nil && call.exclude != int64(7) {
+			t.Errorf("exclude = %v, want 7 or nil", call.exclude)
+		}
+	}
+}
+
+// TestRepository_MissingChecker 测试未注册检查器时返回错误
+func TestRepository_MissingChecker(t *testing.T) {
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithUniquenessChecker("", &fakeRepo{}).
+		Build()
+
+	err := validator.Validate(&account{Username: "alice", RoleID: 1}, SceneCreate)
+	if err == nil || !strings.Contains(err.Error(), `no existence checker registered for table "roles"`) {
+		t.Errorf("Validate() = %v, want missing existence checker error", err)
+	}
+}
+
+// TestRepository_StrategyRequired 测试未启用仓储策略时仓储规则不会被静默忽略
+func TestRepository_StrategyRequired(t *testing.T) {
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		Build()
+
+	err := validator.Validate(&account{Username: "alice", RoleID: 1}, SceneCreate)
+	if err == nil || !strings.Contains(err.Error(), core.ErrRepositoryStrategyRequired.Error()) {
+		t.Errorf("Validate() = %v, want ErrRepositoryStrategyRequired", err)
+	}
+}
@@ -0,0 +1,122 @@
+package v6_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/strategy"
+)
+
+// TestWithRuleSource 测试外部规则按字段覆盖模型规则，轮询后新版本自动生效
+func TestWithRuleSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	writeRules := func(version string, minLen int) {
+		t.Helper()
+		content := fmt.Sprintf(`{"version":%q,"models":{"User":{"%d":{"username":"required,min=%d"}}}}`, version, SceneCreate, minLen)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeRules("v1", 4)
+
+	builder := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithRuleSource(v6.NewFileRuleSource(path), 5*time.Millisecond)
+	validator := builder.Build()
+	t.Cleanup(builder.RuleManager().Stop)
+
+	user := &User{Username: "tom", Email: "tom@example.com", Password: "secret1", Age: 20}
+	err := validator.Validate(user, SceneCreate)
+	if err == nil || len(err.FieldErrors()) != 1 || err.FieldErrors()[0].Tag() != "min" || err.RuleVersion() != "v1" {
+		t.Fatalf("Validate() = %v, want min error from rule version v1", err)
+	}
+
+	// 外部规则放宽后，模型中其他字段的规则仍然生效
+	writeRules("v2", 2)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		user.Age = 10
+		err = validator.Validate(user, SceneCreate)
+		if err != nil && err.RuleVersion() == "v2" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Validate() = %v, want rule version v2", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := tags(err); len(got) != 1 || got[0] != "age: gte" {
+		t.Errorf("Validate() = %v, want only the model rule for age", got)
+	}
+}
+
+// TestWithRuleSource_Lifecycle 测试规则管理器在 Build 时创建，首次加载错误可获取，多次 Build 共用
+func TestWithRuleSource_Lifecycle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.json")
+	builder := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithRuleSource(v6.NewFileRuleSource(path), time.Hour)
+	if builder.RuleManager() != nil {
+		t.Fatal("Build 之前不应创建规则管理器")
+	}
+
+	validator := builder.Build()
+	manager := builder.RuleManager()
+	if manager == nil {
+		t.Fatal("Build 之后 RuleManager() = nil")
+	}
+	t.Cleanup(manager.Stop)
+	if manager.LastError() == nil {
+		t.Error("规则文件不存在时 LastError() 应返回首次加载的错误")
+	}
+
+	// 加载失败时回退到模型内置规则
+	err := validator.Validate(&User{Username: "tom", Email: "tom@example.com", Password: "secret1", Age: 20}, SceneCreate)
+	if err != nil {
+		t.Errorf("Validate() = %v, want 使用模型内置规则通过", err)
+	}
+
+	// 多次 Build 共用同一个规则管理器
+	builder.Build()
+	if builder.RuleManager() != manager {
+		t.Error("多次 Build 应共用同一个规则管理器")
+	}
+
+	// 改用 WithRuleProvider 时不再使用规则源
+	builder.WithRuleProvider(staticRuleProvider{"username": "required,min=5"})
+	if got := tags(builder.Build().Validate(&User{Username: "tom", Email: "tom@example.com", Password: "secret1", Age: 20}, SceneCreate)); !equalStrings(got, []string{"username: min"}) {
+		t.Errorf("WithRuleProvider 之后 errors = %v, want [username: min]", got)
+	}
+}
+
+// TestRuleVersion_Parallel 测试并行执行时多个策略同时记录规则版本号（配合 -race）
+func TestRuleVersion_Parallel(t *testing.T) {
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithRepositoryStrategy(20).
+		WithEmailDeepStrategy(30, strategy.WithoutMXLookup()).
+		WithRuleProvider(staticRuleProvider{"username": "required,min=5"}).
+		WithExecutionMode(v6.ExecutionModeParallel).
+		Build()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				err := validator.Validate(&User{Username: "bob", Email: "bob@example.com", Password: "secret1", Age: 20}, SceneCreate)
+				if err == nil || err.RuleVersion() != "v1" {
+					t.Errorf("Validate() = %v, want min error from rule version v1", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,323 @@
+// Package rulediff 规则版本对比
+//
+// 发布前对比新旧两个版本的规则，找出会让旧数据验证失败的变化：
+// 新增的必填字段、收紧的约束（min 变大、max 变小等）、被移除的场景。
+// 对比结果可直接序列化为 JSON，供 CI 或发布检查使用。
+package rulediff
+
+import (
+	"context"
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// 对比结果
+// ============================================================================
+
+// ChangeKind 变化类型
+type ChangeKind string
+
+const (
+	ChangeRequiredAdded       ChangeKind = "required_added"       // 字段变为必填（破坏性）
+	ChangeRequiredRemoved     ChangeKind = "required_removed"     // 字段不再必填
+	ChangeConstraintAdded     ChangeKind = "constraint_added"     // 新增约束（破坏性）
+	ChangeConstraintRemoved   ChangeKind = "constraint_removed"   // 移除约束
+	ChangeConstraintTightened ChangeKind = "constraint_tightened" // 约束收紧，如 min 变大、max 变小（破坏性）
+	ChangeConstraintLoosened  ChangeKind = "constraint_loosened"  // 约束放宽
+	ChangeConstraintChanged   ChangeKind = "constraint_changed"   // 参数变化且无法判断方向（按破坏性处理）
+	ChangeSceneAdded          ChangeKind = "scene_added"          // 新增场景
+	ChangeSceneRemoved        ChangeKind = "scene_removed"        // 场景被移除（破坏性）
+)
+
+// Change 一条规则变化
+type Change struct {
+	Kind     ChangeKind `json:"kind"`
+	Model    string     `json:"model"`
+	Scene    core.Scene `json:"scene"`
+	Field    string     `json:"field,omitempty"`
+	Tag      string     `json:"tag,omitempty"` // dive 之后的规则带 "dive." 前缀
+	OldParam string     `json:"old_param,omitempty"`
+	NewParam string     `json:"new_param,omitempty"`
+	Breaking bool       `json:"breaking"` // 是否可能让旧版本验证通过的数据验证失败
+}
+
+// String 实现 Stringer 接口
+func (c Change) String() string {
+	target := fmt.Sprintf("%s[%d]", c.Model, c.Scene)
+	if c.Field != "" {
+		target += "." + c.Field
+	}
+	if c.Tag == "" {
+		return fmt.Sprintf("%s %s", c.Kind, target)
+	}
+	return fmt.Sprintf("%s %s %s: %q -> %q", c.Kind, target, c.Tag, c.OldParam, c.NewParam)
+}
+
+// Diff 规则对比结果
+type Diff struct {
+	OldVersion string   `json:"old_version,omitempty"`
+	NewVersion string   `json:"new_version,omitempty"`
+	Changes    []Change `json:"changes"` // 按模型、场景、字段、标签排序
+}
+
+// HasBreaking 是否存在破坏性变化
+func (d *Diff) HasBreaking() bool {
+	for _, change := range d.Changes {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Breaking 获取所有破坏性变化
+func (d *Diff) Breaking() []Change {
+	var breaking []Change
+	for _, change := range d.Changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		}
+	}
+	return breaking
+}
+
+// ============================================================================
+// 对比
+// ============================================================================
+
+// CompareRules 加载新旧两个版本的规则并对比
+// 说明：规则提供者可以是文件、HTTP、键值存储规则源，也可以是 ModelRuleSource（模型内置规则）
+func CompareRules(ctx context.Context, oldProvider, newProvider core.IRuleSource) (*Diff, error) {
+	oldSet, err := oldProvider.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load old rules: %w", err)
+	}
+	newSet, err := newProvider.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load new rules: %w", err)
+	}
+	return CompareRuleSets(oldSet, newSet), nil
+}
+
+// CompareRuleSets 对比两个规则集
+// 说明：按规则集中声明的场景键逐一对比，不做场景位运算合并
+func CompareRuleSets(oldSet, newSet *core.RuleSet) *Diff {
+	if oldSet == nil {
+		oldSet = &core.RuleSet{}
+	}
+	if newSet == nil {
+		newSet = &core.RuleSet{}
+	}
+
+	diff := &Diff{OldVersion: oldSet.Version, NewVersion: newSet.Version, Changes: []Change{}}
+	for model, oldScenes := range oldSet.Models {
+		newScenes := newSet.Models[model]
+		for scene, oldRules := range oldScenes {
+			newRules, ok := newScenes[scene]
+			if !ok {
+				diff.Changes = append(diff.Changes, Change{Kind: ChangeSceneRemoved, Model: model, Scene: scene, Breaking: true})
+				continue
+			}
+			diff.Changes = append(diff.Changes, compareScene(model, scene, oldRules, newRules)...)
+		}
+	}
+
+	// 新增的场景：没有旧规则可比，其中的必填字段与约束同样可能让旧数据失败
+	for model, newScenes := range newSet.Models {
+		for scene, newRules := range newScenes {
+			if _, ok := oldSet.Models[model][scene]; ok {
+				continue
+			}
+			diff.Changes = append(diff.Changes, Change{Kind: ChangeSceneAdded, Model: model, Scene: scene})
+			diff.Changes = append(diff.Changes, compareScene(model, scene, nil, newRules)...)
+		}
+	}
+
+	sortChanges(diff.Changes)
+	return diff
+}
+
+// compareScene 对比同一场景下的字段规则
+func compareScene(model string, scene core.Scene, oldRules, newRules map[string]string) []Change {
+	var changes []Change
+	fields := make(map[string]struct{}, len(oldRules)+len(newRules))
+	for field := range oldRules {
+		fields[field] = struct{}{}
+	}
+	for field := range newRules {
+		fields[field] = struct{}{}
+	}
+
+	for field := range fields {
+		oldTags := parseRule(oldRules[field])
+		newTags := parseRule(newRules[field])
+
+		for tag, newParam := range newTags {
+			oldParam, ok := oldTags[tag]
+			change := Change{Model: model, Scene: scene, Field: field, Tag: tag, OldParam: oldParam, NewParam: newParam}
+			switch {
+			case !ok && isRequiredTag(tag):
+				change.Kind, change.Breaking = ChangeRequiredAdded, true
+			case !ok:
+				change.Kind, change.Breaking = ChangeConstraintAdded, true
+			case oldParam == newParam:
+				continue
+			default:
+				change.Kind = compareParam(tag, oldParam, newParam)
+				change.Breaking = change.Kind != ChangeConstraintLoosened
+			}
+			changes = append(changes, change)
+		}
+
+		for tag, oldParam := range oldTags {
+			if _, ok := newTags[tag]; ok {
+				continue
+			}
+			kind := ChangeConstraintRemoved
+			if isRequiredTag(tag) {
+				kind = ChangeRequiredRemoved
+			}
+			changes = append(changes, Change{Kind: kind, Model: model, Scene: scene, Field: field, Tag: tag, OldParam: oldParam})
+		}
+	}
+	return changes
+}
+
+// compareParam 判断同一标签参数变化的方向
+func compareParam(tag, oldParam, newParam string) ChangeKind {
+	baseTag := tag[strings.LastIndex(tag, ".")+1:]
+
+	switch baseTag {
+	case "min", "gte", "gt":
+		return compareNumber(oldParam, newParam, true)
+	case "max", "lte", "lt":
+		return compareNumber(oldParam, newParam, false)
+	case "oneof":
+		return compareOneOf(oldParam, newParam)
+	default:
+		return ChangeConstraintChanged
+	}
+}
+
+// compareNumber 比较数值参数；lowerBound 为 true 表示下限（变大为收紧）
+func compareNumber(oldParam, newParam string, lowerBound bool) ChangeKind {
+	oldValue, err1 := strconv.ParseFloat(oldParam, 64)
+	newValue, err2 := strconv.ParseFloat(newParam, 64)
+	if err1 != nil || err2 != nil {
+		return ChangeConstraintChanged
+	}
+	if (newValue > oldValue) == lowerBound {
+		return ChangeConstraintTightened
+	}
+	return ChangeConstraintLoosened
+}
+
+// compareOneOf 比较枚举参数：移除了任意候选值即为收紧
+func compareOneOf(oldParam, newParam string) ChangeKind {
+	newValues := make(map[string]struct{})
+	for _, value := range strings.Fields(newParam) {
+		newValues[value] = struct{}{}
+	}
+	for _, value := range strings.Fields(oldParam) {
+		if _, ok := newValues[value]; !ok {
+			return ChangeConstraintTightened
+		}
+	}
+	return ChangeConstraintLoosened
+}
+
+// isRequiredTag 是否为必填类标签（required、required_if、required_with 等）
+func isRequiredTag(tag string) bool {
+	return tag == "required" || strings.HasPrefix(tag, "required_")
+}
+
+// parseRule 解析规则字符串为 标签 -> 参数
+// 说明：dive 之后的标签作用于元素，加上 "dive." 前缀区分（多层 dive 叠加前缀）
+func parseRule(rule string) map[string]string {
+	tags := make(map[string]string)
+	prefix := ""
+	for _, part := range strings.Split(rule, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" || part == "omitempty" {
+			continue
+		}
+		if part == "dive" {
+			prefix += "dive."
+			continue
+		}
+		tag, param, _ := strings.Cut(part, "=")
+		tags[prefix+tag] = param
+	}
+	return tags
+}
+
+// sortChanges 按模型、场景、字段、标签、类型排序，保证输出稳定
+func sortChanges(changes []Change) {
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Model != b.Model {
+			return a.Model < b.Model
+		}
+		if a.Scene != b.Scene {
+			return a.Scene < b.Scene
+		}
+		if a.Field != b.Field {
+			return a.Field < b.Field
+		}
+		if a.Tag != b.Tag {
+			return a.Tag < b.Tag
+		}
+		return a.Kind < b.Kind
+	})
+}
+
+// ============================================================================
+// 规则提供者
+// ============================================================================
+
+// staticRuleSource 内存规则集
+type staticRuleSource struct {
+	ruleSet *core.RuleSet
+}
+
+// StaticRuleSource 使用内存中的规则集作为规则提供者
+func StaticRuleSource(ruleSet *core.RuleSet) core.IRuleSource {
+	return &staticRuleSource{ruleSet: ruleSet}
+}
+
+// Load 实现 IRuleSource 接口
+func (s *staticRuleSource) Load(_ context.Context) (*core.RuleSet, error) {
+	return s.ruleSet, nil
+}
+
+// modelRuleSource 模型内置规则
+type modelRuleSource struct {
+	version string
+	scenes  []core.Scene
+	models  map[string]core.IRuleValidator
+}
+
+// ModelRuleSource 将模型内置的 ValidateRules 导出为规则提供者
+// 说明：模型规则无法枚举场景，需要显式给出要对比的场景；返回空规则的场景视为不存在
+func ModelRuleSource(version string, scenes []core.Scene, models map[string]core.IRuleValidator) core.IRuleSource {
+	return &modelRuleSource{version: version, scenes: scenes, models: models}
+}
+
+// Load 实现 IRuleSource 接口
+func (s *modelRuleSource) Load(_ context.Context) (*core.RuleSet, error) {
+	ruleSet := &core.RuleSet{Version: s.version, Models: make(map[string]map[core.Scene]map[string]string, len(s.models))}
+	for name, model := range s.models {
+		scenes := make(map[core.Scene]map[string]string, len(s.scenes))
+		for _, scene := range s.scenes {
+			if rules := model.ValidateRules(scene); len(rules) > 0 {
+				scenes[scene] = rules
+			}
+		}
+		ruleSet.Models[name] = scenes
+	}
+	return ruleSet, nil
+}
@@ -0,0 +1,114 @@
+package rulediff
+
+import (
+	"context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"testing"
+)
+
+// userRules 测试用模型规则
+type userRules map[core.Scene]map[string]string
+
+func (r userRules) ValidateRules(scene core.Scene) map[string]string {
+	return r[scene]
+}
+
+func TestCompareRuleSets(t *testing.T) {
+	oldSet := &core.RuleSet{
+		Version: "v1",
+		Models: map[string]map[core.Scene]map[string]string{
+			"User": {
+				1: {
+					"username": "required,min=3,max=20",
+					"age":      "omitempty,gte=0,lte=150",
+					"role":     "oneof=admin user guest",
+					"tags":     "dive,max=10",
+					"nickname": "required",
+				},
+				2: {"username": "omitempty,min=3"},
+			},
+			"Order": {1: {"amount": "gt=0"}},
+		},
+	}
+	newSet := &core.RuleSet{
+		Version: "v2",
+		Models: map[string]map[core.Scene]map[string]string{
+			"User": {
+				1: {
+					"username": "required,min=5,max=30",
+					"age":      "omitempty,gte=0,lte=120",
+					"role":     "oneof=admin user",
+					"tags":     "dive,max=8",
+					"email":    "required,email",
+				},
+				4: {"username": "required"},
+			},
+		},
+	}
+
+	diff := CompareRuleSets(oldSet, newSet)
+	got := make(map[string]Change, len(diff.Changes))
+	for _, change := range diff.Changes {
+		got[change.String()] = change
+	}
+
+	want := map[string]bool{
+		`scene_removed Order[1]`:                                                      true,
+		`scene_removed User[2]`:                                                       true,
+		`scene_added User[4]`:                                                         false,
+		`required_added User[4].username required: "" -> ""`:                          true,
+		`constraint_tightened User[1].age lte: "150" -> "120"`:                        true,
+		`required_added User[1].email required: "" -> ""`:                             true,
+		`constraint_added User[1].email email: "" -> ""`:                              true,
+		`required_removed User[1].nickname required: "" -> ""`:                        false,
+		`constraint_tightened User[1].role oneof: "admin user guest" -> "admin user"`: true,
+		`constraint_tightened User[1].tags dive.max: "10" -> "8"`:                     true,
+		`constraint_loosened User[1].username max: "20" -> "30"`:                      false,
+		`constraint_tightened User[1].username min: "3" -> "5"`:                       true,
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d changes, want %d: %v", len(got), len(want), diff.Changes)
+	}
+	for key, breaking := range want {
+		change, ok := got[key]
+		if !ok {
+			t.Errorf("missing change %s", key)
+			continue
+		}
+		if change.Breaking != breaking {
+			t.Errorf("%s breaking = %v, want %v", key, change.Breaking, breaking)
+		}
+	}
+	if !diff.HasBreaking() || len(diff.Breaking()) != 9 {
+		t.Errorf("unexpected breaking changes: %v", diff.Breaking())
+	}
+	if diff.Changes[0].Model != "Order" {
+		t.Errorf("changes should be sorted by model: %v", diff.Changes[0])
+	}
+}
+
+func TestCompareRules_ModelRuleSource(t *testing.T) {
+	scenes := []core.Scene{1, 2}
+	oldSource := ModelRuleSource("old", scenes, map[string]core.IRuleValidator{
+		"User": userRules{1: {"name": "required,max=20"}, 2: {"name": "max=20"}},
+	})
+	newSource := ModelRuleSource("new", scenes, map[string]core.IRuleValidator{
+		"User": userRules{1: {"name": "required,max=30"}},
+	})
+
+	diff, err := CompareRules(context.Background(), oldSource, newSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.OldVersion != "old" || diff.NewVersion != "new" || len(diff.Changes) != 2 {
+		t.Fatalf("unexpected diff: %+v", diff)
+	}
+	if diff.Changes[0].Kind != ChangeConstraintLoosened || diff.Changes[1].Kind != ChangeSceneRemoved {
+		t.Errorf("unexpected changes: %v", diff.Changes)
+	}
+
+	same, err := CompareRules(context.Background(), StaticRuleSource(nil), StaticRuleSource(nil))
+	if err != nil || len(same.Changes) != 0 || same.HasBreaking() {
+		t.Errorf("empty rule sets should have no changes: %+v %v", same, err)
+	}
+}
@@ -0,0 +1,261 @@
+// Package rules 类型安全的规则构建器
+//
+// 规则标签字符串（如 "required,min=3,max=20"）拼写错误只能在运行时发现。
+// 本包用函数构建同样的规则，参数类型由编译器检查，生成的规则与手写标签完全相同，
+// 可直接作为 ValidateRules 的返回值，与标签写法混用：
+//
+//	func (u *User) ValidateRules(scene core.Scene) map[string]string {
+//	    return rules.New().
+//	        Field("username", rules.Required(), rules.MinLen(3), rules.MaxLen(20), rules.AlphaNum()).
+//	        Field("age", rules.Omitempty(), rules.Gte(18), rules.Lte(120)).
+//	        Build()
+//	}
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ============================================================================
+// 约束
+// ============================================================================
+
+// Constraint 单个约束，对应规则中的一个标签（如 min=3）
+type Constraint struct {
+	tag   string
+	param string
+}
+
+// Tag 约束标签
+func (c Constraint) Tag() string {
+	return c.tag
+}
+
+// Param 约束参数（已转义，没有参数时为空）
+func (c Constraint) Param() string {
+	return c.param
+}
+
+// String 约束的标签写法（如 min=3）
+func (c Constraint) String() string {
+	if c.param == "" {
+		return c.tag
+	}
+	return c.tag + "=" + c.param
+}
+
+// Number 数值参数类型
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Custom 自定义约束（自定义验证标签、规则别名或本包未提供的内置标签）
+// 说明：param 按规则语法转义，其中的 "," 和 "|" 不会截断规则
+func Custom(tag string, param ...string) Constraint {
+	return Constraint{tag: tag, param: escapeParam(strings.Join(param, " "))}
+}
+
+// Alias 规则别名（见 RegisterRuleAlias）
+func Alias(name string) Constraint {
+	return Constraint{tag: name}
+}
+
+// Required 必填
+func Required() Constraint { return Constraint{tag: "required"} }
+
+// Omitempty 为空时跳过其余约束
+func Omitempty() Constraint { return Constraint{tag: "omitempty"} }
+
+// Dive 之后的约束作用于切片或 map 的元素
+func Dive() Constraint { return Constraint{tag: "dive"} }
+
+// MinLen 最小长度（字符串按字符数，切片和 map 按元素数）
+func MinLen(n int) Constraint { return Constraint{tag: "min", param: fmt.Sprint(n)} }
+
+// MaxLen 最大长度
+func MaxLen(n int) Constraint { return Constraint{tag: "max", param: fmt.Sprint(n)} }
+
+// Len 固定长度
+func Len(n int) Constraint { return Constraint{tag: "len", param: fmt.Sprint(n)} }
+
+// Min 最小值（数值字段）
+func Min[T Number](v T) Constraint { return Constraint{tag: "min", param: fmt.Sprint(v)} }
+
+// Max 最大值（数值字段）
+func Max[T Number](v T) Constraint { return Constraint{tag: "max", param: fmt.Sprint(v)} }
+
+// Gt 大于
+func Gt[T Number](v T) Constraint { return Constraint{tag: "gt", param: fmt.Sprint(v)} }
+
+// Gte 大于等于
+func Gte[T Number](v T) Constraint { return Constraint{tag: "gte", param: fmt.Sprint(v)} }
+
+// Lt 小于
+func Lt[T Number](v T) Constraint { return Constraint{tag: "lt", param: fmt.Sprint(v)} }
+
+// Lte 小于等于
+func Lte[T Number](v T) Constraint { return Constraint{tag: "lte", param: fmt.Sprint(v)} }
+
+// Eq 等于（数值、字符串或布尔值）
+func Eq[T Number | ~string | ~bool](v T) Constraint {
+	return Constraint{tag: "eq", param: escapeParam(fmt.Sprint(v))}
+}
+
+// Ne 不等于
+func Ne[T Number | ~string | ~bool](v T) Constraint {
+	return Constraint{tag: "ne", param: escapeParam(fmt.Sprint(v))}
+}
+
+// OneOf 取值范围（包含空格的值用单引号包裹）
+func OneOf[T Number | ~string](values ...T) Constraint {
+	params := make([]string, len(values))
+	for i, v := range values {
+		s := fmt.Sprint(v)
+		if strings.ContainsAny(s, " '") {
+			s = "'" + s + "'"
+		}
+		params[i] = s
+	}
+	return Constraint{tag: "oneof", param: escapeParam(strings.Join(params, " "))}
+}
+
+// Email 邮箱格式
+func Email() Constraint { return Constraint{tag: "email"} }
+
+// URL URL 格式
+func URL() Constraint { return Constraint{tag: "url"} }
+
+// UUID UUID 格式
+func UUID() Constraint { return Constraint{tag: "uuid"} }
+
+// Alpha 只包含字母
+func Alpha() Constraint { return Constraint{tag: "alpha"} }
+
+// AlphaNum 只包含字母和数字
+func AlphaNum() Constraint { return Constraint{tag: "alphanum"} }
+
+// Numeric 数字字符串
+func Numeric() Constraint { return Constraint{tag: "numeric"} }
+
+// Lowercase 只包含小写字母
+func Lowercase() Constraint { return Constraint{tag: "lowercase"} }
+
+// Uppercase 只包含大写字母
+func Uppercase() Constraint { return Constraint{tag: "uppercase"} }
+
+// E164 E.164 格式的电话号码
+func E164() Constraint { return Constraint{tag: "e164"} }
+
+// StartsWith 以指定字符串开头
+func StartsWith(prefix string) Constraint {
+	return Constraint{tag: "startswith", param: escapeParam(prefix)}
+}
+
+// EndsWith 以指定字符串结尾
+func EndsWith(suffix string) Constraint {
+	return Constraint{tag: "endswith", param: escapeParam(suffix)}
+}
+
+// Contains 包含指定字符串
+func Contains(substr string) Constraint {
+	return Constraint{tag: "contains", param: escapeParam(substr)}
+}
+
+// Enum 属于已注册的枚举（enum=名称，见 types.NewEnum）
+func Enum(name string) Constraint { return Constraint{tag: "enum", param: name} }
+
+// Regex 匹配正则表达式（regex=模式，逗号和竖线自动转义；编译结果缓存，见 v6.RegexPolicy）
+func Regex(pattern string) Constraint { return Constraint{tag: "regex", param: escapeParam(pattern)} }
+
+// Unique 切片元素不重复（go-playground 内置的 unique）
+func Unique() Constraint { return Constraint{tag: "unique"} }
+
+// UniqueIn 数据库中唯一（仓储规则 unique=table.column，需要仓储策略）
+func UniqueIn(table, column string) Constraint {
+	return Constraint{tag: "unique", param: table + "." + column}
+}
+
+// ExistsIn 数据库中存在（仓储规则 exists=table.column，需要仓储策略）
+func ExistsIn(table, column string) Constraint {
+	return Constraint{tag: "exists", param: table + "." + column}
+}
+
+// ParamRef 引用规则参数（${key}），如 Custom("max", ParamRef("limits.username_max"))
+func ParamRef(key string) string {
+	return "${" + key + "}"
+}
+
+// Or 满足任一约束即可（如 Or(Email(), E164()) -> email|e164）
+func Or(constraints ...Constraint) Constraint {
+	parts := make([]string, len(constraints))
+	for i, c := range constraints {
+		parts[i] = c.String()
+	}
+	return Constraint{tag: strings.Join(parts, "|")}
+}
+
+// escapeParam 转义参数中的规则分隔符（go-playground 使用 0x2C 表示 ","、0x7C 表示 "|"）
+func escapeParam(param string) string {
+	if !strings.ContainsAny(param, ",|") {
+		return param
+	}
+	return strings.NewReplacer(",", "0x2C", "|", "0x7C").Replace(param)
+}
+
+// ============================================================================
+// 规则集
+// ============================================================================
+
+// Set 规则集构建器（字段 -> 约束列表）
+// 说明：非并发安全，通常在 ValidateRules 中构建或在包初始化时构建后复用 Build 的结果
+type Set struct {
+	fields map[string][]Constraint
+}
+
+// New 创建规则集构建器
+func New() *Set {
+	return &Set{fields: make(map[string][]Constraint)}
+}
+
+// Field 为字段追加约束（字段名为 json 名称或 Go 字段名，与标签写法的规则键相同）
+// 说明：同一字段多次调用时约束按顺序追加；dive 之后的约束作用于元素
+func (s *Set) Field(name string, constraints ...Constraint) *Set {
+	s.fields[name] = append(s.fields[name], constraints...)
+	return s
+}
+
+// Fields 已声明的字段（按名称排序）
+func (s *Set) Fields() []string {
+	names := make([]string, 0, len(s.fields))
+	for name := range s.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Rule 字段的规则字符串（未声明时为空）
+func (s *Set) Rule(name string) string {
+	constraints := s.fields[name]
+	parts := make([]string, len(constraints))
+	for i, c := range constraints {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// Build 生成规则表（字段 -> 规则字符串），与标签写法的 ValidateRules 返回值格式相同
+// 说明：每次调用返回新的 map，没有约束的字段不出现在结果中
+func (s *Set) Build() map[string]string {
+	rules := make(map[string]string, len(s.fields))
+	for name := range s.fields {
+		if rule := s.Rule(name); rule != "" {
+			rules[name] = rule
+		}
+	}
+	return rules
+}
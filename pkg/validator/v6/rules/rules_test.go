@@ -0,0 +1,68 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	got := New().
+		Field("username", Required(), MinLen(3), MaxLen(20), AlphaNum()).
+		Field("age", Omitempty(), Gte(18), Lte(120)).
+		Field("score", Gt(0.5), Lt(uint8(100))).
+		Field("role", OneOf("admin", "user", "super admin")).
+		Field("level", OneOf(1, 2, 3)).
+		Field("status", Required(), Enum("UserStatus")).
+		Field("contact", Required(), Or(Email(), E164())).
+		Field("tags", Omitempty(), Unique(), Dive(), MinLen(1), MaxLen(10)).
+		Field("email", UniqueIn("users", "email")).
+		Field("nickname", Custom("max", ParamRef("limits.nickname_max"))).
+		Field("code", StartsWith("a,b"), Contains("x|y")).
+		Field("sku", Regex(`^(AB|CD)-\d{2,4}$`)).
+		Field("empty").
+		Build()
+
+	want := map[string]string{
+		"username": "required,min=3,max=20,alphanum",
+		"age":      "omitempty,gte=18,lte=120",
+		"score":    "gt=0.5,lt=100",
+		"role":     "oneof=admin user 'super admin'",
+		"level":    "oneof=1 2 3",
+		"status":   "required,enum=UserStatus",
+		"contact":  "required,email|e164",
+		"tags":     "omitempty,unique,dive,min=1,max=10",
+		"email":    "unique=users.email",
+		"nickname": "max=${limits.nickname_max}",
+		"code":     "startswith=a0x2Cb,contains=x0x7Cy",
+		"sku":      `regex=^(AB0x7CCD)-\d{20x2C4}$`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Build() = %v, want %v", got, want)
+	}
+}
+
+func TestFieldAppends(t *testing.T) {
+	set := New().Field("name", Required()).Field("name", MaxLen(10))
+	if rule := set.Rule("name"); rule != "required,max=10" {
+		t.Fatalf("Rule() = %q", rule)
+	}
+	if rule := set.Rule("missing"); rule != "" {
+		t.Fatalf("Rule(missing) = %q", rule)
+	}
+	if fields := set.Fields(); !reflect.DeepEqual(fields, []string{"name"}) {
+		t.Fatalf("Fields() = %v", fields)
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	c := Eq("a b")
+	if c.Tag() != "eq" || c.Param() != "a b" || c.String() != "eq=a b" {
+		t.Fatalf("unexpected constraint %q", c)
+	}
+	if s := Ne(true).String(); s != "ne=true" {
+		t.Fatalf("Ne(true) = %q", s)
+	}
+	if s := Alias("username_std").String(); s != "username_std" {
+		t.Fatalf("Alias() = %q", s)
+	}
+}
@@ -0,0 +1,123 @@
+package v6_test
+
+import (
+	"strings"
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// ============================================================================
+// 测试数据
+// ============================================================================
+
+// entityBase 包含 ID 的嵌入结构体
+type entityBase struct {
+	ID int64 `json:"id"`
+}
+
+// article 通过嵌入结构体提供 ID 的模型
+type article struct {
+	entityBase
+	Title string `json:"title"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (a *article) ValidateRules(scene core.Scene) map[string]string {
+	if scene == SceneCreate {
+		return map[string]string{"title": "required"}
+	}
+	return map[string]string{"title": "omitempty,min=3"}
+}
+
+// order 使用 json 名称 order_no 作为标识的模型
+type order struct {
+	Number string `json:"order_no"`
+	Amount int    `json:"amount"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (o *order) ValidateRules(scene core.Scene) map[string]string {
+	if scene == SceneCreate {
+		return map[string]string{"amount": "gt=0"}
+	}
+	return map[string]string{"amount": "gte=0"}
+}
+
+// ============================================================================
+// 自动场景
+// ============================================================================
+
+// TestSceneAuto_IDResolver 测试按 ID 字段是否为零值选择创建或更新场景
+func TestSceneAuto_IDResolver(t *testing.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).WithIDSceneResolver(SceneCreate, SceneUpdate).Build()
+
+	tests := []struct {
+		name  string
+		model any
+		want  []string
+	}{
+		{"ID 为零值按创建验证", &User{Username: "al"}, []string{"username: min", "email: required", "password: required", "age: required"}},
+		{"ID 非零值按更新验证", &User{ID: 7, Username: "al"}, []string{"username: min"}},
+		{"嵌入结构体的 ID", &article{}, []string{"title: required"}},
+		{"嵌入结构体的 ID 非零值", &article{entityBase: entityBase{ID: 1}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tags(validator.Validate(tt.model, v6.SceneAuto)); !equalStrings(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// 按 json 名称指定 ID 字段
+	byJSON := v6.NewBuilder().WithRuleStrategy(10).
+		WithSceneResolver(v6.NewIDSceneResolver(SceneCreate, SceneUpdate, "order_no")).
+		Build()
+	if got := tags(byJSON.Validate(&order{}, v6.SceneAuto)); !equalStrings(got, []string{"amount: gt"}) {
+		t.Errorf("Validate(new order) = %v, want create rules", got)
+	}
+	if err := byJSON.Validate(&order{Number: "A-1"}, v6.SceneAuto); err != nil {
+		t.Errorf("Validate(existing order) = %v, want update rules", tags(err))
+	}
+}
+
+// TestSceneAuto_Unresolved 测试无法解析场景时返回 scene 错误
+func TestSceneAuto_Unresolved(t *testing.T) {
+	custom := v6.SceneResolverFunc(func(obj any) v6.Scene {
+		if _, ok := obj.(*User); ok {
+			return SceneUpdate
+		}
+		return v6.SceneAuto
+	})
+
+	tests := []struct {
+		name      string
+		validator core.IValidator
+		model     any
+		wantMsg   string
+	}{
+		{"未配置解析器", v6.NewBuilder().WithRuleStrategy(10).Build(), &User{}, "scene resolver not configured"},
+		{"没有 ID 字段", v6.NewBuilder().WithRuleStrategy(10).WithIDSceneResolver(SceneCreate, SceneUpdate).Build(), &order{}, "cannot resolve scene for *v6_test.order"},
+		{"解析器返回 SceneAuto", v6.NewBuilder().WithRuleStrategy(10).WithSceneResolver(custom).Build(), &article{}, "cannot resolve scene"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validator.Validate(tt.model, v6.SceneAuto)
+			if err == nil || len(err.FieldErrors()) != 1 {
+				t.Fatalf("Validate() = %v, want one scene error", err)
+			}
+			fe := err.FieldErrors()[0]
+			if fe.Tag() != "scene" || !strings.Contains(fe.Message(), tt.wantMsg) {
+				t.Errorf("error = %s %q, want scene %q", fe.Tag(), fe.Message(), tt.wantMsg)
+			}
+		})
+	}
+
+	// 自定义解析器
+	validator := v6.NewBuilder().WithRuleStrategy(10).WithSceneResolver(custom).Build()
+	if got := tags(validator.Validate(&User{Username: "al"}, v6.SceneAuto)); !equalStrings(got, []string{"username: min"}) {
+		t.Errorf("Validate() = %v, want update rules", got)
+	}
+}
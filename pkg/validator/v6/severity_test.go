@@ -0,0 +1,108 @@
+package v6_test
+
+import (
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// signup 业务验证中产生不同级别结果的模型
+type signup struct {
+	Password string `json:"password"`
+	Referrer string `json:"referrer"`
+	Age      int    `json:"age"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (s *signup) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{"age": "gte=18"}
+}
+
+// ValidateBusiness 实现 IBusinessValidator 接口
+func (s *signup) ValidateBusiness(scene core.Scene, collector core.IErrorCollector) {
+	if len(s.Password) < 10 {
+		collector.AddWarning(v6.NewFieldError("signup.password", "password", "weak"))
+	}
+	if s.Referrer != "" {
+		// Collect 按级别分流：Info 不使验证失败
+		collector.Collect(v6.NewFieldError("signup.referrer", "referrer", "deprecated", v6.WithSeverity(v6.SeverityInfo)))
+	}
+}
+
+// TestSeverity_Routing 测试警告和信息级别结果不使验证失败
+func TestSeverity_Routing(t *testing.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).WithBusinessStrategy(20).Build()
+
+	tests := []struct {
+		name         string
+		model        *signup
+		wantErrors   []string
+		wantWarnings map[string]core.Severity
+	}{
+		{"无提示", &signup{Password: "long-password", Age: 20}, nil, map[string]core.Severity{}},
+		{"警告与信息", &signup{Password: "short", Referrer: "bob", Age: 20}, nil, map[string]core.Severity{
+			"weak":       core.SeverityWarning,
+			"deprecated": core.SeverityInfo,
+		}},
+		{"错误与警告", &signup{Password: "short", Age: 16}, []string{"age: gte"}, map[string]core.Severity{
+			"weak": core.SeverityWarning,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validator.Check(tt.model, SceneCreate)
+			if got := tags(result); !equalStrings(got, tt.wantErrors) {
+				t.Errorf("FieldErrors() = %v, want %v", got, tt.wantErrors)
+			}
+			if result.IsValid() != (len(tt.wantErrors) == 0) {
+				t.Errorf("IsValid() = %t", result.IsValid())
+			}
+
+			warnings := make(map[string]core.Severity)
+			for _, w := range result.Warnings() {
+				warnings[w.Tag()] = w.Severity()
+			}
+			if len(warnings) != len(tt.wantWarnings) {
+				t.Errorf("Warnings() = %v, want %v", warnings, tt.wantWarnings)
+			}
+			for tag, severity := range tt.wantWarnings {
+				if warnings[tag] != severity {
+					t.Errorf("warning %s severity = %s, want %s", tag, warnings[tag], severity)
+				}
+			}
+
+			// Validate 只有警告时返回 nil
+			if err := validator.Validate(tt.model, SceneCreate); (err != nil) != (len(tt.wantErrors) > 0) {
+				t.Errorf("Validate() = %v", err)
+			}
+		})
+	}
+}
+
+// TestSeverity_MaxErrors 测试警告不占用最大错误数
+func TestSeverity_MaxErrors(t *testing.T) {
+	validator := v6.NewBuilder().WithBusinessStrategy(10).WithRuleStrategy(20).WithFailFast().Build()
+
+	result := validator.Check(&signup{Password: "short", Age: 16}, SceneCreate)
+	if got := tags(result); !equalStrings(got, []string{"age: gte"}) {
+		t.Errorf("FieldErrors() = %v, want the rule error after the warning", got)
+	}
+	if len(result.Warnings()) != 1 || result.Truncated() {
+		t.Errorf("Warnings() = %v, Truncated() = %t", result.Warnings(), result.Truncated())
+	}
+}
+
+// equalStrings 比较字符串列表（nil 与空列表视为相等）
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,230 @@
+package strategy
+
+import (
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ============================================================================
+// 规则别名注册表
+// ============================================================================
+
+// ruleAliasTable 规则别名表（不可变，修改时整体替换）
+type ruleAliasTable struct {
+	raw      map[string]string // 别名 -> 注册的规则
+	expanded map[string]string // 别名 -> 递归展开后的规则
+}
+
+var (
+	ruleAliasesMu sync.Mutex // 串行化修改
+	ruleAliases   atomic.Pointer[ruleAliasTable]
+
+	// ruleAliasGeneration 别名注册表版本，每次修改递增，类型信息中缓存的规则据此失效
+	ruleAliasGeneration atomic.Uint64
+)
+
+// RegisterRuleAlias 注册规则别名，解析规则时展开，如 "username_std" -> "required,min=3,max=20,alphanum"
+// 说明：
+//   - 别名可以引用其他别名，注册时递归展开并检测循环引用
+//   - 与依赖库的 RegisterAlias 不同，别名可以包含 required/omitempty，存在性检查和仓储规则按展开后的规则处理
+//   - 规则中的标签与别名完全相同时才展开（带参数的标签如 min=3 不会被当作别名）
+//   - 重复注册时覆盖，引用它的别名同时更新
+//   - 别名名称自动注册为标签（core.RegisterTag）；严格标签模式下规则包含未注册的标签时返回错误
+//
+// 示例：
+//
+//	RegisterRuleAlias("username_std", "required,min=3,max=20,alphanum")
+//	rules["username"] = "username_std"
+func RegisterRuleAlias(name, rules string) error {
+	if err := checkRuleAliasName(name); err != nil {
+		return err
+	}
+	if strings.TrimSpace(rules) == "" {
+		return fmt.Errorf("rule alias %q cannot be empty", name)
+	}
+
+	ruleAliasesMu.Lock()
+	defer ruleAliasesMu.Unlock()
+
+	raw := copyRuleAliases()
+	if core.StrictTags() {
+		for _, tag := range core.UnregisteredTags(rules) {
+			if _, ok := raw[tag]; !ok {
+				return fmt.Errorf("rule alias %q uses unregistered tag %q", name, tag)
+			}
+		}
+	}
+	raw[name] = rules
+	if err := storeRuleAliases(raw); err != nil {
+		return err
+	}
+	return core.RegisterTag(core.Tag(name))
+}
+
+// UnregisterRuleAlias 注销规则别名
+// 返回值：仍被其他别名引用时返回错误，注册表保持不变
+func UnregisterRuleAlias(name string) error {
+	ruleAliasesMu.Lock()
+	defer ruleAliasesMu.Unlock()
+
+	raw := copyRuleAliases()
+	if _, ok := raw[name]; !ok {
+		return nil
+	}
+	delete(raw, name)
+	for alias, rules := range raw {
+		for _, tag := range strings.Split(rules, ",") {
+			if tag == name {
+				return fmt.Errorf("rule alias %q is still referenced by %q", name, alias)
+			}
+		}
+	}
+	return storeRuleAliases(raw)
+}
+
+// ClearRuleAliases 清空所有规则别名
+func ClearRuleAliases() {
+	ruleAliasesMu.Lock()
+	defer ruleAliasesMu.Unlock()
+	ruleAliases.Store(nil)
+	ruleAliasGeneration.Add(1)
+}
+
+// ExpandRuleAliases 展开规则中的别名（没有别名时原样返回）
+func ExpandRuleAliases(rule string) string {
+	table := ruleAliases.Load()
+	if table == nil {
+		return rule
+	}
+	return expandAliasTags(rule, table.expanded)
+}
+
+// expandRuleMapAliases 展开规则表中的别名
+// 说明：有规则被展开时复制后返回，避免修改模型或提供者返回的规则；否则返回原规则表
+func expandRuleMapAliases(rules map[string]string) map[string]string {
+	table := ruleAliases.Load()
+	if table == nil || len(rules) == 0 {
+		return rules
+	}
+
+	var expanded map[string]string
+	for field, rule := range rules {
+		result := expandAliasTags(rule, table.expanded)
+		if result == rule {
+			continue
+		}
+		if expanded == nil {
+			expanded = make(map[string]string, len(rules))
+			for f, r := range rules {
+				expanded[f] = r
+			}
+		}
+		expanded[field] = result
+	}
+	if expanded == nil {
+		return rules
+	}
+	return expanded
+}
+
+// checkRuleAliasName 检查别名名称（不能包含规则分隔符）
+func checkRuleAliasName(name string) error {
+	if name == "" {
+		return fmt.Errorf("rule alias name cannot be empty")
+	}
+	if strings.ContainsAny(name, ",|= \t") {
+		return fmt.Errorf("rule alias name %q cannot contain ',', '|', '=' or spaces", name)
+	}
+	return nil
+}
+
+// copyRuleAliases 复制当前注册的别名
+func copyRuleAliases() map[string]string {
+	raw := make(map[string]string)
+	if table := ruleAliases.Load(); table != nil {
+		for name, rules := range table.raw {
+			raw[name] = rules
+		}
+	}
+	return raw
+}
+
+// storeRuleAliases 递归展开所有别名并替换注册表
+// 返回值：存在循环引用时返回错误，注册表保持不变
+func storeRuleAliases(raw map[string]string) error {
+	if len(raw) == 0 {
+		ruleAliases.Store(nil)
+		ruleAliasGeneration.Add(1)
+		return nil
+	}
+
+	// 按名称顺序展开，循环引用的错误信息稳定
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	expanded := make(map[string]string, len(raw))
+	for _, name := range names {
+		if _, err := expandRuleAlias(name, raw, expanded, nil); err != nil {
+			return err
+		}
+	}
+	ruleAliases.Store(&ruleAliasTable{raw: raw, expanded: expanded})
+	ruleAliasGeneration.Add(1)
+	return nil
+}
+
+// expandRuleAlias 递归展开单个别名（深度优先，path 为当前展开路径）
+func expandRuleAlias(name string, raw, expanded map[string]string, path []string) (string, error) {
+	if rules, ok := expanded[name]; ok {
+		return rules, nil
+	}
+	for _, visiting := range path {
+		if visiting == name {
+			return "", fmt.Errorf("rule alias cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+	}
+	path = append(path, name)
+
+	tags := strings.Split(raw[name], ",")
+	for i, tag := range tags {
+		if _, ok := raw[tag]; !ok {
+			continue
+		}
+		rules, err := expandRuleAlias(tag, raw, expanded, path)
+		if err != nil {
+			return "", err
+		}
+		tags[i] = rules
+	}
+
+	rules := strings.Join(tags, ",")
+	expanded[name] = rules
+	return rules, nil
+}
+
+// expandAliasTags 将规则中与别名完全相同的标签替换为展开后的规则
+func expandAliasTags(rule string, expanded map[string]string) string {
+	if len(expanded) == 0 {
+		return rule
+	}
+
+	changed := false
+	tags := strings.Split(rule, ",")
+	for i, tag := range tags {
+		if rules, ok := expanded[tag]; ok {
+			tags[i] = rules
+			changed = true
+		}
+	}
+	if !changed {
+		return rule
+	}
+	return strings.Join(tags, ",")
+}
@@ -0,0 +1,124 @@
+package strategy
+
+import (
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// 批内唯一性策略 - 批量导入时检查同一批次中的重复记录
+// ============================================================================
+
+// TagBatchDuplicate 与同一批次中前面的记录重复时的错误标签（参数为首次出现的记录下标）
+const TagBatchDuplicate = "batch_duplicate"
+
+// BatchKey 批内唯一键
+type BatchKey struct {
+	// Field 错误中的字段名（与规则键写法一致，如 email）
+	Field string
+	// Extract 提取记录的键，返回 false 表示该记录不参与检查（如字段为空，由 required 规则负责）
+	Extract func(item any) (string, bool)
+}
+
+// UniqueField 按字段值检查批内唯一（Go 字段名或 json 名称，零值不参与检查）
+func UniqueField(field string) BatchKey {
+	return BatchKey{Field: field, Extract: func(item any) (string, bool) {
+		return fieldKeyValue(item, field)
+	}}
+}
+
+// UniqueFieldFold 按字段值检查批内唯一，忽略大小写和首尾空白（适合邮箱、用户名）
+func UniqueFieldFold(field string) BatchKey {
+	return BatchKey{Field: field, Extract: func(item any) (string, bool) {
+		key, ok := fieldKeyValue(item, field)
+		if !ok {
+			return "", false
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		return key, key != ""
+	}}
+}
+
+// UniqueBy 使用自定义函数提取键（如组合键 tenant+code），field 为错误中的字段名
+func UniqueBy(field string, extract func(item any) (string, bool)) BatchKey {
+	return BatchKey{Field: field, Extract: extract}
+}
+
+// BatchUniquenessStrategy 批内唯一性策略
+// 职责：按唯一键检查同一批次中的重复记录，每条重复记录报告一个 batch_duplicate 错误
+// 说明：
+//   - 每个唯一键独立检查；首次出现的记录不报错，之后相同键的记录引用首次出现的下标
+//   - 错误命名空间为 记录类型.字段（如 User.email），与逐条验证的错误合并到该记录的结果中
+//   - 只检查本批次，数据库中的重复由仓储策略（unique=table.column）检查
+type BatchUniquenessStrategy struct {
+	keys []BatchKey
+}
+
+// NewBatchUniquenessStrategy 创建批内唯一性策略
+func NewBatchUniquenessStrategy(keys ...BatchKey) *BatchUniquenessStrategy {
+	return &BatchUniquenessStrategy{keys: keys}
+}
+
+// Name 策略名称
+func (s *BatchUniquenessStrategy) Name() string {
+	return "batch_uniqueness"
+}
+
+// ValidateBatch 检查批内重复
+func (s *BatchUniquenessStrategy) ValidateBatch(items []any, ctx core.IContext, report func(index int, err core.IFieldError)) {
+	for _, key := range s.keys {
+		if key.Extract == nil {
+			continue
+		}
+
+		first := make(map[string]int, len(items))
+		for i, item := range items {
+			if item == nil {
+				continue
+			}
+			value, ok := key.Extract(item)
+			if !ok {
+				continue
+			}
+			prev, seen := first[value]
+			if !seen {
+				first[value] = i
+				continue
+			}
+			typeName := shortTypeName(indirectType(reflect.TypeOf(item)).Name())
+			report(i, errors.NewFieldError(typeName+"."+key.Field, lastPathSegment(key.Field), TagBatchDuplicate,
+				errors.WithParam(strconv.Itoa(prev)),
+				errors.WithMessage(fmt.Sprintf("%s duplicates record %d in the same batch", key.Field, prev))))
+		}
+	}
+}
+
+// fieldKeyValue 读取记录的字段值作为键（零值、nil 指针和非结构体记录不参与检查）
+func fieldKeyValue(item any, field string) (string, bool) {
+	v := reflect.ValueOf(item)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	f := structField(v, field)
+	for f.IsValid() && (f.Kind() == reflect.Ptr || f.Kind() == reflect.Interface) {
+		if f.IsNil() {
+			return "", false
+		}
+		f = f.Elem()
+	}
+	if !f.IsValid() || f.IsZero() || !f.CanInterface() {
+		return "", false
+	}
+	return fmt.Sprint(f.Interface()), true
+}
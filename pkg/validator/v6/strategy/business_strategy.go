@@ -1,6 +1,7 @@
 package strategy
 
 import (
+	"katydid-common-account/pkg/validator/v6/context"
 	"katydid-common-account/pkg/validator/v6/core"
 )
 
@@ -10,14 +11,33 @@ import (
 type businessStrategy struct {
 	name      string
 	inspector core.ITypeInspector
+	legacy    core.ILegacyAdapter // 旧版模型适配器（可选）
+}
+
+// BusinessStrategyOption 业务策略选项
+type BusinessStrategyOption func(*businessStrategy)
+
+// WithBusinessLegacyAdapter 设置旧版模型适配器
+// 说明：未实现 IBusinessValidator 的模型，通过适配器执行旧版接口的业务验证
+func WithBusinessLegacyAdapter(adapter core.ILegacyAdapter) BusinessStrategyOption {
+	return func(s *businessStrategy) {
+		s.legacy = adapter
+	}
 }
 
 // NewBusinessStrategy 创建业务验证策略
-func NewBusinessStrategy(inspector core.ITypeInspector) core.IValidationStrategy {
-	return &businessStrategy{
+func NewBusinessStrategy(inspector core.ITypeInspector, opts ...BusinessStrategyOption) core.IValidationStrategy {
+	s := &businessStrategy{
 		name:      "business",
 		inspector: inspector,
 	}
+
+	// 应用选项
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 // Type 策略类型
@@ -40,9 +60,15 @@ func (s *businessStrategy) Validate(target any, ctx core.IContext, collector cor
 
 	// 已实现缓存，TODO:GG 能提升性能吗？
 
-	// 执行业务验证
-	if validator, ok := target.(core.IBusinessValidator); ok {
+	// 执行业务验证（感知有效字段的验证器优先）
+	if validator, ok := target.(core.IFieldAwareBusinessValidator); ok {
+		validator.ValidateBusinessFields(ctx.Scene(), context.FieldSet(ctx), collector)
+	} else if validator, ok := target.(core.IBusinessValidator); ok {
 		validator.ValidateBusiness(ctx.Scene(), collector)
+	} else if s.legacy != nil {
+		if validator, ok := s.legacy.BusinessValidator(target); ok {
+			validator.ValidateBusiness(ctx.Scene(), collector)
+		}
 	}
 
 	return nil
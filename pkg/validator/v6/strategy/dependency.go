@@ -0,0 +1,44 @@
+package strategy
+
+import (
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// ============================================================================
+// 策略依赖声明
+// ============================================================================
+
+// dependentStrategy 声明了前置策略的策略装饰器
+type dependentStrategy struct {
+	core.IValidationStrategy
+	after []string
+}
+
+// RunsAfter 为策略声明前置策略，编排器保证前置策略先执行
+// 说明：内部策略已实现 IStrategyDependencies 时合并两者的依赖
+//
+// 示例：
+//
+//	builder.WithStrategy(strategy.RunsAfter(passwordHistory, "password_strength"), 40)
+func RunsAfter(s core.IValidationStrategy, names ...string) core.IValidationStrategy {
+	after := append(Dependencies(s), names...)
+	return &dependentStrategy{IValidationStrategy: s, after: after}
+}
+
+// RunsAfter 实现 IStrategyDependencies 接口
+func (s *dependentStrategy) RunsAfter() []string {
+	return s.after
+}
+
+// Inner 获取内部策略
+func (s *dependentStrategy) Inner() core.IValidationStrategy {
+	return s.IValidationStrategy
+}
+
+// Dependencies 获取策略声明的前置策略（未声明时为 nil）
+func Dependencies(s core.IValidationStrategy) []string {
+	if d, ok := s.(core.IStrategyDependencies); ok {
+		return append([]string(nil), d.RunsAfter()...)
+	}
+	return nil
+}
@@ -0,0 +1,379 @@
+package strategy
+
+import (
+	"bufio"
+	stdcontext "context"
+	stderrors "errors"
+	"io"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"net"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// 邮箱深度验证策略
+// ============================================================================
+
+// 邮箱深度验证的错误标签
+const (
+	TagEmailUndeliverable = "email_undeliverable" // 域名不存在或没有可投递的邮件服务器
+	TagEmailDisposable    = "email_disposable"    // 一次性邮箱域名
+)
+
+// tagEmail 基础邮箱格式规则，深度验证只处理带该规则的字段
+const tagEmail = "email"
+
+// MXResolver DNS 解析接口（net.DefaultResolver 实现了该接口）
+type MXResolver interface {
+	LookupMX(ctx stdcontext.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx stdcontext.Context, host string) ([]string, error)
+}
+
+// DisposableDomains 一次性邮箱域名列表接口
+type DisposableDomains interface {
+	// IsDisposable 域名（含子域名）是否属于一次性邮箱
+	IsDisposable(domain string) bool
+}
+
+// EmailDeepStrategy 邮箱深度验证策略（需显式启用）
+// 职责：对规则中带 email 标签的字段，检查域名的 MX 记录和一次性邮箱黑名单
+// 设计原则：
+//   - 失败放行：DNS 超时或临时错误不报告错误，也不缓存结果
+//   - 缓存：域名检查结果跨验证缓存（带过期时间），同一次验证中相同域名只查询一次
+//   - 只处理格式合法的邮箱，格式错误由规则策略的 email 标签报告
+type EmailDeepStrategy struct {
+	inspector    core.ITypeInspector
+	ruleProvider core.IRuleProvider
+	tenantRules  core.ITenantRuleProvider
+	legacy       core.ILegacyAdapter
+
+	resolver   MXResolver
+	disposable DisposableDomains
+	checkMX    bool
+	timeout    time.Duration
+	cache      core.ICacheManager
+	cacheTTL   time.Duration
+}
+
+// EmailOption 邮箱深度验证选项
+type EmailOption func(*EmailDeepStrategy)
+
+// WithMXResolver 设置 DNS 解析器（默认 net.DefaultResolver）
+func WithMXResolver(resolver MXResolver) EmailOption {
+	return func(s *EmailDeepStrategy) {
+		s.resolver = resolver
+	}
+}
+
+// WithoutMXLookup 不检查 MX 记录，只检查一次性邮箱黑名单
+func WithoutMXLookup() EmailOption {
+	return func(s *EmailDeepStrategy) {
+		s.checkMX = false
+	}
+}
+
+// WithDisposableDomains 设置一次性邮箱域名列表（默认为内置的常见域名），nil 表示不检查
+func WithDisposableDomains(domains DisposableDomains) EmailOption {
+	return func(s *EmailDeepStrategy) {
+		s.disposable = domains
+	}
+}
+
+// WithLookupTimeout 设置单次 DNS 查询的超时时间（默认 2 秒）
+func WithLookupTimeout(timeout time.Duration) EmailOption {
+	return func(s *EmailDeepStrategy) {
+		s.timeout = timeout
+	}
+}
+
+// WithDomainCache 设置域名检查结果的缓存和过期时间（默认 LRU 1024 个域名，1 小时）
+func WithDomainCache(cache core.ICacheManager, ttl time.Duration) EmailOption {
+	return func(s *EmailDeepStrategy) {
+		s.cache = cache
+		s.cacheTTL = ttl
+	}
+}
+
+// NewEmailDeepStrategy 创建邮箱深度验证策略
+// 参数：
+//   - inspector：类型检查器
+//   - ruleProvider：外部规则提供者（可选），与规则策略保持一致
+//   - cache：默认的域名结果缓存（如 infrastructure.NewLRUCache(1024)），可通过 WithDomainCache 覆盖
+func NewEmailDeepStrategy(inspector core.ITypeInspector, ruleProvider core.IRuleProvider, cache core.ICacheManager, opts ...EmailOption) *EmailDeepStrategy {
+	s := &EmailDeepStrategy{
+		inspector:    inspector,
+		ruleProvider: ruleProvider,
+		resolver:     net.DefaultResolver,
+		disposable:   NewDomainSet(defaultDisposableDomains...),
+		checkMX:      true,
+		timeout:      2 * time.Second,
+		cache:        cache,
+		cacheTTL:     time.Hour,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// SetTenantRuleProvider 设置租户规则覆盖提供者（构建时调用）
+func (s *EmailDeepStrategy) SetTenantRuleProvider(provider core.ITenantRuleProvider) {
+	s.tenantRules = provider
+}
+
+// SetLegacyAdapter 设置旧版模型适配器（构建时调用）
+// 说明：旧版模型规则中的邮箱规则同样生效，与规则策略使用同一个适配器
+func (s *EmailDeepStrategy) SetLegacyAdapter(adapter core.ILegacyAdapter) {
+	s.legacy = adapter
+}
+
+// Type 策略类型
+func (s *EmailDeepStrategy) Type() core.StrategyType {
+	return core.StrategyTypeEmail
+}
+
+// Name 策略名称
+func (s *EmailDeepStrategy) Name() string {
+	return "email_deep"
+}
+
+// Validate 执行邮箱深度验证
+func (s *EmailDeepStrategy) Validate(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	typeInfo := inspectType(s.inspector, target, ctx)
+	if typeInfo == nil {
+		return nil
+	}
+
+	rules := filterRules(resolveRules(target, typeInfo, ctx, s.ruleProvider, s.tenantRules, s.legacy), ctx)
+	for fieldName, rule := range rules {
+		if !hasEmailTag(rule) {
+			continue
+		}
+
+		value, ok := getFieldValue(target, fieldName, typeInfo)
+		if !ok {
+			continue
+		}
+		if inner, state := resolvePresence(value); state != presenceNotAware {
+			if state != presencePresent {
+				continue
+			}
+			value = inner
+		}
+
+		email, ok := value.(string)
+		if !ok {
+			continue
+		}
+		domain, ok := emailDomain(email)
+		if !ok {
+			continue
+		}
+
+		tag := s.checkDomain(ctx, domain)
+		if tag == "" {
+			continue
+		}
+
+		opts := []errors.FieldErrorOption{errors.WithParam(domain), errors.WithValue(email)}
+		if isSensitiveField(typeInfo, fieldName) {
+			opts = append(opts, errors.WithSensitive())
+		}
+		if !collector.Collect(errors.NewFieldError(typeInfo.TypeName()+"."+fieldName, lastPathSegment(fieldName), tag, opts...)) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// checkDomain 检查域名，返回错误标签（通过时为空）
+func (s *EmailDeepStrategy) checkDomain(ctx core.IContext, domain string) string {
+	if s.disposable != nil && s.disposable.IsDisposable(domain) {
+		return TagEmailDisposable
+	}
+	if !s.checkMX || s.resolver == nil {
+		return ""
+	}
+
+	// 同一次验证中相同域名只查询一次
+	deliverable, err := ctx.MemoValue("email_mx:"+domain, func() (any, error) {
+		return s.deliverable(ctx.GoContext(), domain)
+	})
+	if err != nil || deliverable.(bool) {
+		return ""
+	}
+	return TagEmailUndeliverable
+}
+
+// domainResult 缓存的域名检查结果
+type domainResult struct {
+	deliverable bool
+	expiresAt   time.Time
+}
+
+// deliverable 域名是否可投递（带缓存）
+// 返回值：DNS 临时错误时返回 error，调用方放行
+func (s *EmailDeepStrategy) deliverable(goCtx stdcontext.Context, domain string) (bool, error) {
+	cacheKey := "email_mx:" + domain
+	if s.cache != nil {
+		if cached, ok := s.cache.Get(cacheKey); ok {
+			if result, ok := cached.(domainResult); ok && time.Now().Before(result.expiresAt) {
+				return result.deliverable, nil
+			}
+		}
+	}
+
+	deliverable, err := s.lookup(goCtx, domain)
+	if err != nil {
+		return false, err
+	}
+
+	if s.cache != nil {
+		s.cache.Set(cacheKey, domainResult{deliverable: deliverable, expiresAt: time.Now().Add(s.cacheTTL)})
+	}
+	return deliverable, nil
+}
+
+// lookup 查询 MX 记录，没有 MX 记录时按 RFC 5321 回退到 A/AAAA 记录
+func (s *EmailDeepStrategy) lookup(goCtx stdcontext.Context, domain string) (bool, error) {
+	if goCtx == nil {
+		goCtx = stdcontext.Background()
+	}
+	lookupCtx, cancel := stdcontext.WithTimeout(goCtx, s.timeout)
+	defer cancel()
+
+	records, err := s.resolver.LookupMX(lookupCtx, domain)
+	if err != nil && !isNotFound(err) {
+		return false, err
+	}
+	if len(records) > 0 {
+		// Null MX（RFC 7505）：域名明确声明不接收邮件
+		if len(records) == 1 && (records[0].Host == "." || records[0].Host == "") {
+			return false, nil
+		}
+		return true, nil
+	}
+
+	hosts, err := s.resolver.LookupHost(lookupCtx, domain)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(hosts) > 0, nil
+}
+
+// isNotFound 是否为域名不存在（区别于超时等临时错误）
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return stderrors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// hasEmailTag 规则中是否包含 email 标签（dive 之后的元素规则不处理）
+func hasEmailTag(rule string) bool {
+	for _, tag := range strings.Split(rule, ",") {
+		if tag == tagDive {
+			return false
+		}
+		if tag == tagEmail {
+			return true
+		}
+	}
+	return false
+}
+
+// emailDomain 提取邮箱的域名（小写）
+func emailDomain(email string) (string, bool) {
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 || at == len(email)-1 {
+		return "", false
+	}
+	domain := strings.TrimSuffix(strings.ToLower(email[at+1:]), ".")
+	if !strings.Contains(domain, ".") {
+		return "", false
+	}
+	return domain, true
+}
+
+// ============================================================================
+// 一次性邮箱域名列表
+// ============================================================================
+
+// defaultDisposableDomains 内置的常见一次性邮箱域名
+var defaultDisposableDomains = []string{
+	"10minutemail.com",
+	"dispostable.com",
+	"getnada.com",
+	"guerrillamail.com",
+	"mailinator.com",
+	"maildrop.cc",
+	"sharklasers.com",
+	"temp-mail.org",
+	"trashmail.com",
+	"yopmail.com",
+}
+
+// DomainSet 域名集合，实现 DisposableDomains 接口
+// 说明：子域名同样匹配（列表中有 mailinator.com 时 x.mailinator.com 也算）；创建后只读，并发安全
+type DomainSet struct {
+	domains map[string]struct{}
+}
+
+// NewDomainSet 创建域名集合
+func NewDomainSet(domains ...string) *DomainSet {
+	set := &DomainSet{domains: make(map[string]struct{}, len(domains))}
+	for _, domain := range domains {
+		if domain = normalizeDomain(domain); domain != "" {
+			set.domains[domain] = struct{}{}
+		}
+	}
+	return set
+}
+
+// LoadDomainSet 从文本加载域名集合
+// 格式：每行一个域名，忽略空行和 # 开头的注释（兼容常见的公开一次性邮箱列表）
+func LoadDomainSet(r io.Reader) (*DomainSet, error) {
+	var domains []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewDomainSet(domains...), nil
+}
+
+// IsDisposable 实现 DisposableDomains 接口
+func (d *DomainSet) IsDisposable(domain string) bool {
+	domain = normalizeDomain(domain)
+	for domain != "" {
+		if _, ok := d.domains[domain]; ok {
+			return true
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+	return false
+}
+
+// Len 域名数量
+func (d *DomainSet) Len() int {
+	return len(d.domains)
+}
+
+// normalizeDomain 规范化域名（小写、去掉首尾的点和空白）
+func normalizeDomain(domain string) string {
+	return strings.Trim(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
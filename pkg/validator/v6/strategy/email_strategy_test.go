@@ -0,0 +1,227 @@
+package strategy
+
+import (
+	stdcontext "context"
+	"net"
+	"strings"
+	"testing"
+
+	"katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"katydid-common-account/pkg/validator/v6/infrastructure"
+)
+
+// ============================================================================
+// 测试数据
+// ============================================================================
+
+// fakeResolver 按域名返回固定结果的 DNS 解析器
+type fakeResolver struct {
+	mx      map[string][]*net.MX
+	hosts   map[string][]string
+	timeout map[string]bool
+	calls   map[string]int
+}
+
+func newFakeResolver() *fakeResolver {
+	return &fakeResolver{
+		mx: map[string][]*net.MX{
+			"example.com": {{Host: "mx.example.com.", Pref: 10}},
+			"nomail.com":  {{Host: ".", Pref: 0}},
+		},
+		hosts: map[string][]string{
+			"a-only.com": {"192.0.2.1"},
+		},
+		timeout: map[string]bool{"slow.com": true},
+		calls:   make(map[string]int),
+	}
+}
+
+func (r *fakeResolver) LookupMX(_ stdcontext.Context, name string) ([]*net.MX, error) {
+	r.calls[name]++
+	if r.timeout[name] {
+		return nil, &net.DNSError{Err: "i/o timeout", Name: name, IsTimeout: true}
+	}
+	if records, ok := r.mx[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupHost(_ stdcontext.Context, host string) ([]string, error) {
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// contact 带邮箱规则的模型
+type contact struct {
+	Email  string `json:"email"`
+	Backup string `json:"backup"`
+}
+
+func (c *contact) ValidateRules(core.Scene) map[string]string {
+	return map[string]string{
+		"email":  "required,email",
+		"backup": "omitempty,email",
+	}
+}
+
+// validateEmail 执行邮箱深度验证，返回 "字段: 标签"
+func validateEmail(s *EmailDeepStrategy, model *contact) []string {
+	ctx := context.NewContext(sceneCreate)
+	defer ctx.Release()
+	collector := errors.NewListErrorCollector(10)
+	_ = s.Validate(model, ctx, collector)
+
+	var got []string
+	for _, fe := range collector.Errors() {
+		got = append(got, fe.Field()+": "+fe.Tag())
+	}
+	return got
+}
+
+// newEmailStrategy 创建使用假解析器的策略
+func newEmailStrategy(resolver MXResolver, opts ...EmailOption) *EmailDeepStrategy {
+	inspector := infrastructure.NewTypeInspector(infrastructure.NewLRUCache(16))
+	opts = append([]EmailOption{WithMXResolver(resolver)}, opts...)
+	return NewEmailDeepStrategy(inspector, nil, infrastructure.NewLRUCache(16), opts...)
+}
+
+// ============================================================================
+// MX 检查
+// ============================================================================
+
+// TestEmailDeepStrategy_MX 测试 MX 记录检查、A 记录回退和失败放行
+func TestEmailDeepStrategy_MX(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+		want  []string
+	}{
+		{"有 MX 记录", "user@example.com", nil},
+		{"域名大小写不敏感", "user@EXAMPLE.com.", nil},
+		{"没有 MX 时回退到 A 记录", "user@a-only.com", nil},
+		{"域名不存在", "user@missing.com", []string{"email: " + TagEmailUndeliverable}},
+		{"Null MX", "user@nomail.com", []string{"email: " + TagEmailUndeliverable}},
+		{"DNS 超时放行", "user@slow.com", nil},
+		{"格式错误交给 email 标签", "not-an-email", nil},
+		{"一次性邮箱", "user@mailinator.com", []string{"email: " + TagEmailDisposable}},
+		{"一次性邮箱子域名", "user@x.Mailinator.com", []string{"email: " + TagEmailDisposable}},
+	}
+
+	s := newEmailStrategy(newFakeResolver())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validateEmail(s, &contact{Email: tt.email}); !equalTags(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEmailDeepStrategy_Cache 测试域名结果跨验证缓存，同一次验证只查询一次，临时错误不缓存
+func TestEmailDeepStrategy_Cache(t *testing.T) {
+	resolver := newFakeResolver()
+	s := newEmailStrategy(resolver)
+
+	// 同一次验证中两个字段使用相同域名
+	model := &contact{Email: "a@missing.com", Backup: "b@missing.com"}
+	want := []string{"email: " + TagEmailUndeliverable, "backup: " + TagEmailUndeliverable}
+	if got := validateEmail(s, model); !equalTags(got, want) {
+		t.Errorf("errors = %v, want %v", got, want)
+	}
+	if resolver.calls["missing.com"] != 1 {
+		t.Errorf("同一次验证 LookupMX 次数 = %d, want 1", resolver.calls["missing.com"])
+	}
+
+	// 跨验证命中缓存
+	validateEmail(s, model)
+	if resolver.calls["missing.com"] != 1 {
+		t.Errorf("缓存命中后 LookupMX 次数 = %d, want 1", resolver.calls["missing.com"])
+	}
+
+	// 临时错误不缓存
+	validateEmail(s, &contact{Email: "a@slow.com"})
+	validateEmail(s, &contact{Email: "a@slow.com"})
+	if resolver.calls["slow.com"] != 2 {
+		t.Errorf("临时错误 LookupMX 次数 = %d, want 2", resolver.calls["slow.com"])
+	}
+}
+
+// TestEmailDeepStrategy_Options 测试关闭 MX 检查和自定义一次性邮箱列表
+func TestEmailDeepStrategy_Options(t *testing.T) {
+	resolver := newFakeResolver()
+
+	s := newEmailStrategy(resolver, WithoutMXLookup())
+	if got := validateEmail(s, &contact{Email: "a@missing.com"}); len(got) != 0 {
+		t.Errorf("WithoutMXLookup errors = %v, want none", got)
+	}
+	if got := validateEmail(s, &contact{Email: "a@yopmail.com"}); !equalTags(got, []string{"email: " + TagEmailDisposable}) {
+		t.Errorf("WithoutMXLookup 仍应检查一次性邮箱: %v", got)
+	}
+	if len(resolver.calls) != 0 {
+		t.Errorf("WithoutMXLookup 不应查询 DNS: %v", resolver.calls)
+	}
+
+	s = newEmailStrategy(resolver, WithoutMXLookup(), WithDisposableDomains(NewDomainSet("example.com")))
+	if got := validateEmail(s, &contact{Email: "a@example.com", Backup: "b@mailinator.com"}); !equalTags(got, []string{"email: " + TagEmailDisposable}) {
+		t.Errorf("自定义列表 errors = %v", got)
+	}
+
+	s = newEmailStrategy(resolver, WithoutMXLookup(), WithDisposableDomains(nil))
+	if got := validateEmail(s, &contact{Email: "a@mailinator.com"}); len(got) != 0 {
+		t.Errorf("WithDisposableDomains(nil) errors = %v, want none", got)
+	}
+}
+
+// ============================================================================
+// 一次性邮箱域名列表
+// ============================================================================
+
+// TestLoadDomainSet 测试从文本加载域名列表
+func TestLoadDomainSet(t *testing.T) {
+	set, err := LoadDomainSet(strings.NewReader("# disposable\n\nTempMail.io\n  burner.dev.  \n"))
+	if err != nil {
+		t.Fatalf("LoadDomainSet() error = %v", err)
+	}
+	if set.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", set.Len())
+	}
+
+	tests := []struct {
+		domain string
+		want   bool
+	}{
+		{"tempmail.io", true},
+		{"TEMPMAIL.IO", true},
+		{"inbox.burner.dev", true},
+		{"notburner.dev", false},
+		{"io", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := set.IsDisposable(tt.domain); got != tt.want {
+			t.Errorf("IsDisposable(%q) = %t, want %t", tt.domain, got, tt.want)
+		}
+	}
+}
+
+// equalTags 比较 "字段: 标签" 列表（顺序无关，规则按 map 遍历）
+func equalTags(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	seen := make(map[string]int, len(got))
+	for _, s := range got {
+		seen[s]++
+	}
+	for _, s := range want {
+		if seen[s]--; seen[s] < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+package strategy
+
+import (
+	"katydid-common-account/pkg/validator/v6/core"
+	"reflect"
+	"strings"
+)
+
+// ============================================================================
+// 嵌入结构体规则合并
+// ============================================================================
+
+// embeddedRules 合并嵌入结构体（如 BaseModel）的规则
+// 说明：
+//   - 遍历导出的匿名结构体字段（含指针），嵌入值实现 IRuleValidator 时取其当前场景的规则，递归处理多层嵌入
+//   - 嵌入规则的键加上嵌入字段名前缀（如 BaseModel.id），错误命名空间为 User.BaseModel.id
+//   - 外层规则优先：外层规则键指向提升字段（如 id）时同样加上前缀，与嵌入规则同名时覆盖嵌入规则
+//   - nil 指针嵌入按零值取规则（规则按类型缓存，不能依赖实例），字段视为未提供
+//   - 有改动时复制后返回，避免修改模型返回的规则
+func embeddedRules(target any, rules map[string]string, scene core.Scene, legacy core.ILegacyAdapter) map[string]string {
+	v := reflect.ValueOf(target)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v = reflect.Zero(v.Type().Elem())
+			continue
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || !hasEmbeddedStruct(v.Type()) {
+		return rules
+	}
+
+	typ := v.Type()
+	merged := make(map[string]string, len(rules))
+	for field, rule := range rules {
+		merged[promotedFieldKey(typ, field)] = rule
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !isEmbeddedStruct(field) {
+			continue
+		}
+
+		embedded := embeddedValue(v.Field(i))
+		provider, ok := ruleValidatorOf(embedded, legacy)
+		if !ok {
+			continue
+		}
+		for key, rule := range embeddedRules(embedded, provider.ValidateRules(scene), scene, legacy) {
+			key = field.Name + "." + key
+			if _, exists := merged[key]; !exists {
+				merged[key] = rule
+			}
+		}
+	}
+	return merged
+}
+
+// embeddedValue 嵌入字段的值，指针接收者的规则方法也可调用（nil 指针和不可寻址的值按零值新建）
+func embeddedValue(field reflect.Value) any {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return reflect.New(field.Type().Elem()).Interface()
+		}
+		return field.Interface()
+	}
+	if field.CanAddr() {
+		return field.Addr().Interface()
+	}
+	ptr := reflect.New(field.Type())
+	ptr.Elem().Set(field)
+	return ptr.Interface()
+}
+
+// promotedFieldKey 规则键的首段为嵌入结构体的提升字段时，加上嵌入字段路径（如 id -> BaseModel.id）
+func promotedFieldKey(typ reflect.Type, key string) string {
+	head, _, _ := strings.Cut(key, ".")
+	if path, _, ok := promotedFieldPath(typ, head); ok && path != "" {
+		return path + "." + key
+	}
+	return key
+}
+
+// promotedFieldPath 查找字段（Go 字段名或 json 名称），返回嵌入字段路径（直接字段为空）和嵌入深度
+// 说明：与 Go 的字段提升一致，深度最浅的优先
+func promotedFieldPath(typ reflect.Type, name string) (string, int, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.IsExported() && (field.Name == name || jsonFieldName(field) == name) {
+			return "", 0, true
+		}
+	}
+
+	path, depth, found := "", 0, false
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !isEmbeddedStruct(field) {
+			continue
+		}
+		sub, d, ok := promotedFieldPath(indirectType(field.Type), name)
+		if !ok || (found && d+1 >= depth) {
+			continue
+		}
+		path, depth, found = field.Name, d+1, true
+		if sub != "" {
+			path += "." + sub
+		}
+	}
+	return path, depth, found
+}
+
+// hasEmbeddedStruct 是否有导出的嵌入结构体字段
+func hasEmbeddedStruct(typ reflect.Type) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		if isEmbeddedStruct(typ.Field(i)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmbeddedStruct 是否为导出的嵌入结构体字段（含结构体指针）
+func isEmbeddedStruct(field reflect.StructField) bool {
+	return field.Anonymous && field.IsExported() && indirectType(field.Type).Kind() == reflect.Struct
+}
+
+// indirectType 去掉指针后的类型
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+// jsonFieldName 字段的 json 名称（没有或为 "-" 时为空）
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
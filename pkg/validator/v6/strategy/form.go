@@ -0,0 +1,210 @@
+package strategy
+
+import (
+	"fmt"
+	"io"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// 表单验证 - url.Values 与 multipart 上传
+// ============================================================================
+
+// 文件规则标签
+const (
+	TagMaxSize  = "max_size"  // 单个文件最大大小：max_size=2MB（B、KB、MB、GB，按 1024 换算）
+	TagMimes    = "mimes"     // 允许的内容类型：mimes=image/png image/jpeg，支持 image/*
+	TagMaxFiles = "max_files" // 最多文件数：max_files=3
+	TagFile     = "file"      // 文件无法读取
+)
+
+// sniffLen 检测内容类型读取的字节数（http.DetectContentType 最多使用 512 字节）
+const sniffLen = 512
+
+// ValidateFormRules 按规则验证表单
+// 说明：
+//   - 规则包含 max_size、mimes 或 max_files，或 files 中有该字段时按上传文件验证，其他字段按 Map 规则验证
+//   - 字段值都是字符串，按弱类型模式转换后验证（"25" 满足 gte=18），不记录 coerced 提示
+//   - 同名字段只有一个值时为字符串，多个值或规则包含 dive 时为切片
+//   - 文件字段：required 要求至少一个文件；内容类型按文件内容检测（http.DetectContentType），不信任客户端声明的 Content-Type；
+//     多个文件时错误的命名空间带下标（如 photos[1]）
+func ValidateFormRules(engine core.IDependencyEngine, values url.Values, files map[string][]*multipart.FileHeader, rules map[string]string, collector core.IErrorCollector) {
+	fieldRules := make(map[string]string, len(rules))
+	fileFields := make([]string, 0)
+	for field, rule := range rules {
+		if isFileRule(rule) || len(files[field]) > 0 {
+			fileFields = append(fileFields, field)
+			continue
+		}
+		fieldRules[field] = rule
+	}
+
+	ValidateMapRules(engine, formPayload(values, fieldRules), fieldRules, collector, WithWeakTyping(), func(s *mapSettings) {
+		s.quietCoercion = true
+	})
+
+	sort.Strings(fileFields)
+	for _, field := range fileFields {
+		if collector.Count() >= collector.MaxErrors() {
+			return
+		}
+		validateFiles(field, files[field], rules[field], collector)
+	}
+}
+
+// formPayload 表单值转换为 Map 验证的请求体
+func formPayload(values url.Values, rules map[string]string) map[string]any {
+	payload := make(map[string]any, len(values))
+	for key, vals := range values {
+		if len(vals) == 1 && !hasTag(rules[key], tagDive) {
+			payload[key] = vals[0]
+			continue
+		}
+		items := make([]any, len(vals))
+		for i, v := range vals {
+			items[i] = v
+		}
+		payload[key] = items
+	}
+	return payload
+}
+
+// hasTag 规则是否包含指定标签
+func hasTag(rule, tag string) bool {
+	for _, t := range strings.Split(rule, ",") {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// isFileRule 规则是否包含文件规则标签
+func isFileRule(rule string) bool {
+	for _, tag := range strings.Split(rule, ",") {
+		switch name, _, _ := strings.Cut(tag, "="); name {
+		case TagMaxSize, TagMimes, TagMaxFiles:
+			return true
+		}
+	}
+	return false
+}
+
+// validateFiles 按文件规则验证上传文件（未知标签忽略）
+func validateFiles(field string, headers []*multipart.FileHeader, rule string, collector core.IErrorCollector) {
+	name := lastPathSegment(field)
+	report := func(namespace, tag, param string, value any, message string) {
+		collector.Collect(errors.NewFieldError(namespace, name, tag,
+			errors.WithParam(param), errors.WithValue(value), errors.WithMessage(message)))
+	}
+
+	if len(headers) == 0 {
+		if _, required := splitPresenceRule(rule); required {
+			collector.Collect(errors.NewFieldError(field, name, tagRequired))
+		}
+		return
+	}
+
+	for _, tag := range strings.Split(rule, ",") {
+		tagName, param, _ := strings.Cut(tag, "=")
+		if tagName != TagMaxFiles {
+			continue
+		}
+		if limit, err := strconv.Atoi(param); err == nil && len(headers) > limit {
+			report(field, TagMaxFiles, param, len(headers), fmt.Sprintf("at most %s files are allowed", param))
+		}
+	}
+
+	for i, header := range headers {
+		namespace := field
+		if len(headers) > 1 {
+			namespace = fmt.Sprintf("%s[%d]", field, i)
+		}
+
+		for _, tag := range strings.Split(rule, ",") {
+			tagName, param, _ := strings.Cut(tag, "=")
+			switch tagName {
+			case TagMaxSize:
+				limit, err := parseByteSize(param)
+				if err != nil {
+					report(namespace, TagMaxSize, param, header.Size, fmt.Sprintf("invalid size limit %q", param))
+				} else if header.Size > limit {
+					report(namespace, TagMaxSize, param, header.Size, fmt.Sprintf("file must not exceed %s", param))
+				}
+			case TagMimes:
+				contentType, err := detectContentType(header)
+				if err != nil {
+					report(namespace, TagFile, "", header.Filename, err.Error())
+				} else if !matchMimes(contentType, strings.Fields(param)) {
+					report(namespace, TagMimes, param, contentType, fmt.Sprintf("file type %s is not allowed", contentType))
+				}
+			}
+		}
+	}
+}
+
+// parseByteSize 解析大小（如 512、100KB、2MB、1GB，按 1024 换算，不区分大小写）
+func parseByteSize(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		factor int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}}
+
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	factor := int64(1)
+	for _, unit := range units {
+		if strings.HasSuffix(upper, unit.suffix) {
+			upper, factor = strings.TrimSpace(strings.TrimSuffix(upper, unit.suffix)), unit.factor
+			break
+		}
+	}
+	n, err := strconv.ParseInt(upper, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n * factor, nil
+}
+
+// detectContentType 按文件内容检测类型（不含参数，如 image/png）
+func detectContentType(header *multipart.FileHeader) (string, error) {
+	file, err := header.Open()
+	if err != nil {
+		return "", fmt.Errorf("cannot read file %s: %w", header.Filename, err)
+	}
+	defer file.Close()
+
+	buf := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", fmt.Errorf("cannot read file %s: %w", header.Filename, err)
+	}
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(buf[:n]))
+	if err != nil {
+		return "application/octet-stream", nil
+	}
+	return mediaType, nil
+}
+
+// matchMimes 内容类型是否在白名单中（支持 image/* 通配）
+func matchMimes(contentType string, allowed []string) bool {
+	for _, pattern := range allowed {
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(contentType, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(contentType, pattern) {
+			return true
+		}
+	}
+	return false
+}
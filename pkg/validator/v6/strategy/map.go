@@ -0,0 +1,306 @@
+package strategy
+
+import (
+	"encoding/json"
+	"fmt"
+	"katydid-common-account/pkg/validator/bridge"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// Map 验证 - 无结构体模型的请求体
+// ============================================================================
+
+// TagCoerced 弱类型模式下字符串被转换时记录的提示标签（Info 级别，参数为转换后的类型）
+const TagCoerced = "coerced"
+
+// MapOption Map 验证选项
+type MapOption func(*mapSettings)
+
+// mapSettings Map 验证设置
+type mapSettings struct {
+	weakTyping    bool
+	quietCoercion bool // 不记录 coerced 提示（表单值都是字符串，转换是预期行为）
+}
+
+// WithWeakTyping 弱类型模式（表单提交的请求体，所有值都是字符串）
+// 说明：
+//   - 数字字符串转换为 int64/float64，"true"/"false"（不区分大小写）转换为 bool，再按规则验证，如 "25" 满足 gte=18
+//   - 规则包含字符串格式标签（如 email、alphanum、len、startswith）时不转换，按字符串验证
+//   - 转换后的值来自非空字符串，视为满足 required（"0"、"false" 不会因零值失败）
+//   - 每次转换记录一条 Info 级别的 coerced 提示（只记录字段本身，不记录切片元素）
+func WithWeakTyping() MapOption {
+	return func(s *mapSettings) {
+		s.weakTyping = true
+	}
+}
+
+// ValidateMapRules 按规则验证 map[string]any（如内部工具收到的无模式 JSON）
+// 说明：
+//   - 规则键为字段路径，带 "." 的键按路径进入嵌套对象（如 address.city）；按键排序验证，错误顺序稳定
+//   - 键不存在或值为 nil 时只检查 required，其余规则跳过；提供了值时按 go-playground 语义验证完整规则（空字符串不满足 required）
+//   - 规则中的别名（RegisterRuleAlias）先展开；值在验证前做类型转换，见 coerceMapValue
+//   - 错误的命名空间为字段路径，字段名为路径的最后一段
+//   - 不支持仓储规则（unique/exists，直接忽略）；参数引用（${key}）无法解析，报告 param 错误
+//   - 表单等字符串值的请求体可使用 WithWeakTyping
+func ValidateMapRules(engine core.IDependencyEngine, payload map[string]any, rules map[string]string, collector core.IErrorCollector, opts ...MapOption) {
+	var settings mapSettings
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	fields := make([]string, 0, len(rules))
+	for field := range rules {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		rule := ExpandRuleAliases(rules[field])
+		if len(field) == 0 || len(rule) == 0 {
+			continue
+		}
+		if collector.Count() >= collector.MaxErrors() {
+			break
+		}
+
+		// 仓储规则需要模型对应的表，Map 验证中忽略
+		rule, _ = splitRepositoryRules(rule)
+		if len(rule) == 0 {
+			continue
+		}
+		if hasParamRef(rule) {
+			_, key, _ := expandParams(rule, nil, nil)
+			collector.Collect(errors.NewFieldError(field, lastPathSegment(field), tagParam,
+				errors.WithParam(key),
+				errors.WithMessage(fmt.Sprintf("rule param ${%s} is not supported in map validation", key))))
+			continue
+		}
+
+		value, ok := walkFieldPath(payload, field)
+		if !ok {
+			continue
+		}
+
+		// 未提供或显式 null 时只检查 required；提供了值时按完整规则验证（零值不满足 required）
+		inner, state := resolvePresence(value)
+		if state != presencePresent {
+			if _, required := splitPresenceRule(rule); required {
+				collector.Collect(errors.NewFieldError(field, lastPathSegment(field), tagRequired))
+			}
+			continue
+		}
+
+		value = coerceMapValue(inner, rule, settings.weakTyping)
+		if settings.weakTyping {
+			if original, ok := inner.(string); ok && original != "" {
+				if _, still := value.(string); !still {
+					// 非空字符串已满足 required，避免转换出的零值（0、false）不满足
+					rule, _ = splitPresenceRule(rule)
+					if !settings.quietCoercion {
+						collector.AddWarning(errors.NewFieldError(field, lastPathSegment(field), TagCoerced,
+							errors.WithParam(fmt.Sprintf("%T", value)),
+							errors.WithValue(original),
+							errors.WithSeverity(core.SeverityInfo),
+							errors.WithMessage(fmt.Sprintf("coerced %q to %T", original, value))))
+					}
+					if rule == "" {
+						continue
+					}
+				}
+			}
+		}
+
+		if err := engine.ValidateField(value, rule); err != nil {
+			collectMapErrors(err, field, collector)
+		}
+	}
+}
+
+// collectMapErrors 转换并收集 Map 字段的验证错误
+func collectMapErrors(err error, field string, collector core.IErrorCollector) {
+	fieldErrors, ok := bridge.FromValidationErrors(err, nil)
+	if !ok {
+		collector.Collect(errors.NewFieldErrorWithMessage(err.Error()))
+		return
+	}
+
+	for _, fe := range fieldErrors {
+		switch {
+		case fe.Field == "":
+			fe.Namespace, fe.Field = field, lastPathSegment(field)
+		case strings.HasPrefix(fe.Namespace, "["):
+			// dive 产生的元素错误只有下标（如 [1]），补全为 tags[1]
+			fe.Namespace, fe.Field = field+fe.Namespace, lastPathSegment(field)+fe.Field
+		}
+
+		fieldErr := errors.NewFieldError(fe.Namespace, fe.Field, fe.Tag,
+			errors.WithParam(fe.Param), errors.WithValue(fe.Value))
+		if !collector.Collect(fieldErr) {
+			break
+		}
+	}
+}
+
+// coerceMapValue 将 JSON 解码得到的值转换为规则期望的类型
+// 说明：
+//   - json.Number 转换为 int64（整数）或 float64
+//   - 整数值的浮点数（JSON 数字默认解码为 float64）转换为 int64，使 oneof 等只支持整数的规则可用
+//   - 规则包含 number 或 numeric 时，数字字符串转换为数值，使 gte、max 等按数值比较而不是按长度；
+//     同时包含字符串格式标签（如 len=5,numeric 的邮编）时保持字符串
+//   - 切片和嵌套对象中的元素递归转换（dive 规则作用于转换后的元素）；
+//     元素类型一致的 []any 转换为 []string、[]int64 等，否则 dive 的 required 对 interface 元素不生效
+//   - 弱类型模式下其他字符串也尝试转换，见 WithWeakTyping
+func coerceMapValue(value any, rule string, weak bool) any {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return coerceFloat(f)
+		}
+		return v.String()
+	case float64:
+		return coerceFloat(v)
+	case float32:
+		return coerceFloat(float64(v))
+	case string:
+		if hasStringTag(rule) {
+			return v
+		}
+		if hasNumericTag(rule) {
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return n
+			}
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f
+			}
+		}
+		if weak {
+			return weakCoerceString(v)
+		}
+		return v
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = coerceMapValue(item, elementRule(rule), weak)
+		}
+		return typedSlice(items)
+	case map[string]any:
+		items := make(map[string]any, len(v))
+		for key, item := range v {
+			items[key] = coerceMapValue(item, elementRule(rule), weak)
+		}
+		return items
+	}
+	return value
+}
+
+// weakCoerceString 弱类型模式下转换数字和布尔字符串（规则要求字符串格式时调用方已跳过）
+func weakCoerceString(v string) any {
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return f
+	}
+	switch {
+	case strings.EqualFold(v, "true"):
+		return true
+	case strings.EqualFold(v, "false"):
+		return false
+	}
+	return v
+}
+
+// stringFormatTags 按字符串内容验证的标签，这些规则的值保持字符串，不做数值或布尔转换
+var stringFormatTags = map[string]struct{}{
+	"alpha": {}, "alphanum": {}, "alphaunicode": {}, "alphanumunicode": {}, "ascii": {}, "printascii": {},
+	"lowercase": {}, "uppercase": {}, "hexadecimal": {}, "email": {}, "url": {}, "uri": {}, "http_url": {},
+	"uuid": {}, "uuid4": {}, "e164": {}, "len": {}, "contains": {}, "containsany": {}, "excludes": {},
+	"startswith": {}, "endswith": {}, "ip": {}, "ipv4": {}, "ipv6": {}, "datetime": {}, "base64": {},
+	"json": {}, "jwt": {}, "isbn": {}, "iso3166_1_alpha2": {}, "bcp47_language_tag": {}, TagUsernamePolicy: {},
+}
+
+// hasStringTag 规则（dive 之前的部分）是否按字符串内容验证
+func hasStringTag(rule string) bool {
+	for _, tag := range strings.Split(rule, ",") {
+		if tag == tagDive {
+			return false
+		}
+		name, _, _ := strings.Cut(tag, "=")
+		if _, ok := stringFormatTags[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// typedSlice 元素类型一致时转换为对应类型的切片
+func typedSlice(items []any) any {
+	if len(items) == 0 {
+		return items
+	}
+	switch items[0].(type) {
+	case string:
+		return convertSlice[string](items)
+	case int64:
+		return convertSlice[int64](items)
+	case float64:
+		return convertSlice[float64](items)
+	case bool:
+		return convertSlice[bool](items)
+	}
+	return items
+}
+
+// convertSlice 将 []any 转换为 []T，存在其他类型的元素时原样返回
+func convertSlice[T any](items []any) any {
+	result := make([]T, len(items))
+	for i, item := range items {
+		v, ok := item.(T)
+		if !ok {
+			return items
+		}
+		result[i] = v
+	}
+	return result
+}
+
+// coerceFloat 整数值的浮点数转换为 int64
+func coerceFloat(f float64) any {
+	if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return int64(f)
+	}
+	return f
+}
+
+// hasNumericTag 规则（dive 之前的部分）是否要求数值
+func hasNumericTag(rule string) bool {
+	for _, tag := range strings.Split(rule, ",") {
+		switch tag {
+		case "dive":
+			return false
+		case "number", "numeric":
+			return true
+		}
+	}
+	return false
+}
+
+// elementRule dive 之后作用于元素的规则（没有 dive 时为空）
+func elementRule(rule string) string {
+	tags := strings.Split(rule, ",")
+	for i, tag := range tags {
+		if tag == "dive" {
+			return strings.Join(tags[i+1:], ",")
+		}
+	}
+	return ""
+}
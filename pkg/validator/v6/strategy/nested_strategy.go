@@ -0,0 +1,466 @@
+package strategy
+
+import (
+	stdcontext "context"
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// tagSelf 自验证（ISelfValidator）失败的错误标签
+const tagSelf = "self"
+
+// TagCircularReference 嵌套验证遇到循环引用时的错误标签（CyclePolicyReport，参数为对象类型）
+const TagCircularReference = "circular_reference"
+
+// TagNestedPanic 并发验证集合元素时 panic 的错误标签（归属到该元素的路径）
+const TagNestedPanic = "nested_panic"
+
+// tagValidationDegraded 并发验证因 Go 上下文取消跳过剩余元素时收集的警告标签（与编排层的预算降级警告一致）
+const tagValidationDegraded = "validation_degraded"
+
+// defaultConcurrentMinItems 并发验证的默认元素数下限
+const defaultConcurrentMinItems = 64
+
+// maxSkippedPaths 跳过元素的警告中最多列出的路径数
+const maxSkippedPaths = 10
+
+// nestedStrategy 动态类型字段的嵌套验证策略
+// 职责：验证声明为接口（any、interface）或泛型容器（如 List[T]）的字段中的运行时值
+// 设计原则：组合 - 复用规则策略和业务策略验证具体类型
+type nestedStrategy struct {
+	name     string
+	rules    core.IValidationStrategy // 验证具体类型的规则（可选）
+	business core.IValidationStrategy // 验证具体类型的业务规则（可选）
+	maxDepth int
+	cycles   core.CyclePolicy
+	workers  int // 并发验证集合元素的工作协程数（<= 1 表示串行）
+	minItems int // 集合元素数达到该值时才并发
+}
+
+// NestedStrategyOption 嵌套策略选项
+type NestedStrategyOption func(*nestedStrategy)
+
+// WithNestedMaxDepth 设置最大嵌套深度（超过时不再进入，防止自引用数据无限递归）
+func WithNestedMaxDepth(maxDepth int) NestedStrategyOption {
+	return func(s *nestedStrategy) {
+		if maxDepth > 0 {
+			s.maxDepth = maxDepth
+		}
+	}
+}
+
+// WithNestedCyclePolicy 设置循环引用的处理方式（默认静默跳过）
+func WithNestedCyclePolicy(policy core.CyclePolicy) NestedStrategyOption {
+	return func(s *nestedStrategy) {
+		s.cycles = policy
+	}
+}
+
+// WithNestedConcurrency 并发验证大集合中的元素
+// 说明：
+//   - 集合（切片、数组、map）元素数达到 minItems（<= 0 时为 64）时，使用 workers 个协程验证各元素
+//   - 每个元素的错误先写入独立的收集器，全部完成后按元素顺序合并，输出与串行验证相同
+//   - 元素验证 panic 时报告 nested_panic 错误，其他元素继续验证
+//   - 只并发最外层的集合，元素内部的集合串行验证，协程数不会随嵌套层数放大
+func WithNestedConcurrency(workers, minItems int) NestedStrategyOption {
+	return func(s *nestedStrategy) {
+		s.workers = workers
+		if minItems > 0 {
+			s.minItems = minItems
+		}
+	}
+}
+
+// NewNestedStrategy 创建嵌套验证策略
+// 说明：
+//   - 只检查声明类型为接口、泛型容器或它们的切片、数组、map、指针的导出字段，普通字段由规则策略处理
+//   - 运行时值实现 IRuleValidator、IBusinessValidator 或 ISelfValidator 时，按具体类型验证（rules、business 可以为 nil）
+//   - 错误命名空间为 外层类型.字段路径(具体类型).内部字段，如 Order.items[0](CardPayment).number
+//   - 具体类型中的动态类型字段递归验证
+//   - 按指针识别对象：再次进入递归路径上的对象（如 a.Manager -> b，b.Manager -> a）时按循环引用处理，
+//     不再重复验证；同一对象被多个字段共享时仍分别验证
+func NewNestedStrategy(rules, business core.IValidationStrategy, opts ...NestedStrategyOption) core.IValidationStrategy {
+	s := &nestedStrategy{
+		name:     "nested",
+		rules:    rules,
+		business: business,
+		maxDepth: 50,
+		minItems: defaultConcurrentMinItems,
+	}
+
+	// 应用选项
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Type 策略类型
+func (s *nestedStrategy) Type() core.StrategyType {
+	return core.StrategyTypeNested
+}
+
+// Name 策略名称
+func (s *nestedStrategy) Name() string {
+	return s.name
+}
+
+// Validate 执行嵌套验证
+func (s *nestedStrategy) Validate(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	if ctx.Depth() >= s.maxDepth {
+		return nil
+	}
+
+	v := reflect.ValueOf(target)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := context.FieldSet(ctx)
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || !isDynamicType(field.Type) {
+			continue
+		}
+		if !fields.All() && !fields.IncludesAny(fieldKey(field), field.Name) {
+			continue
+		}
+		s.walk(v.Field(i), typ.Name()+"."+fieldKey(field), target, ctx, collector, 0)
+		if collector.Count() >= collector.MaxErrors() {
+			break
+		}
+	}
+	return nil
+}
+
+// walk 按运行时类型遍历值：实现验证接口的值按具体类型验证，否则进入元素和字段
+// 说明：owner 为字段所属的模型，与递归路径一起用于检测循环引用
+func (s *nestedStrategy) walk(v reflect.Value, path string, owner any, ctx core.IContext, collector core.IErrorCollector, depth int) {
+	if ctx.Depth()+depth >= s.maxDepth || collector.Count() >= collector.MaxErrors() {
+		return
+	}
+
+	for v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return
+	}
+
+	if value, ok := validatableValue(v); ok {
+		s.validateValue(value, path, owner, ctx, collector)
+		return
+	}
+
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		element := func(i int) (reflect.Value, string) {
+			return v.Index(i), fmt.Sprintf("%s[%d]", path, i)
+		}
+		if s.concurrent(v.Len(), ctx) {
+			s.walkConcurrent(v.Len(), path, element, owner, ctx, collector, depth+1)
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			elem, elemPath := element(i)
+			s.walk(elem, elemPath, owner, ctx, collector, depth+1)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		element := func(i int) (reflect.Value, string) {
+			return v.MapIndex(keys[i]), fmt.Sprintf("%s[%v]", path, keys[i].Interface())
+		}
+		if s.concurrent(len(keys), ctx) {
+			s.walkConcurrent(len(keys), path, element, owner, ctx, collector, depth+1)
+			return
+		}
+		for i := range keys {
+			elem, elemPath := element(i)
+			s.walk(elem, elemPath, owner, ctx, collector, depth+1)
+		}
+	case reflect.Struct:
+		// 泛型容器进入所有导出字段，其他结构体只进入动态类型字段
+		generic := isGenericType(v.Type())
+		typ := v.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.IsExported() && (generic || isDynamicType(field.Type)) {
+				s.walk(v.Field(i), path+"."+fieldKey(field), owner, ctx, collector, depth+1)
+			}
+		}
+	}
+}
+
+// nestedWorkerKey Go 上下文中标记已在并发验证的元素内部的键
+type nestedWorkerKey struct{}
+
+// concurrent 集合是否并发验证（已在并发验证的元素内部时串行）
+func (s *nestedStrategy) concurrent(n int, ctx core.IContext) bool {
+	return s.workers > 1 && n >= s.minItems && ctx.GoContext().Value(nestedWorkerKey{}) == nil
+}
+
+// walkConcurrent 使用工作协程池验证集合元素，按元素顺序合并错误
+// 说明：
+//   - 元素间不共享收集器，合并时才受最大错误数限制，因此结果与调度顺序无关
+//   - Go 上下文取消后不再验证剩余元素，并收集一条 validation_degraded 警告列出被跳过的元素路径
+func (s *nestedStrategy) walkConcurrent(n int, path string, element func(i int) (reflect.Value, string), owner any,
+	ctx core.IContext, collector core.IErrorCollector, depth int) {
+	// 元素共用的派生上下文：标记并发验证，共享记忆化缓存和递归路径（只读使用，并发安全）
+	workerCtx := context.NewContext(ctx.Scene(),
+		context.WithGoContext(stdcontext.WithValue(ctx.GoContext(), nestedWorkerKey{}, true)),
+		context.WithDepth(ctx.Depth()),
+		context.WithSharedMemo(ctx),
+		context.WithVisits(ctx),
+	)
+	defer workerCtx.Release()
+
+	results := make([]core.IErrorCollector, n)
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < min(s.workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= n || workerCtx.GoContext().Err() != nil {
+					return
+				}
+				elem, path := element(i)
+				results[i] = s.walkElement(elem, path, owner, workerCtx, collector.MaxErrors(), depth)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var skipped []string
+	for i, result := range results {
+		if result == nil {
+			_, elemPath := element(i)
+			skipped = append(skipped, elemPath)
+		}
+	}
+	if len(skipped) > 0 {
+		collectSkipped(path, skipped, workerCtx.GoContext().Err(), collector)
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		for _, warning := range result.Warnings() {
+			collector.AddWarning(warning)
+		}
+		if !collector.CollectAll(result.Errors()) {
+			return
+		}
+	}
+}
+
+// collectSkipped 收集集合元素因 Go 上下文取消未验证的警告（参数为跳过的元素数）
+func collectSkipped(path string, skipped []string, cause error, collector core.IErrorCollector) {
+	listed := skipped
+	if len(listed) > maxSkippedPaths {
+		listed = listed[:maxSkippedPaths]
+	}
+	message := fmt.Sprintf("%d elements of %s were not validated (%v): %s",
+		len(skipped), path, cause, strings.Join(listed, ", "))
+	if len(skipped) > len(listed) {
+		message += fmt.Sprintf(" and %d more", len(skipped)-len(listed))
+	}
+
+	collector.AddWarning(errors.NewFieldError(path, lastPathSegment(path), tagValidationDegraded,
+		errors.WithParam(strconv.Itoa(len(skipped))),
+		errors.WithMessage(message),
+		errors.WithSeverity(core.SeverityWarning),
+	))
+}
+
+// walkElement 验证单个元素，panic 转换为 nested_panic 错误
+func (s *nestedStrategy) walkElement(v reflect.Value, path string, owner any,
+	ctx core.IContext, maxErrors int, depth int) (result core.IErrorCollector) {
+	result = errors.NewListErrorCollector(maxErrors)
+	defer func() {
+		if r := recover(); r != nil {
+			result.Collect(errors.NewFieldError(path, lastPathSegment(path), TagNestedPanic,
+				errors.WithMessage(fmt.Sprintf("validating %s panicked: %v", path, r))))
+		}
+	}()
+	s.walk(v, path, owner, ctx, result, depth)
+	return result
+}
+
+// validateValue 按具体类型验证运行时值
+// 说明：派生上下文不共享元数据，外层的字段过滤（validate_fields/exclude_fields）不作用于内部模型
+func (s *nestedStrategy) validateValue(value any, path string, owner any, ctx core.IContext, collector core.IErrorCollector) {
+	typeName := indirectType(reflect.TypeOf(value)).Name()
+	prefix := path + "(" + shortTypeName(typeName) + ")"
+
+	if isSameObject(value, owner) || context.Visiting(ctx, value) {
+		if s.cycles == core.CyclePolicyReport {
+			collector.Collect(errors.NewFieldError(prefix, lastPathSegment(path), TagCircularReference,
+				errors.WithParam(shortTypeName(typeName)),
+				errors.WithMessage(fmt.Sprintf("%s refers back to a %s already being validated", path, shortTypeName(typeName)))))
+		}
+		return
+	}
+	nested := errors.NewNamespaceCollector(collector, func(namespace string) string {
+		if namespace == typeName || namespace == "" {
+			return prefix
+		}
+		return prefix + "." + strings.TrimPrefix(namespace, typeName+".")
+	})
+
+	childCtx := context.NewContext(ctx.Scene(),
+		context.WithGoContext(ctx.GoContext()),
+		context.WithDepth(ctx.Depth()+1),
+		context.WithSharedMemo(ctx),
+		context.WithVisits(ctx, owner, value),
+	)
+	defer childCtx.Release()
+
+	if s.rules != nil {
+		_ = s.rules.Validate(value, childCtx, nested)
+	}
+	if s.business != nil {
+		_ = s.business.Validate(value, childCtx, nested)
+	}
+	if self, ok := value.(core.ISelfValidator); ok {
+		collectSelfError(self.ValidateSelf(ctx.Scene()), prefix, path, nested, collector)
+	}
+
+	// 具体类型中的动态类型字段
+	_ = s.Validate(value, childCtx, nested)
+}
+
+// isSameObject 两个值是否为同一个对象（相同类型的同一指针）
+func isSameObject(a, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	return va.Kind() == reflect.Ptr && vb.Kind() == reflect.Ptr && !va.IsNil() &&
+		va.Type() == vb.Type() && va.Pointer() == vb.Pointer()
+}
+
+// collectSelfError 收集自验证的错误
+func collectSelfError(err error, prefix, path string, nested, collector core.IErrorCollector) {
+	switch e := err.(type) {
+	case nil:
+	case core.IValidationError:
+		nested.CollectAll(e.FieldErrors())
+	case core.IFieldError:
+		nested.Collect(e)
+	default:
+		collector.Collect(errors.NewFieldError(prefix, lastPathSegment(path), tagSelf, errors.WithMessage(err.Error())))
+	}
+}
+
+// validatableValue 值（或其指针）实现了验证接口时返回可用于验证的值（指针接收者的方法也可调用）
+func validatableValue(v reflect.Value) (any, bool) {
+	if !v.CanInterface() {
+		return nil, false
+	}
+	if implementsValidation(v.Type()) {
+		return v.Interface(), true
+	}
+	if v.Kind() == reflect.Ptr || !implementsValidation(reflect.PointerTo(v.Type())) {
+		return nil, false
+	}
+
+	if v.CanAddr() {
+		return v.Addr().Interface(), true
+	}
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	return ptr.Interface(), true
+}
+
+// validationInterfaces 触发嵌套验证的接口
+var validationInterfaces = []reflect.Type{
+	reflect.TypeOf((*core.IRuleValidator)(nil)).Elem(),
+	reflect.TypeOf((*core.IBusinessValidator)(nil)).Elem(),
+	reflect.TypeOf((*core.ISelfValidator)(nil)).Elem(),
+}
+
+// implementsValidation 类型是否实现了任一验证接口
+func implementsValidation(typ reflect.Type) bool {
+	for _, iface := range validationInterfaces {
+		if typ.Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDynamicType 是否为需要按运行时类型验证的声明类型（接口、泛型容器及其切片、数组、map、指针）
+func isDynamicType(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return isDynamicType(typ.Elem())
+	case reflect.Struct:
+		return isGenericType(typ)
+	}
+	return false
+}
+
+// isGenericType 是否为泛型类型的实例（类型名带类型参数，如 List[main.Item]）
+func isGenericType(typ reflect.Type) bool {
+	return strings.ContainsRune(typ.Name(), '[')
+}
+
+// shortTypeName 去掉泛型类型参数中的包路径（List[example.com/app/model.Item] -> List[model.Item]）
+func shortTypeName(name string) string {
+	open := strings.IndexByte(name, '[')
+	if open < 0 {
+		return name
+	}
+
+	var b strings.Builder
+	b.WriteString(name[:open])
+	start := open
+	for i := open; i < len(name); i++ {
+		switch name[i] {
+		case '[', ',', ']':
+			b.WriteString(name[start:i])
+			b.WriteByte(name[i])
+			start = i + 1
+		case '/':
+			start = i + 1
+		}
+	}
+	b.WriteString(name[start:])
+	return b.String()
+}
+
+// fieldKey 字段在命名空间中的名称（优先 json 名称，与规则键一致）
+func fieldKey(field reflect.StructField) string {
+	if name := jsonFieldName(field); name != "" {
+		return name
+	}
+	return field.Name
+}
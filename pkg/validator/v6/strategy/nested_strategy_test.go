@@ -0,0 +1,116 @@
+package strategy
+
+import (
+	stdcontext "context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+)
+
+// ============================================================================
+// 测试数据
+// ============================================================================
+
+// lineItem 自验证的集合元素
+type lineItem struct {
+	Qty int
+}
+
+func (i lineItem) ValidateSelf(core.Scene) error {
+	if i.Qty <= 0 {
+		return fmt.Errorf("qty must be positive")
+	}
+	return nil
+}
+
+// cart 包含动态类型集合的模型
+type cart struct {
+	Items []any `json:"items"`
+}
+
+// newCart 创建包含n个元素的模型，invalid中的下标为非法元素
+func newCart(n int, invalid ...int) *cart {
+	c := &cart{Items: make([]any, n)}
+	for i := range c.Items {
+		c.Items[i] = lineItem{Qty: 1}
+	}
+	for _, i := range invalid {
+		c.Items[i] = lineItem{}
+	}
+	return c
+}
+
+// ============================================================================
+// 并发验证
+// ============================================================================
+
+// TestNestedStrategy_Concurrent 测试并发验证与串行验证结果一致
+func TestNestedStrategy_Concurrent(t *testing.T) {
+	model := newCart(8, 5, 1)
+	validate := func(opts ...NestedStrategyOption) []string {
+		ctx := context.NewContext(sceneCreate)
+		defer ctx.Release()
+		collector := errors.NewListErrorCollector(10)
+		_ = NewNestedStrategy(nil, nil, opts...).Validate(model, ctx, collector)
+
+		namespaces := make([]string, 0, collector.Count())
+		for _, err := range collector.Errors() {
+			namespaces = append(namespaces, err.Namespace())
+		}
+		return namespaces
+	}
+
+	serial := validate()
+	concurrent := validate(WithNestedConcurrency(4, 2))
+	want := "cart.items[1](lineItem),cart.items[5](lineItem)"
+	if got := strings.Join(serial, ","); got != want {
+		t.Errorf("串行验证 = %s, want %s", got, want)
+	}
+	if got := strings.Join(concurrent, ","); got != want {
+		t.Errorf("并发验证 = %s, want %s", got, want)
+	}
+}
+
+// TestNestedStrategy_ConcurrentCancelled 测试 Go 上下文取消后跳过的元素以警告报告
+func TestNestedStrategy_ConcurrentCancelled(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    int
+		wantList string
+	}{
+		{"全部列出", 3, "cart.items[0], cart.items[1], cart.items[2]"},
+		{"超出上限", maxSkippedPaths + 5, "cart.items[9] and 5 more"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goCtx, cancel := stdcontext.WithCancel(stdcontext.Background())
+			cancel()
+			ctx := context.NewContext(sceneCreate, context.WithGoContext(goCtx))
+			defer ctx.Release()
+			collector := errors.NewListErrorCollector(10)
+
+			s := NewNestedStrategy(nil, nil, WithNestedConcurrency(4, 2))
+			_ = s.Validate(newCart(tt.items, 0), ctx, collector)
+
+			if collector.HasErrors() {
+				t.Errorf("跳过的元素不应产生错误: %v", collector.Errors())
+			}
+			warnings := collector.Warnings()
+			if len(warnings) != 1 {
+				t.Fatalf("Warnings() = %v, want one warning", warnings)
+			}
+			w := warnings[0]
+			if w.Tag() != tagValidationDegraded || w.Namespace() != "cart.items" || w.Param() != fmt.Sprint(tt.items) {
+				t.Errorf("warning = %s %s %s", w.Namespace(), w.Tag(), w.Param())
+			}
+			if !strings.Contains(w.Message(), tt.wantList) || !strings.Contains(w.Message(), "context canceled") {
+				t.Errorf("Message() = %q, want paths %q", w.Message(), tt.wantList)
+			}
+		})
+	}
+}
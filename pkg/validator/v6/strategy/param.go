@@ -0,0 +1,75 @@
+package strategy
+
+import (
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"strings"
+)
+
+// ============================================================================
+// 规则参数引用 - max=${limits.username_max}
+// ============================================================================
+
+// tagParam 参数引用无法解析时的错误标签
+const tagParam = "param"
+
+// hasParamRef 规则是否包含参数引用
+func hasParamRef(rule string) bool {
+	return strings.Contains(rule, "${")
+}
+
+// paramSeparators 规则中的分隔符，参数值包含时会改变规则结构（如注入额外的规则或 or 分支）
+const paramSeparators = ",|"
+
+// expandParams 展开规则中的 ${key} 引用
+// 说明：同一次验证内每个 key 只解析一次（通过 ctx.MemoValue 缓存）
+// 返回值：引用无法解析（未配置解析器、值不存在或缺少 "}"）或值包含 "," "|" 时返回错误，unresolved 为出错的 key
+func expandParams(rule string, ctx core.IContext, resolver core.IParamResolver) (expanded, unresolved string, err error) {
+	var b strings.Builder
+	b.Grow(len(rule))
+
+	for {
+		start := strings.Index(rule, "${")
+		if start < 0 {
+			b.WriteString(rule)
+			return b.String(), "", nil
+		}
+		end := strings.IndexByte(rule[start:], '}')
+		if end < 0 {
+			key := rule[start+2:]
+			return "", key, fmt.Errorf("rule param ${%s is missing \"}\"", key)
+		}
+
+		key := rule[start+2 : start+end]
+		value, found := resolveParam(key, ctx, resolver)
+		if !found {
+			return "", key, fmt.Errorf("rule param ${%s} is not configured", key)
+		}
+		if strings.ContainsAny(value, paramSeparators) {
+			return "", key, fmt.Errorf("rule param ${%s} value %q must not contain \",\" or \"|\"", key, value)
+		}
+
+		b.WriteString(rule[:start])
+		b.WriteString(value)
+		rule = rule[start+end+1:]
+	}
+}
+
+// resolveParam 解析单个参数引用
+func resolveParam(key string, ctx core.IContext, resolver core.IParamResolver) (string, bool) {
+	if resolver == nil || key == "" {
+		return "", false
+	}
+
+	value, err := ctx.MemoValue("param:"+key, func() (any, error) {
+		value, ok := resolver.ResolveParam(ctx.GoContext(), key)
+		if !ok {
+			return nil, fmt.Errorf("rule param %q not configured", key)
+		}
+		return value, nil
+	})
+	if err != nil {
+		return "", false
+	}
+	return value.(string), true
+}
@@ -0,0 +1,82 @@
+package strategy
+
+import (
+	stdcontext "context"
+	"strings"
+	"testing"
+
+	"katydid-common-account/pkg/validator/v6/context"
+)
+
+// countingResolver 记录解析次数的参数解析器
+type countingResolver struct {
+	values map[string]string
+	calls  map[string]int
+}
+
+func (r *countingResolver) ResolveParam(_ stdcontext.Context, key string) (string, bool) {
+	r.calls[key]++
+	value, ok := r.values[key]
+	return value, ok
+}
+
+// TestExpandParams 测试 ${key} 展开
+func TestExpandParams(t *testing.T) {
+	resolver := &countingResolver{
+		values: map[string]string{
+			"limits.min":  "3",
+			"limits.max":  "20",
+			"roles":       "admin user",
+			"inject":      "3,email",
+			"inject_or":   "3|len=0",
+			"limits.zero": "0",
+		},
+		calls: make(map[string]int),
+	}
+
+	tests := []struct {
+		name        string
+		rule        string
+		want        string
+		wantKey     string
+		wantMessage string
+	}{
+		{"无引用", "required,min=3", "required,min=3", "", ""},
+		{"多个引用", "required,min=${limits.min},max=${limits.max}", "required,min=3,max=20", "", ""},
+		{"重复引用", "min=${limits.min},len=${limits.min}", "min=3,len=3", "", ""},
+		{"空格分隔的值", "oneof=${roles}", "oneof=admin user", "", ""},
+		{"零值", "min=${limits.zero}", "min=0", "", ""},
+		{"未配置", "max=${limits.missing}", "", "limits.missing", "is not configured"},
+		{"空 key", "max=${}", "", "", "is not configured"},
+		{"缺少右括号", "max=${limits.max", "", "limits.max", `missing "}"`},
+		{"值包含逗号", "min=${inject}", "", "inject", `must not contain "," or "|"`},
+		{"值包含竖线", "min=${inject_or}", "", "inject_or", `must not contain "," or "|"`},
+	}
+
+	ctx := context.NewContext(sceneCreate)
+	defer ctx.Release()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, key, err := expandParams(tt.rule, ctx, resolver)
+			if tt.wantMessage == "" {
+				if err != nil || got != tt.want {
+					t.Errorf("expandParams() = %q, %v, want %q", got, err, tt.want)
+				}
+				return
+			}
+			if err == nil || key != tt.wantKey || !strings.Contains(err.Error(), tt.wantMessage) {
+				t.Errorf("expandParams() = %q, %v, want key %q and %q", key, err, tt.wantKey, tt.wantMessage)
+			}
+		})
+	}
+
+	// 同一次验证内每个 key 只解析一次
+	if resolver.calls["limits.min"] != 1 {
+		t.Errorf("limits.min resolved %d times, want 1", resolver.calls["limits.min"])
+	}
+
+	// 未配置解析器
+	if _, key, err := expandParams("max=${limits.max}", ctx, nil); err == nil || key != "limits.max" {
+		t.Errorf("expandParams() without resolver = %q, %v, want error", key, err)
+	}
+}
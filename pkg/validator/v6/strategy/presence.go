@@ -0,0 +1,135 @@
+package strategy
+
+import (
+	"database/sql/driver"
+	"katydid-common-account/pkg/validator/v6/core"
+	"reflect"
+	"strings"
+)
+
+// ============================================================================
+// 字段存在性 - 区分"未提供"和"提供了零值"
+// ============================================================================
+
+const (
+	tagRequired  = "required"
+	tagOmitempty = "omitempty"
+	tagDive      = "dive"
+)
+
+// presenceState 字段存在状态
+type presenceState int
+
+const (
+	presenceNotAware presenceState = iota // 普通字段，按原规则验证
+	presenceAbsent                        // 未提供（nil 指针、未定义的 Optional）
+	presenceNull                          // 显式 null（sql.NullX.Valid=false、Optional 为 null）
+	presencePresent                       // 提供了值（包括零值）
+)
+
+// resolvePresence 解析字段的存在状态
+// 说明：支持 *T（包括 **T、*sql.NullX）、NullX 类型以及实现 core.IOptional 的类型；
+// nil 指针视为未提供，Valid=false 视为显式 null，两者都不满足 required，其他规则跳过
+// 返回值：提供了值时 inner 为解引用后的值，其他规则按该值验证
+func resolvePresence(value any) (inner any, state presenceState) {
+	if value == nil {
+		return nil, presenceNotAware
+	}
+
+	// map 中按键取到的值（如 Extras.brand）
+	if entry, ok := value.(mapEntry); ok {
+		return entry.value, entry.state
+	}
+
+	// Optional[T]
+	if optional, ok := value.(core.IOptional); ok {
+		if !optional.IsDefined() {
+			return nil, presenceAbsent
+		}
+		inner, valid := optional.OptionalValue()
+		if !valid {
+			return nil, presenceNull
+		}
+		return inner, presencePresent
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr:
+		// *T：nil 表示未提供；多级指针（**T）和 *sql.NullX 继续解析，任意一级为 nil 都视为未提供
+		if v.IsNil() {
+			return nil, presenceAbsent
+		}
+		inner, state := resolvePresence(v.Elem().Interface())
+		if state == presenceNotAware {
+			return inner, presencePresent
+		}
+		return inner, state
+	case reflect.Struct:
+		// sql.NullString / sql.NullInt64 等：Valid=false 表示 null
+		if inner, valid, ok := nullValue(v); ok {
+			if !valid {
+				return nil, presenceNull
+			}
+			return inner, presencePresent
+		}
+	}
+
+	return value, presenceNotAware
+}
+
+// mapEntry 按路径从 map 中取到的值
+// 说明：键不存在视为未提供，值为 nil 视为显式 null
+type mapEntry struct {
+	value any
+	state presenceState
+}
+
+// valuerType driver.Valuer 接口类型
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// nullValue 解析 NullX 类型
+// 说明：database/sql 的 NullX 以及结构相同（{值字段, Valid bool}）且实现 driver.Valuer 的类型
+// （如数据库驱动提供的 NullTime）；值字段未导出时不识别
+func nullValue(v reflect.Value) (inner any, valid bool, ok bool) {
+	typ := v.Type()
+	if typ.NumField() != 2 || (typ.PkgPath() != "database/sql" && !typ.Implements(valuerType)) {
+		return nil, false, false
+	}
+
+	validField, found := typ.FieldByName("Valid")
+	if !found || validField.Type.Kind() != reflect.Bool {
+		return nil, false, false
+	}
+
+	valueIndex := 0
+	if validField.Index[0] == 0 {
+		valueIndex = 1
+	}
+	if !typ.Field(valueIndex).IsExported() {
+		return nil, false, false
+	}
+	return v.Field(valueIndex).Interface(), v.FieldByIndex(validField.Index).Bool(), true
+}
+
+// splitPresenceRule 拆出存在性相关的规则
+// 说明：required 改为检查是否提供，omitempty 不再需要（提供了零值也要验证）；
+// dive 之后的规则作用于元素，保持原样
+func splitPresenceRule(rule string) (rest string, required bool) {
+	tags := strings.Split(rule, ",")
+	kept := make([]string, 0, len(tags))
+	for i, tag := range tags {
+		if tag == tagDive {
+			kept = append(kept, tags[i:]...)
+			break
+		}
+		switch tag {
+		case tagRequired:
+			required = true
+		case tagOmitempty:
+		default:
+			kept = append(kept, tag)
+		}
+	}
+	return strings.Join(kept, ","), required
+}
@@ -0,0 +1,142 @@
+package strategy
+
+import (
+	"katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"reflect"
+)
+
+// ============================================================================
+// 查询字段策略 - 按角色限制列表接口的过滤字段
+// ============================================================================
+
+// TagFieldNotQueryable 调用方角色不允许按该字段过滤时的错误标签（参数为角色）
+const TagFieldNotQueryable = "field_not_queryable"
+
+// QueryPolicyStrategy 查询字段策略
+// 职责：查询场景下检查提供的过滤字段是否在调用方角色允许的范围内（IFieldPolicy）
+// 说明：
+//   - 只在 queryScenes 包含的场景执行；模型未实现 IFieldPolicy 或返回 nil 时不限制
+//   - 角色从验证上下文中获取（context.WithRole），未设置时为空字符串，由模型决定空角色允许的字段
+//   - 提供了值的字段视为过滤条件：非零值、非 nil 指针、已定义的 Optional（含显式 null）
+type QueryPolicyStrategy struct {
+	inspector   core.ITypeInspector
+	queryScenes core.Scene
+}
+
+// NewQueryPolicyStrategy 创建查询字段策略
+func NewQueryPolicyStrategy(inspector core.ITypeInspector, queryScenes core.Scene) *QueryPolicyStrategy {
+	return &QueryPolicyStrategy{inspector: inspector, queryScenes: queryScenes}
+}
+
+// Type 策略类型
+func (s *QueryPolicyStrategy) Type() core.StrategyType {
+	return core.StrategyTypeQueryPolicy
+}
+
+// Name 策略名称
+func (s *QueryPolicyStrategy) Name() string {
+	return string(core.StrategyTypeQueryPolicy)
+}
+
+// Validate 检查过滤字段
+func (s *QueryPolicyStrategy) Validate(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	if ctx.Scene()&s.queryScenes == 0 {
+		return nil
+	}
+	policy, ok := target.(core.IFieldPolicy)
+	if !ok {
+		return nil
+	}
+
+	role := context.Role(ctx)
+	allowed := policy.AllowedQueryFields(role)
+	if allowed == nil {
+		return nil
+	}
+
+	typeInfo := inspectType(s.inspector, target, ctx)
+	if typeInfo == nil {
+		return nil
+	}
+	for _, field := range unqueryableFields(target, allowed) {
+		fieldErr := errors.NewFieldError(typeInfo.TypeName()+"."+field, field, TagFieldNotQueryable, errors.WithParam(role))
+		if !collector.Collect(fieldErr) {
+			break
+		}
+	}
+	return nil
+}
+
+// StripQueryFields 清除调用方角色不允许过滤的字段（置为零值），返回被清除的字段
+// 说明：适合宽松的列表接口，忽略无权使用的过滤条件而不是报错；target 必须是结构体指针，
+// 模型未实现 IFieldPolicy 或返回 nil 时不做修改
+func StripQueryFields(target any, role string) []string {
+	policy, ok := target.(core.IFieldPolicy)
+	if !ok {
+		return nil
+	}
+	allowed := policy.AllowedQueryFields(role)
+	if allowed == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	stripped := unqueryableFields(target, allowed)
+	for _, field := range stripped {
+		if f := structField(v.Elem(), field); f.CanSet() {
+			f.Set(reflect.Zero(f.Type()))
+		}
+	}
+	return stripped
+}
+
+// unqueryableFields 提供了值但不在允许列表中的字段（按声明顺序，优先使用 json 名称）
+func unqueryableFields(target any, allowed []string) []string {
+	v := reflect.ValueOf(target)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	allow := make(map[string]struct{}, len(allowed))
+	for _, field := range allowed {
+		allow[field] = struct{}{}
+	}
+
+	var fields []string
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || !isQueryProvided(v.Field(i)) {
+			continue
+		}
+		if _, ok := allow[field.Name]; ok {
+			continue
+		}
+		if name := jsonFieldName(field); name != "" {
+			if _, ok := allow[name]; ok {
+				continue
+			}
+		}
+		fields = append(fields, fieldKey(field))
+	}
+	return fields
+}
+
+// isQueryProvided 字段是否作为过滤条件提供
+func isQueryProvided(field reflect.Value) bool {
+	if _, state := resolvePresence(field.Interface()); state != presenceNotAware {
+		return state != presenceAbsent
+	}
+	return !field.IsZero()
+}
@@ -0,0 +1,300 @@
+package strategy
+
+import (
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ============================================================================
+// 仓储规则解析
+// ============================================================================
+
+const (
+	TagUnique = "unique" // 唯一性规则：unique=table.column
+	TagExists = "exists" // 存在性规则：exists=table.column
+)
+
+// repositoryRule 仓储规则
+type repositoryRule struct {
+	tag    string // unique / exists
+	table  string
+	column string
+}
+
+// param 规则参数（table.column）
+func (r repositoryRule) param() string {
+	return r.table + "." + r.column
+}
+
+// splitRepositoryRules 从规则字符串中拆出仓储规则
+// 说明：
+//   - 只识别 unique=table.column 和 exists=table.column 形式
+//   - 不带 "." 的 unique（如 go-playground 内置的切片唯一性检查）保持原样
+func splitRepositoryRules(rule string) (rest string, repoRules []repositoryRule) {
+	if !strings.Contains(rule, TagUnique+"=") && !strings.Contains(rule, TagExists+"=") {
+		return rule, nil
+	}
+
+	tags := strings.Split(rule, ",")
+	kept := tags[:0]
+	for _, tag := range tags {
+		name, param, found := strings.Cut(tag, "=")
+		if found && (name == TagUnique || name == TagExists) {
+			if table, column, ok := strings.Cut(param, "."); ok && table != "" && column != "" {
+				repoRules = append(repoRules, repositoryRule{tag: name, table: table, column: column})
+				continue
+			}
+		}
+		kept = append(kept, tag)
+	}
+
+	return strings.Join(kept, ","), repoRules
+}
+
+// ============================================================================
+// 仓储验证策略
+// ============================================================================
+
+// repositoryLookup 一组待查询的值（同一 tag + table.column 合并为一次查询）
+type repositoryLookup struct {
+	rule   repositoryRule
+	values []any
+	fields []repositoryField
+}
+
+// repositoryField 待检查的字段
+type repositoryField struct {
+	name  string
+	value any
+}
+
+// RepositoryStrategy 仓储验证策略
+// 职责：处理 unique=table.column / exists=table.column 规则
+// 设计原则：
+//   - 适配器模式：通过注册的 IUniquenessChecker / IExistenceChecker 访问存储
+//   - 批量查询：一次验证中相同 table.column 的所有值合并为一次查询
+//   - 排除自身：模型实现 IRecordIdentifier 时，唯一性检查传入记录标识，更新时自身的值不算冲突
+type RepositoryStrategy struct {
+	inspector    core.ITypeInspector
+	ruleProvider core.IRuleProvider
+	tenantRules  core.ITenantRuleProvider
+	legacy       core.ILegacyAdapter
+
+	mu                 sync.RWMutex
+	uniquenessCheckers map[string]core.IUniquenessChecker // table -> checker，"" 为默认
+	existenceCheckers  map[string]core.IExistenceChecker  // table -> checker，"" 为默认
+}
+
+// NewRepositoryStrategy 创建仓储验证策略
+func NewRepositoryStrategy(inspector core.ITypeInspector, ruleProvider core.IRuleProvider) *RepositoryStrategy {
+	return &RepositoryStrategy{
+		inspector:          inspector,
+		ruleProvider:       ruleProvider,
+		uniquenessCheckers: make(map[string]core.IUniquenessChecker),
+		existenceCheckers:  make(map[string]core.IExistenceChecker),
+	}
+}
+
+// SetTenantRuleProvider 设置租户规则覆盖提供者（构建时调用）
+// 说明：租户可以覆盖 unique/exists 规则，与规则策略使用同一个提供者
+func (s *RepositoryStrategy) SetTenantRuleProvider(provider core.ITenantRuleProvider) {
+	s.tenantRules = provider
+}
+
+// SetLegacyAdapter 设置旧版模型适配器（构建时调用）
+// 说明：旧版模型规则中的 unique/exists 同样生效，与规则策略使用同一个适配器
+func (s *RepositoryStrategy) SetLegacyAdapter(adapter core.ILegacyAdapter) {
+	s.legacy = adapter
+}
+
+// RegisterUniquenessChecker 注册唯一性检查器
+// 说明：table 为空表示默认检查器，用于未单独注册的表
+func (s *RepositoryStrategy) RegisterUniquenessChecker(table string, checker core.IUniquenessChecker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uniquenessCheckers[table] = checker
+}
+
+// RegisterExistenceChecker 注册存在性检查器
+// 说明：table 为空表示默认检查器，用于未单独注册的表
+func (s *RepositoryStrategy) RegisterExistenceChecker(table string, checker core.IExistenceChecker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.existenceCheckers[table] = checker
+}
+
+// Type 策略类型
+func (s *RepositoryStrategy) Type() core.StrategyType {
+	return core.StrategyTypeRepository
+}
+
+// Name 策略名称
+func (s *RepositoryStrategy) Name() string {
+	return "repository"
+}
+
+// Validate 执行仓储验证
+func (s *RepositoryStrategy) Validate(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	typeInfo := inspectType(s.inspector, target, ctx)
+	if typeInfo == nil {
+		return nil
+	}
+
+	rules := filterRules(resolveRules(target, typeInfo, ctx, s.ruleProvider, s.tenantRules, s.legacy), ctx)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	// 步骤1：收集仓储规则，按 tag + table.column 分组
+	lookups := s.collectLookups(target, rules, typeInfo)
+	if len(lookups) == 0 {
+		return nil
+	}
+
+	// 步骤2：每组执行一次批量查询
+	exclude := recordID(target)
+	for _, lookup := range lookups {
+		hits, err := s.query(ctx, lookup, exclude)
+		if err != nil {
+			return err
+		}
+
+		// 步骤3：unique 命中即冲突，exists 未命中即不存在
+		for _, field := range lookup.fields {
+			hit := hits[field.value]
+			if (lookup.rule.tag == TagUnique) != hit {
+				continue
+			}
+
+			opts := []errors.FieldErrorOption{
+				errors.WithParam(lookup.rule.param()),
+				errors.WithValue(field.value),
+			}
+			if typeInfo.IsSensitive(field.name) {
+				opts = append(opts, errors.WithSensitive())
+			}
+
+			fieldErr := errors.NewFieldError(
+				typeInfo.TypeName()+"."+field.name,
+				field.name,
+				lookup.rule.tag,
+				opts...,
+			)
+			if !collector.Collect(fieldErr) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// collectLookups 收集待查询的值
+// 说明：按字段名顺序收集，查询和错误的顺序固定
+func (s *RepositoryStrategy) collectLookups(target any, rules map[string]string, typeInfo core.ITypeInfo) []*repositoryLookup {
+	var lookups []*repositoryLookup
+	index := make(map[repositoryRule]*repositoryLookup)
+
+	fieldNames := make([]string, 0, len(rules))
+	for fieldName := range rules {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		_, repoRules := splitRepositoryRules(rules[fieldName])
+		if len(repoRules) == 0 {
+			continue
+		}
+
+		accessor := typeInfo.FieldAccessor(fieldName)
+		if accessor == nil {
+			continue
+		}
+		value, ok := accessor(target)
+		if !ok {
+			continue
+		}
+
+		// *T、sql.NullX、Optional 只检查提供了的值
+		if inner, state := resolvePresence(value); state != presenceNotAware {
+			if state != presencePresent {
+				continue
+			}
+			value = inner
+		}
+		if !isLookupValue(value) {
+			continue
+		}
+
+		for _, repoRule := range repoRules {
+			lookup, exists := index[repoRule]
+			if !exists {
+				lookup = &repositoryLookup{rule: repoRule}
+				index[repoRule] = lookup
+				lookups = append(lookups, lookup)
+			}
+			lookup.values = append(lookup.values, value)
+			lookup.fields = append(lookup.fields, repositoryField{name: fieldName, value: value})
+		}
+	}
+
+	return lookups
+}
+
+// query 执行一次批量查询
+// 说明：exclude 只用于唯一性检查（存在性检查不需要排除自身）
+func (s *RepositoryStrategy) query(ctx core.IContext, lookup *repositoryLookup, exclude any) (map[any]bool, error) {
+	rule := lookup.rule
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	switch rule.tag {
+	case TagUnique:
+		checker, ok := s.uniquenessCheckers[rule.table]
+		if !ok {
+			checker, ok = s.uniquenessCheckers[""]
+		}
+		if !ok {
+			return nil, fmt.Errorf("no uniqueness checker registered for table %q", rule.table)
+		}
+		return checker.CheckUnique(ctx.GoContext(), rule.table, rule.column, lookup.values, exclude)
+	default:
+		checker, ok := s.existenceCheckers[rule.table]
+		if !ok {
+			checker, ok = s.existenceCheckers[""]
+		}
+		if !ok {
+			return nil, fmt.Errorf("no existence checker registered for table %q", rule.table)
+		}
+		return checker.CheckExists(ctx.GoContext(), rule.table, rule.column, lookup.values)
+	}
+}
+
+// recordID 被验证记录自身的标识（模型未实现 IRecordIdentifier 或为新记录时为 nil）
+func recordID(target any) any {
+	identifier, ok := target.(core.IRecordIdentifier)
+	if !ok {
+		return nil
+	}
+	if id := identifier.RecordID(); isLookupValue(id) {
+		return id
+	}
+	return nil
+}
+
+// isLookupValue 判断值是否需要查询
+// 说明：零值交给 required 等规则处理；不可比较的值无法作为结果集合的键
+func isLookupValue(value any) bool {
+	if value == nil {
+		return false
+	}
+	v := reflect.ValueOf(value)
+	return v.Type().Comparable() && !v.IsZero()
+}
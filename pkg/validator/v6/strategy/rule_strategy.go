@@ -1,12 +1,14 @@
 package strategy
 
 import (
+	"fmt"
+	"katydid-common-account/pkg/validator/bridge"
 	"katydid-common-account/pkg/validator/v6/context"
 	"katydid-common-account/pkg/validator/v6/core"
 	"katydid-common-account/pkg/validator/v6/errors"
 	"reflect"
-
-	"github.com/go-playground/validator/v10"
+	"sort"
+	"strings"
 )
 
 // ruleStrategy 规则验证策略
@@ -17,6 +19,63 @@ type ruleStrategy struct {
 	dependencyEngine core.IDependencyEngine
 	typeInspector    core.ITypeInspector
 	sceneMatcher     core.ISceneMatcher
+	ruleProvider     core.IRuleProvider       // 外部规则提供者（可选）
+	partialScenes    core.Scene               // 部分更新场景，未提供的字段跳过所有规则
+	paramResolver    core.IParamResolver      // 规则参数引用解析器（可选）
+	tenantRules      core.ITenantRuleProvider // 租户规则覆盖（可选）
+	legacy           core.ILegacyAdapter      // 旧版模型适配器（可选）
+	repository       bool                     // unique/exists 规则由仓储策略处理
+}
+
+// RuleStrategyOption 规则策略选项
+type RuleStrategyOption func(*ruleStrategy)
+
+// WithRuleProvider 设置外部规则提供者
+// 说明：外部规则按字段覆盖模型内置规则，模型没有外部规则时回退到 ValidateRules
+func WithRuleProvider(provider core.IRuleProvider) RuleStrategyOption {
+	return func(s *ruleStrategy) {
+		s.ruleProvider = provider
+	}
+}
+
+// WithPartialScenes 设置部分更新场景（如 SceneUpdate）
+// 说明：这些场景下未提供的字段（nil 指针、未定义的 Optional）表示不修改，跳过包括 required 在内的所有规则
+func WithPartialScenes(scenes core.Scene) RuleStrategyOption {
+	return func(s *ruleStrategy) {
+		s.partialScenes = scenes
+	}
+}
+
+// WithTenantRuleProvider 设置租户规则覆盖提供者
+// 说明：租户 ID 从验证上下文中获取（context.WithTenantID），覆盖规则优先级最高
+func WithTenantRuleProvider(provider core.ITenantRuleProvider) RuleStrategyOption {
+	return func(s *ruleStrategy) {
+		s.tenantRules = provider
+	}
+}
+
+// WithLegacyAdapter 设置旧版模型适配器
+// 说明：未实现 IRuleValidator 的模型，通过适配器获取旧版接口提供的规则
+func WithLegacyAdapter(adapter core.ILegacyAdapter) RuleStrategyOption {
+	return func(s *ruleStrategy) {
+		s.legacy = adapter
+	}
+}
+
+// WithParamResolver 设置规则参数解析器
+// 说明：规则中的 ${key}（如 max=${limits.username_max}）在验证时由解析器替换为实际值
+func WithParamResolver(resolver core.IParamResolver) RuleStrategyOption {
+	return func(s *ruleStrategy) {
+		s.paramResolver = resolver
+	}
+}
+
+// WithRepositoryRules 声明 unique/exists 仓储规则由仓储策略处理
+// 说明：未声明时规则中出现 unique=表.列 或 exists=表.列 返回 ErrRepositoryStrategyRequired，避免规则被静默忽略
+func WithRepositoryRules(enabled bool) RuleStrategyOption {
+	return func(s *ruleStrategy) {
+		s.repository = enabled
+	}
 }
 
 // NewRuleStrategy 创建规则验证策略
@@ -24,13 +83,21 @@ func NewRuleStrategy(
 	dependencyEngine core.IDependencyEngine,
 	typeInspector core.ITypeInspector,
 	sceneMatcher core.ISceneMatcher,
+	opts ...RuleStrategyOption,
 ) core.IValidationStrategy {
-	return &ruleStrategy{
+	s := &ruleStrategy{
 		name:             "rule",
 		dependencyEngine: dependencyEngine,
 		typeInspector:    typeInspector,
 		sceneMatcher:     sceneMatcher,
 	}
+
+	// 应用选项
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 // Type 策略类型
@@ -46,63 +113,355 @@ func (s *ruleStrategy) Name() string {
 // Validate 执行规则验证
 func (s *ruleStrategy) Validate(target any, ctx core.IContext, collector core.IErrorCollector) error {
 	// 检查类型信息
-	typeInfo := s.typeInspector.Inspect(target)
-	if typeInfo == nil || !typeInfo.IsRuleValidator() {
+	typeInfo := inspectType(s.typeInspector, target, ctx)
+	if typeInfo == nil {
 		return nil
 	}
 
-	// 获取规则
-	var rules map[string]string
-
-	// 优先从类型信息缓存获取
-	if provider, ok := target.(core.IRuleValidator); ok {
-		// TODO:GG 每次都获取，会不会影响性能？
-		// TODO:GG 是不是还是应该缓存到 typeInfo 里？
-		sceneRules := provider.ValidateRules(ctx.Scene())
-		rules = sceneRules
-	}
+	// 获取预解析规则（外部规则覆盖内置规则）
+	compiled := resolveCompiledRules(target, typeInfo, ctx, s.ruleProvider, s.tenantRules, s.legacy)
 
 	// 如果没有规则，直接返回
-	if len(rules) == 0 {
+	if compiled == nil {
+		return nil
+	}
+	if len(compiled.Repository) > 0 && !s.repository {
+		return fmt.Errorf("%w: %s.%s", core.ErrRepositoryStrategyRequired, typeInfo.TypeName(), compiled.Repository[0])
+	}
+	if len(compiled.Fields) == 0 {
 		return nil
 	}
 
 	// 处理字段过滤
-	rules = s.filterRules(rules, ctx)
+	fields := filterCompiledFields(compiled.Fields, ctx)
 
-	if len(rules) == 0 {
+	if len(fields) == 0 {
 		return nil
 	}
 
 	// 执行字段级验证
-	s.validateFields(target, rules, typeInfo, collector)
+	s.validateFields(target, fields, typeInfo, ctx, collector)
 
 	return nil
 }
 
+// inspectType 检查类型信息，并在检查器支持时发出缓存命中事件
+func inspectType(inspector core.ITypeInspector, target any, ctx core.IContext) core.ITypeInfo {
+	aware, ok := inspector.(core.ICacheAwareInspector)
+	if !ok || !context.HasListeners(ctx) {
+		return inspector.Inspect(target)
+	}
+
+	info, hit := aware.InspectWithCache(target)
+	if info == nil {
+		return nil
+	}
+	event := core.Event{Type: core.EventTypeCacheMiss, TypeName: info.TypeName()}
+	if hit {
+		event.Type = core.EventTypeCacheHit
+	}
+	context.Emit(ctx, event)
+	return info
+}
+
+// resolveRules 获取模型在当前场景下的规则（字段 -> 规则，只读）
+func resolveRules(
+	target any,
+	typeInfo core.ITypeInfo,
+	ctx core.IContext,
+	ruleProvider core.IRuleProvider,
+	tenantRules core.ITenantRuleProvider,
+	legacy core.ILegacyAdapter,
+) map[string]string {
+	compiled := resolveCompiledRules(target, typeInfo, ctx, ruleProvider, tenantRules, legacy)
+	if compiled == nil {
+		return nil
+	}
+	return compiled.Rules
+}
+
+// resolveCompiledRules 获取模型在当前场景下的预解析规则
+// 说明：
+//   - 模型内置规则按场景缓存到类型信息中（见 modelRules）
+//   - 外部规则按字段覆盖内置规则，并在上下文中记录规则版本号；最后叠加租户覆盖规则
+//   - 存在外部规则或租户覆盖时合并后重新解析，不缓存（外部规则可能热更新，租户规则随请求变化）
+//   - 只验证部分字段时（WithFields / WithExcludeFields），不缓存的规则只编译有效字段；缓存的规则在执行前按字段过滤
+func resolveCompiledRules(
+	target any,
+	typeInfo core.ITypeInfo,
+	ctx core.IContext,
+	ruleProvider core.IRuleProvider,
+	tenantRules core.ITenantRuleProvider,
+	legacy core.ILegacyAdapter,
+) *core.CompiledRules {
+	model := typeInfo.TypeName()
+	base := modelRules(target, typeInfo, ctx, legacy)
+
+	var rules map[string]string
+	if base != nil {
+		rules = base.Rules
+	}
+	changed := false
+
+	if ruleProvider != nil {
+		if external, version, ok := ruleProvider.Rules(model, ctx.Scene()); ok {
+			ctx.Metadata().Set(context.MetadataKeyRuleVersion, version)
+			if len(external) > 0 {
+				rules = mergeRules(rules, external)
+				changed = true
+			}
+		}
+	}
+
+	if overlaid, ok := overlayTenantRules(rules, model, ctx, tenantRules); ok {
+		rules = overlaid
+		changed = true
+	}
+
+	if !changed {
+		return base
+	}
+	// 不缓存的规则只编译有效字段
+	return compileRules(expandRuleMapAliases(filterRules(rules, ctx)), target)
+}
+
+// modelRules 获取模型内置规则的预解析结果
+// 说明：
+//   - 嵌入结构体的规则合并到模型规则中，外层规则优先（见 embeddedRules）
+//   - 类型信息支持规则缓存且模型不是动态规则时，按场景缓存，规则别名变化后重新编译
+func modelRules(target any, typeInfo core.ITypeInfo, ctx core.IContext, legacy core.ILegacyAdapter) *core.CompiledRules {
+	provider, ok := ruleValidatorOf(target, legacy)
+	if !ok {
+		return nil
+	}
+
+	scene := ctx.Scene()
+	cache, ok := typeInfo.(core.IRuleCache)
+	if !ok || isDynamicRules(target) {
+		return compileRules(expandRuleMapAliases(filterRules(embeddedRules(target, provider.ValidateRules(scene), scene, legacy), ctx)), target)
+	}
+
+	// 命中缓存时直接返回（不复制，不分配）
+	generation := ruleAliasGeneration.Load()
+	if compiled, ok := cache.LoadRules(scene, generation); ok {
+		return compiled
+	}
+
+	// 并发首次编译时可能编译多次，结果相同
+	compiled := compileRules(expandRuleMapAliases(embeddedRules(target, provider.ValidateRules(scene), scene, legacy)), target)
+	if compiled == nil {
+		compiled = &core.CompiledRules{}
+	}
+	compiled.Generation = generation
+	cache.StoreRules(scene, compiled)
+	return compiled
+}
+
+// isDynamicRules 模型的规则是否随实例变化
+func isDynamicRules(target any) bool {
+	dynamic, ok := target.(core.IDynamicRules)
+	return ok && dynamic.DynamicRules()
+}
+
+// mergeRules 复制后按字段覆盖，避免修改模型返回的规则
+func mergeRules(base, external map[string]string) map[string]string {
+	if len(base) == 0 {
+		return external
+	}
+	merged := make(map[string]string, len(base)+len(external))
+	for field, rule := range base {
+		merged[field] = rule
+	}
+	for field, rule := range external {
+		merged[field] = rule
+	}
+	return merged
+}
+
+// overlayTenantRules 叠加租户覆盖规则，没有覆盖时返回 false
+func overlayTenantRules(rules map[string]string, model string, ctx core.IContext, tenantRules core.ITenantRuleProvider) (map[string]string, bool) {
+	if tenantRules == nil {
+		return rules, false
+	}
+
+	tenantID := context.TenantID(ctx)
+	if tenantID == "" {
+		return rules, false
+	}
+	overlay, ok := tenantRules.TenantRules(tenantID, model, ctx.Scene())
+	if !ok || len(overlay) == 0 {
+		return rules, false
+	}
+	return overlayRules(rules, overlay), true
+}
+
+// overlayRules 复制后按字段覆盖，避免修改模型或提供者返回的规则
+// 说明：覆盖值为空字符串时移除该字段的规则
+func overlayRules(base, overlay map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(overlay))
+	for field, rule := range base {
+		merged[field] = rule
+	}
+	for field, rule := range overlay {
+		if rule == "" {
+			delete(merged, field)
+			continue
+		}
+		merged[field] = rule
+	}
+	return merged
+}
+
+// compileRules 预解析规则：去除仓储规则、拆出存在性规则，按结构体字段声明顺序排序
+// 说明：空字段名、空规则或只有仓储规则的字段不出现在 Fields 中，但保留在 Rules 中供仓储策略使用；
+// 使用了仓储规则的字段记录在 Repository 中
+func compileRules(rules map[string]string, target any) *core.CompiledRules {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	compiled := &core.CompiledRules{
+		Rules:  rules,
+		Fields: make([]core.CompiledRule, 0, len(rules)),
+	}
+	for field, rule := range rules {
+		if len(field) == 0 || len(rule) == 0 {
+			continue
+		}
+
+		// 仓储规则（unique/exists）由仓储策略处理
+		rule, repoRules := splitRepositoryRules(rule)
+		if len(repoRules) > 0 {
+			compiled.Repository = append(compiled.Repository, field)
+		}
+		if len(rule) == 0 {
+			continue
+		}
+
+		rest, required := splitPresenceRule(rule)
+		compiled.Fields = append(compiled.Fields, core.CompiledRule{
+			Field:    field,
+			Rule:     rule,
+			Rest:     rest,
+			Required: required,
+			HasParam: hasParamRef(rule),
+		})
+	}
+	sort.Strings(compiled.Repository)
+
+	// 错误顺序与字段声明顺序一致；不是结构体字段的规则键排在最后，按名称排序
+	order := structFieldOrder(target)
+	sort.Slice(compiled.Fields, func(i, j int) bool {
+		a, b := compiled.Fields[i].Field, compiled.Fields[j].Field
+		if oa, ob := fieldOrderOf(order, a), fieldOrderOf(order, b); oa != ob {
+			return oa < ob
+		}
+		return a < b
+	})
+	return compiled
+}
+
+// structFieldOrder 结构体字段声明顺序（字段名和 json 名称 -> 下标）
+func structFieldOrder(target any) map[string]int {
+	typ := reflect.TypeOf(target)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	order := make(map[string]int, typ.NumField()*2)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		order[field.Name] = i
+		if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+			order[name] = i
+		}
+	}
+	return order
+}
+
+// fieldOrderOf 规则键的声明顺序（路径按首段字段，未知字段排在最后）
+func fieldOrderOf(order map[string]int, field string) int {
+	head, _, _ := strings.Cut(field, ".")
+	if idx, ok := order[head]; ok {
+		return idx
+	}
+	return len(order)
+}
+
+// ruleValidatorOf 获取模型的规则提供者
+// 说明：优先使用 v6 接口，其次通过旧版模型适配器获取
+func ruleValidatorOf(target any, legacy core.ILegacyAdapter) (core.IRuleValidator, bool) {
+	if provider, ok := target.(core.IRuleValidator); ok {
+		return provider, true
+	}
+	if legacy == nil {
+		return nil, false
+	}
+	return legacy.RuleValidator(target)
+}
+
 // validateFields 验证字段
 func (s *ruleStrategy) validateFields(
 	target any,
-	rules map[string]string,
+	fields []core.CompiledRule,
 	typeInfo core.ITypeInfo,
+	ctx core.IContext,
 	collector core.IErrorCollector,
 ) {
+	partial := s.partialScenes != core.SceneNone && s.partialScenes.Has(ctx.Scene())
+
 	// 逐个字段验证
-	for fieldName, rule := range rules {
-		if len(fieldName) == 0 || len(rule) == 0 {
-			continue
+	for i := range fields {
+		field := &fields[i]
+		fieldName, rule, rest, required := field.Field, field.Rule, field.Rest, field.Required
+
+		// 展开参数引用，无法解析时报告 param 错误并跳过该字段
+		if field.HasParam {
+			expanded, key, err := expandParams(rule, ctx, s.paramResolver)
+			if err != nil {
+				s.collectParamError(typeInfo, fieldName, key, err, collector)
+				if collector.Count() >= collector.MaxErrors() {
+					break
+				}
+				continue
+			}
+			rule = expanded
+			rest, required = splitPresenceRule(rule)
 		}
 
 		// 获取字段值
-		fieldValue, ok := s.getFieldValue(target, fieldName, typeInfo)
+		fieldValue, ok := getFieldValue(target, fieldName, typeInfo)
 		if !ok {
 			continue
 		}
 
+		// 区分"未提供"和"提供了零值"
+		if inner, state := resolvePresence(fieldValue); state != presenceNotAware {
+			if state != presencePresent {
+				// 部分更新场景下未提供表示不修改；显式 null 或非部分更新场景下检查 required
+				if required && (state == presenceNull || !partial) {
+					s.collectRequiredError(typeInfo, fieldName, collector)
+				}
+				if collector.Count() >= collector.MaxErrors() {
+					break
+				}
+				continue
+			}
+
+			// 提供了值：required 已满足，零值也按剩余规则验证
+			if len(rest) == 0 {
+				continue
+			}
+			fieldValue, rule = inner, rest
+		}
+
 		// 验证字段
 		if err := s.dependencyEngine.ValidateField(fieldValue, rule); err != nil {
 			// 转换错误
-			s.convertAndCollectErrors(err, collector)
+			s.convertAndCollectErrors(err, typeInfo, fieldName, collector)
 
 			// 如果收集器已满，停止验证
 			if collector.Count() >= collector.MaxErrors() {
@@ -113,7 +472,16 @@ func (s *ruleStrategy) validateFields(
 }
 
 // getFieldValue 获取字段值
-func (s *ruleStrategy) getFieldValue(target any, fieldName string, typeInfo core.ITypeInfo) (any, bool) {
+// 说明：带 "." 的规则键（如 Extras.brand）先取首段字段，再按路径进入 map 或嵌套结构体
+func getFieldValue(target any, fieldName string, typeInfo core.ITypeInfo) (any, bool) {
+	if head, rest, nested := strings.Cut(fieldName, "."); nested {
+		value, ok := getFieldValue(target, head, typeInfo)
+		if !ok {
+			return nil, false
+		}
+		return walkFieldPath(value, rest)
+	}
+
 	// 优先使用缓存的访问器
 	if accessor := typeInfo.FieldAccessor(fieldName); accessor != nil {
 		return accessor(target)
@@ -141,81 +509,162 @@ func (s *ruleStrategy) getFieldValue(target any, fieldName string, typeInfo core
 	return field.Interface(), true
 }
 
-// convertAndCollectErrors 转换并收集错误
-func (s *ruleStrategy) convertAndCollectErrors(err error, collector core.IErrorCollector) {
-	// 检查是否是 validator.ValidationErrors
-	if validationErrors, ok := err.(validator.ValidationErrors); ok {
-		for _, e := range validationErrors {
-			fieldErr := errors.NewFieldError(
-				e.Namespace(),
-				e.Field(),
-				e.Tag(),
-				errors.WithParam(e.Param()),
-				errors.WithValue(e.Value()),
-				errors.WithMessage(e.Error()),
-			)
-
-			// 收集错误
-			if !collector.Collect(fieldErr) {
-				break
+// walkFieldPath 按路径取值
+// 说明：string 键的 map（如 Extras）按键查找，结果包装为 mapEntry 以区分"键不存在"和"值为 null"；
+// 结构体按 Go 字段名或 json 名称查找，中途遇到 nil 视为未提供
+func walkFieldPath(value any, path string) (any, bool) {
+	v := reflect.ValueOf(value)
+	fromMap := false
+	for _, segment := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return mapEntry{state: presenceAbsent}, true
 			}
+			v = v.Elem()
+		}
+
+		switch {
+		case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+			v = v.MapIndex(reflect.ValueOf(segment).Convert(v.Type().Key()))
+			if !v.IsValid() {
+				return mapEntry{state: presenceAbsent}, true
+			}
+			fromMap = true
+		case v.Kind() == reflect.Struct:
+			v = structField(v, segment)
+			if !v.IsValid() || !v.CanInterface() {
+				return nil, false
+			}
+			fromMap = false
+		default:
+			return nil, false
 		}
-	} else {
+	}
+
+	// 结构体字段按普通字段验证
+	if !fromMap {
+		return v.Interface(), true
+	}
+	if v.Kind() == reflect.Interface && v.IsNil() {
+		return mapEntry{state: presenceNull}, true
+	}
+	return mapEntry{value: v.Interface(), state: presencePresent}, true
+}
+
+// structField 按 Go 字段名查找结构体字段，找不到时按 json 名称查找
+func structField(v reflect.Value, name string) reflect.Value {
+	if field := v.FieldByName(name); field.IsValid() {
+		return field
+	}
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); field.IsExported() && jsonFieldName(field) == name {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// collectRequiredError 收集字段未提供的错误
+func (s *ruleStrategy) collectRequiredError(typeInfo core.ITypeInfo, fieldName string, collector core.IErrorCollector) {
+	opts := make([]errors.FieldErrorOption, 0, 1)
+	if isSensitiveField(typeInfo, fieldName) {
+		opts = append(opts, errors.WithSensitive())
+	}
+	collector.Collect(errors.NewFieldError(typeInfo.TypeName()+"."+fieldName, lastPathSegment(fieldName), tagRequired, opts...))
+}
+
+// collectParamError 收集参数引用无法展开的错误
+func (s *ruleStrategy) collectParamError(typeInfo core.ITypeInfo, fieldName, key string, err error, collector core.IErrorCollector) {
+	collector.Collect(errors.NewFieldError(typeInfo.TypeName()+"."+fieldName, lastPathSegment(fieldName), tagParam,
+		errors.WithParam(key),
+		errors.WithMessage(err.Error())))
+}
+
+// convertAndCollectErrors 转换并收集错误
+// 说明：单字段验证（Var）时依赖库不知道字段名，使用规则中的字段名补全命名空间
+// 带有 sensitive 标签的字段，错误输出时值会被脱敏
+func (s *ruleStrategy) convertAndCollectErrors(err error, typeInfo core.ITypeInfo, fieldName string, collector core.IErrorCollector) {
+	opts := make([]errors.FieldErrorOption, 0, 3)
+	if isSensitiveField(typeInfo, fieldName) {
+		opts = append(opts, errors.WithSensitive())
+	}
+
+	// 通过统一桥接转换 go-playground 的验证错误
+	fieldErrors, ok := bridge.FromValidationErrors(err, nil)
+	if !ok {
 		// 其他类型的错误
 		fieldErr := errors.NewFieldErrorWithMessage(err.Error())
 		collector.Collect(fieldErr)
+		return
 	}
-}
 
-// filterRules 过滤规则
-func (s *ruleStrategy) filterRules(rules map[string]string, ctx core.IContext) map[string]string {
-	// 检查是否需要只验证指定字段
-	if fields, ok := ctx.Metadata().Get(context.MetadataKeyValidateFields); ok {
-		if fieldList, ok := fields.([]string); ok && len(fieldList) > 0 {
-			return s.includeFields(rules, fieldList)
+	for _, fe := range fieldErrors {
+		switch {
+		case fe.Field == "":
+			fe.Namespace, fe.Field = typeInfo.TypeName()+"."+fieldName, lastPathSegment(fieldName)
+		case strings.HasPrefix(fe.Namespace, "["):
+			// dive 产生的元素错误只有下标（如 [1]、[key]），补全为 User.Tags[1]
+			fe.Namespace, fe.Field = typeInfo.TypeName()+"."+fieldName+fe.Namespace, lastPathSegment(fieldName)+fe.Field
 		}
-	}
 
-	// 检查是否需要排除字段
-	if excludeFields, ok := ctx.Metadata().Get(context.MetadataKeyExcludeFields); ok {
-		if fieldList, ok := excludeFields.([]string); ok && len(fieldList) > 0 {
-			return s.excludeFields(rules, fieldList)
+		// 不指定消息：优先使用消息模板，其次使用默认消息
+		fieldErr := errors.NewFieldError(
+			fe.Namespace,
+			fe.Field,
+			fe.Tag,
+			append(opts, errors.WithParam(fe.Param), errors.WithValue(fe.Value))...,
+		)
+
+		// 收集错误
+		if !collector.Collect(fieldErr) {
+			break
 		}
 	}
+}
 
-	return rules
+// isSensitiveField 字段是否敏感（嵌入结构体字段按完整路径判断，其他路径按首段字段判断）
+func isSensitiveField(typeInfo core.ITypeInfo, fieldName string) bool {
+	if typeInfo.IsSensitive(fieldName) {
+		return true
+	}
+	head, _, _ := strings.Cut(fieldName, ".")
+	return typeInfo.IsSensitive(head)
 }
 
-// includeFields 只包含指定字段
-func (s *ruleStrategy) includeFields(rules map[string]string, fields []string) map[string]string {
-	filtered := make(map[string]string)
-	fieldSet := make(map[string]bool)
-	for _, f := range fields {
-		fieldSet[f] = true
+// lastPathSegment 路径的最后一段（如 Extras.brand -> brand）
+func lastPathSegment(path string) string {
+	return path[strings.LastIndexByte(path, '.')+1:]
+}
+
+// filterRules 按有效字段过滤规则（没有过滤条件时返回原规则表，不复制）
+func filterRules(rules map[string]string, ctx core.IContext) map[string]string {
+	fields := context.FieldSet(ctx)
+	if fields.All() || len(rules) == 0 {
+		return rules
 	}
 
+	filtered := make(map[string]string)
 	for field, rule := range rules {
-		if fieldSet[field] {
+		if fields.Includes(field) {
 			filtered[field] = rule
 		}
 	}
-
 	return filtered
 }
 
-// excludeFields 排除指定字段
-func (s *ruleStrategy) excludeFields(rules map[string]string, fields []string) map[string]string {
-	filtered := make(map[string]string)
-	excludeSet := make(map[string]bool)
-	for _, f := range fields {
-		excludeSet[f] = true
+// filterCompiledFields 按有效字段过滤预解析规则（没有过滤条件时返回原切片，不复制）
+func filterCompiledFields(fields []core.CompiledRule, ctx core.IContext) []core.CompiledRule {
+	set := context.FieldSet(ctx)
+	if set.All() {
+		return fields
 	}
 
-	for field, rule := range rules {
-		if !excludeSet[field] {
-			filtered[field] = rule
+	filtered := make([]core.CompiledRule, 0, len(fields))
+	for _, field := range fields {
+		if set.Includes(field.Field) {
+			filtered = append(filtered, field)
 		}
 	}
-
 	return filtered
 }
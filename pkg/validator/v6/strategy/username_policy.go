@@ -0,0 +1,298 @@
+package strategy
+
+import (
+	"bufio"
+	"io"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ============================================================================
+// 用户名策略
+// ============================================================================
+
+// TagUsernamePolicy 用户名策略的验证标签
+const TagUsernamePolicy = "username_policy"
+
+// 用户名违规原因（作为错误的 Param）
+const (
+	UsernameReserved   = "reserved"   // 保留名（含形近变体，如 аdmin、adm1n）
+	UsernamePattern    = "pattern"    // 命中禁止的正则
+	UsernameProfanity  = "profanity"  // 包含敏感词
+	UsernameConfusable = "confusable" // 混用多种文字（如拉丁字母和西里尔字母），容易仿冒
+)
+
+// DefaultReservedNames 内置的保留用户名
+var DefaultReservedNames = []string{
+	"admin", "administrator", "root", "system", "sysadmin", "superuser",
+	"support", "help", "official", "security", "staff", "moderator",
+	"api", "www", "mail", "null", "undefined", "anonymous",
+}
+
+// UsernamePolicy 用户名策略
+// 职责：统一各服务重复实现的保留名、正则、敏感词和形近字检查
+// 设计原则：
+//   - 比较前先归一化（Skeleton）：NFKC、小写、形近字和数字替换、去掉分隔符，使 аdmin、Adm1n、a.d.m.i.n 都等价于 admin
+//   - 创建后只读，并发安全
+type UsernamePolicy struct {
+	reserved         map[string]struct{}
+	patterns         []*regexp.Regexp
+	profanity        []string
+	allowMixedScript bool
+}
+
+// UsernamePolicyOption 用户名策略选项
+type UsernamePolicyOption func(*UsernamePolicy)
+
+// WithReservedNames 追加保留名（在内置保留名的基础上）
+func WithReservedNames(names ...string) UsernamePolicyOption {
+	return func(p *UsernamePolicy) {
+		for _, name := range names {
+			if skeleton := Skeleton(name); skeleton != "" {
+				p.reserved[skeleton] = struct{}{}
+			}
+		}
+	}
+}
+
+// WithoutDefaultReservedNames 不使用内置保留名
+// 说明：需放在 WithReservedNames 之前
+func WithoutDefaultReservedNames() UsernamePolicyOption {
+	return func(p *UsernamePolicy) {
+		p.reserved = make(map[string]struct{})
+	}
+}
+
+// WithDeniedPatterns 禁止匹配这些正则的用户名（匹配原始用户名）
+// 说明：正则无效属于编程错误，直接 panic
+func WithDeniedPatterns(patterns ...string) UsernamePolicyOption {
+	return func(p *UsernamePolicy) {
+		for _, pattern := range patterns {
+			p.patterns = append(p.patterns, regexp.MustCompile(pattern))
+		}
+	}
+}
+
+// WithProfanity 追加敏感词（归一化后按子串匹配）
+func WithProfanity(words ...string) UsernamePolicyOption {
+	return func(p *UsernamePolicy) {
+		for _, word := range words {
+			if skeleton := Skeleton(word); skeleton != "" {
+				p.profanity = append(p.profanity, skeleton)
+			}
+		}
+	}
+}
+
+// WithMixedScript 允许混用多种文字（默认禁止）
+func WithMixedScript() UsernamePolicyOption {
+	return func(p *UsernamePolicy) {
+		p.allowMixedScript = true
+	}
+}
+
+// NewUsernamePolicy 创建用户名策略
+func NewUsernamePolicy(opts ...UsernamePolicyOption) *UsernamePolicy {
+	p := &UsernamePolicy{reserved: make(map[string]struct{}, len(DefaultReservedNames))}
+	WithReservedNames(DefaultReservedNames...)(p)
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// LoadWordList 从文本加载词表（保留名或敏感词）
+// 格式：每行一个词，忽略空行和 # 开头的注释
+func LoadWordList(r io.Reader) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+// Check 检查用户名
+// 返回值：违规原因（UsernameReserved 等），通过时为空
+// 说明：检查顺序为 形近字（混用文字）-> 保留名 -> 正则 -> 敏感词
+func (p *UsernamePolicy) Check(username string) string {
+	if username == "" {
+		return ""
+	}
+	if !p.allowMixedScript && isMixedScript(username) {
+		return UsernameConfusable
+	}
+
+	skeleton := Skeleton(username)
+	if _, ok := p.reserved[skeleton]; ok {
+		return UsernameReserved
+	}
+	for _, pattern := range p.patterns {
+		if pattern.MatchString(username) {
+			return UsernamePattern
+		}
+	}
+	for _, word := range p.profanity {
+		if strings.Contains(skeleton, word) {
+			return UsernameProfanity
+		}
+	}
+	return ""
+}
+
+// ValidationFunc 作为验证标签使用的函数（注册为 username_policy 标签）
+// 说明：非字符串值视为通过；标签方式只能得到通过/失败，需要违规原因时使用 UsernamePolicyStrategy
+func (p *UsernamePolicy) ValidationFunc() core.ValidationFunc {
+	return func(value any, _ string) bool {
+		username, ok := value.(string)
+		return !ok || p.Check(username) == ""
+	}
+}
+
+// ============================================================================
+// 归一化与形近字
+// ============================================================================
+
+// confusables 常见形近字（西里尔、希腊字母）和替代数字/符号 -> 拉丁字母
+var confusables = map[rune]rune{
+	// 西里尔字母
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o',
+	'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'ѕ': 's', 'і': 'i', 'ї': 'i',
+	'ј': 'j', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w', 'ɡ': 'g',
+	// 希腊字母
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o',
+	'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x', 'ω': 'w',
+	// 数字和符号替代
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b',
+	'@': 'a', '$': 's', '!': 'i', '|': 'l',
+}
+
+// Skeleton 用户名的归一化形式，用于比较
+// 步骤：NFKC（全角转半角等）-> 小写 -> 形近字替换 -> 去掉分隔符（_ - . 空白）
+func Skeleton(username string) string {
+	normalized := norm.NFKC.String(username)
+
+	var b strings.Builder
+	b.Grow(len(normalized))
+	for _, r := range strings.ToLower(normalized) {
+		if r == '_' || r == '-' || r == '.' || unicode.IsSpace(r) {
+			continue
+		}
+		if mapped, ok := confusables[r]; ok {
+			r = mapped
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// scriptTables 参与混用检查的文字
+var scriptTables = []*unicode.RangeTable{unicode.Latin, unicode.Cyrillic, unicode.Greek, unicode.Han, unicode.Arabic, unicode.Hangul}
+
+// isMixedScript 字母是否来自多种文字
+// 说明：中文与拉丁字母混用（如 张san）很常见，不视为混用；日文假名同理
+func isMixedScript(username string) bool {
+	var found *unicode.RangeTable
+	for _, r := range norm.NFKC.String(username) {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		for _, table := range scriptTables {
+			if !unicode.Is(table, r) {
+				continue
+			}
+			if table == unicode.Han || table == unicode.Hangul {
+				break
+			}
+			if found != nil && found != table {
+				return true
+			}
+			found = table
+			break
+		}
+	}
+	return false
+}
+
+// ============================================================================
+// 用户名策略验证
+// ============================================================================
+
+// UsernamePolicyStrategy 用户名策略验证
+// 职责：对指定字段执行用户名策略，错误标签为 username_policy，Param 为违规原因
+type UsernamePolicyStrategy struct {
+	policy    *UsernamePolicy
+	inspector core.ITypeInspector
+	fields    []string
+}
+
+// NewUsernamePolicyStrategy 创建用户名策略验证
+// 参数：fields 为需要检查的字段（Go 字段名），为空时检查 Username 字段
+func NewUsernamePolicyStrategy(policy *UsernamePolicy, inspector core.ITypeInspector, fields ...string) *UsernamePolicyStrategy {
+	if policy == nil {
+		policy = NewUsernamePolicy()
+	}
+	if len(fields) == 0 {
+		fields = []string{"Username"}
+	}
+	return &UsernamePolicyStrategy{policy: policy, inspector: inspector, fields: fields}
+}
+
+// Type 策略类型
+func (s *UsernamePolicyStrategy) Type() core.StrategyType {
+	return core.StrategyTypeUsernamePolicy
+}
+
+// Name 策略名称
+func (s *UsernamePolicyStrategy) Name() string {
+	return TagUsernamePolicy
+}
+
+// Validate 执行用户名策略验证
+func (s *UsernamePolicyStrategy) Validate(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	typeInfo := inspectType(s.inspector, target, ctx)
+	if typeInfo == nil {
+		return nil
+	}
+
+	for _, fieldName := range s.fields {
+		value, ok := getFieldValue(target, fieldName, typeInfo)
+		if !ok {
+			continue
+		}
+		if inner, state := resolvePresence(value); state != presenceNotAware {
+			if state != presencePresent {
+				continue
+			}
+			value = inner
+		}
+
+		username, ok := value.(string)
+		if !ok {
+			continue
+		}
+		reason := s.policy.Check(username)
+		if reason == "" {
+			continue
+		}
+
+		fieldErr := errors.NewFieldError(typeInfo.TypeName()+"."+fieldName, lastPathSegment(fieldName), TagUsernamePolicy,
+			errors.WithParam(reason), errors.WithValue(username))
+		if !collector.Collect(fieldErr) {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package strategy
+
+import (
+	"strings"
+	"testing"
+
+	"katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"katydid-common-account/pkg/validator/v6/infrastructure"
+)
+
+// TestSkeleton 测试归一化：NFKC、小写、形近字和数字替换、去掉分隔符
+func TestSkeleton(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		want     string
+	}{
+		{"小写", "Admin", "admin"},
+		{"全角", "ＡＤＭＩＮ", "admin"},
+		{"数字替换", "Adm1n", "admin"},
+		{"符号替换", "$upp0rt", "support"},
+		{"分隔符", "a.d-m_i n", "admin"},
+		{"西里尔字母", "аdmin", "admin"},
+		{"希腊字母", "rοοt", "root"},
+		{"中文保持不变", "张san", "张san"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Skeleton(tt.username); got != tt.want {
+				t.Errorf("Skeleton(%q) = %q, want %q", tt.username, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestUsernamePolicy_Check 测试检查顺序：形近字（混用文字）-> 保留名 -> 正则 -> 敏感词
+func TestUsernamePolicy_Check(t *testing.T) {
+	policy := NewUsernamePolicy(
+		WithReservedNames("katydid"),
+		WithDeniedPatterns(`^\d+$`),
+		WithProfanity("badword"),
+	)
+	mixed := NewUsernamePolicy(WithMixedScript())
+	custom := NewUsernamePolicy(WithoutDefaultReservedNames(), WithReservedNames("katydid"))
+
+	tests := []struct {
+		name     string
+		policy   *UsernamePolicy
+		username string
+		want     string
+	}{
+		{"普通用户名", policy, "alice", ""},
+		{"空用户名", policy, "", ""},
+		{"中文与拉丁字母混用", policy, "张san", ""},
+		{"内置保留名", policy, "admin", UsernameReserved},
+		{"保留名形近变体", policy, "Adm1n", UsernameReserved},
+		{"保留名分隔符变体", policy, "a.d.m.i.n", UsernameReserved},
+		{"保留名全角变体", policy, "ＲＯＯＴ", UsernameReserved},
+		{"保留名加后缀", policy, "admin2", ""},
+		{"追加的保留名", policy, "Katy_Did", UsernameReserved},
+		{"拉丁和西里尔混用", policy, "аdmin", UsernameConfusable},
+		{"拉丁和希腊混用", policy, "pαypal", UsernameConfusable},
+		{"允许混用时按保留名检查", mixed, "аdmin", UsernameReserved},
+		{"命中正则", policy, "12345", UsernamePattern},
+		{"敏感词子串", policy, "xxBadW0rdxx", UsernameProfanity},
+		{"敏感词分隔符变体", policy, "bad-word", UsernameProfanity},
+		{"不使用内置保留名", custom, "admin", ""},
+		{"仅自定义保留名", custom, "katydid", UsernameReserved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Check(tt.username); got != tt.want {
+				t.Errorf("Check(%q) = %q, want %q", tt.username, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestUsernamePolicy_ValidationFunc 测试作为验证标签使用
+func TestUsernamePolicy_ValidationFunc(t *testing.T) {
+	fn := NewUsernamePolicy().ValidationFunc()
+	if fn("admin", "") {
+		t.Error("ValidationFunc(admin) = true, want false")
+	}
+	if !fn("alice", "") {
+		t.Error("ValidationFunc(alice) = false, want true")
+	}
+	if !fn(42, "") {
+		t.Error("非字符串值应视为通过")
+	}
+}
+
+// TestLoadWordList 测试从文本加载词表
+func TestLoadWordList(t *testing.T) {
+	words, err := LoadWordList(strings.NewReader("# reserved\n\n  katydid \nstaff\n"))
+	if err != nil {
+		t.Fatalf("LoadWordList() error = %v", err)
+	}
+	if len(words) != 2 || words[0] != "katydid" || words[1] != "staff" {
+		t.Errorf("LoadWordList() = %v, want [katydid staff]", words)
+	}
+}
+
+// member 带多个用户名字段的模型
+type member struct {
+	Username string  `json:"username"`
+	Nickname string  `json:"nickname"`
+	Handle   *string `json:"handle"`
+}
+
+// TestUsernamePolicyStrategy 测试策略检查指定字段，Param 为违规原因
+func TestUsernamePolicyStrategy(t *testing.T) {
+	inspector := infrastructure.NewTypeInspector(infrastructure.NewLRUCache(16))
+	validate := func(s *UsernamePolicyStrategy, model *member) []string {
+		ctx := context.NewContext(sceneCreate)
+		defer ctx.Release()
+		collector := errors.NewListErrorCollector(10)
+		_ = s.Validate(model, ctx, collector)
+
+		var got []string
+		for _, fe := range collector.Errors() {
+			got = append(got, fe.Namespace()+": "+fe.Tag()+"="+fe.Param())
+		}
+		return got
+	}
+
+	handle := "r00t"
+	tests := []struct {
+		name     string
+		strategy *UsernamePolicyStrategy
+		model    *member
+		want     []string
+	}{
+		{"默认检查 Username", NewUsernamePolicyStrategy(nil, inspector), &member{Username: "admin", Nickname: "admin"},
+			[]string{"member.Username: username_policy=reserved"}},
+		{"通过", NewUsernamePolicyStrategy(nil, inspector), &member{Username: "alice"}, nil},
+		{"指定多个字段", NewUsernamePolicyStrategy(nil, inspector, "Nickname", "Handle"), &member{Username: "admin", Nickname: "pаypal", Handle: &handle},
+			[]string{"member.Nickname: username_policy=confusable", "member.Handle: username_policy=reserved"}},
+		{"指针为 nil 时跳过", NewUsernamePolicyStrategy(nil, inspector, "Handle"), &member{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validate(tt.strategy, tt.model); !equalTags(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
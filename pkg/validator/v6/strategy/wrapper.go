@@ -0,0 +1,308 @@
+package strategy
+
+import (
+	stdcontext "context"
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// 降级模式
+// ============================================================================
+
+// DegradeMode 策略失败时的降级模式
+type DegradeMode int
+
+const (
+	// DegradeFail 硬失败：返回错误，中断本次验证
+	DegradeFail DegradeMode = iota
+	// DegradeSkip 跳过：不中断验证，收集一条 degraded 警告（验证结果仍可通过）
+	DegradeSkip
+)
+
+// DegradedTag 降级时收集的警告错误标签
+const DegradedTag = "degraded"
+
+// ============================================================================
+// 熔断器
+// ============================================================================
+
+// CircuitState 熔断器状态
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // 关闭：正常放行
+	CircuitOpen                         // 打开：拒绝执行
+	CircuitHalfOpen                     // 半开：放行一次探测
+)
+
+// String 实现 Stringer 接口
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// circuitBreaker 熔断器
+// 职责：连续失败达到阈值后打开，冷却期后半开放行一次探测
+type circuitBreaker struct {
+	mu        sync.Mutex
+	state     CircuitState
+	failures  int           // 连续失败次数
+	threshold int           // 打开阈值（<=0 表示禁用熔断）
+	cooldown  time.Duration // 打开后的冷却时间
+	openedAt  time.Time     // 打开时刻
+	probing   bool          // 半开状态下是否已有探测在执行
+}
+
+// allow 判断是否放行本次执行
+func (b *circuitBreaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		// 冷却结束，进入半开状态，放行一次探测
+		b.state = CircuitHalfOpen
+		b.probing = true
+		return true
+	case CircuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// onSuccess 记录成功
+func (b *circuitBreaker) onSuccess() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = CircuitClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// onFailure 记录失败
+func (b *circuitBreaker) onFailure() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// currentState 获取当前状态
+func (b *circuitBreaker) currentState() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// ============================================================================
+// 策略包装器
+// ============================================================================
+
+// StrategyWrapper 策略包装器
+// 职责：为依赖外部资源（DB/Redis 等）的策略提供超时、熔断和降级保护
+// 设计模式：装饰器模式 - 对内部策略透明，可包装任意 IValidationStrategy
+type StrategyWrapper struct {
+	inner       core.IValidationStrategy
+	timeout     time.Duration // 单次执行超时（<=0 表示不限制）
+	degradeMode DegradeMode
+	breaker     *circuitBreaker
+}
+
+// WrapperOption 包装器选项
+type WrapperOption func(*StrategyWrapper)
+
+// WithTimeout 设置单次执行超时
+// 说明：超时后内部策略的 GoContext 会被取消，策略实现应尽快退出
+func WithTimeout(timeout time.Duration) WrapperOption {
+	return func(w *StrategyWrapper) {
+		w.timeout = timeout
+	}
+}
+
+// WithCircuitBreaker 设置熔断器
+// 说明：连续失败 threshold 次后打开，cooldown 后半开放行一次探测；threshold<=0 表示禁用
+func WithCircuitBreaker(threshold int, cooldown time.Duration) WrapperOption {
+	return func(w *StrategyWrapper) {
+		w.breaker.threshold = threshold
+		w.breaker.cooldown = cooldown
+	}
+}
+
+// WithDegradeMode 设置降级模式
+func WithDegradeMode(mode DegradeMode) WrapperOption {
+	return func(w *StrategyWrapper) {
+		w.degradeMode = mode
+	}
+}
+
+// NewStrategyWrapper 创建策略包装器
+func NewStrategyWrapper(inner core.IValidationStrategy, opts ...WrapperOption) *StrategyWrapper {
+	w := &StrategyWrapper{
+		inner:       inner,
+		degradeMode: DegradeFail,
+		breaker:     &circuitBreaker{cooldown: 30 * time.Second},
+	}
+
+	// 应用选项
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Type 策略类型（与内部策略一致）
+func (w *StrategyWrapper) Type() core.StrategyType {
+	return w.inner.Type()
+}
+
+// Name 策略名称（与内部策略一致）
+func (w *StrategyWrapper) Name() string {
+	return w.inner.Name()
+}
+
+// Inner 获取内部策略
+func (w *StrategyWrapper) Inner() core.IValidationStrategy {
+	return w.inner
+}
+
+// RunsAfter 实现 IStrategyDependencies 接口（与内部策略一致）
+func (w *StrategyWrapper) RunsAfter() []string {
+	return Dependencies(w.inner)
+}
+
+// CircuitState 获取熔断器当前状态
+func (w *StrategyWrapper) CircuitState() CircuitState {
+	return w.breaker.currentState()
+}
+
+// Validate 执行验证
+func (w *StrategyWrapper) Validate(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	// 熔断器打开，直接降级
+	if !w.breaker.allow() {
+		return w.degrade(core.ErrCircuitOpen, collector)
+	}
+
+	if err := w.execute(target, ctx, collector); err != nil {
+		w.breaker.onFailure()
+		return w.degrade(err, collector)
+	}
+
+	w.breaker.onSuccess()
+	return nil
+}
+
+// execute 执行内部策略（带超时控制）
+func (w *StrategyWrapper) execute(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	if w.timeout <= 0 {
+		return w.safeValidate(target, ctx, collector)
+	}
+
+	goCtx, cancel := stdcontext.WithTimeout(ctx.GoContext(), w.timeout)
+	defer cancel()
+
+	// 派生上下文和独立收集器：超时后内部策略可能仍在后台运行，
+	// 不能继续使用调用方的上下文（会被回收）、元数据和收集器（均非并发安全）。
+	// 内部上下文持有元数据副本，只在按时完成时写回
+	innerCtx := context.NewContext(ctx.Scene(),
+		context.WithGoContext(goCtx),
+		context.WithDepth(ctx.Depth()),
+		context.WithSharedMemo(ctx),
+	)
+	copyMetadata(innerCtx.Metadata(), ctx.Metadata())
+	innerCollector := errors.NewListErrorCollector(collector.MaxErrors())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- w.safeValidate(target, innerCtx, innerCollector)
+	}()
+
+	select {
+	case err := <-done:
+		copyMetadata(ctx.Metadata(), innerCtx.Metadata())
+		innerCtx.Release()
+		collector.CollectAll(innerCollector.Errors())
+		for _, warning := range innerCollector.Warnings() {
+			collector.AddWarning(warning)
+		}
+		return err
+	case <-goCtx.Done():
+		// 内部上下文仍被后台策略使用，不回收
+		return fmt.Errorf("%w: %s after %v", core.ErrStrategyTimeout, w.inner.Name(), w.timeout)
+	}
+}
+
+// copyMetadata 用 src 的内容覆盖 dst（dst 中 src 没有的键被删除）
+func copyMetadata(dst, src core.IMetadata) {
+	all := src.All()
+	for key := range dst.All() {
+		if _, ok := all[key]; !ok {
+			dst.Delete(key)
+		}
+	}
+	for key, value := range all {
+		dst.Set(key, value)
+	}
+}
+
+// safeValidate 执行内部策略并恢复 panic
+func (w *StrategyWrapper) safeValidate(target any, ctx core.IContext, collector core.IErrorCollector) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("strategy %s panic: %v", w.inner.Name(), r)
+		}
+	}()
+	return w.inner.Validate(target, ctx, collector)
+}
+
+// degrade 按降级模式处理失败
+func (w *StrategyWrapper) degrade(err error, collector core.IErrorCollector) error {
+	if w.degradeMode == DegradeFail {
+		return err
+	}
+
+	collector.AddWarning(errors.NewFieldError(w.inner.Name(), w.inner.Name(), DegradedTag,
+		errors.WithMessage(fmt.Sprintf("strategy '%s' skipped: %v", w.inner.Name(), err)),
+		errors.WithSeverity(core.SeverityWarning),
+	))
+	return nil
+}
@@ -0,0 +1,233 @@
+package strategy
+
+import (
+	stderrors "errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"katydid-common-account/pkg/validator/v6/context"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+)
+
+// ============================================================================
+// 测试数据
+// ============================================================================
+
+// 测试场景
+const (
+	sceneCreate core.Scene = 1 << iota
+	sceneUpdate
+)
+
+// funcStrategy 以函数实现的策略（测试用）
+type funcStrategy struct {
+	calls atomic.Int32
+	fn    func(target any, ctx core.IContext, collector core.IErrorCollector) error
+}
+
+func (s *funcStrategy) Type() core.StrategyType { return core.StrategyTypeCustom }
+func (s *funcStrategy) Name() string            { return "func" }
+
+func (s *funcStrategy) Validate(target any, ctx core.IContext, collector core.IErrorCollector) error {
+	s.calls.Add(1)
+	return s.fn(target, ctx, collector)
+}
+
+// errBackend 模拟外部依赖故障
+var errBackend = stderrors.New("backend unavailable")
+
+// runWrapper 在新的上下文和收集器中执行包装器
+func runWrapper(w *StrategyWrapper) (error, core.IErrorCollector) {
+	ctx := context.NewContext(sceneCreate)
+	defer ctx.Release()
+	collector := errors.NewListErrorCollector(10)
+	return w.Validate(nil, ctx, collector), collector
+}
+
+// ============================================================================
+// 超时
+// ============================================================================
+
+// TestStrategyWrapper_Timeout 测试超时：后台继续运行的策略不能访问调用方的上下文
+func TestStrategyWrapper_Timeout(t *testing.T) {
+	finished := make(chan struct{})
+	inner := &funcStrategy{fn: func(_ any, ctx core.IContext, collector core.IErrorCollector) error {
+		defer close(finished)
+		<-ctx.GoContext().Done()
+		time.Sleep(10 * time.Millisecond) // 取消后仍继续运行一段时间
+		ctx.Metadata().Set("late", true)
+		collector.Collect(errors.NewFieldError("User.name", "name", "late"))
+		return nil
+	}}
+	w := NewStrategyWrapper(inner, WithTimeout(time.Millisecond))
+
+	ctx := context.NewContext(sceneCreate, context.WithMetadata("request", "r1"))
+	collector := errors.NewListErrorCollector(10)
+	err := w.Validate(nil, ctx, collector)
+	if !stderrors.Is(err, core.ErrStrategyTimeout) {
+		t.Fatalf("Validate() error = %v, want ErrStrategyTimeout", err)
+	}
+	// 调用方随即回收上下文，上下文可能被复用（-race 下检测数据竞争）
+	ctx.Release()
+	reused := context.NewContext(sceneUpdate)
+	reused.Metadata().Set("other", 1)
+	<-finished
+	reused.Release()
+
+	if collector.HasErrors() {
+		t.Errorf("超时后内部策略的错误不应进入调用方收集器: %v", collector.Errors())
+	}
+}
+
+// TestStrategyWrapper_TimeoutMetadata 测试按时完成时元数据写回调用方
+func TestStrategyWrapper_TimeoutMetadata(t *testing.T) {
+	inner := &funcStrategy{fn: func(_ any, ctx core.IContext, collector core.IErrorCollector) error {
+		if v, _ := ctx.Metadata().Get("request"); v != "r1" {
+			t.Errorf("内部上下文应能读取调用方元数据, got %v", v)
+		}
+		ctx.Metadata().Set("checked", true)
+		ctx.Metadata().Delete("request")
+		collector.Collect(errors.NewFieldError("User.name", "name", "required"))
+		return nil
+	}}
+	w := NewStrategyWrapper(inner, WithTimeout(time.Second))
+
+	ctx := context.NewContext(sceneCreate, context.WithMetadata("request", "r1"))
+	defer ctx.Release()
+	collector := errors.NewListErrorCollector(10)
+	if err := w.Validate(nil, ctx, collector); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if v, _ := ctx.Metadata().Get("checked"); v != true {
+		t.Error("内部策略写入的元数据应写回调用方")
+	}
+	if ctx.Metadata().Has("request") {
+		t.Error("内部策略删除的元数据应同步删除")
+	}
+	if collector.Count() != 1 {
+		t.Errorf("Count() = %d, want 1", collector.Count())
+	}
+}
+
+// ============================================================================
+// 熔断
+// ============================================================================
+
+// TestStrategyWrapper_CircuitBreaker 测试熔断器打开、半开探测和恢复
+func TestStrategyWrapper_CircuitBreaker(t *testing.T) {
+	var failing atomic.Bool
+	failing.Store(true)
+	inner := &funcStrategy{fn: func(any, core.IContext, core.IErrorCollector) error {
+		if failing.Load() {
+			return errBackend
+		}
+		return nil
+	}}
+	w := NewStrategyWrapper(inner, WithCircuitBreaker(2, 20*time.Millisecond))
+
+	// 连续失败达到阈值后打开
+	for i := 0; i < 2; i++ {
+		if err, _ := runWrapper(w); !stderrors.Is(err, errBackend) {
+			t.Fatalf("call %d error = %v", i, err)
+		}
+	}
+	if w.CircuitState() != CircuitOpen {
+		t.Fatalf("CircuitState() = %v, want open", w.CircuitState())
+	}
+
+	// 打开期间不执行内部策略
+	if err, _ := runWrapper(w); !stderrors.Is(err, core.ErrCircuitOpen) {
+		t.Errorf("open circuit error = %v", err)
+	}
+	if calls := inner.calls.Load(); calls != 2 {
+		t.Errorf("inner calls = %d, want 2", calls)
+	}
+
+	// 冷却后半开探测失败，重新打开
+	time.Sleep(25 * time.Millisecond)
+	if err, _ := runWrapper(w); !stderrors.Is(err, errBackend) {
+		t.Errorf("half-open probe error = %v", err)
+	}
+	if w.CircuitState() != CircuitOpen {
+		t.Errorf("失败的探测后 CircuitState() = %v, want open", w.CircuitState())
+	}
+
+	// 冷却后半开探测成功，关闭
+	time.Sleep(25 * time.Millisecond)
+	failing.Store(false)
+	if err, _ := runWrapper(w); err != nil {
+		t.Errorf("half-open probe error = %v", err)
+	}
+	if w.CircuitState() != CircuitClosed {
+		t.Errorf("成功的探测后 CircuitState() = %v, want closed", w.CircuitState())
+	}
+}
+
+// TestCircuitBreaker_HalfOpenSingleProbe 测试半开状态只放行一次探测
+func TestCircuitBreaker_HalfOpenSingleProbe(t *testing.T) {
+	b := &circuitBreaker{threshold: 1, cooldown: time.Millisecond}
+	b.onFailure()
+	time.Sleep(2 * time.Millisecond)
+
+	if !b.allow() {
+		t.Fatal("冷却后应放行探测")
+	}
+	if b.currentState() != CircuitHalfOpen {
+		t.Errorf("state = %v, want half-open", b.currentState())
+	}
+	if b.allow() {
+		t.Error("探测未结束时不应再放行")
+	}
+}
+
+// ============================================================================
+// 降级
+// ============================================================================
+
+// TestStrategyWrapper_Degrade 测试两种降级模式
+func TestStrategyWrapper_Degrade(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    DegradeMode
+		inner   func(any, core.IContext, core.IErrorCollector) error
+		wantErr error
+	}{
+		{"硬失败", DegradeFail, func(any, core.IContext, core.IErrorCollector) error { return errBackend }, errBackend},
+		{"跳过", DegradeSkip, func(any, core.IContext, core.IErrorCollector) error { return errBackend }, nil},
+		{"panic跳过", DegradeSkip, func(any, core.IContext, core.IErrorCollector) error { panic("boom") }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewStrategyWrapper(&funcStrategy{fn: tt.inner}, WithDegradeMode(tt.mode))
+			err, collector := runWrapper(w)
+			if !stderrors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			if collector.HasErrors() {
+				t.Errorf("降级不应收集错误: %v", collector.Errors())
+			}
+
+			warnings := collector.Warnings()
+			if tt.mode == DegradeFail {
+				if len(warnings) != 0 {
+					t.Errorf("硬失败不应收集警告: %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || warnings[0].Tag() != DegradedTag {
+				t.Errorf("Warnings() = %v, want one %s warning", warnings, DegradedTag)
+			}
+		})
+	}
+
+	// 熔断打开时同样按降级模式处理
+	w := NewStrategyWrapper(&funcStrategy{fn: func(any, core.IContext, core.IErrorCollector) error { return errBackend }},
+		WithCircuitBreaker(1, time.Hour), WithDegradeMode(DegradeSkip))
+	_, _ = runWrapper(w)
+	if err, collector := runWrapper(w); err != nil || len(collector.Warnings()) != 1 {
+		t.Errorf("熔断降级 = %v, %v", err, collector.Warnings())
+	}
+}
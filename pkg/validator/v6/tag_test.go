@@ -0,0 +1,100 @@
+package v6_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/binding"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// TestTag_Registry 内置标签预先注册，自定义标签注册后可解析
+func TestTag_Registry(t *testing.T) {
+	for _, tag := range []string{"required", "oneof", "regex", "coerced", "circular_reference"} {
+		if _, err := v6.ParseTag(tag); err != nil {
+			t.Errorf("ParseTag(%q) error = %v", tag, err)
+		}
+	}
+	if _, err := v6.ParseTag("requried"); err == nil {
+		t.Error("未注册的标签应返回错误")
+	}
+
+	for _, bad := range []v6.Tag{"", "a,b", "a|b", "a=b", "a b", "omitempty", "dive"} {
+		if err := v6.RegisterTag(bad); err == nil {
+			t.Errorf("RegisterTag(%q) 应返回错误", bad)
+		}
+	}
+	if err := v6.RegisterTag("tag_test_vip_only"); err != nil {
+		t.Fatal(err)
+	}
+	if !v6.IsRegisteredTag("tag_test_vip_only") {
+		t.Error("注册后应可查询")
+	}
+
+	err := v6.CheckRuleTags(map[string]string{
+		"name":  "required,min=3|tag_test_vip_only",
+		"email": "omitempty,dive,emial",
+		"age":   "requried,gte=18",
+	})
+	if err == nil || err.Error() != "unregistered tags (age: requried; email: emial)" {
+		t.Errorf("CheckRuleTags error = %v", err)
+	}
+}
+
+// TestTag_StrictRuleAlias 严格模式下别名规则中的标签必须已注册，别名本身注册为标签
+func TestTag_StrictRuleAlias(t *testing.T) {
+	v6.SetStrictTags(true)
+	defer v6.SetStrictTags(false)
+	defer func() {
+		_ = v6.UnregisterRuleAlias("tag_test_name")
+		_ = v6.UnregisterRuleAlias("tag_test_typo")
+	}()
+
+	if err := v6.RegisterRuleAlias("tag_test_typo", "requried,min=3"); err == nil {
+		t.Error("严格模式下应拒绝未注册的标签")
+	}
+	if err := v6.RegisterRuleAlias("tag_test_name", "required,min=3,alphanum"); err != nil {
+		t.Fatal(err)
+	}
+	if !v6.IsRegisteredTag("tag_test_name") {
+		t.Error("别名应注册为标签")
+	}
+}
+
+// tagTypoModel 规则中有拼写错误的模型
+type tagTypoModel struct {
+	Name string `json:"name"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (m *tagTypoModel) ValidateRules(scene core.Scene) map[string]string {
+	if scene.Has(SceneUpdate) {
+		return map[string]string{"name": "omitempty,mni=3"}
+	}
+	return map[string]string{"name": "required,min=3"}
+}
+
+// TestTag_StrictModelRegistry 严格模式下模型注册时检查各场景的规则
+func TestTag_StrictModelRegistry(t *testing.T) {
+	newRegistry := func() *binding.ModelRegistry {
+		registry := binding.NewModelRegistry()
+		registry.SetMethodScene(http.MethodPost, SceneCreate)
+		registry.SetMethodScene(http.MethodPut, SceneUpdate)
+		return registry
+	}
+
+	// 默认不检查
+	if err := newRegistry().Register("Typo", &tagTypoModel{}); err != nil {
+		t.Fatalf("非严格模式: %v", err)
+	}
+
+	v6.SetStrictTags(true)
+	defer v6.SetStrictTags(false)
+
+	err := newRegistry().Register("Typo", &tagTypoModel{})
+	if err == nil || !strings.Contains(err.Error(), `model "Typo" PUT: unregistered tags (name: mni)`) {
+		t.Errorf("严格模式 error = %v", err)
+	}
+}
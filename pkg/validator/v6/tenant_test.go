@@ -0,0 +1,94 @@
+package v6_test
+
+import (
+	"context"
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// staticRuleProvider 固定的外部规则
+type staticRuleProvider map[string]string
+
+func (p staticRuleProvider) Rules(model string, scene core.Scene) (map[string]string, string, bool) {
+	return p, "v1", model == "User" && scene == SceneCreate
+}
+
+func (p staticRuleProvider) Version() string { return "v1" }
+
+// tenantOverlays 租户 acme 收紧用户名并移除年龄规则，其他租户没有覆盖
+var tenantOverlays = v6.TenantRuleProviderFunc(func(tenantID, model string, scene core.Scene) (map[string]string, bool) {
+	if tenantID != "acme" || model != "User" {
+		return nil, false
+	}
+	return map[string]string{
+		"username": "required,min=6",
+		"age":      "",
+	}, true
+})
+
+// tenantContext 带租户 ID 的验证选项
+func tenantContext(tenantID string) v6.ValidateOption {
+	return v6.WithContext(v6.WithTenantID(context.Background(), tenantID))
+}
+
+// TestTenantRules_Precedence 测试优先级：模型规则 < 外部规则 < 租户覆盖
+func TestTenantRules_Precedence(t *testing.T) {
+	// 模型要求 username min=3，外部规则收紧为 min=5
+	withProvider := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithRuleProvider(staticRuleProvider{"username": "required,min=5"}).
+		WithTenantRuleProvider(tenantOverlays).
+		Build()
+	modelOnly := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithTenantRuleProvider(tenantOverlays).
+		Build()
+
+	user := &User{Username: "alice", Email: "alice@example.com", Password: "secret1", Age: 10}
+	short := &User{Username: "bob", Email: "bob@example.com", Password: "secret1", Age: 10}
+
+	tests := []struct {
+		name      string
+		validator v6.Validator
+		model     *User
+		opts      []v6.ValidateOption
+		want      []string
+	}{
+		{"模型规则", modelOnly, short, nil, []string{"age: gte"}},
+		{"外部规则覆盖模型规则", withProvider, short, nil, []string{"username: min", "age: gte"}},
+		{"外部规则满足", withProvider, user, nil, []string{"age: gte"}},
+		{"租户覆盖优先于外部规则", withProvider, user, []v6.ValidateOption{tenantContext("acme")}, []string{"username: min"}},
+		{"租户覆盖叠加在模型规则上", modelOnly, user, []v6.ValidateOption{tenantContext("acme")}, []string{"username: min"}},
+		{"没有覆盖的租户", withProvider, user, []v6.ValidateOption{tenantContext("other")}, []string{"age: gte"}},
+		{"空租户 ID", withProvider, user, []v6.ValidateOption{tenantContext("")}, []string{"age: gte"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tags(tt.validator.Validate(tt.model, SceneCreate, tt.opts...))
+			if !equalStrings(got, tt.want) {
+				t.Errorf("errors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTenantRules_NoLeak 测试租户覆盖不写入模型规则缓存，交替租户互不影响
+func TestTenantRules_NoLeak(t *testing.T) {
+	validator := v6.NewBuilder().
+		WithRuleStrategy(10).
+		WithTenantRuleProvider(tenantOverlays).
+		Build()
+	user := &User{Username: "alice", Email: "alice@example.com", Password: "secret1", Age: 10}
+
+	for i := 0; i < 3; i++ {
+		if got := tags(validator.Validate(user, SceneCreate, tenantContext("acme"))); !equalStrings(got, []string{"username: min"}) {
+			t.Fatalf("round %d acme errors = %v", i, got)
+		}
+		if got := tags(validator.Validate(user, SceneCreate)); !equalStrings(got, []string{"age: gte"}) {
+			t.Fatalf("round %d default errors = %v", i, got)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package v6_test
+
+import (
+	"testing"
+
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/core"
+)
+
+// tagged 一个字段可产生多个错误的模型
+type tagged struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// ValidateRules 实现 IRuleValidator 接口
+func (m *tagged) ValidateRules(scene core.Scene) map[string]string {
+	return map[string]string{
+		"name": "required",
+		"tags": "dive,min=3",
+	}
+}
+
+// TestTruncated 测试只有错误被丢弃时才标记截断
+func TestTruncated(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxErrors     int
+		model         *tagged
+		wantErrors    int
+		wantTruncated bool
+	}{
+		{"未达到上限", 10, &tagged{Tags: []string{"a", "b"}}, 3, false},
+		{"恰好达到上限", 3, &tagged{Tags: []string{"a", "b"}}, 3, false},
+		{"超出上限", 2, &tagged{Name: "tom", Tags: []string{"a", "b", "c"}}, 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := v6.NewBuilder().WithRuleStrategy(10).WithMaxErrors(tt.maxErrors).Build()
+			err := validator.Validate(tt.model, SceneCreate)
+			if err == nil {
+				t.Fatal("Validate() = nil, want errors")
+			}
+			if got := len(err.FieldErrors()); got != tt.wantErrors {
+				t.Errorf("len(FieldErrors()) = %d, want %d", got, tt.wantErrors)
+			}
+			if err.Truncated() != tt.wantTruncated {
+				t.Errorf("Truncated() = %t, want %t", err.Truncated(), tt.wantTruncated)
+			}
+		})
+	}
+}
+
+// TestFailFast 测试遇到第一个错误即停止验证
+func TestFailFast(t *testing.T) {
+	validator := v6.NewBuilder().WithRuleStrategy(10).WithFailFast().Build()
+
+	err := validator.Validate(&tagged{Tags: []string{"ok!"}}, SceneCreate)
+	if err == nil || len(err.FieldErrors()) != 1 || err.FieldErrors()[0].Tag() != "required" {
+		t.Fatalf("Validate() = %v, want one required error", err)
+	}
+	if err.Truncated() {
+		t.Error("只有一个错误时不应标记截断")
+	}
+
+	// 同一字段的多个错误只保留第一个，其余被丢弃
+	err = validator.Validate(&tagged{Name: "tom", Tags: []string{"a", "b"}}, SceneCreate)
+	if err == nil || len(err.FieldErrors()) != 1 {
+		t.Fatalf("Validate() = %v, want one error", err)
+	}
+	if !err.Truncated() {
+		t.Error("丢弃错误后应标记截断")
+	}
+
+	if err := validator.Validate(&tagged{Name: "tom", Tags: []string{"abc"}}, SceneCreate); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
@@ -0,0 +1,124 @@
+package v6test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// 断言函数
+// ============================================================================
+
+// 断言函数的 result 参数接受 Validate/Check 的返回值，也接受包装后的 error（通过 errors.As 解包）。
+// namespace 匹配规则：与 FieldError.Namespace() 完全相等；不含 "." 时也可以只匹配 Field()。
+
+// AssertHasError 断言结果中包含指定字段和标签的错误
+// 示例：AssertHasError(t, result, "User.email", "email")
+func AssertHasError(t testing.TB, result error, namespace, tag string) {
+	t.Helper()
+	if _, ok := findError(asValidationError(result), namespace, tag); !ok {
+		t.Errorf("expected validation error %s[%s], got %s", namespace, tag, describe(result))
+	}
+}
+
+// AssertNoFieldError 断言结果中不包含指定字段的任何错误
+func AssertNoFieldError(t testing.TB, result error, namespace string) {
+	t.Helper()
+	if fe, ok := findError(asValidationError(result), namespace, ""); ok {
+		t.Errorf("expected no validation error on %s, got %s[%s]", namespace, fe.Namespace(), fe.Tag())
+	}
+}
+
+// AssertNoError 断言验证通过（警告不影响结果）
+func AssertNoError(t testing.TB, result error) {
+	t.Helper()
+	if ve := asValidationError(result); ve != nil && ve.HasErrors() {
+		t.Errorf("expected validation to pass, got %s", describe(result))
+	} else if ve == nil && result != nil {
+		t.Errorf("expected validation to pass, got non-validation error: %v", result)
+	}
+}
+
+// AssertErrorCount 断言错误数量
+func AssertErrorCount(t testing.TB, result error, count int) {
+	t.Helper()
+	got := 0
+	if ve := asValidationError(result); ve != nil {
+		got = len(ve.FieldErrors())
+	}
+	if got != count {
+		t.Errorf("expected %d validation errors, got %d: %s", count, got, describe(result))
+	}
+}
+
+// AssertHasWarning 断言结果中包含指定字段和标签的警告
+func AssertHasWarning(t testing.TB, result error, namespace, tag string) {
+	t.Helper()
+	if ve := asValidationError(result); ve != nil {
+		for _, fe := range ve.Warnings() {
+			if matches(fe, namespace, tag) {
+				return
+			}
+		}
+	}
+	t.Errorf("expected validation warning %s[%s], got %s", namespace, tag, describe(result))
+}
+
+// asValidationError 解包验证结果
+func asValidationError(result error) core.IValidationError {
+	if result == nil {
+		return nil
+	}
+	var ve core.IValidationError
+	if stderrors.As(result, &ve) {
+		return ve
+	}
+	return nil
+}
+
+// findError 查找匹配的字段错误，tag 为空时匹配任意标签
+func findError(ve core.IValidationError, namespace, tag string) (core.IFieldError, bool) {
+	if ve == nil {
+		return nil, false
+	}
+	for _, fe := range ve.FieldErrors() {
+		if matches(fe, namespace, tag) {
+			return fe, true
+		}
+	}
+	return nil, false
+}
+
+// matches 字段错误是否匹配
+func matches(fe core.IFieldError, namespace, tag string) bool {
+	if tag != "" && fe.Tag() != tag {
+		return false
+	}
+	if fe.Namespace() == namespace {
+		return true
+	}
+	return !strings.Contains(namespace, ".") && fe.Field() == namespace
+}
+
+// describe 生成结果描述，用于失败信息
+func describe(result error) string {
+	ve := asValidationError(result)
+	if ve == nil {
+		if result == nil {
+			return "no errors"
+		}
+		return result.Error()
+	}
+
+	items := make([]string, 0, len(ve.FieldErrors()))
+	for _, fe := range ve.FieldErrors() {
+		items = append(items, fmt.Sprintf("%s[%s]", fe.Namespace(), fe.Tag()))
+	}
+	if len(items) == 0 {
+		return "no errors"
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
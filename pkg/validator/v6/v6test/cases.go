@@ -0,0 +1,360 @@
+package v6test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/binding"
+	"katydid-common-account/pkg/validator/v6/core"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// 用例文件
+// ============================================================================
+
+// Case 数据驱动的验证用例，规则回归会在 CI 中暴露
+// 说明：Method 和 Scene 二选一，Method 按模型注册的 HTTP 方法映射场景；Expect 为空表示期望验证通过
+type Case struct {
+	Name    string              `json:"name"`
+	Model   string              `json:"model"`             // 注册表中的模型名称
+	Method  string              `json:"method,omitempty"`  // HTTP 方法，如 POST
+	Scene   *core.Scene         `json:"scene,omitempty"`   // 场景值，优先于 Method
+	Payload any                 `json:"payload,omitempty"` // 请求体，按 JSON 解码为模型实例
+	Expect  map[string][]string `json:"expect,omitempty"`  // 字段（JSON 名称或 Go 字段名，可带模型名称前缀）-> 期望的错误标签
+
+	// File 用例来源文件，加载时填充
+	File string `json:"-"`
+}
+
+// String 实现 Stringer 接口
+func (c Case) String() string {
+	if c.File == "" {
+		return c.Name
+	}
+	return filepath.Base(c.File) + "/" + c.Name
+}
+
+// caseFile 用例文件结构：{"cases": [...]}
+type caseFile struct {
+	Cases []Case `json:"cases"`
+}
+
+// DecodeFunc 用例文件解码函数（签名与 json.Unmarshal、yaml.Unmarshal 一致）
+type DecodeFunc func(data []byte, v any) error
+
+// Loader 用例加载器
+type Loader struct {
+	decoders map[string]DecodeFunc // 扩展名 -> 解码函数
+}
+
+// LoaderOption 加载器选项
+type LoaderOption func(*Loader)
+
+// WithDecoder 注册其他格式的解码函数
+// 示例：WithDecoder(yaml.Unmarshal, ".yaml", ".yml")
+// 说明：YAML 解码器按小写字段名映射，与 JSON 字段名一致
+func WithDecoder(decode DecodeFunc, exts ...string) LoaderOption {
+	return func(l *Loader) {
+		for _, ext := range exts {
+			l.decoders[strings.ToLower(ext)] = decode
+		}
+	}
+}
+
+// NewLoader 创建用例加载器（默认支持 .json）
+func NewLoader(opts ...LoaderOption) *Loader {
+	l := &Loader{decoders: map[string]DecodeFunc{".json": decodeJSON}}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// LoadFile 加载单个用例文件
+func (l *Loader) LoadFile(path string) ([]Case, error) {
+	decode, ok := l.decoders[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return nil, fmt.Errorf("v6test: unsupported case file %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("v6test: read %s: %w", path, err)
+	}
+
+	var file caseFile
+	if err := decode(data, &file); err != nil {
+		return nil, fmt.Errorf("v6test: decode %s: %w", path, err)
+	}
+	for i := range file.Cases {
+		c := &file.Cases[i]
+		c.File = path
+		if c.Name == "" {
+			c.Name = fmt.Sprintf("case_%d", i+1)
+		}
+		if c.Model == "" {
+			return nil, fmt.Errorf("v6test: %s: model is required", c)
+		}
+		if c.Scene == nil && c.Method == "" {
+			return nil, fmt.Errorf("v6test: %s: method or scene is required", c)
+		}
+	}
+	return file.Cases, nil
+}
+
+// LoadDir 加载目录下所有支持格式的用例文件（不递归，按文件名排序）
+func (l *Loader) LoadDir(dir string) ([]Case, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("v6test: read dir %s: %w", dir, err)
+	}
+
+	var cases []Case
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if _, ok := l.decoders[strings.ToLower(filepath.Ext(entry.Name()))]; !ok {
+			continue
+		}
+		fileCases, err := l.LoadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, fileCases...)
+	}
+	return cases, nil
+}
+
+// decodeJSON 解码 JSON 用例文件，拒绝未知字段（拼写错误的键）
+func decodeJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// ============================================================================
+// 运行
+// ============================================================================
+
+// Result 用例运行结果
+type Result struct {
+	Case       Case
+	Err        error               // 用例本身无法运行（模型未注册、请求体无法解码等）
+	Missing    map[string][]string // 期望出现但没有出现的错误
+	Unexpected map[string][]string // 出现但未期望的错误
+}
+
+// Passed 用例是否通过
+func (r *Result) Passed() bool {
+	return r.Err == nil && len(r.Missing) == 0 && len(r.Unexpected) == 0
+}
+
+// String 实现 Stringer 接口，描述失败原因
+func (r *Result) String() string {
+	switch {
+	case r.Err != nil:
+		return fmt.Sprintf("%s: %v", r.Case, r.Err)
+	case r.Passed():
+		return fmt.Sprintf("%s: ok", r.Case)
+	default:
+		return fmt.Sprintf("%s: missing %v, unexpected %v", r.Case, r.Missing, r.Unexpected)
+	}
+}
+
+// Runner 用例运行器
+type Runner struct {
+	registry  *binding.ModelRegistry
+	validator core.IValidator
+}
+
+// NewRunner 创建用例运行器
+func NewRunner(registry *binding.ModelRegistry, validator core.IValidator) *Runner {
+	return &Runner{registry: registry, validator: validator}
+}
+
+// Run 运行单个用例
+// 说明：只比对错误（不含警告），每个字段的错误标签需与期望完全一致（与顺序无关）
+func (r *Runner) Run(c Case) *Result {
+	result := &Result{Case: c}
+
+	info, ok := r.registry.Get(c.Model)
+	if !ok {
+		result.Err = fmt.Errorf("model %q not registered", c.Model)
+		return result
+	}
+
+	scene, err := caseScene(c, info)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	target := info.New()
+	if c.Payload != nil {
+		data, err := json.Marshal(c.Payload)
+		if err != nil {
+			result.Err = fmt.Errorf("encode payload: %w", err)
+			return result
+		}
+		if err := json.Unmarshal(data, target); err != nil {
+			result.Err = fmt.Errorf("decode payload into %s: %w", info.Type, err)
+			return result
+		}
+	}
+
+	var actual map[string][]string
+	if ve := r.validator.Check(target, scene); ve != nil {
+		actual = groupErrors(ve.FieldErrors(), expectAliases(c, info))
+	}
+	result.Missing = subtract(c.Expect, actual)
+	result.Unexpected = subtract(actual, c.Expect)
+	return result
+}
+
+// RunAll 以子测试的形式运行所有用例
+func (r *Runner) RunAll(t *testing.T, cases []Case) {
+	t.Helper()
+	for _, c := range cases {
+		c := c
+		t.Run(c.String(), func(t *testing.T) {
+			if result := r.Run(c); !result.Passed() {
+				t.Error(result)
+			}
+		})
+	}
+}
+
+// RunDir 加载目录下的用例文件并以子测试的形式运行
+func RunDir(t *testing.T, dir string, registry *binding.ModelRegistry, validator core.IValidator, opts ...LoaderOption) {
+	t.Helper()
+	cases, err := NewLoader(opts...).LoadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) == 0 {
+		t.Fatalf("v6test: no cases found in %s", dir)
+	}
+	NewRunner(registry, validator).RunAll(t, cases)
+}
+
+// caseScene 解析用例的场景
+func caseScene(c Case, info *binding.ModelInfo) (core.Scene, error) {
+	if c.Scene != nil {
+		return *c.Scene, nil
+	}
+	scene, ok := info.SceneFor(c.Method)
+	if !ok {
+		return core.SceneNone, fmt.Errorf("model %q has no scene for method %s", c.Model, c.Method)
+	}
+	return scene, nil
+}
+
+// expectAliases 期望键的别名 -> 期望键
+// 说明：期望键可以写 JSON 名称或 Go 字段名，可以带模型名称前缀（如 User.email、email、Email 均可）
+func expectAliases(c Case, info *binding.ModelInfo) map[string]string {
+	aliases := make(map[string]string, len(c.Expect)*2)
+	for key := range c.Expect {
+		aliases[key] = key
+		path := key
+		for _, root := range []string{c.Model, info.Type.Name()} {
+			if rest, ok := strings.CutPrefix(path, root+"."); ok {
+				path = rest
+				break
+			}
+		}
+		aliases[path] = key
+		aliases[goPath(info.Type, path)] = key
+	}
+	return aliases
+}
+
+// groupErrors 按期望键分组实际错误
+// 说明：依次用命名空间、去掉根类型的相对路径、字段名匹配期望键，都不匹配时使用命名空间
+func groupErrors(fieldErrors []core.IFieldError, aliases map[string]string) map[string][]string {
+	groups := make(map[string][]string, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		namespace := fe.Namespace()
+		key := namespace
+		candidates := []string{namespace, namespace[strings.Index(namespace, ".")+1:], fe.Field()}
+		for _, candidate := range candidates {
+			if expectKey, ok := aliases[candidate]; ok {
+				key = expectKey
+				break
+			}
+		}
+		groups[key] = append(groups[key], fe.Tag())
+	}
+	return groups
+}
+
+// goPath 将 JSON 名称路径转换为 Go 字段名路径（如 profile.emails[0] -> Profile.Emails[0]）
+// 说明：无法解析的段及其后续段保持原样
+func goPath(typ reflect.Type, path string) string {
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			break
+		}
+
+		name, suffix, _ := strings.Cut(segment, "[")
+		field, ok := structField(typ, name)
+		if !ok {
+			break
+		}
+		if suffix != "" {
+			suffix = "[" + suffix
+		}
+		segments[i] = field.Name + suffix
+		typ = field.Type
+	}
+	return strings.Join(segments, ".")
+}
+
+// structField 按 JSON 名称或 Go 字段名查找结构体字段
+func structField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ","); jsonName == name {
+			return field, true
+		}
+	}
+	return typ.FieldByName(name)
+}
+
+// subtract 计算 a 中存在而 b 中不存在的 字段 -> 标签
+func subtract(a, b map[string][]string) map[string][]string {
+	var diff map[string][]string
+	for key, tags := range a {
+		have := make(map[string]int, len(b[key]))
+		for _, tag := range b[key] {
+			have[tag]++
+		}
+		for _, tag := range tags {
+			if have[tag] > 0 {
+				have[tag]--
+				continue
+			}
+			if diff == nil {
+				diff = make(map[string][]string)
+			}
+			diff[key] = append(diff[key], tag)
+		}
+	}
+	for _, tags := range diff {
+		sort.Strings(tags)
+	}
+	return diff
+}
@@ -0,0 +1,97 @@
+package v6test
+
+import (
+	v6 "katydid-common-account/pkg/validator/v6"
+	"katydid-common-account/pkg/validator/v6/binding"
+	"katydid-common-account/pkg/validator/v6/core"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type testUser struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Age      int    `json:"age"`
+}
+
+func (u *testUser) ValidateRules(scene core.Scene) map[string]string {
+	switch scene {
+	case sceneCreate:
+		return map[string]string{"Username": "required,min=3", "Email": "required,email", "Age": "gte=0,lte=150"}
+	case sceneUpdate:
+		return map[string]string{"Username": "omitempty,min=3", "Age": "gte=0,lte=150"}
+	}
+	return nil
+}
+
+func newRunner(t *testing.T) (*binding.ModelRegistry, core.IValidator) {
+	registry := binding.NewModelRegistry()
+	registry.SetMethodScene(http.MethodPost, sceneCreate)
+	registry.MustRegister("User", &testUser{})
+	return registry, v6.NewBuilder().WithRuleStrategy(10).Build()
+}
+
+func TestRunDir(t *testing.T) {
+	registry, validator := newRunner(t)
+	RunDir(t, "testdata", registry, validator)
+}
+
+func TestRunner_ReportsMismatch(t *testing.T) {
+	registry, validator := newRunner(t)
+	runner := NewRunner(registry, validator)
+
+	result := runner.Run(Case{
+		Name:    "wrong expectation",
+		Model:   "User",
+		Method:  http.MethodPost,
+		Payload: map[string]any{"username": "al", "email": "al@example.com"},
+		Expect:  map[string][]string{"username": {"required"}},
+	})
+	if result.Passed() {
+		t.Fatal("mismatched case should fail")
+	}
+	if got := result.Missing["username"]; len(got) != 1 || got[0] != "required" {
+		t.Errorf("missing = %v", result.Missing)
+	}
+	if got := result.Unexpected["username"]; len(got) != 1 || got[0] != "min" {
+		t.Errorf("unexpected = %v", result.Unexpected)
+	}
+
+	if result := runner.Run(Case{Name: "unknown", Model: "Order", Method: http.MethodPost}); result.Err == nil {
+		t.Error("unregistered model should fail")
+	}
+	if result := runner.Run(Case{Name: "no scene", Model: "User", Method: http.MethodDelete}); result.Err == nil {
+		t.Error("unmapped method should fail")
+	}
+}
+
+func TestLoader(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	loader := NewLoader()
+	if _, err := loader.LoadFile(write("typo.json", `{"cases":[{"model":"User","method":"POST","expcet":{}}]}`)); err == nil {
+		t.Error("unknown keys should be rejected")
+	}
+	if _, err := loader.LoadFile(write("noscene.json", `{"cases":[{"model":"User"}]}`)); err == nil {
+		t.Error("case without method or scene should be rejected")
+	}
+
+	// 自定义格式：以 .cases 扩展名注册 JSON 解码器
+	write("extra.cases", `{"cases":[{"model":"User","scene":1}]}`)
+	cases, err := NewLoader(WithDecoder(decodeJSON, ".cases")).LoadFile(filepath.Join(dir, "extra.cases"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 1 || cases[0].Name != "case_1" || *cases[0].Scene != sceneCreate {
+		t.Errorf("unexpected cases: %+v", cases)
+	}
+}
@@ -0,0 +1,117 @@
+package v6test
+
+import (
+	"bytes"
+	"encoding/json"
+	"katydid-common-account/pkg/validator/v6/core"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// ============================================================================
+// 黄金文件
+// ============================================================================
+
+// UpdateGoldenEnv 设置该环境变量为 1 时，AssertGolden 会重写黄金文件
+// 示例：VALIDATOR_UPDATE_GOLDEN=1 go test ./...
+const UpdateGoldenEnv = "VALIDATOR_UPDATE_GOLDEN"
+
+// goldenFieldError 黄金文件中的字段错误
+// 说明：不包含字段值（可能是敏感数据或不稳定的值）
+type goldenFieldError struct {
+	Namespace string `json:"namespace"`
+	Field     string `json:"field"`
+	Tag       string `json:"tag"`
+	Param     string `json:"param,omitempty"`
+	Severity  string `json:"severity"`
+	Message   string `json:"message,omitempty"`
+}
+
+// goldenResult 黄金文件中的验证结果
+type goldenResult struct {
+	Valid       bool               `json:"valid"`
+	Errors      []goldenFieldError `json:"errors"`
+	Warnings    []goldenFieldError `json:"warnings,omitempty"`
+	RuleVersion string             `json:"rule_version,omitempty"`
+	Truncated   bool               `json:"truncated,omitempty"`
+}
+
+// MarshalGolden 将验证结果序列化为稳定的 JSON
+// 说明：错误按 namespace、tag 排序，与规则的执行顺序无关；不包含耗时等不稳定信息
+func MarshalGolden(result error) ([]byte, error) {
+	golden := goldenResult{Valid: true, Errors: []goldenFieldError{}}
+	if ve := asValidationError(result); ve != nil {
+		golden.Valid = !ve.HasErrors()
+		golden.Errors = append([]goldenFieldError{}, toGolden(ve.FieldErrors())...)
+		golden.Warnings = toGolden(ve.Warnings())
+		golden.RuleVersion = ve.RuleVersion()
+		golden.Truncated = ve.Truncated()
+	} else if result != nil {
+		golden.Valid = false
+		golden.Errors = []goldenFieldError{{Severity: "error", Message: result.Error()}}
+	}
+
+	data, err := json.MarshalIndent(golden, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// AssertGolden 将验证结果与 testdata/<name>.golden 比对
+// 说明：设置环境变量 VALIDATOR_UPDATE_GOLDEN=1 时写入当前结果
+func AssertGolden(t testing.TB, name string, result error) {
+	t.Helper()
+
+	got, err := MarshalGolden(result)
+	if err != nil {
+		t.Fatalf("marshal golden %s: %v", name, err)
+	}
+
+	path := filepath.Join("testdata", name+".golden")
+	if os.Getenv(UpdateGoldenEnv) == "1" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write golden %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden %s: %v (run with %s=1 to create)", path, err, UpdateGoldenEnv)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("golden %s mismatch:\n--- got\n%s\n--- want\n%s", path, got, want)
+	}
+}
+
+// toGolden 转换并排序字段错误
+func toGolden(fieldErrors []core.IFieldError) []goldenFieldError {
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+
+	items := make([]goldenFieldError, 0, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		items = append(items, goldenFieldError{
+			Namespace: fe.Namespace(),
+			Field:     fe.Field(),
+			Tag:       fe.Tag(),
+			Param:     fe.Param(),
+			Severity:  fe.Severity().String(),
+			Message:   fe.Message(),
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Tag < items[j].Tag
+	})
+	return items
+}
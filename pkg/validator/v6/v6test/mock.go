@@ -0,0 +1,181 @@
+// Package v6test 为使用 v6 验证器的业务方提供测试工具
+//
+// 包含：
+//   - MockValidator：按类型和场景预设验证结果，无需构建真实规则集
+//   - 断言函数：AssertHasError、AssertNoError 等
+//   - 黄金文件：将 ValidationError 序列化为稳定的 JSON 并与 testdata 中的文件比对
+//   - 数据驱动用例：QA 在 JSON（或 YAML 等其他格式）文件中编写模型名称、场景、请求体和每个字段期望的错误标签，
+//     测试中加载后针对模型注册表中的模型逐条运行，新增用例无需编写 Go 代码
+package v6test
+
+import (
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ============================================================================
+// MockValidator 可编程的验证器
+// ============================================================================
+
+// mockKey 预设结果的键
+type mockKey struct {
+	typ   reflect.Type
+	scene core.Scene
+}
+
+// Call 一次验证调用记录
+type Call struct {
+	Target  any
+	Scene   core.Scene
+	Profile string // 调用时通过 WithProfile 选择的配置档
+}
+
+// MockValidator 可编程的验证器，实现 core.IValidator 接口
+//
+// 使用示例：
+//
+//	mock := v6test.NewMockValidator()
+//	mock.ReturnErrors(&User{}, SceneCreate, v6test.NewError("User.email", "email"))
+//	handler := NewUserHandler(mock)
+//
+// 说明：
+//   - 按目标的类型（自动解引用指针）和场景匹配预设结果，未匹配时返回默认结果（默认通过）
+//   - 记录所有调用，便于断言 handler 是否以正确的场景调用了验证器
+//   - 并发安全
+type MockValidator struct {
+	mu       sync.RWMutex
+	results  map[mockKey]core.IValidationError
+	fallback core.IValidationError
+	calls    []Call
+}
+
+// NewMockValidator 创建 MockValidator
+func NewMockValidator() *MockValidator {
+	return &MockValidator{
+		results: make(map[mockKey]core.IValidationError),
+	}
+}
+
+// Return 为类型和场景预设验证结果
+// 参数：
+//   - target：目标对象或其类型的零值（如 &User{}），只使用其类型
+//   - result：预设结果，nil 表示验证通过
+func (m *MockValidator) Return(target any, scene core.Scene, result core.IValidationError) *MockValidator {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results[mockKey{typ: typeOf(target), scene: scene}] = result
+	return m
+}
+
+// ReturnErrors 为类型和场景预设字段错误
+// 说明：Warning/Info 级别的错误会作为警告返回
+func (m *MockValidator) ReturnErrors(target any, scene core.Scene, fieldErrors ...core.IFieldError) *MockValidator {
+	return m.Return(target, scene, NewResult(fieldErrors...))
+}
+
+// ReturnDefault 设置未匹配时的默认结果，nil 表示验证通过
+func (m *MockValidator) ReturnDefault(result core.IValidationError) *MockValidator {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fallback = result
+	return m
+}
+
+// Reset 清除所有预设结果和调用记录
+func (m *MockValidator) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results = make(map[mockKey]core.IValidationError)
+	m.fallback = nil
+	m.calls = nil
+}
+
+// Calls 获取调用记录
+func (m *MockValidator) Calls() []Call {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallCount 获取调用次数
+func (m *MockValidator) CallCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.calls)
+}
+
+// Validate 实现 IValidator 接口
+func (m *MockValidator) Validate(target any, scene core.Scene, opts ...core.ValidateOption) core.IValidationError {
+	result := m.Check(target, scene, opts...)
+	if result == nil || !result.HasErrors() {
+		return nil
+	}
+	return result
+}
+
+// Check 实现 IValidator 接口
+// 说明：未预设结果时返回不含错误的结果，与真实验证器保持一致（始终非 nil）
+func (m *MockValidator) Check(target any, scene core.Scene, opts ...core.ValidateOption) core.IValidationError {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	options := core.ApplyValidateOptions(opts)
+	m.calls = append(m.calls, Call{Target: target, Scene: scene, Profile: options.Profile})
+	if result, ok := m.results[mockKey{typ: typeOf(target), scene: scene}]; ok && result != nil {
+		return result
+	}
+	if m.fallback != nil {
+		return m.fallback
+	}
+	return NewResult()
+}
+
+// ValidateWithContext 实现 IValidator 接口
+func (m *MockValidator) ValidateWithContext(target any, ctx core.IContext) error {
+	if result := m.Validate(target, ctx.Scene()); result != nil {
+		return result
+	}
+	return nil
+}
+
+// typeOf 获取解引用后的类型
+func typeOf(target any) reflect.Type {
+	typ := reflect.TypeOf(target)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+// ============================================================================
+// 构造函数
+// ============================================================================
+
+// NewError 创建字段错误
+// 参数：
+//   - namespace：完整命名空间（如 User.email），字段名取最后一段
+//   - tag：验证标签（如 email）
+func NewError(namespace, tag string, opts ...errors.FieldErrorOption) core.IFieldError {
+	field := namespace
+	if idx := strings.LastIndex(namespace, "."); idx >= 0 {
+		field = namespace[idx+1:]
+	}
+	return errors.NewFieldError(namespace, field, tag, opts...)
+}
+
+// NewResult 创建验证结果
+// 说明：Warning/Info 级别的错误会作为警告返回
+func NewResult(fieldErrors ...core.IFieldError) core.IValidationError {
+	var errs, warnings []core.IFieldError
+	for _, fe := range fieldErrors {
+		if fe.Severity().IsBlocking() {
+			errs = append(errs, fe)
+		} else {
+			warnings = append(warnings, fe)
+		}
+	}
+	return errors.NewValidationError(errs, errors.NewDefaultFormatter(), errors.WithWarnings(warnings))
+}
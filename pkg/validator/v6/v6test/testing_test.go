@@ -0,0 +1,124 @@
+package v6test
+
+import (
+	"fmt"
+	"katydid-common-account/pkg/validator/v6/core"
+	"katydid-common-account/pkg/validator/v6/errors"
+	"testing"
+)
+
+// ============================================================================
+// 测试模型
+// ============================================================================
+
+type mockUser struct {
+	Email string
+}
+
+type mockOrder struct{}
+
+const (
+	sceneCreate core.Scene = 1 << iota
+	sceneUpdate
+)
+
+// ============================================================================
+// MockValidator 测试
+// ============================================================================
+
+func TestMockValidator_ReturnsByTypeAndScene(t *testing.T) {
+	mock := NewMockValidator().
+		ReturnErrors(&mockUser{}, sceneCreate, NewError("mockUser.Email", "email"))
+
+	// 指针和值类型匹配同一预设
+	AssertHasError(t, mock.Validate(mockUser{}, sceneCreate), "mockUser.Email", "email")
+	AssertHasError(t, mock.Validate(&mockUser{}, sceneCreate), "Email", "email")
+
+	// 场景或类型不匹配时通过
+	AssertNoError(t, mock.Validate(&mockUser{}, sceneUpdate))
+	AssertNoError(t, mock.Validate(&mockOrder{}, sceneCreate))
+
+	if got := mock.CallCount(); got != 4 {
+		t.Errorf("CallCount() = %d, want 4", got)
+	}
+	if calls := mock.Calls(); calls[2].Scene != sceneUpdate {
+		t.Errorf("Calls()[2].Scene = %d, want %d", calls[2].Scene, sceneUpdate)
+	}
+}
+
+func TestMockValidator_DefaultAndWarnings(t *testing.T) {
+	mock := NewMockValidator().
+		ReturnErrors(&mockUser{}, sceneCreate,
+			NewError("mockUser.Email", "deprecated", errors.WithSeverity(core.SeverityWarning))).
+		ReturnDefault(NewResult(NewError("Struct", "required")))
+
+	// 只有警告时 Validate 返回 nil，Check 保留警告
+	if result := mock.Validate(&mockUser{}, sceneCreate); result != nil {
+		t.Fatalf("Validate() = %v, want nil", result)
+	}
+	AssertHasWarning(t, mock.Check(&mockUser{}, sceneCreate), "mockUser.Email", "deprecated")
+
+	AssertErrorCount(t, mock.Validate(&mockOrder{}, sceneCreate), 1)
+
+	mock.Reset()
+	AssertNoError(t, mock.Validate(&mockOrder{}, sceneCreate))
+	if got := mock.CallCount(); got != 1 {
+		t.Errorf("CallCount() after Reset = %d, want 1", got)
+	}
+}
+
+// ============================================================================
+// 断言测试
+// ============================================================================
+
+// recorder 记录断言失败
+type recorder struct {
+	testing.TB
+	failed []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failed = append(r.failed, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions_ReportFailures(t *testing.T) {
+	result := NewResult(NewError("User.email", "email"), NewError("User.age", "gte"))
+
+	r := &recorder{TB: t}
+	AssertHasError(r, result, "User.email", "required")
+	AssertNoFieldError(r, result, "User.age")
+	AssertNoError(r, result)
+	AssertErrorCount(r, result, 1)
+	AssertHasError(r, fmt.Errorf("wrapped: %w", result), "User.email", "email")
+	AssertNoFieldError(r, nil, "User.email")
+
+	if len(r.failed) != 4 {
+		t.Fatalf("failures = %d, want 4: %v", len(r.failed), r.failed)
+	}
+	if want := "expected validation error User.email[required], got [User.email[email], User.age[gte]]"; r.failed[0] != want {
+		t.Errorf("failure message = %q, want %q", r.failed[0], want)
+	}
+}
+
+// ============================================================================
+// 黄金文件测试
+// ============================================================================
+
+func TestMarshalGolden_StableOrder(t *testing.T) {
+	a := NewResult(NewError("User.name", "min", errors.WithParam("3")), NewError("User.email", "email"))
+	b := NewResult(NewError("User.email", "email"), NewError("User.name", "min", errors.WithParam("3")))
+
+	ga, err := MarshalGolden(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gb, _ := MarshalGolden(b)
+	if string(ga) != string(gb) {
+		t.Errorf("MarshalGolden() depends on error order:\n%s\n%s", ga, gb)
+	}
+
+	AssertGolden(t, "user_create", a)
+	AssertGolden(t, "valid", nil)
+}